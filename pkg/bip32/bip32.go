@@ -0,0 +1,251 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// Package bip32 implements the public (non-hardened) child key derivation of
+// [BIP-32](https://github.com/bitcoin/bips/blob/master/bip-0032.mediawiki) for threshold keys.
+//
+// Threshold protocols never hold the parent private key in one place, so only public derivation is possible: given the
+// group public key K and a chain code c agreed at DKG time, the child key is
+//
+//	I = HMAC-SHA512(c, ser_P(K) || ser_32(i)),  K_i = parse_256(I_L)·G + K,  c_i = I_R
+//
+// The additive tweak parse_256(I_L) is public. Every party applies it to its key share locally, which yields a signing
+// view for the child key without any extra interaction.
+//
+// On secp256k1 and P-256 the derivation follows BIP-32 exactly, including the rejection of I_L >= n. Curves whose order
+// is shorter than 256 bits (such as ed25519) would reject most indices under that rule, so on those curves I_L is
+// reduced modulo the group order instead. The tweak is public, so the resulting bias has no security impact.
+package bip32
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcutil/base58"
+	"golang.org/x/crypto/ripemd160" //nolint:staticcheck // BIP-32 fingerprints are defined with RIPEMD-160
+
+	"github.com/TEENet-io/kryptology/internal"
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
+)
+
+const (
+	// HardenedKeyStart is the index of the first hardened child key. Hardened keys cannot be derived publicly.
+	HardenedKeyStart = uint32(0x80000000)
+
+	// ChainCodeSize is the length in bytes of a chain code.
+	ChainCodeSize = 32
+
+	// serializedKeyLength is the length of a decoded xpub: version, depth, fingerprint, index, chain code, key.
+	serializedKeyLength = 4 + 1 + 4 + 4 + ChainCodeSize + 33
+)
+
+// XpubVersion is the version prefix of mainnet extended public keys ("xpub").
+var XpubVersion = [4]byte{0x04, 0x88, 0xb2, 0x1e}
+
+var (
+	// ErrHardenedDerivation is returned when a hardened index is requested from a public key.
+	ErrHardenedDerivation = fmt.Errorf("cannot derive a hardened child from a public key")
+
+	// ErrInvalidChild is returned when an index produces an invalid child; BIP-32 requires moving on to the next index.
+	ErrInvalidChild = fmt.Errorf("the child key at this index is invalid")
+
+	// ErrDepthExceeded is returned when deriving below the maximum depth of 255.
+	ErrDepthExceeded = fmt.Errorf("cannot derive beyond depth 255")
+)
+
+// ExtendedKey is an extended public key: a curve point together with its chain code and position in the tree.
+type ExtendedKey struct {
+	Curve             *curves.Curve
+	PublicKey         curves.Point
+	ChainCode         [ChainCodeSize]byte
+	Depth             uint8
+	ParentFingerprint [4]byte
+	ChildNumber       uint32
+}
+
+// NewMasterKey returns the root extended public key for a group public key and the chain code agreed at DKG time.
+func NewMasterKey(curve *curves.Curve, publicKey curves.Point, chainCode [ChainCodeSize]byte) (*ExtendedKey, error) {
+	if curve == nil || publicKey == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if publicKey.IsIdentity() || !publicKey.IsOnCurve() {
+		return nil, internal.ErrNotOnCurve
+	}
+	return &ExtendedKey{
+		Curve:     curve,
+		PublicKey: publicKey,
+		ChainCode: chainCode,
+	}, nil
+}
+
+// Child derives the non-hardened child key at `index`. It returns the child together with the additive tweak t such
+// that child.PublicKey = k.PublicKey + t·G.
+func (k *ExtendedKey) Child(index uint32) (*ExtendedKey, curves.Scalar, error) {
+	if k == nil || k.Curve == nil || k.PublicKey == nil {
+		return nil, nil, internal.ErrNilArguments
+	}
+	if index >= HardenedKeyStart {
+		return nil, nil, ErrHardenedDerivation
+	}
+	if k.Depth == 255 {
+		return nil, nil, ErrDepthExceeded
+	}
+
+	mac := hmac.New(sha512.New, k.ChainCode[:])
+	_, _ = mac.Write(k.PublicKey.ToAffineCompressed())
+	var i [4]byte
+	binary.BigEndian.PutUint32(i[:], index)
+	_, _ = mac.Write(i[:])
+	sum := mac.Sum(nil)
+
+	tweak, err := parseTweak(k.Curve, sum[:32])
+	if err != nil {
+		return nil, nil, err
+	}
+	childKey := k.PublicKey.Add(k.Curve.ScalarBaseMult(tweak))
+	if childKey.IsIdentity() {
+		return nil, nil, ErrInvalidChild
+	}
+
+	child := &ExtendedKey{
+		Curve:             k.Curve,
+		PublicKey:         childKey,
+		Depth:             k.Depth + 1,
+		ParentFingerprint: k.Fingerprint(),
+		ChildNumber:       index,
+	}
+	copy(child.ChainCode[:], sum[32:])
+	return child, tweak, nil
+}
+
+// DerivePath derives the key at `path` relative to k. The returned tweak is the sum of the tweaks of every step, so
+// that child.PublicKey = k.PublicKey + tweak·G.
+func (k *ExtendedKey) DerivePath(path []uint32) (*ExtendedKey, curves.Scalar, error) {
+	if k == nil || k.Curve == nil {
+		return nil, nil, internal.ErrNilArguments
+	}
+	current := k
+	tweak := k.Curve.Scalar.Zero()
+	for _, index := range path {
+		child, t, err := current.Child(index)
+		if err != nil {
+			return nil, nil, err
+		}
+		tweak = tweak.Add(t)
+		current = child
+	}
+	return current, tweak, nil
+}
+
+// Fingerprint returns the first 4 bytes of HASH160 of the compressed public key.
+func (k *ExtendedKey) Fingerprint() [4]byte {
+	sha := sha256.Sum256(k.PublicKey.ToAffineCompressed())
+	h := ripemd160.New()
+	_, _ = h.Write(sha[:])
+	var fingerprint [4]byte
+	copy(fingerprint[:], h.Sum(nil))
+	return fingerprint
+}
+
+// String returns the base58check "xpub" encoding of the key. Only secp256k1 keys have a standard encoding.
+func (k *ExtendedKey) String() (string, error) {
+	if k == nil || k.Curve == nil || k.PublicKey == nil {
+		return "", internal.ErrNilArguments
+	}
+	if k.Curve.Name != curves.K256Name {
+		return "", fmt.Errorf("xpub encoding is only defined for %s", curves.K256Name)
+	}
+	payload := make([]byte, 0, serializedKeyLength+4)
+	payload = append(payload, XpubVersion[:]...)
+	payload = append(payload, k.Depth)
+	payload = append(payload, k.ParentFingerprint[:]...)
+	payload = binary.BigEndian.AppendUint32(payload, k.ChildNumber)
+	payload = append(payload, k.ChainCode[:]...)
+	payload = append(payload, k.PublicKey.ToAffineCompressed()...)
+	checksum := doubleSha256(payload)
+	payload = append(payload, checksum[:4]...)
+	return base58.Encode(payload), nil
+}
+
+// ParseXpub decodes a base58check "xpub" string into a secp256k1 extended public key.
+func ParseXpub(xpub string) (*ExtendedKey, error) {
+	decoded := base58.Decode(xpub)
+	if len(decoded) != serializedKeyLength+4 {
+		return nil, fmt.Errorf("invalid xpub length")
+	}
+	payload, checksum := decoded[:serializedKeyLength], decoded[serializedKeyLength:]
+	expected := doubleSha256(payload)
+	if !hmac.Equal(checksum, expected[:4]) {
+		return nil, fmt.Errorf("invalid xpub checksum")
+	}
+	if !hmac.Equal(payload[:4], XpubVersion[:]) {
+		return nil, fmt.Errorf("unsupported xpub version")
+	}
+	curve := curves.K256()
+	publicKey, err := curve.Point.FromAffineCompressed(payload[45:])
+	if err != nil {
+		return nil, err
+	}
+	k := &ExtendedKey{
+		Curve:       curve,
+		PublicKey:   publicKey,
+		Depth:       payload[4],
+		ChildNumber: binary.BigEndian.Uint32(payload[9:13]),
+	}
+	copy(k.ParentFingerprint[:], payload[5:9])
+	copy(k.ChainCode[:], payload[13:45])
+	if k.Depth == 0 && (k.ChildNumber != 0 || k.ParentFingerprint != [4]byte{}) {
+		return nil, fmt.Errorf("invalid master xpub")
+	}
+	return k, nil
+}
+
+// ParsePath parses a derivation path such as "m/0/1/2" into child indices. Hardened components ("0'" or "0h") are
+// parsed but will be rejected by DerivePath.
+func ParsePath(path string) ([]uint32, error) {
+	parts := strings.Split(path, "/")
+	if len(parts) == 0 || parts[0] != "m" {
+		return nil, fmt.Errorf("path must start with m")
+	}
+	indices := make([]uint32, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		hardened := strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h") || strings.HasSuffix(part, "H")
+		if hardened {
+			part = part[:len(part)-1]
+		}
+		index, err := strconv.ParseUint(part, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path component %q", part)
+		}
+		if hardened {
+			index += uint64(HardenedKeyStart)
+		}
+		indices = append(indices, uint32(index))
+	}
+	return indices, nil
+}
+
+// parseTweak interprets I_L as a big-endian integer. On 256-bit groups values >= n are invalid as in BIP-32; on
+// smaller groups the value is reduced modulo n.
+func parseTweak(curve *curves.Curve, il []byte) (curves.Scalar, error) {
+	v := new(big.Int).SetBytes(il)
+	order := new(big.Int).Add(curve.Scalar.One().Neg().BigInt(), big.NewInt(1))
+	if order.BitLen() >= 256 && v.Cmp(order) >= 0 {
+		return nil, ErrInvalidChild
+	}
+	return curve.Scalar.SetBigInt(new(big.Int).Mod(v, order))
+}
+
+func doubleSha256(data []byte) [32]byte {
+	first := sha256.Sum256(data)
+	return sha256.Sum256(first[:])
+}
@@ -0,0 +1,115 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package bip32
+
+import (
+	crand "crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
+)
+
+// Public derivation steps of BIP-32 test vector 1.
+func TestChildMatchesBip32TestVector1(t *testing.T) {
+	tests := []struct {
+		parent, index, child string
+	}{
+		{
+			"xpub68Gmy5EdvgibQVfPdqkBBCHxA5htiqg55crXYuXoQRKfDBFA1WEjWgP6LHhwBZeNK1VTsfTFUHCdrfp1bgwQ9xv5ski8PX9rL2dZXvgGDnw",
+			"m/1",
+			"xpub6ASuArnXKPbfEwhqN6e3mwBcDTgzisQN1wXN9BJcM47sSikHjJf3UFHKkNAWbWMiGj7Wf5uMash7SyYq527Hqck2AxYysAA7xmALppuCkwQ",
+		},
+		{
+			"xpub6D4BDPcP2GT577Vvch3R8wDkScZWzQzMMUm3PWbmWvVJrZwQY4VUNgqFJPMM3No2dFDFGTsxxpG5uJh7n7epu4trkrX7x7DogT5Uv6fcLW5",
+			"m/2",
+			"xpub6FHa3pjLCk84BayeJxFW2SP4XRrFd1JYnxeLeU8EqN3vDfZmbqBqaGJAyiLjTAwm6ZLRQUMv1ZACTj37sR62cfN7fe5JnJ7dh8zL4fiyLHV",
+		},
+		{
+			"xpub6FHa3pjLCk84BayeJxFW2SP4XRrFd1JYnxeLeU8EqN3vDfZmbqBqaGJAyiLjTAwm6ZLRQUMv1ZACTj37sR62cfN7fe5JnJ7dh8zL4fiyLHV",
+			"m/1000000000",
+			"xpub6H1LXWLaKsWFhvm6RVpEL9P4KfRZSW7abD2ttkWP3SSQvnyA8FSVqNTEcYFgJS2UaFcxupHiYkro49S8yGasTvXEYBVPamhGW6cFJodrTHy",
+		},
+	}
+	for _, test := range tests {
+		parent, err := ParseXpub(test.parent)
+		require.NoError(t, err)
+		encoded, err := parent.String()
+		require.NoError(t, err)
+		require.Equal(t, test.parent, encoded)
+
+		path, err := ParsePath(test.index)
+		require.NoError(t, err)
+		child, tweak, err := parent.DerivePath(path)
+		require.NoError(t, err)
+		encoded, err = child.String()
+		require.NoError(t, err)
+		require.Equal(t, test.child, encoded)
+		require.True(t, child.PublicKey.Equal(parent.PublicKey.Add(curves.K256().ScalarBaseMult(tweak))))
+	}
+}
+
+func TestDerivePathTweakIsSumOfSteps(t *testing.T) {
+	for _, curve := range []*curves.Curve{curves.K256(), curves.P256(), curves.ED25519()} {
+		sk := curve.Scalar.Random(crand.Reader)
+		var chainCode [ChainCodeSize]byte
+		_, err := crand.Read(chainCode[:])
+		require.NoError(t, err)
+		master, err := NewMasterKey(curve, curve.ScalarBaseMult(sk), chainCode)
+		require.NoError(t, err)
+
+		child, tweak, err := master.DerivePath([]uint32{7, 0, 42})
+		require.NoError(t, err)
+		require.Equal(t, uint8(3), child.Depth)
+		require.Equal(t, uint32(42), child.ChildNumber)
+		require.True(t, curve.ScalarBaseMult(sk.Add(tweak)).Equal(child.PublicKey), curve.Name)
+
+		step, _, err := master.Child(7)
+		require.NoError(t, err)
+		require.Equal(t, master.Fingerprint(), step.ParentFingerprint)
+		rest, _, err := step.DerivePath([]uint32{0, 42})
+		require.NoError(t, err)
+		require.True(t, rest.PublicKey.Equal(child.PublicKey))
+		require.Equal(t, rest.ChainCode, child.ChainCode)
+	}
+}
+
+func TestHardenedDerivationFails(t *testing.T) {
+	curve := curves.K256()
+	master, err := NewMasterKey(curve, curve.Point.Generator(), [ChainCodeSize]byte{})
+	require.NoError(t, err)
+	_, _, err = master.Child(HardenedKeyStart)
+	require.ErrorIs(t, err, ErrHardenedDerivation)
+
+	path, err := ParsePath("m/44'/0h/1")
+	require.NoError(t, err)
+	require.Equal(t, []uint32{HardenedKeyStart + 44, HardenedKeyStart, 1}, path)
+	_, _, err = master.DerivePath(path)
+	require.ErrorIs(t, err, ErrHardenedDerivation)
+}
+
+func TestParsePathInvalid(t *testing.T) {
+	for _, path := range []string{"", "0/1", "m/x", "m/-1", "m/2147483648", "m//1"} {
+		_, err := ParsePath(path)
+		require.Error(t, err, path)
+	}
+	path, err := ParsePath("m")
+	require.NoError(t, err)
+	require.Empty(t, path)
+}
+
+func TestXpubOnlyForK256(t *testing.T) {
+	curve := curves.P256()
+	master, err := NewMasterKey(curve, curve.Point.Generator(), [ChainCodeSize]byte{})
+	require.NoError(t, err)
+	_, err = master.String()
+	require.Error(t, err)
+
+	_, err = ParseXpub("xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet9")
+	require.Error(t, err)
+}
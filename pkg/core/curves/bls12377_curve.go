@@ -17,9 +17,12 @@ import (
 	"math/big"
 
 	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	bls12377fp "github.com/consensys/gnark-crypto/ecc/bls12-377/fp"
 	"golang.org/x/crypto/sha3"
 
+	"github.com/TEENet-io/kryptology/internal"
 	"github.com/TEENet-io/kryptology/pkg/core"
+	"github.com/TEENet-io/kryptology/pkg/core/curves/native"
 )
 
 // See 'r' = https://eprint.iacr.org/2018/962.pdf Figure 16
@@ -266,6 +269,34 @@ func (s *ScalarBls12377) SetBytesWide(bytes []byte) (Scalar, error) {
 	}, nil
 }
 
+func (s *ScalarBls12377) BytesBE() []byte {
+	return s.Bytes()
+}
+
+func (s *ScalarBls12377) BytesLE() []byte {
+	return internal.ReverseScalarBytes(s.Bytes())
+}
+
+func (s *ScalarBls12377) SetBytesBE(bytes []byte) (Scalar, error) {
+	// SetBytes accepts any length, but the fixed-width forms do not
+	if len(bytes) != 32 {
+		return nil, fmt.Errorf("invalid length")
+	}
+	return s.SetBytes(bytes)
+}
+
+func (s *ScalarBls12377) SetBytesLE(bytes []byte) (Scalar, error) {
+	// SetBytes accepts any length, but the fixed-width forms do not
+	if len(bytes) != 32 {
+		return nil, fmt.Errorf("invalid length")
+	}
+	return s.SetBytes(internal.ReverseScalarBytes(bytes))
+}
+
+func (s *ScalarBls12377) SetBytesReduce(bytes []byte) (Scalar, error) {
+	return scalarSetBytesReduce(s, bytes)
+}
+
 func (s *ScalarBls12377) Point() Point {
 	return s.point.Identity()
 }
@@ -356,6 +387,22 @@ func (p *PointBls12377G1) Hash(bytes []byte) Point {
 	return &PointBls12377G1{value: &pt}
 }
 
+func (p *PointBls12377G1) HashWithDst(bytes, dst []byte, hasher *native.EllipticPointHasher) (Point, error) {
+	u, err := hashToField(hasher, bytes, dst, 2, bls12377HashFieldBytes)
+	if err != nil {
+		return nil, err
+	}
+	return &PointBls12377G1{value: bls12377MapToG1(u)}, nil
+}
+
+func (p *PointBls12377G1) EncodeWithDst(bytes, dst []byte, hasher *native.EllipticPointHasher) (Point, error) {
+	u, err := hashToField(hasher, bytes, dst, 1, bls12377HashFieldBytes)
+	if err != nil {
+		return nil, err
+	}
+	return &PointBls12377G1{value: bls12377MapToG1(u)}, nil
+}
+
 func (p *PointBls12377G1) Identity() Point {
 	t := bls12377.G1Affine{}
 	return &PointBls12377G1{
@@ -493,6 +540,19 @@ func (p *PointBls12377G1) FromAffineCompressed(bytes []byte) (Point, error) {
 	return &PointBls12377G1{value}, nil
 }
 
+func (p *PointBls12377G1) IsTorsionFree() bool {
+	return p.value.IsInSubGroup()
+}
+
+func (p *PointBls12377G1) ClearCofactor() Point {
+	value := &bls12377.G1Affine{}
+	return &PointBls12377G1{value.ClearCofactor(p.value)}
+}
+
+func (p *PointBls12377G1) FromAffineCompressedStrict(bytes []byte) (Point, error) {
+	return fromAffineCompressedStrict(p, bytes)
+}
+
 func (p *PointBls12377G1) FromAffineUncompressed(bytes []byte) (Point, error) {
 	if len(bytes) != bls12377.SizeOfG1AffineUncompressed {
 		return nil, fmt.Errorf("invalid point")
@@ -630,6 +690,22 @@ func (p *PointBls12377G2) Hash(bytes []byte) Point {
 	return &PointBls12377G2{value: &pt}
 }
 
+func (p *PointBls12377G2) HashWithDst(bytes, dst []byte, hasher *native.EllipticPointHasher) (Point, error) {
+	u, err := hashToField(hasher, bytes, dst, 4, bls12377HashFieldBytes)
+	if err != nil {
+		return nil, err
+	}
+	return &PointBls12377G2{value: bls12377MapToG2(u)}, nil
+}
+
+func (p *PointBls12377G2) EncodeWithDst(bytes, dst []byte, hasher *native.EllipticPointHasher) (Point, error) {
+	u, err := hashToField(hasher, bytes, dst, 2, bls12377HashFieldBytes)
+	if err != nil {
+		return nil, err
+	}
+	return &PointBls12377G2{value: bls12377MapToG2(u)}, nil
+}
+
 func (p *PointBls12377G2) Identity() Point {
 	t := bls12377.G2Affine{}
 	return &PointBls12377G2{
@@ -766,6 +842,19 @@ func (p *PointBls12377G2) FromAffineCompressed(bytes []byte) (Point, error) {
 	return &PointBls12377G2{value}, nil
 }
 
+func (p *PointBls12377G2) IsTorsionFree() bool {
+	return p.value.IsInSubGroup()
+}
+
+func (p *PointBls12377G2) ClearCofactor() Point {
+	value := &bls12377.G2Affine{}
+	return &PointBls12377G2{value.ClearCofactor(p.value)}
+}
+
+func (p *PointBls12377G2) FromAffineCompressedStrict(bytes []byte) (Point, error) {
+	return fromAffineCompressedStrict(p, bytes)
+}
+
 func (p *PointBls12377G2) FromAffineUncompressed(bytes []byte) (Point, error) {
 	if len(bytes) != bls12377.SizeOfG2AffineUncompressed {
 		return nil, fmt.Errorf("invalid point")
@@ -1216,9 +1305,65 @@ func (s *ScalarBls12377Gt) SetBytesWide(bytes []byte) (Scalar, error) {
 	return &ScalarBls12377Gt{value}, nil
 }
 
+func (s *ScalarBls12377Gt) BytesBE() []byte {
+	return s.Bytes()
+}
+
+func (s *ScalarBls12377Gt) BytesLE() []byte {
+	return internal.ReverseScalarBytes(s.Bytes())
+}
+
+func (s *ScalarBls12377Gt) SetBytesBE(bytes []byte) (Scalar, error) {
+	return s.SetBytes(bytes)
+}
+
+func (s *ScalarBls12377Gt) SetBytesLE(bytes []byte) (Scalar, error) {
+	return s.SetBytes(internal.ReverseScalarBytes(bytes))
+}
+
+func (s *ScalarBls12377Gt) SetBytesReduce(bytes []byte) (Scalar, error) {
+	// Gt is not a prime field, so there is no modulus to reduce by
+	return nil, fmt.Errorf("reduction is not defined in Gt")
+}
+
 func (s *ScalarBls12377Gt) Clone() Scalar {
 	value := &bls12377.GT{}
 	return &ScalarBls12377Gt{
 		value.Set(s.value),
 	}
 }
+
+// bls12377HashFieldBytes is L = ceil((ceil(log2(p)) + k) / 8) of RFC 9380 for k = 128
+const bls12377HashFieldBytes = 64
+
+// bls12377MapToG1 maps the outputs of hash_to_field to G1. One element gives the
+// encode_to_curve encoding of RFC 9380 and two give hash_to_curve: clearing the
+// cofactor of each mapped point before adding them equals clearing it once after
+func bls12377MapToG1(u [][]byte) *bls12377.G1Affine {
+	var sum, q bls12377.G1Jac
+	sum.FromAffine(&g1Inf)
+	for _, b := range u {
+		var e bls12377fp.Element
+		pt := bls12377.MapToG1(*e.SetBytes(b))
+		q.FromAffine(&pt)
+		sum.AddAssign(&q)
+	}
+	out := new(bls12377.G1Affine).FromJacobian(&sum)
+	return out
+}
+
+// bls12377MapToG2 is bls12377MapToG1 for G2, whose field elements are pairs (c0, c1)
+func bls12377MapToG2(u [][]byte) *bls12377.G2Affine {
+	var sum, q bls12377.G2Jac
+	sum.FromAffine(&g2Inf)
+	for i := 0; i+1 < len(u); i += 2 {
+		var e bls12377.E2
+		e.A0.SetBytes(u[i])
+		e.A1.SetBytes(u[i+1])
+		pt := bls12377.MapToG2(e)
+		q.FromAffine(&pt)
+		sum.AddAssign(&q)
+	}
+	out := new(bls12377.G2Affine).FromJacobian(&sum)
+	return out
+}
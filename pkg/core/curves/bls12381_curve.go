@@ -266,6 +266,26 @@ func (s *ScalarBls12381) SetBytesWide(bytes []byte) (Scalar, error) {
 	}, nil
 }
 
+func (s *ScalarBls12381) BytesBE() []byte {
+	return s.Bytes()
+}
+
+func (s *ScalarBls12381) BytesLE() []byte {
+	return internal.ReverseScalarBytes(s.Bytes())
+}
+
+func (s *ScalarBls12381) SetBytesBE(bytes []byte) (Scalar, error) {
+	return s.SetBytes(bytes)
+}
+
+func (s *ScalarBls12381) SetBytesLE(bytes []byte) (Scalar, error) {
+	return s.SetBytes(internal.ReverseScalarBytes(bytes))
+}
+
+func (s *ScalarBls12381) SetBytesReduce(bytes []byte) (Scalar, error) {
+	return scalarSetBytesReduce(s, bytes)
+}
+
 func (s *ScalarBls12381) Point() Point {
 	return s.point.Identity()
 }
@@ -353,6 +373,20 @@ func (p *PointBls12381G1) Hash(bytes []byte) Point {
 	return &PointBls12381G1{Value: pt}
 }
 
+func (p *PointBls12381G1) HashWithDst(bytes, dst []byte, hasher *native.EllipticPointHasher) (Point, error) {
+	if err := checkHashToCurveArgs(hasher, dst); err != nil {
+		return nil, err
+	}
+	return &PointBls12381G1{Value: new(bls12381.G1).Hash(hasher, bytes, dst)}, nil
+}
+
+func (p *PointBls12381G1) EncodeWithDst(bytes, dst []byte, hasher *native.EllipticPointHasher) (Point, error) {
+	if err := checkHashToCurveArgs(hasher, dst); err != nil {
+		return nil, err
+	}
+	return &PointBls12381G1{Value: new(bls12381.G1).Encode(hasher, bytes, dst)}, nil
+}
+
 func (p *PointBls12381G1) Identity() Point {
 	return &PointBls12381G1{
 		Value: new(bls12381.G1).Identity(),
@@ -467,6 +501,18 @@ func (p *PointBls12381G1) FromAffineCompressed(bytes []byte) (Point, error) {
 	return &PointBls12381G1{value}, nil
 }
 
+func (p *PointBls12381G1) IsTorsionFree() bool {
+	return p.Value.InCorrectSubgroup() == 1
+}
+
+func (p *PointBls12381G1) ClearCofactor() Point {
+	return &PointBls12381G1{new(bls12381.G1).ClearCofactor(p.Value)}
+}
+
+func (p *PointBls12381G1) FromAffineCompressedStrict(bytes []byte) (Point, error) {
+	return fromAffineCompressedStrict(p, bytes)
+}
+
 func (p *PointBls12381G1) FromAffineUncompressed(bytes []byte) (Point, error) {
 	var b [96]byte
 	copy(b[:], bytes)
@@ -601,6 +647,20 @@ func (p *PointBls12381G2) Hash(bytes []byte) Point {
 	return &PointBls12381G2{Value: pt}
 }
 
+func (p *PointBls12381G2) HashWithDst(bytes, dst []byte, hasher *native.EllipticPointHasher) (Point, error) {
+	if err := checkHashToCurveArgs(hasher, dst); err != nil {
+		return nil, err
+	}
+	return &PointBls12381G2{Value: new(bls12381.G2).Hash(hasher, bytes, dst)}, nil
+}
+
+func (p *PointBls12381G2) EncodeWithDst(bytes, dst []byte, hasher *native.EllipticPointHasher) (Point, error) {
+	if err := checkHashToCurveArgs(hasher, dst); err != nil {
+		return nil, err
+	}
+	return &PointBls12381G2{Value: new(bls12381.G2).Encode(hasher, bytes, dst)}, nil
+}
+
 func (p *PointBls12381G2) Identity() Point {
 	return &PointBls12381G2{
 		Value: new(bls12381.G2).Identity(),
@@ -715,6 +775,18 @@ func (p *PointBls12381G2) FromAffineCompressed(bytes []byte) (Point, error) {
 	return &PointBls12381G2{value}, nil
 }
 
+func (p *PointBls12381G2) IsTorsionFree() bool {
+	return p.Value.InCorrectSubgroup() == 1
+}
+
+func (p *PointBls12381G2) ClearCofactor() Point {
+	return &PointBls12381G2{new(bls12381.G2).ClearCofactor(p.Value)}
+}
+
+func (p *PointBls12381G2) FromAffineCompressedStrict(bytes []byte) (Point, error) {
+	return fromAffineCompressedStrict(p, bytes)
+}
+
 func (p *PointBls12381G2) FromAffineUncompressed(bytes []byte) (Point, error) {
 	var b [bls12381.DoubleWideFieldBytes]byte
 	copy(b[:], bytes)
@@ -1122,6 +1194,27 @@ func (s *ScalarBls12381Gt) SetBytesWide(bytes []byte) (Scalar, error) {
 	return &ScalarBls12381Gt{value}, nil
 }
 
+func (s *ScalarBls12381Gt) BytesBE() []byte {
+	return s.Bytes()
+}
+
+func (s *ScalarBls12381Gt) BytesLE() []byte {
+	return internal.ReverseScalarBytes(s.Bytes())
+}
+
+func (s *ScalarBls12381Gt) SetBytesBE(bytes []byte) (Scalar, error) {
+	return s.SetBytes(bytes)
+}
+
+func (s *ScalarBls12381Gt) SetBytesLE(bytes []byte) (Scalar, error) {
+	return s.SetBytes(internal.ReverseScalarBytes(bytes))
+}
+
+func (s *ScalarBls12381Gt) SetBytesReduce(bytes []byte) (Scalar, error) {
+	// Gt is not a prime field, so there is no modulus to reduce by
+	return nil, fmt.Errorf("reduction is not defined in Gt")
+}
+
 func (s *ScalarBls12381Gt) Clone() Scalar {
 	return &ScalarBls12381Gt{
 		Value: new(bls12381.Gt).Set(s.Value),
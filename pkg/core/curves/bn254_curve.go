@@ -0,0 +1,1371 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// NOTE that the BN254 curve is NOT constant time, like the bls curves.
+// Uncompressed points use the encoding of the Ethereum precompiles of EIP-196 and EIP-197:
+// big-endian coordinates with the imaginary part of G2 coordinates first, and zeroes for the identity.
+
+package curves
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"math/big"
+
+	bn254 "github.com/consensys/gnark-crypto/ecc/bn254"
+	bn254fp "github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/TEENet-io/kryptology/internal"
+	"github.com/TEENet-io/kryptology/pkg/core"
+	"github.com/TEENet-io/kryptology/pkg/core/curves/native"
+)
+
+// See 'r' = https://eips.ethereum.org/EIPS/eip-197
+var bn254modulus = bhex("30644e72e131a029b85045b68181585d2833e84879b9709143e1f593f0000001")
+var bn254G1Inf = bn254.G1Affine{}
+var bn254G2Inf = bn254.G2Affine{}
+
+type ScalarBn254 struct {
+	value *big.Int
+	point Point
+}
+
+type PointBn254G1 struct {
+	value *bn254.G1Affine
+}
+
+type PointBn254G2 struct {
+	value *bn254.G2Affine
+}
+
+type ScalarBn254Gt struct {
+	value *bn254.GT
+}
+
+func (s *ScalarBn254) Random(reader io.Reader) Scalar {
+	if reader == nil {
+		return nil
+	}
+	var seed [64]byte
+	_, _ = reader.Read(seed[:])
+	return s.Hash(seed[:])
+}
+
+func (s *ScalarBn254) Hash(bytes []byte) Scalar {
+	xmd, err := expandMsgXmd(sha256.New(), bytes, []byte("BN254_XMD:SHA-256_SVDW_RO_"), 48)
+	if err != nil {
+		return nil
+	}
+	v := new(big.Int).SetBytes(xmd)
+	return &ScalarBn254{
+		value: v.Mod(v, bn254modulus),
+		point: s.point,
+	}
+}
+
+func (s *ScalarBn254) Zero() Scalar {
+	return &ScalarBn254{
+		value: big.NewInt(0),
+		point: s.point,
+	}
+}
+
+func (s *ScalarBn254) One() Scalar {
+	return &ScalarBn254{
+		value: big.NewInt(1),
+		point: s.point,
+	}
+}
+
+func (s *ScalarBn254) IsZero() bool {
+	return subtle.ConstantTimeCompare(s.value.Bytes(), []byte{}) == 1
+}
+
+func (s *ScalarBn254) IsOne() bool {
+	return subtle.ConstantTimeCompare(s.value.Bytes(), []byte{1}) == 1
+}
+
+func (s *ScalarBn254) IsOdd() bool {
+	return s.value.Bit(0) == 1
+}
+
+func (s *ScalarBn254) IsEven() bool {
+	return s.value.Bit(0) == 0
+}
+
+func (s *ScalarBn254) New(value int) Scalar {
+	v := big.NewInt(int64(value))
+	if value < 0 {
+		v.Mod(v, bn254modulus)
+	}
+	return &ScalarBn254{
+		value: v,
+		point: s.point,
+	}
+}
+
+func (s *ScalarBn254) Cmp(rhs Scalar) int {
+	r, ok := rhs.(*ScalarBn254)
+	if ok {
+		return s.value.Cmp(r.value)
+	} else {
+		return -2
+	}
+}
+
+func (s *ScalarBn254) Square() Scalar {
+	return &ScalarBn254{
+		value: new(big.Int).Exp(s.value, big.NewInt(2), bn254modulus),
+		point: s.point,
+	}
+}
+
+func (s *ScalarBn254) Double() Scalar {
+	v := new(big.Int).Add(s.value, s.value)
+	return &ScalarBn254{
+		value: v.Mod(v, bn254modulus),
+		point: s.point,
+	}
+}
+
+func (s *ScalarBn254) Invert() (Scalar, error) {
+	return &ScalarBn254{
+		value: new(big.Int).ModInverse(s.value, bn254modulus),
+		point: s.point,
+	}, nil
+}
+
+func (s *ScalarBn254) Sqrt() (Scalar, error) {
+	return &ScalarBn254{
+		value: new(big.Int).ModSqrt(s.value, bn254modulus),
+		point: s.point,
+	}, nil
+}
+
+func (s *ScalarBn254) Cube() Scalar {
+	return &ScalarBn254{
+		value: new(big.Int).Exp(s.value, big.NewInt(3), bn254modulus),
+		point: s.point,
+	}
+}
+
+func (s *ScalarBn254) Add(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarBn254)
+	if ok {
+		v := new(big.Int).Add(s.value, r.value)
+		return &ScalarBn254{
+			value: v.Mod(v, bn254modulus),
+			point: s.point,
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarBn254) Sub(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarBn254)
+	if ok {
+		v := new(big.Int).Sub(s.value, r.value)
+		return &ScalarBn254{
+			value: v.Mod(v, bn254modulus),
+			point: s.point,
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarBn254) Mul(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarBn254)
+	if ok {
+		v := new(big.Int).Mul(s.value, r.value)
+		return &ScalarBn254{
+			value: v.Mod(v, bn254modulus),
+			point: s.point,
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarBn254) MulAdd(y, z Scalar) Scalar {
+	return s.Mul(y).Add(z)
+}
+
+func (s *ScalarBn254) Div(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarBn254)
+	if ok {
+		v := new(big.Int).ModInverse(r.value, bn254modulus)
+		v.Mul(v, s.value)
+		return &ScalarBn254{
+			value: v.Mod(v, bn254modulus),
+			point: s.point,
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarBn254) Neg() Scalar {
+	z := new(big.Int).Neg(s.value)
+	return &ScalarBn254{
+		value: z.Mod(z, bn254modulus),
+		point: s.point,
+	}
+}
+
+func (s *ScalarBn254) SetBigInt(v *big.Int) (Scalar, error) {
+	if v == nil {
+		return nil, fmt.Errorf("invalid value")
+	}
+	t := new(big.Int).Mod(v, bn254modulus)
+	if t.Cmp(v) != 0 {
+		return nil, fmt.Errorf("invalid value")
+	}
+	return &ScalarBn254{
+		value: t,
+		point: s.point,
+	}, nil
+}
+
+func (s *ScalarBn254) BigInt() *big.Int {
+	return new(big.Int).Set(s.value)
+}
+
+func (s *ScalarBn254) Bytes() []byte {
+	var out [32]byte
+	return s.value.FillBytes(out[:])
+}
+
+func (s *ScalarBn254) SetBytes(bytes []byte) (Scalar, error) {
+	value := new(big.Int).SetBytes(bytes)
+	t := new(big.Int).Mod(value, bn254modulus)
+	if t.Cmp(value) != 0 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	return &ScalarBn254{
+		value: t,
+		point: s.point,
+	}, nil
+}
+
+func (s *ScalarBn254) SetBytesWide(bytes []byte) (Scalar, error) {
+	if len(bytes) < 32 || len(bytes) > 128 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	value := new(big.Int).SetBytes(bytes)
+	t := new(big.Int).Mod(value, bn254modulus)
+	return &ScalarBn254{
+		value: t,
+		point: s.point,
+	}, nil
+}
+
+func (s *ScalarBn254) BytesBE() []byte {
+	return s.Bytes()
+}
+
+func (s *ScalarBn254) BytesLE() []byte {
+	return internal.ReverseScalarBytes(s.Bytes())
+}
+
+func (s *ScalarBn254) SetBytesBE(bytes []byte) (Scalar, error) {
+	// SetBytes accepts any length, but the fixed-width forms do not
+	if len(bytes) != 32 {
+		return nil, fmt.Errorf("invalid length")
+	}
+	return s.SetBytes(bytes)
+}
+
+func (s *ScalarBn254) SetBytesLE(bytes []byte) (Scalar, error) {
+	// SetBytes accepts any length, but the fixed-width forms do not
+	if len(bytes) != 32 {
+		return nil, fmt.Errorf("invalid length")
+	}
+	return s.SetBytes(internal.ReverseScalarBytes(bytes))
+}
+
+func (s *ScalarBn254) SetBytesReduce(bytes []byte) (Scalar, error) {
+	return scalarSetBytesReduce(s, bytes)
+}
+
+func (s *ScalarBn254) Point() Point {
+	return s.point.Identity()
+}
+
+func (s *ScalarBn254) Clone() Scalar {
+	return &ScalarBn254{
+		value: new(big.Int).Set(s.value),
+		point: s.point,
+	}
+}
+
+func (s *ScalarBn254) SetPoint(p Point) PairingScalar {
+	return &ScalarBn254{
+		value: new(big.Int).Set(s.value),
+		point: p,
+	}
+}
+
+func (s *ScalarBn254) Order() *big.Int {
+	return bn254modulus
+}
+
+func (s *ScalarBn254) MarshalBinary() ([]byte, error) {
+	return scalarMarshalBinary(s)
+}
+
+func (s *ScalarBn254) UnmarshalBinary(input []byte) error {
+	sc, err := scalarUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarBn254)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.value = ss.value
+	s.point = ss.point
+	return nil
+}
+
+func (s *ScalarBn254) MarshalText() ([]byte, error) {
+	return scalarMarshalText(s)
+}
+
+func (s *ScalarBn254) UnmarshalText(input []byte) error {
+	sc, err := scalarUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarBn254)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.value = ss.value
+	s.point = ss.point
+	return nil
+}
+
+func (s *ScalarBn254) MarshalJSON() ([]byte, error) {
+	return scalarMarshalJson(s)
+}
+
+func (s *ScalarBn254) UnmarshalJSON(input []byte) error {
+	sc, err := scalarUnmarshalJson(input)
+	if err != nil {
+		return err
+	}
+	S, ok := sc.(*ScalarBn254)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	s.value = S.value
+	return nil
+}
+
+func (p *PointBn254G1) Random(reader io.Reader) Point {
+	var seed [64]byte
+	_, _ = reader.Read(seed[:])
+	return p.Hash(seed[:])
+}
+
+func (p *PointBn254G1) Hash(bytes []byte) Point {
+	var domain = []byte("BN254G1_XMD:SHA-256_SVDW_RO_")
+	pt, err := bn254.HashToG1(bytes, domain)
+	if err != nil {
+		return nil
+	}
+	return &PointBn254G1{value: &pt}
+}
+
+func (p *PointBn254G1) HashWithDst(bytes, dst []byte, hasher *native.EllipticPointHasher) (Point, error) {
+	u, err := hashToField(hasher, bytes, dst, 2, bn254HashFieldBytes)
+	if err != nil {
+		return nil, err
+	}
+	return &PointBn254G1{value: bn254MapToG1(u)}, nil
+}
+
+func (p *PointBn254G1) EncodeWithDst(bytes, dst []byte, hasher *native.EllipticPointHasher) (Point, error) {
+	u, err := hashToField(hasher, bytes, dst, 1, bn254HashFieldBytes)
+	if err != nil {
+		return nil, err
+	}
+	return &PointBn254G1{value: bn254MapToG1(u)}, nil
+}
+
+func (p *PointBn254G1) Identity() Point {
+	t := bn254.G1Affine{}
+	return &PointBn254G1{
+		value: t.Set(&bn254G1Inf),
+	}
+}
+
+func (p *PointBn254G1) Generator() Point {
+	t := bn254.G1Affine{}
+	_, _, g1Aff, _ := bn254.Generators()
+	return &PointBn254G1{
+		value: t.Set(&g1Aff),
+	}
+}
+
+func (p *PointBn254G1) IsIdentity() bool {
+	return p.value.IsInfinity()
+}
+
+func (p *PointBn254G1) IsNegative() bool {
+	// The flags of the compressed encoding mark the lexicographically largest `y` coordinate
+	return bn254IsNegative(p.value.Bytes()[0])
+}
+
+func (p *PointBn254G1) IsOnCurve() bool {
+	return p.value.IsOnCurve()
+}
+
+func (p *PointBn254G1) Double() Point {
+	t := &bn254.G1Jac{}
+	t.FromAffine(p.value)
+	t.DoubleAssign()
+	value := bn254.G1Affine{}
+	return &PointBn254G1{value.FromJacobian(t)}
+}
+
+func (p *PointBn254G1) Scalar() Scalar {
+	return &ScalarBn254{
+		value: new(big.Int),
+		point: new(PointBn254G1),
+	}
+}
+
+func (p *PointBn254G1) Neg() Point {
+	value := &bn254.G1Affine{}
+	value.Neg(p.value)
+	return &PointBn254G1{value}
+}
+
+func (p *PointBn254G1) Add(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointBn254G1)
+	if ok {
+		value := &bn254.G1Affine{}
+		return &PointBn254G1{value.Add(p.value, r.value)}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointBn254G1) Sub(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointBn254G1)
+	if ok {
+		value := &bn254.G1Affine{}
+		return &PointBn254G1{value.Sub(p.value, r.value)}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointBn254G1) Mul(rhs Scalar) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*ScalarBn254)
+	if ok {
+		value := &bn254.G1Affine{}
+		return &PointBn254G1{value.ScalarMultiplication(p.value, r.value)}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointBn254G1) Equal(rhs Point) bool {
+	r, ok := rhs.(*PointBn254G1)
+	if ok {
+		return p.value.Equal(r.value)
+	} else {
+		return false
+	}
+}
+
+func (p *PointBn254G1) Set(x, y *big.Int) (Point, error) {
+	if x.Cmp(core.Zero) == 0 &&
+		y.Cmp(core.Zero) == 0 {
+		return p.Identity(), nil
+	}
+	var data [bn254.SizeOfG1AffineUncompressed]byte
+	x.FillBytes(data[:32])
+	y.FillBytes(data[32:])
+	value := &bn254.G1Affine{}
+	_, err := value.SetBytes(data[:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid coordinates")
+	}
+	return &PointBn254G1{value}, nil
+}
+
+func (p *PointBn254G1) ToAffineCompressed() []byte {
+	v := p.value.Bytes()
+	return v[:]
+}
+
+func (p *PointBn254G1) ToAffineUncompressed() []byte {
+	v := p.value.RawBytes()
+	return v[:]
+}
+
+func (p *PointBn254G1) FromAffineCompressed(bytes []byte) (Point, error) {
+	if len(bytes) != bn254.SizeOfG1AffineCompressed {
+		return nil, fmt.Errorf("invalid point")
+	}
+	value := &bn254.G1Affine{}
+	_, err := value.SetBytes(bytes)
+	if err != nil {
+		return nil, err
+	}
+	return &PointBn254G1{value}, nil
+}
+
+func (p *PointBn254G1) IsTorsionFree() bool {
+	return p.value.IsInSubGroup()
+}
+
+func (p *PointBn254G1) ClearCofactor() Point {
+	// The cofactor is one
+	return p
+}
+
+func (p *PointBn254G1) FromAffineCompressedStrict(bytes []byte) (Point, error) {
+	return fromAffineCompressedStrict(p, bytes)
+}
+
+func (p *PointBn254G1) FromAffineUncompressed(bytes []byte) (Point, error) {
+	if len(bytes) != bn254.SizeOfG1AffineUncompressed {
+		return nil, fmt.Errorf("invalid point")
+	}
+	value := &bn254.G1Affine{}
+	_, err := value.SetBytes(bytes)
+	if err != nil {
+		return nil, err
+	}
+	return &PointBn254G1{value}, nil
+}
+
+func (p *PointBn254G1) CurveName() string {
+	return "BN254G1"
+}
+
+func (p *PointBn254G1) SumOfProducts(points []Point, scalars []Scalar) Point {
+	nScalars := make([]*big.Int, len(scalars))
+	for i, sc := range scalars {
+		s, ok := sc.(*ScalarBn254)
+		if !ok {
+			return nil
+		}
+		nScalars[i] = s.value
+	}
+	return sumOfProductsPippenger(points, nScalars)
+}
+
+func (p *PointBn254G1) OtherGroup() PairingPoint {
+	return new(PointBn254G2).Identity().(PairingPoint)
+}
+
+func (p *PointBn254G1) Pairing(rhs PairingPoint) Scalar {
+	pt, ok := rhs.(*PointBn254G2)
+	if !ok {
+		return nil
+	}
+	if !p.value.IsInSubGroup() ||
+		!pt.value.IsInSubGroup() {
+		return nil
+	}
+	value := bn254.GT{}
+	if p.value.IsInfinity() || pt.value.IsInfinity() {
+		return &ScalarBn254Gt{value.SetOne()}
+	}
+	value, err := bn254.Pair([]bn254.G1Affine{*p.value}, []bn254.G2Affine{*pt.value})
+	if err != nil {
+		return nil
+	}
+
+	return &ScalarBn254Gt{&value}
+}
+
+func (p *PointBn254G1) MultiPairing(points ...PairingPoint) Scalar {
+	return multiPairingBn254(points...)
+}
+
+func (p *PointBn254G1) X() *big.Int {
+	b := p.value.RawBytes()
+	return new(big.Int).SetBytes(b[:32])
+}
+
+func (p *PointBn254G1) Y() *big.Int {
+	b := p.value.RawBytes()
+	return new(big.Int).SetBytes(b[32:])
+}
+
+func (p *PointBn254G1) Modulus() *big.Int {
+	return bn254modulus
+}
+
+func (p *PointBn254G1) MarshalBinary() ([]byte, error) {
+	return pointMarshalBinary(p)
+}
+
+func (p *PointBn254G1) UnmarshalBinary(input []byte) error {
+	pt, err := pointUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointBn254G1)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.value = ppt.value
+	return nil
+}
+
+func (p *PointBn254G1) MarshalText() ([]byte, error) {
+	return pointMarshalText(p)
+}
+
+func (p *PointBn254G1) UnmarshalText(input []byte) error {
+	pt, err := pointUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointBn254G1)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.value = ppt.value
+	return nil
+}
+
+func (p *PointBn254G1) MarshalJSON() ([]byte, error) {
+	return pointMarshalJson(p)
+}
+
+func (p *PointBn254G1) UnmarshalJSON(input []byte) error {
+	pt, err := pointUnmarshalJson(input)
+	if err != nil {
+		return err
+	}
+	P, ok := pt.(*PointBn254G1)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	p.value = P.value
+	return nil
+}
+
+func (p *PointBn254G2) Random(reader io.Reader) Point {
+	var seed [64]byte
+	_, _ = reader.Read(seed[:])
+	return p.Hash(seed[:])
+}
+
+func (p *PointBn254G2) Hash(bytes []byte) Point {
+	var domain = []byte("BN254G2_XMD:SHA-256_SVDW_RO_")
+	pt, err := bn254.HashToG2(bytes, domain)
+	if err != nil {
+		return nil
+	}
+	return &PointBn254G2{value: &pt}
+}
+
+func (p *PointBn254G2) HashWithDst(bytes, dst []byte, hasher *native.EllipticPointHasher) (Point, error) {
+	u, err := hashToField(hasher, bytes, dst, 4, bn254HashFieldBytes)
+	if err != nil {
+		return nil, err
+	}
+	return &PointBn254G2{value: bn254MapToG2(u)}, nil
+}
+
+func (p *PointBn254G2) EncodeWithDst(bytes, dst []byte, hasher *native.EllipticPointHasher) (Point, error) {
+	u, err := hashToField(hasher, bytes, dst, 2, bn254HashFieldBytes)
+	if err != nil {
+		return nil, err
+	}
+	return &PointBn254G2{value: bn254MapToG2(u)}, nil
+}
+
+func (p *PointBn254G2) Identity() Point {
+	t := bn254.G2Affine{}
+	return &PointBn254G2{
+		value: t.Set(&bn254G2Inf),
+	}
+}
+
+func (p *PointBn254G2) Generator() Point {
+	t := bn254.G2Affine{}
+	_, _, _, g2Aff := bn254.Generators()
+	return &PointBn254G2{
+		value: t.Set(&g2Aff),
+	}
+}
+
+func (p *PointBn254G2) IsIdentity() bool {
+	return p.value.IsInfinity()
+}
+
+func (p *PointBn254G2) IsNegative() bool {
+	// The flags of the compressed encoding mark the lexicographically largest `y` coordinate
+	return bn254IsNegative(p.value.Bytes()[0])
+}
+
+func (p *PointBn254G2) IsOnCurve() bool {
+	return p.value.IsOnCurve()
+}
+
+func (p *PointBn254G2) Double() Point {
+	t := &bn254.G2Jac{}
+	t.FromAffine(p.value)
+	t.DoubleAssign()
+	value := bn254.G2Affine{}
+	return &PointBn254G2{value.FromJacobian(t)}
+}
+
+func (p *PointBn254G2) Scalar() Scalar {
+	return &ScalarBn254{
+		value: new(big.Int),
+		point: new(PointBn254G2),
+	}
+}
+
+func (p *PointBn254G2) Neg() Point {
+	value := &bn254.G2Affine{}
+	value.Neg(p.value)
+	return &PointBn254G2{value}
+}
+
+func (p *PointBn254G2) Add(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointBn254G2)
+	if ok {
+		value := &bn254.G2Affine{}
+		return &PointBn254G2{value.Add(p.value, r.value)}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointBn254G2) Sub(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointBn254G2)
+	if ok {
+		value := &bn254.G2Affine{}
+		return &PointBn254G2{value.Sub(p.value, r.value)}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointBn254G2) Mul(rhs Scalar) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*ScalarBn254)
+	if ok {
+		value := &bn254.G2Affine{}
+		return &PointBn254G2{value.ScalarMultiplication(p.value, r.value)}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointBn254G2) Equal(rhs Point) bool {
+	r, ok := rhs.(*PointBn254G2)
+	if ok {
+		return p.value.Equal(r.value)
+	} else {
+		return false
+	}
+}
+
+func (p *PointBn254G2) Set(x, y *big.Int) (Point, error) {
+	if x.Cmp(core.Zero) == 0 &&
+		y.Cmp(core.Zero) == 0 {
+		return p.Identity(), nil
+	}
+	var data [bn254.SizeOfG2AffineUncompressed]byte
+	x.FillBytes(data[:64])
+	y.FillBytes(data[64:])
+	value := &bn254.G2Affine{}
+	_, err := value.SetBytes(data[:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid coordinates")
+	}
+	return &PointBn254G2{value}, nil
+}
+
+func (p *PointBn254G2) ToAffineCompressed() []byte {
+	v := p.value.Bytes()
+	return v[:]
+}
+
+func (p *PointBn254G2) ToAffineUncompressed() []byte {
+	v := p.value.RawBytes()
+	return v[:]
+}
+
+func (p *PointBn254G2) FromAffineCompressed(bytes []byte) (Point, error) {
+	if len(bytes) != bn254.SizeOfG2AffineCompressed {
+		return nil, fmt.Errorf("invalid point")
+	}
+	value := &bn254.G2Affine{}
+	_, err := value.SetBytes(bytes)
+	if err != nil {
+		return nil, err
+	}
+	return &PointBn254G2{value}, nil
+}
+
+func (p *PointBn254G2) IsTorsionFree() bool {
+	return p.value.IsInSubGroup()
+}
+
+func (p *PointBn254G2) ClearCofactor() Point {
+	value := &bn254.G2Affine{}
+	return &PointBn254G2{value.ClearCofactor(p.value)}
+}
+
+func (p *PointBn254G2) FromAffineCompressedStrict(bytes []byte) (Point, error) {
+	return fromAffineCompressedStrict(p, bytes)
+}
+
+func (p *PointBn254G2) FromAffineUncompressed(bytes []byte) (Point, error) {
+	if len(bytes) != bn254.SizeOfG2AffineUncompressed {
+		return nil, fmt.Errorf("invalid point")
+	}
+	value := &bn254.G2Affine{}
+	_, err := value.SetBytes(bytes)
+	if err != nil {
+		return nil, err
+	}
+	return &PointBn254G2{value}, nil
+}
+
+func (p *PointBn254G2) CurveName() string {
+	return "BN254G2"
+}
+
+func (p *PointBn254G2) SumOfProducts(points []Point, scalars []Scalar) Point {
+	nScalars := make([]*big.Int, len(scalars))
+	for i, sc := range scalars {
+		s, ok := sc.(*ScalarBn254)
+		if !ok {
+			return nil
+		}
+		nScalars[i] = s.value
+	}
+	return sumOfProductsPippenger(points, nScalars)
+}
+
+func (p *PointBn254G2) OtherGroup() PairingPoint {
+	return new(PointBn254G1).Identity().(PairingPoint)
+}
+
+func (p *PointBn254G2) Pairing(rhs PairingPoint) Scalar {
+	pt, ok := rhs.(*PointBn254G1)
+	if !ok {
+		return nil
+	}
+	if !p.value.IsInSubGroup() ||
+		!pt.value.IsInSubGroup() {
+		return nil
+	}
+	value := bn254.GT{}
+	if p.value.IsInfinity() || pt.value.IsInfinity() {
+		return &ScalarBn254Gt{value.SetOne()}
+	}
+	value, err := bn254.Pair([]bn254.G1Affine{*pt.value}, []bn254.G2Affine{*p.value})
+	if err != nil {
+		return nil
+	}
+
+	return &ScalarBn254Gt{&value}
+}
+
+func (p *PointBn254G2) MultiPairing(points ...PairingPoint) Scalar {
+	return multiPairingBn254(points...)
+}
+
+func (p *PointBn254G2) X() *big.Int {
+	b := p.value.RawBytes()
+	return new(big.Int).SetBytes(b[:64])
+}
+
+func (p *PointBn254G2) Y() *big.Int {
+	b := p.value.RawBytes()
+	return new(big.Int).SetBytes(b[64:])
+}
+
+func (p *PointBn254G2) Modulus() *big.Int {
+	return bn254modulus
+}
+
+func (p *PointBn254G2) MarshalBinary() ([]byte, error) {
+	return pointMarshalBinary(p)
+}
+
+func (p *PointBn254G2) UnmarshalBinary(input []byte) error {
+	pt, err := pointUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointBn254G2)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.value = ppt.value
+	return nil
+}
+
+func (p *PointBn254G2) MarshalText() ([]byte, error) {
+	return pointMarshalText(p)
+}
+
+func (p *PointBn254G2) UnmarshalText(input []byte) error {
+	pt, err := pointUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointBn254G2)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.value = ppt.value
+	return nil
+}
+
+func (p *PointBn254G2) MarshalJSON() ([]byte, error) {
+	return pointMarshalJson(p)
+}
+
+func (p *PointBn254G2) UnmarshalJSON(input []byte) error {
+	pt, err := pointUnmarshalJson(input)
+	if err != nil {
+		return err
+	}
+	P, ok := pt.(*PointBn254G2)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	p.value = P.value
+	return nil
+}
+
+// bn254IsNegative returns true if the most significant byte of a compressed point
+// has the flags of the lexicographically largest root
+func bn254IsNegative(msb byte) bool {
+	return msb>>6 == 0b11
+}
+
+func multiPairingBn254(points ...PairingPoint) Scalar {
+	if len(points)%2 != 0 {
+		return nil
+	}
+	g1Arr := make([]bn254.G1Affine, 0, len(points)/2)
+	g2Arr := make([]bn254.G2Affine, 0, len(points)/2)
+	valid := true
+	for i := 0; i < len(points); i += 2 {
+		pt1, ok := points[i].(*PointBn254G1)
+		valid = valid && ok
+		pt2, ok := points[i+1].(*PointBn254G2)
+		valid = valid && ok
+		if valid {
+			valid = valid && pt1.value.IsInSubGroup()
+			valid = valid && pt2.value.IsInSubGroup()
+		}
+		if valid {
+			g1Arr = append(g1Arr, *pt1.value)
+			g2Arr = append(g2Arr, *pt2.value)
+		}
+	}
+	if !valid {
+		return nil
+	}
+
+	value, err := bn254.Pair(g1Arr, g2Arr)
+	if err != nil {
+		return nil
+	}
+
+	return &ScalarBn254Gt{&value}
+}
+
+func (s *ScalarBn254Gt) Random(reader io.Reader) Scalar {
+	const width = 32
+	offset := 0
+	var data [bn254.SizeOfGT]byte
+	for i := 0; i < 12; i++ {
+		tv, err := rand.Int(reader, bn254modulus)
+		if err != nil {
+			return nil
+		}
+		tv.FillBytes(data[offset*width : (offset+1)*width])
+		offset++
+	}
+	value := bn254.GT{}
+	err := value.SetBytes(data[:])
+	if err != nil {
+		return nil
+	}
+	return &ScalarBn254Gt{&value}
+}
+
+func (s *ScalarBn254Gt) Hash(bytes []byte) Scalar {
+	reader := sha3.NewShake256()
+	n, err := reader.Write(bytes)
+	if err != nil {
+		return nil
+	}
+	if n != len(bytes) {
+		return nil
+	}
+	return s.Random(reader)
+}
+
+func (s *ScalarBn254Gt) Zero() Scalar {
+	var t [bn254.SizeOfGT]byte
+	value := bn254.GT{}
+	err := value.SetBytes(t[:])
+	if err != nil {
+		return nil
+	}
+	return &ScalarBn254Gt{&value}
+}
+
+func (s *ScalarBn254Gt) One() Scalar {
+	value := bn254.GT{}
+	return &ScalarBn254Gt{value.SetOne()}
+}
+
+func (s *ScalarBn254Gt) IsZero() bool {
+	r := byte(0)
+	b := s.value.Bytes()
+	for _, i := range b {
+		r |= i
+	}
+	return r == 0
+}
+
+func (s *ScalarBn254Gt) IsOne() bool {
+	o := bn254.GT{}
+	return s.value.Equal(o.SetOne())
+}
+
+func (s *ScalarBn254Gt) MarshalBinary() ([]byte, error) {
+	return scalarMarshalBinary(s)
+}
+
+func (s *ScalarBn254Gt) UnmarshalBinary(input []byte) error {
+	sc, err := scalarUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarBn254Gt)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.value = ss.value
+	return nil
+}
+
+func (s *ScalarBn254Gt) MarshalText() ([]byte, error) {
+	return scalarMarshalText(s)
+}
+
+func (s *ScalarBn254Gt) UnmarshalText(input []byte) error {
+	sc, err := scalarUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarBn254Gt)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.value = ss.value
+	return nil
+}
+
+func (s *ScalarBn254Gt) MarshalJSON() ([]byte, error) {
+	return scalarMarshalJson(s)
+}
+
+func (s *ScalarBn254Gt) UnmarshalJSON(input []byte) error {
+	sc, err := scalarUnmarshalJson(input)
+	if err != nil {
+		return err
+	}
+	S, ok := sc.(*ScalarBn254Gt)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	s.value = S.value
+	return nil
+}
+
+func (s *ScalarBn254Gt) IsOdd() bool {
+	data := s.value.Bytes()
+	return data[len(data)-1]&1 == 1
+}
+
+func (s *ScalarBn254Gt) IsEven() bool {
+	data := s.value.Bytes()
+	return data[len(data)-1]&1 == 0
+}
+
+func (s *ScalarBn254Gt) New(input int) Scalar {
+	var data [bn254.SizeOfGT]byte
+	data[3] = byte(input >> 24 & 0xFF)
+	data[2] = byte(input >> 16 & 0xFF)
+	data[1] = byte(input >> 8 & 0xFF)
+	data[0] = byte(input & 0xFF)
+
+	value := bn254.GT{}
+	err := value.SetBytes(data[:])
+	if err != nil {
+		return nil
+	}
+	return &ScalarBn254Gt{&value}
+}
+
+func (s *ScalarBn254Gt) Cmp(rhs Scalar) int {
+	r, ok := rhs.(*ScalarBn254Gt)
+	if ok && s.value.Equal(r.value) {
+		return 0
+	} else {
+		return -2
+	}
+}
+
+func (s *ScalarBn254Gt) Square() Scalar {
+	value := bn254.GT{}
+	return &ScalarBn254Gt{
+		value.Square(s.value),
+	}
+}
+
+func (s *ScalarBn254Gt) Double() Scalar {
+	value := &bn254.GT{}
+	return &ScalarBn254Gt{
+		value.Add(s.value, s.value),
+	}
+}
+
+func (s *ScalarBn254Gt) Invert() (Scalar, error) {
+	value := &bn254.GT{}
+	return &ScalarBn254Gt{
+		value.Inverse(s.value),
+	}, nil
+}
+
+func (s *ScalarBn254Gt) Sqrt() (Scalar, error) {
+	// Not implemented
+	return nil, nil
+}
+
+func (s *ScalarBn254Gt) Cube() Scalar {
+	value := &bn254.GT{}
+	value.Square(s.value)
+	value.Mul(value, s.value)
+	return &ScalarBn254Gt{
+		value,
+	}
+}
+
+func (s *ScalarBn254Gt) Add(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarBn254Gt)
+	if ok {
+		value := &bn254.GT{}
+		return &ScalarBn254Gt{
+			value.Add(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarBn254Gt) Sub(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarBn254Gt)
+	if ok {
+		value := &bn254.GT{}
+		return &ScalarBn254Gt{
+			value.Sub(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarBn254Gt) Mul(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarBn254Gt)
+	if ok {
+		value := &bn254.GT{}
+		return &ScalarBn254Gt{
+			value.Mul(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarBn254Gt) MulAdd(y, z Scalar) Scalar {
+	return s.Mul(y).Add(z)
+}
+
+func (s *ScalarBn254Gt) Div(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarBn254Gt)
+	if ok {
+		value := &bn254.GT{}
+		value.Inverse(r.value)
+		value.Mul(value, s.value)
+		return &ScalarBn254Gt{
+			value,
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarBn254Gt) Neg() Scalar {
+	sValue := &bn254.GT{}
+	sValue.SetOne()
+	value := &bn254.GT{}
+	value.SetOne()
+	value.Sub(value, sValue)
+	return &ScalarBn254Gt{
+		value.Sub(value, s.value),
+	}
+}
+
+func (s *ScalarBn254Gt) SetBigInt(v *big.Int) (Scalar, error) {
+	var bytes [bn254.SizeOfGT]byte
+	v.FillBytes(bytes[:])
+	return s.SetBytes(bytes[:])
+}
+
+func (s *ScalarBn254Gt) BigInt() *big.Int {
+	b := s.value.Bytes()
+	return new(big.Int).SetBytes(b[:])
+}
+
+func (s *ScalarBn254Gt) Point() Point {
+	p := &PointBn254G1{}
+	return p.Identity()
+}
+
+func (s *ScalarBn254Gt) Bytes() []byte {
+	b := s.value.Bytes()
+	return b[:]
+}
+
+func (s *ScalarBn254Gt) SetBytes(bytes []byte) (Scalar, error) {
+	value := &bn254.GT{}
+	err := value.SetBytes(bytes)
+	if err != nil {
+		return nil, err
+	}
+	return &ScalarBn254Gt{value}, nil
+}
+
+func (s *ScalarBn254Gt) SetBytesWide(bytes []byte) (Scalar, error) {
+	l := len(bytes)
+	if l != 2*bn254.SizeOfGT {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	value := &bn254.GT{}
+	err := value.SetBytes(bytes[:l/2])
+	if err != nil {
+		return nil, err
+	}
+	value2 := &bn254.GT{}
+	err = value2.SetBytes(bytes[l/2:])
+	if err != nil {
+		return nil, err
+	}
+	value.Add(value, value2)
+	return &ScalarBn254Gt{value}, nil
+}
+
+func (s *ScalarBn254Gt) BytesBE() []byte {
+	return s.Bytes()
+}
+
+func (s *ScalarBn254Gt) BytesLE() []byte {
+	return internal.ReverseScalarBytes(s.Bytes())
+}
+
+func (s *ScalarBn254Gt) SetBytesBE(bytes []byte) (Scalar, error) {
+	return s.SetBytes(bytes)
+}
+
+func (s *ScalarBn254Gt) SetBytesLE(bytes []byte) (Scalar, error) {
+	return s.SetBytes(internal.ReverseScalarBytes(bytes))
+}
+
+func (s *ScalarBn254Gt) SetBytesReduce(bytes []byte) (Scalar, error) {
+	// Gt is not a prime field, so there is no modulus to reduce by
+	return nil, fmt.Errorf("reduction is not defined in Gt")
+}
+
+func (s *ScalarBn254Gt) Clone() Scalar {
+	value := &bn254.GT{}
+	return &ScalarBn254Gt{
+		value.Set(s.value),
+	}
+}
+
+// bn254HashFieldBytes is L = ceil((ceil(log2(p)) + k) / 8) of RFC 9380 for k = 128
+const bn254HashFieldBytes = 48
+
+// bn254MapToG1 maps the outputs of hash_to_field to G1. One element gives the
+// encode_to_curve encoding of RFC 9380 and two give hash_to_curve: clearing the
+// cofactor of each mapped point before adding them equals clearing it once after
+func bn254MapToG1(u [][]byte) *bn254.G1Affine {
+	var sum, q bn254.G1Jac
+	sum.FromAffine(&bn254G1Inf)
+	for _, b := range u {
+		var e bn254fp.Element
+		pt := bn254.MapToG1(*e.SetBytes(b))
+		q.FromAffine(&pt)
+		sum.AddAssign(&q)
+	}
+	out := new(bn254.G1Affine).FromJacobian(&sum)
+	return out
+}
+
+// bn254MapToG2 is bn254MapToG1 for G2, whose field elements are pairs (c0, c1)
+func bn254MapToG2(u [][]byte) *bn254.G2Affine {
+	var sum, q bn254.G2Jac
+	sum.FromAffine(&bn254G2Inf)
+	for i := 0; i+1 < len(u); i += 2 {
+		var e bn254.E2
+		e.A0.SetBytes(u[i])
+		e.A1.SetBytes(u[i+1])
+		pt := bn254.MapToG2(e)
+		q.FromAffine(&pt)
+		sum.AddAssign(&q)
+	}
+	out := new(bn254.G2Affine).FromJacobian(&sum)
+	return out
+}
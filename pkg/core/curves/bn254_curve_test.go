@@ -0,0 +1,149 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curves
+
+import (
+	crand "crypto/rand"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPointBn254G1Ethereum(t *testing.T) {
+	// EIP-196 encodes the generator (1, 2) as two big-endian 32-byte words
+	g := BN254G1().Point.Generator()
+	expected := make([]byte, 64)
+	expected[31] = 1
+	expected[63] = 2
+	require.Equal(t, expected, g.ToAffineUncompressed())
+	require.Equal(t, big.NewInt(1), g.(*PointBn254G1).X())
+	require.Equal(t, big.NewInt(2), g.(*PointBn254G1).Y())
+	pt, err := new(PointBn254G1).Set(big.NewInt(1), big.NewInt(2))
+	require.NoError(t, err)
+	require.True(t, g.Equal(pt))
+
+	// The identity is all zeroes
+	id := BN254G1().Point.Identity()
+	require.Equal(t, make([]byte, 64), id.ToAffineUncompressed())
+	pt, err = new(PointBn254G1).FromAffineUncompressed(make([]byte, 64))
+	require.NoError(t, err)
+	require.True(t, pt.IsIdentity())
+
+	// Points off the curve are rejected
+	expected[63] = 3
+	_, err = new(PointBn254G1).FromAffineUncompressed(expected)
+	require.Error(t, err)
+}
+
+func TestPointBn254G2Ethereum(t *testing.T) {
+	// EIP-197 encodes the coordinates of G2 points as (imaginary, real)
+	xi, _ := new(big.Int).SetString("11559732032986387107991004021392285783925812861821192530917403151452391805634", 10)
+	xr, _ := new(big.Int).SetString("10857046999023057135944570762232829481370756359578518086990519993285655852781", 10)
+	yi, _ := new(big.Int).SetString("4082367875863433681332203403145435568316851327593401208105741076214120093531", 10)
+	yr, _ := new(big.Int).SetString("8495653923123431417604973247489272438418190587263600148770280649306958101930", 10)
+	expected := make([]byte, 128)
+	xi.FillBytes(expected[:32])
+	xr.FillBytes(expected[32:64])
+	yi.FillBytes(expected[64:96])
+	yr.FillBytes(expected[96:])
+
+	g := BN254G2().Point.Generator()
+	require.Equal(t, expected, g.ToAffineUncompressed())
+	pt, err := new(PointBn254G2).FromAffineUncompressed(expected)
+	require.NoError(t, err)
+	require.True(t, g.Equal(pt))
+
+	id := BN254G2().Point.Identity()
+	require.Equal(t, make([]byte, 128), id.ToAffineUncompressed())
+	pt, err = new(PointBn254G2).FromAffineUncompressed(make([]byte, 128))
+	require.NoError(t, err)
+	require.True(t, pt.IsIdentity())
+}
+
+func TestPointBn254AddDoubleMul(t *testing.T) {
+	for _, curve := range []*Curve{BN254G1(), BN254G2()} {
+		g := curve.Point.Generator()
+		require.True(t, g.Add(curve.Point.Identity()).Equal(g))
+		require.True(t, g.Double().Equal(g.Add(g)))
+		require.True(t, g.Mul(curve.Scalar.New(3)).Equal(g.Double().Add(g)))
+		require.True(t, g.Mul(curve.Scalar.New(-1)).Equal(g.Neg()))
+		require.True(t, g.Mul(curve.Scalar.New(-1).Add(curve.Scalar.One())).IsIdentity())
+		require.True(t, g.Sub(g).IsIdentity())
+		require.Nil(t, g.Mul(BLS12377G1().Scalar.One()))
+	}
+}
+
+func TestPointBn254Hash(t *testing.T) {
+	for _, curve := range []*Curve{BN254G1(), BN254G2()} {
+		h0 := curve.Point.Hash(nil)
+		require.True(t, h0.IsOnCurve())
+		h1 := curve.Point.Hash([]byte{1})
+		require.True(t, h1.IsOnCurve())
+		require.False(t, h0.Equal(h1))
+		require.True(t, curve.Point.Random(crand.Reader).IsOnCurve())
+	}
+}
+
+func TestPointBn254Serialize(t *testing.T) {
+	for _, curve := range []*Curve{BN254G1(), BN254G2()} {
+		for i := 0; i < 10; i++ {
+			pt := curve.Point.Generator().Mul(curve.Scalar.Random(crand.Reader))
+			ret, err := curve.Point.FromAffineCompressed(pt.ToAffineCompressed())
+			require.NoError(t, err)
+			require.True(t, pt.Equal(ret))
+			ret, err = curve.Point.FromAffineUncompressed(pt.ToAffineUncompressed())
+			require.NoError(t, err)
+			require.True(t, pt.Equal(ret))
+			require.Equal(t, pt.IsNegative(), !pt.Neg().IsNegative())
+		}
+
+		pt := curve.Point.Random(crand.Reader)
+		js, err := json.Marshal(pt)
+		require.NoError(t, err)
+		out, err := pointUnmarshalJson(js)
+		require.NoError(t, err)
+		require.True(t, pt.Equal(out))
+
+		sc := curve.Scalar.Random(crand.Reader)
+		bin, err := scalarMarshalBinary(sc)
+		require.NoError(t, err)
+		outSc, err := scalarUnmarshalBinary(bin)
+		require.NoError(t, err)
+		require.Equal(t, 0, sc.Cmp(outSc))
+	}
+	require.Equal(t, BN254G1(), GetCurveByName(BN254Name))
+	require.Equal(t, BN254Name, GetPairingCurveByName(BN254G2Name).Name)
+}
+
+func TestBn254Pairing(t *testing.T) {
+	bn254 := BN254(BN254G1().NewIdentityPoint())
+	a := bn254.Scalar.Random(crand.Reader)
+	b := bn254.Scalar.Random(crand.Reader)
+	aP := bn254.ScalarG1BaseMult(a)
+	bQ := bn254.ScalarG2BaseMult(b)
+
+	// e(aP, bQ) = e(abP, Q) = e(P, abQ)
+	lhs := aP.Pairing(bQ)
+	require.NotNil(t, lhs)
+	require.Equal(t, 0, lhs.Cmp(bn254.ScalarG1BaseMult(a.Mul(b)).Pairing(bn254.NewG2GeneratorPoint())))
+	require.Equal(t, 0, lhs.Cmp(bQ.Pairing(aP)))
+	require.False(t, lhs.IsOne())
+
+	// e(aP, bQ) e(-abP, Q) = 1
+	g2 := bn254.NewG2GeneratorPoint()
+	abP := bn254.ScalarG1BaseMult(a.Mul(b)).Neg().(PairingPoint)
+	require.True(t, aP.MultiPairing(aP, bQ, abP, g2).IsOne())
+	require.False(t, aP.MultiPairing(aP, bQ, abP.Neg().(PairingPoint), g2).IsOne())
+
+	// Pairings with the identity are one
+	require.True(t, aP.Pairing(bn254.NewG2IdentityPoint()).IsOne())
+	require.Nil(t, aP.Pairing(aP))
+	require.Nil(t, aP.MultiPairing(aP))
+	require.Nil(t, aP.MultiPairing(bQ, aP))
+}
@@ -0,0 +1,438 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curves
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/TEENet-io/kryptology/internal"
+)
+
+// The codec is the one curve agnostic encoding of points and scalars shared by the protocol packages. A curve is
+// identified by its name, and its elements by their canonical bytes: points are compressed, scalars are big-endian
+// integers of the curve's scalar width that are less than the group order. Decoded points must be canonical and in the
+// prime order subgroup. The identity round trips, so protocols reject it where it is not allowed. Lengths and counts
+// are 4-byte big-endian integers, variable length values are prefixed with their length, and decoders reject trailing
+// bytes so every value has exactly one encoding.
+//
+// Standalone values and protocol messages start with a two byte header, codecMarker followed by the codec version.
+// The marker is chosen so that no gob stream starts with it: gob writes counts below 128 as a single byte and larger
+// ones as a negated byte count of 0xf8 to 0xff. Decoders can therefore tell codec payloads from the gob payloads that
+// the protocol packages produced before, and keep accepting the latter.
+
+// CodecVersion1 is the current version of the codec
+const CodecVersion1 byte = 1
+
+const codecMarker byte = 0xc0
+
+// IsCodecEncoded reports whether data starts with the codec header rather than being a legacy encoding
+func IsCodecEncoded(data []byte) bool {
+	return len(data) >= 2 && data[0] == codecMarker
+}
+
+var gobTypesOnce sync.Once
+
+// RegisterGobTypes registers the point and scalar types of every supported curve with encoding/gob,
+// so that payloads gob encoded before the codec existed can still be decoded.
+func RegisterGobTypes() {
+	gobTypesOnce.Do(func() {
+		for _, name := range []string{
+			K256Name, P256Name, P384Name, ED25519Name, ED448Name, RISTRETTO255Name, PallasName, VestaName,
+			BLS12381G1Name, BLS12381G2Name, BLS12377G1Name, BLS12377G2Name, BN254G1Name, BN254G2Name,
+		} {
+			curve := GetCurveByName(name)
+			gob.Register(curve.Point)
+			gob.Register(curve.Scalar)
+		}
+	})
+}
+
+// Encoder appends values in the codec encoding. The zero value writes no header,
+// for payloads whose version is carried outside of them.
+type Encoder struct {
+	buf bytes.Buffer
+}
+
+// NewEncoder returns an encoder whose output starts with the codec header
+func NewEncoder() *Encoder {
+	e := new(Encoder)
+	e.buf.Write([]byte{codecMarker, CodecVersion1})
+	return e
+}
+
+// Bytes returns the encoded data
+func (e *Encoder) Bytes() []byte {
+	return e.buf.Bytes()
+}
+
+// WriteUint32 writes v as 4 big-endian bytes
+func (e *Encoder) WriteUint32(v uint32) {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], v)
+	e.buf.Write(b[:])
+}
+
+// WriteFixed writes b without a length prefix
+func (e *Encoder) WriteFixed(b []byte) {
+	e.buf.Write(b)
+}
+
+// WriteBytes writes b prefixed with its length
+func (e *Encoder) WriteBytes(b []byte) {
+	e.WriteUint32(uint32(len(b)))
+	e.buf.Write(b)
+}
+
+// WritePresent writes the flag in front of an optional value
+func (e *Encoder) WritePresent(present bool) {
+	if present {
+		e.buf.WriteByte(1)
+	} else {
+		e.buf.WriteByte(0)
+	}
+}
+
+// WriteCurve writes the name of the curve that the following points and scalars belong to
+func (e *Encoder) WriteCurve(name string) {
+	e.WriteBytes([]byte(name))
+}
+
+// WriteScalar writes s as a big-endian integer of the curve's scalar width
+func (e *Encoder) WriteScalar(s Scalar) {
+	e.WriteBytes(s.BytesBE())
+}
+
+// WritePoint writes the compressed form of p
+func (e *Encoder) WritePoint(p Point) {
+	e.WriteBytes(p.ToAffineCompressed())
+}
+
+// WriteBigInt writes a non-negative integer without leading zero bytes
+func (e *Encoder) WriteBigInt(v *big.Int) {
+	e.WriteBytes(v.Bytes())
+}
+
+// Decoder consumes values in the codec encoding. The first error is sticky: later reads return zero
+// values, and it is reported by Err and Finish.
+type Decoder struct {
+	data  []byte
+	curve *Curve
+	err   error
+}
+
+// NewDecoder returns a decoder of data. Callers check the header with ReadHeader
+// unless the version is carried outside of data.
+func NewDecoder(data []byte) *Decoder {
+	return &Decoder{data: data}
+}
+
+// ReadHeader consumes the codec header and fails on an unknown version
+func (d *Decoder) ReadHeader() {
+	var header [2]byte
+	d.ReadFixed(header[:])
+	if d.err != nil {
+		return
+	}
+	if header[0] != codecMarker {
+		d.Fail("missing codec header")
+		return
+	}
+	if header[1] != CodecVersion1 {
+		d.Fail("unsupported codec version %d", header[1])
+	}
+}
+
+// Fail records an error unless one was recorded before
+func (d *Decoder) Fail(format string, args ...interface{}) {
+	if d.err == nil {
+		d.err = fmt.Errorf(format, args...)
+	}
+}
+
+// Err returns the first error of the decoder
+func (d *Decoder) Err() error {
+	return d.err
+}
+
+// Curve returns the curve read last by ReadCurve
+func (d *Decoder) Curve() *Curve {
+	return d.curve
+}
+
+func (d *Decoder) take(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if n < 0 || n > len(d.data) {
+		d.Fail("payload truncated")
+		return nil
+	}
+	out := d.data[:n]
+	d.data = d.data[n:]
+	return out
+}
+
+// ReadUint32 reads 4 big-endian bytes
+func (d *Decoder) ReadUint32() uint32 {
+	b := d.take(4)
+	if b == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint32(b)
+}
+
+// ReadFixed fills out without reading a length prefix
+func (d *Decoder) ReadFixed(out []byte) {
+	copy(out, d.take(len(out)))
+}
+
+// ReadBytes reads a length prefixed value
+func (d *Decoder) ReadBytes() []byte {
+	n := d.ReadUint32()
+	if uint64(n) > uint64(len(d.data)) {
+		d.Fail("length %d exceeds payload", n)
+		return nil
+	}
+	return append([]byte{}, d.take(int(n))...)
+}
+
+// ReadCount reads a list length and checks that the rest of the payload can hold that many items of at least
+// `minSize` bytes, so that a hostile count cannot trigger a large allocation.
+func (d *Decoder) ReadCount(minSize int) int {
+	n := d.ReadUint32()
+	if uint64(n)*uint64(minSize) > uint64(len(d.data)) {
+		d.Fail("count %d exceeds payload", n)
+		return 0
+	}
+	return int(n)
+}
+
+// ReadPresent reads the flag in front of an optional value
+func (d *Decoder) ReadPresent() bool {
+	var flag [1]byte
+	d.ReadFixed(flag[:])
+	if flag[0] > 1 {
+		d.Fail("invalid presence flag %d", flag[0])
+	}
+	return d.err == nil && flag[0] == 1
+}
+
+// ReadCurve reads a curve name and makes it the curve of the following points and scalars
+func (d *Decoder) ReadCurve() {
+	name := string(d.ReadBytes())
+	if d.err != nil {
+		return
+	}
+	d.curve = GetCurveByName(name)
+	if d.curve == nil {
+		d.Fail("unsupported curve %q", name)
+	}
+}
+
+// ReadScalar reads a scalar of the current curve and rejects unreduced values
+func (d *Decoder) ReadScalar() Scalar {
+	b := d.ReadBytes()
+	if d.err != nil {
+		return nil
+	}
+	if d.curve == nil {
+		d.Fail("scalar without a curve")
+		return nil
+	}
+	sc, err := d.curve.Scalar.SetBytesBE(b)
+	if err != nil {
+		d.Fail("invalid scalar: %v", err)
+		return nil
+	}
+	return sc
+}
+
+// ReadPoint reads a compressed point of the current curve and rejects non-canonical encodings
+// and points outside the prime order subgroup
+func (d *Decoder) ReadPoint() Point {
+	b := d.ReadBytes()
+	if d.err != nil {
+		return nil
+	}
+	if d.curve == nil {
+		d.Fail("point without a curve")
+		return nil
+	}
+	p, err := codecPoint(d.curve, b)
+	if err != nil {
+		d.Fail("invalid point: %v", err)
+		return nil
+	}
+	return p
+}
+
+// ReadBigInt reads a non-negative integer and rejects leading zero bytes
+func (d *Decoder) ReadBigInt() *big.Int {
+	b := d.ReadBytes()
+	if len(b) > 0 && b[0] == 0 {
+		d.Fail("integer is not minimally encoded")
+	}
+	return new(big.Int).SetBytes(b)
+}
+
+// Finish returns the first error, or an error if bytes are left over
+func (d *Decoder) Finish() error {
+	if d.err != nil {
+		return d.err
+	}
+	if len(d.data) != 0 {
+		return fmt.Errorf("%d trailing bytes in payload", len(d.data))
+	}
+	return nil
+}
+
+// MarshalPoint encodes p with the codec header, its curve name and its compressed form
+func MarshalPoint(p Point) ([]byte, error) {
+	if p == nil {
+		return nil, internal.ErrNilArguments
+	}
+	e := NewEncoder()
+	e.WriteCurve(p.CurveName())
+	e.WritePoint(p)
+	return e.Bytes(), nil
+}
+
+// UnmarshalPoint decodes a point of any curve from the output of MarshalPoint, or from the legacy
+// name prefixed form of the MarshalBinary methods
+func UnmarshalPoint(data []byte) (Point, error) {
+	if !IsCodecEncoded(data) {
+		p, err := pointUnmarshalBinary(data)
+		if err != nil {
+			return nil, err
+		}
+		if !p.IsIdentity() && !p.IsTorsionFree() {
+			return nil, fmt.Errorf("point is not in the prime order subgroup")
+		}
+		return p, nil
+	}
+	d := NewDecoder(data)
+	d.ReadHeader()
+	d.ReadCurve()
+	p := d.ReadPoint()
+	if err := d.Finish(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// MarshalScalar encodes s with the codec header, its curve name and its canonical big-endian form
+func MarshalScalar(s Scalar) ([]byte, error) {
+	if s == nil {
+		return nil, internal.ErrNilArguments
+	}
+	e := NewEncoder()
+	e.WriteCurve(s.Point().CurveName())
+	e.WriteScalar(s)
+	return e.Bytes(), nil
+}
+
+// UnmarshalScalar decodes a scalar of any curve from the output of MarshalScalar, or from the legacy
+// name prefixed form of the MarshalBinary methods
+func UnmarshalScalar(data []byte) (Scalar, error) {
+	if !IsCodecEncoded(data) {
+		return scalarUnmarshalBinary(data)
+	}
+	d := NewDecoder(data)
+	d.ReadHeader()
+	d.ReadCurve()
+	s := d.ReadScalar()
+	if err := d.Finish(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// codecJson is the JSON form of the codec. Legacy JSON has no version and
+// holds the curve specific Bytes of scalars instead of the big-endian form.
+type codecJson struct {
+	Version byte   `json:"version,omitempty"`
+	Type    string `json:"type"`
+	Value   string `json:"value"`
+}
+
+// MarshalPointJSON encodes p as a JSON object with the codec version, its curve name and its hex encoded
+// compressed form
+func MarshalPointJSON(p Point) ([]byte, error) {
+	if p == nil {
+		return nil, internal.ErrNilArguments
+	}
+	return json.Marshal(codecJson{
+		Version: CodecVersion1,
+		Type:    p.CurveName(),
+		Value:   hex.EncodeToString(p.ToAffineCompressed()),
+	})
+}
+
+// UnmarshalPointJSON decodes a point of any curve from the output of MarshalPointJSON or of the MarshalJSON methods
+func UnmarshalPointJSON(data []byte) (Point, error) {
+	v, curve, err := unmarshalCodecJson(data)
+	if err != nil {
+		return nil, err
+	}
+	return codecPoint(curve, v.value)
+}
+
+// MarshalScalarJSON encodes s as a JSON object with the codec version, its curve name and its hex encoded
+// big-endian form
+func MarshalScalarJSON(s Scalar) ([]byte, error) {
+	if s == nil {
+		return nil, internal.ErrNilArguments
+	}
+	return json.Marshal(codecJson{
+		Version: CodecVersion1,
+		Type:    s.Point().CurveName(),
+		Value:   hex.EncodeToString(s.BytesBE()),
+	})
+}
+
+// UnmarshalScalarJSON decodes a scalar of any curve from the output of MarshalScalarJSON or of the MarshalJSON methods
+func UnmarshalScalarJSON(data []byte) (Scalar, error) {
+	v, curve, err := unmarshalCodecJson(data)
+	if err != nil {
+		return nil, err
+	}
+	if v.version == 0 {
+		return curve.Scalar.SetBytes(v.value)
+	}
+	return curve.Scalar.SetBytesBE(v.value)
+}
+
+type decodedJson struct {
+	version byte
+	value   []byte
+}
+
+func unmarshalCodecJson(data []byte) (*decodedJson, *Curve, error) {
+	var v codecJson
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, nil, err
+	}
+	if v.Version > CodecVersion1 {
+		return nil, nil, fmt.Errorf("unsupported codec version %d", v.Version)
+	}
+	curve := GetCurveByName(v.Type)
+	if curve == nil {
+		return nil, nil, fmt.Errorf("invalid type")
+	}
+	value, err := hex.DecodeString(v.Value)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &decodedJson{version: v.Version, value: value}, curve, nil
+}
@@ -0,0 +1,152 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curves
+
+import (
+	"bytes"
+	crand "crypto/rand"
+	"encoding/gob"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func codecTestCurves() []*Curve {
+	return []*Curve{
+		K256(), P256(), P384(), ED25519(), ED448(), RISTRETTO255(), PALLAS(), VESTA(),
+		BLS12381G1(), BLS12381G2(), BLS12377G1(), BLS12377G2(), BN254G1(), BN254G2(),
+	}
+}
+
+func TestCodecRoundTrip(t *testing.T) {
+	for _, curve := range codecTestCurves() {
+		s := curve.Scalar.Random(crand.Reader)
+		p := curve.ScalarBaseMult(s)
+
+		data, err := MarshalPoint(p)
+		require.NoError(t, err, curve.Name)
+		require.True(t, IsCodecEncoded(data))
+		q, err := UnmarshalPoint(data)
+		require.NoError(t, err, curve.Name)
+		require.True(t, p.Equal(q), curve.Name)
+
+		data, err = MarshalScalar(s)
+		require.NoError(t, err, curve.Name)
+		r, err := UnmarshalScalar(data)
+		require.NoError(t, err, curve.Name)
+		require.Equal(t, 0, s.Cmp(r), curve.Name)
+
+		data, err = MarshalPointJSON(p)
+		require.NoError(t, err, curve.Name)
+		q, err = UnmarshalPointJSON(data)
+		require.NoError(t, err, curve.Name)
+		require.True(t, p.Equal(q), curve.Name)
+
+		data, err = MarshalScalarJSON(s)
+		require.NoError(t, err, curve.Name)
+		r, err = UnmarshalScalarJSON(data)
+		require.NoError(t, err, curve.Name)
+		require.Equal(t, 0, s.Cmp(r), curve.Name)
+	}
+}
+
+func TestCodecIdentity(t *testing.T) {
+	for _, curve := range codecTestCurves() {
+		data, err := MarshalPoint(curve.Point.Identity())
+		require.NoError(t, err, curve.Name)
+		p, err := UnmarshalPoint(data)
+		require.NoError(t, err, curve.Name)
+		require.True(t, p.IsIdentity(), curve.Name)
+
+		data, err = MarshalPointJSON(curve.Point.Identity())
+		require.NoError(t, err, curve.Name)
+		p, err = UnmarshalPointJSON(data)
+		require.NoError(t, err, curve.Name)
+		require.True(t, p.IsIdentity(), curve.Name)
+	}
+}
+
+func TestCodecLegacyForms(t *testing.T) {
+	for _, curve := range []*Curve{K256(), P256(), ED25519(), PALLAS(), BLS12381G1()} {
+		s := curve.Scalar.Random(crand.Reader)
+		p := curve.ScalarBaseMult(s)
+
+		data, err := pointMarshalBinary(p)
+		require.NoError(t, err)
+		require.False(t, IsCodecEncoded(data))
+		q, err := UnmarshalPoint(data)
+		require.NoError(t, err, curve.Name)
+		require.True(t, p.Equal(q), curve.Name)
+
+		data, err = scalarMarshalBinary(s)
+		require.NoError(t, err)
+		r, err := UnmarshalScalar(data)
+		require.NoError(t, err, curve.Name)
+		require.Equal(t, 0, s.Cmp(r), curve.Name)
+
+		data, err = pointMarshalJson(p)
+		require.NoError(t, err)
+		q, err = UnmarshalPointJSON(data)
+		require.NoError(t, err, curve.Name)
+		require.True(t, p.Equal(q), curve.Name)
+
+		data, err = scalarMarshalJson(s)
+		require.NoError(t, err)
+		r, err = UnmarshalScalarJSON(data)
+		require.NoError(t, err, curve.Name)
+		require.Equal(t, 0, s.Cmp(r), curve.Name)
+	}
+}
+
+func TestCodecGobIsNotCodecEncoded(t *testing.T) {
+	RegisterGobTypes()
+	for _, curve := range codecTestCurves() {
+		value := struct{ P Point }{curve.Point.Generator()}
+		buf := new(bytes.Buffer)
+		require.NoError(t, gob.NewEncoder(buf).Encode(value), curve.Name)
+		require.False(t, IsCodecEncoded(buf.Bytes()), curve.Name)
+	}
+}
+
+func TestCodecRejects(t *testing.T) {
+	curve := P256()
+	p := curve.Point.Generator()
+	data, err := MarshalPoint(p)
+	require.NoError(t, err)
+
+	_, err = UnmarshalPoint(append(append([]byte{}, data...), 0))
+	require.Error(t, err)
+	_, err = UnmarshalPoint(data[:len(data)-1])
+	require.Error(t, err)
+	bad := append([]byte{}, data...)
+	bad[1] = CodecVersion1 + 1
+	_, err = UnmarshalPoint(bad)
+	require.Error(t, err)
+
+	e := NewEncoder()
+	e.WriteCurve("unknown")
+	e.WritePoint(p)
+	_, err = UnmarshalPoint(e.Bytes())
+	require.Error(t, err)
+
+	// The group order is not a reduced scalar
+	order := curve.Scalar.One().Neg().BigInt()
+	order.Add(order, big.NewInt(1))
+	e = NewEncoder()
+	e.WriteCurve(curve.Name)
+	e.WriteBytes(order.FillBytes(make([]byte, 32)))
+	_, err = UnmarshalScalar(e.Bytes())
+	require.Error(t, err)
+
+	_, err = MarshalPoint(nil)
+	require.Error(t, err)
+	_, err = MarshalScalar(nil)
+	require.Error(t, err)
+	_, err = UnmarshalPointJSON([]byte(`{"version":2,"type":"P-256","value":""}`))
+	require.Error(t, err)
+}
@@ -16,6 +16,10 @@ import (
 	"math/big"
 	"sync"
 
+	"filippo.io/edwards25519"
+	"github.com/bwesterb/go-ristretto"
+
+	"github.com/TEENet-io/kryptology/pkg/core/curves/native"
 	"github.com/TEENet-io/kryptology/pkg/core/curves/native/bls12381"
 )
 
@@ -43,25 +47,58 @@ var (
 
 	pallasInitonce sync.Once
 	pallas         Curve
+
+	ristretto255Initonce sync.Once
+	ristretto255         Curve
+
+	vestaInitonce sync.Once
+	vesta         Curve
+
+	bn254g1Initonce sync.Once
+	bn254g1         Curve
+
+	bn254g2Initonce sync.Once
+	bn254g2         Curve
+
+	p384Initonce sync.Once
+	p384         Curve
+
+	ed448Initonce sync.Once
+	ed448         Curve
 )
 
 const (
-	K256Name       = "secp256k1"
-	BLS12381G1Name = "BLS12381G1"
-	BLS12381G2Name = "BLS12381G2"
-	BLS12831Name   = "BLS12831"
-	P256Name       = "P-256"
-	ED25519Name    = "ed25519"
-	PallasName     = "pallas"
-	BLS12377G1Name = "BLS12377G1"
-	BLS12377G2Name = "BLS12377G2"
-	BLS12377Name   = "BLS12377"
+	K256Name         = "secp256k1"
+	BLS12381G1Name   = "BLS12381G1"
+	BLS12381G2Name   = "BLS12381G2"
+	BLS12831Name     = "BLS12831"
+	P256Name         = "P-256"
+	ED25519Name      = "ed25519"
+	PallasName       = "pallas"
+	BLS12377G1Name   = "BLS12377G1"
+	BLS12377G2Name   = "BLS12377G2"
+	BLS12377Name     = "BLS12377"
+	RISTRETTO255Name = "ristretto255"
+	VestaName        = "vesta"
+	BN254G1Name      = "BN254G1"
+	BN254G2Name      = "BN254G2"
+	BN254Name        = "BN254"
+	P384Name         = "P-384"
+	ED448Name        = "ed448"
 )
 
 const scalarBytes = 32
 
 // Scalar represents an element of the scalar field \mathbb{F}_q
 // of the elliptic curve construction.
+//
+// Bytes and SetBytes use the byte order of the curve's usual standard, which is
+// big-endian for secp256k1 (SEC1, BIP-340), P-256 and P-384 (SEC1), BLS12-381,
+// BLS12-377 and BN254, and little-endian for ed25519 and ed448 (RFC 8032),
+// ristretto255 (RFC 9496), pallas and vesta. SetBytesWide is little-endian except
+// on BLS12-377 and BN254, where it is big-endian. Callers that need a specific
+// byte order use BytesBE, BytesLE, SetBytesBE, SetBytesLE and SetBytesReduce,
+// which behave the same on every curve.
 type Scalar interface {
 	// Random returns a random scalar using the provided reader
 	// to retrieve bytes
@@ -123,6 +160,17 @@ type Scalar interface {
 	SetBytes(bytes []byte) (Scalar, error)
 	// SetBytesWide creates a scalar expecting double the exact number of bytes needed to represent the scalar which is reduced by the modulus
 	SetBytesWide(bytes []byte) (Scalar, error)
+	// BytesBE returns this scalar as a big-endian integer of the same length as Bytes
+	BytesBE() []byte
+	// BytesLE returns this scalar as a little-endian integer of the same length as Bytes
+	BytesLE() []byte
+	// SetBytesBE creates a scalar from a big-endian integer of the same length as Bytes, rejecting values not less than the group order
+	SetBytesBE(bytes []byte) (Scalar, error)
+	// SetBytesLE creates a scalar from a little-endian integer of the same length as Bytes, rejecting values not less than the group order
+	SetBytesLE(bytes []byte) (Scalar, error)
+	// SetBytesReduce creates a scalar from a big-endian integer of any length, such as a digest, reduced modulo the group order.
+	// It is not constant time, so it is meant for public values
+	SetBytesReduce(bytes []byte) (Scalar, error)
 	// Clone returns a cloned Scalar of this value
 	Clone() Scalar
 }
@@ -149,22 +197,23 @@ func unmarshalScalar(input []byte) (*Curve, []byte, error) {
 }
 
 func scalarMarshalBinary(scalar Scalar) ([]byte, error) {
-	// All scalars are 32 bytes long
-	// The last 32 bytes are the actual value
+	// Scalars are at least 32 bytes long, 48 for P-384 and 57 for Ed448
+	// The last bytes are the actual value
 	// The first remaining bytes are the curve name
 	// separated by a colon
+	t := scalar.Bytes()
 	name := []byte(scalar.Point().CurveName())
-	output := make([]byte, len(name)+1+scalarBytes)
+	output := make([]byte, len(name)+1+len(t))
 	copy(output[:len(name)], name)
 	output[len(name)] = byte(':')
-	copy(output[len(name)+1:], scalar.Bytes())
+	copy(output[len(name)+1:], t)
 	return output, nil
 }
 
 func scalarUnmarshalBinary(input []byte) (Scalar, error) {
-	// All scalars are 32 bytes long
-	// The first 32 bytes are the actual value
-	// The remaining bytes are the curve name
+	// Scalars are at least 32 bytes long
+	// The first bytes are the curve name
+	// The remaining bytes are the actual value
 	if len(input) < scalarBytes+1+len(P256Name) {
 		return nil, fmt.Errorf("invalid byte sequence")
 	}
@@ -176,15 +225,16 @@ func scalarUnmarshalBinary(input []byte) (Scalar, error) {
 }
 
 func scalarMarshalText(scalar Scalar) ([]byte, error) {
-	// All scalars are 32 bytes long
+	// Scalars are at least 32 bytes long
 	// For text encoding we put the curve name first for readability
 	// separated by a colon, then the hex encoding of the scalar
 	// which avoids the base64 weakness with strict mode or not
+	t := scalar.Bytes()
 	name := []byte(scalar.Point().CurveName())
-	output := make([]byte, len(name)+1+scalarBytes*2)
+	output := make([]byte, len(name)+1+len(t)*2)
 	copy(output[:len(name)], name)
 	output[len(name)] = byte(':')
-	_ = hex.Encode(output[len(name)+1:], scalar.Bytes())
+	_ = hex.Encode(output[len(name)+1:], t)
 	return output, nil
 }
 
@@ -196,12 +246,12 @@ func scalarUnmarshalText(input []byte) (Scalar, error) {
 	if err != nil {
 		return nil, err
 	}
-	var t [scalarBytes]byte
-	_, err = hex.Decode(t[:], data)
+	t := make([]byte, hex.DecodedLen(len(data)))
+	_, err = hex.Decode(t, data)
 	if err != nil {
 		return nil, err
 	}
-	return curve.Scalar.SetBytes(t[:])
+	return curve.Scalar.SetBytes(t)
 }
 
 func scalarMarshalJson(scalar Scalar) ([]byte, error) {
@@ -237,11 +287,26 @@ func scalarUnmarshalJson(input []byte) (Scalar, error) {
 type Point interface {
 	Random(reader io.Reader) Point
 	Hash(bytes []byte) Point
+	// HashWithDst maps bytes to the prime order group with the hash_to_curve random
+	// oracle encoding of RFC 9380, i.e. the _RO_ suites, under the caller's domain
+	// separation tag and expand_message hasher
+	HashWithDst(bytes, dst []byte, hasher *native.EllipticPointHasher) (Point, error)
+	// EncodeWithDst is the encode_to_curve nonuniform encoding of RFC 9380, i.e. the
+	// _NU_ suites. It is cheaper than HashWithDst but its output is not uniformly
+	// distributed and it must not be used where a random oracle is needed
+	EncodeWithDst(bytes, dst []byte, hasher *native.EllipticPointHasher) (Point, error)
 	Identity() Point
 	Generator() Point
 	IsIdentity() bool
 	IsNegative() bool
 	IsOnCurve() bool
+	// IsTorsionFree returns true if the point lies in the prime order subgroup. On curves
+	// with a cofactor, such as ed25519, ed448, BLS12-381, BLS12-377 and BN254 G2, a point
+	// can be on the curve and still have a small order component
+	IsTorsionFree() bool
+	// ClearCofactor returns the point multiplied by the cofactor of the curve, which
+	// maps every point of the curve into the prime order subgroup
+	ClearCofactor() Point
 	Double() Point
 	Scalar() Scalar
 	Neg() Point
@@ -253,11 +318,65 @@ type Point interface {
 	ToAffineCompressed() []byte
 	ToAffineUncompressed() []byte
 	FromAffineCompressed(bytes []byte) (Point, error)
+	// FromAffineCompressedStrict is FromAffineCompressed that also rejects non-canonical
+	// encodings, the identity and points outside the prime order subgroup. Points received
+	// from other parties of a protocol are decoded with it
+	FromAffineCompressedStrict(bytes []byte) (Point, error)
 	FromAffineUncompressed(bytes []byte) (Point, error)
 	CurveName() string
 	SumOfProducts(points []Point, scalars []Scalar) Point
 }
 
+// VartimePoint is a Point with a faster variable-time multi-scalar multiplication.
+// SumOfProductsVartime must only be used with public scalars, e.g. to verify signatures and proofs.
+type VartimePoint interface {
+	Point
+	SumOfProductsVartime(points []Point, scalars []Scalar) Point
+}
+
+// SumOfProductsVartime computes ∑ scalars[i] * points[i] for public scalars with the
+// variable-time multiplication of the curve if it has one, and with Mul and Add otherwise.
+// It fails when the lengths differ or the points and scalars are not all of the same curve.
+func SumOfProductsVartime(points []Point, scalars []Scalar) (Point, error) {
+	if len(points) == 0 || len(points) != len(scalars) {
+		return nil, fmt.Errorf("need as many points as scalars, got %d and %d", len(points), len(scalars))
+	}
+	if points[0] == nil {
+		return nil, fmt.Errorf("nil point")
+	}
+	var sum Point
+	if p, ok := points[0].(VartimePoint); ok {
+		sum = p.SumOfProductsVartime(points, scalars)
+	} else {
+		sum = mulAddProducts(points, scalars)
+	}
+	if sum == nil {
+		return nil, fmt.Errorf("points and scalars must be of the same curve")
+	}
+	return sum, nil
+}
+
+// mulAddProducts computes ∑ scalars[i] * points[i] with Mul and Add, or returns nil when they
+// do not combine
+func mulAddProducts(points []Point, scalars []Scalar) Point {
+	var sum Point
+	for i, pt := range points {
+		if pt == nil || scalars[i] == nil {
+			return nil
+		}
+		product := pt.Mul(scalars[i])
+		if product == nil {
+			return nil
+		}
+		if sum == nil {
+			sum = product
+		} else if sum = sum.Add(product); sum == nil {
+			return nil
+		}
+	}
+	return sum
+}
+
 type PairingPoint interface {
 	Point
 	OtherGroup() PairingPoint
@@ -372,7 +491,21 @@ type Curve struct {
 	Name   string
 }
 
+// ScalarBaseMult returns sc * G for the generator G of the curve. The first call builds
+// a fixed-base table of G for K256, P256, Pallas, Vesta and BLS12-381 G1 and G2, and Ed25519 uses
+// the precomputed table of filippo.io/edwards25519 and ristretto255 the one of go-ristretto.
 func (c Curve) ScalarBaseMult(sc Scalar) Point {
+	if s, ok := sc.(*ScalarEd25519); ok && c.Name == ED25519Name {
+		return &PointEd25519{edwards25519.NewIdentityPoint().ScalarBaseMult(s.value)}
+	}
+	if s, ok := sc.(*ScalarRistretto255); ok && c.Name == RISTRETTO255Name {
+		return &PointRistretto255{new(ristretto.Point).ScalarMultBase(s.value)}
+	}
+	if t := c.generatorFixedBaseTable(); t != nil {
+		if p := t.Mul(sc); p != nil {
+			return p
+		}
+	}
 	return c.Point.Generator().Mul(sc)
 }
 
@@ -412,6 +545,20 @@ func (c Curve) ToEllipticCurve() (elliptic.Curve, error) {
 		return nil, err
 	case BLS12377Name:
 		return nil, err
+	case RISTRETTO255Name:
+		return nil, err
+	case VestaName:
+		return nil, err
+	case BN254G1Name:
+		return nil, err
+	case BN254G2Name:
+		return nil, err
+	case BN254Name:
+		return nil, err
+	case P384Name:
+		return elliptic.P384(), nil
+	case ED448Name:
+		return nil, err
 	default:
 		return nil, err
 	}
@@ -478,6 +625,20 @@ func GetCurveByName(name string) *Curve {
 		return BLS12377G2()
 	case BLS12377Name:
 		return BLS12377G1()
+	case RISTRETTO255Name:
+		return RISTRETTO255()
+	case VestaName:
+		return VESTA()
+	case BN254G1Name:
+		return BN254G1()
+	case BN254G2Name:
+		return BN254G2()
+	case BN254Name:
+		return BN254G1()
+	case P384Name:
+		return P384()
+	case ED448Name:
+		return ED448()
 	default:
 		return nil
 	}
@@ -491,6 +652,12 @@ func GetPairingCurveByName(name string) *PairingCurve {
 		return BLS12381(BLS12381G2().NewIdentityPoint())
 	case BLS12831Name:
 		return BLS12381(BLS12381G1().NewIdentityPoint())
+	case BN254G1Name:
+		return BN254(BN254G1().NewIdentityPoint())
+	case BN254G2Name:
+		return BN254(BN254G2().NewIdentityPoint())
+	case BN254Name:
+		return BN254(BN254G1().NewIdentityPoint())
 	default:
 		return nil
 	}
@@ -583,6 +750,54 @@ func bls12377g2Init() {
 	}
 }
 
+// BN254G1 returns the BN254 curve of the Ethereum precompiles with points in G1
+func BN254G1() *Curve {
+	bn254g1Initonce.Do(bn254g1Init)
+	return &bn254g1
+}
+
+func bn254g1Init() {
+	bn254g1 = Curve{
+		Scalar: &ScalarBn254{
+			value: new(big.Int),
+			point: new(PointBn254G1),
+		},
+		Point: new(PointBn254G1).Identity(),
+		Name:  BN254G1Name,
+	}
+}
+
+// BN254G2 returns the BN254 curve of the Ethereum precompiles with points in G2
+func BN254G2() *Curve {
+	bn254g2Initonce.Do(bn254g2Init)
+	return &bn254g2
+}
+
+func bn254g2Init() {
+	bn254g2 = Curve{
+		Scalar: &ScalarBn254{
+			value: new(big.Int),
+			point: new(PointBn254G2),
+		},
+		Point: new(PointBn254G2).Identity(),
+		Name:  BN254G2Name,
+	}
+}
+
+// BN254 returns the BN254 pairing curve whose scalars are associated with the group of preferredPoint
+func BN254(preferredPoint Point) *PairingCurve {
+	return &PairingCurve{
+		Scalar: &ScalarBn254{
+			value: new(big.Int),
+			point: preferredPoint,
+		},
+		PointG1: new(PointBn254G1).Identity().(PairingPoint),
+		PointG2: new(PointBn254G2).Identity().(PairingPoint),
+		GT:      new(ScalarBn254Gt).One(),
+		Name:    BN254Name,
+	}
+}
+
 // K256 returns the secp256k1 curve
 func K256() *Curve {
 	k256Initonce.Do(k256Init)
@@ -610,6 +825,20 @@ func p256Init() {
 	}
 }
 
+// P384 returns the NIST P-384 curve
+func P384() *Curve {
+	p384Initonce.Do(p384Init)
+	return &p384
+}
+
+func p384Init() {
+	p384 = Curve{
+		Scalar: new(ScalarP384).Zero(),
+		Point:  new(PointP384).Identity(),
+		Name:   P384Name,
+	}
+}
+
 func ED25519() *Curve {
 	ed25519Initonce.Do(ed25519Init)
 	return &ed25519
@@ -623,6 +852,20 @@ func ed25519Init() {
 	}
 }
 
+// ED448 returns the edwards448 curve of RFC 8032 with its subgroup of prime order
+func ED448() *Curve {
+	ed448Initonce.Do(ed448Init)
+	return &ed448
+}
+
+func ed448Init() {
+	ed448 = Curve{
+		Scalar: new(ScalarEd448).Zero(),
+		Point:  new(PointEd448).Identity(),
+		Name:   ED448Name,
+	}
+}
+
 func PALLAS() *Curve {
 	pallasInitonce.Do(pallasInit)
 	return &pallas
@@ -636,6 +879,34 @@ func pallasInit() {
 	}
 }
 
+// VESTA returns the Vesta curve of the Pasta cycle
+func VESTA() *Curve {
+	vestaInitonce.Do(vestaInit)
+	return &vesta
+}
+
+func vestaInit() {
+	vesta = Curve{
+		Scalar: new(ScalarVesta).Zero(),
+		Point:  new(PointVesta).Identity(),
+		Name:   VestaName,
+	}
+}
+
+// RISTRETTO255 returns the prime order group ristretto255 of RFC 9496
+func RISTRETTO255() *Curve {
+	ristretto255Initonce.Do(ristretto255Init)
+	return &ristretto255
+}
+
+func ristretto255Init() {
+	ristretto255 = Curve{
+		Scalar: new(ScalarRistretto255).Zero(),
+		Point:  new(PointRistretto255).Identity(),
+		Name:   RISTRETTO255Name,
+	}
+}
+
 // https://tools.ietf.org/html/draft-irtf-cfrg-hash-to-curve-11#appendix-G.2.1
 func osswu3mod4(u *big.Int, p *sswuParams) (x, y *big.Int) {
 	params := p.Params
@@ -116,6 +116,49 @@ func (a EcPoint) IsIdentity() bool {
 	return (x & y) == 1
 }
 
+// torsionFreeCurve is implemented by the elliptic.Curve types whose group has
+// a cofactor, such as the ed25519 curve of the v1 sharing package
+type torsionFreeCurve interface {
+	IsTorsionFree(x, y *big.Int) bool
+}
+
+// IsTorsionFree returns true if this Point is on the curve and in its prime order subgroup
+func (a EcPoint) IsTorsionFree() bool {
+	if !a.IsOnCurve() {
+		return false
+	}
+	if c, ok := a.Curve.(torsionFreeCurve); ok {
+		return c.IsTorsionFree(a.X, a.Y)
+	}
+	return true
+}
+
+// peerPointCurve is implemented by the elliptic.Curve types that encode points in their own way,
+// such as the ed25519 curve of the v1 sharing package, to decode them strictly
+type peerPointCurve interface {
+	PeerPoint(x, y *big.Int) (Point, error)
+}
+
+// PeerPoint converts this EcPoint, received from another party, to a Point of the curve with the
+// same name through FromAffineCompressedStrict. It fails unless the point is on the curve, in its
+// prime order subgroup and not the identity.
+func (a EcPoint) PeerPoint() (Point, error) {
+	if a.Curve == nil || a.X == nil || a.Y == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if c, ok := a.Curve.(peerPointCurve); ok {
+		return c.PeerPoint(a.X, a.Y)
+	}
+	if a.IsIdentity() {
+		return nil, fmt.Errorf("point is the identity")
+	}
+	p, err := a.ToPoint()
+	if err != nil {
+		return nil, err
+	}
+	return GetCurveByName(p.CurveName()).PeerPoint(p)
+}
+
 // Equals return true if a + b have the same x,y coordinates
 func (a EcPoint) Equals(b *EcPoint) bool {
 	if !sameCurve(&a, b) {
@@ -230,6 +273,40 @@ func PointFromBytesUncompressed(curve elliptic.Curve, b []byte) (*EcPoint, error
 	return p, nil
 }
 
+// NewEcPoint converts a Point of a short Weierstrass curve supported by EcPoint
+func NewEcPoint(p Point) (*EcPoint, error) {
+	if p == nil {
+		return nil, internal.ErrNilArguments
+	}
+	mapper, ok := curveMapper[p.CurveName()]
+	if !ok {
+		return nil, fmt.Errorf("unsupported curve %s", p.CurveName())
+	}
+	curve := mapper()
+	fieldSize := internal.CalcFieldSize(curve)
+	b := p.ToAffineUncompressed()
+	if len(b) != 2*fieldSize+1 {
+		return nil, fmt.Errorf("invalid number of bytes")
+	}
+	return &EcPoint{
+		Curve: curve,
+		X:     new(big.Int).SetBytes(b[1 : fieldSize+1]),
+		Y:     new(big.Int).SetBytes(b[fieldSize+1:]),
+	}, nil
+}
+
+// ToPoint converts this EcPoint to a Point of the curve with the same name
+func (a EcPoint) ToPoint() (Point, error) {
+	if a.Curve == nil || a.X == nil || a.Y == nil {
+		return nil, internal.ErrNilArguments
+	}
+	curve := GetCurveByName(a.Curve.Params().Name)
+	if curve == nil {
+		return nil, fmt.Errorf("unsupported curve %s", a.Curve.Params().Name)
+	}
+	return curve.Point.Set(a.X, a.Y)
+}
+
 // sameCurve determines if points a,b appear to be from the same curve
 func sameCurve(a, b *EcPoint) bool {
 	// Handle identical pointers and double-nil
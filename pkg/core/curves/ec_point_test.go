@@ -9,6 +9,7 @@ package curves
 import (
 	"bytes"
 	"crypto/elliptic"
+	crand "crypto/rand"
 	"math/big"
 	"testing"
 
@@ -368,3 +369,47 @@ func TestEquals(t *testing.T) {
 		})
 	}
 }
+
+func TestEcPointToPointRoundTrip(t *testing.T) {
+	for _, curve := range []*Curve{K256(), P256()} {
+		t.Run(curve.Name, func(t *testing.T) {
+			for _, p := range []Point{curve.Point.Random(crand.Reader), curve.NewGeneratorPoint(), curve.NewIdentityPoint()} {
+				ecPoint, err := NewEcPoint(p)
+				require.NoError(t, err)
+				require.True(t, ecPoint.IsValid())
+				require.Equal(t, curve.Name, ecPoint.Curve.Params().Name)
+
+				q, err := ecPoint.ToPoint()
+				require.NoError(t, err)
+				require.True(t, p.Equal(q))
+			}
+		})
+	}
+
+	_, err := NewEcPoint(ED25519().NewGeneratorPoint())
+	require.Error(t, err)
+	_, err = NewEcPoint(nil)
+	require.Error(t, err)
+	_, err = EcPoint{Curve: elliptic.P256(), X: core.One, Y: core.Two}.ToPoint()
+	require.Error(t, err)
+}
+
+func TestEcPointPeerPoint(t *testing.T) {
+	for _, curve := range []*Curve{K256(), P256()} {
+		p := curve.Point.Random(crand.Reader)
+		ecPoint, err := NewEcPoint(p)
+		require.NoError(t, err)
+		q, err := ecPoint.PeerPoint()
+		require.NoError(t, err, curve.Name)
+		require.True(t, p.Equal(q), curve.Name)
+
+		identity, err := NewEcPoint(curve.NewIdentityPoint())
+		require.NoError(t, err)
+		_, err = identity.PeerPoint()
+		require.Error(t, err, curve.Name)
+	}
+	_, err := EcPoint{Curve: elliptic.P256(), X: core.One, Y: core.Two}.PeerPoint()
+	require.Error(t, err)
+	_, err = EcPoint{}.PeerPoint()
+	require.Error(t, err)
+}
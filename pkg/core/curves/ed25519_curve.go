@@ -20,6 +20,7 @@ import (
 	ed "github.com/bwesterb/go-ristretto/edwards25519"
 
 	"github.com/TEENet-io/kryptology/internal"
+	"github.com/TEENet-io/kryptology/pkg/core/curves/native"
 )
 
 type ScalarEd25519 struct {
@@ -32,6 +33,9 @@ type PointEd25519 struct {
 
 var scOne, _ = edwards25519.NewScalar().SetCanonicalBytes([]byte{1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0})
 
+// ed25519OrderMinusOne is ℓ-1, the largest canonical scalar
+var ed25519OrderMinusOne = edwards25519.NewScalar().Negate(scOne)
+
 func (s *ScalarEd25519) Random(reader io.Reader) Scalar {
 	if reader == nil {
 		return nil
@@ -266,6 +270,26 @@ func (s *ScalarEd25519) SetBytesWide(bytes []byte) (Scalar, error) {
 	return &ScalarEd25519{value}, nil
 }
 
+func (s *ScalarEd25519) BytesBE() []byte {
+	return internal.ReverseScalarBytes(s.Bytes())
+}
+
+func (s *ScalarEd25519) BytesLE() []byte {
+	return s.Bytes()
+}
+
+func (s *ScalarEd25519) SetBytesBE(bytes []byte) (Scalar, error) {
+	return s.SetBytes(internal.ReverseScalarBytes(bytes))
+}
+
+func (s *ScalarEd25519) SetBytesLE(bytes []byte) (Scalar, error) {
+	return s.SetBytes(bytes)
+}
+
+func (s *ScalarEd25519) SetBytesReduce(bytes []byte) (Scalar, error) {
+	return scalarSetBytesReduce(s, bytes)
+}
+
 // SetBytesClamping uses SetBytesWithClamping of fillipo.io/edwards25519- https://github.com/FiloSottile/edwards25519/blob/v1.0.0-rc.1/scalar.go#L135
 // which applies the buffer pruning described in RFC 8032, Section 5.1.5 (also known as clamping)
 // and sets bytes to the result. The input must be 32-byte long, and it is not modified.
@@ -377,6 +401,22 @@ func (p *PointEd25519) Hash(bytes []byte) Point {
 	return toEdwards(m1, signBit)
 }
 
+func (p *PointEd25519) HashWithDst(bytes, dst []byte, hasher *native.EllipticPointHasher) (Point, error) {
+	value, err := hashEd25519(hasher, bytes, dst, 2)
+	if err != nil {
+		return nil, err
+	}
+	return &PointEd25519{value}, nil
+}
+
+func (p *PointEd25519) EncodeWithDst(bytes, dst []byte, hasher *native.EllipticPointHasher) (Point, error) {
+	value, err := hashEd25519(hasher, bytes, dst, 1)
+	if err != nil {
+		return nil, err
+	}
+	return &PointEd25519{value}, nil
+}
+
 func (p *PointEd25519) Identity() Point {
 	return &PointEd25519{
 		value: edwards25519.NewIdentityPoint(),
@@ -581,6 +621,20 @@ func (p *PointEd25519) FromAffineCompressed(inBytes []byte) (Point, error) {
 	return &PointEd25519{value: pt}, nil
 }
 
+func (p *PointEd25519) IsTorsionFree() bool {
+	// [ℓ]P is computed as [ℓ-1]P + P, since ℓ is not a canonical scalar
+	t := edwards25519.NewIdentityPoint().ScalarMult(ed25519OrderMinusOne, p.value)
+	return t.Add(t, p.value).Equal(edwards25519.NewIdentityPoint()) == 1
+}
+
+func (p *PointEd25519) ClearCofactor() Point {
+	return &PointEd25519{value: edwards25519.NewIdentityPoint().MultByCofactor(p.value)}
+}
+
+func (p *PointEd25519) FromAffineCompressedStrict(bytes []byte) (Point, error) {
+	return fromAffineCompressedStrict(p, bytes)
+}
+
 func (p *PointEd25519) FromAffineUncompressed(inBytes []byte) (Point, error) {
 	if len(inBytes) != 64 {
 		return nil, fmt.Errorf("invalid byte sequence")
@@ -784,3 +838,119 @@ func elligatorEncode(r0 *ed.FieldElement) *ed.FieldElement {
 	cselect(u, u, new(ed.FieldElement).Neg(u), wasSquare)
 	return u
 }
+
+// ed25519SqrtM1 is the nonnegative square root of -1 mod 2^255 - 19
+var ed25519SqrtM1, _ = new(field.Element).SetBytes([]byte{
+	0xb0, 0xa0, 0x0e, 0x4a, 0x27, 0x1b, 0xee, 0xc4, 0x78, 0xe4, 0x2f, 0xad, 0x06, 0x18, 0x43, 0x2f,
+	0xa7, 0xd7, 0xfb, 0x3d, 0x99, 0x00, 0x4d, 0x2b, 0x0b, 0xdf, 0xc1, 0x4f, 0x80, 0x24, 0x83, 0x2b,
+})
+
+// ed25519SqrtNegA2 is sqrt(-486664) with sgn0 = 0, the constant c1 of the rational
+// map from curve25519 to edwards25519 of RFC 9380 Appendix G.2.2
+var ed25519SqrtNegA2, _ = new(field.Element).SetBytes([]byte{
+	0x06, 0x7e, 0x45, 0xff, 0xaa, 0x04, 0x6e, 0xcc, 0x82, 0x1a, 0x7d, 0x4b, 0xd1, 0xd3, 0xa1, 0xc5,
+	0x7e, 0x4f, 0xfc, 0x03, 0xdc, 0x08, 0x7b, 0xd2, 0xbb, 0x06, 0xa0, 0x60, 0xf4, 0xed, 0x26, 0x0f,
+})
+
+// ed25519MontgomeryA is the coefficient A = 486662 of curve25519
+var ed25519MontgomeryA, _ = new(field.Element).SetBytes([]byte{
+	0x06, 0x6d, 0x07, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+})
+
+// hashEd25519 is hash_to_curve of RFC 9380 with the Elligator 2 map to curve25519 followed
+// by the rational map to edwards25519 when count is two, and encode_to_curve when count is one
+func hashEd25519(hasher *native.EllipticPointHasher, msg, dst []byte, count int) (*edwards25519.Point, error) {
+	// L = ceil((ceil(log2(p)) + k) / 8) = 48 for k = 128
+	u, err := hashToField(hasher, msg, dst, count, 48)
+	if err != nil {
+		return nil, err
+	}
+	q := edwards25519.NewIdentityPoint()
+	for _, b := range u {
+		pt, err := mapEll2Ed25519(ed25519FieldFromHash(b))
+		if err != nil {
+			return nil, err
+		}
+		q.Add(q, pt)
+	}
+	// h_eff = 8
+	return q.MultByCofactor(q), nil
+}
+
+// ed25519FieldFromHash reduces the 48 big-endian bytes of hash_to_field
+// as lo + hi * 2^248 where lo is the low 31 bytes and hi the high 17 bytes
+func ed25519FieldFromHash(b []byte) *field.Element {
+	le := internal.ReverseScalarBytes(b)
+	var buf [32]byte
+	copy(buf[:31], le[:31])
+	lo, _ := new(field.Element).SetBytes(buf[:])
+	buf = [32]byte{}
+	copy(buf[:], le[31:])
+	hi, _ := new(field.Element).SetBytes(buf[:])
+	buf = [32]byte{}
+	buf[31] = 1
+	shift, _ := new(field.Element).SetBytes(buf[:])
+	return hi.Multiply(hi, shift).Add(hi, lo)
+}
+
+// ed25519Sqrt returns a square root of a and 1 if a is square, and 0 otherwise
+func ed25519Sqrt(a *field.Element) (*field.Element, int) {
+	// r = a^((p+3)/8) is a root of a or -a
+	r := new(field.Element).Pow22523(a)
+	r.Multiply(r, a)
+	check := new(field.Element).Square(r)
+	negA := new(field.Element).Negate(a)
+	isRoot := check.Equal(a)
+	isNegRoot := check.Equal(negA)
+	r.Select(new(field.Element).Multiply(r, ed25519SqrtM1), r, isNegRoot)
+	return r, isRoot | isNegRoot
+}
+
+// mapEll2Ed25519 is the Elligator 2 map of section 6.7.1 of RFC 9380 to curve25519 with
+// Z = 2 followed by the rational map of section 6.8.2 to edwards25519
+func mapEll2Ed25519(u *field.Element) (*edwards25519.Point, error) {
+	zero := new(field.Element).Zero()
+	one := new(field.Element).One()
+	negA := new(field.Element).Negate(ed25519MontgomeryA)
+
+	// x1 = -A / (1 + Z u^2), or -A if 1 + Z u^2 = 0
+	tv := new(field.Element).Square(u)
+	tv.Add(tv, tv)
+	tv.Add(tv, one)
+	x1 := new(field.Element).Invert(tv)
+	x1.Multiply(x1, negA)
+	x1.Select(negA, x1, tv.Equal(zero))
+	// x2 = -x1 - A
+	x2 := new(field.Element).Subtract(negA, x1)
+
+	y1, e1 := ed25519Sqrt(ed25519MontgomeryRhs(x1))
+	y2, _ := ed25519Sqrt(ed25519MontgomeryRhs(x2))
+	s := new(field.Element).Select(x1, x2, e1)
+	t := new(field.Element).Select(y1, y2, e1)
+	// sgn0(t) = 1 exactly when x1 is used
+	t.Select(t, new(field.Element).Negate(t), 1^t.IsNegative()^e1)
+
+	// (x, y) = (sqrt(-486664) s / t, (s - 1) / (s + 1)), or (0, 1) if t (s + 1) = 0
+	xn := new(field.Element).Multiply(ed25519SqrtNegA2, s)
+	yn := new(field.Element).Subtract(s, one)
+	yd := new(field.Element).Add(s, one)
+	den := new(field.Element).Multiply(t, yd)
+	isExceptional := den.Equal(zero)
+	inv := new(field.Element).Invert(den)
+	x := new(field.Element).Multiply(xn, yd)
+	x.Multiply(x, inv)
+	y := new(field.Element).Multiply(yn, t)
+	y.Multiply(y, inv)
+	x.Select(zero, x, isExceptional)
+	y.Select(one, y, isExceptional)
+	return edwards25519.NewIdentityPoint().SetExtendedCoordinates(x, y, one, new(field.Element).Multiply(x, y))
+}
+
+// ed25519MontgomeryRhs computes x^3 + A x^2 + x of curve25519
+func ed25519MontgomeryRhs(x *field.Element) *field.Element {
+	rhs := new(field.Element).Add(x, ed25519MontgomeryA)
+	rhs.Multiply(rhs, x)
+	rhs.Add(rhs, new(field.Element).One())
+	return rhs.Multiply(rhs, x)
+}
@@ -0,0 +1,915 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curves
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/TEENet-io/kryptology/internal"
+	"github.com/TEENet-io/kryptology/pkg/core/curves/native"
+	"github.com/TEENet-io/kryptology/pkg/core/curves/native/ed448/fp"
+	"github.com/TEENet-io/kryptology/pkg/core/curves/native/ed448/fq"
+)
+
+// Ed448 is the untwisted Edwards curve x^2 + y^2 = 1 - 39081 x^2 y^2 of RFC 8032 over the field of
+// p = 2^448 - 2^224 - 1, whose group has order 4ℓ. Scalars are integers mod ℓ and the generator is
+// the one of RFC 8032, which generates the subgroup of order ℓ.
+// Points use the projective formulas of RFC 8032, which are complete since d is not a square,
+// are hashed with edwards448_XOF:SHAKE256_ELL2_RO_ of RFC 9380 and are encoded in 57 bytes as in RFC 8032.
+
+var ed448D = new(fp.Fp).SetRaw(&[fp.Limbs]uint64{0xffffffffffff6756, 0xffffffffffffffff, 0xffffffffffffffff, 0xfffffffeffffffff, 0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff})
+var ed448Gx = new(fp.Fp).SetRaw(&[fp.Limbs]uint64{0x2626a82bc70cc05e, 0x433b80e18b00938e, 0x12ae1af72ab66511, 0xea6de324a3d3a464, 0x9e146570470f1767, 0x221d15a622bf36da, 0x4f1970c66bed0ded})
+var ed448Gy = new(fp.Fp).SetRaw(&[fp.Limbs]uint64{0x9808795bf230fa14, 0xfdbd132c4ed7c8ad, 0x3ad3ff1ce67c39c4, 0x87789c1e05a0c2d7, 0x4bea73736ca39840, 0x8876203756c9c762, 0x693f46716eb6bc24})
+
+// ed448MontgomeryA is A of curve448 v^2 = u^3 + A u^2 + u which is 4-isogenous to edwards448
+var ed448MontgomeryA = new(fp.Fp).SetUint64(156326)
+
+// ed448Wide is 2^896 mod ℓ to reduce the top two bytes of 114 byte inputs
+var ed448Wide = new(fq.Fq).SetRaw(&[fq.Limbs]uint64{0xe3539257049b9b60, 0x7af32c4bc1b195d9, 0x0d66de2388ea1859, 0xae17cf725ee4d838, 0x1a9cc14ba3c47c44, 0x2052bcb7e4d070af, 0x3402a939f823b729})
+
+const ed448HashDst = "edwards448_XOF:SHAKE256_ELL2_RO_"
+
+// ed448EncodedBytes is the length of the RFC 8032 encodings of points and scalars
+const ed448EncodedBytes = 57
+
+// ed448WideBytes is the length of the SHAKE256 outputs that RFC 8032 reduces mod ℓ
+const ed448WideBytes = 114
+
+type ScalarEd448 struct {
+	value *fq.Fq
+}
+
+func (s *ScalarEd448) Random(reader io.Reader) Scalar {
+	if reader == nil {
+		return nil
+	}
+	var seed [64]byte
+	_, _ = reader.Read(seed[:])
+	return s.Hash(seed[:])
+}
+
+func (s *ScalarEd448) Hash(bytes []byte) Scalar {
+	// L = ceil((ceil(log2(ℓ)) + k) / 8) = 84 for k = 224
+	xof := native.ExpandMsgXof(native.EllipticPointHasherShake256(), bytes, []byte(ed448HashDst), 84)
+	var t [fq.WideBytes]byte
+	copy(t[:84], internal.ReverseScalarBytes(xof))
+	return &ScalarEd448{
+		value: new(fq.Fq).SetBytesWide(&t),
+	}
+}
+
+func (s *ScalarEd448) Zero() Scalar {
+	return &ScalarEd448{
+		value: new(fq.Fq).SetZero(),
+	}
+}
+
+func (s *ScalarEd448) One() Scalar {
+	return &ScalarEd448{
+		value: new(fq.Fq).SetOne(),
+	}
+}
+
+func (s *ScalarEd448) IsZero() bool {
+	return s.value.IsZero()
+}
+
+func (s *ScalarEd448) IsOne() bool {
+	return s.value.IsOne()
+}
+
+func (s *ScalarEd448) IsOdd() bool {
+	return s.value.IsOdd()
+}
+
+func (s *ScalarEd448) IsEven() bool {
+	return !s.value.IsOdd()
+}
+
+func (s *ScalarEd448) New(value int) Scalar {
+	v := big.NewInt(int64(value))
+	return &ScalarEd448{
+		value: new(fq.Fq).SetBigInt(v),
+	}
+}
+
+func (s *ScalarEd448) Cmp(rhs Scalar) int {
+	r, ok := rhs.(*ScalarEd448)
+	if ok {
+		return s.value.Cmp(r.value)
+	} else {
+		return -2
+	}
+}
+
+func (s *ScalarEd448) Square() Scalar {
+	return &ScalarEd448{
+		value: new(fq.Fq).Square(s.value),
+	}
+}
+
+func (s *ScalarEd448) Double() Scalar {
+	return &ScalarEd448{
+		value: new(fq.Fq).Double(s.value),
+	}
+}
+
+func (s *ScalarEd448) Invert() (Scalar, error) {
+	value, wasInverted := new(fq.Fq).Invert(s.value)
+	if !wasInverted {
+		return nil, fmt.Errorf("inverse doesn't exist")
+	}
+	return &ScalarEd448{
+		value,
+	}, nil
+}
+
+func (s *ScalarEd448) Sqrt() (Scalar, error) {
+	value, wasSquare := new(fq.Fq).Sqrt(s.value)
+	if !wasSquare {
+		return nil, fmt.Errorf("not a square")
+	}
+	return &ScalarEd448{
+		value,
+	}, nil
+}
+
+func (s *ScalarEd448) Cube() Scalar {
+	value := new(fq.Fq).Square(s.value)
+	value.Mul(value, s.value)
+	return &ScalarEd448{
+		value,
+	}
+}
+
+func (s *ScalarEd448) Add(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarEd448)
+	if ok {
+		return &ScalarEd448{
+			value: new(fq.Fq).Add(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarEd448) Sub(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarEd448)
+	if ok {
+		return &ScalarEd448{
+			value: new(fq.Fq).Sub(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarEd448) Mul(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarEd448)
+	if ok {
+		return &ScalarEd448{
+			value: new(fq.Fq).Mul(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarEd448) MulAdd(y, z Scalar) Scalar {
+	return s.Mul(y).Add(z)
+}
+
+func (s *ScalarEd448) Div(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarEd448)
+	if ok {
+		v, wasInverted := new(fq.Fq).Invert(r.value)
+		if !wasInverted {
+			return nil
+		}
+		v.Mul(v, s.value)
+		return &ScalarEd448{value: v}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarEd448) Neg() Scalar {
+	return &ScalarEd448{
+		value: new(fq.Fq).Neg(s.value),
+	}
+}
+
+func (s *ScalarEd448) SetBigInt(v *big.Int) (Scalar, error) {
+	if v == nil {
+		return nil, fmt.Errorf("'v' cannot be nil")
+	}
+	return &ScalarEd448{
+		value: new(fq.Fq).SetBigInt(v),
+	}, nil
+}
+
+func (s *ScalarEd448) BigInt() *big.Int {
+	return s.value.BigInt()
+}
+
+// Bytes returns the 57 byte little-endian encoding of RFC 8032
+func (s *ScalarEd448) Bytes() []byte {
+	var out [ed448EncodedBytes]byte
+	t := s.value.Bytes()
+	copy(out[:], t[:])
+	return out[:]
+}
+
+// SetBytes takes the 57 byte little-endian encoding of a reduced scalar
+func (s *ScalarEd448) SetBytes(bytes []byte) (Scalar, error) {
+	if len(bytes) != ed448EncodedBytes {
+		return nil, fmt.Errorf("invalid length")
+	}
+	if bytes[fq.Bytes] != 0 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	var seq [fq.Bytes]byte
+	copy(seq[:], bytes)
+	value, err := new(fq.Fq).SetBytes(&seq)
+	if err != nil {
+		return nil, err
+	}
+	return &ScalarEd448{
+		value,
+	}, nil
+}
+
+// SetBytesWide reduces 114 little-endian bytes, e.g. the SHAKE256 outputs of RFC 8032
+func (s *ScalarEd448) SetBytesWide(bytes []byte) (Scalar, error) {
+	if len(bytes) != ed448WideBytes {
+		return nil, fmt.Errorf("invalid length")
+	}
+	var seq [fq.WideBytes]byte
+	copy(seq[:], bytes)
+	value := new(fq.Fq).SetBytesWide(&seq)
+	hi := new(fq.Fq).SetUint64(uint64(bytes[fq.WideBytes]) | uint64(bytes[fq.WideBytes+1])<<8)
+	hi.Mul(hi, ed448Wide)
+	return &ScalarEd448{
+		value: value.Add(value, hi),
+	}, nil
+}
+
+func (s *ScalarEd448) BytesBE() []byte {
+	return internal.ReverseScalarBytes(s.Bytes())
+}
+
+func (s *ScalarEd448) BytesLE() []byte {
+	return s.Bytes()
+}
+
+func (s *ScalarEd448) SetBytesBE(bytes []byte) (Scalar, error) {
+	return s.SetBytes(internal.ReverseScalarBytes(bytes))
+}
+
+func (s *ScalarEd448) SetBytesLE(bytes []byte) (Scalar, error) {
+	return s.SetBytes(bytes)
+}
+
+func (s *ScalarEd448) SetBytesReduce(bytes []byte) (Scalar, error) {
+	return scalarSetBytesReduce(s, bytes)
+}
+
+// SetBytesClamping applies the buffer pruning of RFC 8032, Section 5.2.5 to the
+// 57 byte input and sets the result reduced mod ℓ. The input is not modified.
+func (s *ScalarEd448) SetBytesClamping(bytes []byte) (Scalar, error) {
+	if len(bytes) != ed448EncodedBytes {
+		return nil, fmt.Errorf("invalid length")
+	}
+	var seq [ed448WideBytes]byte
+	copy(seq[:], bytes)
+	seq[0] &= 0xFC
+	seq[ed448EncodedBytes-2] |= 0x80
+	seq[ed448EncodedBytes-1] = 0
+	return s.SetBytesWide(seq[:])
+}
+
+func (s *ScalarEd448) Point() Point {
+	return new(PointEd448).Identity()
+}
+
+func (s *ScalarEd448) Clone() Scalar {
+	return &ScalarEd448{
+		value: new(fq.Fq).Set(s.value),
+	}
+}
+
+func (s *ScalarEd448) MarshalBinary() ([]byte, error) {
+	return scalarMarshalBinary(s)
+}
+
+func (s *ScalarEd448) UnmarshalBinary(input []byte) error {
+	sc, err := scalarUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarEd448)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.value = ss.value
+	return nil
+}
+
+func (s *ScalarEd448) MarshalText() ([]byte, error) {
+	return scalarMarshalText(s)
+}
+
+func (s *ScalarEd448) UnmarshalText(input []byte) error {
+	sc, err := scalarUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarEd448)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.value = ss.value
+	return nil
+}
+
+func (s *ScalarEd448) MarshalJSON() ([]byte, error) {
+	return scalarMarshalJson(s)
+}
+
+func (s *ScalarEd448) UnmarshalJSON(input []byte) error {
+	sc, err := scalarUnmarshalJson(input)
+	if err != nil {
+		return err
+	}
+	S, ok := sc.(*ScalarEd448)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	s.value = S.value
+	return nil
+}
+
+type PointEd448 struct {
+	value *ed448Point
+}
+
+func (p *PointEd448) Random(reader io.Reader) Point {
+	var seed [64]byte
+	_, _ = reader.Read(seed[:])
+	return p.Hash(seed[:])
+}
+
+func (p *PointEd448) Hash(bytes []byte) Point {
+	value, err := hashEd448(native.EllipticPointHasherShake256(), bytes, []byte(ed448HashDst), 2)
+	if err != nil {
+		return nil
+	}
+	return &PointEd448{value}
+}
+
+func (p *PointEd448) HashWithDst(bytes, dst []byte, hasher *native.EllipticPointHasher) (Point, error) {
+	value, err := hashEd448(hasher, bytes, dst, 2)
+	if err != nil {
+		return nil, err
+	}
+	return &PointEd448{value}, nil
+}
+
+func (p *PointEd448) EncodeWithDst(bytes, dst []byte, hasher *native.EllipticPointHasher) (Point, error) {
+	value, err := hashEd448(hasher, bytes, dst, 1)
+	if err != nil {
+		return nil, err
+	}
+	return &PointEd448{value}, nil
+}
+
+func (p *PointEd448) Identity() Point {
+	return &PointEd448{new(ed448Point).Identity()}
+}
+
+func (p *PointEd448) Generator() Point {
+	return &PointEd448{new(ed448Point).Generator()}
+}
+
+func (p *PointEd448) IsIdentity() bool {
+	return p.value.IsIdentity()
+}
+
+// IsNegative returns true if x is odd, which is the sign bit of the RFC 8032 encoding
+func (p *PointEd448) IsNegative() bool {
+	x, _ := p.value.ToAffine()
+	return x.IsOdd()
+}
+
+func (p *PointEd448) IsOnCurve() bool {
+	return p.value.IsOnCurve()
+}
+
+func (p *PointEd448) Double() Point {
+	return &PointEd448{new(ed448Point).Double(p.value)}
+}
+
+func (p *PointEd448) Scalar() Scalar {
+	return new(ScalarEd448).Zero()
+}
+
+func (p *PointEd448) Neg() Point {
+	return &PointEd448{new(ed448Point).Neg(p.value)}
+}
+
+func (p *PointEd448) Add(rhs Point) Point {
+	r, ok := rhs.(*PointEd448)
+	if !ok {
+		return nil
+	}
+	return &PointEd448{new(ed448Point).Add(p.value, r.value)}
+}
+
+func (p *PointEd448) Sub(rhs Point) Point {
+	r, ok := rhs.(*PointEd448)
+	if !ok {
+		return nil
+	}
+	return &PointEd448{new(ed448Point).Sub(p.value, r.value)}
+}
+
+func (p *PointEd448) Mul(rhs Scalar) Point {
+	s, ok := rhs.(*ScalarEd448)
+	if !ok {
+		return nil
+	}
+	return &PointEd448{new(ed448Point).Mul(p.value, s.value)}
+}
+
+func (p *PointEd448) Equal(rhs Point) bool {
+	r, ok := rhs.(*PointEd448)
+	if !ok {
+		return false
+	}
+	return p.value.Equal(r.value)
+}
+
+func (p *PointEd448) Set(x, y *big.Int) (Point, error) {
+	if x == nil || y == nil {
+		return nil, internal.ErrNilArguments
+	}
+	value, err := new(ed448Point).SetAffine(new(fp.Fp).SetBigInt(x), new(fp.Fp).SetBigInt(y))
+	if err != nil {
+		return nil, err
+	}
+	return &PointEd448{value}, nil
+}
+
+// ToAffineCompressed returns the 57 byte encoding of RFC 8032, Section 5.2.2
+// which is y in little-endian with the sign of x in the top bit of the last byte
+func (p *PointEd448) ToAffineCompressed() []byte {
+	var out [ed448EncodedBytes]byte
+	x, y := p.value.ToAffine()
+	arr := y.Bytes()
+	copy(out[:], arr[:])
+	if x.IsOdd() {
+		out[fp.Bytes] = 0x80
+	}
+	return out[:]
+}
+
+// ToAffineUncompressed returns x || y in little-endian
+func (p *PointEd448) ToAffineUncompressed() []byte {
+	var out [2 * fp.Bytes]byte
+	x, y := p.value.ToAffine()
+	arr := x.Bytes()
+	copy(out[:fp.Bytes], arr[:])
+	arr = y.Bytes()
+	copy(out[fp.Bytes:], arr[:])
+	return out[:]
+}
+
+// FromAffineCompressed decodes a point as in RFC 8032, Section 5.2.3
+func (p *PointEd448) FromAffineCompressed(bytes []byte) (Point, error) {
+	if len(bytes) != ed448EncodedBytes {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	sign := bytes[fp.Bytes] >> 7
+	if bytes[fp.Bytes]&0x7F != 0 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	var arr [fp.Bytes]byte
+	copy(arr[:], bytes)
+	y, err := new(fp.Fp).SetBytes(&arr)
+	if err != nil {
+		return nil, err
+	}
+	// x^2 = (y^2 - 1) / (d y^2 - 1)
+	one := new(fp.Fp).SetOne()
+	y2 := new(fp.Fp).Square(y)
+	u := new(fp.Fp).Sub(y2, one)
+	v := new(fp.Fp).Mul(ed448D, y2)
+	v.Sub(v, one)
+	// d is not a square so v is not zero
+	v.Invert(v)
+	x, wasSquare := new(fp.Fp).Sqrt(u.Mul(u, v))
+	if !wasSquare {
+		return nil, fmt.Errorf("point is not on the curve")
+	}
+	if x.IsZero() && sign == 1 {
+		return nil, fmt.Errorf("invalid sign bit")
+	}
+	if x.IsOdd() != (sign == 1) {
+		x.Neg(x)
+	}
+	value, err := new(ed448Point).SetAffine(x, y)
+	if err != nil {
+		return nil, err
+	}
+	return &PointEd448{value}, nil
+}
+
+func (p *PointEd448) IsTorsionFree() bool {
+	// [ℓ]P is computed as [ℓ-1]P + P, since ℓ is not a canonical scalar
+	t := new(ed448Point).Mul(p.value, new(fq.Fq).Neg(new(fq.Fq).SetOne()))
+	return t.Add(t, p.value).IsIdentity()
+}
+
+func (p *PointEd448) ClearCofactor() Point {
+	// The cofactor is 4
+	t := new(ed448Point).Double(p.value)
+	return &PointEd448{t.Double(t)}
+}
+
+func (p *PointEd448) FromAffineCompressedStrict(bytes []byte) (Point, error) {
+	return fromAffineCompressedStrict(p, bytes)
+}
+
+func (p *PointEd448) FromAffineUncompressed(bytes []byte) (Point, error) {
+	if len(bytes) != 2*fp.Bytes {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	var arr [fp.Bytes]byte
+	copy(arr[:], bytes[:fp.Bytes])
+	x, err := new(fp.Fp).SetBytes(&arr)
+	if err != nil {
+		return nil, err
+	}
+	copy(arr[:], bytes[fp.Bytes:])
+	y, err := new(fp.Fp).SetBytes(&arr)
+	if err != nil {
+		return nil, err
+	}
+	value, err := new(ed448Point).SetAffine(x, y)
+	if err != nil {
+		return nil, err
+	}
+	return &PointEd448{value}, nil
+}
+
+func (p *PointEd448) CurveName() string {
+	return ED448Name
+}
+
+func (p *PointEd448) SumOfProducts(points []Point, scalars []Scalar) Point {
+	if len(points) != len(scalars) {
+		return nil
+	}
+	result := new(ed448Point).Identity()
+	for i, pt := range points {
+		pp, ok := pt.(*PointEd448)
+		if !ok {
+			return nil
+		}
+		s, ok := scalars[i].(*ScalarEd448)
+		if !ok {
+			return nil
+		}
+		result.Add(result, new(ed448Point).Mul(pp.value, s.value))
+	}
+	return &PointEd448{result}
+}
+
+// X returns the affine x-coordinate
+func (p *PointEd448) X() *fp.Fp {
+	x, _ := p.value.ToAffine()
+	return x
+}
+
+// Y returns the affine y-coordinate
+func (p *PointEd448) Y() *fp.Fp {
+	_, y := p.value.ToAffine()
+	return y
+}
+
+func (p *PointEd448) MarshalBinary() ([]byte, error) {
+	return pointMarshalBinary(p)
+}
+
+func (p *PointEd448) UnmarshalBinary(input []byte) error {
+	pt, err := pointUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointEd448)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.value = ppt.value
+	return nil
+}
+
+func (p *PointEd448) MarshalText() ([]byte, error) {
+	return pointMarshalText(p)
+}
+
+func (p *PointEd448) UnmarshalText(input []byte) error {
+	pt, err := pointUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointEd448)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.value = ppt.value
+	return nil
+}
+
+func (p *PointEd448) MarshalJSON() ([]byte, error) {
+	return pointMarshalJson(p)
+}
+
+func (p *PointEd448) UnmarshalJSON(input []byte) error {
+	pt, err := pointUnmarshalJson(input)
+	if err != nil {
+		return err
+	}
+	P, ok := pt.(*PointEd448)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	p.value = P.value
+	return nil
+}
+
+// ed448Point is a point in projective coordinates (X : Y : Z) with x = X/Z and y = Y/Z.
+// The identity is (0 : 1 : 1).
+type ed448Point struct {
+	x, y, z fp.Fp
+}
+
+func (p *ed448Point) Identity() *ed448Point {
+	p.x.SetZero()
+	p.y.SetOne()
+	p.z.SetOne()
+	return p
+}
+
+func (p *ed448Point) Generator() *ed448Point {
+	p.x.Set(ed448Gx)
+	p.y.Set(ed448Gy)
+	p.z.SetOne()
+	return p
+}
+
+func (p *ed448Point) Set(other *ed448Point) *ed448Point {
+	*p = *other
+	return p
+}
+
+// SetAffine sets p to (x, y) if it is on the curve
+func (p *ed448Point) SetAffine(x, y *fp.Fp) (*ed448Point, error) {
+	p.x.Set(x)
+	p.y.Set(y)
+	p.z.SetOne()
+	if !p.IsOnCurve() {
+		return nil, fmt.Errorf("point is not on the curve")
+	}
+	return p, nil
+}
+
+func (p *ed448Point) IsIdentity() bool {
+	return p.x.IsZero() && p.y.Equal(&p.z)
+}
+
+// IsOnCurve checks (X^2 + Y^2) Z^2 = Z^4 + d X^2 Y^2 and Z != 0
+func (p *ed448Point) IsOnCurve() bool {
+	x2 := new(fp.Fp).Square(&p.x)
+	y2 := new(fp.Fp).Square(&p.y)
+	z2 := new(fp.Fp).Square(&p.z)
+	lhs := new(fp.Fp).Add(x2, y2)
+	lhs.Mul(lhs, z2)
+	rhs := new(fp.Fp).Mul(x2, y2)
+	rhs.Mul(rhs, ed448D)
+	rhs.Add(rhs, z2.Square(z2))
+	return !p.z.IsZero() && lhs.Equal(rhs)
+}
+
+func (p *ed448Point) ToAffine() (*fp.Fp, *fp.Fp) {
+	zInv, _ := new(fp.Fp).Invert(&p.z)
+	return new(fp.Fp).Mul(&p.x, zInv), new(fp.Fp).Mul(&p.y, zInv)
+}
+
+func (p *ed448Point) Equal(other *ed448Point) bool {
+	// X1 Z2 == X2 Z1 and Y1 Z2 == Y2 Z1
+	lhs := new(fp.Fp).Mul(&p.x, &other.z)
+	rhs := new(fp.Fp).Mul(&other.x, &p.z)
+	x := lhs.Equal(rhs)
+	lhs.Mul(&p.y, &other.z)
+	rhs.Mul(&other.y, &p.z)
+	return x && lhs.Equal(rhs)
+}
+
+func (p *ed448Point) Neg(other *ed448Point) *ed448Point {
+	p.x.Neg(&other.x)
+	p.y.Set(&other.y)
+	p.z.Set(&other.z)
+	return p
+}
+
+func (p *ed448Point) Sub(lhs, rhs *ed448Point) *ed448Point {
+	return p.Add(lhs, new(ed448Point).Neg(rhs))
+}
+
+// Add sets p = lhs + rhs as in RFC 8032, Section 5.2.4
+func (p *ed448Point) Add(lhs, rhs *ed448Point) *ed448Point {
+	a := new(fp.Fp).Mul(&lhs.z, &rhs.z) // A = Z1 * Z2
+	b := new(fp.Fp).Square(a)           // B = A^2
+	c := new(fp.Fp).Mul(&lhs.x, &rhs.x) // C = X1 * X2
+	d := new(fp.Fp).Mul(&lhs.y, &rhs.y) // D = Y1 * Y2
+	e := new(fp.Fp).Mul(ed448D, c)      // E = d * C * D
+	e.Mul(e, d)
+	f := new(fp.Fp).Sub(b, e)           // F = B - E
+	g := new(fp.Fp).Add(b, e)           // G = B + E
+	h := new(fp.Fp).Add(&lhs.x, &lhs.y) // H = (X1 + Y1) * (X2 + Y2)
+	h.Mul(h, new(fp.Fp).Add(&rhs.x, &rhs.y))
+
+	// X3 = A * F * (H - C - D)
+	h.Sub(h, c)
+	h.Sub(h, d)
+	p.x.Mul(a, f)
+	p.x.Mul(&p.x, h)
+	// Y3 = A * G * (D - C)
+	d.Sub(d, c)
+	p.y.Mul(a, g)
+	p.y.Mul(&p.y, d)
+	// Z3 = F * G
+	p.z.Mul(f, g)
+	return p
+}
+
+// Double sets p = 2 * other as in RFC 8032, Section 5.2.4
+func (p *ed448Point) Double(other *ed448Point) *ed448Point {
+	b := new(fp.Fp).Add(&other.x, &other.y) // B = (X1 + Y1)^2
+	b.Square(b)
+	c := new(fp.Fp).Square(&other.x) // C = X1^2
+	d := new(fp.Fp).Square(&other.y) // D = Y1^2
+	e := new(fp.Fp).Add(c, d)        // E = C + D
+	h := new(fp.Fp).Square(&other.z) // H = Z1^2
+	j := new(fp.Fp).Double(h)        // J = E - 2 * H
+	j.Sub(e, j)
+
+	// X3 = (B - E) * J
+	p.x.Sub(b, e)
+	p.x.Mul(&p.x, j)
+	// Y3 = E * (C - D)
+	p.y.Sub(c, d)
+	p.y.Mul(&p.y, e)
+	// Z3 = E * J
+	p.z.Mul(e, j)
+	return p
+}
+
+// Mul sets p = scalar * point with a 4-bit fixed window and constant-time table lookups
+func (p *ed448Point) Mul(point *ed448Point, scalar *fq.Fq) *ed448Point {
+	bytes := scalar.Bytes()
+	var precomputed [16]ed448Point
+	precomputed[0].Identity()
+	precomputed[1].Set(point)
+	for i := 2; i < 16; i += 2 {
+		precomputed[i].Double(&precomputed[i>>1])
+		precomputed[i+1].Add(&precomputed[i], point)
+	}
+	result := new(ed448Point).Identity()
+	entry := new(ed448Point)
+	for i := 2*fq.Bytes - 1; i >= 0; i-- {
+		for j := 0; j < 4; j++ {
+			result.Double(result)
+		}
+		window := int(bytes[i>>1]>>(4*(i&1))) & 0x0F
+		entry.Identity()
+		for j := 1; j < 16; j++ {
+			entry.CMove(entry, &precomputed[j], ctEqual(j, window))
+		}
+		result.Add(result, entry)
+	}
+	return p.Set(result)
+}
+
+func (p *ed448Point) CMove(lhs, rhs *ed448Point, condition int) *ed448Point {
+	p.x.CMove(&lhs.x, &rhs.x, condition)
+	p.y.CMove(&lhs.y, &rhs.y, condition)
+	p.z.CMove(&lhs.z, &rhs.z, condition)
+	return p
+}
+
+// hashEd448 is hash_to_curve of RFC 9380 with the Elligator 2 map to curve448 followed
+// by the 4-isogeny to edwards448 when count is two, and encode_to_curve when count is one
+func hashEd448(hasher *native.EllipticPointHasher, msg, dst []byte, count int) (*ed448Point, error) {
+	// L = ceil((ceil(log2(p)) + k) / 8) = 84 for k = 224
+	u, err := hashToField(hasher, msg, dst, count, 84)
+	if err != nil {
+		return nil, err
+	}
+	q := new(ed448Point).Identity()
+	for _, b := range u {
+		var t [fp.WideBytes]byte
+		copy(t[:], internal.ReverseScalarBytes(b))
+		q.Add(q, mapEll2Ed448(new(fp.Fp).SetBytesWide(&t)))
+	}
+	// h_eff = 4
+	q.Double(q)
+	return q.Double(q), nil
+}
+
+// mapEll2Ed448 is the Elligator 2 map of section 6.7.1 of RFC 9380 to curve448 with Z = -1
+// followed by the 4-isogeny of RFC 7748 to edwards448
+func mapEll2Ed448(u *fp.Fp) *ed448Point {
+	one := new(fp.Fp).SetOne()
+	negA := new(fp.Fp).Neg(ed448MontgomeryA)
+
+	// x1 = -A / (1 + Z u^2), or -A if 1 + Z u^2 = 0
+	tv := new(fp.Fp).Square(u)
+	tv.Sub(one, tv)
+	x1, _ := new(fp.Fp).Invert(tv)
+	x1.Mul(x1, negA)
+	x1.CMove(x1, negA, bool2int[tv.IsZero()])
+	gx1 := ed448MontgomeryRhs(x1)
+	x2 := new(fp.Fp).Sub(negA, x1)
+	gx2 := ed448MontgomeryRhs(x2)
+
+	// y = sqrt(gx1) with sgn0(y) = 1, else y = sqrt(gx2) with sgn0(y) = 0
+	y1, e1 := new(fp.Fp).Sqrt(gx1)
+	y2, _ := new(fp.Fp).Sqrt(gx2)
+	s := new(fp.Fp).CMove(x2, x1, bool2int[e1])
+	t := new(fp.Fp).CMove(y2, y1, bool2int[e1])
+	negT := new(fp.Fp).Neg(t)
+	t.CMove(t, negT, bool2int[t.IsOdd() != e1])
+
+	return ed448IsogenyMap(s, t)
+}
+
+// ed448MontgomeryRhs returns u^3 + A u^2 + u
+func ed448MontgomeryRhs(u *fp.Fp) *fp.Fp {
+	rhs := new(fp.Fp).Add(u, ed448MontgomeryA)
+	rhs.Mul(rhs, u)
+	rhs.Add(rhs, new(fp.Fp).SetOne())
+	return rhs.Mul(rhs, u)
+}
+
+// ed448IsogenyMap maps (u, v) on curve448 to edwards448 with the 4-isogeny of RFC 7748, Section 4.2:
+// x = 4 v (u^2 - 1) / (u^4 - 2 u^2 + 4 v^2 + 1)
+// y = -(u^5 - 2 u^3 - 4 u v^2 + u) / (u^5 - 2 u^2 v^2 - 2 u^3 - 2 v^2 + u)
+// and to the identity if a denominator is zero
+func ed448IsogenyMap(u, v *fp.Fp) *ed448Point {
+	one := new(fp.Fp).SetOne()
+	u2 := new(fp.Fp).Square(u)
+	u3 := new(fp.Fp).Mul(u2, u)
+	u4 := new(fp.Fp).Square(u2)
+	u5 := new(fp.Fp).Mul(u4, u)
+	v2 := new(fp.Fp).Square(v)
+
+	xn := new(fp.Fp).Sub(u2, one)
+	xn.Mul(xn, v)
+	xn.Double(xn)
+	xn.Double(xn)
+
+	xd := new(fp.Fp).Sub(u4, new(fp.Fp).Double(u2))
+	xd.Add(xd, one)
+	t := new(fp.Fp).Double(v2)
+	xd.Add(xd, t.Double(t))
+
+	u3x2 := new(fp.Fp).Double(u3)
+	uv2x4 := new(fp.Fp).Mul(u, v2)
+	uv2x4.Double(uv2x4)
+	uv2x4.Double(uv2x4)
+	yn := new(fp.Fp).Sub(u5, u3x2)
+	yn.Sub(yn, uv2x4)
+	yn.Add(yn, u)
+	yn.Neg(yn)
+
+	yd := new(fp.Fp).Mul(u2, v2)
+	yd.Double(yd)
+	yd.Sub(u5, yd)
+	yd.Sub(yd, u3x2)
+	yd.Sub(yd, new(fp.Fp).Double(v2))
+	yd.Add(yd, u)
+
+	p := new(ed448Point)
+	p.x.Mul(xn, yd)
+	p.y.Mul(yn, xd)
+	p.z.Mul(xd, yd)
+	return p.CMove(p, new(ed448Point).Identity(), bool2int[p.z.IsZero()])
+}
@@ -0,0 +1,178 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curves
+
+import (
+	crand "crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/TEENet-io/kryptology/pkg/core/curves/native/ed448/fp"
+)
+
+func TestPointEd448Rfc8032(t *testing.T) {
+	// Test vector -----Blank of RFC 8032, Section 7.4
+	sk, _ := hex.DecodeString("6c82a562cb808d10d632be89c8513ebf6c929f34ddfa8c9f63c9960ef6e348a3528c8a3fcc2f044e39a3fc5b94492f8f032e7549a20098f95b")
+	pk, _ := hex.DecodeString("5fd7449b59b461fd2ce787ec616ad46a1da1342485a70e1f8a0ea75d80e96778edf124769b46c7061bd6783df1e50f6cd1fa1abeafe8256180")
+	sig, _ := hex.DecodeString("533a37f6bbe457251f023c0d88f976ae2dfb504a843e34d2074fd823d41a591f2b233f034f628281f2fd7a22ddd47d7828c59bd0a21bfd3980ff0d2028d4b18a9df63e006c5d1c2d345b925d8dc00b4104852db99ac5c7cdda8530a113a0f4dbb61149f05a7363268c71d95808ff2e652600")
+
+	curve := ED448()
+	h := make([]byte, 114)
+	sha3.ShakeSum256(h, sk)
+	s, err := new(ScalarEd448).SetBytesClamping(h[:57])
+	require.NoError(t, err)
+	a := curve.ScalarBaseMult(s)
+	require.Equal(t, pk, a.ToAffineCompressed())
+
+	// [S]B = R + [k]A with k = SHAKE256(dom4(0, "") || R || A || M, 114)
+	r, err := curve.Point.FromAffineCompressed(sig[:57])
+	require.NoError(t, err)
+	ss, err := curve.Scalar.SetBytes(sig[57:])
+	require.NoError(t, err)
+	shake := sha3.NewShake256()
+	_, _ = shake.Write([]byte("SigEd448\x00\x00"))
+	_, _ = shake.Write(sig[:57])
+	_, _ = shake.Write(pk)
+	_, _ = shake.Read(h)
+	k, err := curve.Scalar.SetBytesWide(h)
+	require.NoError(t, err)
+	require.True(t, curve.ScalarBaseMult(ss).Equal(r.Add(a.Mul(k))))
+}
+
+func TestPointEd448Isogeny(t *testing.T) {
+	// The 4-isogeny maps the base point u = 5 of curve448 to 4 times the base point of edwards448
+	u := new(fp.Fp).SetUint64(5)
+	v, ok := new(big.Int).SetString("355293926785568175264127502063783334808976399387714271831880898435169088786967410002932673765864550910142774147268105838985595290606362", 10)
+	require.True(t, ok)
+	pt := &PointEd448{ed448IsogenyMap(u, new(fp.Fp).SetBigInt(v))}
+	g := ED448().Point.Generator()
+	require.True(t, pt.Equal(g.Double().Double()))
+
+	// (0, 0) has order two and maps to the identity
+	require.True(t, ed448IsogenyMap(new(fp.Fp), new(fp.Fp)).IsIdentity())
+}
+
+func TestPointEd448Hash(t *testing.T) {
+	curve := ED448()
+	h0 := curve.Point.Hash(nil)
+	h1 := curve.Point.Hash([]byte("abc"))
+	require.True(t, h0.IsOnCurve())
+	require.True(t, h1.IsOnCurve())
+	require.False(t, h0.Equal(h1))
+	require.False(t, h0.IsIdentity())
+	// The cofactor is cleared so the points have order ℓ
+	for _, h := range []Point{h0, h1} {
+		require.True(t, h.Mul(curve.Scalar.New(-1)).Add(h).IsIdentity())
+	}
+	// The exceptional case 1 - u^2 = 0 of Elligator 2 gives a point on the curve
+	require.True(t, mapEll2Ed448(new(fp.Fp).SetOne()).IsOnCurve())
+	require.True(t, mapEll2Ed448(new(fp.Fp)).IsOnCurve())
+}
+
+func TestPointEd448AddDoubleMul(t *testing.T) {
+	curve := ED448()
+	g := curve.Point.Generator()
+	id := curve.Point.Identity()
+	require.True(t, id.IsIdentity())
+	require.True(t, g.IsOnCurve())
+	require.True(t, g.Add(id).Equal(g))
+	require.True(t, g.Double().Equal(g.Add(g)))
+	require.True(t, g.Mul(curve.Scalar.New(3)).Equal(g.Double().Add(g)))
+	require.True(t, g.Mul(curve.Scalar.New(-1)).Equal(g.Neg()))
+	require.True(t, g.Mul(curve.Scalar.New(-1)).Add(g).IsIdentity())
+	require.True(t, g.Sub(g).IsIdentity())
+	require.Nil(t, g.Mul(ED25519().Scalar.One()))
+
+	a := curve.Scalar.Random(crand.Reader)
+	b := curve.Scalar.Random(crand.Reader)
+	require.True(t, g.Mul(a).Add(g.Mul(b)).Equal(g.Mul(a.Add(b))))
+	require.True(t, g.Mul(a).Mul(b).Equal(g.Mul(a.Mul(b))))
+	require.True(t, g.SumOfProducts([]Point{g, g.Double()}, []Scalar{a, b}).Equal(g.Mul(a.Add(b.Double()))))
+}
+
+func TestPointEd448Serialize(t *testing.T) {
+	curve := ED448()
+	for i := 0; i < 10; i++ {
+		pt := curve.Point.Random(crand.Reader)
+		require.Len(t, pt.ToAffineCompressed(), 57)
+		ret, err := curve.Point.FromAffineCompressed(pt.ToAffineCompressed())
+		require.NoError(t, err)
+		require.True(t, pt.Equal(ret))
+		ret, err = curve.Point.FromAffineUncompressed(pt.ToAffineUncompressed())
+		require.NoError(t, err)
+		require.True(t, pt.Equal(ret))
+		require.Equal(t, pt.IsNegative(), !pt.Neg().IsNegative())
+	}
+	id := curve.Point.Identity()
+	ret, err := curve.Point.FromAffineCompressed(id.ToAffineCompressed())
+	require.NoError(t, err)
+	require.True(t, ret.IsIdentity())
+
+	// x = 0 with the sign bit set is rejected
+	bad := id.ToAffineCompressed()
+	bad[56] = 0x80
+	_, err = curve.Point.FromAffineCompressed(bad)
+	require.Error(t, err)
+	bad[56] = 0x01
+	_, err = curve.Point.FromAffineCompressed(bad)
+	require.Error(t, err)
+
+	pt := curve.Point.Random(crand.Reader)
+	js, err := json.Marshal(pt)
+	require.NoError(t, err)
+	out, err := pointUnmarshalJson(js)
+	require.NoError(t, err)
+	require.True(t, pt.Equal(out))
+	require.Equal(t, ED448(), GetCurveByName(ED448Name))
+}
+
+func TestScalarEd448(t *testing.T) {
+	curve := ED448()
+	a := curve.Scalar.Random(crand.Reader)
+	b := curve.Scalar.Random(crand.Reader)
+	require.Equal(t, 0, a.Add(b).Sub(b).Cmp(a))
+	require.Equal(t, 0, a.Mul(b).Div(b).Cmp(a))
+	inv, err := a.Invert()
+	require.NoError(t, err)
+	require.True(t, inv.Mul(a).IsOne())
+
+	// Bytes are 57 little-endian bytes as in RFC 8032
+	require.Len(t, a.Bytes(), 57)
+	ret, err := curve.Scalar.SetBytes(a.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, 0, a.Cmp(ret))
+	bad := a.Bytes()
+	bad[56] = 1
+	_, err = curve.Scalar.SetBytes(bad)
+	require.Error(t, err)
+
+	wide := make([]byte, 114)
+	wide[113] = 1
+	ret, err = curve.Scalar.SetBytesWide(wide)
+	require.NoError(t, err)
+	order := new(big.Int).Add(curve.Scalar.New(-1).BigInt(), big.NewInt(1))
+	expected := new(big.Int).Lsh(big.NewInt(1), 904)
+	require.Equal(t, expected.Mod(expected, order), ret.BigInt())
+	_, err = curve.Scalar.SetBytesWide(wide[:112])
+	require.Error(t, err)
+
+	bin, err := scalarMarshalBinary(a)
+	require.NoError(t, err)
+	out, err := scalarUnmarshalBinary(bin)
+	require.NoError(t, err)
+	require.Equal(t, 0, a.Cmp(out))
+	txt, err := scalarMarshalText(a)
+	require.NoError(t, err)
+	out, err = scalarUnmarshalText(txt)
+	require.NoError(t, err)
+	require.Equal(t, 0, a.Cmp(out))
+}
@@ -0,0 +1,282 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curves
+
+import (
+	"fmt"
+	"sync"
+
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+
+	"github.com/TEENet-io/kryptology/internal"
+	"github.com/TEENet-io/kryptology/pkg/core/curves/native"
+	"github.com/TEENet-io/kryptology/pkg/core/curves/native/bls12381"
+)
+
+const (
+	// fixedBaseWindow is the width in bits of the windows of a fixed-base table
+	fixedBaseWindow = 4
+	// fixedBaseWindows covers scalars of up to 256 bits
+	fixedBaseWindows = 256 / fixedBaseWindow
+	// fixedBaseEntries is the number of multiples in each window
+	fixedBaseEntries = 1 << fixedBaseWindow
+)
+
+// FixedBaseTable holds the multiples j 16^i P for 0 ≤ j < 16 of a fixed point P,
+// so that multiplying P by a scalar takes one constant-time table lookup and one addition
+// for every 4 bits of the scalar instead of a variable-base multiplication.
+// Tables are supported for K256, P256, Ed25519, Pallas, Vesta and BLS12-381 G1 and G2 points.
+type FixedBaseTable struct {
+	point  Point
+	table  [fixedBaseWindows][fixedBaseEntries]Point
+	lookup func(entries *[fixedBaseEntries]Point, index int) Point
+	bytes  func(sc Scalar) ([]byte, bool)
+}
+
+// NewFixedBaseTable builds the table of point, e.g. of a Pedersen commitment generator H.
+// The table takes 1024 points of memory.
+func NewFixedBaseTable(point Point) (*FixedBaseTable, error) {
+	if point == nil {
+		return nil, internal.ErrNilArguments
+	}
+	t := &FixedBaseTable{point: point}
+	switch point.(type) {
+	case *PointK256:
+		t.lookup = lookupK256
+		t.bytes = scalarBytesK256
+	case *PointP256:
+		t.lookup = lookupP256
+		t.bytes = scalarBytesP256
+	case *PointEd25519:
+		t.lookup = lookupEd25519
+		t.bytes = scalarBytesEd25519
+	case *PointPallas:
+		t.lookup = lookupPallas
+		t.bytes = scalarBytesPallas
+	case *PointVesta:
+		t.lookup = lookupVesta
+		t.bytes = scalarBytesVesta
+	case *PointBls12381G1:
+		t.lookup = lookupBls12381G1
+		t.bytes = scalarBytesBls12381
+	case *PointBls12381G2:
+		t.lookup = lookupBls12381G2
+		t.bytes = scalarBytesBls12381
+	default:
+		return nil, fmt.Errorf("fixed-base tables are not supported for %s", point.CurveName())
+	}
+
+	base := point
+	for i := range t.table {
+		t.table[i][0] = point.Identity()
+		t.table[i][1] = base
+		for j := 2; j < fixedBaseEntries; j++ {
+			t.table[i][j] = t.table[i][j-1].Add(base)
+		}
+		base = t.table[i][fixedBaseEntries-1].Add(base)
+	}
+	return t, nil
+}
+
+// Point returns the fixed point of the table
+func (t *FixedBaseTable) Point() Point {
+	return t.point
+}
+
+// Mul returns sc * P, or nil if sc is not a scalar of the curve of P. The table lookups take
+// constant time, and the additions take as long as Point.Add of the curve.
+func (t *FixedBaseTable) Mul(sc Scalar) Point {
+	if sc == nil {
+		return nil
+	}
+	bytes, ok := t.bytes(sc)
+	if !ok {
+		return nil
+	}
+	result := t.point.Identity()
+	for i := range t.table {
+		nibble := int(bytes[i>>1]>>(fixedBaseWindow*(i&1))) & (fixedBaseEntries - 1)
+		result = result.Add(t.lookup(&t.table[i], nibble))
+	}
+	return result
+}
+
+// generatorTables are the lazily built tables of the generators for Curve.ScalarBaseMult
+var generatorTables = map[string]*generatorTable{
+	K256Name:       {},
+	P256Name:       {},
+	PallasName:     {},
+	VestaName:      {},
+	BLS12381G1Name: {},
+	BLS12381G2Name: {},
+}
+
+type generatorTable struct {
+	once  sync.Once
+	table *FixedBaseTable
+}
+
+// generatorFixedBaseTable returns the table of the generator of c, or nil if it has none
+func (c Curve) generatorFixedBaseTable() *FixedBaseTable {
+	g, ok := generatorTables[c.Name]
+	if !ok {
+		return nil
+	}
+	g.once.Do(func() {
+		g.table, _ = NewFixedBaseTable(c.Point.Generator())
+	})
+	return g.table
+}
+
+// ctEqual returns 1 if a == b and 0 otherwise without branching
+func ctEqual(a, b int) int {
+	x := uint64(a ^ b)
+	return int(1 ^ (x|-x)>>63)
+}
+
+// lookupNative selects entries[index] of native.EllipticPoints in constant time
+func lookupNative(out *native.EllipticPoint, entries *[fixedBaseEntries]Point, index int, value func(Point) *native.EllipticPoint) {
+	for j := 1; j < fixedBaseEntries; j++ {
+		p := value(entries[j])
+		choice := ctEqual(j, index)
+		out.X.CMove(out.X, p.X, choice)
+		out.Y.CMove(out.Y, p.Y, choice)
+		out.Z.CMove(out.Z, p.Z, choice)
+	}
+}
+
+func lookupK256(entries *[fixedBaseEntries]Point, index int) Point {
+	value := func(p Point) *native.EllipticPoint { return p.(*PointK256).value }
+	out := new(native.EllipticPoint).Set(value(entries[0]))
+	lookupNative(out, entries, index, value)
+	return &PointK256{out}
+}
+
+func lookupP256(entries *[fixedBaseEntries]Point, index int) Point {
+	value := func(p Point) *native.EllipticPoint { return p.(*PointP256).value }
+	out := new(native.EllipticPoint).Set(value(entries[0]))
+	lookupNative(out, entries, index, value)
+	return &PointP256{out}
+}
+
+func lookupEd25519(entries *[fixedBaseEntries]Point, index int) Point {
+	var x, y, z, t field.Element
+	x0, y0, z0, t0 := entries[0].(*PointEd25519).value.ExtendedCoordinates()
+	x.Set(x0)
+	y.Set(y0)
+	z.Set(z0)
+	t.Set(t0)
+	for j := 1; j < fixedBaseEntries; j++ {
+		xj, yj, zj, tj := entries[j].(*PointEd25519).value.ExtendedCoordinates()
+		choice := ctEqual(j, index)
+		x.Select(xj, &x, choice)
+		y.Select(yj, &y, choice)
+		z.Select(zj, &z, choice)
+		t.Select(tj, &t, choice)
+	}
+	value, err := edwards25519.NewIdentityPoint().SetExtendedCoordinates(&x, &y, &z, &t)
+	if err != nil {
+		return nil
+	}
+	return &PointEd25519{value}
+}
+
+func lookupPallas(entries *[fixedBaseEntries]Point, index int) Point {
+	out := new(Ep).Set(entries[0].(*PointPallas).value)
+	for j := 1; j < fixedBaseEntries; j++ {
+		e := entries[j].(*PointPallas).value
+		choice := ctEqual(j, index)
+		out.x.CMove(out.x, e.x, choice)
+		out.y.CMove(out.y, e.y, choice)
+		out.z.CMove(out.z, e.z, choice)
+	}
+	return &PointPallas{out}
+}
+
+func lookupVesta(entries *[fixedBaseEntries]Point, index int) Point {
+	out := new(Eq).Set(entries[0].(*PointVesta).value)
+	for j := 1; j < fixedBaseEntries; j++ {
+		e := entries[j].(*PointVesta).value
+		choice := ctEqual(j, index)
+		out.x.CMove(out.x, e.x, choice)
+		out.y.CMove(out.y, e.y, choice)
+		out.z.CMove(out.z, e.z, choice)
+	}
+	return &PointVesta{out}
+}
+
+func lookupBls12381G1(entries *[fixedBaseEntries]Point, index int) Point {
+	out := new(bls12381.G1).Set(entries[0].(*PointBls12381G1).Value)
+	for j := 1; j < fixedBaseEntries; j++ {
+		out.CMove(out, entries[j].(*PointBls12381G1).Value, ctEqual(j, index))
+	}
+	return &PointBls12381G1{out}
+}
+
+func lookupBls12381G2(entries *[fixedBaseEntries]Point, index int) Point {
+	out := new(bls12381.G2).Set(entries[0].(*PointBls12381G2).Value)
+	for j := 1; j < fixedBaseEntries; j++ {
+		out.CMove(out, entries[j].(*PointBls12381G2).Value, ctEqual(j, index))
+	}
+	return &PointBls12381G2{out}
+}
+
+// The scalarBytes functions return the little-endian bytes of scalars of each curve
+
+func scalarBytesK256(sc Scalar) ([]byte, bool) {
+	s, ok := sc.(*ScalarK256)
+	if !ok {
+		return nil, false
+	}
+	b := s.value.Bytes()
+	return b[:], true
+}
+
+func scalarBytesP256(sc Scalar) ([]byte, bool) {
+	s, ok := sc.(*ScalarP256)
+	if !ok {
+		return nil, false
+	}
+	b := s.value.Bytes()
+	return b[:], true
+}
+
+func scalarBytesEd25519(sc Scalar) ([]byte, bool) {
+	s, ok := sc.(*ScalarEd25519)
+	if !ok {
+		return nil, false
+	}
+	return s.value.Bytes(), true
+}
+
+func scalarBytesPallas(sc Scalar) ([]byte, bool) {
+	s, ok := sc.(*ScalarPallas)
+	if !ok {
+		return nil, false
+	}
+	b := s.value.Bytes()
+	return b[:], true
+}
+
+func scalarBytesVesta(sc Scalar) ([]byte, bool) {
+	s, ok := sc.(*ScalarVesta)
+	if !ok {
+		return nil, false
+	}
+	b := s.value.Bytes()
+	return b[:], true
+}
+
+func scalarBytesBls12381(sc Scalar) ([]byte, bool) {
+	s, ok := sc.(*ScalarBls12381)
+	if !ok {
+		return nil, false
+	}
+	b := s.Value.Bytes()
+	return b[:], true
+}
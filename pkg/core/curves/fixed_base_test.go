@@ -0,0 +1,81 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curves
+
+import (
+	crand "crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func fixedBaseTestCurves() []*Curve {
+	return []*Curve{K256(), P256(), ED25519(), PALLAS(), VESTA(), BLS12381G1(), BLS12381G2()}
+}
+
+func fixedBaseTestScalars(curve *Curve) []Scalar {
+	scalars := []Scalar{
+		curve.Scalar.Zero(),
+		curve.Scalar.One(),
+		curve.Scalar.New(16),
+		curve.Scalar.One().Neg(),
+	}
+	for i := 0; i < 10; i++ {
+		scalars = append(scalars, curve.Scalar.Random(crand.Reader))
+	}
+	return scalars
+}
+
+func TestScalarBaseMult(t *testing.T) {
+	for _, curve := range fixedBaseTestCurves() {
+		g := curve.Point.Generator()
+		for _, sc := range fixedBaseTestScalars(curve) {
+			require.True(t, g.Mul(sc).Equal(curve.ScalarBaseMult(sc)), curve.Name)
+		}
+	}
+}
+
+func TestFixedBaseTable(t *testing.T) {
+	for _, curve := range fixedBaseTestCurves() {
+		h := curve.Point.Random(crand.Reader)
+		table, err := NewFixedBaseTable(h)
+		require.NoError(t, err)
+		require.True(t, h.Equal(table.Point()))
+		for _, sc := range fixedBaseTestScalars(curve) {
+			require.True(t, h.Mul(sc).Equal(table.Mul(sc)), curve.Name)
+		}
+	}
+
+	// Scalars of another curve
+	table, err := NewFixedBaseTable(K256().Point.Generator())
+	require.NoError(t, err)
+	require.Nil(t, table.Mul(P256().Scalar.One()))
+	require.Nil(t, table.Mul(nil))
+
+	_, err = NewFixedBaseTable(BLS12377G1().Point.Generator())
+	require.Error(t, err)
+	_, err = NewFixedBaseTable(nil)
+	require.Error(t, err)
+}
+
+func BenchmarkScalarBaseMult(b *testing.B) {
+	for _, curve := range fixedBaseTestCurves() {
+		sc := curve.Scalar.Random(crand.Reader)
+		curve.ScalarBaseMult(sc)
+		b.Run(curve.Name+" table", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				curve.ScalarBaseMult(sc)
+			}
+		})
+		b.Run(curve.Name+" variable base", func(b *testing.B) {
+			g := curve.Point.Generator()
+			for i := 0; i < b.N; i++ {
+				g.Mul(sc)
+			}
+		})
+	}
+}
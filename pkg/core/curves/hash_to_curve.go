@@ -0,0 +1,50 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curves
+
+import (
+	"fmt"
+
+	"github.com/TEENet-io/kryptology/internal"
+	"github.com/TEENet-io/kryptology/pkg/core/curves/native"
+)
+
+// hashToField is hash_to_field of RFC 9380 Section 5.2. It expands msg with dst
+// to count elements of l bytes each with the expand_message variant of the hasher,
+// and returns them big-endian so the caller can reduce them into its field
+func hashToField(hasher *native.EllipticPointHasher, msg, dst []byte, count, l int) ([][]byte, error) {
+	if err := checkHashToCurveArgs(hasher, dst); err != nil {
+		return nil, err
+	}
+	var uniform []byte
+	switch hasher.Type() {
+	case native.XMD:
+		uniform = native.ExpandMsgXmd(hasher, msg, dst, count*l)
+	case native.XOF:
+		uniform = native.ExpandMsgXof(hasher, msg, dst, count*l)
+	}
+	out := make([][]byte, count)
+	for i := range out {
+		out[i] = uniform[i*l : (i+1)*l]
+	}
+	return out, nil
+}
+
+// checkHashToCurveArgs validates the hasher and dst of HashWithDst and EncodeWithDst
+func checkHashToCurveArgs(hasher *native.EllipticPointHasher, dst []byte) error {
+	if hasher == nil {
+		return internal.ErrNilArguments
+	}
+	// RFC 9380 Section 3.1 requires a nonempty DST
+	if len(dst) == 0 {
+		return fmt.Errorf("invalid domain separation tag")
+	}
+	if hasher.Type() != native.XMD && hasher.Type() != native.XOF {
+		return fmt.Errorf("unsupported hash type %s", hasher.Type())
+	}
+	return nil
+}
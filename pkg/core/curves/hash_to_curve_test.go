@@ -0,0 +1,278 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curves
+
+import (
+	"encoding/hex"
+	"testing"
+
+	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	bn254 "github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TEENet-io/kryptology/internal"
+	"github.com/TEENet-io/kryptology/pkg/core/curves/native"
+)
+
+type hashToCurveVector struct {
+	msg  string
+	x, y string
+}
+
+type hashToCurveSuite struct {
+	point   Point
+	hasher  func() *native.EllipticPointHasher
+	dst     string
+	encode  bool
+	vectors []hashToCurveVector
+	// uncompressed builds the ToAffineUncompressed encoding from the affine coordinates
+	uncompressed func(x, y []byte) []byte
+}
+
+func sec1Uncompressed(x, y []byte) []byte {
+	return append(append([]byte{4}, x...), y...)
+}
+
+func littleEndianUncompressed(x, y []byte) []byte {
+	return append(internal.ReverseScalarBytes(x), internal.ReverseScalarBytes(y)...)
+}
+
+func bigEndianUncompressed(x, y []byte) []byte {
+	return append(x, y...)
+}
+
+// Test vectors of RFC 9380, Appendix J
+var hashToCurveSuites = []hashToCurveSuite{
+	{
+		P256().Point, native.EllipticPointHasherSha256, "QUUX-V01-CS02-with-P256_XMD:SHA-256_SSWU_RO_", false,
+		[]hashToCurveVector{
+			{"", "2c15230b26dbc6fc9a37051158c95b79656e17a1a920b11394ca91c44247d3e4", "8a7a74985cc5c776cdfe4b1f19884970453912e9d31528c060be9ab5c43e8415"},
+			{"abc", "0bb8b87485551aa43ed54f009230450b492fead5f1cc91658775dac4a3388a0f", "5c41b3d0731a27a7b14bc0bf0ccded2d8751f83493404c84a88e71ffd424212e"},
+		},
+		sec1Uncompressed,
+	},
+	{
+		P256().Point, native.EllipticPointHasherSha256, "QUUX-V01-CS02-with-P256_XMD:SHA-256_SSWU_NU_", true,
+		[]hashToCurveVector{
+			{"", "f871caad25ea3b59c16cf87c1894902f7e7b2c822c3d3f73596c5ace8ddd14d1", "87b9ae23335bee057b99bac1e68588b18b5691af476234b8971bc4f011ddc99b"},
+			{"abc", "fc3f5d734e8dce41ddac49f47dd2b8a57257522a865c124ed02b92b5237befa4", "fe4d197ecf5a62645b9690599e1d80e82c500b22ac705a0b421fac7b47157866"},
+		},
+		sec1Uncompressed,
+	},
+	{
+		P384().Point, native.EllipticPointHasherSha384, "QUUX-V01-CS02-with-P384_XMD:SHA-384_SSWU_RO_", false,
+		[]hashToCurveVector{
+			{"", "eb9fe1b4f4e14e7140803c1d99d0a93cd823d2b024040f9c067a8eca1f5a2eeac9ad604973527a356f3fa3aeff0e4d83", "0c21708cff382b7f4643c07b105c2eaec2cead93a917d825601e63c8f21f6abd9abc22c93c2bed6f235954b25048bb1a"},
+			{"abc", "e02fc1a5f44a7519419dd314e29863f30df55a514da2d655775a81d413003c4d4e7fd59af0826dfaad4200ac6f60abe1", "01f638d04d98677d65bef99aef1a12a70a4cbb9270ec55248c04530d8bc1f8f90f8a6a859a7c1f1ddccedf8f96d675f6"},
+		},
+		sec1Uncompressed,
+	},
+	{
+		P384().Point, native.EllipticPointHasherSha384, "QUUX-V01-CS02-with-P384_XMD:SHA-384_SSWU_NU_", true,
+		[]hashToCurveVector{
+			{"", "de5a893c83061b2d7ce6a0d8b049f0326f2ada4b966dc7e72927256b033ef61058029a3bfb13c1c7ececd6641881ae20", "63f46da6139785674da315c1947e06e9a0867f5608cf24724eb3793a1f5b3809ee28eb21a0c64be3be169afc6cdb38ca"},
+			{"abc", "1f08108b87e703c86c872ab3eb198a19f2b708237ac4be53d7929fb4bd5194583f40d052f32df66afe5249c9915d139b", "1369dc8d5bf038032336b989994874a2270adadb67a7fcc32f0f8824bc5118613f0ac8de04a1041d90ff8a5ad555f96c"},
+		},
+		sec1Uncompressed,
+	},
+	{
+		K256().Point, native.EllipticPointHasherSha256, "QUUX-V01-CS02-with-secp256k1_XMD:SHA-256_SSWU_RO_", false,
+		[]hashToCurveVector{
+			{"", "c1cae290e291aee617ebaef1be6d73861479c48b841eaba9b7b5852ddfeb1346", "64fa678e07ae116126f08b022a94af6de15985c996c3a91b64c406a960e51067"},
+			{"abc", "3377e01eab42db296b512293120c6cee72b6ecf9f9205760bd9ff11fb3cb2c4b", "7f95890f33efebd1044d382a01b1bee0900fb6116f94688d487c6c7b9c8371f6"},
+		},
+		sec1Uncompressed,
+	},
+	{
+		K256().Point, native.EllipticPointHasherSha256, "QUUX-V01-CS02-with-secp256k1_XMD:SHA-256_SSWU_NU_", true,
+		[]hashToCurveVector{
+			{"", "a4792346075feae77ac3b30026f99c1441b4ecf666ded19b7522cf65c4c55c5b", "62c59e2a6aeed1b23be5883e833912b08ba06be7f57c0e9cdc663f31639ff3a7"},
+		},
+		sec1Uncompressed,
+	},
+	{
+		ED25519().Point, native.EllipticPointHasherSha512, "QUUX-V01-CS02-with-edwards25519_XMD:SHA-512_ELL2_RO_", false,
+		[]hashToCurveVector{
+			{"", "3c3da6925a3c3c268448dcabb47ccde5439559d9599646a8260e47b1e4822fc6", "09a6c8561a0b22bef63124c588ce4c62ea83a3c899763af26d795302e115dc21"},
+			{"abc", "608040b42285cc0d72cbb3985c6b04c935370c7361f4b7fbdb1ae7f8c1a8ecad", "1a8395b88338f22e435bbd301183e7f20a5f9de643f11882fb237f88268a5531"},
+			{"abcdef0123456789", "6d7fabf47a2dc03fe7d47f7dddd21082c5fb8f86743cd020f3fb147d57161472", "53060a3d140e7fbcda641ed3cf42c88a75411e648a1add71217f70ea8ec561a6"},
+		},
+		littleEndianUncompressed,
+	},
+	{
+		ED25519().Point, native.EllipticPointHasherSha512, "QUUX-V01-CS02-with-edwards25519_XMD:SHA-512_ELL2_NU_", true,
+		[]hashToCurveVector{
+			{"", "1ff2b70ecf862799e11b7ae744e3489aa058ce805dd323a936375a84695e76da", "222e314d04a4d5725e9f2aff9fb2a6b69ef375a1214eb19021ceab2d687f0f9b"},
+			{"abc", "5f13cc69c891d86927eb37bd4afc6672360007c63f68a33ab423a3aa040fd2a8", "67732d50f9a26f73111dd1ed5dba225614e538599db58ba30aaea1f5c827fa42"},
+			{"abcdef0123456789", "1dd2fefce934ecfd7aae6ec998de088d7dd03316aa1847198aecf699ba6613f1", "2f8a6c24dd1adde73909cada6a4a137577b0f179d336685c4a955a0a8e1a86fb"},
+		},
+		littleEndianUncompressed,
+	},
+	{
+		BLS12381G1().Point, native.EllipticPointHasherSha256, "QUUX-V01-CS02-with-BLS12381G1_XMD:SHA-256_SSWU_RO_", false,
+		[]hashToCurveVector{
+			{"", "052926add2207b76ca4fa57a8734416c8dc95e24501772c814278700eed6d1e4e8cf62d9c09db0fac349612b759e79a1", "08ba738453bfed09cb546dbb0783dbb3a5f1f566ed67bb6be0e8c67e2e81a4cc68ee29813bb7994998f3eae0c9c6a265"},
+			{"abc", "03567bc5ef9c690c2ab2ecdf6a96ef1c139cc0b2f284dca0a9a7943388a49a3aee664ba5379a7655d3c68900be2f6903", "0b9c15f3fe6e5cf4211f346271d7b01c8f3b28be689c8429c85b67af215533311f0b8dfaaa154fa6b88176c229f2885d"},
+		},
+		bigEndianUncompressed,
+	},
+	{
+		BLS12381G1().Point, native.EllipticPointHasherSha256, "QUUX-V01-CS02-with-BLS12381G1_XMD:SHA-256_SSWU_NU_", true,
+		[]hashToCurveVector{
+			{"", "184bb665c37ff561a89ec2122dd343f20e0f4cbcaec84e3c3052ea81d1834e192c426074b02ed3dca4e7676ce4ce48ba", "04407b8d35af4dacc809927071fc0405218f1401a6d15af775810e4e460064bcc9468beeba82fdc751be70476c888bf3"},
+			{"abc", "009769f3ab59bfd551d53a5f846b9984c59b97d6842b20a2c565baa167945e3d026a3755b6345df8ec7e6acb6868ae6d", "1532c00cf61aa3d0ce3e5aa20c3b531a2abd2c770a790a2613818303c6b830ffc0ecf6c357af3317b9575c567f11cd2c"},
+		},
+		bigEndianUncompressed,
+	},
+}
+
+func TestHashToCurveRfc9380(t *testing.T) {
+	for _, suite := range hashToCurveSuites {
+		for _, v := range suite.vectors {
+			var pt Point
+			var err error
+			if suite.encode {
+				pt, err = suite.point.EncodeWithDst([]byte(v.msg), []byte(suite.dst), suite.hasher())
+			} else {
+				pt, err = suite.point.HashWithDst([]byte(v.msg), []byte(suite.dst), suite.hasher())
+			}
+			require.NoError(t, err)
+			require.True(t, pt.IsOnCurve())
+			x, _ := hex.DecodeString(v.x)
+			y, _ := hex.DecodeString(v.y)
+			require.Equal(t, suite.uncompressed(x, y), pt.ToAffineUncompressed(), "%s %q", suite.dst, v.msg)
+		}
+	}
+}
+
+func TestHashToCurveGnark(t *testing.T) {
+	// gnark-crypto checks its BLS12-381 G2 suites against RFC 9380, which has no
+	// vectors for BLS12-377 and BN254, and implements them with the same maps
+	for _, msg := range []string{"", "abc", "abcdef0123456789"} {
+		dst := "QUUX-V01-CS02-with-BLS12381G2_XMD:SHA-256_SSWU_RO_"
+		pt, err := BLS12381G2().Point.HashWithDst([]byte(msg), []byte(dst), native.EllipticPointHasherSha256())
+		require.NoError(t, err)
+		g2, err := bls12381.HashToG2([]byte(msg), []byte(dst))
+		require.NoError(t, err)
+		raw2 := g2.RawBytes()
+		require.Equal(t, raw2[:], pt.ToAffineUncompressed())
+		dst = "QUUX-V01-CS02-with-BLS12381G2_XMD:SHA-256_SSWU_NU_"
+		pt, err = BLS12381G2().Point.EncodeWithDst([]byte(msg), []byte(dst), native.EllipticPointHasherSha256())
+		require.NoError(t, err)
+		g2, err = bls12381.EncodeToG2([]byte(msg), []byte(dst))
+		require.NoError(t, err)
+		raw2 = g2.RawBytes()
+		require.Equal(t, raw2[:], pt.ToAffineUncompressed())
+
+		dst = "BLS12377G1_XMD:SHA-256_SSWU_RO_TEST"
+		pt, err = BLS12377G1().Point.HashWithDst([]byte(msg), []byte(dst), native.EllipticPointHasherSha256())
+		require.NoError(t, err)
+		p377, err := bls12377.HashToG1([]byte(msg), []byte(dst))
+		require.NoError(t, err)
+		require.True(t, pt.Equal(&PointBls12377G1{value: &p377}))
+		pt, err = BLS12377G1().Point.EncodeWithDst([]byte(msg), []byte(dst), native.EllipticPointHasherSha256())
+		require.NoError(t, err)
+		p377, err = bls12377.EncodeToG1([]byte(msg), []byte(dst))
+		require.NoError(t, err)
+		require.True(t, pt.Equal(&PointBls12377G1{value: &p377}))
+		pt, err = BLS12377G2().Point.HashWithDst([]byte(msg), []byte(dst), native.EllipticPointHasherSha256())
+		require.NoError(t, err)
+		q377, err := bls12377.HashToG2([]byte(msg), []byte(dst))
+		require.NoError(t, err)
+		require.True(t, pt.Equal(&PointBls12377G2{value: &q377}))
+		pt, err = BLS12377G2().Point.EncodeWithDst([]byte(msg), []byte(dst), native.EllipticPointHasherSha256())
+		require.NoError(t, err)
+		q377, err = bls12377.EncodeToG2([]byte(msg), []byte(dst))
+		require.NoError(t, err)
+		require.True(t, pt.Equal(&PointBls12377G2{value: &q377}))
+
+		dst = "BN254G1_XMD:SHA-256_SVDW_RO_TEST"
+		pt, err = BN254G1().Point.HashWithDst([]byte(msg), []byte(dst), native.EllipticPointHasherSha256())
+		require.NoError(t, err)
+		p254, err := bn254.HashToG1([]byte(msg), []byte(dst))
+		require.NoError(t, err)
+		require.True(t, pt.Equal(&PointBn254G1{value: &p254}))
+		pt, err = BN254G1().Point.EncodeWithDst([]byte(msg), []byte(dst), native.EllipticPointHasherSha256())
+		require.NoError(t, err)
+		p254, err = bn254.EncodeToG1([]byte(msg), []byte(dst))
+		require.NoError(t, err)
+		require.True(t, pt.Equal(&PointBn254G1{value: &p254}))
+		pt, err = BN254G2().Point.HashWithDst([]byte(msg), []byte(dst), native.EllipticPointHasherSha256())
+		require.NoError(t, err)
+		q254, err := bn254.HashToG2([]byte(msg), []byte(dst))
+		require.NoError(t, err)
+		require.True(t, pt.Equal(&PointBn254G2{value: &q254}))
+		pt, err = BN254G2().Point.EncodeWithDst([]byte(msg), []byte(dst), native.EllipticPointHasherSha256())
+		require.NoError(t, err)
+		q254, err = bn254.EncodeToG2([]byte(msg), []byte(dst))
+		require.NoError(t, err)
+		require.True(t, pt.Equal(&PointBn254G2{value: &q254}))
+	}
+}
+
+func TestHashToCurveDefaultSuites(t *testing.T) {
+	// Point.Hash is HashWithDst with the fixed suite of the curve
+	msg := []byte("abc")
+	pt, err := P384().Point.HashWithDst(msg, []byte(p384HashDst), native.EllipticPointHasherSha384())
+	require.NoError(t, err)
+	require.True(t, pt.Equal(P384().Point.Hash(msg)))
+	pt, err = ED448().Point.HashWithDst(msg, []byte(ed448HashDst), native.EllipticPointHasherShake256())
+	require.NoError(t, err)
+	require.True(t, pt.Equal(ED448().Point.Hash(msg)))
+	pt, err = RISTRETTO255().Point.HashWithDst(msg, []byte(ristretto255HashDst), native.EllipticPointHasherSha512())
+	require.NoError(t, err)
+	require.True(t, pt.Equal(RISTRETTO255().Point.Hash(msg)))
+	pt, err = BLS12381G1().Point.HashWithDst(msg, []byte("BLS12381G1_XMD:SHA-256_SSWU_RO_"), native.EllipticPointHasherSha256())
+	require.NoError(t, err)
+	require.True(t, pt.Equal(BLS12381G1().Point.Hash(msg)))
+	pt, err = K256().Point.HashWithDst(msg, []byte("secp256k1_XMD:SHA-256_SSWU_RO_"), native.EllipticPointHasherSha256())
+	require.NoError(t, err)
+	require.True(t, pt.Equal(K256().Point.Hash(msg)))
+}
+
+func TestHashToCurveAllCurves(t *testing.T) {
+	points := []Point{
+		K256().Point, P256().Point, P384().Point, ED25519().Point, ED448().Point,
+		RISTRETTO255().Point, PALLAS().Point, VESTA().Point,
+		BLS12381G1().Point, BLS12381G2().Point, BLS12377G1().Point, BLS12377G2().Point, BN254G1().Point, BN254G2().Point,
+	}
+	hashers := []func() *native.EllipticPointHasher{
+		native.EllipticPointHasherSha256, native.EllipticPointHasherSha3512,
+		native.EllipticPointHasherBlake2b, native.EllipticPointHasherShake256,
+	}
+	msg := []byte("abc")
+	for _, point := range points {
+		for _, hasher := range hashers {
+			h0, err := point.HashWithDst(msg, []byte("TEST-V01-CS01-RO"), hasher())
+			require.NoError(t, err)
+			h1, err := point.HashWithDst(msg, []byte("TEST-V01-CS02-RO"), hasher())
+			require.NoError(t, err)
+			e0, err := point.EncodeWithDst(msg, []byte("TEST-V01-CS01-NU"), hasher())
+			require.NoError(t, err)
+			for _, pt := range []Point{h0, h1, e0} {
+				require.True(t, pt.IsOnCurve(), point.CurveName())
+				require.False(t, pt.IsIdentity(), point.CurveName())
+				// The cofactor is cleared
+				require.True(t, pt.Mul(pt.Scalar().New(-1)).Add(pt).IsIdentity(), point.CurveName())
+			}
+			// Distinct domains give distinct points
+			require.False(t, h0.Equal(h1), point.CurveName())
+			require.False(t, h0.Equal(e0), point.CurveName())
+			again, err := point.HashWithDst(msg, []byte("TEST-V01-CS01-RO"), hasher())
+			require.NoError(t, err)
+			require.True(t, h0.Equal(again), point.CurveName())
+		}
+
+		_, err := point.HashWithDst(msg, []byte("TEST"), nil)
+		require.Error(t, err)
+		_, err = point.HashWithDst(msg, nil, native.EllipticPointHasherSha256())
+		require.Error(t, err)
+		_, err = point.EncodeWithDst(msg, nil, native.EllipticPointHasherSha256())
+		require.Error(t, err)
+	}
+}
@@ -3,6 +3,7 @@ package curves
 import (
 	crand "crypto/rand"
 	"crypto/sha256"
+	"fmt"
 	"io"
 	"math/big"
 	"testing"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/TEENet-io/kryptology/internal"
 	mod "github.com/TEENet-io/kryptology/pkg/core"
+	"github.com/TEENet-io/kryptology/pkg/core/curves/native"
 )
 
 func BenchmarkK256(b *testing.B) {
@@ -311,6 +313,26 @@ func (s *BenchScalar) SetBytesWide(bytes []byte) (Scalar, error) {
 	}, nil
 }
 
+func (s *BenchScalar) BytesBE() []byte {
+	return nil
+}
+
+func (s *BenchScalar) BytesLE() []byte {
+	return nil
+}
+
+func (s *BenchScalar) SetBytesBE(bytes []byte) (Scalar, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (s *BenchScalar) SetBytesLE(bytes []byte) (Scalar, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (s *BenchScalar) SetBytesReduce(bytes []byte) (Scalar, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
 func (s *BenchScalar) Clone() Scalar {
 	return &BenchScalar{
 		value: new(big.Int).Set(s.value),
@@ -337,6 +359,14 @@ func (p *BenchPoint) Hash(bytes []byte) Point {
 	return nil
 }
 
+func (p *BenchPoint) HashWithDst(bytes, dst []byte, hasher *native.EllipticPointHasher) (Point, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (p *BenchPoint) EncodeWithDst(bytes, dst []byte, hasher *native.EllipticPointHasher) (Point, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
 func (p *BenchPoint) Identity() Point {
 	return &BenchPoint{x: big.NewInt(0), y: big.NewInt(0)}
 }
@@ -422,6 +452,18 @@ func (p *BenchPoint) FromAffineCompressed(bytes []byte) (Point, error) {
 	return nil, nil
 }
 
+func (p *BenchPoint) IsTorsionFree() bool {
+	return true
+}
+
+func (p *BenchPoint) ClearCofactor() Point {
+	return p
+}
+
+func (p *BenchPoint) FromAffineCompressedStrict(bytes []byte) (Point, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
 func (p *BenchPoint) FromAffineUncompressed(bytes []byte) (Point, error) {
 	return nil, nil
 }
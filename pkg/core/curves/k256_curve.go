@@ -318,6 +318,26 @@ func (s *ScalarK256) SetBytesWide(bytes []byte) (Scalar, error) {
 	}, nil
 }
 
+func (s *ScalarK256) BytesBE() []byte {
+	return s.Bytes()
+}
+
+func (s *ScalarK256) BytesLE() []byte {
+	return internal.ReverseScalarBytes(s.Bytes())
+}
+
+func (s *ScalarK256) SetBytesBE(bytes []byte) (Scalar, error) {
+	return s.SetBytes(bytes)
+}
+
+func (s *ScalarK256) SetBytesLE(bytes []byte) (Scalar, error) {
+	return s.SetBytes(internal.ReverseScalarBytes(bytes))
+}
+
+func (s *ScalarK256) SetBytesReduce(bytes []byte) (Scalar, error) {
+	return scalarSetBytesReduce(s, bytes)
+}
+
 func (s *ScalarK256) Point() Point {
 	return new(PointK256).Identity()
 }
@@ -396,6 +416,28 @@ func (p *PointK256) Hash(bytes []byte) Point {
 	return &PointK256{value}
 }
 
+func (p *PointK256) HashWithDst(bytes, dst []byte, hasher *native.EllipticPointHasher) (Point, error) {
+	if err := checkHashToCurveArgs(hasher, dst); err != nil {
+		return nil, err
+	}
+	value, err := secp256k1.K256PointNew().HashWithDst(bytes, dst, hasher)
+	if err != nil {
+		return nil, err
+	}
+	return &PointK256{value}, nil
+}
+
+func (p *PointK256) EncodeWithDst(bytes, dst []byte, hasher *native.EllipticPointHasher) (Point, error) {
+	if err := checkHashToCurveArgs(hasher, dst); err != nil {
+		return nil, err
+	}
+	value, err := secp256k1.K256PointNew().EncodeWithDst(bytes, dst, hasher)
+	if err != nil {
+		return nil, err
+	}
+	return &PointK256{value}, nil
+}
+
 func (p *PointK256) Identity() Point {
 	return &PointK256{
 		value: secp256k1.K256PointNew().Identity(),
@@ -466,7 +508,7 @@ func (p *PointK256) Mul(rhs Scalar) Point {
 	}
 	r, ok := rhs.(*ScalarK256)
 	if ok {
-		value := secp256k1.K256PointNew().Mul(p.value, r.value)
+		value := secp256k1.ScalarMul(p.value, r.value)
 		return &PointK256{value}
 	} else {
 		return nil
@@ -550,6 +592,20 @@ func (p *PointK256) FromAffineCompressed(bytes []byte) (Point, error) {
 	return &PointK256{value}, nil
 }
 
+func (p *PointK256) IsTorsionFree() bool {
+	// The group has prime order
+	return p.IsOnCurve()
+}
+
+func (p *PointK256) ClearCofactor() Point {
+	// The cofactor is one
+	return p
+}
+
+func (p *PointK256) FromAffineCompressedStrict(bytes []byte) (Point, error) {
+	return fromAffineCompressedStrict(p, bytes)
+}
+
 func (p *PointK256) FromAffineUncompressed(bytes []byte) (Point, error) {
 	var arr [native.FieldBytes]byte
 	if len(bytes) != 65 {
@@ -581,28 +637,49 @@ func (p *PointK256) CurveName() string {
 }
 
 func (p *PointK256) SumOfProducts(points []Point, scalars []Scalar) Point {
+	nPoints, nScalars, ok := k256Products(points, scalars)
+	if !ok {
+		return nil
+	}
+	value, err := secp256k1.SumOfProducts(nPoints, nScalars)
+	if err != nil {
+		return nil
+	}
+	return &PointK256{value}
+}
+
+// SumOfProductsVartime is SumOfProducts in variable time, for public scalars only
+func (p *PointK256) SumOfProductsVartime(points []Point, scalars []Scalar) Point {
+	nPoints, nScalars, ok := k256Products(points, scalars)
+	if !ok {
+		return nil
+	}
+	value, err := secp256k1.SumOfProductsVartime(nPoints, nScalars)
+	if err != nil {
+		return nil
+	}
+	return &PointK256{value}
+}
+
+// k256Products returns the native values of points and scalars
+func k256Products(points []Point, scalars []Scalar) ([]*native.EllipticPoint, []*native.Field, bool) {
 	nPoints := make([]*native.EllipticPoint, len(points))
 	nScalars := make([]*native.Field, len(scalars))
 	for i, pt := range points {
 		ptv, ok := pt.(*PointK256)
 		if !ok {
-			return nil
+			return nil, nil, false
 		}
 		nPoints[i] = ptv.value
 	}
 	for i, sc := range scalars {
 		s, ok := sc.(*ScalarK256)
 		if !ok {
-			return nil
+			return nil, nil, false
 		}
 		nScalars[i] = s.value
 	}
-	value := secp256k1.K256PointNew()
-	_, err := value.SumOfProducts(nPoints, nScalars)
-	if err != nil {
-		return nil
-	}
-	return &PointK256{value}
+	return nPoints, nScalars, true
 }
 
 func (p *PointK256) X() *native.Field {
@@ -390,6 +390,58 @@ func TestPointK256Nil(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestPointK256MulMatchesBtcec(t *testing.T) {
+	for j := 0; j < 25; j++ {
+		pt := new(PointK256).Random(crand.Reader).(*PointK256)
+		sc := new(ScalarK256).Random(crand.Reader)
+		x, y := pt.value.BigInt()
+		ex, ey := btcec.S256().ScalarMult(x, y, sc.Bytes())
+		ax, ay := pt.Mul(sc).(*PointK256).value.BigInt()
+		require.Equal(t, ex, ax)
+		require.Equal(t, ey, ay)
+	}
+}
+
+func TestPointK256SumOfProductsVartime(t *testing.T) {
+	points := make([]Point, 5)
+	scalars := make([]Scalar, 5)
+	for j := 0; j < 10; j++ {
+		for i := range points {
+			points[i] = new(PointK256).Random(crand.Reader)
+			scalars[i] = new(ScalarK256).Random(crand.Reader)
+		}
+		expected := new(PointK256).SumOfProducts(points, scalars)
+		sum, err := SumOfProductsVartime(points, scalars)
+		require.NoError(t, err)
+		require.True(t, expected.Equal(sum))
+	}
+	_, err := SumOfProductsVartime(points[1:], scalars)
+	require.Error(t, err)
+
+	// Mixing curves fails instead of returning nil
+	p256 := P256()
+	_, err = SumOfProductsVartime([]Point{points[0], p256.Point.Generator()}, scalars[:2])
+	require.Error(t, err)
+	_, err = SumOfProductsVartime(points[:2], []Scalar{scalars[0], p256.Scalar.One()})
+	require.Error(t, err)
+
+	// Curves without a variable-time multiplication use Mul and Add
+	g := p256.Point.Generator()
+	sum, err := SumOfProductsVartime([]Point{g, g}, []Scalar{p256.Scalar.New(2), p256.Scalar.New(3)})
+	require.NoError(t, err)
+	require.True(t, sum.Equal(g.Mul(p256.Scalar.New(5))))
+	_, err = SumOfProductsVartime([]Point{g, points[0]}, []Scalar{p256.Scalar.New(2), p256.Scalar.New(3)})
+	require.Error(t, err)
+}
+
+func BenchmarkPointK256Mul(b *testing.B) {
+	pt := new(PointK256).Random(crand.Reader)
+	sc := new(ScalarK256).Random(crand.Reader)
+	for i := 0; i < b.N; i++ {
+		pt.Mul(sc)
+	}
+}
+
 func TestPointK256SumOfProducts(t *testing.T) {
 	lhs := new(PointK256).Generator().Mul(new(ScalarK256).New(50))
 	points := make([]Point, 5)
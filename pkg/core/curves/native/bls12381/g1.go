@@ -577,6 +577,29 @@ func (g1 *G1) Hash(hash *native.EllipticPointHasher, msg, dst []byte) *G1 {
 	return g1.ClearCofactor(g1)
 }
 
+// Encode uses the hasher to map bytes to a valid point with the
+// nonuniform encoding, i.e. a single field element is mapped to the curve
+func (g1 *G1) Encode(hash *native.EllipticPointHasher, msg, dst []byte) *G1 {
+	var u []byte
+	var u0 fp
+	var r0 G1
+
+	switch hash.Type() {
+	case native.XMD:
+		u = native.ExpandMsgXmd(hash, msg, dst, 64)
+	case native.XOF:
+		u = native.ExpandMsgXof(hash, msg, dst, 64)
+	}
+
+	var buf [WideFieldBytes]byte
+	copy(buf[:64], internal.ReverseScalarBytes(u))
+	u0.SetBytesWide(&buf)
+
+	r0.osswu3mod4(&u0)
+	g1.isogenyMap(&r0)
+	return g1.ClearCofactor(g1)
+}
+
 // Identity returns the identity point
 func (g1 *G1) Identity() *G1 {
 	g1.x.SetZero()
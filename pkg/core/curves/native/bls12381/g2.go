@@ -545,6 +545,31 @@ func (g2 *G2) Hash(hash *native.EllipticPointHasher, msg, dst []byte) *G2 {
 	return g2.ClearCofactor(g2)
 }
 
+// Encode uses the hasher to map bytes to a valid point with the
+// nonuniform encoding, i.e. a single field element is mapped to the curve
+func (g2 *G2) Encode(hash *native.EllipticPointHasher, msg, dst []byte) *G2 {
+	var u []byte
+	var u0 fp2
+	var r0 G2
+
+	switch hash.Type() {
+	case native.XMD:
+		u = native.ExpandMsgXmd(hash, msg, dst, 128)
+	case native.XOF:
+		u = native.ExpandMsgXof(hash, msg, dst, 128)
+	}
+
+	var buf [96]byte
+	copy(buf[:64], internal.ReverseScalarBytes(u[:64]))
+	u0.A.SetBytesWide(&buf)
+	copy(buf[:64], internal.ReverseScalarBytes(u[64:]))
+	u0.B.SetBytesWide(&buf)
+
+	r0.sswu(&u0)
+	g2.isogenyMap(&r0)
+	return g2.ClearCofactor(g2)
+}
+
 // Identity returns the identity point
 func (g2 *G2) Identity() *G2 {
 	g2.x.SetZero()
@@ -0,0 +1,394 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package fp
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"math/bits"
+
+	"github.com/TEENet-io/kryptology/internal"
+)
+
+// Limbs is the number of 64-bit limbs of a field element
+const Limbs = 7
+
+// Bytes is the number of bytes of a field element
+const Bytes = 56
+
+// WideBytes is the number of bytes that SetBytesWide reduces
+const WideBytes = 2 * Bytes
+
+// Fp is an element of the Ed448 base field in montgomery form
+type Fp [Limbs]uint64
+
+// modulus representation
+// p = 0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffeffffffffffffffffffffffffffffffffffffffffffffffffffffffff
+var modulus = &Fp{
+	0xffffffffffffffff,
+	0xffffffffffffffff,
+	0xffffffffffffffff,
+	0xfffffffeffffffff,
+	0xffffffffffffffff,
+	0xffffffffffffffff,
+	0xffffffffffffffff,
+}
+
+// r = 2^448 mod p
+var r = &Fp{
+	0x0000000000000001,
+	0x0000000000000000,
+	0x0000000000000000,
+	0x0000000100000000,
+	0x0000000000000000,
+	0x0000000000000000,
+	0x0000000000000000,
+}
+
+// r2 = 2^896 mod p
+var r2 = &Fp{
+	0x0000000000000002,
+	0x0000000000000000,
+	0x0000000000000000,
+	0x0000000300000000,
+	0x0000000000000000,
+	0x0000000000000000,
+	0x0000000000000000,
+}
+
+// r3 = 2^1344 mod p
+var r3 = &Fp{
+	0x0000000000000005,
+	0x0000000000000000,
+	0x0000000000000000,
+	0x0000000800000000,
+	0x0000000000000000,
+	0x0000000000000000,
+	0x0000000000000000,
+}
+
+// inv = -p^{-1} mod 2^64
+const inv = 0x0000000000000001
+
+var biModulus = new(big.Int).SetBytes([]byte{
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	0xff, 0xff, 0xff, 0xfe, 0xff, 0xff, 0xff, 0xff,
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+})
+
+// Cmp returns -1 if fp < rhs
+// 0 if fp == rhs
+// 1 if fp > rhs
+func (fp *Fp) Cmp(rhs *Fp) int {
+	gt := 0
+	lt := 0
+	for i := len(fp) - 1; i >= 0; i-- {
+		// convert to two 64-bit numbers where
+		// the leading bits are zeros and hold no meaning
+		rhsH := rhs[i] >> 32
+		rhsL := rhs[i] & 0xffffffff
+		lhsH := fp[i] >> 32
+		lhsL := fp[i] & 0xffffffff
+
+		gt |= int((rhsH-lhsH)>>32&1) &^ lt
+		lt |= int((lhsH-rhsH)>>32&1) &^ gt
+		gt |= int((rhsL-lhsL)>>32&1) &^ lt
+		lt |= int((lhsL-rhsL)>>32&1) &^ gt
+	}
+	return gt - lt
+}
+
+// Equal returns true if fp == rhs
+func (fp *Fp) Equal(rhs *Fp) bool {
+	t := uint64(0)
+	for i := range fp {
+		t |= fp[i] ^ rhs[i]
+	}
+	return t == 0
+}
+
+// IsZero returns true if fp == 0
+func (fp *Fp) IsZero() bool {
+	t := uint64(0)
+	for i := range fp {
+		t |= fp[i]
+	}
+	return t == 0
+}
+
+// IsOne returns true if fp == R
+func (fp *Fp) IsOne() bool {
+	return fp.Equal(r)
+}
+
+// IsOdd returns true if the canonical value of fp is odd
+func (fp *Fp) IsOdd() bool {
+	tv := new(Fp).fromMontgomery(fp)
+	return tv[0]&0x01 == 0x01
+}
+
+// Set fp == rhs
+func (fp *Fp) Set(rhs *Fp) *Fp {
+	*fp = *rhs
+	return fp
+}
+
+// SetUint64 sets fp == rhs
+func (fp *Fp) SetUint64(rhs uint64) *Fp {
+	t := &Fp{rhs}
+	return fp.toMontgomery(t)
+}
+
+// SetOne fp == R
+func (fp *Fp) SetOne() *Fp {
+	return fp.Set(r)
+}
+
+// SetZero fp == 0
+func (fp *Fp) SetZero() *Fp {
+	*fp = Fp{}
+	return fp
+}
+
+// SetBytesWide takes 112 bytes as input and treats them as a 896-bit number.
+// The number is decomposed into two 448-bit digits with the higher digit multiplied
+// by 2^448. The lower digit is multiplied by r2 and the higher one by r3 = r2 * 2^448,
+// which puts both in montgomery form, and their sum is the reduction of the input.
+func (fp *Fp) SetBytesWide(input *[WideBytes]byte) *Fp {
+	d0 := new(Fp)
+	d1 := new(Fp)
+	for i := range d0 {
+		d0[i] = binary.LittleEndian.Uint64(input[8*i : 8*i+8])
+		d1[i] = binary.LittleEndian.Uint64(input[Bytes+8*i : Bytes+8*i+8])
+	}
+	d0.Mul(d0, r2)
+	d1.Mul(d1, r3)
+	return fp.Add(d0, d1)
+}
+
+// SetBytes attempts to convert a little endian byte representation
+// of a scalar into a `Fp`, failing if input is not canonical
+func (fp *Fp) SetBytes(input *[Bytes]byte) (*Fp, error) {
+	d0 := new(Fp)
+	for i := range d0 {
+		d0[i] = binary.LittleEndian.Uint64(input[8*i : 8*i+8])
+	}
+	if d0.Cmp(modulus) != -1 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	return fp.toMontgomery(d0), nil
+}
+
+// SetBigInt initializes an element from big.Int
+// The value is reduced by the modulus
+func (fp *Fp) SetBigInt(bi *big.Int) *Fp {
+	var buffer [Bytes]byte
+	t := new(big.Int).Set(bi)
+	t.Mod(t, biModulus)
+	t.FillBytes(buffer[:])
+	copy(buffer[:], internal.ReverseScalarBytes(buffer[:]))
+	_, _ = fp.SetBytes(&buffer)
+	return fp
+}
+
+// SetRaw converts a raw array into a field element
+func (fp *Fp) SetRaw(array *[Limbs]uint64) *Fp {
+	return fp.toMontgomery((*Fp)(array))
+}
+
+// Bytes converts this element into a byte representation
+// in little endian byte order
+func (fp *Fp) Bytes() [Bytes]byte {
+	var output [Bytes]byte
+	tv := new(Fp).fromMontgomery(fp)
+	for i := range tv {
+		binary.LittleEndian.PutUint64(output[8*i:8*i+8], tv[i])
+	}
+	return output
+}
+
+// BigInt converts this element into the big.Int struct
+func (fp *Fp) BigInt() *big.Int {
+	buffer := fp.Bytes()
+	return new(big.Int).SetBytes(internal.ReverseScalarBytes(buffer[:]))
+}
+
+// Double this element
+func (fp *Fp) Double(elem *Fp) *Fp {
+	return fp.Add(elem, elem)
+}
+
+// Square this element
+func (fp *Fp) Square(elem *Fp) *Fp {
+	return fp.Mul(elem, elem)
+}
+
+// Sqrt this element, if it exists. If true, then value
+// is a square root. If false, value is a QNR
+func (fp *Fp) Sqrt(elem *Fp) (*Fp, bool) {
+	// p = 3 mod 4 so a square root is elem^((p + 1) / 4)
+	exp := [Limbs]uint64{
+		0x0000000000000000,
+		0x0000000000000000,
+		0x0000000000000000,
+		0xffffffffc0000000,
+		0xffffffffffffffff,
+		0xffffffffffffffff,
+		0x3fffffffffffffff,
+	}
+	z := new(Fp).pow(elem, exp)
+	wasSquare := new(Fp).Square(z).Equal(elem)
+	return fp.Set(z), wasSquare
+}
+
+// Invert this element i.e. compute the multiplicative inverse
+// return false, zero if this element is zero
+func (fp *Fp) Invert(elem *Fp) (*Fp, bool) {
+	// computes elem^(p - 2) mod p
+	exp := [Limbs]uint64{
+		0xfffffffffffffffd,
+		0xffffffffffffffff,
+		0xffffffffffffffff,
+		0xfffffffeffffffff,
+		0xffffffffffffffff,
+		0xffffffffffffffff,
+		0xffffffffffffffff,
+	}
+	return fp.pow(elem, exp), !elem.IsZero()
+}
+
+// Mul returns the result from multiplying this element by rhs
+func (fp *Fp) Mul(lhs, rhs *Fp) *Fp {
+	// Coarsely integrated operand scanning,
+	// see Algorithm 14.36 in the Handbook of Applied Cryptography.
+	// The extra words hold the carries of a full width modulus.
+	var t [Limbs + 2]uint64
+	var carry uint64
+	for i := 0; i < Limbs; i++ {
+		// t += lhs * rhs[i]
+		carry = 0
+		for j := 0; j < Limbs; j++ {
+			t[j], carry = mac(t[j], lhs[j], rhs[i], carry)
+		}
+		t[Limbs], t[Limbs+1] = bits.Add64(t[Limbs], carry, 0)
+
+		// t = (t + k * p) / 2^64
+		k := t[0] * inv
+		_, carry = mac(t[0], k, modulus[0], 0)
+		for j := 1; j < Limbs; j++ {
+			t[j-1], carry = mac(t[j], k, modulus[j], carry)
+		}
+		t[Limbs-1], carry = bits.Add64(t[Limbs], carry, 0)
+		t[Limbs] = t[Limbs+1] + carry
+	}
+	return fp.reduce(&t)
+}
+
+// Sub returns the result from subtracting rhs from this element
+func (fp *Fp) Sub(lhs, rhs *Fp) *Fp {
+	var t Fp
+	var borrow, carry uint64
+	for i := range t {
+		t[i], borrow = bits.Sub64(lhs[i], rhs[i], borrow)
+	}
+	// Add the modulus back if the subtraction underflowed
+	mask := -borrow
+	for i := range t {
+		t[i], carry = bits.Add64(t[i], modulus[i]&mask, carry)
+	}
+	return fp.Set(&t)
+}
+
+// Add returns the result from adding rhs to this element
+func (fp *Fp) Add(lhs, rhs *Fp) *Fp {
+	var t [Limbs + 2]uint64
+	var carry uint64
+	for i := 0; i < Limbs; i++ {
+		t[i], carry = bits.Add64(lhs[i], rhs[i], carry)
+	}
+	t[Limbs] = carry
+	return fp.reduce(&t)
+}
+
+// Neg returns negation of this element
+func (fp *Fp) Neg(elem *Fp) *Fp {
+	return fp.Sub(new(Fp), elem)
+}
+
+// Exp exponentiates this element by exp
+func (fp *Fp) Exp(base, exp *Fp) *Fp {
+	// convert exponent to integer form
+	e := new(Fp).fromMontgomery(exp)
+	return fp.pow(base, *e)
+}
+
+func (fp *Fp) pow(base *Fp, exp [Limbs]uint64) *Fp {
+	res := new(Fp).SetOne()
+	tmp := new(Fp)
+
+	for i := len(exp) - 1; i >= 0; i-- {
+		for j := 63; j >= 0; j-- {
+			res.Square(res)
+			tmp.Mul(res, base)
+			res.CMove(res, tmp, int(exp[i]>>j)&1)
+		}
+	}
+	return fp.Set(res)
+}
+
+// CMove selects lhs if choice == 0 and rhs if choice == 1
+func (fp *Fp) CMove(lhs, rhs *Fp, choice int) *Fp {
+	mask := -uint64(choice & 1)
+	for i := range fp {
+		fp[i] = lhs[i] ^ ((lhs[i] ^ rhs[i]) & mask)
+	}
+	return fp
+}
+
+// ToRaw converts this element into the a [Limbs]uint64
+func (fp *Fp) ToRaw() [Limbs]uint64 {
+	return *new(Fp).fromMontgomery(fp)
+}
+
+// toMontgomery converts a canonical value to montgomery form
+func (fp *Fp) toMontgomery(a *Fp) *Fp {
+	return fp.Mul(a, r2)
+}
+
+// fromMontgomery converts a montgomery form value to its canonical value
+func (fp *Fp) fromMontgomery(a *Fp) *Fp {
+	return fp.Mul(a, &Fp{1})
+}
+
+// reduce sets fp to t mod p for t < 2p
+func (fp *Fp) reduce(t *[Limbs + 2]uint64) *Fp {
+	var lhs, d Fp
+	var borrow uint64
+	copy(lhs[:], t[:Limbs])
+	for i := range d {
+		d[i], borrow = bits.Sub64(lhs[i], modulus[i], borrow)
+	}
+	_, borrow = bits.Sub64(t[Limbs], 0, borrow)
+	// Keep t if subtracting the modulus underflowed
+	return fp.CMove(&d, &lhs, int(borrow))
+}
+
+// mac returns a + b * c + carry as the low and high words
+func mac(a, b, c, carry uint64) (uint64, uint64) {
+	hi, lo := bits.Mul64(b, c)
+	var cc uint64
+	lo, cc = bits.Add64(lo, a, 0)
+	hi += cc
+	lo, cc = bits.Add64(lo, carry, 0)
+	hi += cc
+	return lo, hi
+}
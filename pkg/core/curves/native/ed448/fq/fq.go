@@ -0,0 +1,394 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package fq
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"math/bits"
+
+	"github.com/TEENet-io/kryptology/internal"
+)
+
+// Limbs is the number of 64-bit limbs of a field element
+const Limbs = 7
+
+// Bytes is the number of bytes of a field element
+const Bytes = 56
+
+// WideBytes is the number of bytes that SetBytesWide reduces
+const WideBytes = 2 * Bytes
+
+// Fq is an element of the Ed448 scalar field in montgomery form
+type Fq [Limbs]uint64
+
+// modulus representation
+// q = 0x3fffffffffffffffffffffffffffffffffffffffffffffffffffffff7cca23e9c44edb49aed63690216cc2728dc58f552378c292ab5844f3
+var modulus = &Fq{
+	0x2378c292ab5844f3,
+	0x216cc2728dc58f55,
+	0xc44edb49aed63690,
+	0xffffffff7cca23e9,
+	0xffffffffffffffff,
+	0xffffffffffffffff,
+	0x3fffffffffffffff,
+}
+
+// r = 2^448 mod q
+var r = &Fq{
+	0x721cf5b5529eec34,
+	0x7a4cf635c8e9c2ab,
+	0xeec492d944a725bf,
+	0x000000020cd77058,
+	0x0000000000000000,
+	0x0000000000000000,
+	0x0000000000000000,
+}
+
+// r2 = 2^896 mod q
+var r2 = &Fq{
+	0xe3539257049b9b60,
+	0x7af32c4bc1b195d9,
+	0x0d66de2388ea1859,
+	0xae17cf725ee4d838,
+	0x1a9cc14ba3c47c44,
+	0x2052bcb7e4d070af,
+	0x3402a939f823b729,
+}
+
+// r3 = 2^1344 mod q
+var r3 = &Fq{
+	0x62db79e25f9b74ed,
+	0x32d533584f61d636,
+	0x3e0d0c8b5fa74964,
+	0x178769ed878dfcda,
+	0xe4c71af86754b842,
+	0xed66e7f42bab736d,
+	0x0d30a4f69d3af5f1,
+}
+
+// inv = -q^{-1} mod 2^64
+const inv = 0x03bd440fae918bc5
+
+var biModulus = new(big.Int).SetBytes([]byte{
+	0x3f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	0xff, 0xff, 0xff, 0xff, 0x7c, 0xca, 0x23, 0xe9,
+	0xc4, 0x4e, 0xdb, 0x49, 0xae, 0xd6, 0x36, 0x90,
+	0x21, 0x6c, 0xc2, 0x72, 0x8d, 0xc5, 0x8f, 0x55,
+	0x23, 0x78, 0xc2, 0x92, 0xab, 0x58, 0x44, 0xf3,
+})
+
+// Cmp returns -1 if fq < rhs
+// 0 if fq == rhs
+// 1 if fq > rhs
+func (fq *Fq) Cmp(rhs *Fq) int {
+	gt := 0
+	lt := 0
+	for i := len(fq) - 1; i >= 0; i-- {
+		// convert to two 64-bit numbers where
+		// the leading bits are zeros and hold no meaning
+		rhsH := rhs[i] >> 32
+		rhsL := rhs[i] & 0xffffffff
+		lhsH := fq[i] >> 32
+		lhsL := fq[i] & 0xffffffff
+
+		gt |= int((rhsH-lhsH)>>32&1) &^ lt
+		lt |= int((lhsH-rhsH)>>32&1) &^ gt
+		gt |= int((rhsL-lhsL)>>32&1) &^ lt
+		lt |= int((lhsL-rhsL)>>32&1) &^ gt
+	}
+	return gt - lt
+}
+
+// Equal returns true if fq == rhs
+func (fq *Fq) Equal(rhs *Fq) bool {
+	t := uint64(0)
+	for i := range fq {
+		t |= fq[i] ^ rhs[i]
+	}
+	return t == 0
+}
+
+// IsZero returns true if fq == 0
+func (fq *Fq) IsZero() bool {
+	t := uint64(0)
+	for i := range fq {
+		t |= fq[i]
+	}
+	return t == 0
+}
+
+// IsOne returns true if fq == R
+func (fq *Fq) IsOne() bool {
+	return fq.Equal(r)
+}
+
+// IsOdd returns true if the canonical value of fq is odd
+func (fq *Fq) IsOdd() bool {
+	tv := new(Fq).fromMontgomery(fq)
+	return tv[0]&0x01 == 0x01
+}
+
+// Set fq == rhs
+func (fq *Fq) Set(rhs *Fq) *Fq {
+	*fq = *rhs
+	return fq
+}
+
+// SetUint64 sets fq == rhs
+func (fq *Fq) SetUint64(rhs uint64) *Fq {
+	t := &Fq{rhs}
+	return fq.toMontgomery(t)
+}
+
+// SetOne fq == R
+func (fq *Fq) SetOne() *Fq {
+	return fq.Set(r)
+}
+
+// SetZero fq == 0
+func (fq *Fq) SetZero() *Fq {
+	*fq = Fq{}
+	return fq
+}
+
+// SetBytesWide takes 112 bytes as input and treats them as a 896-bit number.
+// The number is decomposed into two 448-bit digits with the higher digit multiplied
+// by 2^448. The lower digit is multiplied by r2 and the higher one by r3 = r2 * 2^448,
+// which puts both in montgomery form, and their sum is the reduction of the input.
+func (fq *Fq) SetBytesWide(input *[WideBytes]byte) *Fq {
+	d0 := new(Fq)
+	d1 := new(Fq)
+	for i := range d0 {
+		d0[i] = binary.LittleEndian.Uint64(input[8*i : 8*i+8])
+		d1[i] = binary.LittleEndian.Uint64(input[Bytes+8*i : Bytes+8*i+8])
+	}
+	d0.Mul(d0, r2)
+	d1.Mul(d1, r3)
+	return fq.Add(d0, d1)
+}
+
+// SetBytes attempts to convert a little endian byte representation
+// of a scalar into a `Fq`, failing if input is not canonical
+func (fq *Fq) SetBytes(input *[Bytes]byte) (*Fq, error) {
+	d0 := new(Fq)
+	for i := range d0 {
+		d0[i] = binary.LittleEndian.Uint64(input[8*i : 8*i+8])
+	}
+	if d0.Cmp(modulus) != -1 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	return fq.toMontgomery(d0), nil
+}
+
+// SetBigInt initializes an element from big.Int
+// The value is reduced by the modulus
+func (fq *Fq) SetBigInt(bi *big.Int) *Fq {
+	var buffer [Bytes]byte
+	t := new(big.Int).Set(bi)
+	t.Mod(t, biModulus)
+	t.FillBytes(buffer[:])
+	copy(buffer[:], internal.ReverseScalarBytes(buffer[:]))
+	_, _ = fq.SetBytes(&buffer)
+	return fq
+}
+
+// SetRaw converts a raw array into a field element
+func (fq *Fq) SetRaw(array *[Limbs]uint64) *Fq {
+	return fq.toMontgomery((*Fq)(array))
+}
+
+// Bytes converts this element into a byte representation
+// in little endian byte order
+func (fq *Fq) Bytes() [Bytes]byte {
+	var output [Bytes]byte
+	tv := new(Fq).fromMontgomery(fq)
+	for i := range tv {
+		binary.LittleEndian.PutUint64(output[8*i:8*i+8], tv[i])
+	}
+	return output
+}
+
+// BigInt converts this element into the big.Int struct
+func (fq *Fq) BigInt() *big.Int {
+	buffer := fq.Bytes()
+	return new(big.Int).SetBytes(internal.ReverseScalarBytes(buffer[:]))
+}
+
+// Double this element
+func (fq *Fq) Double(elem *Fq) *Fq {
+	return fq.Add(elem, elem)
+}
+
+// Square this element
+func (fq *Fq) Square(elem *Fq) *Fq {
+	return fq.Mul(elem, elem)
+}
+
+// Sqrt this element, if it exists. If true, then value
+// is a square root. If false, value is a QNR
+func (fq *Fq) Sqrt(elem *Fq) (*Fq, bool) {
+	// q = 3 mod 4 so a square root is elem^((q + 1) / 4)
+	exp := [Limbs]uint64{
+		0x48de30a4aad6113d,
+		0x085b309ca37163d5,
+		0x7113b6d26bb58da4,
+		0xffffffffdf3288fa,
+		0xffffffffffffffff,
+		0xffffffffffffffff,
+		0x0fffffffffffffff,
+	}
+	z := new(Fq).pow(elem, exp)
+	wasSquare := new(Fq).Square(z).Equal(elem)
+	return fq.Set(z), wasSquare
+}
+
+// Invert this element i.e. compute the multiplicative inverse
+// return false, zero if this element is zero
+func (fq *Fq) Invert(elem *Fq) (*Fq, bool) {
+	// computes elem^(q - 2) mod q
+	exp := [Limbs]uint64{
+		0x2378c292ab5844f1,
+		0x216cc2728dc58f55,
+		0xc44edb49aed63690,
+		0xffffffff7cca23e9,
+		0xffffffffffffffff,
+		0xffffffffffffffff,
+		0x3fffffffffffffff,
+	}
+	return fq.pow(elem, exp), !elem.IsZero()
+}
+
+// Mul returns the result from multiplying this element by rhs
+func (fq *Fq) Mul(lhs, rhs *Fq) *Fq {
+	// Coarsely integrated operand scanning,
+	// see Algorithm 14.36 in the Handbook of Applied Cryptography.
+	// The extra words hold the carries of a full width modulus.
+	var t [Limbs + 2]uint64
+	var carry uint64
+	for i := 0; i < Limbs; i++ {
+		// t += lhs * rhs[i]
+		carry = 0
+		for j := 0; j < Limbs; j++ {
+			t[j], carry = mac(t[j], lhs[j], rhs[i], carry)
+		}
+		t[Limbs], t[Limbs+1] = bits.Add64(t[Limbs], carry, 0)
+
+		// t = (t + k * q) / 2^64
+		k := t[0] * inv
+		_, carry = mac(t[0], k, modulus[0], 0)
+		for j := 1; j < Limbs; j++ {
+			t[j-1], carry = mac(t[j], k, modulus[j], carry)
+		}
+		t[Limbs-1], carry = bits.Add64(t[Limbs], carry, 0)
+		t[Limbs] = t[Limbs+1] + carry
+	}
+	return fq.reduce(&t)
+}
+
+// Sub returns the result from subtracting rhs from this element
+func (fq *Fq) Sub(lhs, rhs *Fq) *Fq {
+	var t Fq
+	var borrow, carry uint64
+	for i := range t {
+		t[i], borrow = bits.Sub64(lhs[i], rhs[i], borrow)
+	}
+	// Add the modulus back if the subtraction underflowed
+	mask := -borrow
+	for i := range t {
+		t[i], carry = bits.Add64(t[i], modulus[i]&mask, carry)
+	}
+	return fq.Set(&t)
+}
+
+// Add returns the result from adding rhs to this element
+func (fq *Fq) Add(lhs, rhs *Fq) *Fq {
+	var t [Limbs + 2]uint64
+	var carry uint64
+	for i := 0; i < Limbs; i++ {
+		t[i], carry = bits.Add64(lhs[i], rhs[i], carry)
+	}
+	t[Limbs] = carry
+	return fq.reduce(&t)
+}
+
+// Neg returns negation of this element
+func (fq *Fq) Neg(elem *Fq) *Fq {
+	return fq.Sub(new(Fq), elem)
+}
+
+// Exp exponentiates this element by exp
+func (fq *Fq) Exp(base, exp *Fq) *Fq {
+	// convert exponent to integer form
+	e := new(Fq).fromMontgomery(exp)
+	return fq.pow(base, *e)
+}
+
+func (fq *Fq) pow(base *Fq, exp [Limbs]uint64) *Fq {
+	res := new(Fq).SetOne()
+	tmp := new(Fq)
+
+	for i := len(exp) - 1; i >= 0; i-- {
+		for j := 63; j >= 0; j-- {
+			res.Square(res)
+			tmp.Mul(res, base)
+			res.CMove(res, tmp, int(exp[i]>>j)&1)
+		}
+	}
+	return fq.Set(res)
+}
+
+// CMove selects lhs if choice == 0 and rhs if choice == 1
+func (fq *Fq) CMove(lhs, rhs *Fq, choice int) *Fq {
+	mask := -uint64(choice & 1)
+	for i := range fq {
+		fq[i] = lhs[i] ^ ((lhs[i] ^ rhs[i]) & mask)
+	}
+	return fq
+}
+
+// ToRaw converts this element into the a [Limbs]uint64
+func (fq *Fq) ToRaw() [Limbs]uint64 {
+	return *new(Fq).fromMontgomery(fq)
+}
+
+// toMontgomery converts a canonical value to montgomery form
+func (fq *Fq) toMontgomery(a *Fq) *Fq {
+	return fq.Mul(a, r2)
+}
+
+// fromMontgomery converts a montgomery form value to its canonical value
+func (fq *Fq) fromMontgomery(a *Fq) *Fq {
+	return fq.Mul(a, &Fq{1})
+}
+
+// reduce sets fq to t mod q for t < 2q
+func (fq *Fq) reduce(t *[Limbs + 2]uint64) *Fq {
+	var lhs, d Fq
+	var borrow uint64
+	copy(lhs[:], t[:Limbs])
+	for i := range d {
+		d[i], borrow = bits.Sub64(lhs[i], modulus[i], borrow)
+	}
+	_, borrow = bits.Sub64(t[Limbs], 0, borrow)
+	// Keep t if subtracting the modulus underflowed
+	return fq.CMove(&d, &lhs, int(borrow))
+}
+
+// mac returns a + b * c + carry as the low and high words
+func mac(a, b, c, carry uint64) (uint64, uint64) {
+	hi, lo := bits.Mul64(b, c)
+	var cc uint64
+	lo, cc = bits.Add64(lo, a, 0)
+	hi += cc
+	lo, cc = bits.Add64(lo, carry, 0)
+	hi += cc
+	return lo, hi
+}
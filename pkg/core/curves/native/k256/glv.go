@@ -0,0 +1,323 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package k256
+
+import (
+	"fmt"
+	"math/bits"
+	"sync"
+
+	"github.com/TEENet-io/kryptology/pkg/core/curves/native"
+	"github.com/TEENet-io/kryptology/pkg/core/curves/native/k256/fp"
+	"github.com/TEENet-io/kryptology/pkg/core/curves/native/k256/fq"
+)
+
+// secp256k1 has the efficiently computable endomorphism φ(x, y) = (βx, y) = λ(x, y)
+// for cube roots of unity β mod p and λ mod n. Gallant-Lambert-Vanstone (GLV) splits a scalar
+// k = k1 + k2λ mod n into halves of at most 128 bits, so that kP = k1P + k2φ(P) takes half as many
+// doublings. The decomposition follows libsecp256k1's secp256k1_scalar_split_lambda.
+
+const (
+	// glvWindow is the width of the signed windows of the constant-time multiplications
+	glvWindow = 4
+	// glvDigits is the number of signed windows of a 128-bit half, including the final carry
+	glvDigits = 128/glvWindow + 1
+	// glvTableSize holds 0P, 1P, ..., 8P for the signed digits -8 ≤ d ≤ 8
+	glvTableSize = 1<<(glvWindow-1) + 1
+	// wnafWidth is the width of the wNAF of the variable-time multiplications
+	wnafWidth = 5
+	// wnafTableSize holds P, 3P, ..., 15P for the odd wNAF digits
+	wnafTableSize = 1 << (wnafWidth - 2)
+)
+
+var (
+	glvInitOnce sync.Once
+	glvParams   struct {
+		// beta is the cube root of unity mod p of φ
+		beta *native.Field
+		// negLambda is -λ mod n
+		negLambda *native.Field
+		// minusB1 and minusB2 are -b1 and -b2 mod n for the lattice basis (a1, b1), (a2, b2)
+		minusB1, minusB2 *native.Field
+	}
+	// glvG1 and glvG2 are round(2^384 b2 / n) and round(2^384 (-b1) / n)
+	glvG1 = [native.FieldLimbs]uint64{0xe893209a45dbb031, 0x3daa8a1471e8ca7f, 0xe86c90e49284eb15, 0x3086d221a7d46bcd}
+	glvG2 = [native.FieldLimbs]uint64{0x1571b4ae8ac47f71, 0x221208ac9df506c6, 0x6f547fa90abfe4c4, 0xe4437ed6010e8828}
+)
+
+func glvParamsInit() {
+	glvParams.beta = fp.K256FpNew().SetLimbs(&[native.FieldLimbs]uint64{
+		0xc1396c28719501ee, 0x9cf0497512f58995, 0x6e64479eac3434e9, 0x7ae96a2b657c0710,
+	})
+	glvParams.negLambda = fq.K256FqNew().SetLimbs(&[native.FieldLimbs]uint64{
+		0xe0cfc810b51283cf, 0xa880b9fc8ec739c2, 0x5ad9e3fd77ed9ba4, 0xac9c52b33fa3cf1f,
+	})
+	glvParams.minusB1 = fq.K256FqNew().SetLimbs(&[native.FieldLimbs]uint64{
+		0x6f547fa90abfe4c3, 0xe4437ed6010e8828, 0x0000000000000000, 0x0000000000000000,
+	})
+	glvParams.minusB2 = fq.K256FqNew().SetLimbs(&[native.FieldLimbs]uint64{
+		0xd765cda83db1562c, 0x8a280ac50774346d, 0xfffffffffffffffe, 0xffffffffffffffff,
+	})
+}
+
+func getGlvParams() {
+	glvInitOnce.Do(glvParamsInit)
+}
+
+// ScalarMul computes scalar * point with the GLV endomorphism in constant time,
+// so it is safe for secret scalars
+func ScalarMul(point *native.EllipticPoint, scalar *native.Field) *native.EllipticPoint {
+	out, _ := SumOfProducts([]*native.EllipticPoint{point}, []*native.Field{scalar})
+	return out
+}
+
+// SumOfProducts computes ∑ scalars[i] * points[i] with the GLV endomorphism and interleaved
+// signed fixed windows in constant time, so it is safe for secret scalars
+func SumOfProducts(points []*native.EllipticPoint, scalars []*native.Field) (*native.EllipticPoint, error) {
+	if len(points) != len(scalars) {
+		return nil, fmt.Errorf("length mismatch")
+	}
+	getGlvParams()
+	var arithmetic k256PointArithmetic
+	tables := make([][glvTableSize]*native.EllipticPoint, len(points))
+	digits := make([][2][glvDigits]int, len(points))
+	signs := make([][2]int, len(points))
+	for i, point := range points {
+		k1, k2, neg1, neg2 := glvSplit(scalars[i])
+		digits[i][0] = glvRecode(k1)
+		digits[i][1] = glvRecode(k2)
+		signs[i] = [2]int{neg1, neg2}
+		tables[i][0] = K256PointNew().Identity()
+		tables[i][1] = K256PointNew().Set(point)
+		for j := 2; j < glvTableSize; j++ {
+			tables[i][j] = K256PointNew()
+			arithmetic.Add(tables[i][j], tables[i][j-1], point)
+		}
+	}
+
+	out := K256PointNew().Identity()
+	t := K256PointNew()
+	for w := glvDigits - 1; w >= 0; w-- {
+		if w < glvDigits-1 {
+			for j := 0; j < glvWindow; j++ {
+				arithmetic.Double(out, out)
+			}
+		}
+		for i := range tables {
+			glvLookup(t, &tables[i], digits[i][0][w], signs[i][0])
+			arithmetic.Add(out, out, t)
+			glvLookup(t, &tables[i], digits[i][1][w], signs[i][1])
+			t.X.Mul(t.X, glvParams.beta)
+			arithmetic.Add(out, out, t)
+		}
+	}
+	return out, nil
+}
+
+// SumOfProductsVartime computes ∑ scalars[i] * points[i] with the GLV endomorphism and
+// interleaved wNAF. It takes variable time, so it must only be used with public scalars,
+// e.g. to verify signatures and proofs.
+func SumOfProductsVartime(points []*native.EllipticPoint, scalars []*native.Field) (*native.EllipticPoint, error) {
+	if len(points) != len(scalars) {
+		return nil, fmt.Errorf("length mismatch")
+	}
+	getGlvParams()
+	var arithmetic k256PointArithmetic
+	type term struct {
+		digits []int
+		table  *[wnafTableSize]*native.EllipticPoint
+	}
+	terms := make([]term, 0, 2*len(points))
+	length := 0
+	for i, point := range points {
+		k1, k2, neg1, neg2 := glvSplit(scalars[i])
+		base := K256PointNew().Set(point)
+		if neg1 == 1 {
+			base.Neg(base)
+		}
+		table := wnafTable(base)
+		terms = append(terms, term{digits: wnaf(k1), table: table})
+
+		// φ(±P) is the table of ±P with x multiplied by β
+		phiTable := new([wnafTableSize]*native.EllipticPoint)
+		for j, p := range table {
+			phiTable[j] = K256PointNew().Set(p)
+			phiTable[j].X.Mul(phiTable[j].X, glvParams.beta)
+			if neg1 != neg2 {
+				phiTable[j].Neg(phiTable[j])
+			}
+		}
+		terms = append(terms, term{digits: wnaf(k2), table: phiTable})
+	}
+	for _, t := range terms {
+		if len(t.digits) > length {
+			length = len(t.digits)
+		}
+	}
+
+	out := K256PointNew().Identity()
+	neg := K256PointNew()
+	for i := length - 1; i >= 0; i-- {
+		arithmetic.Double(out, out)
+		for _, t := range terms {
+			if i >= len(t.digits) || t.digits[i] == 0 {
+				continue
+			}
+			d := t.digits[i]
+			if d > 0 {
+				arithmetic.Add(out, out, t.table[d>>1])
+			} else {
+				p := t.table[(-d)>>1]
+				neg.X.Set(p.X)
+				neg.Y.Neg(p.Y)
+				neg.Z.Set(p.Z)
+				arithmetic.Add(out, out, neg)
+			}
+		}
+	}
+	return out, nil
+}
+
+// glvSplit returns |k1|, |k2| < 2^128 and their signs for scalar = k1 + k2λ mod n
+func glvSplit(scalar *native.Field) (k1, k2 [2]uint64, neg1, neg2 int) {
+	getGlvParams()
+	k := scalar.Raw()
+	c1 := mulShiftRound(&k, &glvG1)
+	c2 := mulShiftRound(&k, &glvG2)
+
+	// k2 = -(c1 b1 + c2 b2) and k1 = k - k2 λ
+	r2 := fq.K256FqNew().SetLimbs(&c1)
+	r2.Mul(r2, glvParams.minusB1)
+	t := fq.K256FqNew().SetLimbs(&c2)
+	t.Mul(t, glvParams.minusB2)
+	r2.Add(r2, t)
+	r1 := fq.K256FqNew().Mul(r2, glvParams.negLambda)
+	r1.Add(r1, scalar)
+
+	k1, neg1 = glvHalf(r1)
+	k2, neg2 = glvHalf(r2)
+	return k1, k2, neg1, neg2
+}
+
+// glvHalf returns the magnitude and the sign of r, which is either less than 2^128 or
+// greater than n - 2^128
+func glvHalf(r *native.Field) ([2]uint64, int) {
+	pos := r.Raw()
+	neg := fq.K256FqNew().Neg(r).Raw()
+	high := pos[2] | pos[3]
+	isNeg := int((high | -high) >> 63)
+	mask := -uint64(isNeg)
+	return [2]uint64{
+		pos[0] ^ (mask & (pos[0] ^ neg[0])),
+		pos[1] ^ (mask & (pos[1] ^ neg[1])),
+	}, isNeg
+}
+
+// mulShiftRound returns round(a b / 2^384) for a b < 2^512
+func mulShiftRound(a, b *[native.FieldLimbs]uint64) [native.FieldLimbs]uint64 {
+	var t [2 * native.FieldLimbs]uint64
+	for i := 0; i < native.FieldLimbs; i++ {
+		var carry uint64
+		for j := 0; j < native.FieldLimbs; j++ {
+			hi, lo := bits.Mul64(a[i], b[j])
+			var c uint64
+			lo, c = bits.Add64(lo, t[i+j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+			t[i+j] = lo
+			carry = hi
+		}
+		t[i+native.FieldLimbs] = carry
+	}
+	// Bit 383 rounds the quotient
+	lo, c := bits.Add64(t[6], t[5]>>63, 0)
+	hi, _ := bits.Add64(t[7], 0, c)
+	return [native.FieldLimbs]uint64{lo, hi, 0, 0}
+}
+
+// glvRecode writes k < 2^128 as ∑ d_i 16^i with signed digits -8 ≤ d_i ≤ 8 in constant time
+func glvRecode(k [2]uint64) [glvDigits]int {
+	var digits [glvDigits]int
+	carry := 0
+	for i := 0; i < glvDigits-1; i++ {
+		bit := i * glvWindow
+		v := int(k[bit/64]>>(bit%64)&(1<<glvWindow-1)) + carry
+		carry = (v + 1<<(glvWindow-1)) >> glvWindow
+		digits[i] = v - carry<<glvWindow
+	}
+	digits[glvDigits-1] = carry
+	return digits
+}
+
+// glvLookup sets out to d * table[1], negated if negate is 1, without branching on d
+func glvLookup(out *native.EllipticPoint, table *[glvTableSize]*native.EllipticPoint, d, negate int) {
+	sign := int(uint64(d) >> 63)
+	abs := (d ^ -sign) + sign
+	out.X.Set(table[0].X)
+	out.Y.Set(table[0].Y)
+	out.Z.Set(table[0].Z)
+	for j := 1; j < glvTableSize; j++ {
+		choice := ctEqual(abs, j)
+		out.X.CMove(out.X, table[j].X, choice)
+		out.Y.CMove(out.Y, table[j].Y, choice)
+		out.Z.CMove(out.Z, table[j].Z, choice)
+	}
+	negY := fp.K256FpNew().Neg(out.Y)
+	out.Y.CMove(out.Y, negY, sign^negate)
+}
+
+// ctEqual returns 1 if a == b and 0 otherwise without branching
+func ctEqual(a, b int) int {
+	x := uint64(a ^ b)
+	return int(1 ^ (x|-x)>>63)
+}
+
+// wnafTable returns P, 3P, ..., (2 wnafTableSize - 1)P
+func wnafTable(point *native.EllipticPoint) *[wnafTableSize]*native.EllipticPoint {
+	var arithmetic k256PointArithmetic
+	table := new([wnafTableSize]*native.EllipticPoint)
+	double := K256PointNew()
+	arithmetic.Double(double, point)
+	table[0] = K256PointNew().Set(point)
+	for j := 1; j < wnafTableSize; j++ {
+		table[j] = K256PointNew()
+		arithmetic.Add(table[j], table[j-1], double)
+	}
+	return table
+}
+
+// wnaf returns the width-wnafWidth non-adjacent form of k, least significant digit first
+func wnaf(k [2]uint64) []int {
+	x := [3]uint64{k[0], k[1], 0}
+	digits := make([]int, 0, 129)
+	for x[0]|x[1]|x[2] != 0 {
+		d := 0
+		if x[0]&1 == 1 {
+			d = int(x[0] & (1<<wnafWidth - 1))
+			if d >= 1<<(wnafWidth-1) {
+				d -= 1 << wnafWidth
+			}
+			// x -= d
+			var b uint64
+			if d > 0 {
+				x[0], b = bits.Sub64(x[0], uint64(d), 0)
+				x[1], b = bits.Sub64(x[1], 0, b)
+				x[2], _ = bits.Sub64(x[2], 0, b)
+			} else {
+				x[0], b = bits.Add64(x[0], uint64(-d), 0)
+				x[1], b = bits.Add64(x[1], 0, b)
+				x[2], _ = bits.Add64(x[2], 0, b)
+			}
+		}
+		digits = append(digits, d)
+		x[0] = x[0]>>1 | x[1]<<63
+		x[1] = x[1]>>1 | x[2]<<63
+		x[2] >>= 1
+	}
+	return digits
+}
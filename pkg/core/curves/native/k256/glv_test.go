@@ -0,0 +1,139 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package k256_test
+
+import (
+	crand "crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/TEENet-io/kryptology/pkg/core/curves/native"
+	"github.com/TEENet-io/kryptology/pkg/core/curves/native/k256"
+	"github.com/TEENet-io/kryptology/pkg/core/curves/native/k256/fq"
+)
+
+// glvTestScalars are edge cases of the GLV decomposition followed by random scalars
+func glvTestScalars(t testing.TB, random int) []*native.Field {
+	n := fq.K256FqNew().Params.BiModulus
+	lambda, _ := new(big.Int).SetString("5363ad4cc05c30e0a5261c028812645a122e22ea20816678df02967c1b23bd72", 16)
+	values := []*big.Int{
+		big.NewInt(0),
+		big.NewInt(1),
+		big.NewInt(2),
+		new(big.Int).Sub(n, big.NewInt(1)),
+		new(big.Int).Rsh(n, 1),
+		new(big.Int).Lsh(big.NewInt(1), 128),
+		new(big.Int).Sub(n, new(big.Int).Lsh(big.NewInt(1), 128)),
+		lambda,
+		new(big.Int).Sub(n, lambda),
+		new(big.Int).Lsh(big.NewInt(1), 255),
+	}
+	for i := 0; i < random; i++ {
+		v, err := crand.Int(crand.Reader, n)
+		require.NoError(t, err)
+		values = append(values, v)
+	}
+	scalars := make([]*native.Field, len(values))
+	for i, v := range values {
+		scalars[i] = fq.K256FqNew().SetBigInt(v)
+	}
+	return scalars
+}
+
+func randomPoint(t testing.TB) *native.EllipticPoint {
+	var b [32]byte
+	_, err := crand.Read(b[:])
+	require.NoError(t, err)
+	p, err := k256.K256PointNew().Hash(b[:], native.EllipticPointHasherSha256())
+	require.NoError(t, err)
+	return p
+}
+
+func TestScalarMul(t *testing.T) {
+	points := []*native.EllipticPoint{
+		k256.K256PointNew().Generator(),
+		randomPoint(t),
+		k256.K256PointNew().Identity(),
+	}
+	for _, point := range points {
+		for _, scalar := range glvTestScalars(t, 20) {
+			expected := k256.K256PointNew().Mul(point, scalar)
+			require.Equal(t, 1, k256.ScalarMul(point, scalar).Equal(expected))
+			actual, err := k256.SumOfProductsVartime([]*native.EllipticPoint{point}, []*native.Field{scalar})
+			require.NoError(t, err)
+			require.Equal(t, 1, actual.Equal(expected))
+		}
+	}
+}
+
+func TestSumOfProducts(t *testing.T) {
+	scalars := glvTestScalars(t, 6)
+	points := make([]*native.EllipticPoint, len(scalars))
+	expected := k256.K256PointNew().Identity()
+	for i := range points {
+		points[i] = randomPoint(t)
+		expected.Add(expected, k256.K256PointNew().Mul(points[i], scalars[i]))
+	}
+	actual, err := k256.SumOfProducts(points, scalars)
+	require.NoError(t, err)
+	require.Equal(t, 1, actual.Equal(expected))
+	actual, err = k256.SumOfProductsVartime(points, scalars)
+	require.NoError(t, err)
+	require.Equal(t, 1, actual.Equal(expected))
+
+	_, err = k256.SumOfProducts(points[1:], scalars)
+	require.Error(t, err)
+	_, err = k256.SumOfProductsVartime(points[1:], scalars)
+	require.Error(t, err)
+}
+
+func BenchmarkScalarMul(b *testing.B) {
+	point := randomPoint(b)
+	scalar := glvTestScalars(b, 1)[10]
+	b.Run("Generic", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			k256.K256PointNew().Mul(point, scalar)
+		}
+	})
+	b.Run("GLV", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			k256.ScalarMul(point, scalar)
+		}
+	})
+	b.Run("GLV vartime", func(b *testing.B) {
+		points := []*native.EllipticPoint{point}
+		scalars := []*native.Field{scalar}
+		for i := 0; i < b.N; i++ {
+			_, _ = k256.SumOfProductsVartime(points, scalars)
+		}
+	})
+}
+
+func BenchmarkSumOfProducts(b *testing.B) {
+	scalars := glvTestScalars(b, 6)
+	points := make([]*native.EllipticPoint, len(scalars))
+	for i := range points {
+		points[i] = randomPoint(b)
+	}
+	b.Run("Generic", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = k256.K256PointNew().SumOfProducts(points, scalars)
+		}
+	})
+	b.Run("GLV", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = k256.SumOfProducts(points, scalars)
+		}
+	})
+	b.Run("GLV vartime", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = k256.SumOfProductsVartime(points, scalars)
+		}
+	})
+}
@@ -1,6 +1,7 @@
 package k256
 
 import (
+	"fmt"
 	"sync"
 
 	"github.com/TEENet-io/kryptology/internal"
@@ -244,24 +245,15 @@ func getK256PointIsogenyParams() *native.IsogenyParams {
 type k256PointArithmetic struct{}
 
 func (k k256PointArithmetic) Hash(out *native.EllipticPoint, hash *native.EllipticPointHasher, msg, dst []byte) error {
-	var u []byte
+	u, err := k256HashToField(hash, msg, dst, 2)
+	if err != nil {
+		return err
+	}
 	sswuParams := getK256PointSswuParams()
 	isoParams := getK256PointIsogenyParams()
 
-	switch hash.Type() {
-	case native.XMD:
-		u = native.ExpandMsgXmd(hash, msg, dst, 96)
-	case native.XOF:
-		u = native.ExpandMsgXof(hash, msg, dst, 96)
-	}
-	var buf [64]byte
-	copy(buf[:48], internal.ReverseScalarBytes(u[:48]))
-	u0 := fp.K256FpNew().SetBytesWide(&buf)
-	copy(buf[:48], internal.ReverseScalarBytes(u[48:]))
-	u1 := fp.K256FpNew().SetBytesWide(&buf)
-
-	r0x, r0y := sswuParams.Osswu3mod4(u0)
-	r1x, r1y := sswuParams.Osswu3mod4(u1)
+	r0x, r0y := sswuParams.Osswu3mod4(u[0])
+	r1x, r1y := sswuParams.Osswu3mod4(u[1])
 	q0x, q0y := isoParams.Map(r0x, r0y)
 	q1x, q1y := isoParams.Map(r1x, r1y)
 	out.X = q0x
@@ -276,6 +268,41 @@ func (k k256PointArithmetic) Hash(out *native.EllipticPoint, hash *native.Ellipt
 	return nil
 }
 
+func (k k256PointArithmetic) Encode(out *native.EllipticPoint, hash *native.EllipticPointHasher, msg, dst []byte) error {
+	u, err := k256HashToField(hash, msg, dst, 1)
+	if err != nil {
+		return err
+	}
+	sswuParams := getK256PointSswuParams()
+	isoParams := getK256PointIsogenyParams()
+
+	r0x, r0y := sswuParams.Osswu3mod4(u[0])
+	out.X, out.Y = isoParams.Map(r0x, r0y)
+	out.Z.SetOne()
+	return nil
+}
+
+// k256HashToField is hash_to_field of RFC 9380 with L = 48
+func k256HashToField(hash *native.EllipticPointHasher, msg, dst []byte, count int) ([]*native.Field, error) {
+	const l = 48
+	var u []byte
+	switch hash.Type() {
+	case native.XMD:
+		u = native.ExpandMsgXmd(hash, msg, dst, count*l)
+	case native.XOF:
+		u = native.ExpandMsgXof(hash, msg, dst, count*l)
+	default:
+		return nil, fmt.Errorf("unsupported hash type %s", hash.Type())
+	}
+	out := make([]*native.Field, count)
+	var buf [64]byte
+	for i := range out {
+		copy(buf[:l], internal.ReverseScalarBytes(u[i*l:(i+1)*l]))
+		out[i] = fp.K256FpNew().SetBytesWide(&buf)
+	}
+	return out, nil
+}
+
 func (k k256PointArithmetic) Double(out, arg *native.EllipticPoint) {
 	// Addition formula from Renes-Costello-Batina 2015
 	// (https://eprint.iacr.org/2015/1060 Algorithm 9)
@@ -1,6 +1,7 @@
 package p256
 
 import (
+	"fmt"
 	"sync"
 
 	"github.com/TEENet-io/kryptology/internal"
@@ -102,23 +103,14 @@ func p256PointSswuParamsInit() {
 type p256PointArithmetic struct{}
 
 func (k p256PointArithmetic) Hash(out *native.EllipticPoint, hash *native.EllipticPointHasher, msg, dst []byte) error {
-	var u []byte
-	sswuParams := getP256PointSswuParams()
-
-	switch hash.Type() {
-	case native.XMD:
-		u = native.ExpandMsgXmd(hash, msg, dst, 96)
-	case native.XOF:
-		u = native.ExpandMsgXof(hash, msg, dst, 96)
+	u, err := p256HashToField(hash, msg, dst, 2)
+	if err != nil {
+		return err
 	}
-	var buf [64]byte
-	copy(buf[:48], internal.ReverseScalarBytes(u[:48]))
-	u0 := fp.P256FpNew().SetBytesWide(&buf)
-	copy(buf[:48], internal.ReverseScalarBytes(u[48:]))
-	u1 := fp.P256FpNew().SetBytesWide(&buf)
+	sswuParams := getP256PointSswuParams()
 
-	q0x, q0y := sswuParams.Osswu3mod4(u0)
-	q1x, q1y := sswuParams.Osswu3mod4(u1)
+	q0x, q0y := sswuParams.Osswu3mod4(u[0])
+	q1x, q1y := sswuParams.Osswu3mod4(u[1])
 	out.X = q0x
 	out.Y = q0y
 	out.Z.SetOne()
@@ -131,6 +123,39 @@ func (k p256PointArithmetic) Hash(out *native.EllipticPoint, hash *native.Ellipt
 	return nil
 }
 
+func (k p256PointArithmetic) Encode(out *native.EllipticPoint, hash *native.EllipticPointHasher, msg, dst []byte) error {
+	u, err := p256HashToField(hash, msg, dst, 1)
+	if err != nil {
+		return err
+	}
+	sswuParams := getP256PointSswuParams()
+
+	out.X, out.Y = sswuParams.Osswu3mod4(u[0])
+	out.Z.SetOne()
+	return nil
+}
+
+// p256HashToField is hash_to_field of RFC 9380 with L = 48
+func p256HashToField(hash *native.EllipticPointHasher, msg, dst []byte, count int) ([]*native.Field, error) {
+	const l = 48
+	var u []byte
+	switch hash.Type() {
+	case native.XMD:
+		u = native.ExpandMsgXmd(hash, msg, dst, count*l)
+	case native.XOF:
+		u = native.ExpandMsgXof(hash, msg, dst, count*l)
+	default:
+		return nil, fmt.Errorf("unsupported hash type %s", hash.Type())
+	}
+	out := make([]*native.Field, count)
+	var buf [64]byte
+	for i := range out {
+		copy(buf[:l], internal.ReverseScalarBytes(u[i*l:(i+1)*l]))
+		out[i] = fp.P256FpNew().SetBytesWide(&buf)
+	}
+	return out, nil
+}
+
 func (k p256PointArithmetic) Double(out, arg *native.EllipticPoint) {
 	// Addition formula from Renes-Costello-Batina 2015
 	// (https://eprint.iacr.org/2015/1060 Algorithm 6)
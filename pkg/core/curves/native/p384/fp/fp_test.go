@@ -0,0 +1,103 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package fp
+
+import (
+	crand "crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func randomFp(t *testing.T) (*Fp, *big.Int) {
+	var seed [WideBytes]byte
+	_, err := crand.Read(seed[:])
+	require.NoError(t, err)
+	e := new(Fp).SetBytesWide(&seed)
+	return e, e.BigInt()
+}
+
+func TestFpModulus(t *testing.T) {
+	require.Equal(t, "fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffeffffffff0000000000000000ffffffff", biModulus.Text(16))
+	require.True(t, new(Fp).SetOne().IsOne())
+	require.Equal(t, big.NewInt(1), new(Fp).SetOne().BigInt())
+	require.True(t, new(Fp).SetBigInt(biModulus).IsZero())
+	minusOne := new(big.Int).Sub(biModulus, big.NewInt(1))
+	require.Equal(t, minusOne, new(Fp).Neg(new(Fp).SetOne()).BigInt())
+}
+
+func TestFpArithmetic(t *testing.T) {
+	for i := 0; i < 25; i++ {
+		a, ba := randomFp(t)
+		b, bb := randomFp(t)
+
+		exp := new(big.Int).Add(ba, bb)
+		require.Equal(t, exp.Mod(exp, biModulus), new(Fp).Add(a, b).BigInt())
+		exp = new(big.Int).Sub(ba, bb)
+		require.Equal(t, exp.Mod(exp, biModulus), new(Fp).Sub(a, b).BigInt())
+		exp = new(big.Int).Mul(ba, bb)
+		require.Equal(t, exp.Mod(exp, biModulus), new(Fp).Mul(a, b).BigInt())
+		exp = new(big.Int).Mul(ba, ba)
+		require.Equal(t, exp.Mod(exp, biModulus), new(Fp).Square(a).BigInt())
+		exp = new(big.Int).Neg(ba)
+		require.Equal(t, exp.Mod(exp, biModulus), new(Fp).Neg(a).BigInt())
+
+		inv, ok := new(Fp).Invert(a)
+		require.True(t, ok)
+		require.Equal(t, new(big.Int).ModInverse(ba, biModulus), inv.BigInt())
+
+		sq := new(Fp).Square(a)
+		root, ok := new(Fp).Sqrt(sq)
+		require.True(t, ok)
+		require.True(t, new(Fp).Square(root).Equal(sq))
+		_, ok = new(Fp).Sqrt(new(Fp).Neg(sq))
+		require.False(t, ok)
+
+		require.True(t, new(Fp).SetBigInt(ba).Equal(a))
+		require.Equal(t, ba.Bit(0) == 1, a.IsOdd())
+		bytes := a.Bytes()
+		c, err := new(Fp).SetBytes(&bytes)
+		require.NoError(t, err)
+		require.True(t, c.Equal(a))
+		raw := a.ToRaw()
+		require.True(t, new(Fp).SetRaw(&raw).Equal(a))
+		require.True(t, new(Fp).Exp(a, new(Fp).SetUint64(3)).Equal(new(Fp).Mul(sq, a)))
+		require.True(t, new(Fp).CMove(a, b, 1).Equal(b))
+		require.True(t, new(Fp).CMove(a, b, 0).Equal(a))
+	}
+
+	// Values at the top of the field
+	minusOne := new(Fp).Neg(new(Fp).SetOne())
+	require.True(t, new(Fp).Add(minusOne, minusOne).Equal(new(Fp).Neg(new(Fp).SetUint64(2))))
+	require.True(t, new(Fp).Mul(minusOne, minusOne).IsOne())
+	_, ok := new(Fp).Invert(new(Fp))
+	require.False(t, ok)
+}
+
+func TestFpSetBytes(t *testing.T) {
+	var bytes [Bytes]byte
+	copy(bytes[:], reverse(biModulus.Bytes()))
+	_, err := new(Fp).SetBytes(&bytes)
+	require.Error(t, err)
+
+	var wide [WideBytes]byte
+	for i := range wide {
+		wide[i] = 0xff
+	}
+	max := new(big.Int).Lsh(big.NewInt(1), 8*WideBytes)
+	max.Sub(max, big.NewInt(1))
+	require.Equal(t, max.Mod(max, biModulus), new(Fp).SetBytesWide(&wide).BigInt())
+}
+
+func reverse(b []byte) []byte {
+	out := make([]byte, len(b))
+	for i := range b {
+		out[len(b)-1-i] = b[i]
+	}
+	return out
+}
@@ -0,0 +1,387 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package fq
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"math/bits"
+
+	"github.com/TEENet-io/kryptology/internal"
+)
+
+// Limbs is the number of 64-bit limbs of a field element
+const Limbs = 6
+
+// Bytes is the number of bytes of a field element
+const Bytes = 48
+
+// WideBytes is the number of bytes that SetBytesWide reduces
+const WideBytes = 2 * Bytes
+
+// Fq is an element of the P-384 scalar field in montgomery form
+type Fq [Limbs]uint64
+
+// modulus representation
+// q = 0xffffffffffffffffffffffffffffffffffffffffffffffffc7634d81f4372ddf581a0db248b0a77aecec196accc52973
+var modulus = &Fq{
+	0xecec196accc52973,
+	0x581a0db248b0a77a,
+	0xc7634d81f4372ddf,
+	0xffffffffffffffff,
+	0xffffffffffffffff,
+	0xffffffffffffffff,
+}
+
+// r = 2^384 mod q
+var r = &Fq{
+	0x1313e695333ad68d,
+	0xa7e5f24db74f5885,
+	0x389cb27e0bc8d220,
+	0x0000000000000000,
+	0x0000000000000000,
+	0x0000000000000000,
+}
+
+// r2 = 2^768 mod q
+var r2 = &Fq{
+	0x2d319b2419b409a9,
+	0xff3d81e5df1aa419,
+	0xbc3e483afcb82947,
+	0xd40d49174aab1cc5,
+	0x3fb05b7a28266895,
+	0x0c84ee012b39bf21,
+}
+
+// r3 = 2^1152 mod q
+var r3 = &Fq{
+	0x302a6faf377c7677,
+	0x2a70cb61d26894bc,
+	0x0c27ddb8ba8dc4ba,
+	0x5dbd3f41edb48eb6,
+	0x16d081679522617b,
+	0xd558bfbcb33c33c6,
+}
+
+// inv = -q^{-1} mod 2^64
+const inv = 0x6ed46089e88fdc45
+
+var biModulus = new(big.Int).SetBytes([]byte{
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	0xc7, 0x63, 0x4d, 0x81, 0xf4, 0x37, 0x2d, 0xdf,
+	0x58, 0x1a, 0x0d, 0xb2, 0x48, 0xb0, 0xa7, 0x7a,
+	0xec, 0xec, 0x19, 0x6a, 0xcc, 0xc5, 0x29, 0x73,
+})
+
+// Cmp returns -1 if fq < rhs
+// 0 if fq == rhs
+// 1 if fq > rhs
+func (fq *Fq) Cmp(rhs *Fq) int {
+	gt := 0
+	lt := 0
+	for i := len(fq) - 1; i >= 0; i-- {
+		// convert to two 64-bit numbers where
+		// the leading bits are zeros and hold no meaning
+		rhsH := rhs[i] >> 32
+		rhsL := rhs[i] & 0xffffffff
+		lhsH := fq[i] >> 32
+		lhsL := fq[i] & 0xffffffff
+
+		gt |= int((rhsH-lhsH)>>32&1) &^ lt
+		lt |= int((lhsH-rhsH)>>32&1) &^ gt
+		gt |= int((rhsL-lhsL)>>32&1) &^ lt
+		lt |= int((lhsL-rhsL)>>32&1) &^ gt
+	}
+	return gt - lt
+}
+
+// Equal returns true if fq == rhs
+func (fq *Fq) Equal(rhs *Fq) bool {
+	t := uint64(0)
+	for i := range fq {
+		t |= fq[i] ^ rhs[i]
+	}
+	return t == 0
+}
+
+// IsZero returns true if fq == 0
+func (fq *Fq) IsZero() bool {
+	t := uint64(0)
+	for i := range fq {
+		t |= fq[i]
+	}
+	return t == 0
+}
+
+// IsOne returns true if fq == R
+func (fq *Fq) IsOne() bool {
+	return fq.Equal(r)
+}
+
+// IsOdd returns true if the canonical value of fq is odd
+func (fq *Fq) IsOdd() bool {
+	tv := new(Fq).fromMontgomery(fq)
+	return tv[0]&0x01 == 0x01
+}
+
+// Set fq == rhs
+func (fq *Fq) Set(rhs *Fq) *Fq {
+	*fq = *rhs
+	return fq
+}
+
+// SetUint64 sets fq == rhs
+func (fq *Fq) SetUint64(rhs uint64) *Fq {
+	t := &Fq{rhs}
+	return fq.toMontgomery(t)
+}
+
+// SetOne fq == R
+func (fq *Fq) SetOne() *Fq {
+	return fq.Set(r)
+}
+
+// SetZero fq == 0
+func (fq *Fq) SetZero() *Fq {
+	*fq = Fq{}
+	return fq
+}
+
+// SetBytesWide takes 96 bytes as input and treats them as a 768-bit number.
+// The number is decomposed into two 384-bit digits with the higher digit multiplied
+// by 2^384. The lower digit is multiplied by r2 and the higher one by r3 = r2 * 2^384,
+// which puts both in montgomery form, and their sum is the reduction of the input.
+func (fq *Fq) SetBytesWide(input *[WideBytes]byte) *Fq {
+	d0 := new(Fq)
+	d1 := new(Fq)
+	for i := range d0 {
+		d0[i] = binary.LittleEndian.Uint64(input[8*i : 8*i+8])
+		d1[i] = binary.LittleEndian.Uint64(input[Bytes+8*i : Bytes+8*i+8])
+	}
+	d0.Mul(d0, r2)
+	d1.Mul(d1, r3)
+	return fq.Add(d0, d1)
+}
+
+// SetBytes attempts to convert a little endian byte representation
+// of a scalar into a `Fq`, failing if input is not canonical
+func (fq *Fq) SetBytes(input *[Bytes]byte) (*Fq, error) {
+	d0 := new(Fq)
+	for i := range d0 {
+		d0[i] = binary.LittleEndian.Uint64(input[8*i : 8*i+8])
+	}
+	if d0.Cmp(modulus) != -1 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	return fq.toMontgomery(d0), nil
+}
+
+// SetBigInt initializes an element from big.Int
+// The value is reduced by the modulus
+func (fq *Fq) SetBigInt(bi *big.Int) *Fq {
+	var buffer [Bytes]byte
+	t := new(big.Int).Set(bi)
+	t.Mod(t, biModulus)
+	t.FillBytes(buffer[:])
+	copy(buffer[:], internal.ReverseScalarBytes(buffer[:]))
+	_, _ = fq.SetBytes(&buffer)
+	return fq
+}
+
+// SetRaw converts a raw array into a field element
+func (fq *Fq) SetRaw(array *[Limbs]uint64) *Fq {
+	return fq.toMontgomery((*Fq)(array))
+}
+
+// Bytes converts this element into a byte representation
+// in little endian byte order
+func (fq *Fq) Bytes() [Bytes]byte {
+	var output [Bytes]byte
+	tv := new(Fq).fromMontgomery(fq)
+	for i := range tv {
+		binary.LittleEndian.PutUint64(output[8*i:8*i+8], tv[i])
+	}
+	return output
+}
+
+// BigInt converts this element into the big.Int struct
+func (fq *Fq) BigInt() *big.Int {
+	buffer := fq.Bytes()
+	return new(big.Int).SetBytes(internal.ReverseScalarBytes(buffer[:]))
+}
+
+// Double this element
+func (fq *Fq) Double(elem *Fq) *Fq {
+	return fq.Add(elem, elem)
+}
+
+// Square this element
+func (fq *Fq) Square(elem *Fq) *Fq {
+	return fq.Mul(elem, elem)
+}
+
+// Sqrt this element, if it exists. If true, then value
+// is a square root. If false, value is a QNR
+func (fq *Fq) Sqrt(elem *Fq) (*Fq, bool) {
+	// q = 3 mod 4 so a square root is elem^((q + 1) / 4)
+	exp := [Limbs]uint64{
+		0xbb3b065ab3314a5d,
+		0xd606836c922c29de,
+		0xf1d8d3607d0dcb77,
+		0xffffffffffffffff,
+		0xffffffffffffffff,
+		0x3fffffffffffffff,
+	}
+	z := new(Fq).pow(elem, exp)
+	wasSquare := new(Fq).Square(z).Equal(elem)
+	return fq.Set(z), wasSquare
+}
+
+// Invert this element i.e. compute the multiplicative inverse
+// return false, zero if this element is zero
+func (fq *Fq) Invert(elem *Fq) (*Fq, bool) {
+	// computes elem^(q - 2) mod q
+	exp := [Limbs]uint64{
+		0xecec196accc52971,
+		0x581a0db248b0a77a,
+		0xc7634d81f4372ddf,
+		0xffffffffffffffff,
+		0xffffffffffffffff,
+		0xffffffffffffffff,
+	}
+	return fq.pow(elem, exp), !elem.IsZero()
+}
+
+// Mul returns the result from multiplying this element by rhs
+func (fq *Fq) Mul(lhs, rhs *Fq) *Fq {
+	// Coarsely integrated operand scanning,
+	// see Algorithm 14.36 in the Handbook of Applied Cryptography.
+	// The extra words hold the carries of a full width modulus.
+	var t [Limbs + 2]uint64
+	var carry uint64
+	for i := 0; i < Limbs; i++ {
+		// t += lhs * rhs[i]
+		carry = 0
+		for j := 0; j < Limbs; j++ {
+			t[j], carry = mac(t[j], lhs[j], rhs[i], carry)
+		}
+		t[Limbs], t[Limbs+1] = bits.Add64(t[Limbs], carry, 0)
+
+		// t = (t + k * q) / 2^64
+		k := t[0] * inv
+		_, carry = mac(t[0], k, modulus[0], 0)
+		for j := 1; j < Limbs; j++ {
+			t[j-1], carry = mac(t[j], k, modulus[j], carry)
+		}
+		t[Limbs-1], carry = bits.Add64(t[Limbs], carry, 0)
+		t[Limbs] = t[Limbs+1] + carry
+	}
+	return fq.reduce(&t)
+}
+
+// Sub returns the result from subtracting rhs from this element
+func (fq *Fq) Sub(lhs, rhs *Fq) *Fq {
+	var t Fq
+	var borrow, carry uint64
+	for i := range t {
+		t[i], borrow = bits.Sub64(lhs[i], rhs[i], borrow)
+	}
+	// Add the modulus back if the subtraction underflowed
+	mask := -borrow
+	for i := range t {
+		t[i], carry = bits.Add64(t[i], modulus[i]&mask, carry)
+	}
+	return fq.Set(&t)
+}
+
+// Add returns the result from adding rhs to this element
+func (fq *Fq) Add(lhs, rhs *Fq) *Fq {
+	var t [Limbs + 2]uint64
+	var carry uint64
+	for i := 0; i < Limbs; i++ {
+		t[i], carry = bits.Add64(lhs[i], rhs[i], carry)
+	}
+	t[Limbs] = carry
+	return fq.reduce(&t)
+}
+
+// Neg returns negation of this element
+func (fq *Fq) Neg(elem *Fq) *Fq {
+	return fq.Sub(new(Fq), elem)
+}
+
+// Exp exponentiates this element by exp
+func (fq *Fq) Exp(base, exp *Fq) *Fq {
+	// convert exponent to integer form
+	e := new(Fq).fromMontgomery(exp)
+	return fq.pow(base, *e)
+}
+
+func (fq *Fq) pow(base *Fq, exp [Limbs]uint64) *Fq {
+	res := new(Fq).SetOne()
+	tmp := new(Fq)
+
+	for i := len(exp) - 1; i >= 0; i-- {
+		for j := 63; j >= 0; j-- {
+			res.Square(res)
+			tmp.Mul(res, base)
+			res.CMove(res, tmp, int(exp[i]>>j)&1)
+		}
+	}
+	return fq.Set(res)
+}
+
+// CMove selects lhs if choice == 0 and rhs if choice == 1
+func (fq *Fq) CMove(lhs, rhs *Fq, choice int) *Fq {
+	mask := -uint64(choice & 1)
+	for i := range fq {
+		fq[i] = lhs[i] ^ ((lhs[i] ^ rhs[i]) & mask)
+	}
+	return fq
+}
+
+// ToRaw converts this element into the a [Limbs]uint64
+func (fq *Fq) ToRaw() [Limbs]uint64 {
+	return *new(Fq).fromMontgomery(fq)
+}
+
+// toMontgomery converts a canonical value to montgomery form
+func (fq *Fq) toMontgomery(a *Fq) *Fq {
+	return fq.Mul(a, r2)
+}
+
+// fromMontgomery converts a montgomery form value to its canonical value
+func (fq *Fq) fromMontgomery(a *Fq) *Fq {
+	return fq.Mul(a, &Fq{1})
+}
+
+// reduce sets fq to t mod q for t < 2q
+func (fq *Fq) reduce(t *[Limbs + 2]uint64) *Fq {
+	var lhs, d Fq
+	var borrow uint64
+	copy(lhs[:], t[:Limbs])
+	for i := range d {
+		d[i], borrow = bits.Sub64(lhs[i], modulus[i], borrow)
+	}
+	_, borrow = bits.Sub64(t[Limbs], 0, borrow)
+	// Keep t if subtracting the modulus underflowed
+	return fq.CMove(&d, &lhs, int(borrow))
+}
+
+// mac returns a + b * c + carry as the low and high words
+func mac(a, b, c, carry uint64) (uint64, uint64) {
+	hi, lo := bits.Mul64(b, c)
+	var cc uint64
+	lo, cc = bits.Add64(lo, a, 0)
+	hi += cc
+	lo, cc = bits.Add64(lo, carry, 0)
+	hi += cc
+	return lo, hi
+}
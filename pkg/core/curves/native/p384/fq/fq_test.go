@@ -0,0 +1,103 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package fq
+
+import (
+	crand "crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func randomFq(t *testing.T) (*Fq, *big.Int) {
+	var seed [WideBytes]byte
+	_, err := crand.Read(seed[:])
+	require.NoError(t, err)
+	e := new(Fq).SetBytesWide(&seed)
+	return e, e.BigInt()
+}
+
+func TestFqModulus(t *testing.T) {
+	require.Equal(t, "ffffffffffffffffffffffffffffffffffffffffffffffffc7634d81f4372ddf581a0db248b0a77aecec196accc52973", biModulus.Text(16))
+	require.True(t, new(Fq).SetOne().IsOne())
+	require.Equal(t, big.NewInt(1), new(Fq).SetOne().BigInt())
+	require.True(t, new(Fq).SetBigInt(biModulus).IsZero())
+	minusOne := new(big.Int).Sub(biModulus, big.NewInt(1))
+	require.Equal(t, minusOne, new(Fq).Neg(new(Fq).SetOne()).BigInt())
+}
+
+func TestFqArithmetic(t *testing.T) {
+	for i := 0; i < 25; i++ {
+		a, ba := randomFq(t)
+		b, bb := randomFq(t)
+
+		exp := new(big.Int).Add(ba, bb)
+		require.Equal(t, exp.Mod(exp, biModulus), new(Fq).Add(a, b).BigInt())
+		exp = new(big.Int).Sub(ba, bb)
+		require.Equal(t, exp.Mod(exp, biModulus), new(Fq).Sub(a, b).BigInt())
+		exp = new(big.Int).Mul(ba, bb)
+		require.Equal(t, exp.Mod(exp, biModulus), new(Fq).Mul(a, b).BigInt())
+		exp = new(big.Int).Mul(ba, ba)
+		require.Equal(t, exp.Mod(exp, biModulus), new(Fq).Square(a).BigInt())
+		exp = new(big.Int).Neg(ba)
+		require.Equal(t, exp.Mod(exp, biModulus), new(Fq).Neg(a).BigInt())
+
+		inv, ok := new(Fq).Invert(a)
+		require.True(t, ok)
+		require.Equal(t, new(big.Int).ModInverse(ba, biModulus), inv.BigInt())
+
+		sq := new(Fq).Square(a)
+		root, ok := new(Fq).Sqrt(sq)
+		require.True(t, ok)
+		require.True(t, new(Fq).Square(root).Equal(sq))
+		_, ok = new(Fq).Sqrt(new(Fq).Neg(sq))
+		require.False(t, ok)
+
+		require.True(t, new(Fq).SetBigInt(ba).Equal(a))
+		require.Equal(t, ba.Bit(0) == 1, a.IsOdd())
+		bytes := a.Bytes()
+		c, err := new(Fq).SetBytes(&bytes)
+		require.NoError(t, err)
+		require.True(t, c.Equal(a))
+		raw := a.ToRaw()
+		require.True(t, new(Fq).SetRaw(&raw).Equal(a))
+		require.True(t, new(Fq).Exp(a, new(Fq).SetUint64(3)).Equal(new(Fq).Mul(sq, a)))
+		require.True(t, new(Fq).CMove(a, b, 1).Equal(b))
+		require.True(t, new(Fq).CMove(a, b, 0).Equal(a))
+	}
+
+	// Values at the top of the field
+	minusOne := new(Fq).Neg(new(Fq).SetOne())
+	require.True(t, new(Fq).Add(minusOne, minusOne).Equal(new(Fq).Neg(new(Fq).SetUint64(2))))
+	require.True(t, new(Fq).Mul(minusOne, minusOne).IsOne())
+	_, ok := new(Fq).Invert(new(Fq))
+	require.False(t, ok)
+}
+
+func TestFqSetBytes(t *testing.T) {
+	var bytes [Bytes]byte
+	copy(bytes[:], reverse(biModulus.Bytes()))
+	_, err := new(Fq).SetBytes(&bytes)
+	require.Error(t, err)
+
+	var wide [WideBytes]byte
+	for i := range wide {
+		wide[i] = 0xff
+	}
+	max := new(big.Int).Lsh(big.NewInt(1), 8*WideBytes)
+	max.Sub(max, big.NewInt(1))
+	require.Equal(t, max.Mod(max, biModulus), new(Fq).SetBytesWide(&wide).BigInt())
+}
+
+func reverse(b []byte) []byte {
+	out := make([]byte, len(b))
+	for i := range b {
+		out[len(b)-1-i] = b[i]
+	}
+	return out
+}
@@ -247,3 +247,11 @@ func TestFqSetBytesWide(t *testing.T) {
 	})
 	require.Equal(t, e, a)
 }
+
+func TestFqIsOdd(t *testing.T) {
+	require.False(t, new(Fq).SetZero().IsOdd())
+	require.True(t, new(Fq).SetOne().IsOdd())
+	require.False(t, new(Fq).SetUint64(2).IsOdd())
+	// -1 = q - 1 is even
+	require.False(t, new(Fq).Neg(new(Fq).SetOne()).IsOdd())
+}
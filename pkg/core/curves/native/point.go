@@ -37,6 +37,7 @@ const (
 	BLAKE2B
 	SHAKE128
 	SHAKE256
+	SHA384
 )
 
 // EllipticPoint represents a Weierstrauss elliptic curve point
@@ -107,6 +108,15 @@ func EllipticPointHasherSha512() *EllipticPointHasher {
 	}
 }
 
+// EllipticPointHasherSha384 creates a point hasher that uses Sha384
+func EllipticPointHasherSha384() *EllipticPointHasher {
+	return &EllipticPointHasher{
+		name:     SHA384,
+		hashType: XMD,
+		xmd:      sha512.New384(),
+	}
+}
+
 // EllipticPointHasherSha3256 creates a point hasher that uses Sha3256
 func EllipticPointHasherSha3256() *EllipticPointHasher {
 	return &EllipticPointHasher{
@@ -168,6 +178,9 @@ type EllipticPointArithmetic interface {
 	// Hash a byte sequence to the curve using the specified hasher
 	// and dst and store the result in out
 	Hash(out *EllipticPoint, hasher *EllipticPointHasher, bytes, dst []byte) error
+	// Encode a byte sequence to the curve using the specified hasher
+	// and dst with the nonuniform encoding and store the result in out
+	Encode(out *EllipticPoint, hasher *EllipticPointHasher, bytes, dst []byte) error
 	// Double arg and store the result in out
 	Double(out, arg *EllipticPoint)
 	// Add arg1 with arg2 and store the result in out
@@ -208,6 +221,8 @@ func (n EllipticPointHashName) String() string {
 		return "SHAKE-128"
 	case SHAKE256:
 		return "SHAKE-256"
+	case SHA384:
+		return "SHA-384"
 	}
 	return "unknown"
 }
@@ -241,6 +256,26 @@ func (p *EllipticPoint) Hash(bytes []byte, hasher *EllipticPointHasher) (*Ellipt
 	return p, nil
 }
 
+// HashWithDst uses the hasher to map bytes to a valid point with the
+// hash_to_curve random oracle encoding of RFC 9380 and the specified dst
+func (p *EllipticPoint) HashWithDst(bytes, dst []byte, hasher *EllipticPointHasher) (*EllipticPoint, error) {
+	err := p.Arithmetic.Hash(p, hasher, bytes, dst)
+	if err != nil {
+		return nil, errors.Wrap(err, "hash failed")
+	}
+	return p, nil
+}
+
+// EncodeWithDst uses the hasher to map bytes to a valid point with the
+// encode_to_curve nonuniform encoding of RFC 9380 and the specified dst
+func (p *EllipticPoint) EncodeWithDst(bytes, dst []byte, hasher *EllipticPointHasher) (*EllipticPoint, error) {
+	err := p.Arithmetic.Encode(p, hasher, bytes, dst)
+	if err != nil {
+		return nil, errors.Wrap(err, "encode failed")
+	}
+	return p, nil
+}
+
 // Identity returns the identity point
 func (p *EllipticPoint) Identity() *EllipticPoint {
 	p.X.SetZero()
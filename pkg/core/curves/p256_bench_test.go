@@ -17,6 +17,7 @@ import (
 	"testing"
 
 	"github.com/TEENet-io/kryptology/pkg/core"
+	"github.com/TEENet-io/kryptology/pkg/core/curves/native"
 )
 
 func BenchmarkP256(b *testing.B) {
@@ -380,6 +381,26 @@ func (s *BenchScalarP256) SetBytesWide(bytes []byte) (Scalar, error) {
 	}, nil
 }
 
+func (s *BenchScalarP256) BytesBE() []byte {
+	return nil
+}
+
+func (s *BenchScalarP256) BytesLE() []byte {
+	return nil
+}
+
+func (s *BenchScalarP256) SetBytesBE(bytes []byte) (Scalar, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (s *BenchScalarP256) SetBytesLE(bytes []byte) (Scalar, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (s *BenchScalarP256) SetBytesReduce(bytes []byte) (Scalar, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
 func (s *BenchScalarP256) Point() Point {
 	return new(BenchPointP256).Identity()
 }
@@ -471,6 +492,14 @@ func (p *BenchPointP256) Hash(bytes []byte) Point {
 	}
 }
 
+func (p *BenchPointP256) HashWithDst(bytes, dst []byte, hasher *native.EllipticPointHasher) (Point, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (p *BenchPointP256) EncodeWithDst(bytes, dst []byte, hasher *native.EllipticPointHasher) (Point, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
 func (p *BenchPointP256) Identity() Point {
 	return &BenchPointP256{
 		x: big.NewInt(0), y: big.NewInt(0),
@@ -625,6 +654,18 @@ func (p *BenchPointP256) FromAffineCompressed(bytes []byte) (Point, error) {
 	}, nil
 }
 
+func (p *BenchPointP256) IsTorsionFree() bool {
+	return true
+}
+
+func (p *BenchPointP256) ClearCofactor() Point {
+	return p
+}
+
+func (p *BenchPointP256) FromAffineCompressedStrict(bytes []byte) (Point, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
 func (p *BenchPointP256) FromAffineUncompressed(bytes []byte) (Point, error) {
 	if len(bytes) != 65 {
 		return nil, fmt.Errorf("invalid byte sequence")
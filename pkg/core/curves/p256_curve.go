@@ -316,6 +316,26 @@ func (s *ScalarP256) SetBytesWide(bytes []byte) (Scalar, error) {
 	}, nil
 }
 
+func (s *ScalarP256) BytesBE() []byte {
+	return s.Bytes()
+}
+
+func (s *ScalarP256) BytesLE() []byte {
+	return internal.ReverseScalarBytes(s.Bytes())
+}
+
+func (s *ScalarP256) SetBytesBE(bytes []byte) (Scalar, error) {
+	return s.SetBytes(bytes)
+}
+
+func (s *ScalarP256) SetBytesLE(bytes []byte) (Scalar, error) {
+	return s.SetBytes(internal.ReverseScalarBytes(bytes))
+}
+
+func (s *ScalarP256) SetBytesReduce(bytes []byte) (Scalar, error) {
+	return scalarSetBytesReduce(s, bytes)
+}
+
 func (s *ScalarP256) Point() Point {
 	return new(PointP256).Identity()
 }
@@ -394,6 +414,28 @@ func (p *PointP256) Hash(bytes []byte) Point {
 	return &PointP256{value}
 }
 
+func (p *PointP256) HashWithDst(bytes, dst []byte, hasher *native.EllipticPointHasher) (Point, error) {
+	if err := checkHashToCurveArgs(hasher, dst); err != nil {
+		return nil, err
+	}
+	value, err := p256n.P256PointNew().HashWithDst(bytes, dst, hasher)
+	if err != nil {
+		return nil, err
+	}
+	return &PointP256{value}, nil
+}
+
+func (p *PointP256) EncodeWithDst(bytes, dst []byte, hasher *native.EllipticPointHasher) (Point, error) {
+	if err := checkHashToCurveArgs(hasher, dst); err != nil {
+		return nil, err
+	}
+	value, err := p256n.P256PointNew().EncodeWithDst(bytes, dst, hasher)
+	if err != nil {
+		return nil, err
+	}
+	return &PointP256{value}, nil
+}
+
 func (p *PointP256) Identity() Point {
 	return &PointP256{
 		value: p256n.P256PointNew().Identity(),
@@ -548,6 +590,20 @@ func (p *PointP256) FromAffineCompressed(bytes []byte) (Point, error) {
 	return &PointP256{value}, nil
 }
 
+func (p *PointP256) IsTorsionFree() bool {
+	// The group has prime order
+	return p.IsOnCurve()
+}
+
+func (p *PointP256) ClearCofactor() Point {
+	// The cofactor is one
+	return p
+}
+
+func (p *PointP256) FromAffineCompressedStrict(bytes []byte) (Point, error) {
+	return fromAffineCompressedStrict(p, bytes)
+}
+
 func (p *PointP256) FromAffineUncompressed(bytes []byte) (Point, error) {
 	var arr [native.FieldBytes]byte
 	if len(bytes) != 65 {
@@ -0,0 +1,927 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curves
+
+import (
+	"crypto/elliptic"
+	"crypto/sha512"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/TEENet-io/kryptology/internal"
+	"github.com/TEENet-io/kryptology/pkg/core/curves/native"
+	"github.com/TEENet-io/kryptology/pkg/core/curves/native/p384/fp"
+	"github.com/TEENet-io/kryptology/pkg/core/curves/native/p384/fq"
+)
+
+// P-384 is the curve y^2 = x^3 - 3x + b of FIPS 186-4 over the field of p = 2^384 - 2^128 - 2^96 + 2^32 - 1.
+// Points use the complete projective formulas of Renes, Costello and Batina for a = -3
+// in https://eprint.iacr.org/2015/1060 so additions and doublings have no exceptional cases,
+// are hashed with P384_XMD:SHA-384_SSWU_RO_ of RFC 9380 and are encoded as in SEC 1.
+
+var p384B = new(fp.Fp).SetRaw(&[fp.Limbs]uint64{0x2a85c8edd3ec2aef, 0xc656398d8a2ed19d, 0x0314088f5013875a, 0x181d9c6efe814112, 0x988e056be3f82d19, 0xb3312fa7e23ee7e4})
+var p384Gx = new(fp.Fp).SetRaw(&[fp.Limbs]uint64{0x3a545e3872760ab7, 0x5502f25dbf55296c, 0x59f741e082542a38, 0x6e1d3b628ba79b98, 0x8eb1c71ef320ad74, 0xaa87ca22be8b0537})
+var p384Gy = new(fp.Fp).SetRaw(&[fp.Limbs]uint64{0x7a431d7c90ea0e5f, 0x0a60b1ce1d7e819d, 0xe9da3113b5f0b8c0, 0xf8f41dbd289a147c, 0x5d9e98bf9292dc29, 0x3617de4a96262c6f})
+
+// Constants of the simplified SWU map with Z = -12:
+// p384SswuC1 = -b / a and p384SswuC2 = b / (Z * a)
+var p384SswuZ = new(fp.Fp).SetRaw(&[fp.Limbs]uint64{0x00000000fffffff3, 0xffffffff00000000, 0xfffffffffffffffe, 0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff})
+var p384SswuC1 = new(fp.Fp).SetRaw(&[fp.Limbs]uint64{0x6381eda546a40e4f, 0x421cbdd92e0f9b34, 0xabb1582fc55bd7c8, 0x0809decfaa2b15b0, 0x32da01cea152b9b3, 0xe665ba8d4b6a4d4c})
+var p384SswuC2 = new(fp.Fp).SetRaw(&[fp.Limbs]uint64{0x084ad3ce05e30131, 0xc58265272e814cef, 0xb8f97203fb1cfca5, 0x9600d2914e2e41ce, 0xaee780268d718f79, 0x533324e11b9e311b})
+
+const p384HashDst = "P384_XMD:SHA-384_SSWU_RO_"
+
+type ScalarP384 struct {
+	value *fq.Fq
+}
+
+func (s *ScalarP384) Random(reader io.Reader) Scalar {
+	if reader == nil {
+		return nil
+	}
+	var seed [64]byte
+	_, _ = reader.Read(seed[:])
+	return s.Hash(seed[:])
+}
+
+func (s *ScalarP384) Hash(bytes []byte) Scalar {
+	// L = ceil((ceil(log2(n)) + k) / 8) = 72 for k = 192
+	xmd, err := expandMsgXmd(sha512.New384(), bytes, []byte(p384HashDst), 72)
+	if err != nil {
+		return nil
+	}
+	var t [fq.WideBytes]byte
+	copy(t[:72], internal.ReverseScalarBytes(xmd))
+	return &ScalarP384{
+		value: new(fq.Fq).SetBytesWide(&t),
+	}
+}
+
+func (s *ScalarP384) Zero() Scalar {
+	return &ScalarP384{
+		value: new(fq.Fq).SetZero(),
+	}
+}
+
+func (s *ScalarP384) One() Scalar {
+	return &ScalarP384{
+		value: new(fq.Fq).SetOne(),
+	}
+}
+
+func (s *ScalarP384) IsZero() bool {
+	return s.value.IsZero()
+}
+
+func (s *ScalarP384) IsOne() bool {
+	return s.value.IsOne()
+}
+
+func (s *ScalarP384) IsOdd() bool {
+	return s.value.IsOdd()
+}
+
+func (s *ScalarP384) IsEven() bool {
+	return !s.value.IsOdd()
+}
+
+func (s *ScalarP384) New(value int) Scalar {
+	v := big.NewInt(int64(value))
+	return &ScalarP384{
+		value: new(fq.Fq).SetBigInt(v),
+	}
+}
+
+func (s *ScalarP384) Cmp(rhs Scalar) int {
+	r, ok := rhs.(*ScalarP384)
+	if ok {
+		return s.value.Cmp(r.value)
+	} else {
+		return -2
+	}
+}
+
+func (s *ScalarP384) Square() Scalar {
+	return &ScalarP384{
+		value: new(fq.Fq).Square(s.value),
+	}
+}
+
+func (s *ScalarP384) Double() Scalar {
+	return &ScalarP384{
+		value: new(fq.Fq).Double(s.value),
+	}
+}
+
+func (s *ScalarP384) Invert() (Scalar, error) {
+	value, wasInverted := new(fq.Fq).Invert(s.value)
+	if !wasInverted {
+		return nil, fmt.Errorf("inverse doesn't exist")
+	}
+	return &ScalarP384{
+		value,
+	}, nil
+}
+
+func (s *ScalarP384) Sqrt() (Scalar, error) {
+	value, wasSquare := new(fq.Fq).Sqrt(s.value)
+	if !wasSquare {
+		return nil, fmt.Errorf("not a square")
+	}
+	return &ScalarP384{
+		value,
+	}, nil
+}
+
+func (s *ScalarP384) Cube() Scalar {
+	value := new(fq.Fq).Square(s.value)
+	value.Mul(value, s.value)
+	return &ScalarP384{
+		value,
+	}
+}
+
+func (s *ScalarP384) Add(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarP384)
+	if ok {
+		return &ScalarP384{
+			value: new(fq.Fq).Add(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarP384) Sub(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarP384)
+	if ok {
+		return &ScalarP384{
+			value: new(fq.Fq).Sub(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarP384) Mul(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarP384)
+	if ok {
+		return &ScalarP384{
+			value: new(fq.Fq).Mul(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarP384) MulAdd(y, z Scalar) Scalar {
+	return s.Mul(y).Add(z)
+}
+
+func (s *ScalarP384) Div(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarP384)
+	if ok {
+		v, wasInverted := new(fq.Fq).Invert(r.value)
+		if !wasInverted {
+			return nil
+		}
+		v.Mul(v, s.value)
+		return &ScalarP384{value: v}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarP384) Neg() Scalar {
+	return &ScalarP384{
+		value: new(fq.Fq).Neg(s.value),
+	}
+}
+
+func (s *ScalarP384) SetBigInt(v *big.Int) (Scalar, error) {
+	if v == nil {
+		return nil, fmt.Errorf("'v' cannot be nil")
+	}
+	return &ScalarP384{
+		value: new(fq.Fq).SetBigInt(v),
+	}, nil
+}
+
+func (s *ScalarP384) BigInt() *big.Int {
+	return s.value.BigInt()
+}
+
+// Bytes returns the 48 byte big-endian encoding of SEC 1
+func (s *ScalarP384) Bytes() []byte {
+	t := s.value.Bytes()
+	return internal.ReverseScalarBytes(t[:])
+}
+
+func (s *ScalarP384) SetBytes(bytes []byte) (Scalar, error) {
+	if len(bytes) != fq.Bytes {
+		return nil, fmt.Errorf("invalid length")
+	}
+	var seq [fq.Bytes]byte
+	copy(seq[:], internal.ReverseScalarBytes(bytes))
+	value, err := new(fq.Fq).SetBytes(&seq)
+	if err != nil {
+		return nil, err
+	}
+	return &ScalarP384{
+		value,
+	}, nil
+}
+
+// SetBytesWide reduces 96 little-endian bytes
+func (s *ScalarP384) SetBytesWide(bytes []byte) (Scalar, error) {
+	if len(bytes) != fq.WideBytes {
+		return nil, fmt.Errorf("invalid length")
+	}
+	var seq [fq.WideBytes]byte
+	copy(seq[:], bytes)
+	return &ScalarP384{
+		value: new(fq.Fq).SetBytesWide(&seq),
+	}, nil
+}
+
+func (s *ScalarP384) BytesBE() []byte {
+	return s.Bytes()
+}
+
+func (s *ScalarP384) BytesLE() []byte {
+	return internal.ReverseScalarBytes(s.Bytes())
+}
+
+func (s *ScalarP384) SetBytesBE(bytes []byte) (Scalar, error) {
+	return s.SetBytes(bytes)
+}
+
+func (s *ScalarP384) SetBytesLE(bytes []byte) (Scalar, error) {
+	return s.SetBytes(internal.ReverseScalarBytes(bytes))
+}
+
+func (s *ScalarP384) SetBytesReduce(bytes []byte) (Scalar, error) {
+	return scalarSetBytesReduce(s, bytes)
+}
+
+func (s *ScalarP384) Point() Point {
+	return new(PointP384).Identity()
+}
+
+func (s *ScalarP384) Clone() Scalar {
+	return &ScalarP384{
+		value: new(fq.Fq).Set(s.value),
+	}
+}
+
+func (s *ScalarP384) MarshalBinary() ([]byte, error) {
+	return scalarMarshalBinary(s)
+}
+
+func (s *ScalarP384) UnmarshalBinary(input []byte) error {
+	sc, err := scalarUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarP384)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.value = ss.value
+	return nil
+}
+
+func (s *ScalarP384) MarshalText() ([]byte, error) {
+	return scalarMarshalText(s)
+}
+
+func (s *ScalarP384) UnmarshalText(input []byte) error {
+	sc, err := scalarUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarP384)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.value = ss.value
+	return nil
+}
+
+func (s *ScalarP384) MarshalJSON() ([]byte, error) {
+	return scalarMarshalJson(s)
+}
+
+func (s *ScalarP384) UnmarshalJSON(input []byte) error {
+	sc, err := scalarUnmarshalJson(input)
+	if err != nil {
+		return err
+	}
+	S, ok := sc.(*ScalarP384)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	s.value = S.value
+	return nil
+}
+
+type PointP384 struct {
+	value *p384Point
+}
+
+func (p *PointP384) Random(reader io.Reader) Point {
+	var seed [64]byte
+	_, _ = reader.Read(seed[:])
+	return p.Hash(seed[:])
+}
+
+func (p *PointP384) Hash(bytes []byte) Point {
+	value, err := hashP384(native.EllipticPointHasherSha384(), bytes, []byte(p384HashDst), 2)
+	if err != nil {
+		return nil
+	}
+	return &PointP384{value}
+}
+
+func (p *PointP384) HashWithDst(bytes, dst []byte, hasher *native.EllipticPointHasher) (Point, error) {
+	value, err := hashP384(hasher, bytes, dst, 2)
+	if err != nil {
+		return nil, err
+	}
+	return &PointP384{value}, nil
+}
+
+func (p *PointP384) EncodeWithDst(bytes, dst []byte, hasher *native.EllipticPointHasher) (Point, error) {
+	value, err := hashP384(hasher, bytes, dst, 1)
+	if err != nil {
+		return nil, err
+	}
+	return &PointP384{value}, nil
+}
+
+func (p *PointP384) Identity() Point {
+	return &PointP384{new(p384Point).Identity()}
+}
+
+func (p *PointP384) Generator() Point {
+	return &PointP384{new(p384Point).Generator()}
+}
+
+func (p *PointP384) IsIdentity() bool {
+	return p.value.IsIdentity()
+}
+
+func (p *PointP384) IsNegative() bool {
+	_, y := p.value.ToAffine()
+	return y.IsOdd()
+}
+
+func (p *PointP384) IsOnCurve() bool {
+	return p.value.IsOnCurve()
+}
+
+func (p *PointP384) Double() Point {
+	return &PointP384{new(p384Point).Double(p.value)}
+}
+
+func (p *PointP384) Scalar() Scalar {
+	return new(ScalarP384).Zero()
+}
+
+func (p *PointP384) Neg() Point {
+	return &PointP384{new(p384Point).Neg(p.value)}
+}
+
+func (p *PointP384) Add(rhs Point) Point {
+	r, ok := rhs.(*PointP384)
+	if !ok {
+		return nil
+	}
+	return &PointP384{new(p384Point).Add(p.value, r.value)}
+}
+
+func (p *PointP384) Sub(rhs Point) Point {
+	r, ok := rhs.(*PointP384)
+	if !ok {
+		return nil
+	}
+	return &PointP384{new(p384Point).Sub(p.value, r.value)}
+}
+
+func (p *PointP384) Mul(rhs Scalar) Point {
+	s, ok := rhs.(*ScalarP384)
+	if !ok {
+		return nil
+	}
+	return &PointP384{new(p384Point).Mul(p.value, s.value)}
+}
+
+func (p *PointP384) Equal(rhs Point) bool {
+	r, ok := rhs.(*PointP384)
+	if !ok {
+		return false
+	}
+	return p.value.Equal(r.value)
+}
+
+// Set returns the point (x, y), or the identity if x and y are zero
+func (p *PointP384) Set(x, y *big.Int) (Point, error) {
+	if x == nil || y == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if x.Sign() == 0 && y.Sign() == 0 {
+		return p.Identity(), nil
+	}
+	value, err := new(p384Point).SetAffine(new(fp.Fp).SetBigInt(x), new(fp.Fp).SetBigInt(y))
+	if err != nil {
+		return nil, err
+	}
+	return &PointP384{value}, nil
+}
+
+// ToAffineCompressed returns the 49 byte SEC 1 encoding 02 || x or 03 || x depending on the sign of y.
+// The identity has no such encoding and is all zeroes.
+func (p *PointP384) ToAffineCompressed() []byte {
+	var out [1 + fp.Bytes]byte
+	if p.value.IsIdentity() {
+		return out[:]
+	}
+	x, y := p.value.ToAffine()
+	out[0] = 2
+	if y.IsOdd() {
+		out[0] |= 1
+	}
+	arr := x.Bytes()
+	copy(out[1:], internal.ReverseScalarBytes(arr[:]))
+	return out[:]
+}
+
+// ToAffineUncompressed returns the 97 byte SEC 1 encoding 04 || x || y.
+// The identity has no such encoding and is all zeroes.
+func (p *PointP384) ToAffineUncompressed() []byte {
+	var out [1 + 2*fp.Bytes]byte
+	if p.value.IsIdentity() {
+		return out[:]
+	}
+	x, y := p.value.ToAffine()
+	out[0] = 4
+	arr := x.Bytes()
+	copy(out[1:1+fp.Bytes], internal.ReverseScalarBytes(arr[:]))
+	arr = y.Bytes()
+	copy(out[1+fp.Bytes:], internal.ReverseScalarBytes(arr[:]))
+	return out[:]
+}
+
+func (p *PointP384) FromAffineCompressed(bytes []byte) (Point, error) {
+	if len(bytes) != 1+fp.Bytes {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	if isAllZero(bytes) {
+		return p.Identity(), nil
+	}
+	sign := bytes[0]
+	if sign != 2 && sign != 3 {
+		return nil, fmt.Errorf("invalid sign byte")
+	}
+	x, err := p384FpFromBigEndian(bytes[1:])
+	if err != nil {
+		return nil, err
+	}
+	y, wasSquare := new(fp.Fp).Sqrt(p384Rhs(x))
+	if !wasSquare {
+		return nil, fmt.Errorf("rhs of given x-coordinate is not a square")
+	}
+	if y.IsOdd() != (sign&1 == 1) {
+		y.Neg(y)
+	}
+	value, err := new(p384Point).SetAffine(x, y)
+	if err != nil {
+		return nil, err
+	}
+	return &PointP384{value}, nil
+}
+
+func (p *PointP384) IsTorsionFree() bool {
+	// The group has prime order
+	return p.IsOnCurve()
+}
+
+func (p *PointP384) ClearCofactor() Point {
+	// The cofactor is one
+	return p
+}
+
+func (p *PointP384) FromAffineCompressedStrict(bytes []byte) (Point, error) {
+	return fromAffineCompressedStrict(p, bytes)
+}
+
+func (p *PointP384) FromAffineUncompressed(bytes []byte) (Point, error) {
+	if len(bytes) != 1+2*fp.Bytes {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	if isAllZero(bytes) {
+		return p.Identity(), nil
+	}
+	if bytes[0] != 4 {
+		return nil, fmt.Errorf("invalid sign byte")
+	}
+	x, err := p384FpFromBigEndian(bytes[1 : 1+fp.Bytes])
+	if err != nil {
+		return nil, err
+	}
+	y, err := p384FpFromBigEndian(bytes[1+fp.Bytes:])
+	if err != nil {
+		return nil, err
+	}
+	value, err := new(p384Point).SetAffine(x, y)
+	if err != nil {
+		return nil, err
+	}
+	return &PointP384{value}, nil
+}
+
+func (p *PointP384) CurveName() string {
+	return P384Name
+}
+
+func (p *PointP384) SumOfProducts(points []Point, scalars []Scalar) Point {
+	if len(points) != len(scalars) {
+		return nil
+	}
+	result := new(p384Point).Identity()
+	for i, pt := range points {
+		pp, ok := pt.(*PointP384)
+		if !ok {
+			return nil
+		}
+		s, ok := scalars[i].(*ScalarP384)
+		if !ok {
+			return nil
+		}
+		result.Add(result, new(p384Point).Mul(pp.value, s.value))
+	}
+	return &PointP384{result}
+}
+
+// X returns the affine x-coordinate
+func (p *PointP384) X() *fp.Fp {
+	x, _ := p.value.ToAffine()
+	return x
+}
+
+// Y returns the affine y-coordinate
+func (p *PointP384) Y() *fp.Fp {
+	_, y := p.value.ToAffine()
+	return y
+}
+
+func (p *PointP384) Params() *elliptic.CurveParams {
+	return elliptic.P384().Params()
+}
+
+func (p *PointP384) MarshalBinary() ([]byte, error) {
+	return pointMarshalBinary(p)
+}
+
+func (p *PointP384) UnmarshalBinary(input []byte) error {
+	pt, err := pointUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointP384)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.value = ppt.value
+	return nil
+}
+
+func (p *PointP384) MarshalText() ([]byte, error) {
+	return pointMarshalText(p)
+}
+
+func (p *PointP384) UnmarshalText(input []byte) error {
+	pt, err := pointUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointP384)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.value = ppt.value
+	return nil
+}
+
+func (p *PointP384) MarshalJSON() ([]byte, error) {
+	return pointMarshalJson(p)
+}
+
+func (p *PointP384) UnmarshalJSON(input []byte) error {
+	pt, err := pointUnmarshalJson(input)
+	if err != nil {
+		return err
+	}
+	P, ok := pt.(*PointP384)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	p.value = P.value
+	return nil
+}
+
+// p384Point is a point in homogeneous projective coordinates (X : Y : Z) with x = X/Z and y = Y/Z.
+// The identity is (0 : 1 : 0).
+type p384Point struct {
+	x, y, z fp.Fp
+}
+
+func (p *p384Point) Identity() *p384Point {
+	p.x.SetZero()
+	p.y.SetOne()
+	p.z.SetZero()
+	return p
+}
+
+func (p *p384Point) Generator() *p384Point {
+	p.x.Set(p384Gx)
+	p.y.Set(p384Gy)
+	p.z.SetOne()
+	return p
+}
+
+func (p *p384Point) Set(other *p384Point) *p384Point {
+	*p = *other
+	return p
+}
+
+// SetAffine sets p to (x, y) if it is on the curve
+func (p *p384Point) SetAffine(x, y *fp.Fp) (*p384Point, error) {
+	lhs := new(fp.Fp).Square(y)
+	if !lhs.Equal(p384Rhs(x)) {
+		return nil, fmt.Errorf("point is not on the curve")
+	}
+	p.x.Set(x)
+	p.y.Set(y)
+	p.z.SetOne()
+	return p, nil
+}
+
+func (p *p384Point) IsIdentity() bool {
+	return p.z.IsZero()
+}
+
+// IsOnCurve checks Y^2 Z = X^3 - 3 X Z^2 + b Z^3
+func (p *p384Point) IsOnCurve() bool {
+	if p.IsIdentity() {
+		return p.x.IsZero() && !p.y.IsZero()
+	}
+	z2 := new(fp.Fp).Square(&p.z)
+	z3 := new(fp.Fp).Mul(z2, &p.z)
+	lhs := new(fp.Fp).Square(&p.y)
+	lhs.Mul(lhs, &p.z)
+	rhs := new(fp.Fp).Square(&p.x)
+	rhs.Mul(rhs, &p.x)
+	t := new(fp.Fp).Mul(&p.x, z2)
+	rhs.Sub(rhs, t)
+	rhs.Sub(rhs, t)
+	rhs.Sub(rhs, t)
+	rhs.Add(rhs, new(fp.Fp).Mul(p384B, z3))
+	return lhs.Equal(rhs)
+}
+
+func (p *p384Point) ToAffine() (*fp.Fp, *fp.Fp) {
+	zInv, _ := new(fp.Fp).Invert(&p.z)
+	return new(fp.Fp).Mul(&p.x, zInv), new(fp.Fp).Mul(&p.y, zInv)
+}
+
+func (p *p384Point) Equal(other *p384Point) bool {
+	// X1 Z2 == X2 Z1 and Y1 Z2 == Y2 Z1
+	lhs := new(fp.Fp).Mul(&p.x, &other.z)
+	rhs := new(fp.Fp).Mul(&other.x, &p.z)
+	x := lhs.Equal(rhs)
+	lhs.Mul(&p.y, &other.z)
+	rhs.Mul(&other.y, &p.z)
+	return x && lhs.Equal(rhs)
+}
+
+func (p *p384Point) Neg(other *p384Point) *p384Point {
+	p.x.Set(&other.x)
+	p.y.Neg(&other.y)
+	p.z.Set(&other.z)
+	return p
+}
+
+func (p *p384Point) Sub(lhs, rhs *p384Point) *p384Point {
+	return p.Add(lhs, new(p384Point).Neg(rhs))
+}
+
+// Add sets p = lhs + rhs with algorithm 4 of https://eprint.iacr.org/2015/1060
+func (p *p384Point) Add(lhs, rhs *p384Point) *p384Point {
+	t0 := new(fp.Fp).Mul(&lhs.x, &rhs.x) // t0 := X1 * X2
+	t1 := new(fp.Fp).Mul(&lhs.y, &rhs.y) // t1 := Y1 * Y2
+	t2 := new(fp.Fp).Mul(&lhs.z, &rhs.z) // t2 := Z1 * Z2
+	t3 := new(fp.Fp).Add(&lhs.x, &lhs.y) // t3 := X1 + Y1
+	t4 := new(fp.Fp).Add(&rhs.x, &rhs.y) // t4 := X2 + Y2
+	t3.Mul(t3, t4)                       // t3 := t3 * t4
+	t4.Add(t0, t1)                       // t4 := t0 + t1
+	t3.Sub(t3, t4)                       // t3 := t3 - t4
+	t4.Add(&lhs.y, &lhs.z)               // t4 := Y1 + Z1
+	x3 := new(fp.Fp).Add(&rhs.y, &rhs.z) // X3 := Y2 + Z2
+	t4.Mul(t4, x3)                       // t4 := t4 * X3
+	x3.Add(t1, t2)                       // X3 := t1 + t2
+	t4.Sub(t4, x3)                       // t4 := t4 - X3
+	x3.Add(&lhs.x, &lhs.z)               // X3 := X1 + Z1
+	y3 := new(fp.Fp).Add(&rhs.x, &rhs.z) // Y3 := X2 + Z2
+	x3.Mul(x3, y3)                       // X3 := X3 * Y3
+	y3.Add(t0, t2)                       // Y3 := t0 + t2
+	y3.Sub(x3, y3)                       // Y3 := X3 - Y3
+	z3 := new(fp.Fp).Mul(p384B, t2)      // Z3 := b * t2
+	x3.Sub(y3, z3)                       // X3 := Y3 - Z3
+	z3.Add(x3, x3)                       // Z3 := X3 + X3
+	x3.Add(x3, z3)                       // X3 := X3 + Z3
+	z3.Sub(t1, x3)                       // Z3 := t1 - X3
+	x3.Add(t1, x3)                       // X3 := t1 + X3
+	y3.Mul(p384B, y3)                    // Y3 := b * Y3
+	t1.Add(t2, t2)                       // t1 := t2 + t2
+	t2.Add(t1, t2)                       // t2 := t1 + t2
+	y3.Sub(y3, t2)                       // Y3 := Y3 - t2
+	y3.Sub(y3, t0)                       // Y3 := Y3 - t0
+	t1.Add(y3, y3)                       // t1 := Y3 + Y3
+	y3.Add(t1, y3)                       // Y3 := t1 + Y3
+	t1.Add(t0, t0)                       // t1 := t0 + t0
+	t0.Add(t1, t0)                       // t0 := t1 + t0
+	t0.Sub(t0, t2)                       // t0 := t0 - t2
+	t1.Mul(t4, y3)                       // t1 := t4 * Y3
+	t2.Mul(t0, y3)                       // t2 := t0 * Y3
+	y3.Mul(x3, z3)                       // Y3 := X3 * Z3
+	y3.Add(y3, t2)                       // Y3 := Y3 + t2
+	x3.Mul(x3, t3)                       // X3 := X3 * t3
+	x3.Sub(x3, t1)                       // X3 := X3 - t1
+	z3.Mul(z3, t4)                       // Z3 := Z3 * t4
+	t1.Mul(t3, t0)                       // t1 := t3 * t0
+	z3.Add(z3, t1)                       // Z3 := Z3 + t1
+
+	p.x.Set(x3)
+	p.y.Set(y3)
+	p.z.Set(z3)
+	return p
+}
+
+// Double sets p = 2 * other with algorithm 6 of https://eprint.iacr.org/2015/1060
+func (p *p384Point) Double(other *p384Point) *p384Point {
+	t0 := new(fp.Fp).Square(&other.x)        // t0 := X ^ 2
+	t1 := new(fp.Fp).Square(&other.y)        // t1 := Y ^ 2
+	t2 := new(fp.Fp).Square(&other.z)        // t2 := Z ^ 2
+	t3 := new(fp.Fp).Mul(&other.x, &other.y) // t3 := X * Y
+	t3.Add(t3, t3)                           // t3 := t3 + t3
+	z3 := new(fp.Fp).Mul(&other.x, &other.z) // Z3 := X * Z
+	z3.Add(z3, z3)                           // Z3 := Z3 + Z3
+	y3 := new(fp.Fp).Mul(p384B, t2)          // Y3 := b * t2
+	y3.Sub(y3, z3)                           // Y3 := Y3 - Z3
+	x3 := new(fp.Fp).Add(y3, y3)             // X3 := Y3 + Y3
+	y3.Add(x3, y3)                           // Y3 := X3 + Y3
+	x3.Sub(t1, y3)                           // X3 := t1 - Y3
+	y3.Add(t1, y3)                           // Y3 := t1 + Y3
+	y3.Mul(x3, y3)                           // Y3 := X3 * Y3
+	x3.Mul(x3, t3)                           // X3 := X3 * t3
+	t3.Add(t2, t2)                           // t3 := t2 + t2
+	t2.Add(t2, t3)                           // t2 := t2 + t3
+	z3.Mul(p384B, z3)                        // Z3 := b * Z3
+	z3.Sub(z3, t2)                           // Z3 := Z3 - t2
+	z3.Sub(z3, t0)                           // Z3 := Z3 - t0
+	t3.Add(z3, z3)                           // t3 := Z3 + Z3
+	z3.Add(z3, t3)                           // Z3 := Z3 + t3
+	t3.Add(t0, t0)                           // t3 := t0 + t0
+	t0.Add(t3, t0)                           // t0 := t3 + t0
+	t0.Sub(t0, t2)                           // t0 := t0 - t2
+	t0.Mul(t0, z3)                           // t0 := t0 * Z3
+	y3.Add(y3, t0)                           // Y3 := Y3 + t0
+	t0.Mul(&other.y, &other.z)               // t0 := Y * Z
+	t0.Add(t0, t0)                           // t0 := t0 + t0
+	z3.Mul(t0, z3)                           // Z3 := t0 * Z3
+	x3.Sub(x3, z3)                           // X3 := X3 - Z3
+	z3.Mul(t0, t1)                           // Z3 := t0 * t1
+	z3.Add(z3, z3)                           // Z3 := Z3 + Z3
+	z3.Add(z3, z3)                           // Z3 := Z3 + Z3
+
+	p.x.Set(x3)
+	p.y.Set(y3)
+	p.z.Set(z3)
+	return p
+}
+
+// Mul sets p = scalar * point with a 4-bit fixed window and constant-time table lookups
+func (p *p384Point) Mul(point *p384Point, scalar *fq.Fq) *p384Point {
+	bytes := scalar.Bytes()
+	var precomputed [16]p384Point
+	precomputed[0].Identity()
+	precomputed[1].Set(point)
+	for i := 2; i < 16; i += 2 {
+		precomputed[i].Double(&precomputed[i>>1])
+		precomputed[i+1].Add(&precomputed[i], point)
+	}
+	result := new(p384Point).Identity()
+	entry := new(p384Point)
+	for i := 2*fq.Bytes - 1; i >= 0; i-- {
+		for j := 0; j < 4; j++ {
+			result.Double(result)
+		}
+		window := int(bytes[i>>1]>>(4*(i&1))) & 0x0F
+		entry.Identity()
+		for j := 1; j < 16; j++ {
+			entry.CMove(entry, &precomputed[j], ctEqual(j, window))
+		}
+		result.Add(result, entry)
+	}
+	return p.Set(result)
+}
+
+func (p *p384Point) CMove(lhs, rhs *p384Point, condition int) *p384Point {
+	p.x.CMove(&lhs.x, &rhs.x, condition)
+	p.y.CMove(&lhs.y, &rhs.y, condition)
+	p.z.CMove(&lhs.z, &rhs.z, condition)
+	return p
+}
+
+// p384Rhs returns x^3 - 3x + b
+func p384Rhs(x *fp.Fp) *fp.Fp {
+	rhs := new(fp.Fp).Square(x)
+	rhs.Mul(rhs, x)
+	rhs.Sub(rhs, x)
+	rhs.Sub(rhs, x)
+	rhs.Sub(rhs, x)
+	return rhs.Add(rhs, p384B)
+}
+
+// p384FpFromBigEndian reads a canonical big-endian field element
+func p384FpFromBigEndian(bytes []byte) (*fp.Fp, error) {
+	var arr [fp.Bytes]byte
+	copy(arr[:], internal.ReverseScalarBytes(bytes))
+	return new(fp.Fp).SetBytes(&arr)
+}
+
+// hashP384 is hash_to_curve of RFC 9380 with the simplified SWU map with Z = -12
+// when count is two, and encode_to_curve when count is one
+func hashP384(hasher *native.EllipticPointHasher, msg, dst []byte, count int) (*p384Point, error) {
+	// L = ceil((ceil(log2(p)) + k) / 8) = 72 for k = 192
+	u, err := hashToField(hasher, msg, dst, count, 72)
+	if err != nil {
+		return nil, err
+	}
+	q := new(p384Point).Identity()
+	for _, b := range u {
+		var t [fp.WideBytes]byte
+		copy(t[:], internal.ReverseScalarBytes(b))
+		q.Add(q, mapSswuP384(new(fp.Fp).SetBytesWide(&t)))
+	}
+	// The cofactor of P-384 is one
+	return q, nil
+}
+
+// mapSswuP384 is the simplified SWU map of section 6.6.2 of RFC 9380
+func mapSswuP384(u *fp.Fp) *p384Point {
+	tv1 := new(fp.Fp).Square(u)
+	tv1.Mul(tv1, p384SswuZ) // tv1 = Z * u^2
+	tv2 := new(fp.Fp).Square(tv1)
+	tv2.Add(tv2, tv1) // tv2 = Z^2 * u^4 + Z * u^2
+
+	// x1 = (-b / a) * (1 + 1 / tv2), or b / (Z * a) if tv2 = 0
+	x1, _ := new(fp.Fp).Invert(tv2)
+	x1.Add(x1, new(fp.Fp).SetOne())
+	x1.Mul(x1, p384SswuC1)
+	x1.CMove(x1, p384SswuC2, bool2int[tv2.IsZero()])
+	gx1 := p384Rhs(x1)
+
+	x2 := new(fp.Fp).Mul(tv1, x1)
+	gx2 := p384Rhs(x2)
+
+	y1, e1 := new(fp.Fp).Sqrt(gx1)
+	y2, _ := new(fp.Fp).Sqrt(gx2)
+	x := new(fp.Fp).CMove(x2, x1, bool2int[e1])
+	y := new(fp.Fp).CMove(y2, y1, bool2int[e1])
+
+	// sgn0(u) == sgn0(y)
+	negY := new(fp.Fp).Neg(y)
+	y.CMove(y, negY, bool2int[u.IsOdd() != y.IsOdd()])
+
+	p := new(p384Point)
+	p.x.Set(x)
+	p.y.Set(y)
+	p.z.SetOne()
+	return p
+}
+
+// isAllZero returns true if every byte of bytes is zero
+func isAllZero(bytes []byte) bool {
+	t := byte(0)
+	for _, b := range bytes {
+		t |= b
+	}
+	return t == 0
+}
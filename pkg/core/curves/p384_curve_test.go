@@ -0,0 +1,205 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curves
+
+import (
+	"crypto/elliptic"
+	crand "crypto/rand"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/TEENet-io/kryptology/pkg/core/curves/native"
+)
+
+func TestPointP384HashToCurve(t *testing.T) {
+	// Test vectors of RFC 9380, Appendix J.3.1
+	dst := []byte("QUUX-V01-CS02-with-P384_XMD:SHA-384_SSWU_RO_")
+	tests := []struct {
+		msg  string
+		x, y string
+	}{
+		{
+			"",
+			"eb9fe1b4f4e14e7140803c1d99d0a93cd823d2b024040f9c067a8eca1f5a2eeac9ad604973527a356f3fa3aeff0e4d83",
+			"0c21708cff382b7f4643c07b105c2eaec2cead93a917d825601e63c8f21f6abd9abc22c93c2bed6f235954b25048bb1a",
+		},
+		{
+			"abc",
+			"e02fc1a5f44a7519419dd314e29863f30df55a514da2d655775a81d413003c4d4e7fd59af0826dfaad4200ac6f60abe1",
+			"01f638d04d98677d65bef99aef1a12a70a4cbb9270ec55248c04530d8bc1f8f90f8a6a859a7c1f1ddccedf8f96d675f6",
+		},
+	}
+	for _, test := range tests {
+		ret, err := P384().Point.HashWithDst([]byte(test.msg), dst, native.EllipticPointHasherSha384())
+		require.NoError(t, err)
+		pt := ret.(*PointP384)
+		require.True(t, pt.IsOnCurve())
+		require.Equal(t, bhex(test.x), pt.X().BigInt())
+		require.Equal(t, bhex(test.y), pt.Y().BigInt())
+	}
+}
+
+func TestPointP384Elliptic(t *testing.T) {
+	curve := P384()
+	ec := elliptic.P384()
+	ell, err := curve.ToEllipticCurve()
+	require.NoError(t, err)
+	require.Equal(t, ec, ell)
+
+	g := curve.Point.Generator().(*PointP384)
+	require.Equal(t, ec.Params().Gx, g.X().BigInt())
+	require.Equal(t, ec.Params().Gy, g.Y().BigInt())
+	require.Equal(t, ec.Params().N, new(big.Int).Add(curve.Scalar.New(-1).BigInt(), big.NewInt(1)))
+
+	for i := 0; i < 10; i++ {
+		k := curve.Scalar.Random(crand.Reader)
+		x, y := ec.ScalarBaseMult(k.Bytes())
+		pt := g.Mul(k)
+		require.Equal(t, elliptic.Marshal(ec, x, y), pt.ToAffineUncompressed())
+		require.Equal(t, elliptic.MarshalCompressed(ec, x, y), pt.ToAffineCompressed())
+
+		x2, y2 := ec.Double(x, y)
+		x3, y3 := ec.Add(x2, y2, ec.Params().Gx, ec.Params().Gy)
+		expected, err := curve.Point.Set(x3, y3)
+		require.NoError(t, err)
+		require.True(t, pt.Double().Add(g).Equal(expected))
+	}
+}
+
+func TestPointP384AddDoubleMul(t *testing.T) {
+	curve := P384()
+	g := curve.Point.Generator()
+	id := curve.Point.Identity()
+	require.True(t, id.IsIdentity())
+	require.True(t, id.IsOnCurve())
+	require.True(t, g.Add(id).Equal(g))
+	require.True(t, id.Add(g).Equal(g))
+	require.True(t, id.Double().IsIdentity())
+	require.True(t, g.Double().Equal(g.Add(g)))
+	require.True(t, g.Mul(curve.Scalar.New(3)).Equal(g.Double().Add(g)))
+	require.True(t, g.Mul(curve.Scalar.New(-1)).Equal(g.Neg()))
+	require.True(t, g.Mul(curve.Scalar.Zero()).IsIdentity())
+	require.True(t, g.Sub(g).IsIdentity())
+	require.True(t, g.Add(g.Neg()).IsIdentity())
+	require.False(t, g.Equal(id))
+	require.Nil(t, g.Mul(P256().Scalar.One()))
+	require.Nil(t, g.Add(P256().Point.Generator()))
+
+	a := curve.Scalar.Random(crand.Reader)
+	b := curve.Scalar.Random(crand.Reader)
+	require.True(t, g.Mul(a).Add(g.Mul(b)).Equal(g.Mul(a.Add(b))))
+	require.True(t, g.Mul(a).Mul(b).Equal(g.Mul(a.Mul(b))))
+	require.True(t, g.SumOfProducts([]Point{g, g.Double()}, []Scalar{a, b}).Equal(g.Mul(a.Add(b.Double()))))
+	require.True(t, curve.ScalarBaseMult(a).Equal(g.Mul(a)))
+}
+
+func TestPointP384Serialize(t *testing.T) {
+	curve := P384()
+	for i := 0; i < 10; i++ {
+		pt := curve.Point.Random(crand.Reader)
+		require.True(t, pt.IsOnCurve())
+		require.Len(t, pt.ToAffineCompressed(), 49)
+		require.Len(t, pt.ToAffineUncompressed(), 97)
+		ret, err := curve.Point.FromAffineCompressed(pt.ToAffineCompressed())
+		require.NoError(t, err)
+		require.True(t, pt.Equal(ret))
+		ret, err = curve.Point.FromAffineUncompressed(pt.ToAffineUncompressed())
+		require.NoError(t, err)
+		require.True(t, pt.Equal(ret))
+		require.Equal(t, pt.IsNegative(), !pt.Neg().IsNegative())
+	}
+
+	id := curve.Point.Identity()
+	ret, err := curve.Point.FromAffineCompressed(id.ToAffineCompressed())
+	require.NoError(t, err)
+	require.True(t, ret.IsIdentity())
+	ret, err = curve.Point.FromAffineUncompressed(id.ToAffineUncompressed())
+	require.NoError(t, err)
+	require.True(t, ret.IsIdentity())
+
+	// Points off the curve are rejected
+	bad := curve.Point.Generator().ToAffineUncompressed()
+	bad[96] ^= 1
+	_, err = curve.Point.FromAffineUncompressed(bad)
+	require.Error(t, err)
+	bad = curve.Point.Generator().ToAffineCompressed()
+	bad[0] = 4
+	_, err = curve.Point.FromAffineCompressed(bad)
+	require.Error(t, err)
+	_, err = curve.Point.FromAffineCompressed(bad[:33])
+	require.Error(t, err)
+
+	pt := curve.Point.Random(crand.Reader)
+	js, err := json.Marshal(pt)
+	require.NoError(t, err)
+	out, err := pointUnmarshalJson(js)
+	require.NoError(t, err)
+	require.True(t, pt.Equal(out))
+	bin, err := pointMarshalBinary(pt)
+	require.NoError(t, err)
+	out, err = pointUnmarshalBinary(bin)
+	require.NoError(t, err)
+	require.True(t, pt.Equal(out))
+	require.Equal(t, P384(), GetCurveByName(P384Name))
+}
+
+func TestScalarP384(t *testing.T) {
+	curve := P384()
+	a := curve.Scalar.Random(crand.Reader)
+	b := curve.Scalar.Random(crand.Reader)
+	require.Equal(t, 0, a.Add(b).Sub(b).Cmp(a))
+	require.Equal(t, 0, a.Mul(b).Div(b).Cmp(a))
+	require.Equal(t, 0, a.Square().Cmp(a.Mul(a)))
+	require.Equal(t, 0, a.Cube().Cmp(a.Square().Mul(a)))
+	inv, err := a.Invert()
+	require.NoError(t, err)
+	require.True(t, inv.Mul(a).IsOne())
+	sq, err := a.Square().Sqrt()
+	require.NoError(t, err)
+	require.True(t, sq.Cmp(a) == 0 || sq.Cmp(a.Neg()) == 0)
+	require.Equal(t, -2, a.Cmp(P256().Scalar.One()))
+
+	// Bytes are big-endian as in SEC 1
+	require.Len(t, a.Bytes(), 48)
+	require.Equal(t, a.BigInt().FillBytes(make([]byte, 48)), a.Bytes())
+	ret, err := curve.Scalar.SetBytes(a.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, 0, a.Cmp(ret))
+	_, err = curve.Scalar.SetBytes(elliptic.P384().Params().N.FillBytes(make([]byte, 48)))
+	require.Error(t, err)
+	_, err = curve.Scalar.SetBytes(make([]byte, 32))
+	require.Error(t, err)
+
+	wide := make([]byte, 96)
+	wide[48] = 1
+	ret, err = curve.Scalar.SetBytesWide(wide)
+	require.NoError(t, err)
+	expected := new(big.Int).Lsh(big.NewInt(1), 384)
+	require.Equal(t, expected.Mod(expected, elliptic.P384().Params().N), ret.BigInt())
+
+	require.False(t, curve.Scalar.Hash(nil).IsZero())
+	require.NotEqual(t, 0, curve.Scalar.Hash(nil).Cmp(curve.Scalar.Hash([]byte{0})))
+
+	bin, err := scalarMarshalBinary(a)
+	require.NoError(t, err)
+	out, err := scalarUnmarshalBinary(bin)
+	require.NoError(t, err)
+	require.Equal(t, 0, a.Cmp(out))
+	txt, err := scalarMarshalText(a)
+	require.NoError(t, err)
+	out, err = scalarUnmarshalText(txt)
+	require.NoError(t, err)
+	require.Equal(t, 0, a.Cmp(out))
+	js, err := json.Marshal(a)
+	require.NoError(t, err)
+	out, err = scalarUnmarshalJson(js)
+	require.NoError(t, err)
+	require.Equal(t, 0, a.Cmp(out))
+}
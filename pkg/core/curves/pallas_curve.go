@@ -18,6 +18,8 @@ import (
 
 	"golang.org/x/crypto/blake2b"
 
+	"github.com/TEENet-io/kryptology/internal"
+	"github.com/TEENet-io/kryptology/pkg/core/curves/native"
 	"github.com/TEENet-io/kryptology/pkg/core/curves/native/pasta/fp"
 	"github.com/TEENet-io/kryptology/pkg/core/curves/native/pasta/fq"
 )
@@ -420,6 +422,26 @@ func (s *ScalarPallas) SetBytesWide(bytes []byte) (Scalar, error) {
 	}, nil
 }
 
+func (s *ScalarPallas) BytesBE() []byte {
+	return internal.ReverseScalarBytes(s.Bytes())
+}
+
+func (s *ScalarPallas) BytesLE() []byte {
+	return s.Bytes()
+}
+
+func (s *ScalarPallas) SetBytesBE(bytes []byte) (Scalar, error) {
+	return scalarSetBytesLEReduced(s, internal.ReverseScalarBytes(bytes))
+}
+
+func (s *ScalarPallas) SetBytesLE(bytes []byte) (Scalar, error) {
+	return scalarSetBytesLEReduced(s, bytes)
+}
+
+func (s *ScalarPallas) SetBytesReduce(bytes []byte) (Scalar, error) {
+	return scalarSetBytesReduce(s, bytes)
+}
+
 func (s *ScalarPallas) Point() Point {
 	return new(PointPallas).Identity()
 }
@@ -502,6 +524,22 @@ func (p *PointPallas) Hash(bytes []byte) Point {
 	return &PointPallas{new(Ep).Hash(bytes)}
 }
 
+func (p *PointPallas) HashWithDst(bytes, dst []byte, hasher *native.EllipticPointHasher) (Point, error) {
+	value, err := hashPallas(hasher, bytes, dst, 2)
+	if err != nil {
+		return nil, err
+	}
+	return &PointPallas{value}, nil
+}
+
+func (p *PointPallas) EncodeWithDst(bytes, dst []byte, hasher *native.EllipticPointHasher) (Point, error) {
+	value, err := hashPallas(hasher, bytes, dst, 1)
+	if err != nil {
+		return nil, err
+	}
+	return &PointPallas{value}, nil
+}
+
 func (p *PointPallas) Identity() Point {
 	return &PointPallas{new(Ep).Identity()}
 }
@@ -602,6 +640,20 @@ func (p *PointPallas) FromAffineCompressed(bytes []byte) (Point, error) {
 	return &PointPallas{value}, nil
 }
 
+func (p *PointPallas) IsTorsionFree() bool {
+	// The group has prime order
+	return p.IsOnCurve()
+}
+
+func (p *PointPallas) ClearCofactor() Point {
+	// The cofactor is one
+	return p
+}
+
+func (p *PointPallas) FromAffineCompressedStrict(bytes []byte) (Point, error) {
+	return fromAffineCompressedStrict(p, bytes)
+}
+
 func (p *PointPallas) FromAffineUncompressed(bytes []byte) (Point, error) {
 	value, err := new(Ep).FromAffineUncompressed(bytes)
 	if err != nil {
@@ -721,6 +773,24 @@ func (p *Ep) Hash(bytes []byte) *Ep {
 	return p.Identity().Add(r1, r2)
 }
 
+// hashPallas is hash_to_curve of RFC 9380 when count is two and encode_to_curve when count
+// is one. Unlike Ep.Hash, which reads 64 expanded bytes little-endian,
+// the field elements are read big-endian as RFC 9380 requires
+func hashPallas(hasher *native.EllipticPointHasher, msg, dst []byte, count int) (*Ep, error) {
+	// L = ceil((ceil(log2(p)) + k) / 8) = 48 for k = 128
+	u, err := hashToField(hasher, msg, dst, count, 48)
+	if err != nil {
+		return nil, err
+	}
+	q := new(Ep).Identity()
+	for _, b := range u {
+		var buf [64]byte
+		copy(buf[:], internal.ReverseScalarBytes(b))
+		q = new(Ep).Add(q, isoMap(mapSswu(new(fp.Fp).SetBytesWide(&buf))))
+	}
+	return q, nil
+}
+
 func (p *Ep) Identity() *Ep {
 	p.x = new(fp.Fp).SetZero()
 	p.y = new(fp.Fp).SetZero()
@@ -0,0 +1,613 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curves
+
+import (
+	"crypto/sha512"
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/bwesterb/go-ristretto"
+
+	"github.com/TEENet-io/kryptology/internal"
+	"github.com/TEENet-io/kryptology/pkg/core/curves/native"
+)
+
+// ristretto255HashDst is the domain separation tag of Point.Hash,
+// the hash_to_ristretto255 suite of RFC 9380 Appendix B
+const ristretto255HashDst = "ristretto255_XMD:SHA-512_R255MAP_RO_"
+
+// ScalarRistretto255 is an element of the prime order scalar field of ristretto255,
+// the same field as the ed25519 scalars
+type ScalarRistretto255 struct {
+	value *ristretto.Scalar
+}
+
+// PointRistretto255 is an element of the prime order group ristretto255 of RFC 9496.
+// Ristretto255 has no cofactor, so every encoding that decodes is a valid group element
+// and distinct elements have distinct encodings.
+type PointRistretto255 struct {
+	value *ristretto.Point
+}
+
+func (s *ScalarRistretto255) Random(reader io.Reader) Scalar {
+	if reader == nil {
+		return nil
+	}
+	var seed [64]byte
+	_, _ = reader.Read(seed[:])
+	value := new(ristretto.Scalar).SetReduced(&seed)
+	return &ScalarRistretto255{value}
+}
+
+func (s *ScalarRistretto255) Hash(bytes []byte) Scalar {
+	return &ScalarRistretto255{
+		value: new(ristretto.Scalar).Derive(bytes),
+	}
+}
+
+func (s *ScalarRistretto255) Zero() Scalar {
+	return &ScalarRistretto255{
+		value: new(ristretto.Scalar).SetZero(),
+	}
+}
+
+func (s *ScalarRistretto255) One() Scalar {
+	return &ScalarRistretto255{
+		value: new(ristretto.Scalar).SetOne(),
+	}
+}
+
+func (s *ScalarRistretto255) IsZero() bool {
+	return s.value.IsNonZeroI() == 0
+}
+
+func (s *ScalarRistretto255) IsOne() bool {
+	return s.value.EqualsI(new(ristretto.Scalar).SetOne()) == 1
+}
+
+func (s *ScalarRistretto255) IsOdd() bool {
+	return s.value.Bytes()[0]&1 == 1
+}
+
+func (s *ScalarRistretto255) IsEven() bool {
+	return s.value.Bytes()[0]&1 == 0
+}
+
+func (s *ScalarRistretto255) New(input int) Scalar {
+	var data [64]byte
+	i := input
+	if input < 0 {
+		i = -input
+	}
+	data[0] = byte(i)
+	data[1] = byte(i >> 8)
+	data[2] = byte(i >> 16)
+	data[3] = byte(i >> 24)
+	value := new(ristretto.Scalar).SetReduced(&data)
+	if input < 0 {
+		value.Neg(value)
+	}
+	return &ScalarRistretto255{value}
+}
+
+func (s *ScalarRistretto255) Cmp(rhs Scalar) int {
+	r := s.Sub(rhs)
+	if r != nil && r.IsZero() {
+		return 0
+	} else {
+		return -2
+	}
+}
+
+func (s *ScalarRistretto255) Square() Scalar {
+	return &ScalarRistretto255{
+		value: new(ristretto.Scalar).Square(s.value),
+	}
+}
+
+func (s *ScalarRistretto255) Double() Scalar {
+	return &ScalarRistretto255{
+		value: new(ristretto.Scalar).Add(s.value, s.value),
+	}
+}
+
+func (s *ScalarRistretto255) Invert() (Scalar, error) {
+	if s.IsZero() {
+		return nil, fmt.Errorf("inverse doesn't exist")
+	}
+	return &ScalarRistretto255{
+		value: new(ristretto.Scalar).Inverse(s.value),
+	}, nil
+}
+
+func (s *ScalarRistretto255) Sqrt() (Scalar, error) {
+	bi25519, _ := new(big.Int).SetString("1000000000000000000000000000000014DEF9DEA2F79CD65812631A5CF5D3ED", 16)
+	x := s.BigInt()
+	if x.ModSqrt(x, bi25519) == nil {
+		return nil, fmt.Errorf("not a square")
+	}
+	return s.SetBigInt(x)
+}
+
+func (s *ScalarRistretto255) Cube() Scalar {
+	value := new(ristretto.Scalar).Square(s.value)
+	value.Mul(value, s.value)
+	return &ScalarRistretto255{value}
+}
+
+func (s *ScalarRistretto255) Add(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarRistretto255)
+	if ok {
+		return &ScalarRistretto255{
+			value: new(ristretto.Scalar).Add(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarRistretto255) Sub(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarRistretto255)
+	if ok {
+		return &ScalarRistretto255{
+			value: new(ristretto.Scalar).Sub(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarRistretto255) Mul(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarRistretto255)
+	if ok {
+		return &ScalarRistretto255{
+			value: new(ristretto.Scalar).Mul(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarRistretto255) MulAdd(y, z Scalar) Scalar {
+	yy, ok := y.(*ScalarRistretto255)
+	if !ok {
+		return nil
+	}
+	zz, ok := z.(*ScalarRistretto255)
+	if !ok {
+		return nil
+	}
+	return &ScalarRistretto255{value: new(ristretto.Scalar).MulAdd(s.value, yy.value, zz.value)}
+}
+
+func (s *ScalarRistretto255) Div(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarRistretto255)
+	if ok {
+		value := new(ristretto.Scalar).Inverse(r.value)
+		value.Mul(value, s.value)
+		return &ScalarRistretto255{value}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarRistretto255) Neg() Scalar {
+	return &ScalarRistretto255{
+		value: new(ristretto.Scalar).Neg(s.value),
+	}
+}
+
+func (s *ScalarRistretto255) SetBigInt(x *big.Int) (Scalar, error) {
+	if x == nil {
+		return nil, fmt.Errorf("invalid value")
+	}
+	return &ScalarRistretto255{
+		value: new(ristretto.Scalar).SetBigInt(x),
+	}, nil
+}
+
+func (s *ScalarRistretto255) BigInt() *big.Int {
+	return s.value.BigInt()
+}
+
+// Bytes returns the 32-byte little-endian encoding of the scalar
+func (s *ScalarRistretto255) Bytes() []byte {
+	return s.value.Bytes()
+}
+
+// SetBytes takes input a 32-byte long little-endian encoding of a reduced scalar
+// and returns a ristretto255 scalar. Non-canonical encodings are rejected.
+func (s *ScalarRistretto255) SetBytes(input []byte) (Scalar, error) {
+	if len(input) != 32 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	var data [32]byte
+	copy(data[:], input)
+	value := new(ristretto.Scalar).SetBytes(&data)
+	if subtle.ConstantTimeCompare(value.Bytes(), input) != 1 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	return &ScalarRistretto255{value}, nil
+}
+
+// SetBytesWide takes input a 64-byte long little-endian byte array, reduces it
+// and returns a ristretto255 scalar
+func (s *ScalarRistretto255) SetBytesWide(bytes []byte) (Scalar, error) {
+	if len(bytes) != 64 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	var data [64]byte
+	copy(data[:], bytes)
+	return &ScalarRistretto255{
+		value: new(ristretto.Scalar).SetReduced(&data),
+	}, nil
+}
+
+func (s *ScalarRistretto255) BytesBE() []byte {
+	return internal.ReverseScalarBytes(s.Bytes())
+}
+
+func (s *ScalarRistretto255) BytesLE() []byte {
+	return s.Bytes()
+}
+
+func (s *ScalarRistretto255) SetBytesBE(bytes []byte) (Scalar, error) {
+	return s.SetBytes(internal.ReverseScalarBytes(bytes))
+}
+
+func (s *ScalarRistretto255) SetBytesLE(bytes []byte) (Scalar, error) {
+	return s.SetBytes(bytes)
+}
+
+func (s *ScalarRistretto255) SetBytesReduce(bytes []byte) (Scalar, error) {
+	return scalarSetBytesReduce(s, bytes)
+}
+
+func (s *ScalarRistretto255) Point() Point {
+	return new(PointRistretto255).Identity()
+}
+
+func (s *ScalarRistretto255) Clone() Scalar {
+	return &ScalarRistretto255{
+		value: new(ristretto.Scalar).Set(s.value),
+	}
+}
+
+func (s *ScalarRistretto255) MarshalBinary() ([]byte, error) {
+	return scalarMarshalBinary(s)
+}
+
+func (s *ScalarRistretto255) UnmarshalBinary(input []byte) error {
+	sc, err := scalarUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarRistretto255)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.value = ss.value
+	return nil
+}
+
+func (s *ScalarRistretto255) MarshalText() ([]byte, error) {
+	return scalarMarshalText(s)
+}
+
+func (s *ScalarRistretto255) UnmarshalText(input []byte) error {
+	sc, err := scalarUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarRistretto255)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.value = ss.value
+	return nil
+}
+
+func (s *ScalarRistretto255) MarshalJSON() ([]byte, error) {
+	return scalarMarshalJson(s)
+}
+
+func (s *ScalarRistretto255) UnmarshalJSON(input []byte) error {
+	sc, err := scalarUnmarshalJson(input)
+	if err != nil {
+		return err
+	}
+	S, ok := sc.(*ScalarRistretto255)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	s.value = S.value
+	return nil
+}
+
+func (p *PointRistretto255) Random(reader io.Reader) Point {
+	var seed [64]byte
+	_, _ = reader.Read(seed[:])
+	pt, _ := p.SetUniformBytes(seed[:])
+	return pt
+}
+
+// Hash maps bytes to the group with hash_to_ristretto255 of RFC 9380 Appendix B,
+// which expands the input with expand_message_xmd and SHA-512 and applies the
+// one-way map of RFC 9496 Section 4.3.4
+func (p *PointRistretto255) Hash(bytes []byte) Point {
+	uniform, err := expandMsgXmd(sha512.New(), bytes, []byte(ristretto255HashDst), 64)
+	if err != nil {
+		return nil
+	}
+	pt, _ := p.SetUniformBytes(uniform)
+	return pt
+}
+
+func (p *PointRistretto255) HashWithDst(bytes, dst []byte, hasher *native.EllipticPointHasher) (Point, error) {
+	// hash_to_ristretto255 of RFC 9380 Appendix B
+	u, err := hashToField(hasher, bytes, dst, 1, 64)
+	if err != nil {
+		return nil, err
+	}
+	return p.SetUniformBytes(u[0])
+}
+
+func (p *PointRistretto255) EncodeWithDst(bytes, dst []byte, hasher *native.EllipticPointHasher) (Point, error) {
+	// A single application of the MAP of RFC 9496 Section 4.3.4
+	u, err := hashToField(hasher, bytes, dst, 1, 32)
+	if err != nil {
+		return nil, err
+	}
+	var half [32]byte
+	copy(half[:], u[0])
+	return &PointRistretto255{new(ristretto.Point).SetElligator(&half)}, nil
+}
+
+// SetUniformBytes applies the one-way map of RFC 9496 Section 4.3.4 to 64 uniformly
+// random bytes, which adds the Elligator images of both halves of the input
+func (p *PointRistretto255) SetUniformBytes(input []byte) (Point, error) {
+	if len(input) != 64 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	var half [32]byte
+	copy(half[:], input[:32])
+	value := new(ristretto.Point).SetElligator(&half)
+	copy(half[:], input[32:])
+	value.Add(value, new(ristretto.Point).SetElligator(&half))
+	return &PointRistretto255{value}, nil
+}
+
+func (p *PointRistretto255) Identity() Point {
+	return &PointRistretto255{
+		value: new(ristretto.Point).SetZero(),
+	}
+}
+
+func (p *PointRistretto255) Generator() Point {
+	return &PointRistretto255{
+		value: new(ristretto.Point).SetBase(),
+	}
+}
+
+func (p *PointRistretto255) IsIdentity() bool {
+	return p.value.Equals(new(ristretto.Point).SetZero())
+}
+
+func (p *PointRistretto255) IsNegative() bool {
+	// Ristretto255 elements have no sign
+	return false
+}
+
+func (p *PointRistretto255) IsOnCurve() bool {
+	// Every ristretto255 element that can be constructed is in the group
+	return p.value != nil
+}
+
+func (p *PointRistretto255) Double() Point {
+	return &PointRistretto255{value: new(ristretto.Point).Double(p.value)}
+}
+
+func (p *PointRistretto255) Scalar() Scalar {
+	return new(ScalarRistretto255).Zero()
+}
+
+func (p *PointRistretto255) Neg() Point {
+	return &PointRistretto255{value: new(ristretto.Point).Neg(p.value)}
+}
+
+func (p *PointRistretto255) Add(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointRistretto255)
+	if ok {
+		return &PointRistretto255{value: new(ristretto.Point).Add(p.value, r.value)}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointRistretto255) Sub(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointRistretto255)
+	if ok {
+		return &PointRistretto255{value: new(ristretto.Point).Sub(p.value, r.value)}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointRistretto255) Mul(rhs Scalar) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*ScalarRistretto255)
+	if ok {
+		return &PointRistretto255{value: new(ristretto.Point).ScalarMult(p.value, r.value)}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointRistretto255) Equal(rhs Point) bool {
+	r, ok := rhs.(*PointRistretto255)
+	if ok {
+		return p.value.Equals(r.value)
+	} else {
+		return false
+	}
+}
+
+// Set is not supported since ristretto255 elements are equivalence classes of
+// edwards25519 points rather than points with affine coordinates
+func (p *PointRistretto255) Set(x, y *big.Int) (Point, error) {
+	return nil, fmt.Errorf("ristretto255 points have no affine coordinates")
+}
+
+// ToAffineCompressed returns the canonical 32-byte encoding of RFC 9496 Section 4.3.2
+func (p *PointRistretto255) ToAffineCompressed() []byte {
+	return p.value.Bytes()
+}
+
+// ToAffineUncompressed returns the canonical 32-byte encoding since
+// ristretto255 has no uncompressed encoding
+func (p *PointRistretto255) ToAffineUncompressed() []byte {
+	return p.value.Bytes()
+}
+
+// FromAffineCompressed decodes the canonical 32-byte encoding of RFC 9496 Section 4.3.1
+// and rejects every other input
+func (p *PointRistretto255) FromAffineCompressed(inBytes []byte) (Point, error) {
+	if len(inBytes) != 32 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	var data [32]byte
+	copy(data[:], inBytes)
+	value := new(ristretto.Point)
+	if !value.SetBytes(&data) {
+		return nil, fmt.Errorf("invalid point encoding")
+	}
+	return &PointRistretto255{value}, nil
+}
+
+func (p *PointRistretto255) IsTorsionFree() bool {
+	// ristretto255 is a prime order group by construction
+	return true
+}
+
+func (p *PointRistretto255) ClearCofactor() Point {
+	// The cofactor is one
+	return p
+}
+
+func (p *PointRistretto255) FromAffineCompressedStrict(bytes []byte) (Point, error) {
+	return fromAffineCompressedStrict(p, bytes)
+}
+
+// FromAffineUncompressed decodes the canonical 32-byte encoding like FromAffineCompressed
+func (p *PointRistretto255) FromAffineUncompressed(inBytes []byte) (Point, error) {
+	return p.FromAffineCompressed(inBytes)
+}
+
+func (p *PointRistretto255) CurveName() string {
+	return RISTRETTO255Name
+}
+
+func (p *PointRistretto255) SumOfProducts(points []Point, scalars []Scalar) Point {
+	if len(points) != len(scalars) {
+		return nil
+	}
+	value := new(ristretto.Point).SetZero()
+	for i, pt := range points {
+		pp, ok := pt.(*PointRistretto255)
+		if !ok {
+			return nil
+		}
+		sc, ok := scalars[i].(*ScalarRistretto255)
+		if !ok {
+			return nil
+		}
+		value.Add(value, new(ristretto.Point).ScalarMult(pp.value, sc.value))
+	}
+	return &PointRistretto255{value}
+}
+
+// SumOfProductsVartime is SumOfProducts with variable-time multiplications for public scalars
+func (p *PointRistretto255) SumOfProductsVartime(points []Point, scalars []Scalar) Point {
+	if len(points) != len(scalars) {
+		return nil
+	}
+	value := new(ristretto.Point).SetZero()
+	for i, pt := range points {
+		pp, ok := pt.(*PointRistretto255)
+		if !ok {
+			return nil
+		}
+		sc, ok := scalars[i].(*ScalarRistretto255)
+		if !ok {
+			return nil
+		}
+		value.Add(value, new(ristretto.Point).PublicScalarMult(pp.value, sc.value))
+	}
+	return &PointRistretto255{value}
+}
+
+func (p *PointRistretto255) MarshalBinary() ([]byte, error) {
+	return pointMarshalBinary(p)
+}
+
+func (p *PointRistretto255) UnmarshalBinary(input []byte) error {
+	pt, err := pointUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointRistretto255)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.value = ppt.value
+	return nil
+}
+
+func (p *PointRistretto255) MarshalText() ([]byte, error) {
+	return pointMarshalText(p)
+}
+
+func (p *PointRistretto255) UnmarshalText(input []byte) error {
+	pt, err := pointUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointRistretto255)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.value = ppt.value
+	return nil
+}
+
+func (p *PointRistretto255) MarshalJSON() ([]byte, error) {
+	return pointMarshalJson(p)
+}
+
+func (p *PointRistretto255) UnmarshalJSON(input []byte) error {
+	pt, err := pointUnmarshalJson(input)
+	if err != nil {
+		return err
+	}
+	P, ok := pt.(*PointRistretto255)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	p.value = P.value
+	return nil
+}
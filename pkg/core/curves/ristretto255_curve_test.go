@@ -0,0 +1,261 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curves
+
+import (
+	crand "crypto/rand"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScalarRistretto255Random(t *testing.T) {
+	ristretto255 := RISTRETTO255()
+	for i := 0; i < 10; i++ {
+		sc := ristretto255.Scalar.Random(crand.Reader)
+		_, ok := sc.(*ScalarRistretto255)
+		require.True(t, ok)
+		require.False(t, sc.IsZero())
+	}
+	require.Nil(t, ristretto255.Scalar.Random(nil))
+}
+
+func TestScalarRistretto255Arithmetic(t *testing.T) {
+	ristretto255 := RISTRETTO255()
+	require.True(t, ristretto255.Scalar.Zero().IsZero())
+	require.True(t, ristretto255.Scalar.Zero().IsEven())
+	require.True(t, ristretto255.Scalar.One().IsOne())
+	require.True(t, ristretto255.Scalar.One().IsOdd())
+
+	three := ristretto255.Scalar.New(3)
+	require.Equal(t, big.NewInt(9), three.Square().BigInt())
+	require.Equal(t, big.NewInt(27), three.Cube().BigInt())
+	require.Equal(t, big.NewInt(6), three.Double().BigInt())
+	require.Equal(t, big.NewInt(5), three.Add(ristretto255.Scalar.New(2)).BigInt())
+	require.Equal(t, big.NewInt(1), three.Sub(ristretto255.Scalar.New(2)).BigInt())
+	require.Equal(t, big.NewInt(12), three.Mul(ristretto255.Scalar.New(4)).BigInt())
+	require.Equal(t, big.NewInt(14), three.MulAdd(ristretto255.Scalar.New(4), ristretto255.Scalar.New(2)).BigInt())
+	require.Equal(t, 0, ristretto255.Scalar.New(-3).Cmp(three.Neg()))
+	require.Equal(t, 0, ristretto255.Scalar.New(12).Div(three).Cmp(ristretto255.Scalar.New(4)))
+
+	inv, err := three.Invert()
+	require.NoError(t, err)
+	require.True(t, inv.Mul(three).IsOne())
+	_, err = ristretto255.Scalar.Zero().Invert()
+	require.Error(t, err)
+
+	sqrt, err := ristretto255.Scalar.New(4).Sqrt()
+	require.NoError(t, err)
+	require.Equal(t, 0, sqrt.Square().Cmp(ristretto255.Scalar.New(4)))
+
+	// Arithmetic with the ed25519 scalars agrees since they have the same field
+	x := ED25519().Scalar.Random(crand.Reader)
+	y := ED25519().Scalar.Random(crand.Reader)
+	xx, err := ristretto255.Scalar.SetBytes(x.Bytes())
+	require.NoError(t, err)
+	yy, err := ristretto255.Scalar.SetBytes(y.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, x.Mul(y).Add(x).Bytes(), xx.Mul(yy).Add(xx).Bytes())
+	require.Equal(t, x.BigInt(), xx.BigInt())
+
+	// Mixing curves fails
+	require.Nil(t, three.Add(x))
+	require.Nil(t, three.Mul(x))
+	require.Nil(t, three.MulAdd(x, three))
+}
+
+func TestScalarRistretto255Bytes(t *testing.T) {
+	ristretto255 := RISTRETTO255()
+	// l - 1 is canonical
+	lMinusOne, _ := hex.DecodeString("ecd3f55c1a631258d69cf7a2def9de1400000000000000000000000000000010")
+	sc, err := ristretto255.Scalar.SetBytes(lMinusOne)
+	require.NoError(t, err)
+	require.True(t, sc.Add(ristretto255.Scalar.One()).IsZero())
+	require.Equal(t, lMinusOne, sc.Bytes())
+
+	// l and values above it are not
+	l, _ := hex.DecodeString("edd3f55c1a631258d69cf7a2def9de1400000000000000000000000000000010")
+	_, err = ristretto255.Scalar.SetBytes(l)
+	require.Error(t, err)
+	high, _ := hex.DecodeString("ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	_, err = ristretto255.Scalar.SetBytes(high)
+	require.Error(t, err)
+	_, err = ristretto255.Scalar.SetBytes(lMinusOne[:31])
+	require.Error(t, err)
+
+	// SetBytesWide reduces 64 bytes
+	wide := make([]byte, 64)
+	copy(wide, l)
+	sc, err = ristretto255.Scalar.SetBytesWide(wide)
+	require.NoError(t, err)
+	require.True(t, sc.IsZero())
+	_, err = ristretto255.Scalar.SetBytesWide(l)
+	require.Error(t, err)
+
+	sc, err = ristretto255.Scalar.SetBigInt(new(big.Int).Neg(big.NewInt(1)))
+	require.NoError(t, err)
+	require.Equal(t, lMinusOne, sc.Bytes())
+}
+
+func TestScalarRistretto255Serialize(t *testing.T) {
+	sc := RISTRETTO255().Scalar.Random(crand.Reader)
+	bin, err := sc.(*ScalarRistretto255).MarshalBinary()
+	require.NoError(t, err)
+	out := new(ScalarRistretto255)
+	require.NoError(t, out.UnmarshalBinary(bin))
+	require.Equal(t, 0, sc.Cmp(out))
+
+	txt, err := sc.(*ScalarRistretto255).MarshalText()
+	require.NoError(t, err)
+	out = new(ScalarRistretto255)
+	require.NoError(t, out.UnmarshalText(txt))
+	require.Equal(t, 0, sc.Cmp(out))
+
+	js, err := json.Marshal(sc)
+	require.NoError(t, err)
+	out = new(ScalarRistretto255)
+	require.NoError(t, json.Unmarshal(js, out))
+	require.Equal(t, 0, sc.Cmp(out))
+
+	// An ed25519 scalar does not unmarshal as a ristretto255 one
+	edBin, err := ED25519().Scalar.One().(*ScalarEd25519).MarshalBinary()
+	require.NoError(t, err)
+	require.Error(t, out.UnmarshalBinary(edBin))
+}
+
+// TestPointRistretto255Generator checks the encodings of small multiples of the
+// generator from RFC 9496 Appendix A.1
+func TestPointRistretto255Generator(t *testing.T) {
+	ristretto255 := RISTRETTO255()
+	multiples := []string{
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		"e2f2ae0a6abc4e71a884a961c500515f58e30b6aa582dd8db6a65945e08d2d76",
+		"6a493210f7499cd17fecb510ae0cea23a110e8d5b901f8acadd3095c73a3b919",
+		"94741f5d5d52755ece4f23f044ee27d5d1ea1e2bd196b462166b16152a9d0259",
+		"da80862773358b466ffadfe0b3293ab3d9fd53c5ea6c955358f568322daf6a57",
+	}
+	g := ristretto255.Point.Generator()
+	acc := ristretto255.Point.Identity()
+	for i, m := range multiples {
+		expected, _ := hex.DecodeString(m)
+		require.Equal(t, expected, acc.ToAffineCompressed())
+		require.Equal(t, expected, g.Mul(ristretto255.Scalar.New(i)).ToAffineCompressed())
+		require.Equal(t, expected, ristretto255.ScalarBaseMult(ristretto255.Scalar.New(i)).ToAffineCompressed())
+		pt, err := ristretto255.Point.FromAffineCompressed(expected)
+		require.NoError(t, err)
+		require.True(t, pt.Equal(acc))
+		acc = acc.Add(g)
+	}
+	require.True(t, ristretto255.Point.Identity().IsIdentity())
+	require.False(t, g.IsIdentity())
+}
+
+// TestPointRistretto255InvalidEncodings checks encodings rejected by RFC 9496 Appendix A.2
+func TestPointRistretto255InvalidEncodings(t *testing.T) {
+	invalid := []string{
+		// Non-canonical field encodings
+		"00ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+		"f3ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+		"edffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+		// Negative field elements
+		"0100000000000000000000000000000000000000000000000000000000000000",
+		"01ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+	}
+	for _, enc := range invalid {
+		data, _ := hex.DecodeString(enc)
+		_, err := RISTRETTO255().Point.FromAffineCompressed(data)
+		require.Error(t, err, enc)
+	}
+	_, err := RISTRETTO255().Point.FromAffineCompressed(make([]byte, 31))
+	require.Error(t, err)
+}
+
+// TestPointRistretto255UniformBytes checks the one-way map against RFC 9496 Appendix A.3
+func TestPointRistretto255UniformBytes(t *testing.T) {
+	input, _ := hex.DecodeString("5d1be09e3d0c82fc538112490e35701979d99e06ca3e2b5b54bffe8b4dc772c14d98b696a1bbfb5ca32c436cc61c16563790306c79eaca7705668b47dffe5bb6")
+	expected, _ := hex.DecodeString("3066f82a1a747d45120d1740f14358531a8f04bbffe6a819f86dfe50f44a0a46")
+	pt, err := new(PointRistretto255).SetUniformBytes(input)
+	require.NoError(t, err)
+	require.Equal(t, expected, pt.ToAffineCompressed())
+	_, err = new(PointRistretto255).SetUniformBytes(input[:32])
+	require.Error(t, err)
+}
+
+func TestPointRistretto255Hash(t *testing.T) {
+	ristretto255 := RISTRETTO255()
+	p1 := ristretto255.Point.Hash([]byte("message"))
+	p2 := ristretto255.Point.Hash([]byte("message"))
+	p3 := ristretto255.Point.Hash([]byte("another message"))
+	require.True(t, p1.Equal(p2))
+	require.False(t, p1.Equal(p3))
+	require.False(t, p1.IsIdentity())
+
+	// The hash is the one-way map of expand_message_xmd
+	uniform, err := expandMsgXmd(sha512.New(), []byte("message"), []byte(ristretto255HashDst), 64)
+	require.NoError(t, err)
+	expected, err := new(PointRistretto255).SetUniformBytes(uniform)
+	require.NoError(t, err)
+	require.True(t, expected.Equal(p1))
+}
+
+func TestPointRistretto255Arithmetic(t *testing.T) {
+	ristretto255 := RISTRETTO255()
+	g := ristretto255.Point.Generator()
+	a := ristretto255.Scalar.Random(crand.Reader)
+	b := ristretto255.Scalar.Random(crand.Reader)
+	require.True(t, g.Mul(a).Add(g.Mul(b)).Equal(g.Mul(a.Add(b))))
+	require.True(t, g.Mul(a).Sub(g.Mul(b)).Equal(g.Mul(a.Sub(b))))
+	require.True(t, g.Mul(a).Neg().Equal(g.Mul(a.Neg())))
+	require.True(t, g.Double().Equal(g.Add(g)))
+	require.True(t, g.Mul(a).Sub(g.Mul(a)).IsIdentity())
+	require.True(t, g.Mul(a).IsOnCurve())
+
+	points := []Point{g, ristretto255.Point.Random(crand.Reader), ristretto255.Point.Hash([]byte("H"))}
+	scalars := []Scalar{a, b, a.Mul(b)}
+	expected := points[0].Mul(scalars[0]).Add(points[1].Mul(scalars[1])).Add(points[2].Mul(scalars[2]))
+	require.True(t, expected.Equal(g.SumOfProducts(points, scalars)))
+	sum, err := SumOfProductsVartime(points, scalars)
+	require.NoError(t, err)
+	require.True(t, expected.Equal(sum))
+
+	// Mixing curves fails
+	require.Nil(t, g.Add(ED25519().Point.Generator()))
+	require.Nil(t, g.Mul(ED25519().Scalar.One()))
+	require.False(t, g.Equal(ED25519().Point.Generator()))
+	_, err = SumOfProductsVartime([]Point{g, ED25519().Point.Generator()}, []Scalar{a, b})
+	require.Error(t, err)
+	_, err = g.Set(big.NewInt(1), big.NewInt(1))
+	require.Error(t, err)
+}
+
+func TestPointRistretto255Serialize(t *testing.T) {
+	pt := RISTRETTO255().Point.Random(crand.Reader)
+	bin, err := pt.(*PointRistretto255).MarshalBinary()
+	require.NoError(t, err)
+	out := new(PointRistretto255)
+	require.NoError(t, out.UnmarshalBinary(bin))
+	require.True(t, pt.Equal(out))
+
+	txt, err := pt.(*PointRistretto255).MarshalText()
+	require.NoError(t, err)
+	out = new(PointRistretto255)
+	require.NoError(t, out.UnmarshalText(txt))
+	require.True(t, pt.Equal(out))
+
+	js, err := json.Marshal(pt)
+	require.NoError(t, err)
+	out = new(PointRistretto255)
+	require.NoError(t, json.Unmarshal(js, out))
+	require.True(t, pt.Equal(out))
+
+	require.Equal(t, RISTRETTO255(), GetCurveByName(RISTRETTO255Name))
+}
@@ -0,0 +1,41 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curves
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/TEENet-io/kryptology/internal"
+)
+
+// scalarSetBytesReduce interprets bytes as a big-endian integer of any length
+// and returns it modulo the group order of s
+func scalarSetBytesReduce(s Scalar, bytes []byte) (Scalar, error) {
+	v := new(big.Int).SetBytes(bytes)
+	return s.SetBigInt(v.Mod(v, scalarOrder(s)))
+}
+
+// scalarSetBytesLEReduced interprets bytes as a 32 byte little-endian integer
+// and rejects values that are not less than the group order of s. The pasta
+// fields do not range check in SetBytes.
+func scalarSetBytesLEReduced(s Scalar, bytes []byte) (Scalar, error) {
+	if len(bytes) != 32 {
+		return nil, fmt.Errorf("invalid length")
+	}
+	v := new(big.Int).SetBytes(internal.ReverseScalarBytes(bytes))
+	if v.Cmp(scalarOrder(s)) >= 0 {
+		return nil, fmt.Errorf("invalid scalar: not reduced")
+	}
+	return s.SetBigInt(v)
+}
+
+// scalarOrder returns the group order of s
+func scalarOrder(s Scalar) *big.Int {
+	order := s.One().Neg().BigInt()
+	return order.Add(order, big.NewInt(1))
+}
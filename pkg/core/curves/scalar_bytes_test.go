@@ -0,0 +1,73 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curves
+
+import (
+	"bytes"
+	crand "crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/TEENet-io/kryptology/internal"
+)
+
+func TestScalarBytesEndianness(t *testing.T) {
+	for _, curve := range codecTestCurves() {
+		s := curve.Scalar.Random(crand.Reader)
+		width := len(s.Bytes())
+
+		be := s.BytesBE()
+		require.Equal(t, s.BigInt().FillBytes(make([]byte, width)), be, curve.Name)
+		require.Equal(t, internal.ReverseScalarBytes(be), s.BytesLE(), curve.Name)
+
+		r, err := curve.Scalar.SetBytesBE(be)
+		require.NoError(t, err, curve.Name)
+		require.Equal(t, 0, s.Cmp(r), curve.Name)
+		r, err = curve.Scalar.SetBytesLE(s.BytesLE())
+		require.NoError(t, err, curve.Name)
+		require.Equal(t, 0, s.Cmp(r), curve.Name)
+
+		// One is 1 in the last big-endian and the first little-endian byte
+		one := curve.Scalar.One()
+		require.Equal(t, byte(1), one.BytesBE()[width-1], curve.Name)
+		require.Equal(t, byte(1), one.BytesLE()[0], curve.Name)
+
+		order := scalarOrder(curve.Scalar).FillBytes(make([]byte, width))
+		_, err = curve.Scalar.SetBytesBE(order)
+		require.Error(t, err, curve.Name)
+		_, err = curve.Scalar.SetBytesLE(internal.ReverseScalarBytes(order))
+		require.Error(t, err, curve.Name)
+		allOnes := bytes.Repeat([]byte{0xff}, width)
+		_, err = curve.Scalar.SetBytesBE(allOnes)
+		require.Error(t, err, curve.Name)
+		_, err = curve.Scalar.SetBytesLE(allOnes)
+		require.Error(t, err, curve.Name)
+		_, err = curve.Scalar.SetBytesBE(be[1:])
+		require.Error(t, err, curve.Name)
+	}
+}
+
+func TestScalarSetBytesReduce(t *testing.T) {
+	for _, curve := range codecTestCurves() {
+		order := scalarOrder(curve.Scalar)
+		for _, n := range []int{0, 1, 32, 64, 100} {
+			input := make([]byte, n)
+			_, _ = crand.Read(input)
+			s, err := curve.Scalar.SetBytesReduce(input)
+			require.NoError(t, err, curve.Name)
+			expected := new(big.Int).SetBytes(input)
+			require.Equal(t, 0, expected.Mod(expected, order).Cmp(s.BigInt()), curve.Name)
+		}
+		s, err := curve.Scalar.SetBytesReduce(order.Bytes())
+		require.NoError(t, err, curve.Name)
+		require.True(t, s.IsZero(), curve.Name)
+	}
+	_, err := new(ScalarBls12381Gt).SetBytesReduce([]byte{1})
+	require.Error(t, err)
+}
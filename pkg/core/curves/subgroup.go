@@ -0,0 +1,73 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curves
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// fromAffineCompressedStrict decodes input with p.FromAffineCompressed and rejects
+// encodings that do not round trip, the identity and points of small order
+func fromAffineCompressedStrict(p Point, input []byte) (Point, error) {
+	q, err := p.FromAffineCompressed(input)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(q.ToAffineCompressed(), input) {
+		return nil, fmt.Errorf("non-canonical point encoding")
+	}
+	if err := validatePoint(q); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// codecPoint decodes a compressed point of curve like fromAffineCompressedStrict,
+// except that the encoding of the identity is accepted
+func codecPoint(curve *Curve, input []byte) (Point, error) {
+	identity := curve.Point.Identity()
+	if bytes.Equal(identity.ToAffineCompressed(), input) {
+		return identity, nil
+	}
+	return curve.Point.FromAffineCompressedStrict(input)
+}
+
+// validatePoint returns an error unless p is on its curve, in the prime order subgroup
+// and not the identity, which is what protocols require of points from other parties
+func validatePoint(p Point) error {
+	if p == nil {
+		return fmt.Errorf("nil point")
+	}
+	if !p.IsOnCurve() {
+		return fmt.Errorf("point is not on the curve")
+	}
+	if p.IsIdentity() {
+		return fmt.Errorf("point is the identity")
+	}
+	if !p.IsTorsionFree() {
+		return fmt.Errorf("point is not in the prime order subgroup")
+	}
+	return nil
+}
+
+// PeerPoint returns p decoded again with FromAffineCompressedStrict of c. Protocols pass the points
+// they receive from other parties through it, which rejects points of another curve, the identity
+// and points outside the prime order subgroup as decoding them from bytes would.
+func (c Curve) PeerPoint(p Point) (Point, error) {
+	if p == nil || c.Point == nil {
+		return nil, fmt.Errorf("nil point")
+	}
+	if p.CurveName() != c.Name {
+		return nil, fmt.Errorf("point of curve %s instead of %s", p.CurveName(), c.Name)
+	}
+	// Some curves encode the identity like a point on the curve, so it is rejected before decoding
+	if p.IsIdentity() {
+		return nil, fmt.Errorf("point is the identity")
+	}
+	return c.Point.FromAffineCompressedStrict(p.ToAffineCompressed())
+}
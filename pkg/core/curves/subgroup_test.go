@@ -0,0 +1,132 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curves
+
+import (
+	crand "crypto/rand"
+	"testing"
+
+	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubgroupPrimeOrderPoints(t *testing.T) {
+	for _, curve := range codecTestCurves() {
+		p := curve.ScalarBaseMult(curve.Scalar.Random(crand.Reader))
+		require.True(t, p.IsTorsionFree(), curve.Name)
+		require.True(t, p.ClearCofactor().IsTorsionFree(), curve.Name)
+
+		q, err := curve.Point.FromAffineCompressedStrict(p.ToAffineCompressed())
+		require.NoError(t, err, curve.Name)
+		require.True(t, p.Equal(q), curve.Name)
+
+		// Some curves, like P-256, encode the identity like the point with x = 0, which may then be decoded
+		q, err = curve.Point.FromAffineCompressedStrict(curve.Point.Identity().ToAffineCompressed())
+		if err == nil {
+			require.False(t, q.IsIdentity(), curve.Name)
+		}
+	}
+}
+
+func TestSubgroupEd25519SmallOrder(t *testing.T) {
+	// y = 0 encodes a point of order 4
+	torsion, err := new(PointEd25519).FromAffineCompressed(make([]byte, 32))
+	require.NoError(t, err)
+	require.True(t, torsion.IsOnCurve())
+	require.False(t, torsion.IsTorsionFree())
+	require.True(t, torsion.ClearCofactor().IsIdentity())
+	_, err = new(PointEd25519).FromAffineCompressedStrict(make([]byte, 32))
+	require.Error(t, err)
+
+	p := ED25519().ScalarBaseMult(ED25519().Scalar.Random(crand.Reader))
+	mixed := p.Add(torsion)
+	require.False(t, mixed.IsTorsionFree())
+	require.True(t, mixed.ClearCofactor().Equal(p.Mul(ED25519().Scalar.New(8))))
+	_, err = new(PointEd25519).FromAffineCompressedStrict(mixed.ToAffineCompressed())
+	require.Error(t, err)
+
+	// The sign bit of x = 0 is set in this encoding of (0, -1)
+	negOne := []byte{
+		0xec, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	}
+	_, err = new(PointEd25519).FromAffineCompressed(negOne)
+	require.NoError(t, err)
+	_, err = new(PointEd25519).FromAffineCompressedStrict(negOne)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "non-canonical")
+}
+
+func TestSubgroupEd448SmallOrder(t *testing.T) {
+	// y = 0 encodes a point of order 4
+	torsion, err := new(PointEd448).FromAffineCompressed(make([]byte, ed448EncodedBytes))
+	require.NoError(t, err)
+	require.True(t, torsion.IsOnCurve())
+	require.False(t, torsion.IsTorsionFree())
+	require.True(t, torsion.ClearCofactor().IsIdentity())
+
+	p := ED448().ScalarBaseMult(ED448().Scalar.Random(crand.Reader))
+	mixed := p.Add(torsion)
+	require.False(t, mixed.IsTorsionFree())
+	require.True(t, mixed.ClearCofactor().Equal(p.Mul(ED448().Scalar.New(4))))
+	_, err = new(PointEd448).FromAffineCompressedStrict(mixed.ToAffineCompressed())
+	require.Error(t, err)
+}
+
+func TestSubgroupBls12377SmallOrder(t *testing.T) {
+	// (0, 1) is a point of order 3 on y^2 = x^3 + 1
+	value := &bls12377.G1Affine{}
+	value.Y.SetOne()
+	torsion := &PointBls12377G1{value}
+	require.True(t, torsion.IsOnCurve())
+	require.False(t, torsion.IsTorsionFree())
+	require.True(t, torsion.ClearCofactor().IsTorsionFree())
+
+	p := BLS12377G1().ScalarBaseMult(BLS12377G1().Scalar.Random(crand.Reader))
+	require.False(t, p.Add(torsion).IsTorsionFree())
+}
+
+func TestSubgroupCodecRejectsTorsion(t *testing.T) {
+	torsion, err := new(PointEd25519).FromAffineCompressed(make([]byte, 32))
+	require.NoError(t, err)
+	p := ED25519().ScalarBaseMult(ED25519().Scalar.Random(crand.Reader))
+	data, err := MarshalPoint(p.Add(torsion))
+	require.NoError(t, err)
+	_, err = UnmarshalPoint(data)
+	require.Error(t, err)
+	data, err = MarshalPointJSON(p.Add(torsion))
+	require.NoError(t, err)
+	_, err = UnmarshalPointJSON(data)
+	require.Error(t, err)
+	data, err = pointMarshalBinary(p.Add(torsion))
+	require.NoError(t, err)
+	_, err = UnmarshalPoint(data)
+	require.Error(t, err)
+}
+
+func TestSubgroupPeerPoint(t *testing.T) {
+	for _, curve := range codecTestCurves() {
+		p := curve.ScalarBaseMult(curve.Scalar.Random(crand.Reader))
+		q, err := curve.PeerPoint(p)
+		require.NoError(t, err, curve.Name)
+		require.True(t, p.Equal(q), curve.Name)
+		_, err = curve.PeerPoint(curve.Point.Identity())
+		require.Error(t, err, curve.Name)
+		_, err = curve.PeerPoint(nil)
+		require.Error(t, err, curve.Name)
+	}
+
+	// K-256 and P-256 points have compressed encodings of the same length
+	_, err := K256().PeerPoint(P256().Point.Generator())
+	require.Error(t, err)
+
+	torsion, err := new(PointEd25519).FromAffineCompressed(make([]byte, 32))
+	require.NoError(t, err)
+	p := ED25519().ScalarBaseMult(ED25519().Scalar.Random(crand.Reader))
+	_, err = ED25519().PeerPoint(p.Add(torsion))
+	require.Error(t, err)
+}
@@ -0,0 +1,172 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package curves
+
+import (
+	crand "crypto/rand"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/TEENet-io/kryptology/pkg/core/curves/native/pasta/fp"
+	"github.com/TEENet-io/kryptology/pkg/core/curves/native/pasta/fq"
+)
+
+func TestVestaPastaCycle(t *testing.T) {
+	// The scalar field of each curve is the base field of the other
+	p, _ := new(big.Int).SetString("40000000000000000000000000000000224698fc094cf91b992d30ed00000001", 16)
+	q, _ := new(big.Int).SetString("40000000000000000000000000000000224698fc0994a8dd8c46eb2100000001", 16)
+	require.Equal(t, q, fq.BiModulus)
+	require.Equal(t, p, new(big.Int).Add(VESTA().Scalar.New(-1).BigInt(), big.NewInt(1)))
+	require.Equal(t, q, new(big.Int).Add(PALLAS().Scalar.New(-1).BigInt(), big.NewInt(1)))
+	require.True(t, new(Eq).Generator().IsOnCurve())
+}
+
+func TestPointVestaAddDoubleMul(t *testing.T) {
+	g := new(Eq).Generator()
+	id := new(Eq).Identity()
+	require.Equal(t, g.Add(g, id), g)
+
+	g2 := new(Eq).Add(g, g)
+	require.True(t, new(Eq).Double(g).Equal(g2))
+	require.Equal(t, new(Eq).Double(g), new(Eq).Add(g, g))
+	g3 := new(Eq).Add(g, g2)
+	require.True(t, g3.Equal(new(Eq).Mul(g, new(fp.Fp).SetUint64(3))))
+
+	g4 := new(Eq).Add(g3, g)
+	require.True(t, g4.Equal(new(Eq).Double(g2)))
+	require.True(t, g4.Equal(new(Eq).Mul(g, new(fp.Fp).SetUint64(4))))
+
+	// The generator has order p
+	require.True(t, new(Eq).Mul(g, new(fp.Fp).Neg(new(fp.Fp).SetOne())).Equal(new(Eq).Neg(g)))
+}
+
+func TestPointVestaHash(t *testing.T) {
+	h0 := new(Eq).Hash(nil)
+	require.True(t, h0.IsOnCurve())
+	h1 := new(Eq).Hash([]byte{})
+	require.True(t, h1.IsOnCurve())
+	require.True(t, h0.Equal(h1))
+	h2 := new(Eq).Hash([]byte{1})
+	require.True(t, h2.IsOnCurve())
+	require.False(t, h0.Equal(h2))
+
+	for i := 0; i < 25; i++ {
+		require.True(t, new(Eq).Random(crand.Reader).IsOnCurve())
+	}
+}
+
+func TestPointVestaMapSswu(t *testing.T) {
+	// The simplified SWU map lands on the isogenous curve y^2 = x^3 + A'x + B'
+	for i := 0; i < 25; i++ {
+		var seed [64]byte
+		_, _ = crand.Read(seed[:])
+		u := new(fq.Fq).SetBytesWide(&seed)
+		q := mapSswuVesta(u)
+		lhs := new(fq.Fq).Square(q.y)
+		rhs := new(fq.Fq).Square(q.x)
+		rhs.Add(rhs, vestaIsoa)
+		rhs.Mul(rhs, q.x)
+		rhs.Add(rhs, vestaIsob)
+		require.True(t, lhs.Equal(rhs))
+		require.True(t, isoMapVesta(q).IsOnCurve())
+	}
+}
+
+func TestPointVestaNeg(t *testing.T) {
+	g := new(Eq).Generator()
+	g.Neg(g)
+	require.True(t, g.Neg(g).Equal(new(Eq).Generator()))
+	id := new(Eq).Identity()
+	require.True(t, new(Eq).Neg(id).Equal(id))
+}
+
+func TestPointVestaSerialize(t *testing.T) {
+	g := new(Eq).Generator()
+	for i := 0; i < 25; i++ {
+		s := new(ScalarVesta).Random(crand.Reader).(*ScalarVesta)
+		pt := new(Eq).Mul(g, s.value)
+		cmprs := pt.ToAffineCompressed()
+		require.Equal(t, len(cmprs), 32)
+		retC, err := new(Eq).FromAffineCompressed(cmprs)
+		require.NoError(t, err)
+		require.True(t, pt.Equal(retC))
+
+		un := pt.ToAffineUncompressed()
+		require.Equal(t, len(un), 64)
+		retU, err := new(Eq).FromAffineUncompressed(un)
+		require.NoError(t, err)
+		require.True(t, pt.Equal(retU))
+	}
+
+	pt := VESTA().Point.Random(crand.Reader)
+	js, err := json.Marshal(pt)
+	require.NoError(t, err)
+	out := new(PointVesta)
+	require.NoError(t, json.Unmarshal(js, out))
+	require.True(t, pt.Equal(out))
+	bin, err := pt.(*PointVesta).MarshalBinary()
+	require.NoError(t, err)
+	out = new(PointVesta)
+	require.NoError(t, out.UnmarshalBinary(bin))
+	require.True(t, pt.Equal(out))
+
+	sc := VESTA().Scalar.Random(crand.Reader)
+	js, err = json.Marshal(sc)
+	require.NoError(t, err)
+	outSc := new(ScalarVesta)
+	require.NoError(t, json.Unmarshal(js, outSc))
+	require.Equal(t, 0, sc.Cmp(outSc))
+
+	// Pallas points do not decode as Vesta points
+	bin, err = PALLAS().Point.Generator().(*PointPallas).MarshalBinary()
+	require.NoError(t, err)
+	require.Error(t, out.UnmarshalBinary(bin))
+	require.Equal(t, VESTA(), GetCurveByName(VestaName))
+}
+
+func TestScalarVestaArithmetic(t *testing.T) {
+	vesta := VESTA()
+	three := vesta.Scalar.New(3)
+	require.Equal(t, big.NewInt(9), three.Square().BigInt())
+	require.Equal(t, big.NewInt(27), three.Cube().BigInt())
+	require.True(t, three.IsOdd())
+	require.True(t, vesta.Scalar.New(4).IsEven())
+	inv, err := three.Invert()
+	require.NoError(t, err)
+	require.True(t, inv.Mul(three).IsOne())
+	sqrt, err := vesta.Scalar.New(4).Sqrt()
+	require.NoError(t, err)
+	require.Equal(t, 0, sqrt.Square().Cmp(vesta.Scalar.New(4)))
+	require.Nil(t, three.Add(PALLAS().Scalar.One()))
+
+	g := vesta.Point.Generator()
+	a := vesta.Scalar.Random(crand.Reader)
+	b := vesta.Scalar.Random(crand.Reader)
+	require.True(t, g.Mul(a).Add(g.Mul(b)).Equal(g.Mul(a.Add(b))))
+}
+
+func TestPointVestaSumOfProducts(t *testing.T) {
+	lhs := new(Eq).Generator()
+	lhs.Mul(lhs, new(fp.Fp).SetUint64(50))
+	points := make([]*Eq, 5)
+	for i := range points {
+		points[i] = new(Eq).Generator()
+	}
+	scalars := []Scalar{
+		new(ScalarVesta).New(8),
+		new(ScalarVesta).New(9),
+		new(ScalarVesta).New(10),
+		new(ScalarVesta).New(11),
+		new(ScalarVesta).New(12),
+	}
+	rhs := lhs.SumOfProducts(points, scalars)
+	require.NotNil(t, rhs)
+	require.True(t, lhs.Equal(rhs))
+}
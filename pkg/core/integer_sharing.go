@@ -0,0 +1,45 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package core
+
+import (
+	"math/big"
+)
+
+// MaxShareLimit is the largest number of shares of Shamir sharing over the integers, the same as in pkg/sharing
+const MaxShareLimit = 255
+
+// Factorial returns n!, the Δ of Shoup's threshold schemes that keeps Lagrange coefficients integral
+func Factorial(n uint32) *big.Int {
+	return new(big.Int).MulRange(1, int64(n))
+}
+
+// EvaluatePolynomial returns f(x) mod m for f with the given coefficients, constant term first
+func EvaluatePolynomial(coefficients []*big.Int, x, m *big.Int) *big.Int {
+	y := new(big.Int)
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		y.Mul(y, x)
+		y.Add(y, coefficients[i])
+		y.Mod(y, m)
+	}
+	return y
+}
+
+// LagrangeCoefficient returns the integer Δ ∏_{j≠i} j / (j - i) over ids, which is exact when Δ is
+// Factorial of the number of shares
+func LagrangeCoefficient(delta *big.Int, i uint32, ids []uint32) *big.Int {
+	num := new(big.Int).Set(delta)
+	den := big.NewInt(1)
+	for _, j := range ids {
+		if j == i {
+			continue
+		}
+		num.Mul(num, big.NewInt(int64(j)))
+		den.Mul(den, big.NewInt(int64(j)-int64(i)))
+	}
+	return num.Quo(num, den)
+}
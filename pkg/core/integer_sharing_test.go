@@ -0,0 +1,42 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFactorial(t *testing.T) {
+	require.Equal(t, big.NewInt(1), Factorial(0))
+	require.Equal(t, big.NewInt(1), Factorial(1))
+	require.Equal(t, big.NewInt(120), Factorial(5))
+}
+
+func TestEvaluatePolynomial(t *testing.T) {
+	// f(x) = 3 + 2x + x² at 4 is 27, which is 6 mod 7
+	f := []*big.Int{big.NewInt(3), big.NewInt(2), big.NewInt(1)}
+	require.Equal(t, big.NewInt(27), EvaluatePolynomial(f, big.NewInt(4), big.NewInt(100)))
+	require.Equal(t, big.NewInt(6), EvaluatePolynomial(f, big.NewInt(4), big.NewInt(7)))
+}
+
+func TestLagrangeCoefficientInterpolates(t *testing.T) {
+	// Any three shares of f(x) = 3 + 2x + x² give Δ f(0) = Σ μ_i f(i)
+	f := []*big.Int{big.NewInt(3), big.NewInt(2), big.NewInt(1)}
+	modulus := big.NewInt(1 << 40)
+	delta := Factorial(5)
+	for _, ids := range [][]uint32{{1, 2, 3}, {2, 4, 5}, {1, 3, 5}} {
+		sum := new(big.Int)
+		for _, i := range ids {
+			mu := LagrangeCoefficient(delta, i, ids)
+			sum.Add(sum, mu.Mul(mu, EvaluatePolynomial(f, big.NewInt(int64(i)), modulus)))
+		}
+		require.Equal(t, new(big.Int).Mul(delta, f[0]), sum)
+	}
+}
@@ -7,36 +7,256 @@
 package core
 
 import (
+	"context"
 	"crypto/rand"
 	"fmt"
 	"math"
 	"math/big"
+	"runtime"
+	"sync"
 )
 
+const (
+	// sieveBound bounds the small primes that are sieved out of safe prime candidates
+	sieveBound = 1 << 13
+	// sieveWindow is the number of candidates each worker scans from one random start
+	sieveWindow = 1 << 16
+	// minSieveBits is the smallest safe prime size that is generated with the sieve,
+	// so that no candidate equals one of the small primes
+	minSieveBits = 32
+)
+
+// sievePrimes are the primes 5 ≤ r < sieveBound. 2 and 3 are handled by picking q = 5 mod 6.
+var sievePrimes = func() []uint64 {
+	composite := make([]bool, sieveBound)
+	var primes []uint64
+	for i := 2; i < sieveBound; i++ {
+		if composite[i] {
+			continue
+		}
+		if i >= 5 {
+			primes = append(primes, uint64(i))
+		}
+		for j := i * i; j < sieveBound; j += i {
+			composite[j] = true
+		}
+	}
+	return primes
+}()
+
+// safePrimePool is the pool that GenerateSafePrime draws from, if any
+var safePrimePool struct {
+	sync.RWMutex
+	pool *SafePrimePool
+}
+
 // GenerateSafePrime creates a prime number `p`
-// where (`p`-1)/2 is also prime with at least `bits`
+// where (`p`-1)/2 is also prime with at least `bits`.
+// It uses the pool set by UseSafePrimePool when it generates primes of the same size.
 func GenerateSafePrime(bits uint) (*big.Int, error) {
+	safePrimePool.RLock()
+	pool := safePrimePool.pool
+	safePrimePool.RUnlock()
+	if pool != nil && pool.bits == bits {
+		return pool.Get(context.Background())
+	}
+	return GenerateSafePrimeContext(context.Background(), bits)
+}
+
+// GenerateSafePrimeContext creates a safe prime `p` = 2`q`+1 of `bits` bits with parallel workers.
+// Each worker sieves both q and 2q+1 by the small primes before testing them for primality.
+// It returns ctx.Err() when ctx is done before a safe prime is found.
+func GenerateSafePrimeContext(ctx context.Context, bits uint) (*big.Int, error) {
 	if bits < 3 {
 		return nil, fmt.Errorf("safe prime size must be at least 3-bits")
 	}
+	if bits < minSieveBits {
+		return generateSmallSafePrime(ctx, bits)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	workers := runtime.NumCPU()
+	results := make(chan *big.Int, workers)
+	errors := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			p, err := sieveSafePrime(ctx, bits)
+			if err != nil {
+				errors <- err
+				return
+			}
+			results <- p
+		}()
+	}
 
-	var p *big.Int
 	var err error
+	for i := 0; i < workers; i++ {
+		select {
+		case p := <-results:
+			return p, nil
+		case err = <-errors:
+		}
+	}
+	return nil, err
+}
+
+// sieveSafePrime searches for a safe prime until it finds one or ctx is done
+func sieveSafePrime(ctx context.Context, bits uint) (*big.Int, error) {
 	checks := int(math.Max(float64(bits)/16, 8))
+	remainders := make([]uint64, len(sievePrimes))
+	step := big.NewInt(6)
+	two := big.NewInt(2)
 	for {
+		// q has its top two bits set so that p = 2q + 1 has exactly bits bits, and q = 5 mod 6
+		// so that neither q nor p is divisible by 2 or 3
+		q, err := rand.Int(rand.Reader, new(big.Int).Lsh(One, bits-1))
+		if err != nil {
+			return nil, err
+		}
+		q.SetBit(q, int(bits)-2, 1)
+		q.SetBit(q, int(bits)-3, 1)
+		q.Sub(q, new(big.Int).Mod(q, step))
+		q.Add(q, big.NewInt(5))
+		for i, r := range sievePrimes {
+			remainders[i] = new(big.Int).Mod(q, new(big.Int).SetUint64(r)).Uint64()
+		}
+
+		for k := 0; k < sieveWindow; k++ {
+			if k > 0 {
+				q.Add(q, step)
+				for i, r := range sievePrimes {
+					remainders[i] = (remainders[i] + 6) % r
+				}
+			}
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			if q.BitLen() != int(bits)-1 {
+				break
+			}
+			// r divides p = 2q + 1 exactly when q = (r - 1) / 2 mod r
+			sieved := false
+			for i, r := range sievePrimes {
+				if remainders[i] == 0 || remainders[i] == (r-1)/2 {
+					sieved = true
+					break
+				}
+			}
+			if sieved {
+				continue
+			}
+
+			// Fermat tests to base 2 rule out most candidates before the full tests
+			p := new(big.Int).Lsh(q, 1)
+			p.Add(p, One)
+			if new(big.Int).Exp(two, new(big.Int).Sub(q, One), q).Cmp(One) != 0 {
+				continue
+			}
+			if new(big.Int).Exp(two, new(big.Int).Lsh(q, 1), p).Cmp(One) != 0 {
+				continue
+			}
+			if q.ProbablyPrime(checks) && p.ProbablyPrime(checks) {
+				return p, nil
+			}
+		}
+	}
+}
+
+// generateSmallSafePrime creates a safe prime of fewer than minSieveBits bits
+func generateSmallSafePrime(ctx context.Context, bits uint) (*big.Int, error) {
+	checks := int(math.Max(float64(bits)/16, 8))
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		// rand.Prime throws an error if bits < 2
-		// -1 so the Sophie-Germain prime is 1023 bits
-		// and the Safe prime is 1024
-		p, err = rand.Prime(rand.Reader, int(bits)-1)
+		// -1 so the Sophie-Germain prime is bits-1 bits
+		// and the Safe prime is bits
+		p, err := rand.Prime(rand.Reader, int(bits)-1)
 		if err != nil {
 			return nil, err
 		}
 		p.Add(p.Lsh(p, 1), One)
 
 		if p.ProbablyPrime(checks) {
-			break
+			return p, nil
+		}
+	}
+}
+
+// SafePrimePool generates safe primes of one size in the background, so that later calls
+// take a prime that is ready instead of waiting for the generation
+type SafePrimePool struct {
+	bits   uint
+	primes chan *big.Int
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewSafePrimePool starts generating up to size safe primes of bits bits in the background.
+// The pool keeps generating primes to replace those taken until Close is called.
+func NewSafePrimePool(bits uint, size int) (*SafePrimePool, error) {
+	if bits < 3 {
+		return nil, fmt.Errorf("safe prime size must be at least 3-bits")
+	}
+	if size < 1 {
+		return nil, fmt.Errorf("pool size must be at least 1")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := &SafePrimePool{
+		bits:   bits,
+		primes: make(chan *big.Int, size),
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	go pool.fill(ctx)
+	return pool, nil
+}
+
+// fill generates safe primes into the pool until ctx is done
+func (pool *SafePrimePool) fill(ctx context.Context) {
+	defer close(pool.done)
+	for {
+		p, err := GenerateSafePrimeContext(ctx, pool.bits)
+		if err != nil {
+			// Only a cancelled context or a failing random source end the generation
+			return
+		}
+		select {
+		case pool.primes <- p:
+		case <-ctx.Done():
+			return
 		}
 	}
+}
+
+// Bits returns the size of the safe primes in the pool
+func (pool *SafePrimePool) Bits() uint {
+	return pool.bits
+}
+
+// Get returns a safe prime from the pool, or generates one when none is ready
+func (pool *SafePrimePool) Get(ctx context.Context) (*big.Int, error) {
+	select {
+	case p := <-pool.primes:
+		return p, nil
+	default:
+		return GenerateSafePrimeContext(ctx, pool.bits)
+	}
+}
+
+// Close stops the background generation and waits for it to finish
+func (pool *SafePrimePool) Close() {
+	pool.cancel()
+	<-pool.done
+}
 
-	return p, nil
+// UseSafePrimePool makes GenerateSafePrime take primes of the pool's size from pool,
+// e.g. for paillier.NewKeys, dealer.NewProofParams and camshoup.NewPaillierGroup.
+// A nil pool restores generation on every call.
+func UseSafePrimePool(pool *SafePrimePool) {
+	safePrimePool.Lock()
+	safePrimePool.pool = pool
+	safePrimePool.Unlock()
 }
@@ -0,0 +1,79 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package core
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func requireSafePrime(t *testing.T, p *big.Int, bits uint) {
+	require.Equal(t, int(bits), p.BitLen())
+	require.True(t, p.ProbablyPrime(20))
+	q := new(big.Int).Rsh(p, 1)
+	require.True(t, q.ProbablyPrime(20))
+}
+
+func TestGenerateSafePrime(t *testing.T) {
+	for _, bits := range []uint{3, 8, 31, 32, 64, 256} {
+		p, err := GenerateSafePrime(bits)
+		require.NoError(t, err)
+		requireSafePrime(t, p, bits)
+	}
+	_, err := GenerateSafePrime(2)
+	require.Error(t, err)
+}
+
+func TestGenerateSafePrimeContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := GenerateSafePrimeContext(ctx, 1024)
+	require.Equal(t, context.Canceled, err)
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	_, err = GenerateSafePrimeContext(ctx, 4096)
+	require.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestSafePrimePool(t *testing.T) {
+	pool, err := NewSafePrimePool(128, 2)
+	require.NoError(t, err)
+	defer pool.Close()
+	require.Equal(t, uint(128), pool.Bits())
+
+	UseSafePrimePool(pool)
+	defer UseSafePrimePool(nil)
+	seen := make(map[string]bool)
+	for i := 0; i < 4; i++ {
+		p, err := GenerateSafePrime(128)
+		require.NoError(t, err)
+		requireSafePrime(t, p, 128)
+		require.False(t, seen[p.String()])
+		seen[p.String()] = true
+	}
+	// Other sizes are generated on demand
+	p, err := GenerateSafePrime(64)
+	require.NoError(t, err)
+	requireSafePrime(t, p, 64)
+
+	_, err = NewSafePrimePool(128, 0)
+	require.Error(t, err)
+}
+
+func BenchmarkGenerateSafePrime(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, err := GenerateSafePrime(1024)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
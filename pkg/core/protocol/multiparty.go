@@ -0,0 +1,210 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package protocol
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Multi-party protocols exchange the same Message type as two party protocols, with the following conventions.
+//
+// The output of a round holds the payload sent to every other party under BroadcastPayload, and the payload sent to
+// a single peer under PeerPayload(id). Its metadata names the sender under SenderMetadataKey. The transport uses
+// Deliver to cut the output into the message each peer receives. Once a party has received the messages of all its
+// peers for a round, Combine merges them into the input of the party's next round.
+const (
+	// BroadcastPayload is the payload key of the part of a round output that every other party receives.
+	BroadcastPayload = "broadcast"
+
+	// DirectPayload is the payload key, in a delivered message, of the part that was sent to the recipient only.
+	DirectPayload = "direct"
+
+	// SenderMetadataKey is the metadata key of the id of the party that produced a message.
+	SenderMetadataKey = "sender"
+
+	// RoundMetadataKey is the metadata key of the round that produced a message.
+	RoundMetadataKey = "round"
+)
+
+// PeerPayload returns the payload key of the part of a round output that only the party with the given id receives.
+func PeerPayload(id uint32) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+// Sender returns the id of the party that produced a message.
+func Sender(m *Message) (uint32, error) {
+	if m == nil {
+		return 0, fmt.Errorf("nil message")
+	}
+	value, ok := m.Metadata[SenderMetadataKey]
+	if !ok {
+		return 0, fmt.Errorf("message has no sender")
+	}
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid sender %q", value)
+	}
+	return uint32(id), nil
+}
+
+// Deliver returns the message the party `to` receives from a round output: the broadcast payload, and the payload
+// sent to `to` under DirectPayload. Payloads for other parties are left out. It returns nil if the output has
+// nothing for `to`.
+func Deliver(output *Message, to uint32) *Message {
+	if output == nil {
+		return nil
+	}
+	payloads := make(map[string][]byte, 2)
+	if broadcast, ok := output.Payloads[BroadcastPayload]; ok {
+		payloads[BroadcastPayload] = broadcast
+	}
+	if direct, ok := output.Payloads[PeerPayload(to)]; ok {
+		payloads[DirectPayload] = direct
+	}
+	if len(payloads) == 0 {
+		return nil
+	}
+	metadata := make(map[string]string, len(output.Metadata))
+	for k, v := range output.Metadata {
+		metadata[k] = v
+	}
+	return &Message{
+		Protocol: output.Protocol,
+		Version:  output.Version,
+		Payloads: payloads,
+		Metadata: metadata,
+	}
+}
+
+// Combine merges the delivered messages a party received from its peers in one round into a single message, which
+// is the input of the party's next round. All messages must be of the same protocol, version and round, and come
+// from distinct senders.
+func Combine(messages []*Message) (*Message, error) {
+	if len(messages) == 0 {
+		return nil, fmt.Errorf("no messages to combine")
+	}
+	combined := &Message{
+		Protocol: messages[0].Protocol,
+		Version:  messages[0].Version,
+		Payloads: make(map[string][]byte, 2*len(messages)),
+		Metadata: make(map[string]string, 1),
+	}
+	if round, ok := messages[0].Metadata[RoundMetadataKey]; ok {
+		combined.Metadata[RoundMetadataKey] = round
+	}
+	seen := make(map[uint32]bool, len(messages))
+	for _, m := range messages {
+		sender, err := Sender(m)
+		if err != nil {
+			return nil, err
+		}
+		if seen[sender] {
+			return nil, fmt.Errorf("more than one message from party %d", sender)
+		}
+		seen[sender] = true
+		if m.Protocol != combined.Protocol || m.Version != combined.Version {
+			return nil, fmt.Errorf("message from party %d is of protocol %s version %d, expected %s version %d",
+				sender, m.Protocol, m.Version, combined.Protocol, combined.Version)
+		}
+		if m.Metadata[RoundMetadataKey] != combined.Metadata[RoundMetadataKey] {
+			return nil, fmt.Errorf("message from party %d is of another round", sender)
+		}
+		for key, payload := range m.Payloads {
+			if key != BroadcastPayload && key != DirectPayload {
+				return nil, fmt.Errorf("message from party %d has undelivered payload %q", sender, key)
+			}
+			combined.Payloads[PeerPayload(sender)+"/"+key] = payload
+		}
+	}
+	return combined, nil
+}
+
+// Split is the inverse of Combine. It returns the delivered messages of a combined message by sender.
+func Split(combined *Message) (map[uint32]*Message, error) {
+	if combined == nil {
+		return nil, fmt.Errorf("nil message")
+	}
+	messages := make(map[uint32]*Message)
+	for key, payload := range combined.Payloads {
+		parts := strings.SplitN(key, "/", 2)
+		if len(parts) != 2 || (parts[1] != BroadcastPayload && parts[1] != DirectPayload) {
+			return nil, fmt.Errorf("invalid payload key %q", key)
+		}
+		sender, err := strconv.ParseUint(parts[0], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid payload key %q", key)
+		}
+		m, ok := messages[uint32(sender)]
+		if !ok {
+			m = &Message{
+				Protocol: combined.Protocol,
+				Version:  combined.Version,
+				Payloads: make(map[string][]byte, 2),
+				Metadata: map[string]string{SenderMetadataKey: parts[0]},
+			}
+			if round, ok := combined.Metadata[RoundMetadataKey]; ok {
+				m.Metadata[RoundMetadataKey] = round
+			}
+			messages[uint32(sender)] = m
+		}
+		m.Payloads[parts[1]] = payload
+	}
+	return messages, nil
+}
+
+// NewRoundOutput returns the output of round `round` of a multi-party protocol: broadcast, if not nil, is sent to
+// every other party and direct[id] to the party id only.
+func NewRoundOutput(protocolName string, version uint, sender uint32, round int, broadcast []byte, direct map[uint32][]byte) *Message {
+	payloads := make(map[string][]byte, len(direct)+1)
+	if broadcast != nil {
+		payloads[BroadcastPayload] = broadcast
+	}
+	for id, payload := range direct {
+		payloads[PeerPayload(id)] = payload
+	}
+	return &Message{
+		Protocol: protocolName,
+		Version:  version,
+		Payloads: payloads,
+		Metadata: map[string]string{
+			SenderMetadataKey: PeerPayload(sender),
+			RoundMetadataKey:  strconv.Itoa(round),
+		},
+	}
+}
+
+// SplitRound splits the combined input of a round into the messages of each peer. It checks that the input holds
+// exactly one message from each of the peers, produced by round `round` of the given protocol and version.
+func SplitRound(combined *Message, protocolName string, version uint, round int, peers map[uint32]bool) (map[uint32]*Message, error) {
+	if combined == nil {
+		return nil, fmt.Errorf("nil message")
+	}
+	if combined.Protocol != protocolName || combined.Version != version {
+		return nil, fmt.Errorf("expected %s version %d, got %s version %d",
+			protocolName, version, combined.Protocol, combined.Version)
+	}
+	if combined.Metadata[RoundMetadataKey] != strconv.Itoa(round) {
+		return nil, fmt.Errorf("expected messages of round %d, got round %q", round, combined.Metadata[RoundMetadataKey])
+	}
+	messages, err := Split(combined)
+	if err != nil {
+		return nil, err
+	}
+	for id := range messages {
+		if !peers[id] {
+			return nil, fmt.Errorf("unexpected message from party %d", id)
+		}
+	}
+	for id := range peers {
+		if _, ok := messages[id]; !ok {
+			return nil, fmt.Errorf("missing message from party %d", id)
+		}
+	}
+	return messages, nil
+}
@@ -25,6 +25,15 @@ const (
 	// Dkls18Refresh specifies the DKG protocol of the DKLs18 potocol.
 	Dkls18Refresh = "DKLs18-Refresh"
 
+	// Gg20Dkg specifies the DKG protocol of the GG20 protocol.
+	Gg20Dkg = "GG20-DKG"
+
+	// Gg20Sign specifies the sign protocol of the GG20 protocol.
+	Gg20Sign = "GG20-Sign"
+
+	// Gg20Reshare specifies the resharing protocol of the GG20 protocol.
+	Gg20Reshare = "GG20-Reshare"
+
 	// versions will increment in 100 intervals, to leave room for adding other versions in between them if it is
 	// ever needed in the future.
 
@@ -33,6 +42,9 @@ const (
 
 	// Version1 is version 2!
 	Version1 = 200
+
+	// Version2 is version 3! It replaces gob with a deterministic, language-neutral binary encoding of the payloads.
+	Version2 = 300
 )
 
 // Message provides serializers and deserializer for the inputs and outputs of each step of the protocol.
@@ -53,7 +65,8 @@ type Message struct {
 	Metadata map[string]string
 }
 
-// Iterator an interface for the DKLs18 protocols that follows the iterator pattern.
+// Iterator an interface for the DKLs18 and GG20 protocols that follows the iterator pattern. For multi-party
+// protocols, the input of each round is the Combine of the messages received from the peers.
 type Iterator interface {
 	// Next runs the next round of the protocol.
 	// Returns `ErrProtocolFinished` when protocol has completed.
@@ -0,0 +1,39 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package protocol
+
+// Stepper implements Next for protocols that run a fixed list of steps, one per call. Iterators embed it, set
+// their steps with NewStepper and implement Result.
+type Stepper struct {
+	steps []func(input *Message) (*Message, error)
+	step  int
+}
+
+// NewStepper returns a Stepper that runs steps in order
+func NewStepper(steps []func(input *Message) (*Message, error)) Stepper {
+	return Stepper{steps: steps}
+}
+
+// Next runs the next step in the protocol and reports errors or increments the step index
+func (p *Stepper) Next(input *Message) (*Message, error) {
+	if p.Complete() {
+		return nil, ErrProtocolFinished
+	}
+
+	// Run the current protocol step and report any errors
+	output, err := p.steps[p.step](input)
+	if err != nil {
+		return nil, err
+	}
+
+	// Increment the step index and report success
+	p.step++
+	return output, nil
+}
+
+// Complete reports true if every step has run
+func (p *Stepper) Complete() bool { return p.step >= len(p.steps) }
@@ -0,0 +1,50 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package protocol
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStepper(t *testing.T) {
+	var ran []int
+	fail := true
+	stepper := NewStepper([]func(*Message) (*Message, error){
+		func(*Message) (*Message, error) {
+			ran = append(ran, 1)
+			return &Message{Protocol: "test"}, nil
+		},
+		func(*Message) (*Message, error) {
+			if fail {
+				fail = false
+				return nil, fmt.Errorf("step failed")
+			}
+			ran = append(ran, 2)
+			return nil, nil
+		},
+	})
+
+	output, err := stepper.Next(nil)
+	require.NoError(t, err)
+	require.Equal(t, "test", output.Protocol)
+	require.False(t, stepper.Complete())
+
+	// A failing step is not skipped
+	_, err = stepper.Next(nil)
+	require.Error(t, err)
+	require.False(t, stepper.Complete())
+	_, err = stepper.Next(nil)
+	require.NoError(t, err)
+	require.True(t, stepper.Complete())
+	require.Equal(t, []int{1, 2}, ran)
+
+	_, err = stepper.Next(nil)
+	require.ErrorIs(t, err, ErrProtocolFinished)
+}
@@ -0,0 +1,100 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package biprime
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/TEENet-io/kryptology/internal"
+	"github.com/TEENet-io/kryptology/pkg/core"
+	"github.com/TEENet-io/kryptology/pkg/paillier"
+	"github.com/TEENet-io/kryptology/pkg/verenc/camshoup"
+)
+
+// KeyShare is a party's output of biprime generation
+type KeyShare struct {
+	ID      uint32
+	Parties []uint32
+	// N = pq where no party knows p or q
+	N *big.Int
+	// PhiShare is an additive share of φ(N): the shares of all parties sum to φ(N)
+	PhiShare *big.Int
+	// DecryptionShare is an additive share of Δ³βφ(N) for a random β unknown to all parties
+	DecryptionShare *big.Int
+	// Theta is θ = Δ³βφ(N) mod N
+	Theta *big.Int
+}
+
+// PublicKey returns the Paillier public key of modulus N
+func (ks *KeyShare) PublicKey() (*paillier.PublicKey, error) {
+	if ks == nil || ks.N == nil {
+		return nil, internal.ErrNilArguments
+	}
+	return paillier.NewPubkey(ks.N)
+}
+
+// PaillierGroup returns a Camenisch-Shoup group of modulus N
+func (ks *KeyShare) PaillierGroup() (*camshoup.PaillierGroup, error) {
+	if ks == nil || ks.N == nil {
+		return nil, internal.ErrNilArguments
+	}
+	return camshoup.NewPaillierGroupWithModulus(ks.N)
+}
+
+// PartialDecrypt returns this party's share c^{DecryptionShare} mod N² of the decryption of
+// a Paillier ciphertext under N
+func (ks *KeyShare) PartialDecrypt(c paillier.Ciphertext) (*big.Int, error) {
+	if ks == nil || c == nil || core.AnyNil(ks.N, ks.DecryptionShare) {
+		return nil, internal.ErrNilArguments
+	}
+	n2 := new(big.Int).Mul(ks.N, ks.N)
+	if err := core.In(c, n2); err != nil {
+		return nil, err
+	}
+	d := new(big.Int).Exp(c, ks.DecryptionShare, n2)
+	if d == nil {
+		return nil, fmt.Errorf("ciphertext is not a unit mod N²")
+	}
+	return d, nil
+}
+
+// Combine recovers the plaintext of a Paillier ciphertext under N from the partial decryptions
+// of all parties: ∏ c^{d_i} = c^{Δ³βφ(N)} = (N+1)^{θm} mod N²
+func (ks *KeyShare) Combine(partials map[uint32]*big.Int) (*big.Int, error) {
+	if ks == nil || core.AnyNil(ks.N, ks.Theta) {
+		return nil, internal.ErrNilArguments
+	}
+	if len(partials) != len(ks.Parties) {
+		return nil, fmt.Errorf("need the partial decryptions of all %d parties", len(ks.Parties))
+	}
+	n2 := new(big.Int).Mul(ks.N, ks.N)
+	product := big.NewInt(1)
+	for _, id := range ks.Parties {
+		d, ok := partials[id]
+		if !ok || d == nil {
+			return nil, fmt.Errorf("missing partial decryption from party id=%v", id)
+		}
+		if err := core.In(d, n2); err != nil {
+			return nil, err
+		}
+		product.Mul(product, d)
+		product.Mod(product, n2)
+	}
+
+	// L(x) = (x - 1) / N for x = 1 mod N
+	ell := new(big.Int).Sub(product, core.One)
+	ell, rem := ell.QuoRem(ell, ks.N, new(big.Int))
+	if rem.Sign() != 0 {
+		return nil, fmt.Errorf("partial decryptions do not combine")
+	}
+	thetaInv := new(big.Int).ModInverse(ks.Theta, ks.N)
+	if thetaInv == nil {
+		return nil, fmt.Errorf("theta is not a unit mod N")
+	}
+	return core.Mul(ell, thetaInv, ks.N)
+}
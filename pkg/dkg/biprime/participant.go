@@ -0,0 +1,300 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// Package biprime is an implementation of distributed generation of an RSA modulus N = pq,
+// where no party learns p or q, from
+// Efficient Generation of Shared RSA Keys [BF97] https://crypto.stanford.edu/~dabo/pubs/papers/sharedrsa.pdf
+// with the integer secret sharing of
+// Simplified VSS and Fast-track Multiparty Computations with Applications to Threshold Cryptography [Rab98]
+// https://dl.acm.org/doi/10.1145/277697.277716
+//
+// In each attempt, the parties pick additive shares of a batch of candidates p = Σ p_i and q = Σ q_i,
+// compute every N = pq with the BGW protocol over integer Shamir shares, drop the N with small factors,
+// and run the biprimality test of [BF97] §4 on the others. The first N that passes is blinded with a
+// random β to reveal θ = Δ³βφ(N) mod N, which doubles as the gcd step of the biprimality test.
+// Each party ends with an additive share of φ(N) and an additive share of Δ³βφ(N), which decrypts
+// Paillier ciphertexts under N without any party knowing φ(N).
+//
+// Like [BF97], the protocol is secure against a passive adversary that corrupts fewer than half of
+// the parties, so at least three parties are required. p and q are primes equal to 3 mod 4, but not
+// necessarily safe primes.
+package biprime
+
+import (
+	crand "crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+
+	"github.com/TEENet-io/kryptology/internal"
+)
+
+const (
+	// statisticalSecurity is the bit length of the margins that statistically hide secrets
+	// in integer shares and masked values
+	statisticalSecurity = 128
+	// biprimalityIterations is the number of repetitions of the biprimality test,
+	// each of which rejects a non-biprime with probability at least 1/2
+	biprimalityIterations = 40
+	// trialDivisionBound bounds the primes that are trial divided into each N
+	trialDivisionBound = 1 << 12
+	// minModulusBits is the smallest modulus size accepted by NewParticipant
+	minModulusBits = 64
+)
+
+// ErrNoBiprime is returned when no candidate of an attempt is a biprime.
+// The participant is reset to round 1 to start a new attempt.
+var ErrNoBiprime = errors.New("no candidate modulus is a biprime")
+
+// Participant is a party of distributed biprime generation
+type Participant struct {
+	ID    uint32
+	Round uint
+	// parties are the ids of all the parties in ascending order, including ID
+	parties []uint32
+	// bits is the minimum bit length of N and candidates the number of candidates per attempt
+	bits       uint
+	candidates uint
+	sid        []byte
+	state      *state
+}
+
+// state holds the values accumulated during the rounds of one attempt
+type state struct {
+	// Round 1 variables
+	p, q []*big.Int
+	// self holds the values this party sends to itself
+	self *Round1P2PSend
+	// Round 2 variables
+	pPoint, qPoint []*big.Int
+	values         []*big.Int
+	// Round 3 variables
+	survivors []*candidate
+	// betaShares holds the values for the survivors that this party sends to itself
+	betaShares *Round3P2PSend
+	// Round 4 variables
+	chosen *candidate
+}
+
+// candidate is a modulus N that has no small factors
+type candidate struct {
+	n        *big.Int
+	index    int
+	phiShare *big.Int
+	// decryptionShare is this party's additive share of Δ³βφ(N)
+	decryptionShare *big.Int
+}
+
+// NewParticipant creates a party of biprime generation among parties, which must include id.
+// Each attempt tries the given number of candidates, so that N has at least bits bits.
+// sid is a session identifier that all parties agree on and that is unique to this execution
+func NewParticipant(id uint32, parties []uint32, bits, candidates uint, sid []byte) (*Participant, error) {
+	if len(sid) == 0 {
+		return nil, internal.ErrNilArguments
+	}
+	if bits < minModulusBits {
+		return nil, fmt.Errorf("modulus must have at least %d bits", minModulusBits)
+	}
+	if candidates == 0 {
+		return nil, fmt.Errorf("at least one candidate is required")
+	}
+	if err := checkParties(id, parties); err != nil {
+		return nil, err
+	}
+	sorted := append([]uint32{}, parties...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return &Participant{
+		ID:         id,
+		Round:      1,
+		parties:    sorted,
+		bits:       bits,
+		candidates: candidates,
+		sid:        append([]byte{}, sid...),
+	}, nil
+}
+
+// checkParties checks that there are at least three distinct non-zero party ids, including id
+func checkParties(id uint32, parties []uint32) error {
+	if len(parties) < 3 {
+		return fmt.Errorf("at least three parties are required")
+	}
+	seen := make(map[uint32]bool, len(parties))
+	for _, j := range parties {
+		if j == 0 {
+			return fmt.Errorf("party id cannot be zero")
+		}
+		if seen[j] {
+			return fmt.Errorf("duplicate party id %d", j)
+		}
+		seen[j] = true
+	}
+	if !seen[id] {
+		return fmt.Errorf("parties do not include %d", id)
+	}
+	return nil
+}
+
+// checkInput checks that the map in has an entry for each party other than id.
+// An entry for id itself is allowed and ignored.
+func checkInput(id uint32, parties []uint32, in interface{}) error {
+	v := reflect.ValueOf(in)
+	if v.Kind() != reflect.Map {
+		return fmt.Errorf("parameter `in` must be a map; instead is %v", v.Kind())
+	}
+	expected := make(map[uint32]bool, len(parties))
+	for _, j := range parties {
+		expected[j] = true
+	}
+	for _, key := range v.MapKeys() {
+		if !expected[uint32(key.Uint())] {
+			return fmt.Errorf("party id=%v is not valid", key.Uint())
+		}
+	}
+	for _, j := range parties {
+		if j == id {
+			continue
+		}
+		value := v.MapIndex(reflect.ValueOf(j))
+		if !value.IsValid() || value.IsNil() {
+			return fmt.Errorf("missing input from party id=%v", j)
+		}
+	}
+	return nil
+}
+
+// reset discards the current attempt so that the next one starts at round 1
+func (p *Participant) reset() {
+	p.state = nil
+	p.Round = 1
+}
+
+// isLeader is true for the party with the lowest id, whose shares of p and q are 3 mod 4
+func (p *Participant) isLeader() bool {
+	return p.ID == p.parties[0]
+}
+
+// point is the evaluation point of party id in Shamir sharings: its position in parties, from 1
+func (p *Participant) point(id uint32) int64 {
+	for i, j := range p.parties {
+		if j == id {
+			return int64(i + 1)
+		}
+	}
+	return 0
+}
+
+// degree is the degree t of the sharing polynomials, so that products of two have degree 2t < n
+func (p *Participant) degree() int {
+	return (len(p.parties) - 1) / 2
+}
+
+// delta is Δ = n!, which clears the denominators of the Lagrange coefficients
+func (p *Participant) delta() *big.Int {
+	return new(big.Int).MulRange(1, int64(len(p.parties)))
+}
+
+// shareBits is the bit length of the additive shares of p and q
+func (p *Participant) shareBits() uint {
+	return (p.bits + 1) / 2
+}
+
+// share splits secret into integer Shamir shares [Rab98] with f(0) = Δ secret and
+// coefficients in [0, 2^{bits + κ} Δ²), for secrets of at most bits bits.
+// It returns f(point) for the point of each party.
+func (p *Participant) share(secret *big.Int, bits uint) (map[uint32]*big.Int, error) {
+	delta := p.delta()
+	bound := new(big.Int).Lsh(new(big.Int).Mul(delta, delta), bits+statisticalSecurity)
+	coefficients := make([]*big.Int, p.degree()+1)
+	coefficients[0] = new(big.Int).Mul(delta, secret)
+	for i := 1; i < len(coefficients); i++ {
+		c, err := crand.Int(crand.Reader, bound)
+		if err != nil {
+			return nil, err
+		}
+		coefficients[i] = c
+	}
+	shares := make(map[uint32]*big.Int, len(p.parties))
+	for _, id := range p.parties {
+		x := big.NewInt(p.point(id))
+		y := new(big.Int)
+		for i := len(coefficients) - 1; i >= 0; i-- {
+			y.Mul(y, x)
+			y.Add(y, coefficients[i])
+		}
+		shares[id] = y
+	}
+	return shares, nil
+}
+
+// pointBits bounds the bit length of the sum over all parties of f(j) for shares of secrets
+// of at most bits bits: |f(j)| < (t+1) n^t 2^{bits + κ} Δ²
+func (p *Participant) pointBits(bits uint) uint {
+	nBits := uint(big.NewInt(int64(len(p.parties))).BitLen())
+	deltaBits := uint(p.delta().BitLen())
+	return bits + statisticalSecurity + 2*deltaBits + uint(p.degree()+2)*nBits
+}
+
+// maskBits is the bit length of masks that statistically hide Δλ_j f(j) g(j) for shares of
+// secrets of at most aBits and bBits bits, with |Δλ_j| ≤ Δ²
+func (p *Participant) maskBits(aBits, bBits uint) uint {
+	deltaBits := uint(p.delta().BitLen())
+	return p.pointBits(aBits) + p.pointBits(bBits) + 2*deltaBits + statisticalSecurity
+}
+
+// zeroSum returns random integers of maskBits bits for each party that sum to zero
+func (p *Participant) zeroSum(maskBits uint) (map[uint32]*big.Int, error) {
+	bound := new(big.Int).Lsh(big.NewInt(1), maskBits)
+	masks := make(map[uint32]*big.Int, len(p.parties))
+	sum := new(big.Int)
+	for _, id := range p.parties {
+		if id == p.ID {
+			continue
+		}
+		m, err := crand.Int(crand.Reader, bound)
+		if err != nil {
+			return nil, err
+		}
+		masks[id] = m
+		sum.Add(sum, m)
+	}
+	masks[p.ID] = sum.Neg(sum)
+	return masks, nil
+}
+
+// lagrange returns the integer Δλ_j for the point of this party, where λ_j interpolates
+// polynomials of degree less than n at 0 from the points of all parties
+func (p *Participant) lagrange() *big.Int {
+	num := p.delta()
+	den := big.NewInt(1)
+	j := p.point(p.ID)
+	for _, id := range p.parties {
+		k := p.point(id)
+		if k == j {
+			continue
+		}
+		num.Mul(num, big.NewInt(k))
+		den.Mul(den, big.NewInt(k-j))
+	}
+	return num.Quo(num, den)
+}
+
+// smallPrimes is the product of the odd primes below trialDivisionBound
+var smallPrimes = func() *big.Int {
+	product := big.NewInt(1)
+	composite := make([]bool, trialDivisionBound)
+	for i := 3; i < trialDivisionBound; i += 2 {
+		if composite[i] {
+			continue
+		}
+		product.Mul(product, big.NewInt(int64(i)))
+		for j := i * i; j < trialDivisionBound; j += 2 * i {
+			composite[j] = true
+		}
+	}
+	return product
+}()
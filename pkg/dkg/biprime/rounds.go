@@ -0,0 +1,450 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package biprime
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"golang.org/x/crypto/sha3"
+
+	"github.com/TEENet-io/kryptology/internal"
+	"github.com/TEENet-io/kryptology/pkg/core"
+)
+
+// Round1P2PSend contains values to be sent to a single party after the completion of round 1.
+// For each candidate, P and Q are the recipient's shares of this party's shares of p and q,
+// and Masks hide the recipient's share of N.
+type Round1P2PSend struct {
+	P, Q, Masks []*big.Int
+}
+
+// Round2Bcast contains values to be broadcast to all parties after the completion of round 2.
+// Values are masked additive shares of Δ³N for each candidate.
+type Round2Bcast struct {
+	Values []*big.Int
+}
+
+// Round3Bcast contains values to be broadcast to all parties after the completion of round 3.
+// For each candidate without small factors, Witnesses are g^{φ_i/4} mod N for the bases g
+// of the biprimality test, where φ_i is this party's additive share of φ(N).
+type Round3Bcast struct {
+	Witnesses [][]*big.Int
+}
+
+// Round3P2PSend contains values to be sent to a single party after the completion of round 3.
+// For each candidate without small factors, Beta is the recipient's share of this party's share
+// of β, and Masks hide the recipient's share of Δ³βφ(N).
+type Round3P2PSend struct {
+	Beta, Masks []*big.Int
+}
+
+// Round4Bcast contains values to be broadcast to all parties after the completion of round 4.
+// Theta is this party's masked additive share of θ = Δ³βφ(N) mod N for the chosen candidate.
+type Round4Bcast struct {
+	Theta *big.Int
+}
+
+// Round1 picks the shares of the candidates and shares them with the other parties
+func (p *Participant) Round1() (map[uint32]*Round1P2PSend, error) {
+	if p.Round != 1 {
+		return nil, internal.ErrInvalidRound
+	}
+	bits := p.shareBits()
+	maskBits := p.maskBits(bits, bits)
+	st := &state{
+		p: make([]*big.Int, p.candidates),
+		q: make([]*big.Int, p.candidates),
+	}
+	out := make(map[uint32]*Round1P2PSend, len(p.parties))
+	for _, id := range p.parties {
+		out[id] = &Round1P2PSend{
+			P:     make([]*big.Int, p.candidates),
+			Q:     make([]*big.Int, p.candidates),
+			Masks: make([]*big.Int, p.candidates),
+		}
+	}
+
+	for k := range st.p {
+		var err error
+		if st.p[k], err = p.randomShare(bits); err != nil {
+			return nil, err
+		}
+		if st.q[k], err = p.randomShare(bits); err != nil {
+			return nil, err
+		}
+		pShares, err := p.share(st.p[k], bits)
+		if err != nil {
+			return nil, err
+		}
+		qShares, err := p.share(st.q[k], bits)
+		if err != nil {
+			return nil, err
+		}
+		masks, err := p.zeroSum(maskBits)
+		if err != nil {
+			return nil, err
+		}
+		for id, msg := range out {
+			msg.P[k] = pShares[id]
+			msg.Q[k] = qShares[id]
+			msg.Masks[k] = masks[id]
+		}
+	}
+
+	st.self = out[p.ID]
+	delete(out, p.ID)
+	p.state = st
+	p.Round = 2
+	return out, nil
+}
+
+// Round2 computes the masked additive shares of Δ³N = Δ Σ_j Δλ_j P(j) Q(j) for each candidate
+func (p *Participant) Round2(in map[uint32]*Round1P2PSend) (*Round2Bcast, error) {
+	if p.Round != 2 {
+		return nil, internal.ErrInvalidRound
+	}
+	if err := checkInput(p.ID, p.parties, in); err != nil {
+		return nil, err
+	}
+	for id, msg := range in {
+		if id == p.ID {
+			continue
+		}
+		if err := p.checkLengths(p.candidates, msg.P, msg.Q, msg.Masks); err != nil {
+			return nil, fmt.Errorf("invalid input from party id=%v: %v", id, err)
+		}
+	}
+
+	st := p.state
+	lambda := p.lagrange()
+	st.pPoint = make([]*big.Int, p.candidates)
+	st.qPoint = make([]*big.Int, p.candidates)
+	values := make([]*big.Int, p.candidates)
+	for k := range values {
+		pPoint := new(big.Int).Set(st.self.P[k])
+		qPoint := new(big.Int).Set(st.self.Q[k])
+		mask := new(big.Int).Set(st.self.Masks[k])
+		for id, msg := range in {
+			if id == p.ID {
+				continue
+			}
+			pPoint.Add(pPoint, msg.P[k])
+			qPoint.Add(qPoint, msg.Q[k])
+			mask.Add(mask, msg.Masks[k])
+		}
+		st.pPoint[k], st.qPoint[k] = pPoint, qPoint
+		v := new(big.Int).Mul(pPoint, qPoint)
+		v.Mul(v, lambda)
+		values[k] = v.Add(v, mask)
+	}
+	st.self = nil
+	st.values = values
+	p.Round = 3
+	return &Round2Bcast{Values: values}, nil
+}
+
+// Round3 reveals each candidate N, drops those with small factors and starts the biprimality
+// test and the computation of θ for the others.
+// It returns ErrNoBiprime when every candidate has small factors.
+func (p *Participant) Round3(in map[uint32]*Round2Bcast) (*Round3Bcast, map[uint32]*Round3P2PSend, error) {
+	if p.Round != 3 {
+		return nil, nil, internal.ErrInvalidRound
+	}
+	if err := checkInput(p.ID, p.parties, in); err != nil {
+		return nil, nil, err
+	}
+	for id, msg := range in {
+		if id == p.ID {
+			continue
+		}
+		if err := p.checkLengths(p.candidates, msg.Values); err != nil {
+			return nil, nil, fmt.Errorf("invalid input from party id=%v: %v", id, err)
+		}
+	}
+	if err := p.revealCandidates(in); err != nil {
+		return nil, nil, err
+	}
+	st := p.state
+	if len(st.survivors) == 0 {
+		p.reset()
+		return nil, nil, ErrNoBiprime
+	}
+
+	betaBits := p.bits + statisticalSecurity
+	betaBound := new(big.Int).Lsh(core.One, betaBits)
+	maskBits := p.maskBits(p.bits+2, betaBits)
+	bcast := &Round3Bcast{Witnesses: make([][]*big.Int, len(st.survivors))}
+	out := make(map[uint32]*Round3P2PSend, len(p.parties))
+	for _, id := range p.parties {
+		out[id] = &Round3P2PSend{
+			Beta:  make([]*big.Int, len(st.survivors)),
+			Masks: make([]*big.Int, len(st.survivors)),
+		}
+	}
+	for s, c := range st.survivors {
+		// φ(N) = N + 1 - p - q, where the leader adds N + 1
+		c.phiShare = new(big.Int).Add(st.p[c.index], st.q[c.index])
+		c.phiShare.Neg(c.phiShare)
+		if p.isLeader() {
+			c.phiShare.Add(c.phiShare, c.n)
+			c.phiShare.Add(c.phiShare, core.One)
+		}
+		witnesses, err := p.witnesses(c.n, c.phiShare)
+		if err != nil {
+			return nil, nil, err
+		}
+		bcast.Witnesses[s] = witnesses
+
+		beta, err := crand.Int(crand.Reader, betaBound)
+		if err != nil {
+			return nil, nil, err
+		}
+		betaShares, err := p.share(beta, betaBits)
+		if err != nil {
+			return nil, nil, err
+		}
+		masks, err := p.zeroSum(maskBits)
+		if err != nil {
+			return nil, nil, err
+		}
+		for id, msg := range out {
+			msg.Beta[s] = betaShares[id]
+			msg.Masks[s] = masks[id]
+		}
+	}
+	st.betaShares = out[p.ID]
+	delete(out, p.ID)
+	p.Round = 4
+	return bcast, out, nil
+}
+
+// Round4 runs the biprimality test on each candidate without small factors and computes the
+// masked additive share of θ for the first one that passes.
+// It returns ErrNoBiprime when no candidate passes.
+func (p *Participant) Round4(bcast map[uint32]*Round3Bcast, p2p map[uint32]*Round3P2PSend) (*Round4Bcast, error) {
+	if p.Round != 4 {
+		return nil, internal.ErrInvalidRound
+	}
+	if err := checkInput(p.ID, p.parties, bcast); err != nil {
+		return nil, err
+	}
+	if err := checkInput(p.ID, p.parties, p2p); err != nil {
+		return nil, err
+	}
+	st := p.state
+	survivors := uint(len(st.survivors))
+	for _, id := range p.parties {
+		if id == p.ID {
+			continue
+		}
+		if len(bcast[id].Witnesses) != len(st.survivors) {
+			return nil, fmt.Errorf("invalid input from party id=%v: expected %d values", id, len(st.survivors))
+		}
+		for _, witnesses := range bcast[id].Witnesses {
+			if err := p.checkLengths(biprimalityIterations, witnesses); err != nil {
+				return nil, fmt.Errorf("invalid input from party id=%v: %v", id, err)
+			}
+		}
+		if err := p.checkLengths(survivors, p2p[id].Beta, p2p[id].Masks); err != nil {
+			return nil, fmt.Errorf("invalid input from party id=%v: %v", id, err)
+		}
+	}
+
+	for s, c := range st.survivors {
+		if !p.isBiprime(c, s, bcast) {
+			continue
+		}
+		// B(j) φ(j) with φ(j) = Δ(N + 1) - P(j) - Q(j), so that φ(0) = Δφ(N)
+		betaPoint := new(big.Int).Set(st.betaShares.Beta[s])
+		mask := new(big.Int).Set(st.betaShares.Masks[s])
+		for _, id := range p.parties {
+			if id == p.ID {
+				continue
+			}
+			betaPoint.Add(betaPoint, p2p[id].Beta[s])
+			mask.Add(mask, p2p[id].Masks[s])
+		}
+		phiPoint := new(big.Int).Add(c.n, core.One)
+		phiPoint.Mul(phiPoint, p.delta())
+		phiPoint.Sub(phiPoint, st.pPoint[c.index])
+		phiPoint.Sub(phiPoint, st.qPoint[c.index])
+
+		v := new(big.Int).Mul(betaPoint, phiPoint)
+		v.Mul(v, p.lagrange())
+		c.decryptionShare = v.Add(v, mask)
+		st.chosen = c
+		p.Round = 5
+		return &Round4Bcast{Theta: new(big.Int).Mod(c.decryptionShare, c.n)}, nil
+	}
+	p.reset()
+	return nil, ErrNoBiprime
+}
+
+// Output computes θ and returns this party's key share.
+// It returns ErrNoBiprime when θ is not a unit mod N, which means that N is not a biprime.
+func (p *Participant) Output(in map[uint32]*Round4Bcast) (*KeyShare, error) {
+	if p.Round != 5 {
+		return nil, internal.ErrInvalidRound
+	}
+	if err := checkInput(p.ID, p.parties, in); err != nil {
+		return nil, err
+	}
+	c := p.state.chosen
+	theta := new(big.Int).Mod(c.decryptionShare, c.n)
+	for id, msg := range in {
+		if id == p.ID {
+			continue
+		}
+		if msg.Theta == nil {
+			return nil, fmt.Errorf("invalid input from party id=%v", id)
+		}
+		theta.Add(theta, msg.Theta)
+	}
+	theta.Mod(theta, c.n)
+	if new(big.Int).GCD(nil, nil, theta, c.n).Cmp(core.One) != 0 {
+		p.reset()
+		return nil, ErrNoBiprime
+	}
+	p.state = nil
+	p.Round = 6
+	return &KeyShare{
+		ID:              p.ID,
+		Parties:         append([]uint32{}, p.parties...),
+		N:               c.n,
+		PhiShare:        c.phiShare,
+		DecryptionShare: c.decryptionShare,
+		Theta:           theta,
+	}, nil
+}
+
+// randomShare returns a random additive share of p or q that is less than 2^bits.
+// The leader's share is 3 mod 4 and the others are 0 mod 4, so that p = q = 3 mod 4.
+func (p *Participant) randomShare(bits uint) (*big.Int, error) {
+	r, err := crand.Int(crand.Reader, new(big.Int).Lsh(core.One, bits-2))
+	if err != nil {
+		return nil, err
+	}
+	r.Lsh(r, 2)
+	if p.isLeader() {
+		r.Add(r, big.NewInt(3))
+	}
+	return r, nil
+}
+
+// revealCandidates computes each candidate N from the shares of all parties and keeps those
+// of at least p.bits bits without small factors
+func (p *Participant) revealCandidates(in map[uint32]*Round2Bcast) error {
+	st := p.state
+	delta := p.delta()
+	delta3 := new(big.Int).Mul(delta, delta)
+	delta3.Mul(delta3, delta)
+	st.survivors = nil
+	for k, own := range st.values {
+		sum := new(big.Int).Set(own)
+		for id, msg := range in {
+			if id == p.ID {
+				continue
+			}
+			if msg.Values[k] == nil {
+				return fmt.Errorf("invalid input from party id=%v", id)
+			}
+			sum.Add(sum, msg.Values[k])
+		}
+		n, rem := new(big.Int).QuoRem(sum, delta3, new(big.Int))
+		if rem.Sign() != 0 || n.Sign() <= 0 {
+			return fmt.Errorf("shares of candidate %d do not interpolate to a modulus", k)
+		}
+		if uint(n.BitLen()) < p.bits || new(big.Int).GCD(nil, nil, n, smallPrimes).Cmp(core.One) != 0 {
+			continue
+		}
+		st.survivors = append(st.survivors, &candidate{n: n, index: k})
+	}
+	return nil
+}
+
+// witnesses returns g^{φ_i/4} mod N for the bases g of the biprimality test
+func (p *Participant) witnesses(n, phiShare *big.Int) ([]*big.Int, error) {
+	// φ_i = 0 mod 4 for every party
+	e := new(big.Int).Rsh(new(big.Int).Abs(phiShare), 2)
+	witnesses := make([]*big.Int, biprimalityIterations)
+	for i := range witnesses {
+		g := p.base(n, uint32(i))
+		w := new(big.Int).Exp(g, e, n)
+		if phiShare.Sign() < 0 {
+			if w.ModInverse(w, n) == nil {
+				return nil, fmt.Errorf("base is not a unit")
+			}
+		}
+		witnesses[i] = w
+	}
+	return witnesses, nil
+}
+
+// isBiprime runs the biprimality test of [BF97] §4 on candidate c with the witnesses of all parties:
+// ∏_i g^{φ_i/4} = g^{φ(N)/4} = ±1 mod N for each base g with Jacobi symbol 1
+func (p *Participant) isBiprime(c *candidate, s int, bcast map[uint32]*Round3Bcast) bool {
+	own, err := p.witnesses(c.n, c.phiShare)
+	if err != nil {
+		return false
+	}
+	minusOne := new(big.Int).Sub(c.n, core.One)
+	for i, w := range own {
+		product := new(big.Int).Set(w)
+		for _, id := range p.parties {
+			if id == p.ID {
+				continue
+			}
+			v := bcast[id].Witnesses[s][i]
+			if v == nil || core.In(v, c.n) != nil {
+				return false
+			}
+			product.Mul(product, v)
+			product.Mod(product, c.n)
+		}
+		if product.Cmp(core.One) != 0 && product.Cmp(minusOne) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// base derives the i-th base of the biprimality test of N from the session id,
+// so that all parties use the same random g with Jacobi symbol (g/N) = 1
+func (p *Participant) base(n *big.Int, i uint32) *big.Int {
+	var counter [8]byte
+	binary.BigEndian.PutUint32(counter[:4], i)
+	for j := uint32(0); ; j++ {
+		binary.BigEndian.PutUint32(counter[4:], j)
+		h := sha3.NewShake256()
+		_, _ = h.Write(p.sid)
+		_, _ = h.Write(n.Bytes())
+		_, _ = h.Write(counter[:])
+		buf := make([]byte, (n.BitLen()+statisticalSecurity+7)/8)
+		_, _ = h.Read(buf)
+		g := new(big.Int).SetBytes(buf)
+		g.Mod(g, n)
+		if g.Cmp(core.One) > 0 && big.Jacobi(g, n) == 1 {
+			return g
+		}
+	}
+}
+
+// checkLengths checks that each slice has length and no nil entries
+func (p *Participant) checkLengths(length uint, values ...[]*big.Int) error {
+	for _, v := range values {
+		if uint(len(v)) != length {
+			return fmt.Errorf("expected %d values, got %d", length, len(v))
+		}
+		if core.AnyNil(v...) {
+			return internal.ErrNilArguments
+		}
+	}
+	return nil
+}
@@ -0,0 +1,264 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package biprime
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/TEENet-io/kryptology/internal"
+	"github.com/TEENet-io/kryptology/pkg/core"
+	"github.com/TEENet-io/kryptology/pkg/verenc/camshoup"
+)
+
+const (
+	testBits       = 256
+	testCandidates = 512
+)
+
+// newTestParticipants creates participants with ids 1..n
+func newTestParticipants(t *testing.T, n int) map[uint32]*Participant {
+	parties := make([]uint32, n)
+	for i := range parties {
+		parties[i] = uint32(i + 1)
+	}
+	participants := make(map[uint32]*Participant, n)
+	for _, id := range parties {
+		p, err := NewParticipant(id, parties, testBits, testCandidates, []byte("biprime test"))
+		require.NoError(t, err)
+		participants[id] = p
+	}
+	return participants
+}
+
+// runAttempt runs one attempt of all rounds, and returns nil when no candidate is a biprime
+func runAttempt(t *testing.T, participants map[uint32]*Participant) map[uint32]*KeyShare {
+	r1 := make(map[uint32]map[uint32]*Round1P2PSend, len(participants))
+	for id, p := range participants {
+		var err error
+		r1[id], err = p.Round1()
+		require.NoError(t, err)
+	}
+	r2 := make(map[uint32]*Round2Bcast, len(participants))
+	for id, p := range participants {
+		in := make(map[uint32]*Round1P2PSend, len(participants))
+		for from, sent := range r1 {
+			if from != id {
+				in[from] = sent[id]
+			}
+		}
+		var err error
+		r2[id], err = p.Round2(in)
+		require.NoError(t, err)
+	}
+	r3 := make(map[uint32]*Round3Bcast, len(participants))
+	r3p2p := make(map[uint32]map[uint32]*Round3P2PSend, len(participants))
+	noBiprime := 0
+	for id, p := range participants {
+		var err error
+		r3[id], r3p2p[id], err = p.Round3(r2)
+		if err == ErrNoBiprime {
+			noBiprime++
+			continue
+		}
+		require.NoError(t, err)
+	}
+	if noBiprime > 0 {
+		require.Equal(t, len(participants), noBiprime)
+		return nil
+	}
+	r4 := make(map[uint32]*Round4Bcast, len(participants))
+	for id, p := range participants {
+		in := make(map[uint32]*Round3P2PSend, len(participants))
+		for from, sent := range r3p2p {
+			if from != id {
+				in[from] = sent[id]
+			}
+		}
+		var err error
+		r4[id], err = p.Round4(r3, in)
+		if err == ErrNoBiprime {
+			noBiprime++
+			continue
+		}
+		require.NoError(t, err)
+	}
+	if noBiprime > 0 {
+		require.Equal(t, len(participants), noBiprime)
+		return nil
+	}
+	shares := make(map[uint32]*KeyShare, len(participants))
+	for id, p := range participants {
+		share, err := p.Output(r4)
+		if err == ErrNoBiprime {
+			noBiprime++
+			continue
+		}
+		require.NoError(t, err)
+		shares[id] = share
+	}
+	if noBiprime > 0 {
+		require.Equal(t, len(participants), noBiprime)
+		return nil
+	}
+	return shares
+}
+
+// runBiprime runs attempts among n parties until one finds a biprime
+func runBiprime(t *testing.T, n int) map[uint32]*KeyShare {
+	participants := newTestParticipants(t, n)
+	for attempt := 0; attempt < 100; attempt++ {
+		if shares := runAttempt(t, participants); shares != nil {
+			return shares
+		}
+		for _, p := range participants {
+			require.Equal(t, uint(1), p.Round)
+		}
+	}
+	require.FailNow(t, "no biprime found")
+	return nil
+}
+
+// requireBiprime checks that the shares of φ(N) sum to φ(N) = (p-1)(q-1) for primes p and q
+func requireBiprime(t *testing.T, shares map[uint32]*KeyShare) {
+	n := shares[1].N
+	require.GreaterOrEqual(t, n.BitLen(), testBits)
+	phi := new(big.Int)
+	for _, share := range shares {
+		require.Equal(t, n, share.N)
+		require.Equal(t, shares[1].Theta, share.Theta)
+		phi.Add(phi, share.PhiShare)
+	}
+	// p + q = N - φ(N) + 1 and (p - q)^2 = (p + q)^2 - 4N
+	sum := new(big.Int).Sub(n, phi)
+	sum.Add(sum, core.One)
+	diff := new(big.Int).Mul(sum, sum)
+	diff.Sub(diff, new(big.Int).Lsh(n, 2))
+	require.True(t, diff.Sign() > 0)
+	diff.Sqrt(diff)
+	p := new(big.Int).Add(sum, diff)
+	p.Rsh(p, 1)
+	q := new(big.Int).Sub(sum, diff)
+	q.Rsh(q, 1)
+	require.Equal(t, n, new(big.Int).Mul(p, q))
+	require.True(t, p.ProbablyPrime(20))
+	require.True(t, q.ProbablyPrime(20))
+	require.Equal(t, uint(3), uint(p.Bit(0)+2*p.Bit(1)))
+	require.Equal(t, uint(3), uint(q.Bit(0)+2*q.Bit(1)))
+}
+
+func TestBiprimeWorks(t *testing.T) {
+	for _, n := range []int{3, 5} {
+		shares := runBiprime(t, n)
+		require.Len(t, shares, n)
+		requireBiprime(t, shares)
+	}
+}
+
+func TestBiprimePaillierDecryption(t *testing.T) {
+	shares := runBiprime(t, 3)
+	pk, err := shares[1].PublicKey()
+	require.NoError(t, err)
+
+	// Homomorphic sum of two ciphertexts decrypted by all parties
+	a, _, err := pk.Encrypt(big.NewInt(1234))
+	require.NoError(t, err)
+	b, _, err := pk.Encrypt(big.NewInt(4321))
+	require.NoError(t, err)
+	c, err := pk.Add(a, b)
+	require.NoError(t, err)
+
+	partials := make(map[uint32]*big.Int, len(shares))
+	for id, share := range shares {
+		partials[id], err = share.PartialDecrypt(c)
+		require.NoError(t, err)
+	}
+	for _, share := range shares {
+		m, err := share.Combine(partials)
+		require.NoError(t, err)
+		require.Equal(t, int64(5555), m.Int64())
+	}
+
+	delete(partials, 2)
+	_, err = shares[1].Combine(partials)
+	require.Error(t, err)
+}
+
+func TestBiprimeCamenischShoup(t *testing.T) {
+	shares := runBiprime(t, 3)
+	group, err := shares[1].PaillierGroup()
+	require.NoError(t, err)
+	ek, dk, err := camshoup.NewKeys(1, group)
+	require.NoError(t, err)
+	domain := []byte("biprime test")
+	msgs := []*big.Int{big.NewInt(42)}
+	ciphertext, err := ek.Encrypt(domain, msgs)
+	require.NoError(t, err)
+	decrypted, err := dk.Decrypt(domain, ciphertext)
+	require.NoError(t, err)
+	require.Equal(t, msgs, decrypted)
+}
+
+func TestBiprimalityTestRejectsComposites(t *testing.T) {
+	participants := newTestParticipants(t, 3)
+	// p = 127·16399·32839 = 3 mod 4 is composite, so that N = pq is not a biprime
+	p := new(big.Int).Mul(big.NewInt(4*31+3), big.NewInt(4*4099+3))
+	p.Mul(p, big.NewInt(4*8209+3))
+	q, err := core.GenerateSafePrime(64)
+	require.NoError(t, err)
+	for q.Bit(1) == 0 {
+		q, err = core.GenerateSafePrime(64)
+		require.NoError(t, err)
+	}
+	n := new(big.Int).Mul(p, q)
+	phi := new(big.Int).Add(p, q)
+	phi.Sub(n, phi)
+	phi.Add(phi, core.One)
+
+	// Split φ between the leader and the others
+	c := &candidate{n: n, phiShare: new(big.Int).Add(phi, big.NewInt(8))}
+	bcast := map[uint32]*Round3Bcast{}
+	for _, id := range []uint32{2, 3} {
+		w, err := participants[id].witnesses(n, big.NewInt(-4))
+		require.NoError(t, err)
+		bcast[id] = &Round3Bcast{Witnesses: [][]*big.Int{w}}
+	}
+	require.False(t, participants[1].isBiprime(c, 0, bcast))
+}
+
+func TestNewParticipantErrors(t *testing.T) {
+	sid := []byte("sid")
+	_, err := NewParticipant(1, []uint32{1, 2}, testBits, 1, sid)
+	require.Error(t, err)
+	_, err = NewParticipant(4, []uint32{1, 2, 3}, testBits, 1, sid)
+	require.Error(t, err)
+	_, err = NewParticipant(1, []uint32{1, 2, 2}, testBits, 1, sid)
+	require.Error(t, err)
+	_, err = NewParticipant(1, []uint32{1, 2, 3}, 32, 1, sid)
+	require.Error(t, err)
+	_, err = NewParticipant(1, []uint32{1, 2, 3}, testBits, 0, sid)
+	require.Error(t, err)
+	_, err = NewParticipant(1, []uint32{1, 2, 3}, testBits, 1, nil)
+	require.Error(t, err)
+}
+
+func TestBiprimeInvalidRound(t *testing.T) {
+	p := newTestParticipants(t, 3)[1]
+	_, err := p.Round2(nil)
+	require.Equal(t, internal.ErrInvalidRound, err)
+	_, err = p.Output(nil)
+	require.Equal(t, internal.ErrInvalidRound, err)
+	_, err = p.Round1()
+	require.NoError(t, err)
+	_, err = p.Round1()
+	require.Equal(t, internal.ErrInvalidRound, err)
+	// Missing input from party 3
+	_, err = p.Round2(map[uint32]*Round1P2PSend{2: {}})
+	require.Error(t, err)
+}
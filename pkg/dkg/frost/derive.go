@@ -0,0 +1,87 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package frost
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/TEENet-io/kryptology/internal"
+	"github.com/TEENet-io/kryptology/pkg/bip32"
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
+)
+
+// ExtendedKey returns the BIP-32 master extended public key of the group, built from VerificationKey and ChainCode.
+func (dp *DkgParticipant) ExtendedKey() (*bip32.ExtendedKey, error) {
+	if dp == nil || dp.Curve == nil || dp.VerificationKey == nil {
+		return nil, internal.ErrNilArguments
+	}
+	return bip32.NewMasterKey(dp.Curve, dp.VerificationKey, dp.ChainCode)
+}
+
+// DerivePath returns a signing view of the participant for the non-hardened BIP-32 child at `path`, along with the
+// child's extended public key. No interaction is needed: each participant applies the public tweak to its own share.
+// The returned participant can be passed to `ted25519/frost.NewSigner` like the original.
+func (dp *DkgParticipant) DerivePath(path []uint32) (*DkgParticipant, *bip32.ExtendedKey, error) {
+	master, err := dp.ExtendedKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	child, tweak, err := master.DerivePath(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	derived, err := dp.Derive(tweak)
+	if err != nil {
+		return nil, nil, err
+	}
+	derived.ChainCode = child.ChainCode
+	return derived, child, nil
+}
+
+// Derive returns a signing view of the participant for the key VerificationKey + tweak·G.
+// Since the Lagrange coefficients of any signing set sum to one, adding the tweak to every share adds it to the
+// secret, and adding tweak·G to the constant commitment keeps every share consistent with the commitments.
+//
+// On secp256k1 the derived key is normalized to even Y like the DKG output, so its x-only key matches the BIP-32
+// child but its full point may be the negation of it.
+func (dp *DkgParticipant) Derive(tweak curves.Scalar) (*DkgParticipant, error) {
+	if dp == nil || dp.Curve == nil || tweak == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if dp.SkShare == nil || dp.VerificationKey == nil || len(dp.Commitments) == 0 {
+		return nil, fmt.Errorf("participant has not completed the DKG")
+	}
+	tweakPoint := dp.Curve.ScalarBaseMult(tweak)
+
+	derived := *dp
+	derived.SkShare = dp.SkShare.Add(tweak)
+	derived.VerificationKey = dp.VerificationKey.Add(tweakPoint)
+	if dp.VkShare != nil {
+		derived.VkShare = dp.VkShare.Add(tweakPoint)
+	}
+	derived.Commitments = append([]curves.Point{}, dp.Commitments...)
+	derived.Commitments[0] = derived.VerificationKey
+	if derived.VerificationKey.IsIdentity() {
+		return nil, fmt.Errorf("derived verification key is the identity")
+	}
+	derived.normalizeBIP340IfNeeded()
+	return &derived, nil
+}
+
+// deriveChainCode hashes the joint commitments into a chain code for the group key.
+func deriveChainCode(ctx byte, commitments []curves.Point) [32]byte {
+	h := sha256.New()
+	_, _ = h.Write([]byte("FROST_DKG_ChainCode"))
+	_, _ = h.Write([]byte{ctx})
+	for _, c := range commitments {
+		_, _ = h.Write(c.ToAffineCompressed())
+	}
+	var chainCode [32]byte
+	copy(chainCode[:], h.Sum(nil))
+	return chainCode
+}
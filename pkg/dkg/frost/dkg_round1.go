@@ -10,6 +10,7 @@ import (
 	"bytes"
 	crand "crypto/rand"
 	"encoding/gob"
+	"encoding/json"
 	"fmt"
 	"reflect"
 
@@ -32,23 +33,115 @@ type Round1Result struct {
 	P2P       *sharing.ShamirShare
 }
 
+// Encode serializes the result with the codec of the curves package
 func (result *Round1Result) Encode() ([]byte, error) {
-	gob.Register(result.Broadcast.Verifiers.Commitments[0]) // just the point for now
-	gob.Register(result.Broadcast.Ci)
-	buf := &bytes.Buffer{}
-	enc := gob.NewEncoder(buf)
-	if err := enc.Encode(result); err != nil {
-		return nil, errors.Wrap(err, "couldn't encode round 1 broadcast")
-	}
-	return buf.Bytes(), nil
+	if result == nil || result.Broadcast == nil || result.Broadcast.Verifiers == nil ||
+		len(result.Broadcast.Verifiers.Commitments) == 0 || result.Broadcast.Wi == nil || result.Broadcast.Ci == nil {
+		return nil, internal.ErrNilArguments
+	}
+	commitments := result.Broadcast.Verifiers.Commitments
+	enc := curves.NewEncoder()
+	enc.WriteCurve(commitments[0].CurveName())
+	enc.WriteUint32(uint32(len(commitments)))
+	for _, c := range commitments {
+		enc.WritePoint(c)
+	}
+	enc.WriteScalar(result.Broadcast.Wi)
+	enc.WriteScalar(result.Broadcast.Ci)
+	enc.WritePresent(result.P2P != nil)
+	if result.P2P != nil {
+		enc.WriteUint32(result.P2P.Id)
+		enc.WriteBytes(result.P2P.Value)
+	}
+	return enc.Bytes(), nil
 }
 
+// Decode deserializes the output of Encode, or the gob encoding used by earlier versions
 func (result *Round1Result) Decode(input []byte) error {
-	buf := bytes.NewBuffer(input)
-	dec := gob.NewDecoder(buf)
-	if err := dec.Decode(result); err != nil {
-		return errors.Wrap(err, "couldn't encode round 1 broadcast")
+	if !curves.IsCodecEncoded(input) {
+		curves.RegisterGobTypes()
+		dec := gob.NewDecoder(bytes.NewBuffer(input))
+		if err := dec.Decode(result); err != nil {
+			return errors.Wrap(err, "couldn't decode round 1 broadcast")
+		}
+		return nil
+	}
+	dec := curves.NewDecoder(input)
+	dec.ReadHeader()
+	dec.ReadCurve()
+	commitments := make([]curves.Point, dec.ReadCount(4))
+	for i := range commitments {
+		commitments[i] = dec.ReadPoint()
+	}
+	bcast := &Round1Bcast{
+		Verifiers: &sharing.FeldmanVerifier{Commitments: commitments},
+		Wi:        dec.ReadScalar(),
+		Ci:        dec.ReadScalar(),
+	}
+	var p2p *sharing.ShamirShare
+	if dec.ReadPresent() {
+		p2p = &sharing.ShamirShare{Id: dec.ReadUint32(), Value: dec.ReadBytes()}
+	}
+	if err := dec.Finish(); err != nil {
+		return errors.Wrap(err, "couldn't decode round 1 broadcast")
+	}
+	if len(commitments) == 0 {
+		return errors.New("couldn't decode round 1 broadcast: no commitments")
+	}
+	result.Broadcast, result.P2P = bcast, p2p
+	return nil
+}
+
+type round1BcastJson struct {
+	Commitments []json.RawMessage `json:"commitments"`
+	Wi          json.RawMessage   `json:"wi"`
+	Ci          json.RawMessage   `json:"ci"`
+}
+
+// MarshalJSON serializes the broadcast with the JSON form of the codec of the curves package
+func (bcast Round1Bcast) MarshalJSON() ([]byte, error) {
+	if bcast.Verifiers == nil {
+		return nil, internal.ErrNilArguments
+	}
+	v := round1BcastJson{Commitments: make([]json.RawMessage, len(bcast.Verifiers.Commitments))}
+	var err error
+	for i, c := range bcast.Verifiers.Commitments {
+		if v.Commitments[i], err = curves.MarshalPointJSON(c); err != nil {
+			return nil, err
+		}
+	}
+	if v.Wi, err = curves.MarshalScalarJSON(bcast.Wi); err != nil {
+		return nil, err
+	}
+	if v.Ci, err = curves.MarshalScalarJSON(bcast.Ci); err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON deserializes the output of MarshalJSON
+func (bcast *Round1Bcast) UnmarshalJSON(input []byte) error {
+	var v round1BcastJson
+	if err := json.Unmarshal(input, &v); err != nil {
+		return err
+	}
+	commitments := make([]curves.Point, len(v.Commitments))
+	var err error
+	for i, c := range v.Commitments {
+		if commitments[i], err = curves.UnmarshalPointJSON(c); err != nil {
+			return errors.Wrap(err, "couldn't decode round 1 broadcast")
+		}
+	}
+	wi, err := curves.UnmarshalScalarJSON(v.Wi)
+	if err != nil {
+		return errors.Wrap(err, "couldn't decode round 1 broadcast")
+	}
+	ci, err := curves.UnmarshalScalarJSON(v.Ci)
+	if err != nil {
+		return errors.Wrap(err, "couldn't decode round 1 broadcast")
 	}
+	bcast.Verifiers = &sharing.FeldmanVerifier{Commitments: commitments}
+	bcast.Wi, bcast.Ci = wi, ci
 	return nil
 }
 
@@ -7,8 +7,11 @@
 package frost
 
 import (
+	"encoding/json"
 	"fmt"
 
+	"github.com/pkg/errors"
+
 	"github.com/TEENet-io/kryptology/internal"
 	"github.com/TEENet-io/kryptology/pkg/core/curves"
 	"github.com/TEENet-io/kryptology/pkg/sharing"
@@ -21,6 +24,115 @@ type Round2Bcast struct {
 	VkShare     curves.Point
 }
 
+// Encode serializes the broadcast with the codec of the curves package
+func (bcast *Round2Bcast) Encode() ([]byte, error) {
+	if bcast == nil || len(bcast.Commitments) == 0 || bcast.VkShare == nil {
+		return nil, internal.ErrNilArguments
+	}
+	enc := curves.NewEncoder()
+	enc.WriteCurve(bcast.VkShare.CurveName())
+	writePoints(enc, bcast.Commitments)
+	enc.WritePoint(bcast.VkShare)
+	return enc.Bytes(), nil
+}
+
+// Decode deserializes the output of Encode
+func (bcast *Round2Bcast) Decode(input []byte) error {
+	dec := curves.NewDecoder(input)
+	dec.ReadHeader()
+	dec.ReadCurve()
+	commitments := readPoints(dec)
+	vkShare := dec.ReadPoint()
+	if err := dec.Finish(); err != nil {
+		return errors.Wrap(err, "couldn't decode round 2 broadcast")
+	}
+	if len(commitments) == 0 {
+		return errors.New("couldn't decode round 2 broadcast: no commitments")
+	}
+	bcast.Commitments, bcast.VkShare = commitments, vkShare
+	return nil
+}
+
+type round2BcastJson struct {
+	Commitments []json.RawMessage `json:"commitments"`
+	VkShare     json.RawMessage   `json:"vkShare"`
+}
+
+// MarshalJSON serializes the broadcast with the JSON form of the codec of the curves package
+func (bcast Round2Bcast) MarshalJSON() ([]byte, error) {
+	if bcast.VkShare == nil {
+		return nil, internal.ErrNilArguments
+	}
+	commitments, err := marshalPointsJSON(bcast.Commitments)
+	if err != nil {
+		return nil, err
+	}
+	vkShare, err := curves.MarshalPointJSON(bcast.VkShare)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(round2BcastJson{Commitments: commitments, VkShare: vkShare})
+}
+
+// UnmarshalJSON deserializes the output of MarshalJSON
+func (bcast *Round2Bcast) UnmarshalJSON(input []byte) error {
+	var v round2BcastJson
+	if err := json.Unmarshal(input, &v); err != nil {
+		return err
+	}
+	commitments, err := unmarshalPointsJSON(v.Commitments)
+	if err != nil {
+		return errors.Wrap(err, "couldn't decode round 2 broadcast")
+	}
+	vkShare, err := curves.UnmarshalPointJSON(v.VkShare)
+	if err != nil {
+		return errors.Wrap(err, "couldn't decode round 2 broadcast")
+	}
+	bcast.Commitments, bcast.VkShare = commitments, vkShare
+	return nil
+}
+
+// writePoints writes the number of points followed by the points
+func writePoints(enc *curves.Encoder, points []curves.Point) {
+	enc.WriteUint32(uint32(len(points)))
+	for _, p := range points {
+		enc.WritePoint(p)
+	}
+}
+
+// readPoints reads the output of writePoints
+func readPoints(dec *curves.Decoder) []curves.Point {
+	points := make([]curves.Point, dec.ReadCount(4))
+	for i := range points {
+		points[i] = dec.ReadPoint()
+	}
+	return points
+}
+
+// marshalPointsJSON encodes each point with curves.MarshalPointJSON
+func marshalPointsJSON(points []curves.Point) ([]json.RawMessage, error) {
+	out := make([]json.RawMessage, len(points))
+	var err error
+	for i, p := range points {
+		if out[i], err = curves.MarshalPointJSON(p); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// unmarshalPointsJSON decodes the output of marshalPointsJSON
+func unmarshalPointsJSON(data []json.RawMessage) ([]curves.Point, error) {
+	points := make([]curves.Point, len(data))
+	var err error
+	for i, d := range data {
+		if points[i], err = curves.UnmarshalPointJSON(d); err != nil {
+			return nil, err
+		}
+	}
+	return points, nil
+}
+
 // Round2 implements dkg round 2 of FROST
 func (dp *DkgParticipant) Round2(bcast map[uint32]*Round1Bcast, p2psend map[uint32]*sharing.ShamirShare) (*Round2Bcast, error) {
 	// Make sure dkg participant is not empty
@@ -54,11 +166,11 @@ func (dp *DkgParticipant) Round2(bcast map[uint32]*Round1Bcast, p2psend map[uint
 			return nil, fmt.Errorf("ci should not be zero from participant %d\n", id)
 		}
 	}
-	// Validate each received commitment is on curve
+	// Validate each received commitment is a point of the curve in the prime order subgroup
 	for id := range bcast {
 		for _, com := range bcast[id].Verifiers.Commitments {
-			if !com.IsOnCurve() || com.IsIdentity() {
-				return nil, fmt.Errorf("some commitment is not on curve from participant %d\n", id)
+			if _, err := dp.Curve.PeerPoint(com); err != nil {
+				return nil, fmt.Errorf("some commitment is not in the prime order subgroup from participant %d: %v", id, err)
 			}
 		}
 	}
@@ -82,7 +194,7 @@ func (dp *DkgParticipant) Round2(bcast map[uint32]*Round1Bcast, p2psend map[uint
 		prod2 := Aj0.Mul(bcast[id].Ci.Neg())
 
 		// We need to check Aj0 and prod2 are points on the same curve.
-		if !Aj0.IsOnCurve() || Aj0.IsIdentity() || !prod2.IsOnCurve() || prod2.IsIdentity() || Aj0.CurveName() != prod2.CurveName() {
+		if !Aj0.IsOnCurve() || Aj0.IsIdentity() || !Aj0.IsTorsionFree() || !prod2.IsOnCurve() || prod2.IsIdentity() || Aj0.CurveName() != prod2.CurveName() {
 			return nil, fmt.Errorf("invalid Aj0 or prod2 which is not on the same curve")
 		}
 		if prod2 == nil {
@@ -163,6 +275,9 @@ func (dp *DkgParticipant) Round2(bcast map[uint32]*Round1Bcast, p2psend map[uint
 	// don't need to know about it. No-op on other curves.
 	dp.normalizeBIP340IfNeeded()
 
+	// Every participant holds the same joint commitments, and they depend on the randomness of all participants.
+	dp.ChainCode = deriveChainCode(dp.ctx, dp.Commitments)
+
 	// Update round number
 	dp.round = 3
 
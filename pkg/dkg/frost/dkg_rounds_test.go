@@ -7,6 +7,9 @@
 package frost
 
 import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -166,3 +169,142 @@ func TestFullDkgRoundsWorks(t *testing.T) {
 	vk := testCurve.ScalarBaseMult(sk)
 	require.True(t, vk.Equal(p1.VerificationKey))
 }
+
+func TestRound1ResultEncodingAllCurves(t *testing.T) {
+	for _, curve := range []*curves.Curve{
+		curves.ED25519(), curves.K256(), curves.P256(), curves.PALLAS(), curves.BLS12381G1(),
+	} {
+		p1, err := NewDkgParticipant(1, 2, Ctx, curve, 2)
+		require.NoError(t, err, curve.Name)
+		bcast, p2psend, err := p1.Round1(nil)
+		require.NoError(t, err, curve.Name)
+		result := &Round1Result{Broadcast: bcast, P2P: p2psend[2]}
+
+		enc, err := result.Encode()
+		require.NoError(t, err, curve.Name)
+		decoded := new(Round1Result)
+		require.NoError(t, decoded.Decode(enc), curve.Name)
+		requireRound1BcastEqual(t, bcast, decoded.Broadcast)
+		require.Equal(t, result.P2P, decoded.P2P)
+		require.Error(t, new(Round1Result).Decode(append(enc, 0)), curve.Name)
+
+		enc, err = json.Marshal(result)
+		require.NoError(t, err, curve.Name)
+		decoded = new(Round1Result)
+		require.NoError(t, json.Unmarshal(enc, decoded), curve.Name)
+		requireRound1BcastEqual(t, bcast, decoded.Broadcast)
+		require.Equal(t, result.P2P, decoded.P2P)
+	}
+}
+
+func TestRound1ResultDecodesLegacyGob(t *testing.T) {
+	p1, err := NewDkgParticipant(1, 2, Ctx, testCurve, 2)
+	require.NoError(t, err)
+	bcast, p2psend, err := p1.Round1(nil)
+	require.NoError(t, err)
+	result := &Round1Result{Broadcast: bcast, P2P: p2psend[2]}
+	gob.Register(bcast.Verifiers.Commitments[0])
+	gob.Register(bcast.Ci)
+	buf := new(bytes.Buffer)
+	require.NoError(t, gob.NewEncoder(buf).Encode(result))
+
+	decoded := new(Round1Result)
+	require.NoError(t, decoded.Decode(buf.Bytes()))
+	requireRound1BcastEqual(t, bcast, decoded.Broadcast)
+	require.Equal(t, result.P2P, decoded.P2P)
+}
+
+func TestRound2AndResharingBcastEncodingAllCurves(t *testing.T) {
+	for _, curve := range []*curves.Curve{
+		curves.ED25519(), curves.K256(), curves.P256(), curves.PALLAS(), curves.BLS12381G1(),
+	} {
+		p1, err := NewDkgParticipant(1, 2, Ctx, curve, 2)
+		require.NoError(t, err, curve.Name)
+		p2, err := NewDkgParticipant(2, 2, Ctx, curve, 1)
+		require.NoError(t, err, curve.Name)
+		bcast1, p2psend1, err := p1.Round1(nil)
+		require.NoError(t, err, curve.Name)
+		bcast2, p2psend2, err := p2.Round1(nil)
+		require.NoError(t, err, curve.Name)
+		bcast := map[uint32]*Round1Bcast{1: bcast1, 2: bcast2}
+		round2, err := p1.Round2(bcast, map[uint32]*sharing.ShamirShare{2: p2psend2[1]})
+		require.NoError(t, err, curve.Name)
+		_, err = p2.Round2(bcast, map[uint32]*sharing.ShamirShare{1: p2psend1[2]})
+		require.NoError(t, err, curve.Name)
+
+		enc, err := round2.Encode()
+		require.NoError(t, err, curve.Name)
+		decoded := new(Round2Bcast)
+		require.NoError(t, decoded.Decode(enc), curve.Name)
+		requirePointsEqual(t, round2.Commitments, decoded.Commitments)
+		require.True(t, round2.VkShare.Equal(decoded.VkShare), curve.Name)
+		require.Error(t, new(Round2Bcast).Decode(append(enc, 0)), curve.Name)
+
+		enc, err = json.Marshal(round2)
+		require.NoError(t, err, curve.Name)
+		decoded = new(Round2Bcast)
+		require.NoError(t, json.Unmarshal(enc, decoded), curve.Name)
+		requirePointsEqual(t, round2.Commitments, decoded.Commitments)
+		require.True(t, round2.VkShare.Equal(decoded.VkShare), curve.Name)
+
+		r, err := NewResharing(2, curve, []uint32{1, 2}, []uint32{3, 4, 5})
+		require.NoError(t, err, curve.Name)
+		reshare, _, err := r.ResharingRound1(p1)
+		require.NoError(t, err, curve.Name)
+
+		enc, err = reshare.Encode()
+		require.NoError(t, err, curve.Name)
+		decodedReshare := new(ResharingBcast)
+		require.NoError(t, decodedReshare.Decode(enc), curve.Name)
+		requirePointsEqual(t, reshare.As, decodedReshare.As)
+		requirePointsEqual(t, reshare.PHIs, decodedReshare.PHIs)
+		require.Error(t, new(ResharingBcast).Decode(enc[:len(enc)-1]), curve.Name)
+
+		enc, err = json.Marshal(reshare)
+		require.NoError(t, err, curve.Name)
+		decodedReshare = new(ResharingBcast)
+		require.NoError(t, json.Unmarshal(enc, decodedReshare), curve.Name)
+		requirePointsEqual(t, reshare.As, decodedReshare.As)
+		requirePointsEqual(t, reshare.PHIs, decodedReshare.PHIs)
+	}
+}
+
+func requirePointsEqual(t *testing.T, expected, actual []curves.Point) {
+	require.Len(t, actual, len(expected))
+	for i, p := range expected {
+		require.True(t, p.Equal(actual[i]))
+	}
+}
+
+func requireRound1BcastEqual(t *testing.T, expected, actual *Round1Bcast) {
+	require.Len(t, actual.Verifiers.Commitments, len(expected.Verifiers.Commitments))
+	for i, c := range expected.Verifiers.Commitments {
+		require.True(t, c.Equal(actual.Verifiers.Commitments[i]))
+	}
+	require.Equal(t, 0, expected.Wi.Cmp(actual.Wi))
+	require.Equal(t, 0, expected.Ci.Cmp(actual.Ci))
+}
+
+func TestDkgRound2RejectsTorsionCommitment(t *testing.T) {
+	p1, _, bcast1, bcast2, _, p2psend2 := PrepareRound2Input(t)
+	// y = 0 encodes a point of order 4
+	torsion, err := testCurve.Point.FromAffineCompressed(make([]byte, 32))
+	require.NoError(t, err)
+	commitments := bcast2.Verifiers.Commitments
+	commitments[len(commitments)-1] = commitments[len(commitments)-1].Add(torsion)
+	bcast := map[uint32]*Round1Bcast{1: bcast1, 2: bcast2}
+	p2p := map[uint32]*sharing.ShamirShare{2: p2psend2[1]}
+	_, err = p1.Round2(bcast, p2p)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "prime order subgroup")
+}
+
+func TestDkgRound2RejectsCommitmentOfOtherCurve(t *testing.T) {
+	p1, _, bcast1, bcast2, _, p2psend2 := PrepareRound2Input(t)
+	bcast2.Verifiers.Commitments[1] = curves.RISTRETTO255().Point.Generator()
+	bcast := map[uint32]*Round1Bcast{1: bcast1, 2: bcast2}
+	p2p := map[uint32]*sharing.ShamirShare{2: p2psend2[1]}
+	_, err := p1.Round2(bcast, p2p)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "ristretto255")
+}
@@ -31,6 +31,10 @@ type DkgParticipant struct {
 	Commitments []curves.Point
 	Threshold   uint32
 
+	// ChainCode is the BIP-32 chain code of VerificationKey, derived from the joint commitments at the end of
+	// round 2. It is the same for every participant. Resharing does not change it; new participants copy it over.
+	ChainCode [32]byte
+
 	feldman      *sharing.Feldman
 	verifiers    *sharing.FeldmanVerifier
 	secretShares map[uint32]*sharing.ShamirShare
@@ -2,8 +2,11 @@ package frost
 
 import (
 	crand "crypto/rand"
+	"encoding/json"
 	"fmt"
 
+	"github.com/pkg/errors"
+
 	"github.com/TEENet-io/kryptology/internal"
 	"github.com/TEENet-io/kryptology/pkg/core/curves"
 	"github.com/TEENet-io/kryptology/pkg/sharing"
@@ -30,6 +33,71 @@ type ResharingBcast struct {
 	PHIs []curves.Point
 }
 
+// Encode serializes the broadcast with the codec of the curves package
+func (bcast *ResharingBcast) Encode() ([]byte, error) {
+	if bcast == nil || len(bcast.PHIs) == 0 || bcast.PHIs[0] == nil {
+		return nil, internal.ErrNilArguments
+	}
+	enc := curves.NewEncoder()
+	enc.WriteCurve(bcast.PHIs[0].CurveName())
+	writePoints(enc, bcast.As)
+	writePoints(enc, bcast.PHIs)
+	return enc.Bytes(), nil
+}
+
+// Decode deserializes the output of Encode
+func (bcast *ResharingBcast) Decode(input []byte) error {
+	dec := curves.NewDecoder(input)
+	dec.ReadHeader()
+	dec.ReadCurve()
+	as := readPoints(dec)
+	phis := readPoints(dec)
+	if err := dec.Finish(); err != nil {
+		return errors.Wrap(err, "couldn't decode resharing broadcast")
+	}
+	if len(phis) == 0 {
+		return errors.New("couldn't decode resharing broadcast: no commitments")
+	}
+	bcast.As, bcast.PHIs = as, phis
+	return nil
+}
+
+type resharingBcastJson struct {
+	As   []json.RawMessage `json:"as"`
+	PHIs []json.RawMessage `json:"phis"`
+}
+
+// MarshalJSON serializes the broadcast with the JSON form of the codec of the curves package
+func (bcast ResharingBcast) MarshalJSON() ([]byte, error) {
+	as, err := marshalPointsJSON(bcast.As)
+	if err != nil {
+		return nil, err
+	}
+	phis, err := marshalPointsJSON(bcast.PHIs)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(resharingBcastJson{As: as, PHIs: phis})
+}
+
+// UnmarshalJSON deserializes the output of MarshalJSON
+func (bcast *ResharingBcast) UnmarshalJSON(input []byte) error {
+	var v resharingBcastJson
+	if err := json.Unmarshal(input, &v); err != nil {
+		return err
+	}
+	as, err := unmarshalPointsJSON(v.As)
+	if err != nil {
+		return errors.Wrap(err, "couldn't decode resharing broadcast")
+	}
+	phis, err := unmarshalPointsJSON(v.PHIs)
+	if err != nil {
+		return errors.Wrap(err, "couldn't decode resharing broadcast")
+	}
+	bcast.As, bcast.PHIs = as, phis
+	return nil
+}
+
 type ResharingP2PSend = map[uint32]*sharing.ShamirShare
 
 // ResharingRound1 is called by a participant who hold a valid secret share
@@ -71,6 +71,13 @@ func (r *Resharing) ResharingRound2(
 			len(data.As) != int(r.Threshold) || len(data.PHIs) > len(bcast) {
 			return fmt.Errorf("invalid broadcast data")
 		}
+		for _, points := range [][]curves.Point{data.As, data.PHIs} {
+			for _, p := range points {
+				if _, err := r.curve.PeerPoint(p); err != nil {
+					return fmt.Errorf("invalid broadcast commitment: %v", err)
+				}
+			}
+		}
 	}
 
 	for _, data := range p2psend {
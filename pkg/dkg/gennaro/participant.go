@@ -74,6 +74,19 @@ func NewParticipant(id, threshold uint32, generator *curves.EcPoint, scalar curv
 	}, nil
 }
 
+// checkPeerPoint returns an error unless v, received from another participant, is a point of
+// the participant's curve that decodes through FromAffineCompressedStrict
+func (dp *Participant) checkPeerPoint(v *v1.ShareVerifier) error {
+	if v == nil || v.Curve == nil {
+		return internal.ErrNilArguments
+	}
+	if v.Curve.Params().Name != dp.curve.Params().Name {
+		return fmt.Errorf("point of curve %s instead of %s", v.Curve.Params().Name, dp.curve.Params().Name)
+	}
+	_, err := v.PeerPoint()
+	return err
+}
+
 // Determines if the SSIDs are exactly the values 1..n.
 func validIds(ids []uint32) error {
 	// Index
@@ -56,6 +56,11 @@ func (dp *Participant) Round2(bcast map[uint32]Round1Bcast, p2p map[uint32]*Roun
 		xji := p2p[id].SecretShare
 		rji := p2p[id].BlindingShare
 		bvs := bcast[id]
+		for _, v := range bvs {
+			if err := dp.checkPeerPoint(v); err != nil {
+				return nil, fmt.Errorf("blinding verifier is not in the prime order subgroup for participant id=%v: %v", id, err)
+			}
+		}
 		if ok, err := dp.pedersen.Verify(xji, rji, bvs); !ok {
 			if err != nil {
 				return nil, err
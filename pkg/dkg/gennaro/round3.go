@@ -48,6 +48,11 @@ func (dp *Participant) Round3(bcast map[uint32]Round2Bcast) (*Round3Bcast, *v1.S
 		// 4. If FeldmanVerify(E, xji, {R_j1,...,R_jt}) = false; abort
 		xji := dp.otherParticipantShares[id].Share
 		vs := bcast[id]
+		for _, v := range vs {
+			if err := dp.checkPeerPoint(v); err != nil {
+				return nil, nil, fmt.Errorf("verifier is not in the prime order subgroup for participant id=%v: %v", id, err)
+			}
+		}
 		if ok, err := dp.feldman.Verify(xji, vs); !ok {
 			if err != nil {
 				return nil, nil, err
@@ -69,7 +74,7 @@ func (dp *Participant) Round3(bcast map[uint32]Round2Bcast) (*Round3Bcast, *v1.S
 
 	// This is a sanity check to make sure nothing went wrong
 	// when computing the public key
-	if !Pk.IsOnCurve() || Pk.IsIdentity() {
+	if !Pk.IsTorsionFree() || Pk.IsIdentity() {
 		return nil, nil, fmt.Errorf("invalid public key")
 	}
 
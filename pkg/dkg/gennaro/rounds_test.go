@@ -8,11 +8,14 @@ package gennaro
 
 import (
 	"fmt"
+	"math/big"
 	"testing"
 
+	"filippo.io/edwards25519"
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/stretchr/testify/require"
 
+	"github.com/TEENet-io/kryptology/internal"
 	"github.com/TEENet-io/kryptology/pkg/core/curves"
 	v1 "github.com/TEENet-io/kryptology/pkg/sharing/v1"
 )
@@ -139,6 +142,45 @@ func TestParticipantRound2BadInput(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestParticipantRound2RejectsVerifierOfOtherCurve(t *testing.T) {
+	p1, _, bcast1, bcast2, p2psend2 := PrepareRound2Input(t)
+	other, err := curves.NewEcPoint(curves.P256().NewGeneratorPoint())
+	require.NoError(t, err)
+	bcast2[1] = other
+
+	bcast := map[uint32]Round1Bcast{1: bcast1, 2: bcast2}
+	p2p := map[uint32]*Round1P2PSendPacket{2: p2psend2[1]}
+	_, err = p1.Round2(bcast, p2p)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "P-256")
+}
+
+func TestParticipantRound2RejectsTorsionVerifier(t *testing.T) {
+	generator, err := curves.NewScalarBaseMult(v1.Ed25519(), big.NewInt(3333))
+	require.NoError(t, err)
+	p1, err := NewParticipant(1, 2, generator, curves.NewEd25519Scalar(), 2)
+	require.NoError(t, err)
+	p2, err := NewParticipant(2, 2, generator, curves.NewEd25519Scalar(), 1)
+	require.NoError(t, err)
+	bcast1, _, err := p1.Round1(nil)
+	require.NoError(t, err)
+	bcast2, p2psend2, err := p2.Round1(nil)
+	require.NoError(t, err)
+
+	// Add a point of order 4, encoded by y = 0, to a blinding verifier of participant 2
+	torsion, err := edwards25519.NewIdentityPoint().SetBytes(make([]byte, 32))
+	require.NoError(t, err)
+	v, err := internal.BigInt2Ed25519Point(bcast2[1].Y)
+	require.NoError(t, err)
+	bcast2[1].Y = new(big.Int).SetBytes(v.Add(v, torsion).Bytes())
+
+	bcast := map[uint32]Round1Bcast{1: bcast1, 2: bcast2}
+	p2p := map[uint32]*Round1P2PSendPacket{2: p2psend2[1]}
+	_, err = p1.Round2(bcast, p2p)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "prime order subgroup")
+}
+
 func PrepareRound3Input(t *testing.T) (*Participant, *Participant, map[uint32]Round2Bcast) {
 	p1, _ := NewParticipant(1, 2, testGenerator, curves.NewK256Scalar(), 2)
 	p2, _ := NewParticipant(2, 2, testGenerator, curves.NewK256Scalar(), 1)
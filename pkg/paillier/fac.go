@@ -0,0 +1,271 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// This file contains proofs that Paillier moduli have no small factors: [CGGMP21] fig 28
+// https://eprint.iacr.org/2021/060.pdf
+
+package paillier
+
+import (
+	crand "crypto/rand"
+	"fmt"
+	"math/big"
+
+	"github.com/TEENet-io/kryptology/internal"
+	crypto "github.com/TEENet-io/kryptology/pkg/core"
+)
+
+const (
+	// FacProofEll is the statistical parameter ℓ of Πfac: both factors of N exceed 2^ℓ
+	FacProofEll = 256
+	// FacProofEpsilon is the slackness parameter ε of Πfac
+	FacProofEpsilon = 2 * FacProofEll
+)
+
+// FacProofParams contains the inputs to ProveFac
+type FacProofParams struct {
+	SecretKey *SecretKey
+	// RingPedersen are the parameters of the verifier
+	RingPedersen *RingPedersenParams
+	Pi           uint32
+	// Sid is the session the proof is bound to, see ModProofParams
+	Sid []byte
+}
+
+// FacVerifyParams contains the inputs to VerifyFac
+type FacVerifyParams struct {
+	PublicKey *PublicKey
+	// RingPedersen are the parameters of the verifier
+	RingPedersen *RingPedersenParams
+	Pi           uint32
+	Sid          []byte
+}
+
+// FacProof proves that both factors of a Paillier modulus N are at most 2^{ℓ+ε}·√N,
+// and so at least √N / 2^{ℓ+ε}
+type FacProof struct {
+	P, Q, A, B, T, Sigma *big.Int
+	Z1, Z2, W1, W2, V    *big.Int
+}
+
+// Prove that a Paillier modulus has no small factors
+// [CGGMP21] fig 28
+func (p *FacProofParams) Prove() (*FacProof, error) {
+	if p.SecretKey == nil || p.RingPedersen == nil || p.Pi == 0 {
+		return nil, internal.ErrNilArguments
+	}
+	if err := p.RingPedersen.validate(); err != nil {
+		return nil, err
+	}
+	n0 := p.SecretKey.N
+	P, Q, err := p.SecretKey.primes()
+	if err != nil {
+		return nil, err
+	}
+	nHat, s, t := p.RingPedersen.N, p.RingPedersen.S, p.RingPedersen.T
+
+	// 1. Sample α, β ← ±2^{ℓ+ε}·√N0, μ, ν ← ±2^ℓ·N̂, σ ← ±2^ℓ·N0·N̂, r ← ±2^{ℓ+ε}·N0·N̂, x, y ← ±2^{ℓ+ε}·N̂
+	bounds := facBounds(n0, nHat)
+	alpha, err := randomSigned(bounds.sqrtN0)
+	if err != nil {
+		return nil, err
+	}
+	beta, err := randomSigned(bounds.sqrtN0)
+	if err != nil {
+		return nil, err
+	}
+	mu, err := randomSigned(bounds.nHat)
+	if err != nil {
+		return nil, err
+	}
+	nu, err := randomSigned(bounds.nHat)
+	if err != nil {
+		return nil, err
+	}
+	sigma, err := randomSigned(bounds.n0NHat)
+	if err != nil {
+		return nil, err
+	}
+	r, err := randomSigned(bounds.n0NHatEps)
+	if err != nil {
+		return nil, err
+	}
+	x, err := randomSigned(bounds.nHatEps)
+	if err != nil {
+		return nil, err
+	}
+	y, err := randomSigned(bounds.nHatEps)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. P = s^p t^μ, Q = s^q t^ν, A = s^α t^x, B = s^β t^y, T = Q^α t^r mod N̂
+	proof := &FacProof{Sigma: sigma}
+	if proof.P, err = pedersen(s, t, P, mu, nHat); err != nil {
+		return nil, err
+	}
+	if proof.Q, err = pedersen(s, t, Q, nu, nHat); err != nil {
+		return nil, err
+	}
+	if proof.A, err = pedersen(s, t, alpha, x, nHat); err != nil {
+		return nil, err
+	}
+	if proof.B, err = pedersen(s, t, beta, y, nHat); err != nil {
+		return nil, err
+	}
+	if proof.T, err = pedersen(proof.Q, t, alpha, r, nHat); err != nil {
+		return nil, err
+	}
+
+	// 3. e ← FS-HASH(N0, N̂, s, t, P, Q, A, B, T, σ, Pi, Sid)
+	e, err := facChallenge(n0, p.RingPedersen, proof, p.Pi, p.Sid)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. σ̂ = σ - νp, z1 = α + ep, z2 = β + eq, w1 = x + eμ, w2 = y + eν, v = r + eσ̂
+	sigmaHat := new(big.Int).Sub(sigma, new(big.Int).Mul(nu, P))
+	proof.Z1 = affine(alpha, e, P)
+	proof.Z2 = affine(beta, e, Q)
+	proof.W1 = affine(x, e, mu)
+	proof.W2 = affine(y, e, nu)
+	proof.V = affine(r, e, sigmaHat)
+	return proof, nil
+}
+
+// Verify that a Paillier modulus has no small factors
+// [CGGMP21] fig 28
+func (p *FacProof) Verify(params *FacVerifyParams) error {
+	if p == nil || params == nil || params.PublicKey == nil || params.PublicKey.N == nil ||
+		params.RingPedersen == nil || params.Pi == 0 {
+		return internal.ErrNilArguments
+	}
+	if crypto.AnyNil(p.P, p.Q, p.A, p.B, p.T, p.Sigma, p.Z1, p.Z2, p.W1, p.W2, p.V) {
+		return internal.ErrNilArguments
+	}
+	if err := params.RingPedersen.validate(); err != nil {
+		return err
+	}
+	n0 := params.PublicKey.N
+	nHat, s, t := params.RingPedersen.N, params.RingPedersen.S, params.RingPedersen.T
+	for _, v := range []*big.Int{p.P, p.Q, p.A, p.B, p.T} {
+		if err := crypto.In(v, nHat); err != nil {
+			return err
+		}
+		if new(big.Int).GCD(nil, nil, v, nHat).Cmp(crypto.One) != 0 {
+			return fmt.Errorf("invalid fac proof")
+		}
+	}
+
+	e, err := facChallenge(n0, params.RingPedersen, p, params.Pi, params.Sid)
+	if err != nil {
+		return err
+	}
+
+	// R = s^N0 t^σ mod N̂
+	R, err := pedersen(s, t, n0, p.Sigma, nHat)
+	if err != nil {
+		return err
+	}
+	checks := []struct {
+		base, x, y, commitment, value *big.Int
+	}{
+		// s^z1 t^w1 = A P^e mod N̂
+		{s, p.Z1, p.W1, p.A, p.P},
+		// s^z2 t^w2 = B Q^e mod N̂
+		{s, p.Z2, p.W2, p.B, p.Q},
+		// Q^z1 t^v = T R^e mod N̂
+		{p.Q, p.Z1, p.V, p.T, R},
+	}
+	for i, c := range checks {
+		lhs, err := pedersen(c.base, t, c.x, c.y, nHat)
+		if err != nil {
+			return err
+		}
+		rhs := new(big.Int).Exp(c.value, e, nHat)
+		rhs.Mul(rhs, c.commitment)
+		rhs.Mod(rhs, nHat)
+		if lhs.Cmp(rhs) != 0 {
+			return fmt.Errorf("invalid fac proof at %d", i)
+		}
+	}
+
+	// z1, z2 ∈ ±2^{ℓ+ε}·√N0
+	bound := facBounds(n0, nHat).sqrtN0
+	for _, z := range []*big.Int{p.Z1, p.Z2} {
+		if new(big.Int).Abs(z).Cmp(bound) == 1 {
+			return fmt.Errorf("fac proof response out of range")
+		}
+	}
+	return nil
+}
+
+// facRanges are the bounds of the values sampled in FacProof
+type facRanges struct {
+	// 2^{ℓ+ε}·√N0
+	sqrtN0 *big.Int
+	// 2^ℓ·N̂
+	nHat *big.Int
+	// 2^{ℓ+ε}·N̂
+	nHatEps *big.Int
+	// 2^ℓ·N0·N̂
+	n0NHat *big.Int
+	// 2^{ℓ+ε}·N0·N̂
+	n0NHatEps *big.Int
+}
+
+func facBounds(n0, nHat *big.Int) facRanges {
+	sqrtN0 := new(big.Int).Sqrt(n0)
+	sqrtN0.Add(sqrtN0, crypto.One)
+	n0NHat := new(big.Int).Mul(n0, nHat)
+	return facRanges{
+		sqrtN0:    sqrtN0.Lsh(sqrtN0, FacProofEll+FacProofEpsilon),
+		nHat:      new(big.Int).Lsh(nHat, FacProofEll),
+		nHatEps:   new(big.Int).Lsh(nHat, FacProofEll+FacProofEpsilon),
+		n0NHat:    new(big.Int).Lsh(n0NHat, FacProofEll),
+		n0NHatEps: new(big.Int).Lsh(n0NHat, FacProofEll+FacProofEpsilon),
+	}
+}
+
+// facChallenge computes the Fiat-Shamir challenge e ∈ [0, 2^ℓ) of FacProof
+func facChallenge(n0 *big.Int, rp *RingPedersenParams, p *FacProof, pi uint32, sid []byte) (*big.Int, error) {
+	// The hash only takes non-negative integers, so σ is committed to by its absolute value and sign
+	sign := big.NewInt(int64(p.Sigma.Sign() + 1))
+	e, err := crypto.FiatShamir(
+		n0, rp.N, rp.S, rp.T, p.P, p.Q, p.A, p.B, p.T, new(big.Int).Abs(p.Sigma), sign,
+		new(big.Int).SetUint64(uint64(pi)), sidInt(sid),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(e[:FacProofEll/8]), nil
+}
+
+// pedersen computes s^x t^y mod n for signed exponents x and y
+func pedersen(s, t, x, y, n *big.Int) (*big.Int, error) {
+	sx := new(big.Int).Exp(s, x, n)
+	ty := new(big.Int).Exp(t, y, n)
+	// Exp returns nil when an exponent is negative and the base is not invertible
+	if sx == nil || ty == nil {
+		return nil, fmt.Errorf("cannot compute the multiplicative inverse")
+	}
+	sx.Mul(sx, ty)
+	return sx.Mod(sx, n), nil
+}
+
+// affine returns a + e·b
+func affine(a, e, b *big.Int) *big.Int {
+	r := new(big.Int).Mul(e, b)
+	return r.Add(r, a)
+}
+
+// randomSigned returns a uniform integer in [-bound, bound]
+func randomSigned(bound *big.Int) (*big.Int, error) {
+	r, err := crand.Int(crand.Reader, new(big.Int).Add(new(big.Int).Lsh(bound, 1), crypto.One))
+	if err != nil {
+		return nil, err
+	}
+	return r.Sub(r, bound), nil
+}
@@ -0,0 +1,85 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package paillier
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	crypto "github.com/TEENet-io/kryptology/pkg/core"
+)
+
+func setupFacProof(t *testing.T, sk *SecretKey) (*RingPedersenParams, *FacProof) {
+	verifierSk, err := NewSecretKey(testPrimes[2], testPrimes[3])
+	require.NoError(t, err)
+	rp, _, err := NewRingPedersenParams(verifierSk)
+	require.NoError(t, err)
+	proof, err := (&FacProofParams{SecretKey: sk, RingPedersen: rp, Pi: 1}).Prove()
+	require.NoError(t, err)
+	return rp, proof
+}
+
+func TestFacProofWorks(t *testing.T) {
+	sk, err := NewSecretKey(testPrimes[0], testPrimes[1])
+	require.NoError(t, err)
+	rp, proof := setupFacProof(t, sk)
+	params := &FacVerifyParams{PublicKey: &sk.PublicKey, RingPedersen: rp, Pi: 1}
+	require.NoError(t, proof.Verify(params))
+
+	bytes, err := json.Marshal(proof)
+	require.NoError(t, err)
+	unmarshaled := new(FacProof)
+	require.NoError(t, json.Unmarshal(bytes, unmarshaled))
+	require.NoError(t, unmarshaled.Verify(params))
+
+	// Proofs are bound to the prover id, the session and the verifier's parameters
+	require.Error(t, proof.Verify(&FacVerifyParams{PublicKey: &sk.PublicKey, RingPedersen: rp, Pi: 2}))
+	require.Error(t, proof.Verify(&FacVerifyParams{PublicKey: &sk.PublicKey, RingPedersen: rp, Pi: 1, Sid: []byte("other")}))
+	otherSk, err := NewSecretKey(testPrimes[4], testPrimes[5])
+	require.NoError(t, err)
+	other, _, err := NewRingPedersenParams(otherSk)
+	require.NoError(t, err)
+	require.Error(t, proof.Verify(&FacVerifyParams{PublicKey: &sk.PublicKey, RingPedersen: other, Pi: 1}))
+}
+
+func TestFacProofSmallFactor(t *testing.T) {
+	// N = 3·Q with Q = testPrimes[0]·testPrimes[1]
+	sk, err := NewSecretKey(big.NewInt(3), new(big.Int).Mul(testPrimes[0], testPrimes[1]))
+	require.NoError(t, err)
+	rp, proof := setupFacProof(t, sk)
+	require.Error(t, proof.Verify(&FacVerifyParams{PublicKey: &sk.PublicKey, RingPedersen: rp, Pi: 1}))
+}
+
+func TestFacProofTampered(t *testing.T) {
+	sk, err := NewSecretKey(testPrimes[0], testPrimes[1])
+	require.NoError(t, err)
+	rp, proof := setupFacProof(t, sk)
+	params := &FacVerifyParams{PublicKey: &sk.PublicKey, RingPedersen: rp, Pi: 1}
+
+	for _, tamper := range []func(p *FacProof){
+		func(p *FacProof) { p.Z1 = new(big.Int).Add(p.Z1, crypto.One) },
+		func(p *FacProof) { p.Z2 = new(big.Int).Neg(p.Z2) },
+		func(p *FacProof) { p.V = new(big.Int).Sub(p.V, crypto.One) },
+		func(p *FacProof) { p.Sigma = new(big.Int).Neg(p.Sigma) },
+		func(p *FacProof) { p.T = new(big.Int).Add(p.T, rp.N) },
+		func(p *FacProof) { p.P = rp.N },
+		func(p *FacProof) { p.W1 = nil },
+	} {
+		tampered := *proof
+		tamper(&tampered)
+		require.Error(t, tampered.Verify(params))
+	}
+
+	require.Error(t, proof.Verify(nil))
+	_, err = (&FacProofParams{SecretKey: sk, Pi: 1}).Prove()
+	require.Error(t, err)
+	_, err = (&FacProofParams{SecretKey: sk, RingPedersen: &RingPedersenParams{N: rp.N, S: rp.S, T: crypto.One}, Pi: 1}).Prove()
+	require.Error(t, err)
+}
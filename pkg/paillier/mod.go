@@ -0,0 +1,278 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// This file contains proofs that Paillier moduli are Paillier-Blum moduli: [CGGMP21] fig 16
+// https://eprint.iacr.org/2021/060.pdf
+
+package paillier
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/TEENet-io/kryptology/internal"
+	crypto "github.com/TEENet-io/kryptology/pkg/core"
+)
+
+// ModProofLength is the number of challenges m in Πmod, for a soundness error of 2^-ModProofLength
+const ModProofLength = 80
+
+// ModProofParams contains the inputs to ProveMod
+type ModProofParams struct {
+	SecretKey *SecretKey
+	Pi        uint32
+	// Sid binds the proof to a session, such as the sid and rid of CGGMP21. The prover and
+	// the verifier must use the same value, which may be empty.
+	Sid []byte
+}
+
+// ModVerifyParams contains the inputs to VerifyMod
+type ModVerifyParams struct {
+	PublicKey *PublicKey
+	Pi        uint32
+	Sid       []byte
+}
+
+// ModProof proves that a Paillier modulus N = PQ is a Paillier-Blum modulus:
+// gcd(N, φ(N)) = 1 and P = Q = 3 mod 4
+type ModProof struct {
+	W *big.Int
+	X []*big.Int
+	A []bool
+	B []bool
+	Z []*big.Int
+}
+
+// Prove that a Paillier modulus is a Paillier-Blum modulus
+// [CGGMP21] fig 16
+func (p *ModProofParams) Prove() (*ModProof, error) {
+	if p.SecretKey == nil || p.Pi == 0 {
+		return nil, internal.ErrNilArguments
+	}
+	n := p.SecretKey.N
+	P, Q, err := p.SecretKey.primes()
+	if err != nil {
+		return nil, err
+	}
+	if P.Bit(0) != 1 || P.Bit(1) != 1 || Q.Bit(0) != 1 || Q.Bit(1) != 1 {
+		return nil, fmt.Errorf("paillier primes are not 3 mod 4")
+	}
+
+	// 1. Sample w ← Z_N with Jacobi symbol (w|N) = -1
+	var w *big.Int
+	for {
+		w, err = crypto.Rand(n)
+		if err != nil {
+			return nil, err
+		}
+		if big.Jacobi(w, n) == -1 {
+			break
+		}
+	}
+
+	// 2. [y_1, ..., y_m] ← FS-HASH(N, w, Pi, Sid)
+	y, err := modChallenges(n, w, p.Pi, p.Sid)
+	if err != nil {
+		return nil, err
+	}
+
+	// N^-1 mod φ(N), reduced for exponentiations modulo P and Q
+	nInv, err := crypto.Inv(n, p.SecretKey.Totient)
+	if err != nil {
+		return nil, err
+	}
+	nInvP := new(big.Int).Mod(nInv, new(big.Int).Sub(P, crypto.One))
+	nInvQ := new(big.Int).Mod(nInv, new(big.Int).Sub(Q, crypto.One))
+	// Exponents taking the fourth root of a quadratic residue modulo P and Q
+	eP := fourthRootExponent(P)
+	eQ := fourthRootExponent(Q)
+	qInv := new(big.Int).ModInverse(Q, P)
+
+	proof := &ModProof{
+		W: w,
+		X: make([]*big.Int, ModProofLength),
+		A: make([]bool, ModProofLength),
+		B: make([]bool, ModProofLength),
+		Z: make([]*big.Int, ModProofLength),
+	}
+	for i, yi := range y {
+		// 3. Find a_i, b_i such that y'_i = (-1)^a_i w^b_i y_i is a quadratic residue mod N
+		found := false
+		for _, ab := range [][2]bool{{false, false}, {true, false}, {false, true}, {true, true}} {
+			yPrime := new(big.Int).Set(yi)
+			if ab[0] {
+				yPrime.Sub(n, yPrime)
+			}
+			if ab[1] {
+				yPrime.Mul(yPrime, w)
+				yPrime.Mod(yPrime, n)
+			}
+			if big.Jacobi(yPrime, P) != 1 || big.Jacobi(yPrime, Q) != 1 {
+				continue
+			}
+			// 4. x_i = y'_i^{1/4} mod N
+			xP := new(big.Int).Exp(yPrime, eP, P)
+			xQ := new(big.Int).Exp(yPrime, eQ, Q)
+			proof.X[i] = crt(xP, xQ, P, Q, qInv)
+			proof.A[i], proof.B[i] = ab[0], ab[1]
+			found = true
+			break
+		}
+		if !found {
+			return nil, fmt.Errorf("paillier modulus is not a Blum integer")
+		}
+
+		// 5. z_i = y_i^{N^-1 mod φ(N)} mod N
+		proof.Z[i] = crt(new(big.Int).Exp(yi, nInvP, P), new(big.Int).Exp(yi, nInvQ, Q), P, Q, qInv)
+	}
+	return proof, nil
+}
+
+// Verify that a Paillier modulus is a Paillier-Blum modulus
+// [CGGMP21] fig 16
+func (p *ModProof) Verify(params *ModVerifyParams) error {
+	if p == nil || params == nil || params.PublicKey == nil || params.PublicKey.N == nil || params.Pi == 0 {
+		return internal.ErrNilArguments
+	}
+	if p.W == nil ||
+		len(p.X) != ModProofLength ||
+		len(p.A) != ModProofLength ||
+		len(p.B) != ModProofLength ||
+		len(p.Z) != ModProofLength {
+		return fmt.Errorf("invalid mod proof")
+	}
+	n := params.PublicKey.N
+
+	// N must be an odd composite
+	if n.Bit(0) != 1 || n.ProbablyPrime(20) {
+		return fmt.Errorf("paillier modulus is not an odd composite")
+	}
+	if err := crypto.In(p.W, n); err != nil {
+		return err
+	}
+	if big.Jacobi(p.W, n) != -1 {
+		return fmt.Errorf("invalid mod proof")
+	}
+
+	y, err := modChallenges(n, p.W, params.Pi, params.Sid)
+	if err != nil {
+		return err
+	}
+	for i, yi := range y {
+		if p.X[i] == nil || p.Z[i] == nil {
+			return internal.ErrNilArguments
+		}
+		// z_i^N = y_i mod N
+		if new(big.Int).Exp(p.Z[i], n, n).Cmp(yi) != 0 {
+			return fmt.Errorf("invalid mod proof at %d", i)
+		}
+		// x_i^4 = (-1)^a_i w^b_i y_i mod N
+		rhs := new(big.Int).Set(yi)
+		if p.A[i] {
+			rhs.Sub(n, rhs)
+		}
+		if p.B[i] {
+			rhs.Mul(rhs, p.W)
+			rhs.Mod(rhs, n)
+		}
+		if new(big.Int).Exp(p.X[i], big.NewInt(4), n).Cmp(rhs) != 0 {
+			return fmt.Errorf("invalid mod proof at %d", i)
+		}
+	}
+	return nil
+}
+
+// modChallenges computes the deterministic challenges y_i ∈ Z_N* of ModProof
+func modChallenges(n, w *big.Int, pi uint32, sid []byte) ([]*big.Int, error) {
+	return challengesModN(n, ModProofLength, n, w, new(big.Int).SetUint64(uint64(pi)), sidInt(sid))
+}
+
+// sidInt encodes a session id as an integer for hashing. The leading one keeps leading zero bytes of sid.
+func sidInt(sid []byte) *big.Int {
+	return new(big.Int).SetBytes(append([]byte{1}, sid...))
+}
+
+// challengesModN computes count deterministic elements of Z_N* from the Fiat-Shamir hash of values
+func challengesModN(n *big.Int, count int, values ...*big.Int) ([]*big.Int, error) {
+	b := n.BitLen()
+	if b < 8 {
+		return nil, internal.ErrNilArguments
+	}
+	// number of hash outputs needed to fill b bits
+	const h = 256
+	s := (b + h - 1) / h
+
+	x := make([]*big.Int, 0, count)
+	m := big.NewInt(0)
+	inputs := make([]*big.Int, len(values)+3)
+	copy(inputs, values)
+	for j := int64(0); len(x) < count; {
+		var e []byte
+		for k := int64(1); k <= int64(s); k++ {
+			inputs[len(values)] = big.NewInt(j)
+			inputs[len(values)+1] = big.NewInt(k)
+			inputs[len(values)+2] = m
+			res, err := crypto.FiatShamir(inputs...)
+			if err != nil {
+				return nil, err
+			}
+			e = append(e, res...)
+		}
+		// truncate to b bits
+		xj := new(big.Int).SetBytes(e)
+		xj.Rsh(xj, uint(len(e)*8-b))
+
+		if xj.Sign() == 1 && xj.Cmp(n) == -1 && new(big.Int).GCD(nil, nil, xj, n).Cmp(crypto.One) == 0 {
+			x = append(x, xj)
+			j++
+			m = big.NewInt(0)
+		} else {
+			m = new(big.Int).Add(m, crypto.One)
+		}
+	}
+	return x, nil
+}
+
+// primes recovers the factors P and Q of N from N and φ(N)
+func (sk *SecretKey) primes() (*big.Int, *big.Int, error) {
+	if sk.N == nil || sk.Totient == nil {
+		return nil, nil, internal.ErrNilArguments
+	}
+	// P + Q = N - φ(N) + 1 and (P - Q)^2 = (P + Q)^2 - 4N
+	sum := new(big.Int).Sub(sk.N, sk.Totient)
+	sum.Add(sum, crypto.One)
+	diff := new(big.Int).Mul(sum, sum)
+	diff.Sub(diff, new(big.Int).Lsh(sk.N, 2))
+	if diff.Sign() < 0 {
+		return nil, nil, fmt.Errorf("invalid paillier secret key")
+	}
+	diff.Sqrt(diff)
+	p := new(big.Int).Add(sum, diff)
+	p.Rsh(p, 1)
+	q := new(big.Int).Sub(sum, diff)
+	q.Rsh(q, 1)
+	if new(big.Int).Mul(p, q).Cmp(sk.N) != 0 {
+		return nil, nil, fmt.Errorf("invalid paillier secret key")
+	}
+	return p, q, nil
+}
+
+// fourthRootExponent returns ((P+1)/4)^2 mod (P-1), which maps a quadratic residue mod P = 3 mod 4
+// to its fourth root that is a quadratic residue
+func fourthRootExponent(p *big.Int) *big.Int {
+	e := new(big.Int).Add(p, crypto.One)
+	e.Rsh(e, 2)
+	e.Mul(e, e)
+	return e.Mod(e, new(big.Int).Sub(p, crypto.One))
+}
+
+// crt returns x mod PQ with x = xP mod P and x = xQ mod Q, given qInv = Q^-1 mod P
+func crt(xP, xQ, p, q, qInv *big.Int) *big.Int {
+	h := new(big.Int).Sub(xP, xQ)
+	h.Mul(h, qInv)
+	h.Mod(h, p)
+	h.Mul(h, q)
+	return h.Add(h, xQ)
+}
@@ -0,0 +1,118 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package paillier
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	crypto "github.com/TEENet-io/kryptology/pkg/core"
+)
+
+func TestModProofWorks(t *testing.T) {
+	for i := 0; i < 3; i++ {
+		sk, err := NewSecretKey(testPrimes[i], testPrimes[i+1])
+		require.NoError(t, err)
+		pi := uint32(i + 1)
+		proof, err := (&ModProofParams{SecretKey: sk, Pi: pi}).Prove()
+		require.NoError(t, err)
+		require.NoError(t, proof.Verify(&ModVerifyParams{PublicKey: &sk.PublicKey, Pi: pi}))
+
+		// Proofs are bound to the prover id
+		require.Error(t, proof.Verify(&ModVerifyParams{PublicKey: &sk.PublicKey, Pi: pi + 1}))
+
+		// Proofs survive serialization
+		bytes, err := json.Marshal(proof)
+		require.NoError(t, err)
+		unmarshaled := new(ModProof)
+		require.NoError(t, json.Unmarshal(bytes, unmarshaled))
+		require.NoError(t, unmarshaled.Verify(&ModVerifyParams{PublicKey: &sk.PublicKey, Pi: pi}))
+	}
+}
+
+func TestModProofSid(t *testing.T) {
+	sk, err := NewSecretKey(testPrimes[0], testPrimes[1])
+	require.NoError(t, err)
+	sid := []byte("session")
+	proof, err := (&ModProofParams{SecretKey: sk, Pi: 1, Sid: sid}).Prove()
+	require.NoError(t, err)
+	require.NoError(t, proof.Verify(&ModVerifyParams{PublicKey: &sk.PublicKey, Pi: 1, Sid: sid}))
+	require.Error(t, proof.Verify(&ModVerifyParams{PublicKey: &sk.PublicKey, Pi: 1}))
+	// Leading zero bytes are part of the session id
+	require.Error(t, proof.Verify(&ModVerifyParams{PublicKey: &sk.PublicKey, Pi: 1, Sid: append([]byte{0}, sid...)}))
+}
+
+func TestModProofPrimes(t *testing.T) {
+	sk, err := NewSecretKey(testPrimes[0], testPrimes[1])
+	require.NoError(t, err)
+	p, q, err := sk.primes()
+	require.NoError(t, err)
+	require.Equal(t, testPrimes[0], p)
+	require.Equal(t, testPrimes[1], q)
+}
+
+func TestModProofInvalidModulus(t *testing.T) {
+	// 13 is 1 mod 4
+	sk, err := NewSecretKey(big.NewInt(13), testPrimes[0])
+	require.NoError(t, err)
+	_, err = (&ModProofParams{SecretKey: sk, Pi: 1}).Prove()
+	require.Error(t, err)
+
+	// A proof for one modulus does not verify for another
+	sk, err = NewSecretKey(testPrimes[0], testPrimes[1])
+	require.NoError(t, err)
+	proof, err := (&ModProofParams{SecretKey: sk, Pi: 1}).Prove()
+	require.NoError(t, err)
+	other, err := NewPubkey(new(big.Int).Mul(testPrimes[2], testPrimes[3]))
+	require.NoError(t, err)
+	require.Error(t, proof.Verify(&ModVerifyParams{PublicKey: other, Pi: 1}))
+
+	// A prime modulus is rejected
+	prime, err := NewPubkey(testPrimes[0])
+	require.NoError(t, err)
+	require.Error(t, proof.Verify(&ModVerifyParams{PublicKey: prime, Pi: 1}))
+}
+
+func TestModProofTampered(t *testing.T) {
+	sk, err := NewSecretKey(testPrimes[0], testPrimes[1])
+	require.NoError(t, err)
+	params := &ModVerifyParams{PublicKey: &sk.PublicKey, Pi: 1}
+	proof, err := (&ModProofParams{SecretKey: sk, Pi: 1}).Prove()
+	require.NoError(t, err)
+
+	tampered := *proof
+	tampered.X = append([]*big.Int{}, proof.X...)
+	tampered.X[3] = new(big.Int).Add(proof.X[3], crypto.One)
+	require.Error(t, tampered.Verify(params))
+
+	tampered = *proof
+	tampered.Z = append([]*big.Int{}, proof.Z...)
+	tampered.Z[0] = new(big.Int).Add(proof.Z[0], crypto.One)
+	require.Error(t, tampered.Verify(params))
+
+	tampered = *proof
+	tampered.A = append([]bool{}, proof.A...)
+	tampered.A[5] = !tampered.A[5]
+	require.Error(t, tampered.Verify(params))
+
+	tampered = *proof
+	tampered.Z = proof.Z[1:]
+	require.Error(t, tampered.Verify(params))
+
+	tampered = *proof
+	tampered.W = nil
+	require.Error(t, tampered.Verify(params))
+
+	require.Error(t, proof.Verify(nil))
+	_, err = (&ModProofParams{Pi: 1}).Prove()
+	require.Error(t, err)
+	_, err = (&ModProofParams{SecretKey: sk}).Prove()
+	require.Error(t, err)
+}
@@ -12,9 +12,10 @@
 // This module provides APIs for:
 //
 //   - generating a safe keypair,
-//   - encryption and decryption,
-//   - adding two encrypted values, Enc(a) and Enc(b), and obtaining Enc(a + b), and
-//   - multiplying a plain value, a, and an encrypted value Enc(b), and obtaining Enc(a * b).
+//   - encryption and decryption, with CRT decryption and nonces precomputed by PublicKey.Precompute,
+//   - adding two encrypted values, Enc(a) and Enc(b), and obtaining Enc(a + b),
+//   - multiplying a plain value, a, and an encrypted value Enc(b), and obtaining Enc(a * b), and
+//   - threshold decryption, where any t of n key shares decrypt with verifiable partial decryptions.
 //
 // The encrypted values are represented as big.Int and are serializable. This module also provides
 // JSON serialization for the PublicKey and the SecretKey.
@@ -37,8 +38,9 @@ const PaillierPrimeBits = 1024
 type (
 	// PublicKey is a Paillier public key: N = P*Q; for safe primes P,Q.
 	PublicKey struct {
-		N  *big.Int // N = PQ
-		N2 *big.Int // N² computed and cached to prevent re-computation.
+		N      *big.Int   // N = PQ
+		N2     *big.Int   // N² computed and cached to prevent re-computation.
+		nonces *noncePool // r^N precomputed for encryption by Precompute
 	}
 
 	// PublicKeyJson encapsulates the data that is serialized to JSON.
@@ -54,6 +56,7 @@ type (
 		Lambda  *big.Int // lcm(P - 1, Q - 1)
 		Totient *big.Int // Euler's totient: (P - 1) * (Q - 1)
 		U       *big.Int // L((N + 1)^λ(N) mod N²)−1 mod N
+		crt     *crtKey  // P and Q for decryption mod P² and Q², when known
 	}
 
 	// SecretKeyJson encapsulates the data that is serialized to JSON.
@@ -143,7 +146,9 @@ func NewSecretKey(p, q *big.Int) (*SecretKey, error) {
 	// L((N+1)^λ(N) mod N²)^-1 mod N
 	u.ModInverse(u, n)
 
-	return &SecretKey{pk, lambda, totient, u}, nil
+	// Decrypt falls back to λ(N) when P and Q are not coprime
+	crt, _ := newCrtKey(p, q)
+	return &SecretKey{pk, lambda, totient, u, crt}, nil
 }
 
 // MarshalJSON converts the public key into json format.
@@ -273,7 +278,13 @@ func (pk *PublicKey) Mul(a *big.Int, c Ciphertext) (Ciphertext, error) {
 }
 
 // Encrypt produces a ciphertext on input message.
+// It uses a nonce precomputed by Precompute when one is available.
 func (pk *PublicKey) Encrypt(msg *big.Int) (Ciphertext, *big.Int, error) {
+	if n := pk.noncePool(false).take(); n != nil {
+		ct, err := pk.encryptWithRN(msg, n.rN)
+		return ct, n.r, err
+	}
+
 	// generate a nonce: r \in Z**_N
 	r, err := core.Rand(pk.N)
 	if err != nil {
@@ -305,11 +316,24 @@ func (pk *PublicKey) encrypt(msg, r *big.Int) (Ciphertext, error) {
 		return nil, fmt.Errorf("r cannot be 0")
 	}
 
-	// Compute the ciphertext components: ɑ, β
-	// ɑ = (N+1)^m (mod N²)
-	ɑ := new(big.Int).Add(pk.N, core.One)
-	ɑ.Exp(ɑ, msg, pk.N2)
 	β := new(big.Int).Exp(r, pk.N, pk.N2) // β = r^N (mod N²)
+	return pk.encryptWithRN(msg, β)
+}
+
+// encryptWithRN produces a ciphertext on input a message and β = r^N mod N² for a nonce r.
+func (pk *PublicKey) encryptWithRN(msg, β *big.Int) (Ciphertext, error) {
+	if msg == nil || β == nil {
+		return nil, internal.ErrNilArguments
+	}
+	// Ensure msg ∈ Z_N
+	if err := core.In(msg, pk.N); err != nil {
+		return nil, err
+	}
+
+	// Compute the ciphertext components: ɑ, β
+	// ɑ = (N+1)^m = 1 + mN (mod N²)
+	ɑ := new(big.Int).Mul(msg, pk.N)
+	ɑ.Add(ɑ, core.One)
 
 	// ciphertext = ɑ*β = (N+1)^m * r^N  (mod N²)
 	c, err := core.Mul(ɑ, β, pk.N2)
@@ -331,6 +355,10 @@ func (sk *SecretKey) Decrypt(c Ciphertext) (*big.Int, error) {
 		return nil, err
 	}
 
+	if sk.crt != nil {
+		return sk.crt.decrypt(c)
+	}
+
 	// Compute the msg in components
 	// ɑ ≡ c^{λ(N)}		mod N²
 	ɑ := new(big.Int).Exp(c, sk.Lambda, sk.N2)
@@ -374,5 +402,9 @@ func (sk *SecretKey) UnmarshalJSON(bytes []byte) error {
 	sk.U = data.U
 	sk.Totient = data.Totient
 	sk.Lambda = data.Lambda
+	sk.crt = nil
+	if p, q, err := sk.primes(); err == nil {
+		sk.crt, _ = newCrtKey(p, q)
+	}
 	return nil
 }
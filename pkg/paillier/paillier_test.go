@@ -908,7 +908,7 @@ func TestDecryptErrorConditions(t *testing.T) {
 	pk, err := NewPubkey(N)
 	require.NoError(t, err)
 	// A fake secret key, but good enough to test parameter validation
-	sk := &SecretKey{*pk, NplusOne, NplusOne, NplusOne}
+	sk := &SecretKey{PublicKey: *pk, Lambda: NplusOne, Totient: NplusOne, U: NplusOne}
 
 	var tests = []struct {
 		c            *big.Int
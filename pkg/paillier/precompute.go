@@ -0,0 +1,176 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// This file contains the precomputations that speed up Paillier decryption and encryption:
+// decryption mod P² and Q² with the Chinese Remainder Theorem [P99] §7, and nonces r^N mod N²
+// computed ahead of encryption.
+
+package paillier
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/TEENet-io/kryptology/internal"
+	crypto "github.com/TEENet-io/kryptology/pkg/core"
+)
+
+// crtKey holds the values for decryption mod P² and Q²
+type crtKey struct {
+	p, q             *big.Int
+	pp, qq           *big.Int // P², Q²
+	pMinus1, qMinus1 *big.Int
+	hp, hq           *big.Int // L_P((N+1)^{P-1} mod P²)^-1 mod P and likewise for Q
+	qInv             *big.Int // Q^-1 mod P
+}
+
+// newCrtKey precomputes the values for decryption mod P² and Q²
+func newCrtKey(p, q *big.Int) (*crtKey, error) {
+	if p == nil || q == nil {
+		return nil, internal.ErrNilArguments
+	}
+	qInv := new(big.Int).ModInverse(q, p)
+	if qInv == nil {
+		return nil, fmt.Errorf("p and q must be coprime")
+	}
+	g := new(big.Int).Mul(p, q)
+	g.Add(g, crypto.One)
+	k := &crtKey{
+		p:       p,
+		q:       q,
+		pp:      new(big.Int).Mul(p, p),
+		qq:      new(big.Int).Mul(q, q),
+		pMinus1: new(big.Int).Sub(p, crypto.One),
+		qMinus1: new(big.Int).Sub(q, crypto.One),
+		qInv:    qInv,
+	}
+	var err error
+	if k.hp, err = crtH(g, p, k.pp, k.pMinus1); err != nil {
+		return nil, err
+	}
+	if k.hq, err = crtH(g, q, k.qq, k.qMinus1); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// crtH returns L_p(g^{p-1} mod p²)^-1 mod p
+func crtH(g, p, pp, pMinus1 *big.Int) (*big.Int, error) {
+	l, err := crtL(new(big.Int).Exp(g, pMinus1, pp), p)
+	if err != nil {
+		return nil, err
+	}
+	if l.ModInverse(l, p) == nil {
+		return nil, fmt.Errorf("invalid paillier primes")
+	}
+	return l, nil
+}
+
+// crtL computes (x - 1) / p for x = 1 mod p
+func crtL(x, p *big.Int) (*big.Int, error) {
+	l := new(big.Int).Sub(x, crypto.One)
+	l, rem := l.QuoRem(l, p, new(big.Int))
+	if rem.Sign() != 0 {
+		return nil, internal.ErrResidueOne
+	}
+	return l, nil
+}
+
+// decrypt computes m_P = L_P(c^{P-1} mod P²) h_P mod P and m_Q likewise, and returns
+// m = m_P mod P and m = m_Q mod Q
+func (k *crtKey) decrypt(c Ciphertext) (*big.Int, error) {
+	mp, err := crtL(new(big.Int).Exp(c, k.pMinus1, k.pp), k.p)
+	if err != nil {
+		return nil, err
+	}
+	mp.Mul(mp, k.hp)
+	mp.Mod(mp, k.p)
+	mq, err := crtL(new(big.Int).Exp(c, k.qMinus1, k.qq), k.q)
+	if err != nil {
+		return nil, err
+	}
+	mq.Mul(mq, k.hq)
+	mq.Mod(mq, k.q)
+	return crt(mp, mq, k.p, k.q, k.qInv), nil
+}
+
+// nonce is a random r ∈ Z_N^* with r^N mod N²
+type nonce struct {
+	r, rN *big.Int
+}
+
+// noncePool holds the nonces computed by PublicKey.Precompute
+type noncePool struct {
+	sync.Mutex
+	nonces []*nonce
+}
+
+// noncePoolsMu guards the lazy creation of the nonce pool of every PublicKey. A package-level lock
+// keeps PublicKey copyable, which SecretKey and the callers that dereference keys rely on.
+var noncePoolsMu sync.Mutex
+
+// noncePool returns the nonce pool of pk, creating it when create is set and pk has none yet.
+// It returns nil when pk has no pool and create is not set.
+func (pk *PublicKey) noncePool(create bool) *noncePool {
+	noncePoolsMu.Lock()
+	defer noncePoolsMu.Unlock()
+	if pk.nonces == nil && create {
+		pk.nonces = new(noncePool)
+	}
+	return pk.nonces
+}
+
+// take removes a nonce from the pool, or returns nil when it is empty
+func (pool *noncePool) take() *nonce {
+	if pool == nil {
+		return nil
+	}
+	pool.Lock()
+	defer pool.Unlock()
+	if len(pool.nonces) == 0 {
+		return nil
+	}
+	n := pool.nonces[len(pool.nonces)-1]
+	pool.nonces[len(pool.nonces)-1] = nil
+	pool.nonces = pool.nonces[:len(pool.nonces)-1]
+	return n
+}
+
+// Precompute computes count nonces r^N mod N² ahead of time, e.g. while the parties of a protocol
+// wait for messages. Encrypt takes one of them instead of computing r^N.
+// It is safe to call concurrently with Encrypt and with other calls of Precompute.
+func (pk *PublicKey) Precompute(count int) error {
+	if pk.N == nil || pk.N2 == nil {
+		return internal.ErrNilArguments
+	}
+	if count < 0 {
+		return fmt.Errorf("count must not be negative, got %d", count)
+	}
+	nonces := make([]*nonce, count)
+	for i := range nonces {
+		r, err := crypto.Rand(pk.N)
+		if err != nil {
+			return err
+		}
+		nonces[i] = &nonce{r: r, rN: new(big.Int).Exp(r, pk.N, pk.N2)}
+	}
+	pool := pk.noncePool(true)
+	pool.Lock()
+	pool.nonces = append(pool.nonces, nonces...)
+	pool.Unlock()
+	return nil
+}
+
+// Precomputed returns the number of nonces that are ready for encryption
+func (pk *PublicKey) Precomputed() int {
+	pool := pk.noncePool(false)
+	if pool == nil {
+		return 0
+	}
+	pool.Lock()
+	defer pool.Unlock()
+	return len(pool.nonces)
+}
@@ -0,0 +1,165 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package paillier
+
+import (
+	"encoding/json"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	crypto "github.com/TEENet-io/kryptology/pkg/core"
+)
+
+// withoutCrt returns a copy of sk that decrypts with λ(N)
+func withoutCrt(sk *SecretKey) *SecretKey {
+	return &SecretKey{PublicKey: sk.PublicKey, Lambda: sk.Lambda, Totient: sk.Totient, U: sk.U}
+}
+
+func TestDecryptCrt(t *testing.T) {
+	sk, err := NewSecretKey(testPrimes[0], testPrimes[1])
+	require.NoError(t, err)
+	require.NotNil(t, sk.crt)
+	lambda := withoutCrt(sk)
+	for _, msg := range []*big.Int{crypto.Zero, crypto.One, big.NewInt(123456789), new(big.Int).Sub(sk.N, crypto.One)} {
+		c, _, err := sk.Encrypt(msg)
+		require.NoError(t, err)
+		m, err := sk.Decrypt(c)
+		require.NoError(t, err)
+		require.Equal(t, 0, msg.Cmp(m))
+		m, err = lambda.Decrypt(c)
+		require.NoError(t, err)
+		require.Equal(t, 0, msg.Cmp(m))
+	}
+
+	// c is not a unit
+	_, err = sk.Decrypt(testPrimes[0])
+	require.Error(t, err)
+}
+
+func TestSecretKeyJsonCrt(t *testing.T) {
+	sk, err := NewSecretKey(testPrimes[0], testPrimes[1])
+	require.NoError(t, err)
+	bytes, err := json.Marshal(sk)
+	require.NoError(t, err)
+	unmarshaled := new(SecretKey)
+	require.NoError(t, json.Unmarshal(bytes, unmarshaled))
+	require.Equal(t, sk.crt, unmarshaled.crt)
+
+	c, _, err := sk.Encrypt(big.NewInt(42))
+	require.NoError(t, err)
+	m, err := unmarshaled.Decrypt(c)
+	require.NoError(t, err)
+	require.Equal(t, int64(42), m.Int64())
+}
+
+func TestPrecompute(t *testing.T) {
+	sk, err := NewSecretKey(testPrimes[0], testPrimes[1])
+	require.NoError(t, err)
+	pk, err := NewPubkey(sk.N)
+	require.NoError(t, err)
+	require.Equal(t, 0, pk.Precomputed())
+	require.NoError(t, pk.Precompute(3))
+	require.NoError(t, pk.Precompute(2))
+	require.Equal(t, 5, pk.Precomputed())
+
+	nonces := make(map[string]bool)
+	for i := int64(0); i < 7; i++ {
+		msg := big.NewInt(1000 + i)
+		c, r, err := pk.Encrypt(msg)
+		require.NoError(t, err)
+		require.False(t, nonces[r.String()])
+		nonces[r.String()] = true
+
+		// The ciphertext is the encryption of msg with nonce r
+		expected, err := pk.encrypt(msg, r)
+		require.NoError(t, err)
+		require.Equal(t, expected, c)
+		m, err := sk.Decrypt(c)
+		require.NoError(t, err)
+		require.Equal(t, 0, msg.Cmp(m))
+	}
+	require.Equal(t, 0, pk.Precomputed())
+
+	require.Error(t, (&PublicKey{}).Precompute(1))
+	require.Error(t, pk.Precompute(-1))
+	require.NoError(t, pk.Precompute(0))
+	require.Equal(t, 0, pk.Precomputed())
+}
+
+func TestPrecomputeConcurrent(t *testing.T) {
+	pk, err := NewPubkey(new(big.Int).Mul(testPrimes[0], testPrimes[1]))
+	require.NoError(t, err)
+	msg := big.NewInt(42)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			require.NoError(t, pk.Precompute(2))
+		}()
+		go func() {
+			defer wg.Done()
+			_, _, err := pk.Encrypt(msg)
+			require.NoError(t, err)
+			_ = pk.Precomputed()
+		}()
+	}
+	wg.Wait()
+	require.LessOrEqual(t, 4, pk.Precomputed())
+}
+
+func BenchmarkDecrypt(b *testing.B) {
+	sk, err := NewSecretKey(testPrimes[0], testPrimes[1])
+	require.NoError(b, err)
+	c, _, err := sk.Encrypt(big.NewInt(42))
+	require.NoError(b, err)
+
+	b.Run("CRT", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, err := sk.Decrypt(c)
+			require.NoError(b, err)
+		}
+	})
+	b.Run("Lambda", func(b *testing.B) {
+		lambda := withoutCrt(sk)
+		for i := 0; i < b.N; i++ {
+			_, err := lambda.Decrypt(c)
+			require.NoError(b, err)
+		}
+	})
+}
+
+func BenchmarkEncrypt(b *testing.B) {
+	pk, err := NewPubkey(new(big.Int).Mul(testPrimes[0], testPrimes[1]))
+	require.NoError(b, err)
+	msg := big.NewInt(42)
+
+	b.Run("Fresh nonce", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _, err := pk.Encrypt(msg)
+			require.NoError(b, err)
+		}
+	})
+	b.Run("Precomputed nonce", func(b *testing.B) {
+		// Computing b.N nonces would take as long as the fresh encryptions, so one nonce is
+		// put back into the pool before each encryption
+		require.NoError(b, pk.Precompute(1))
+		n := pk.nonces.take()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			pk.nonces.nonces = append(pk.nonces.nonces, n)
+			b.StartTimer()
+			_, _, err := pk.Encrypt(msg)
+			require.NoError(b, err)
+		}
+	})
+}
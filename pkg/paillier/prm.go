@@ -0,0 +1,183 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// This file contains Ring-Pedersen parameters and proofs that they are well formed: [CGGMP21] fig 17
+// https://eprint.iacr.org/2021/060.pdf
+
+package paillier
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/TEENet-io/kryptology/internal"
+	crypto "github.com/TEENet-io/kryptology/pkg/core"
+)
+
+// PrmProofLength is the number of repetitions m in Πprm, for a soundness error of 2^-PrmProofLength
+const PrmProofLength = 80
+
+// RingPedersenParams are the public parameters of Ring-Pedersen commitments s^m t^r mod N,
+// where s = t^λ mod N for a secret λ.
+// These are the N, H1 = t and H2 = s of [spec] dealer.ProofParams.
+type RingPedersenParams struct {
+	N *big.Int
+	S *big.Int
+	T *big.Int
+}
+
+// NewRingPedersenParams creates Ring-Pedersen parameters over the modulus of sk,
+// and returns them with the secret λ used to prove they are well formed
+func NewRingPedersenParams(sk *SecretKey) (*RingPedersenParams, *big.Int, error) {
+	if sk == nil || sk.N == nil || sk.Totient == nil {
+		return nil, nil, internal.ErrNilArguments
+	}
+	// t = r^2 mod N is a random quadratic residue
+	r, err := crypto.Rand(sk.N)
+	if err != nil {
+		return nil, nil, err
+	}
+	t := new(big.Int).Exp(r, two, sk.N)
+	lambda, err := crypto.Rand(sk.Totient)
+	if err != nil {
+		return nil, nil, err
+	}
+	s := new(big.Int).Exp(t, lambda, sk.N)
+	return &RingPedersenParams{N: sk.N, S: s, T: t}, lambda, nil
+}
+
+// PrmProofParams contains the inputs to ProvePrm
+type PrmProofParams struct {
+	RingPedersen *RingPedersenParams
+	// Lambda is the discrete log of S to the base T
+	Lambda *big.Int
+	// Totient is φ(N)
+	Totient *big.Int
+	Pi      uint32
+	// Sid is the session the proof is bound to, see ModProofParams
+	Sid []byte
+}
+
+// PrmVerifyParams contains the inputs to VerifyPrm
+type PrmVerifyParams struct {
+	RingPedersen *RingPedersenParams
+	Pi           uint32
+	Sid          []byte
+}
+
+// PrmProof proves knowledge of λ with s = t^λ mod N, i.e. that s is in the group generated by t
+type PrmProof struct {
+	A []*big.Int
+	Z []*big.Int
+}
+
+// Prove that Ring-Pedersen parameters are well formed
+// [CGGMP21] fig 17
+func (p *PrmProofParams) Prove() (*PrmProof, error) {
+	if p.RingPedersen == nil ||
+		crypto.AnyNil(p.RingPedersen.N, p.RingPedersen.S, p.RingPedersen.T, p.Lambda, p.Totient) ||
+		p.Pi == 0 {
+		return nil, internal.ErrNilArguments
+	}
+	rp := p.RingPedersen
+
+	// 1. Sample a_i ← Z_φ(N) and compute A_i = t^a_i mod N
+	a := make([]*big.Int, PrmProofLength)
+	proof := &PrmProof{
+		A: make([]*big.Int, PrmProofLength),
+		Z: make([]*big.Int, PrmProofLength),
+	}
+	for i := range a {
+		ai, err := crypto.Rand(p.Totient)
+		if err != nil {
+			return nil, err
+		}
+		a[i] = ai
+		proof.A[i] = new(big.Int).Exp(rp.T, ai, rp.N)
+	}
+
+	// 2. e_i ← FS-HASH(N, s, t, A_1, ..., A_m, Pi, Sid)
+	e, err := prmChallenge(rp, proof.A, p.Pi, p.Sid)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. z_i = a_i + e_i λ mod φ(N)
+	for i, ai := range a {
+		zi := new(big.Int).Set(ai)
+		if e.Bit(i) == 1 {
+			zi.Add(zi, p.Lambda)
+			zi.Mod(zi, p.Totient)
+		}
+		proof.Z[i] = zi
+	}
+	return proof, nil
+}
+
+// Verify that Ring-Pedersen parameters are well formed
+// [CGGMP21] fig 17
+func (p *PrmProof) Verify(params *PrmVerifyParams) error {
+	if p == nil || params == nil || params.RingPedersen == nil || params.Pi == 0 {
+		return internal.ErrNilArguments
+	}
+	rp := params.RingPedersen
+	if err := rp.validate(); err != nil {
+		return err
+	}
+	if len(p.A) != PrmProofLength || len(p.Z) != PrmProofLength || crypto.AnyNil(p.A...) || crypto.AnyNil(p.Z...) {
+		return fmt.Errorf("invalid prm proof")
+	}
+
+	e, err := prmChallenge(rp, p.A, params.Pi, params.Sid)
+	if err != nil {
+		return err
+	}
+	for i := range p.A {
+		// t^z_i = A_i s^e_i mod N
+		lhs := new(big.Int).Exp(rp.T, p.Z[i], rp.N)
+		rhs := new(big.Int).Mod(p.A[i], rp.N)
+		if e.Bit(i) == 1 {
+			rhs.Mul(rhs, rp.S)
+			rhs.Mod(rhs, rp.N)
+		}
+		if lhs.Cmp(rhs) != 0 {
+			return fmt.Errorf("invalid prm proof at %d", i)
+		}
+	}
+	return nil
+}
+
+// validate checks that s and t are units modulo an odd composite N and that t is not trivial
+func (rp *RingPedersenParams) validate() error {
+	if crypto.AnyNil(rp.N, rp.S, rp.T) {
+		return internal.ErrNilArguments
+	}
+	if rp.N.Bit(0) != 1 || rp.N.ProbablyPrime(20) {
+		return fmt.Errorf("ring-pedersen modulus is not an odd composite")
+	}
+	for _, v := range []*big.Int{rp.S, rp.T} {
+		if err := crypto.In(v, rp.N); err != nil {
+			return err
+		}
+		if new(big.Int).GCD(nil, nil, v, rp.N).Cmp(crypto.One) != 0 {
+			return fmt.Errorf("ring-pedersen parameter is not a unit")
+		}
+	}
+	if rp.T.Cmp(crypto.One) == 0 || new(big.Int).Sub(rp.N, rp.T).Cmp(crypto.One) == 0 {
+		return fmt.Errorf("ring-pedersen parameter t generates a trivial group")
+	}
+	return nil
+}
+
+// prmChallenge computes the Fiat-Shamir challenge of PrmProof, whose first PrmProofLength bits are the e_i
+func prmChallenge(rp *RingPedersenParams, a []*big.Int, pi uint32, sid []byte) (*big.Int, error) {
+	values := append([]*big.Int{rp.N, rp.S, rp.T}, a...)
+	values = append(values, new(big.Int).SetUint64(uint64(pi)), sidInt(sid))
+	e, err := crypto.FiatShamir(values...)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(e), nil
+}
@@ -0,0 +1,77 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package paillier
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	crypto "github.com/TEENet-io/kryptology/pkg/core"
+)
+
+func TestPrmProofWorks(t *testing.T) {
+	sk, err := NewSecretKey(testPrimes[0], testPrimes[1])
+	require.NoError(t, err)
+	rp, lambda, err := NewRingPedersenParams(sk)
+	require.NoError(t, err)
+	require.Equal(t, sk.N, rp.N)
+	require.Equal(t, rp.S, new(big.Int).Exp(rp.T, lambda, rp.N))
+
+	proof, err := (&PrmProofParams{RingPedersen: rp, Lambda: lambda, Totient: sk.Totient, Pi: 2}).Prove()
+	require.NoError(t, err)
+	require.NoError(t, proof.Verify(&PrmVerifyParams{RingPedersen: rp, Pi: 2}))
+	require.Error(t, proof.Verify(&PrmVerifyParams{RingPedersen: rp, Pi: 1}))
+	require.Error(t, proof.Verify(&PrmVerifyParams{RingPedersen: rp, Pi: 2, Sid: []byte("other")}))
+
+	bytes, err := json.Marshal(proof)
+	require.NoError(t, err)
+	unmarshaled := new(PrmProof)
+	require.NoError(t, json.Unmarshal(bytes, unmarshaled))
+	require.NoError(t, unmarshaled.Verify(&PrmVerifyParams{RingPedersen: rp, Pi: 2}))
+}
+
+func TestPrmProofInvalidParams(t *testing.T) {
+	sk, err := NewSecretKey(testPrimes[0], testPrimes[1])
+	require.NoError(t, err)
+	rp, lambda, err := NewRingPedersenParams(sk)
+	require.NoError(t, err)
+
+	// s outside of the group generated by t cannot be proven
+	bad := *rp
+	bad.S = new(big.Int).Add(rp.S, crypto.One)
+	proof, err := (&PrmProofParams{RingPedersen: &bad, Lambda: lambda, Totient: sk.Totient, Pi: 1}).Prove()
+	require.NoError(t, err)
+	require.Error(t, proof.Verify(&PrmVerifyParams{RingPedersen: &bad, Pi: 1}))
+
+	proof, err = (&PrmProofParams{RingPedersen: rp, Lambda: lambda, Totient: sk.Totient, Pi: 1}).Prove()
+	require.NoError(t, err)
+	for _, invalid := range []*RingPedersenParams{
+		{N: rp.N, S: rp.S, T: crypto.One},
+		{N: rp.N, S: rp.N, T: rp.T},
+		{N: testPrimes[0], S: rp.S, T: rp.T},
+		{N: rp.N, S: nil, T: rp.T},
+	} {
+		require.Error(t, proof.Verify(&PrmVerifyParams{RingPedersen: invalid, Pi: 1}))
+	}
+
+	tampered := *proof
+	tampered.Z = append([]*big.Int{}, proof.Z...)
+	tampered.Z[7] = new(big.Int).Add(proof.Z[7], crypto.One)
+	require.Error(t, tampered.Verify(&PrmVerifyParams{RingPedersen: rp, Pi: 1}))
+
+	tampered = *proof
+	tampered.A = proof.A[1:]
+	require.Error(t, tampered.Verify(&PrmVerifyParams{RingPedersen: rp, Pi: 1}))
+
+	_, err = (&PrmProofParams{RingPedersen: rp, Totient: sk.Totient, Pi: 1}).Prove()
+	require.Error(t, err)
+	_, _, err = NewRingPedersenParams(nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,322 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// This file contains threshold Paillier decryption: [DJ01] §4.1 with s = 1, following Shoup's
+// threshold RSA [S00].
+// [DJ01] A Generalisation, a Simplification and Some Applications of Paillier's Probabilistic
+// Public-Key System. https://www.brics.dk/RS/00/45/BRICS-RS-00-45.pdf
+// [S00] Practical Threshold Signatures. https://www.iacr.org/archive/eurocrypt2000/1807/18070209-new.pdf
+
+package paillier
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/TEENet-io/kryptology/internal"
+	crypto "github.com/TEENet-io/kryptology/pkg/core"
+)
+
+// thresholdChallengeBits is the bit length of the Fiat-Shamir challenges in partial decryption proofs
+const thresholdChallengeBits = 256
+
+// ThresholdPublicKey is the public key of threshold Paillier decryption.
+// Any Threshold of the Limit key shares decrypt ciphertexts of PublicKey, so ciphertexts
+// combined with PublicKey.Add and PublicKey.Mul are decrypted without a single party
+// holding the secret key.
+type ThresholdPublicKey struct {
+	PublicKey *PublicKey
+	Threshold uint32
+	Limit     uint32
+	// V is a random square in Z_N²
+	V *big.Int
+	// VerificationKeys maps each share id i to V^{Δ s_i} mod N², with Δ = Limit!
+	VerificationKeys map[uint32]*big.Int
+}
+
+// ThresholdSecretKeyShare is the share s_i = f(i) mod Nm of the decryption exponent d,
+// where f is a polynomial of degree Threshold - 1 with f(0) = d.
+type ThresholdSecretKeyShare struct {
+	Id    uint32
+	Share *big.Int
+}
+
+// PartialDecryption is the decryption share c^{2Δ s_i} mod N² of a ciphertext c
+type PartialDecryption struct {
+	Id    uint32
+	Value *big.Int
+	Proof *PartialDecryptionProof
+}
+
+// PartialDecryptionProof proves that a partial decryption is computed with the same
+// exponent as the verification key of its share: log_{c^4}(c_i^2) = log_V(V_i)
+type PartialDecryptionProof struct {
+	E *big.Int
+	Z *big.Int
+}
+
+// NewThresholdKeys generates a Paillier key pair and splits its secret key into
+// limit shares, any threshold of which decrypt
+func NewThresholdKeys(threshold, limit uint32) (*ThresholdPublicKey, []*ThresholdSecretKeyShare, error) {
+	_, sk, err := NewKeys()
+	if err != nil {
+		return nil, nil, err
+	}
+	return NewThresholdKeysFromSecretKey(sk, threshold, limit)
+}
+
+// NewThresholdKeysFromSecretKey splits sk into limit shares, any threshold of which decrypt.
+// sk must be made of safe primes. The dealer must discard sk afterwards.
+func NewThresholdKeysFromSecretKey(sk *SecretKey, threshold, limit uint32) (*ThresholdPublicKey, []*ThresholdSecretKeyShare, error) {
+	if sk == nil || sk.N == nil || sk.Totient == nil {
+		return nil, nil, internal.ErrNilArguments
+	}
+	if limit < threshold {
+		return nil, nil, fmt.Errorf("limit cannot be less than threshold")
+	}
+	if threshold < 1 {
+		return nil, nil, fmt.Errorf("threshold cannot be less than 1")
+	}
+	if limit > crypto.MaxShareLimit {
+		return nil, nil, fmt.Errorf("cannot exceed %d shares", crypto.MaxShareLimit)
+	}
+	pk, err := NewPubkey(sk.N)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// m = p'q' for safe primes p = 2p' + 1 and q = 2q' + 1
+	if sk.Totient.Bit(0) != 0 || sk.Totient.Bit(1) != 0 {
+		return nil, nil, fmt.Errorf("paillier secret key is not made of safe primes")
+	}
+	m := new(big.Int).Rsh(sk.Totient, 2)
+	// d = 0 mod m and d = 1 mod N
+	mInv := new(big.Int).ModInverse(m, sk.N)
+	if mInv == nil {
+		return nil, nil, fmt.Errorf("paillier secret key is not made of safe primes")
+	}
+	d := new(big.Int).Mul(m, mInv)
+	nm := new(big.Int).Mul(sk.N, m)
+
+	// f(X) = d + a_1 X + ... + a_{t-1} X^{t-1} mod Nm
+	coefficients := make([]*big.Int, threshold)
+	coefficients[0] = d
+	for i := 1; i < len(coefficients); i++ {
+		if coefficients[i], err = crypto.Rand(nm); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// V = r^2 mod N² generates the squares of Z_N² with overwhelming probability
+	r, err := crypto.Rand(pk.N2)
+	if err != nil {
+		return nil, nil, err
+	}
+	v := new(big.Int).Exp(r, two, pk.N2)
+
+	delta := crypto.Factorial(limit)
+	shares := make([]*ThresholdSecretKeyShare, limit)
+	verificationKeys := make(map[uint32]*big.Int, limit)
+	for i := range shares {
+		id := uint32(i + 1)
+		s := crypto.EvaluatePolynomial(coefficients, big.NewInt(int64(id)), nm)
+		shares[i] = &ThresholdSecretKeyShare{Id: id, Share: s}
+		e := new(big.Int).Mul(delta, s)
+		verificationKeys[id] = new(big.Int).Exp(v, e, pk.N2)
+	}
+	return &ThresholdPublicKey{
+		PublicKey:        pk,
+		Threshold:        threshold,
+		Limit:            limit,
+		V:                v,
+		VerificationKeys: verificationKeys,
+	}, shares, nil
+}
+
+// PartialDecrypt computes the decryption share of c with a proof that it is correct
+func (share *ThresholdSecretKeyShare) PartialDecrypt(pk *ThresholdPublicKey, c Ciphertext) (*PartialDecryption, error) {
+	if share == nil || share.Share == nil || c == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if err := pk.validate(); err != nil {
+		return nil, err
+	}
+	vi, ok := pk.VerificationKeys[share.Id]
+	if !ok {
+		return nil, fmt.Errorf("no verification key for share %d", share.Id)
+	}
+	if err := checkUnit(c, pk.PublicKey); err != nil {
+		return nil, err
+	}
+	n2 := pk.PublicKey.N2
+
+	// c_i = c^{2Δ s_i} mod N²
+	x := new(big.Int).Mul(crypto.Factorial(pk.Limit), share.Share)
+	ci := new(big.Int).Exp(c, new(big.Int).Lsh(x, 1), n2)
+
+	// a = (c^4)^r and b = V^r
+	c4 := new(big.Int).Exp(c, big.NewInt(4), n2)
+	r, err := crypto.Rand(new(big.Int).Lsh(crypto.One, pk.proofBits()))
+	if err != nil {
+		return nil, err
+	}
+	a := new(big.Int).Exp(c4, r, n2)
+	b := new(big.Int).Exp(pk.V, r, n2)
+	e, err := pk.partialDecryptionChallenge(c, ci, vi, a, b)
+	if err != nil {
+		return nil, err
+	}
+	// z = r + eΔs_i
+	z := new(big.Int).Mul(e, x)
+	z.Add(z, r)
+	return &PartialDecryption{
+		Id:    share.Id,
+		Value: ci,
+		Proof: &PartialDecryptionProof{E: e, Z: z},
+	}, nil
+}
+
+// Verify checks that pd is the decryption share of c for the verification key of pd.Id
+func (pd *PartialDecryption) Verify(pk *ThresholdPublicKey, c Ciphertext) error {
+	if pd == nil || pd.Proof == nil || crypto.AnyNil(pd.Value, pd.Proof.E, pd.Proof.Z, c) {
+		return internal.ErrNilArguments
+	}
+	if err := pk.validate(); err != nil {
+		return err
+	}
+	vi, ok := pk.VerificationKeys[pd.Id]
+	if !ok {
+		return fmt.Errorf("no verification key for share %d", pd.Id)
+	}
+	if err := checkUnit(c, pk.PublicKey); err != nil {
+		return err
+	}
+	if err := checkUnit(pd.Value, pk.PublicKey); err != nil {
+		return err
+	}
+	if pd.Proof.E.Sign() < 0 || pd.Proof.E.BitLen() > thresholdChallengeBits ||
+		pd.Proof.Z.Sign() < 0 || pd.Proof.Z.BitLen() > int(pk.proofBits())+1 {
+		return fmt.Errorf("invalid partial decryption proof")
+	}
+	n2 := pk.PublicKey.N2
+	negE := new(big.Int).Neg(pd.Proof.E)
+
+	// a = (c^4)^z (c_i^2)^-e
+	c4 := new(big.Int).Exp(c, big.NewInt(4), n2)
+	ci2 := new(big.Int).Exp(pd.Value, two, n2)
+	a := new(big.Int).Exp(c4, pd.Proof.Z, n2)
+	a.Mul(a, new(big.Int).Exp(ci2, negE, n2))
+	a.Mod(a, n2)
+
+	// b = V^z V_i^-e
+	b := new(big.Int).Exp(pk.V, pd.Proof.Z, n2)
+	b.Mul(b, new(big.Int).Exp(vi, negE, n2))
+	b.Mod(b, n2)
+
+	e, err := pk.partialDecryptionChallenge(c, pd.Value, vi, a, b)
+	if err != nil {
+		return err
+	}
+	if e.Cmp(pd.Proof.E) != 0 {
+		return fmt.Errorf("invalid partial decryption proof")
+	}
+	return nil
+}
+
+// Combine verifies at least Threshold partial decryptions of c and recovers the plaintext
+func (pk *ThresholdPublicKey) Combine(c Ciphertext, partials []*PartialDecryption) (*big.Int, error) {
+	if c == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if err := pk.validate(); err != nil {
+		return nil, err
+	}
+	ids := make([]uint32, 0, len(partials))
+	seen := make(map[uint32]bool, len(partials))
+	for _, pd := range partials {
+		if pd == nil {
+			return nil, internal.ErrNilArguments
+		}
+		if seen[pd.Id] {
+			return nil, fmt.Errorf("duplicate partial decryption from share %d", pd.Id)
+		}
+		if err := pd.Verify(pk, c); err != nil {
+			return nil, fmt.Errorf("invalid partial decryption from share %d: %v", pd.Id, err)
+		}
+		seen[pd.Id] = true
+		ids = append(ids, pd.Id)
+	}
+	if uint32(len(ids)) < pk.Threshold {
+		return nil, fmt.Errorf("need at least %d partial decryptions, got %d", pk.Threshold, len(ids))
+	}
+
+	// c' = ∏ c_i^{2μ_i} = c^{4Δ²d} with the integer Lagrange coefficients μ_i = Δ λ_{0,i}
+	n2 := pk.PublicKey.N2
+	delta := crypto.Factorial(pk.Limit)
+	cPrime := big.NewInt(1)
+	for _, pd := range partials {
+		mu := crypto.LagrangeCoefficient(delta, pd.Id, ids)
+		t := new(big.Int).Exp(pd.Value, mu.Lsh(mu, 1), n2)
+		if t == nil {
+			return nil, fmt.Errorf("invalid partial decryption from share %d", pd.Id)
+		}
+		cPrime.Mul(cPrime, t)
+		cPrime.Mod(cPrime, n2)
+	}
+
+	// M = L(c') (4Δ²)^-1 mod N
+	ell, err := pk.PublicKey.l(cPrime)
+	if err != nil {
+		return nil, err
+	}
+	inv := new(big.Int).Mul(delta, delta)
+	inv.Lsh(inv, 2)
+	if inv.ModInverse(inv, pk.PublicKey.N) == nil {
+		return nil, fmt.Errorf("invalid threshold public key")
+	}
+	return crypto.Mul(ell, inv, pk.PublicKey.N)
+}
+
+// validate checks that pk has every value needed to verify partial decryptions
+func (pk *ThresholdPublicKey) validate() error {
+	if pk == nil || pk.PublicKey == nil || crypto.AnyNil(pk.PublicKey.N, pk.PublicKey.N2, pk.V) {
+		return internal.ErrNilArguments
+	}
+	if pk.Threshold < 1 || pk.Limit < pk.Threshold || pk.Limit > crypto.MaxShareLimit {
+		return fmt.Errorf("invalid threshold public key")
+	}
+	for id, vi := range pk.VerificationKeys {
+		if vi == nil {
+			return fmt.Errorf("nil verification key for share %d", id)
+		}
+	}
+	return nil
+}
+
+// proofBits is the bit length of the randomness in partial decryption proofs,
+// which statistically hides eΔs_i < 2^{challenge bits} Δ N²
+func (pk *ThresholdPublicKey) proofBits() uint {
+	return uint(pk.PublicKey.N2.BitLen() + crypto.Factorial(pk.Limit).BitLen() + 2*thresholdChallengeBits)
+}
+
+// partialDecryptionChallenge is the Fiat-Shamir challenge of a partial decryption proof
+func (pk *ThresholdPublicKey) partialDecryptionChallenge(c, ci, vi, a, b *big.Int) (*big.Int, error) {
+	e, err := crypto.FiatShamir(pk.PublicKey.N, pk.V, c, ci, vi, a, b)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(e), nil
+}
+
+// checkUnit checks that x ∈ Z_N²^*
+func checkUnit(x *big.Int, pk *PublicKey) error {
+	if err := crypto.In(x, pk.N2); err != nil {
+		return err
+	}
+	if new(big.Int).GCD(nil, nil, x, pk.N).Cmp(crypto.One) != 0 {
+		return fmt.Errorf("value is not a unit mod N²")
+	}
+	return nil
+}
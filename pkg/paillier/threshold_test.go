@@ -0,0 +1,158 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package paillier
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	crypto "github.com/TEENet-io/kryptology/pkg/core"
+)
+
+func newTestThresholdKeys(t *testing.T, threshold, limit uint32) (*ThresholdPublicKey, []*ThresholdSecretKeyShare) {
+	sk, err := NewSecretKey(testPrimes[0], testPrimes[1])
+	require.NoError(t, err)
+	pk, shares, err := NewThresholdKeysFromSecretKey(sk, threshold, limit)
+	require.NoError(t, err)
+	return pk, shares
+}
+
+func partialDecryptAll(t *testing.T, pk *ThresholdPublicKey, shares []*ThresholdSecretKeyShare, c Ciphertext) []*PartialDecryption {
+	partials := make([]*PartialDecryption, len(shares))
+	for i, share := range shares {
+		pd, err := share.PartialDecrypt(pk, c)
+		require.NoError(t, err)
+		require.NoError(t, pd.Verify(pk, c))
+		partials[i] = pd
+	}
+	return partials
+}
+
+func TestThresholdDecryptWorks(t *testing.T) {
+	pk, shares := newTestThresholdKeys(t, 3, 5)
+	msg := big.NewInt(123456789)
+	c, _, err := pk.PublicKey.Encrypt(msg)
+	require.NoError(t, err)
+	partials := partialDecryptAll(t, pk, shares, c)
+
+	for _, subset := range [][]int{{0, 1, 2}, {4, 2, 0}, {1, 3, 4}, {0, 1, 2, 3}, {0, 1, 2, 3, 4}} {
+		in := make([]*PartialDecryption, len(subset))
+		for i, j := range subset {
+			in[i] = partials[j]
+		}
+		m, err := pk.Combine(c, in)
+		require.NoError(t, err)
+		require.Equal(t, 0, msg.Cmp(m))
+	}
+}
+
+func TestThresholdDecryptTally(t *testing.T) {
+	pk, shares := newTestThresholdKeys(t, 2, 3)
+
+	// Sum weighted votes homomorphically
+	votes := []int64{1, 0, 1, 1, 0, 1}
+	weights := []int64{1, 2, 3, 4, 5, 6}
+	tally, _, err := pk.PublicKey.Encrypt(crypto.Zero)
+	require.NoError(t, err)
+	expected := int64(0)
+	for i, vote := range votes {
+		c, _, err := pk.PublicKey.Encrypt(big.NewInt(vote))
+		require.NoError(t, err)
+		c, err = pk.PublicKey.Mul(big.NewInt(weights[i]), c)
+		require.NoError(t, err)
+		tally, err = pk.PublicKey.Add(tally, c)
+		require.NoError(t, err)
+		expected += vote * weights[i]
+	}
+
+	partials := partialDecryptAll(t, pk, shares[1:], tally)
+	m, err := pk.Combine(tally, partials)
+	require.NoError(t, err)
+	require.Equal(t, expected, m.Int64())
+}
+
+func TestThresholdDecryptTooFewShares(t *testing.T) {
+	pk, shares := newTestThresholdKeys(t, 3, 4)
+	c, _, err := pk.PublicKey.Encrypt(big.NewInt(7))
+	require.NoError(t, err)
+	partials := partialDecryptAll(t, pk, shares, c)
+	_, err = pk.Combine(c, partials[:2])
+	require.Error(t, err)
+	_, err = pk.Combine(c, []*PartialDecryption{partials[0], partials[1], partials[0]})
+	require.Error(t, err)
+}
+
+func TestThresholdDecryptInvalidProofs(t *testing.T) {
+	pk, shares := newTestThresholdKeys(t, 2, 3)
+	c, _, err := pk.PublicKey.Encrypt(big.NewInt(42))
+	require.NoError(t, err)
+	partials := partialDecryptAll(t, pk, shares, c)
+
+	// A wrong decryption share
+	wrong := *partials[1]
+	wrong.Value = new(big.Int).Mul(wrong.Value, big.NewInt(2))
+	wrong.Value.Mod(wrong.Value, pk.PublicKey.N2)
+	require.Error(t, wrong.Verify(pk, c))
+	_, err = pk.Combine(c, []*PartialDecryption{partials[0], &wrong})
+	require.Error(t, err)
+
+	// A share claimed by another id
+	other := *partials[1]
+	other.Id = 3
+	require.Error(t, other.Verify(pk, c))
+
+	// A share of another ciphertext
+	d, _, err := pk.PublicKey.Encrypt(big.NewInt(42))
+	require.NoError(t, err)
+	require.Error(t, partials[0].Verify(pk, d))
+
+	// Unknown id
+	_, err = (&ThresholdSecretKeyShare{Id: 4, Share: shares[0].Share}).PartialDecrypt(pk, c)
+	require.Error(t, err)
+
+	// The valid shares still combine
+	m, err := pk.Combine(c, partials[:2])
+	require.NoError(t, err)
+	require.Equal(t, int64(42), m.Int64())
+}
+
+func TestThresholdPublicKeyJson(t *testing.T) {
+	pk, shares := newTestThresholdKeys(t, 2, 2)
+	bytes, err := json.Marshal(pk)
+	require.NoError(t, err)
+	unmarshaled := new(ThresholdPublicKey)
+	require.NoError(t, json.Unmarshal(bytes, unmarshaled))
+
+	c, _, err := unmarshaled.PublicKey.Encrypt(big.NewInt(99))
+	require.NoError(t, err)
+	partials := partialDecryptAll(t, unmarshaled, shares, c)
+	m, err := unmarshaled.Combine(c, partials)
+	require.NoError(t, err)
+	require.Equal(t, int64(99), m.Int64())
+}
+
+func TestNewThresholdKeysErrors(t *testing.T) {
+	sk, err := NewSecretKey(testPrimes[0], testPrimes[1])
+	require.NoError(t, err)
+	_, _, err = NewThresholdKeysFromSecretKey(nil, 2, 3)
+	require.Error(t, err)
+	_, _, err = NewThresholdKeysFromSecretKey(sk, 4, 3)
+	require.Error(t, err)
+	_, _, err = NewThresholdKeysFromSecretKey(sk, 0, 3)
+	require.Error(t, err)
+	_, _, err = NewThresholdKeysFromSecretKey(sk, 2, 256)
+	require.Error(t, err)
+
+	// φ(N)/4 must be invertible mod N
+	notSafe, err := NewSecretKey(big.NewInt(3), big.NewInt(7))
+	require.NoError(t, err)
+	_, _, err = NewThresholdKeysFromSecretKey(notSafe, 2, 3)
+	require.Error(t, err)
+}
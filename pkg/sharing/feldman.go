@@ -23,13 +23,16 @@ func (v FeldmanVerifier) Verify(share *ShamirShare) error {
 	if err != nil {
 		return err
 	}
+	// rhs = ∑ C_j x^j with public powers of x
 	x := curve.Scalar.New(int(share.Id))
-	i := curve.Scalar.One()
-	rhs := v.Commitments[0]
-
-	for j := 1; j < len(v.Commitments); j++ {
-		i = i.Mul(x)
-		rhs = rhs.Add(v.Commitments[j].Mul(i))
+	powers := make([]curves.Scalar, len(v.Commitments))
+	powers[0] = curve.Scalar.One()
+	for j := 1; j < len(powers); j++ {
+		powers[j] = powers[j-1].Mul(x)
+	}
+	rhs, err := curves.SumOfProductsVartime(v.Commitments, powers)
+	if err != nil {
+		return err
 	}
 	sc, _ := curve.Scalar.SetBytes(share.Value)
 	lhs := v.Commitments[0].Generator().Mul(sc)
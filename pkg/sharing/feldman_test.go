@@ -0,0 +1,36 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package sharing
+
+import (
+	crand "crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
+)
+
+func TestFeldmanWideCurves(t *testing.T) {
+	for _, curve := range []*curves.Curve{curves.P384(), curves.ED448()} {
+		scheme, err := NewFeldman(2, 3, curve)
+		require.NoError(t, err)
+		secret := curve.Scalar.Random(crand.Reader)
+		verifiers, shares, err := scheme.Split(secret, crand.Reader)
+		require.NoError(t, err)
+		for _, s := range shares {
+			require.NoError(t, verifiers.Verify(s))
+		}
+		rSecret, err := scheme.Combine(shares[1], shares[3])
+		require.NoError(t, err)
+		require.Equal(t, 0, secret.Cmp(rSecret))
+
+		// A share of another value is rejected
+		shares[2].Value = curve.Scalar.Random(crand.Reader).Bytes()
+		require.Error(t, verifiers.Verify(shares[2]))
+	}
+}
@@ -34,13 +34,16 @@ func (pv PedersenVerifier) Verify(share, blindShare *ShamirShare) error {
 		return err
 	}
 
+	// rhs = ∑ C_j x^j with public powers of x
 	x := curve.Scalar.New(int(share.Id))
-	i := curve.Scalar.One()
-	rhs := pv.Commitments[0]
-
-	for j := 1; j < len(pv.Commitments); j++ {
-		i = i.Mul(x)
-		rhs = rhs.Add(pv.Commitments[j].Mul(i))
+	powers := make([]curves.Scalar, len(pv.Commitments))
+	powers[0] = curve.Scalar.One()
+	for j := 1; j < len(powers); j++ {
+		powers[j] = powers[j-1].Mul(x)
+	}
+	rhs, err := curves.SumOfProductsVartime(pv.Commitments, powers)
+	if err != nil {
+		return err
 	}
 
 	sc, _ := curve.Scalar.SetBytes(share.Value)
@@ -8,6 +8,7 @@ package v1
 
 import (
 	"crypto/elliptic"
+	"fmt"
 	"math/big"
 	"sync"
 
@@ -51,6 +52,30 @@ func (curve *Ed25519Curve) IsOnCurve(x, y *big.Int) bool {
 	return err == nil
 }
 
+// IsTorsionFree returns true if the point encoded by y is in the prime order subgroup
+func (curve *Ed25519Curve) IsTorsionFree(x, y *big.Int) bool {
+	p, err := internal.BigInt2Ed25519Point(y)
+	if err != nil {
+		return false
+	}
+	return new(curves.PointEd25519).SetEdwardsPoint(p).IsTorsionFree()
+}
+
+// PeerPoint decodes the point encoded by y with FromAffineCompressedStrict, which rejects
+// non-canonical encodings, the identity and points outside the prime order subgroup
+func (curve *Ed25519Curve) PeerPoint(x, y *big.Int) (curves.Point, error) {
+	if y == nil {
+		return nil, internal.ErrNilArguments
+	}
+	b := y.Bytes()
+	if len(b) > 32 {
+		return nil, fmt.Errorf("invalid point encoding")
+	}
+	var arr [32]byte
+	copy(arr[32-len(b):], b)
+	return curves.ED25519().Point.FromAffineCompressedStrict(arr[:])
+}
+
 func (curve *Ed25519Curve) Add(x1, y1, x2, y2 *big.Int) (*big.Int, *big.Int) {
 	var p1, p2 *ed.Point
 	var err error
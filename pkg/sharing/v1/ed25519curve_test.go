@@ -10,7 +10,10 @@ import (
 	"math/big"
 	"testing"
 
+	"filippo.io/edwards25519"
 	"github.com/stretchr/testify/require"
+
+	core "github.com/TEENet-io/kryptology/pkg/core/curves"
 )
 
 func TestEd25519ScalarMult(t *testing.T) {
@@ -24,3 +27,28 @@ func TestEd25519ScalarMult(t *testing.T) {
 	_, newY := curve.ScalarMult(nil, y, x.Bytes())
 	require.True(t, curve.IsOnCurve(nil, newY))
 }
+
+func TestEd25519IsTorsionFree(t *testing.T) {
+	curve := Ed25519()
+	require.True(t, curve.IsTorsionFree(nil, curve.Gy))
+
+	// y = 0 encodes a point of order 4
+	torsion, err := edwards25519.NewIdentityPoint().SetBytes(make([]byte, 32))
+	require.NoError(t, err)
+	mixed := edwards25519.NewIdentityPoint().Add(edwards25519.NewGeneratorPoint(), torsion)
+	y := new(big.Int).SetBytes(mixed.Bytes())
+	require.True(t, curve.IsOnCurve(nil, y))
+	require.False(t, curve.IsTorsionFree(nil, y))
+
+	p := &core.EcPoint{Curve: curve, X: new(big.Int), Y: y}
+	require.False(t, p.IsTorsionFree())
+	require.True(t, ed25519BasePoint.IsTorsionFree())
+
+	_, err = p.PeerPoint()
+	require.Error(t, err)
+	g, err := ed25519BasePoint.PeerPoint()
+	require.NoError(t, err)
+	require.True(t, g.Equal(core.ED25519().NewGeneratorPoint()))
+	_, err = (&core.EcPoint{Curve: curve, X: new(big.Int), Y: new(big.Int)}).PeerPoint()
+	require.Error(t, err)
+}
@@ -66,11 +66,23 @@ func generateRandBytes(count int) ([]byte, error) {
 // Input key material (ikm) MUST be at least 32 bytes long,
 // but it MAY be longer.
 func (sk SecretKey) Generate(ikm []byte) (*SecretKey, error) {
+	okm, err := keyGenOkm(ikm)
+	if err != nil {
+		return nil, err
+	}
+	var wide [native.WideFieldBytes]byte
+	copy(wide[:48], internal.ReverseScalarBytes(okm))
+	v := bls12381.Bls12381FqNew().SetBytesWide(&wide)
+	return &SecretKey{value: v}, nil
+}
+
+// keyGenOkm derives the 48 big-endian bytes that KeyGen reduces to a secret key
+// See section 2.3 in https://tools.ietf.org/html/draft-irtf-cfrg-bls-signature-04
+func keyGenOkm(ikm []byte) ([]byte, error) {
 	if len(ikm) < 32 {
 		return nil, fmt.Errorf("ikm is too short. Must be at least 32")
 	}
 
-	// https://tools.ietf.org/html/draft-irtf-cfrg-bls-signature-04#section-2.3
 	h := sha256.New()
 	n, err := h.Write([]byte(hkdfKeyGenSalt))
 	if err != nil {
@@ -84,18 +96,16 @@ func (sk SecretKey) Generate(ikm []byte) (*SecretKey, error) {
 	ikm = append(ikm, 0)
 	// Leaves key_info parameter as the default empty string
 	// and just adds parameter I2OSP(L, 2)
-	var okm [native.WideFieldBytes]byte
+	okm := make([]byte, 48)
 	kdf := hkdf.New(sha256.New, ikm, salt, []byte{0, 48})
-	read, err := kdf.Read(okm[:48])
-	copy(okm[:48], internal.ReverseScalarBytes(okm[:48]))
+	read, err := kdf.Read(okm)
 	if err != nil {
 		return nil, err
 	}
 	if read != 48 {
 		return nil, fmt.Errorf("failed to create private key")
 	}
-	v := bls12381.Bls12381FqNew().SetBytesWide(&okm)
-	return &SecretKey{value: v}, nil
+	return okm, nil
 }
 
 // Serialize a secret key to raw bytes
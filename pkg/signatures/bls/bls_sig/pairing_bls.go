@@ -0,0 +1,252 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package bls_sig
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/TEENet-io/kryptology/internal"
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
+)
+
+// PairingSigBasic is the basic BLS signature scheme over any curves.PairingCurve, e.g. BN254.
+// Public keys are in the group of the preferred point of the curve and signatures in the other group,
+// so curves.BN254(curves.BN254G2().NewIdentityPoint()) has the G2 public keys and G1 signatures
+// checked by the Ethereum pairing precompile.
+// The hash to curve of each group has a fixed domain separation tag, so the tag of the scheme
+// is prepended with its length to the message before hashing.
+type PairingSigBasic struct {
+	curve *curves.PairingCurve
+	dst   string
+}
+
+// PairingSecretKey is a BLS secret key of a PairingSigBasic scheme
+type PairingSecretKey struct {
+	value curves.Scalar
+}
+
+// PairingPublicKey is a BLS public key of a PairingSigBasic scheme
+type PairingPublicKey struct {
+	value curves.PairingPoint
+}
+
+// PairingSignature is a BLS signature of a PairingSigBasic scheme
+type PairingSignature struct {
+	value curves.PairingPoint
+}
+
+// Creates a new BLS basic signature scheme over curve with a domain separation tag derived from its name.
+func NewPairingSigBasic(curve *curves.PairingCurve) (*PairingSigBasic, error) {
+	if curve == nil {
+		return nil, internal.ErrNilArguments
+	}
+	return NewPairingSigBasicWithDst(curve, fmt.Sprintf("BLS_SIG_%s_RO_NUL_", curve.Name))
+}
+
+// Creates a new BLS basic signature scheme over curve with a custom domain separation tag used for signatures.
+func NewPairingSigBasicWithDst(curve *curves.PairingCurve, signDst string) (*PairingSigBasic, error) {
+	if curve == nil || curve.Scalar == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if _, ok := curve.Scalar.Point().(curves.PairingPoint); !ok {
+		return nil, fmt.Errorf("the preferred point of %s is not a pairing point", curve.Name)
+	}
+	return &PairingSigBasic{curve: curve, dst: signDst}, nil
+}
+
+// Creates a new BLS key pair
+func (b PairingSigBasic) Keygen() (*PairingPublicKey, *PairingSecretKey, error) {
+	ikm, err := generateRandBytes(32)
+	if err != nil {
+		return nil, nil, err
+	}
+	return b.KeygenWithSeed(ikm)
+}
+
+// Creates a new BLS key pair
+// Input key material (ikm) MUST be at least 32 bytes long,
+// but it MAY be longer.
+func (b PairingSigBasic) KeygenWithSeed(ikm []byte) (*PairingPublicKey, *PairingSecretKey, error) {
+	okm, err := keyGenOkm(ikm)
+	if err != nil {
+		return nil, nil, err
+	}
+	order := new(big.Int).Add(b.curve.Scalar.New(-1).BigInt(), big.NewInt(1))
+	v := new(big.Int).SetBytes(okm)
+	value, err := b.curve.Scalar.SetBigInt(v.Mod(v, order))
+	if err != nil {
+		return nil, nil, err
+	}
+	if value.IsZero() {
+		return nil, nil, fmt.Errorf("invalid secret key")
+	}
+	sk := &PairingSecretKey{value: value}
+	pk, err := b.PublicKey(sk)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pk, sk, nil
+}
+
+// PublicKey returns the public key of sk
+func (b PairingSigBasic) PublicKey(sk *PairingSecretKey) (*PairingPublicKey, error) {
+	if sk == nil || sk.value == nil || sk.value.IsZero() {
+		return nil, fmt.Errorf("invalid secret key")
+	}
+	value := b.keyGroup().Generator().Mul(sk.value)
+	if value == nil {
+		return nil, fmt.Errorf("secret key is not a scalar of %s", b.curve.Name)
+	}
+	return &PairingPublicKey{value: value.(curves.PairingPoint)}, nil
+}
+
+// Computes a signature from sk, a secret key, and a message
+// nil message is not permitted but empty slice is allowed
+func (b PairingSigBasic) Sign(sk *PairingSecretKey, msg []byte) (*PairingSignature, error) {
+	if msg == nil {
+		return nil, fmt.Errorf("message cannot be nil")
+	}
+	if sk == nil || sk.value == nil || sk.value.IsZero() {
+		return nil, fmt.Errorf("invalid secret key")
+	}
+	h := b.hash(msg)
+	if h == nil {
+		return nil, fmt.Errorf("unable to hash message")
+	}
+	value := h.Mul(sk.value)
+	if value == nil {
+		return nil, fmt.Errorf("secret key is not a scalar of %s", b.curve.Name)
+	}
+	return &PairingSignature{value: value.(curves.PairingPoint)}, nil
+}
+
+// Verify checks that sig is a signature of msg under pk
+func (b PairingSigBasic) Verify(pk *PairingPublicKey, msg []byte, sig *PairingSignature) bool {
+	return b.AggregateVerify([]*PairingPublicKey{pk}, [][]byte{msg}, sig)
+}
+
+// AggregateSignatures combines signatures of distinct messages into one signature
+func (b PairingSigBasic) AggregateSignatures(sigs ...*PairingSignature) (*PairingSignature, error) {
+	if len(sigs) < 1 {
+		return nil, fmt.Errorf("at least one signature is required")
+	}
+	result := b.keyGroup().OtherGroup().Identity()
+	for i, s := range sigs {
+		if s == nil || !b.validPoint(s.value, b.keyGroup().OtherGroup()) {
+			return nil, fmt.Errorf("signature at %d is invalid", i)
+		}
+		result = result.Add(s.value)
+	}
+	return &PairingSignature{value: result.(curves.PairingPoint)}, nil
+}
+
+// AggregateVerify checks that sig is the aggregate of signatures of msgs under pks.
+// The messages must be distinct, see section 3.1.1 in
+// https://tools.ietf.org/html/draft-irtf-cfrg-bls-signature-03
+func (b PairingSigBasic) AggregateVerify(pks []*PairingPublicKey, msgs [][]byte, sig *PairingSignature) bool {
+	if len(pks) < 1 || len(pks) != len(msgs) || sig == nil {
+		return false
+	}
+	if len(msgs) > 1 && !allRowsUnique(msgs) {
+		return false
+	}
+	keyGroup := b.keyGroup()
+	if !b.validPoint(sig.value, keyGroup.OtherGroup()) || sig.value.IsIdentity() {
+		return false
+	}
+	// e(pk_1, H(m_1)) ... e(pk_n, H(m_n)) == e(g, s)
+	// is checked with a single final exponentiation as
+	// e(pk_1, H(m_1)) ... e(pk_n, H(m_n)) e(-g, s) == 1
+	pairs := make([]curves.PairingPoint, 0, 2*len(pks)+2)
+	for i, pk := range pks {
+		if pk == nil || msgs[i] == nil || !b.validPoint(pk.value, keyGroup) || pk.value.IsIdentity() {
+			return false
+		}
+		h := b.hash(msgs[i])
+		if h == nil {
+			return false
+		}
+		pairs = b.appendPair(pairs, pk.value, h)
+	}
+	pairs = b.appendPair(pairs, keyGroup.Generator().Neg().(curves.PairingPoint), sig.value)
+	result := pairs[0].MultiPairing(pairs...)
+	return result != nil && result.IsOne()
+}
+
+// PublicKeyFromBytes reads a public key from its compressed encoding
+func (b PairingSigBasic) PublicKeyFromBytes(data []byte) (*PairingPublicKey, error) {
+	value, err := b.keyGroup().FromAffineCompressed(data)
+	if err != nil {
+		return nil, err
+	}
+	if !b.validPoint(value, b.keyGroup()) || value.IsIdentity() {
+		return nil, fmt.Errorf("invalid public key")
+	}
+	return &PairingPublicKey{value: value.(curves.PairingPoint)}, nil
+}
+
+// SignatureFromBytes reads a signature from its compressed encoding
+func (b PairingSigBasic) SignatureFromBytes(data []byte) (*PairingSignature, error) {
+	value, err := b.keyGroup().OtherGroup().FromAffineCompressed(data)
+	if err != nil {
+		return nil, err
+	}
+	if !b.validPoint(value, b.keyGroup().OtherGroup()) {
+		return nil, fmt.Errorf("invalid signature")
+	}
+	return &PairingSignature{value: value.(curves.PairingPoint)}, nil
+}
+
+// Point returns the public key point
+func (pk PairingPublicKey) Point() curves.PairingPoint {
+	return pk.value
+}
+
+// Bytes returns the compressed encoding of the public key
+func (pk PairingPublicKey) Bytes() []byte {
+	return pk.value.ToAffineCompressed()
+}
+
+// Point returns the signature point
+func (sig PairingSignature) Point() curves.PairingPoint {
+	return sig.value
+}
+
+// Bytes returns the compressed encoding of the signature
+func (sig PairingSignature) Bytes() []byte {
+	return sig.value.ToAffineCompressed()
+}
+
+// keyGroup returns the identity of the group of public keys
+func (b PairingSigBasic) keyGroup() curves.PairingPoint {
+	return b.curve.Scalar.Point().(curves.PairingPoint)
+}
+
+// hash maps msg to the group of signatures, or returns nil if hashing fails
+func (b PairingSigBasic) hash(msg []byte) curves.PairingPoint {
+	input := make([]byte, 2, 2+len(b.dst)+len(msg))
+	binary.BigEndian.PutUint16(input, uint16(len(b.dst)))
+	input = append(input, b.dst...)
+	input = append(input, msg...)
+	h, _ := b.keyGroup().OtherGroup().Hash(input).(curves.PairingPoint)
+	return h
+}
+
+// validPoint returns true if p is a point on the curve in the same group as group
+func (b PairingSigBasic) validPoint(p curves.Point, group curves.PairingPoint) bool {
+	return p != nil && p.CurveName() == group.CurveName() && p.IsOnCurve()
+}
+
+// appendPair appends the pair of p and q with the G1 point first as MultiPairing expects
+func (b PairingSigBasic) appendPair(pairs []curves.PairingPoint, p, q curves.PairingPoint) []curves.PairingPoint {
+	if p.CurveName() == b.curve.PointG1.CurveName() {
+		return append(pairs, p, q)
+	}
+	return append(pairs, q, p)
+}
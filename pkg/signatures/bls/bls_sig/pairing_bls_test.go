@@ -0,0 +1,127 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package bls_sig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
+)
+
+func pairingSigTestCurves() []*curves.PairingCurve {
+	return []*curves.PairingCurve{
+		curves.BN254(curves.BN254G1().NewIdentityPoint()),
+		curves.BN254(curves.BN254G2().NewIdentityPoint()),
+		curves.BLS12381(curves.BLS12381G1().NewIdentityPoint()),
+	}
+}
+
+func TestPairingSigBasicSignVerify(t *testing.T) {
+	for _, curve := range pairingSigTestCurves() {
+		bls, err := NewPairingSigBasic(curve)
+		require.NoError(t, err)
+		pk, sk, err := bls.Keygen()
+		require.NoError(t, err)
+		msg := []byte("BLS signatures on any pairing curve")
+		sig, err := bls.Sign(sk, msg)
+		require.NoError(t, err)
+		require.True(t, bls.Verify(pk, msg, sig))
+		require.False(t, bls.Verify(pk, []byte("another message"), sig))
+		require.False(t, bls.Verify(pk, nil, sig))
+
+		other, _, err := bls.Keygen()
+		require.NoError(t, err)
+		require.False(t, bls.Verify(other, msg, sig))
+
+		// A tag of another scheme gives another signature
+		otherDst, err := NewPairingSigBasicWithDst(curve, "BLS_SIG_OTHER_")
+		require.NoError(t, err)
+		require.False(t, otherDst.Verify(pk, msg, sig))
+
+		pk2, err := bls.PublicKeyFromBytes(pk.Bytes())
+		require.NoError(t, err)
+		sig2, err := bls.SignatureFromBytes(sig.Bytes())
+		require.NoError(t, err)
+		require.True(t, bls.Verify(pk2, msg, sig2))
+		_, err = bls.PublicKeyFromBytes(sig.Bytes())
+		require.Error(t, err)
+	}
+}
+
+func TestPairingSigBasicKeygenWithSeed(t *testing.T) {
+	bls, err := NewPairingSigBasic(curves.BN254(curves.BN254G2().NewIdentityPoint()))
+	require.NoError(t, err)
+	ikm := make([]byte, 32)
+	pk1, sk1, err := bls.KeygenWithSeed(ikm)
+	require.NoError(t, err)
+	pk2, sk2, err := bls.KeygenWithSeed(ikm)
+	require.NoError(t, err)
+	require.Equal(t, 0, sk1.value.Cmp(sk2.value))
+	require.True(t, pk1.Point().Equal(pk2.Point()))
+	require.Equal(t, curves.BN254G2Name, pk1.Point().CurveName())
+
+	_, _, err = bls.KeygenWithSeed(make([]byte, 31))
+	require.Error(t, err)
+
+	// The key derivation matches the BLS12-381 scheme on BLS12-381
+	bls, err = NewPairingSigBasic(curves.BLS12381(curves.BLS12381G1().NewIdentityPoint()))
+	require.NoError(t, err)
+	pk3, _, err := bls.KeygenWithSeed(ikm)
+	require.NoError(t, err)
+	pk4, _, err := NewSigBasic().KeygenWithSeed(ikm)
+	require.NoError(t, err)
+	expected, err := pk4.MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, expected, pk3.Bytes())
+}
+
+func TestPairingSigBasicAggregate(t *testing.T) {
+	for _, curve := range pairingSigTestCurves() {
+		bls, err := NewPairingSigBasic(curve)
+		require.NoError(t, err)
+		pks := make([]*PairingPublicKey, 5)
+		sigs := make([]*PairingSignature, 5)
+		msgs := make([][]byte, 5)
+		for i := range pks {
+			pk, sk, err := bls.Keygen()
+			require.NoError(t, err)
+			msgs[i] = []byte{byte(i)}
+			sigs[i], err = bls.Sign(sk, msgs[i])
+			require.NoError(t, err)
+			pks[i] = pk
+		}
+		sig, err := bls.AggregateSignatures(sigs...)
+		require.NoError(t, err)
+		require.True(t, bls.AggregateVerify(pks, msgs, sig))
+		require.False(t, bls.AggregateVerify(pks[1:], msgs[1:], sig))
+
+		// The basic scheme requires distinct messages
+		msgs[1] = msgs[0]
+		require.False(t, bls.AggregateVerify(pks, msgs, sig))
+	}
+}
+
+func TestPairingSigBasicInvalidArguments(t *testing.T) {
+	_, err := NewPairingSigBasic(nil)
+	require.Error(t, err)
+	_, err = NewPairingSigBasicWithDst(&curves.PairingCurve{Scalar: curves.BN254G1().Scalar.(curves.PairingScalar).SetPoint(curves.K256().NewIdentityPoint())}, "")
+	require.Error(t, err)
+
+	bls, err := NewPairingSigBasic(curves.BN254(curves.BN254G1().NewIdentityPoint()))
+	require.NoError(t, err)
+	_, sk, err := bls.Keygen()
+	require.NoError(t, err)
+	_, err = bls.Sign(sk, nil)
+	require.Error(t, err)
+	_, err = bls.Sign(&PairingSecretKey{value: curves.BN254G1().Scalar.Zero()}, []byte{})
+	require.Error(t, err)
+	_, err = bls.Sign(&PairingSecretKey{value: curves.K256().Scalar.One()}, []byte{})
+	require.Error(t, err)
+	require.False(t, bls.Verify(nil, []byte{}, nil))
+}
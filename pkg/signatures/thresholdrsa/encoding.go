@@ -0,0 +1,130 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// This file contains the message encodings EMSA-PKCS1-v1_5 and EMSA-PSS of RFC 8017 §9,
+// which crypto/rsa applies when it signs and verifies but does not export.
+
+package thresholdrsa
+
+import (
+	"crypto"
+	"fmt"
+	"math/big"
+)
+
+// hashPrefixes are the DER encodings of the DigestInfo prefixes in RFC 8017 §9.2
+var hashPrefixes = map[crypto.Hash][]byte{
+	crypto.SHA1:       {0x30, 0x21, 0x30, 0x09, 0x06, 0x05, 0x2b, 0x0e, 0x03, 0x02, 0x1a, 0x05, 0x00, 0x04, 0x14},
+	crypto.SHA224:     {0x30, 0x2d, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x04, 0x05, 0x00, 0x04, 0x1c},
+	crypto.SHA256:     {0x30, 0x31, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x01, 0x05, 0x00, 0x04, 0x20},
+	crypto.SHA384:     {0x30, 0x41, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x02, 0x05, 0x00, 0x04, 0x30},
+	crypto.SHA512:     {0x30, 0x51, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x03, 0x05, 0x00, 0x04, 0x40},
+	crypto.SHA512_256: {0x30, 0x31, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x06, 0x05, 0x00, 0x04, 0x20},
+	crypto.SHA3_256:   {0x30, 0x31, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x08, 0x05, 0x00, 0x04, 0x20},
+	crypto.SHA3_384:   {0x30, 0x41, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x09, 0x05, 0x00, 0x04, 0x30},
+	crypto.SHA3_512:   {0x30, 0x51, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x0a, 0x05, 0x00, 0x04, 0x40},
+}
+
+// EncodePKCS1v15 returns the message representative of the digest hashed for a signature that
+// verifies with rsa.VerifyPKCS1v15(pk.RSAPublicKey(), hash, hashed, signature).
+// As with rsa.SignPKCS1v15, hash 0 signs hashed directly.
+func (pk *PublicKey) EncodePKCS1v15(hash crypto.Hash, hashed []byte) (*big.Int, error) {
+	if err := pk.validate(); err != nil {
+		return nil, err
+	}
+	var prefix []byte
+	if hash != 0 {
+		var ok bool
+		if prefix, ok = hashPrefixes[hash]; !ok {
+			return nil, fmt.Errorf("unsupported hash function")
+		}
+		if len(hashed) != hash.Size() {
+			return nil, fmt.Errorf("input must be hashed message")
+		}
+	}
+
+	// EM = 0x00 || 0x01 || PS || 0x00 || T with PS at least 8 bytes of 0xff
+	k := pk.size()
+	tLen := len(prefix) + len(hashed)
+	if k < tLen+11 {
+		return nil, fmt.Errorf("message too long for RSA key size")
+	}
+	em := make([]byte, k)
+	em[1] = 1
+	for i := 2; i < k-tLen-1; i++ {
+		em[i] = 0xff
+	}
+	copy(em[k-tLen:], prefix)
+	copy(em[k-len(hashed):], hashed)
+	return new(big.Int).SetBytes(em), nil
+}
+
+// EncodePSS returns the message representative of the digest hashed with the given salt for a
+// signature that verifies with rsa.VerifyPSS(pk.RSAPublicKey(), hash, hashed, signature, opts),
+// where opts.SaltLength is len(salt) or rsa.PSSSaltLengthAuto.
+// The salt should be random, e.g. hash.Size() bytes chosen by whoever collects the signature
+// shares, and every signer must use the same salt.
+func (pk *PublicKey) EncodePSS(hash crypto.Hash, hashed, salt []byte) (*big.Int, error) {
+	if err := pk.validate(); err != nil {
+		return nil, err
+	}
+	if !hash.Available() {
+		return nil, fmt.Errorf("unsupported hash function")
+	}
+	hLen := hash.Size()
+	if len(hashed) != hLen {
+		return nil, fmt.Errorf("input must be hashed message")
+	}
+	emBits := pk.N.BitLen() - 1
+	emLen := (emBits + 7) / 8
+	if emLen < hLen+len(salt)+2 {
+		return nil, fmt.Errorf("message too long for RSA key size")
+	}
+
+	// H = Hash(0x00 * 8 || mHash || salt)
+	h := hash.New()
+	h.Write(make([]byte, 8))
+	h.Write(hashed)
+	h.Write(salt)
+	mPrimeHash := h.Sum(nil)
+
+	// DB = PS || 0x01 || salt, masked with MGF1(H)
+	db := make([]byte, emLen-hLen-1)
+	db[len(db)-len(salt)-1] = 0x01
+	copy(db[len(db)-len(salt):], salt)
+	mgf1XOR(db, hash, mPrimeHash)
+	db[0] &= 0xff >> uint(8*emLen-emBits)
+
+	// EM = maskedDB || H || 0xbc
+	em := make([]byte, 0, emLen)
+	em = append(em, db...)
+	em = append(em, mPrimeHash...)
+	em = append(em, 0xbc)
+	return new(big.Int).SetBytes(em), nil
+}
+
+// mgf1XOR XORs out with the mask MGF1(seed) of RFC 8017 §B.2.1
+func mgf1XOR(out []byte, hash crypto.Hash, seed []byte) {
+	var counter [4]byte
+	h := hash.New()
+	for done := 0; done < len(out); {
+		h.Reset()
+		h.Write(seed)
+		h.Write(counter[:])
+		for _, b := range h.Sum(nil) {
+			if done == len(out) {
+				break
+			}
+			out[done] ^= b
+			done++
+		}
+		for i := len(counter) - 1; i >= 0; i-- {
+			counter[i]++
+			if counter[i] != 0 {
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,378 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// Package thresholdrsa implements Shoup's practical threshold RSA signatures [S00].
+// A trusted dealer splits the secret exponent of an RSA key made of safe primes into shares.
+// Any threshold of the share holders produce signature shares with proofs of correctness,
+// and anyone combines them into a standard RSA signature that verifies with crypto/rsa,
+// e.g. with rsa.VerifyPKCS1v15 or rsa.VerifyPSS for messages encoded with
+// PublicKey.EncodePKCS1v15 or PublicKey.EncodePSS.
+//
+// [S00] Practical Threshold Signatures. https://www.iacr.org/archive/eurocrypt2000/1807/18070209-new.pdf
+package thresholdrsa
+
+import (
+	"crypto/rsa"
+	"fmt"
+	"math/big"
+
+	"github.com/TEENet-io/kryptology/internal"
+	"github.com/TEENet-io/kryptology/pkg/core"
+)
+
+const (
+	// DefaultExponent is the public exponent of keys generated by NewKeys
+	DefaultExponent = 65537
+
+	// challengeBits is the bit length of the Fiat-Shamir challenges in signature share proofs
+	challengeBits = 256
+
+	// minModulusBits is the smallest modulus that crypto/rsa accepts
+	minModulusBits = 1024
+)
+
+// PublicKey is the RSA public key (N, E) with the values needed to verify signature shares
+type PublicKey struct {
+	N         *big.Int
+	E         int
+	Threshold uint32
+	Limit     uint32
+	// V is a random square in Z_N
+	V *big.Int
+	// VerificationKeys maps each share id i to V^{s_i} mod N
+	VerificationKeys map[uint32]*big.Int
+}
+
+// SecretKeyShare is the share s_i = f(i) mod m of the secret exponent d, where m = p'q'
+// and f is a polynomial of degree Threshold - 1 with f(0) = d
+type SecretKeyShare struct {
+	Id    uint32
+	Share *big.Int
+}
+
+// SignatureShare is the share x^{2Δ s_i} mod N of the signature of the message representative x,
+// with Δ = Limit!
+type SignatureShare struct {
+	Id    uint32
+	Value *big.Int
+	Proof *SignatureShareProof
+}
+
+// SignatureShareProof proves that a signature share is computed with the same exponent as the
+// verification key of its share: log_{x^{4Δ}}(x_i^2) = log_V(V_i)
+type SignatureShareProof struct {
+	C *big.Int
+	Z *big.Int
+}
+
+// NewKeys generates an RSA key of bits bits with the public exponent DefaultExponent
+// made of two safe primes, and splits its secret exponent into limit shares, any threshold
+// of which sign
+func NewKeys(bits uint, threshold, limit uint32) (*PublicKey, []*SecretKeyShare, error) {
+	if bits < minModulusBits {
+		return nil, nil, fmt.Errorf("modulus must be at least %d bits", minModulusBits)
+	}
+	var p, q *big.Int
+	var err error
+	for p == nil || p.Cmp(q) == 0 {
+		if p, err = core.GenerateSafePrime(bits / 2); err != nil {
+			return nil, nil, err
+		}
+		if q, err = core.GenerateSafePrime(bits - bits/2); err != nil {
+			return nil, nil, err
+		}
+	}
+	return NewKeysFromPrimes(p, q, DefaultExponent, threshold, limit)
+}
+
+// NewKeysFromPrimes splits the secret exponent of the RSA key with safe primes p and q and
+// the public exponent e into limit shares, any threshold of which sign.
+// e must be a prime larger than limit. The dealer must discard p and q afterwards.
+func NewKeysFromPrimes(p, q *big.Int, e int, threshold, limit uint32) (*PublicKey, []*SecretKeyShare, error) {
+	if core.AnyNil(p, q) {
+		return nil, nil, internal.ErrNilArguments
+	}
+	if limit < threshold {
+		return nil, nil, fmt.Errorf("limit cannot be less than threshold")
+	}
+	if threshold < 1 {
+		return nil, nil, fmt.Errorf("threshold cannot be less than 1")
+	}
+	if limit > core.MaxShareLimit {
+		return nil, nil, fmt.Errorf("cannot exceed %d shares", core.MaxShareLimit)
+	}
+	bigE := big.NewInt(int64(e))
+	if e <= int(limit) || !bigE.ProbablyPrime(20) {
+		return nil, nil, fmt.Errorf("public exponent must be a prime larger than %d", limit)
+	}
+	if p.Cmp(q) == 0 {
+		return nil, nil, fmt.Errorf("p and q must be distinct")
+	}
+
+	// m = p'q' for safe primes p = 2p' + 1 and q = 2q' + 1
+	pPrime := new(big.Int).Rsh(p, 1)
+	qPrime := new(big.Int).Rsh(q, 1)
+	if p.Bit(0) != 1 || q.Bit(0) != 1 || !pPrime.ProbablyPrime(20) || !qPrime.ProbablyPrime(20) ||
+		!p.ProbablyPrime(20) || !q.ProbablyPrime(20) {
+		return nil, nil, fmt.Errorf("p and q must be safe primes")
+	}
+	n := new(big.Int).Mul(p, q)
+	if n.BitLen() < minModulusBits {
+		return nil, nil, fmt.Errorf("modulus must be at least %d bits", minModulusBits)
+	}
+	m := new(big.Int).Mul(pPrime, qPrime)
+	d := new(big.Int).ModInverse(bigE, m)
+	if d == nil {
+		return nil, nil, fmt.Errorf("public exponent is not invertible mod p'q'")
+	}
+
+	// f(X) = d + a_1 X + ... + a_{t-1} X^{t-1} mod m
+	coefficients := make([]*big.Int, threshold)
+	coefficients[0] = d
+	var err error
+	for i := 1; i < len(coefficients); i++ {
+		if coefficients[i], err = core.Rand(m); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// V = r^2 mod N generates the squares of Z_N^* with overwhelming probability
+	r, err := core.Rand(n)
+	if err != nil {
+		return nil, nil, err
+	}
+	v := new(big.Int).Exp(r, core.Two, n)
+
+	shares := make([]*SecretKeyShare, limit)
+	verificationKeys := make(map[uint32]*big.Int, limit)
+	for i := range shares {
+		id := uint32(i + 1)
+		s := core.EvaluatePolynomial(coefficients, big.NewInt(int64(id)), m)
+		shares[i] = &SecretKeyShare{Id: id, Share: s}
+		verificationKeys[id] = new(big.Int).Exp(v, s, n)
+	}
+	return &PublicKey{
+		N:                n,
+		E:                e,
+		Threshold:        threshold,
+		Limit:            limit,
+		V:                v,
+		VerificationKeys: verificationKeys,
+	}, shares, nil
+}
+
+// RSAPublicKey returns the crypto/rsa public key that verifies the combined signatures
+func (pk *PublicKey) RSAPublicKey() *rsa.PublicKey {
+	return &rsa.PublicKey{N: new(big.Int).Set(pk.N), E: pk.E}
+}
+
+// Sign computes the signature share of the message representative x with a proof that it is correct.
+// Every signer must sign the same x, e.g. as encoded by PublicKey.EncodePKCS1v15 or PublicKey.EncodePSS.
+func (share *SecretKeyShare) Sign(pk *PublicKey, x *big.Int) (*SignatureShare, error) {
+	if share == nil || share.Share == nil || x == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if err := pk.validate(); err != nil {
+		return nil, err
+	}
+	vi, ok := pk.VerificationKeys[share.Id]
+	if !ok {
+		return nil, fmt.Errorf("no verification key for share %d", share.Id)
+	}
+	if err := checkUnit(x, pk.N); err != nil {
+		return nil, err
+	}
+
+	// x_i = x^{2Δ s_i} mod N
+	delta := core.Factorial(pk.Limit)
+	exp := new(big.Int).Mul(delta, share.Share)
+	xi := new(big.Int).Exp(x, exp.Lsh(exp, 1), pk.N)
+
+	// x' = (x^{4Δ})^r and v' = V^r
+	xTilde := pk.xTilde(x, delta)
+	r, err := core.Rand(new(big.Int).Lsh(core.One, pk.proofBits()))
+	if err != nil {
+		return nil, err
+	}
+	xPrime := new(big.Int).Exp(xTilde, r, pk.N)
+	vPrime := new(big.Int).Exp(pk.V, r, pk.N)
+	c, err := pk.challenge(xTilde, vi, new(big.Int).Exp(xi, core.Two, pk.N), vPrime, xPrime)
+	if err != nil {
+		return nil, err
+	}
+	// z = s_i c + r
+	z := new(big.Int).Mul(share.Share, c)
+	z.Add(z, r)
+	return &SignatureShare{
+		Id:    share.Id,
+		Value: xi,
+		Proof: &SignatureShareProof{C: c, Z: z},
+	}, nil
+}
+
+// Verify checks that ss is the signature share of x for the verification key of ss.Id
+func (ss *SignatureShare) Verify(pk *PublicKey, x *big.Int) error {
+	if ss == nil || ss.Proof == nil || core.AnyNil(ss.Value, ss.Proof.C, ss.Proof.Z, x) {
+		return internal.ErrNilArguments
+	}
+	if err := pk.validate(); err != nil {
+		return err
+	}
+	vi, ok := pk.VerificationKeys[ss.Id]
+	if !ok {
+		return fmt.Errorf("no verification key for share %d", ss.Id)
+	}
+	if err := checkUnit(x, pk.N); err != nil {
+		return err
+	}
+	if err := checkUnit(ss.Value, pk.N); err != nil {
+		return err
+	}
+	if ss.Proof.C.Sign() < 0 || ss.Proof.C.BitLen() > challengeBits ||
+		ss.Proof.Z.Sign() < 0 || ss.Proof.Z.BitLen() > int(pk.proofBits())+1 {
+		return fmt.Errorf("invalid signature share proof")
+	}
+	negC := new(big.Int).Neg(ss.Proof.C)
+
+	// v' = V^z V_i^-c
+	vPrime := new(big.Int).Exp(pk.V, ss.Proof.Z, pk.N)
+	vPrime.Mul(vPrime, new(big.Int).Exp(vi, negC, pk.N))
+	vPrime.Mod(vPrime, pk.N)
+
+	// x' = (x^{4Δ})^z (x_i^2)^-c
+	xTilde := pk.xTilde(x, core.Factorial(pk.Limit))
+	xi2 := new(big.Int).Exp(ss.Value, core.Two, pk.N)
+	xPrime := new(big.Int).Exp(xTilde, ss.Proof.Z, pk.N)
+	xPrime.Mul(xPrime, new(big.Int).Exp(xi2, negC, pk.N))
+	xPrime.Mod(xPrime, pk.N)
+
+	c, err := pk.challenge(xTilde, vi, xi2, vPrime, xPrime)
+	if err != nil {
+		return err
+	}
+	if c.Cmp(ss.Proof.C) != 0 {
+		return fmt.Errorf("invalid signature share proof")
+	}
+	return nil
+}
+
+// Combine verifies at least Threshold signature shares of x and returns the RSA signature
+// y = x^d mod N, left-padded to the size of N as crypto/rsa expects
+func (pk *PublicKey) Combine(x *big.Int, shares []*SignatureShare) ([]byte, error) {
+	if x == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if err := pk.validate(); err != nil {
+		return nil, err
+	}
+	ids := make([]uint32, 0, len(shares))
+	seen := make(map[uint32]bool, len(shares))
+	for _, ss := range shares {
+		if ss == nil {
+			return nil, internal.ErrNilArguments
+		}
+		if seen[ss.Id] {
+			return nil, fmt.Errorf("duplicate signature share from share %d", ss.Id)
+		}
+		if err := ss.Verify(pk, x); err != nil {
+			return nil, fmt.Errorf("invalid signature share from share %d: %v", ss.Id, err)
+		}
+		seen[ss.Id] = true
+		ids = append(ids, ss.Id)
+	}
+	if uint32(len(ids)) < pk.Threshold {
+		return nil, fmt.Errorf("need at least %d signature shares, got %d", pk.Threshold, len(ids))
+	}
+
+	// w = ∏ x_i^{2μ_i} = x^{4Δ²d} with the integer Lagrange coefficients μ_i = Δ λ_{0,i}
+	delta := core.Factorial(pk.Limit)
+	w := big.NewInt(1)
+	for _, ss := range shares {
+		mu := core.LagrangeCoefficient(delta, ss.Id, ids)
+		t := new(big.Int).Exp(ss.Value, mu.Lsh(mu, 1), pk.N)
+		if t == nil {
+			return nil, fmt.Errorf("invalid signature share from share %d", ss.Id)
+		}
+		w.Mul(w, t)
+		w.Mod(w, pk.N)
+	}
+
+	// w^e = x^{e'} with e' = 4Δ², so y = w^a x^b for ae' + be = 1
+	ePrime := new(big.Int).Mul(delta, delta)
+	ePrime.Lsh(ePrime, 2)
+	a, b := new(big.Int), new(big.Int)
+	if new(big.Int).GCD(a, b, ePrime, big.NewInt(int64(pk.E))).Cmp(core.One) != 0 {
+		return nil, fmt.Errorf("invalid threshold public key")
+	}
+	y := new(big.Int).Exp(w, a, pk.N)
+	xb := new(big.Int).Exp(x, b, pk.N)
+	if y == nil || xb == nil {
+		return nil, fmt.Errorf("invalid signature shares")
+	}
+	y.Mul(y, xb)
+	y.Mod(y, pk.N)
+
+	// y^e = x confirms the combination
+	if new(big.Int).Exp(y, big.NewInt(int64(pk.E)), pk.N).Cmp(x) != 0 {
+		return nil, fmt.Errorf("invalid signature")
+	}
+	return y.FillBytes(make([]byte, pk.size())), nil
+}
+
+// validate checks that pk has every value needed to verify signature shares
+func (pk *PublicKey) validate() error {
+	if pk == nil || core.AnyNil(pk.N, pk.V) {
+		return internal.ErrNilArguments
+	}
+	if pk.Threshold < 1 || pk.Limit < pk.Threshold || pk.Limit > core.MaxShareLimit {
+		return fmt.Errorf("invalid threshold public key")
+	}
+	if pk.E <= int(pk.Limit) || pk.N.Sign() <= 0 {
+		return fmt.Errorf("invalid threshold public key")
+	}
+	for id, vi := range pk.VerificationKeys {
+		if vi == nil {
+			return fmt.Errorf("nil verification key for share %d", id)
+		}
+	}
+	return nil
+}
+
+// size returns the length of N in bytes
+func (pk *PublicKey) size() int {
+	return (pk.N.BitLen() + 7) / 8
+}
+
+// xTilde returns x^{4Δ} mod N
+func (pk *PublicKey) xTilde(x, delta *big.Int) *big.Int {
+	return new(big.Int).Exp(x, new(big.Int).Lsh(delta, 2), pk.N)
+}
+
+// proofBits is the bit length of the randomness in signature share proofs,
+// which statistically hides c s_i < 2^{challenge bits} N
+func (pk *PublicKey) proofBits() uint {
+	return uint(pk.N.BitLen() + 2*challengeBits)
+}
+
+// challenge is the Fiat-Shamir challenge of a signature share proof
+func (pk *PublicKey) challenge(xTilde, vi, xi2, vPrime, xPrime *big.Int) (*big.Int, error) {
+	c, err := core.FiatShamir(pk.N, big.NewInt(int64(pk.E)), pk.V, xTilde, vi, xi2, vPrime, xPrime)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(c), nil
+}
+
+// checkUnit checks that x ∈ Z_N^*
+func checkUnit(x, n *big.Int) error {
+	if err := core.In(x, n); err != nil {
+		return err
+	}
+	if new(big.Int).GCD(nil, nil, x, n).Cmp(core.One) != 0 {
+		return fmt.Errorf("value is not a unit mod N")
+	}
+	return nil
+}
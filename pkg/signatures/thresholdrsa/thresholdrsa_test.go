@@ -0,0 +1,212 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package thresholdrsa
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/TEENet-io/kryptology/internal"
+)
+
+// testPrimes are 1024-bit safe primes
+var testPrimes = []*big.Int{
+	internal.B10("186141419611617071752010179586510154515933389116254425631491755419216243670159714804545944298892950871169229878325987039840135057969555324774918895952900547869933648175107076399993833724447909579697857041081987997463765989497319509683575289675966710007879762972723174353568113668226442698275449371212397561567"),
+	internal.B10("94210786053667323206442523040419729883258172350738703980637961803118626748668924192069593010365236618255120977661397310932923345291377692570649198560048403943687994859423283474169530971418656709749020402756179383990602363122039939937953514870699284906666247063852187255623958659551404494107714695311474384687"),
+}
+
+func newTestKeys(t *testing.T, threshold, limit uint32) (*PublicKey, []*SecretKeyShare) {
+	pk, shares, err := NewKeysFromPrimes(testPrimes[0], testPrimes[1], DefaultExponent, threshold, limit)
+	require.NoError(t, err)
+	require.Len(t, shares, int(limit))
+	return pk, shares
+}
+
+// sign has the shares with the given ids sign x and combines their signature shares
+func sign(t *testing.T, pk *PublicKey, shares []*SecretKeyShare, x *big.Int, ids ...uint32) []byte {
+	signatureShares := make([]*SignatureShare, 0, len(ids))
+	for _, id := range ids {
+		ss, err := shares[id-1].Sign(pk, x)
+		require.NoError(t, err)
+		require.NoError(t, ss.Verify(pk, x))
+		signatureShares = append(signatureShares, ss)
+	}
+	signature, err := pk.Combine(x, signatureShares)
+	require.NoError(t, err)
+	return signature
+}
+
+func TestThresholdRsaPKCS1v15(t *testing.T) {
+	pk, shares := newTestKeys(t, 3, 5)
+	hashed := sha256.Sum256([]byte("It is not good to have a rule of many."))
+	x, err := pk.EncodePKCS1v15(crypto.SHA256, hashed[:])
+	require.NoError(t, err)
+
+	// PKCS#1 v1.5 signatures are deterministic, so they equal those of the whole key
+	n := new(big.Int).Mul(testPrimes[0], testPrimes[1])
+	sk := &rsa.PrivateKey{
+		PublicKey: rsa.PublicKey{N: n, E: DefaultExponent},
+		Primes:    []*big.Int{testPrimes[0], testPrimes[1]},
+	}
+	sk.D = new(big.Int).ModInverse(big.NewInt(DefaultExponent), new(big.Int).Mul(
+		new(big.Int).Sub(testPrimes[0], big.NewInt(1)), new(big.Int).Sub(testPrimes[1], big.NewInt(1))))
+	sk.Precompute()
+	expected, err := rsa.SignPKCS1v15(nil, sk, crypto.SHA256, hashed[:])
+	require.NoError(t, err)
+
+	for _, ids := range [][]uint32{{1, 2, 3}, {5, 3, 1}, {2, 3, 4, 5}} {
+		signature := sign(t, pk, shares, x, ids...)
+		require.NoError(t, rsa.VerifyPKCS1v15(pk.RSAPublicKey(), crypto.SHA256, hashed[:], signature))
+		require.Equal(t, expected, signature)
+	}
+}
+
+func TestThresholdRsaPSS(t *testing.T) {
+	pk, shares := newTestKeys(t, 2, 3)
+	hashed := sha512.Sum384([]byte("I will be brief. Your noble son is mad."))
+	salt := make([]byte, crypto.SHA384.Size())
+	_, err := rand.Read(salt)
+	require.NoError(t, err)
+	x, err := pk.EncodePSS(crypto.SHA384, hashed[:], salt)
+	require.NoError(t, err)
+
+	signature := sign(t, pk, shares, x, 3, 1)
+	require.NoError(t, rsa.VerifyPSS(pk.RSAPublicKey(), crypto.SHA384, hashed[:], signature,
+		&rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash}))
+	require.NoError(t, rsa.VerifyPSS(pk.RSAPublicKey(), crypto.SHA384, hashed[:], signature,
+		&rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthAuto}))
+
+	// Another message does not verify
+	other := sha512.Sum384([]byte("other message"))
+	require.Error(t, rsa.VerifyPSS(pk.RSAPublicKey(), crypto.SHA384, other[:], signature, nil))
+}
+
+func TestThresholdRsaOneOfOne(t *testing.T) {
+	pk, shares := newTestKeys(t, 1, 1)
+	hashed := sha256.Sum256([]byte("one of one"))
+	x, err := pk.EncodePKCS1v15(crypto.SHA256, hashed[:])
+	require.NoError(t, err)
+	signature := sign(t, pk, shares, x, 1)
+	require.NoError(t, rsa.VerifyPKCS1v15(pk.RSAPublicKey(), crypto.SHA256, hashed[:], signature))
+}
+
+func TestNewKeys(t *testing.T) {
+	pk, shares, err := NewKeys(1024, 2, 3)
+	require.NoError(t, err)
+	require.Equal(t, 1024, pk.N.BitLen())
+	hashed := sha256.Sum256([]byte("new keys"))
+	x, err := pk.EncodePKCS1v15(crypto.SHA256, hashed[:])
+	require.NoError(t, err)
+	signature := sign(t, pk, shares, x, 1, 3)
+	require.NoError(t, rsa.VerifyPKCS1v15(pk.RSAPublicKey(), crypto.SHA256, hashed[:], signature))
+
+	_, _, err = NewKeys(512, 2, 3)
+	require.Error(t, err)
+}
+
+func TestNewKeysFromPrimesErrors(t *testing.T) {
+	p, q := testPrimes[0], testPrimes[1]
+	_, _, err := NewKeysFromPrimes(nil, q, DefaultExponent, 2, 3)
+	require.Equal(t, internal.ErrNilArguments, err)
+	_, _, err = NewKeysFromPrimes(p, q, DefaultExponent, 3, 2)
+	require.Error(t, err)
+	_, _, err = NewKeysFromPrimes(p, q, DefaultExponent, 0, 2)
+	require.Error(t, err)
+	_, _, err = NewKeysFromPrimes(p, q, DefaultExponent, 2, 256)
+	require.Error(t, err)
+	// e must be a prime larger than limit
+	_, _, err = NewKeysFromPrimes(p, q, 65535, 2, 3)
+	require.Error(t, err)
+	_, _, err = NewKeysFromPrimes(p, q, 3, 2, 3)
+	require.Error(t, err)
+	_, _, err = NewKeysFromPrimes(p, p, DefaultExponent, 2, 3)
+	require.Error(t, err)
+	// p + 2 is not a safe prime
+	_, _, err = NewKeysFromPrimes(new(big.Int).Add(p, big.NewInt(2)), q, DefaultExponent, 2, 3)
+	require.Error(t, err)
+}
+
+func TestCombineErrors(t *testing.T) {
+	pk, shares := newTestKeys(t, 3, 5)
+	hashed := sha256.Sum256([]byte("combine"))
+	x, err := pk.EncodePKCS1v15(crypto.SHA256, hashed[:])
+	require.NoError(t, err)
+	signatureShares := make([]*SignatureShare, 3)
+	for i := range signatureShares {
+		signatureShares[i], err = shares[i].Sign(pk, x)
+		require.NoError(t, err)
+	}
+
+	// Too few shares
+	_, err = pk.Combine(x, signatureShares[:2])
+	require.Error(t, err)
+
+	// Duplicate shares
+	_, err = pk.Combine(x, []*SignatureShare{signatureShares[0], signatureShares[1], signatureShares[1]})
+	require.Error(t, err)
+
+	// Shares of another message
+	y := new(big.Int).Add(x, big.NewInt(1))
+	require.Error(t, signatureShares[0].Verify(pk, y))
+	_, err = pk.Combine(y, signatureShares)
+	require.Error(t, err)
+
+	// A tampered share value
+	tampered := *signatureShares[2]
+	tampered.Value = new(big.Int).Mul(tampered.Value, tampered.Value)
+	tampered.Value.Mod(tampered.Value, pk.N)
+	require.Error(t, tampered.Verify(pk, x))
+	_, err = pk.Combine(x, []*SignatureShare{signatureShares[0], signatureShares[1], &tampered})
+	require.Error(t, err)
+
+	// A share whose proof claims another id
+	tampered = *signatureShares[2]
+	tampered.Id = 4
+	require.Error(t, tampered.Verify(pk, x))
+
+	// A tampered proof
+	tampered = *signatureShares[2]
+	tampered.Proof = &SignatureShareProof{C: signatureShares[2].Proof.C, Z: new(big.Int).Add(signatureShares[2].Proof.Z, big.NewInt(1))}
+	require.Error(t, tampered.Verify(pk, x))
+
+	// Unknown share
+	_, err = (&SecretKeyShare{Id: 6, Share: big.NewInt(1)}).Sign(pk, x)
+	require.Error(t, err)
+
+	// x is not a unit
+	_, err = shares[0].Sign(pk, testPrimes[0])
+	require.Error(t, err)
+	_, err = shares[0].Sign(pk, pk.N)
+	require.Error(t, err)
+}
+
+func TestEncodeErrors(t *testing.T) {
+	pk, _ := newTestKeys(t, 1, 1)
+	hashed := sha256.Sum256([]byte("encode"))
+	_, err := pk.EncodePKCS1v15(crypto.SHA256, hashed[:16])
+	require.Error(t, err)
+	_, err = pk.EncodePKCS1v15(crypto.MD4, hashed[:16])
+	require.Error(t, err)
+	_, err = pk.EncodePKCS1v15(0, make([]byte, pk.size()))
+	require.Error(t, err)
+	_, err = pk.EncodePSS(crypto.SHA256, hashed[:16], nil)
+	require.Error(t, err)
+	_, err = pk.EncodePSS(crypto.SHA256, hashed[:], make([]byte, pk.size()))
+	require.Error(t, err)
+
+	// An empty salt is allowed
+	x, err := pk.EncodePSS(crypto.SHA256, hashed[:], nil)
+	require.NoError(t, err)
+	require.True(t, x.Cmp(pk.N) < 0)
+}
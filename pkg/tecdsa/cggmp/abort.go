@@ -0,0 +1,55 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package cggmp
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// AbortError is returned by a round that failed because a specific party sent an invalid message.
+// The caller can exclude the Culprit and restart the protocol with the remaining parties.
+//
+// Two checks cannot be attributed to a single party from public values and are returned as plain
+// errors: g^δ != ∏Δ_j in presigning round 4, and the product of the S_j not being the public key.
+// Both fail before any signature share is released.
+type AbortError struct {
+	Protocol string
+	Round    uint
+	Culprit  uint32
+	err      error
+}
+
+// Error returns the reason of the abort.
+func (e *AbortError) Error() string {
+	return fmt.Sprintf("%s round %d aborted by party %d: %v", e.Protocol, e.Round, e.Culprit, e.err)
+}
+
+// Unwrap returns the underlying verification error.
+func (e *AbortError) Unwrap() error {
+	return e.err
+}
+
+// Culprit returns the id of the party that caused err, if err is or wraps an AbortError.
+func Culprit(err error) (uint32, bool) {
+	var abort *AbortError
+	if errors.As(err, &abort) {
+		return abort.Culprit, true
+	}
+	return 0, false
+}
+
+// newAbortError attributes err to the party culprit in a round of protocol.
+func newAbortError(protocol string, round uint, culprit uint32, err error) *AbortError {
+	return &AbortError{
+		Protocol: protocol,
+		Round:    round,
+		Culprit:  culprit,
+		err:      err,
+	}
+}
@@ -0,0 +1,244 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// Package cggmp is an implementation of the threshold ECDSA protocol of Canetti, Gennaro, Goldfeder,
+// Makriyannis and Peled [CGGMP21] https://eprint.iacr.org/2021/060.pdf
+//
+// All n parties take part in every protocol, and the signing key is additively shared among them:
+//   - KeygenParticipant generates a key share, fig 5
+//   - RefreshParticipant generates the auxiliary info (Paillier keys and Ring-Pedersen parameters, with
+//     their proofs) and refreshes the key shares, fig 7. It must be run once after key generation, and can be
+//     run again at any time to refresh the shares for proactive security
+//   - PresignParticipant computes a presignature independent of the message, fig 8
+//   - Presignature.Sign and Presignature.Output sign a message in one round, fig 9
+//
+// A round that fails because of a specific party returns an AbortError naming it.
+//
+// All messages are expected to be sent over authenticated channels, and P2P messages over private ones.
+// Each presignature can be used for a single signature.
+package cggmp
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"golang.org/x/crypto/sha3"
+
+	"github.com/TEENet-io/kryptology/internal"
+	"github.com/TEENet-io/kryptology/pkg/core"
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
+	"github.com/TEENet-io/kryptology/pkg/paillier"
+)
+
+// ridLength is the byte length of the random identifiers rid_i that are combined into the session's rid
+const ridLength = 32
+
+// KeyShare is the output of a party of key generation and refresh
+type KeyShare struct {
+	Curve *curves.Curve
+	ID    uint32
+	// Parties are the ids of all the parties sharing the key, including ID
+	Parties []uint32
+	// SecretShare is the additive share x_i of the signing key
+	SecretShare curves.Scalar
+	// PublicShares map each party to X_j = g^{x_j}
+	PublicShares map[uint32]curves.Point
+	PublicKey    curves.Point
+	// Rid is the random identifier agreed on by the last key generation or refresh
+	Rid []byte
+	// Auxiliary info, set by RefreshParticipant: the Paillier secret key of this party,
+	// and the Paillier keys and Ring-Pedersen parameters of all parties, including this one
+	PaillierKey  *paillier.SecretKey
+	PaillierKeys map[uint32]*paillier.PublicKey
+	RingPedersen map[uint32]*paillier.RingPedersenParams
+}
+
+// hasAuxInfo checks that the key share carries the auxiliary info of all parties
+func (ks *KeyShare) hasAuxInfo() bool {
+	if ks.PaillierKey == nil {
+		return false
+	}
+	for _, id := range ks.Parties {
+		if ks.PaillierKeys[id] == nil || ks.RingPedersen[id] == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// validate checks that the key share is consistent
+func (ks *KeyShare) validate() error {
+	if ks == nil || ks.SecretShare == nil || ks.PublicKey == nil || ks.PublicShares == nil {
+		return internal.ErrNilArguments
+	}
+	if err := checkCurve(ks.Curve); err != nil {
+		return err
+	}
+	if err := checkParties(ks.ID, ks.Parties); err != nil {
+		return err
+	}
+	for _, id := range ks.Parties {
+		if !onCurve(ks.Curve, ks.PublicShares[id]) {
+			return fmt.Errorf("invalid public share of party %d", id)
+		}
+	}
+	if !ks.Curve.ScalarBaseMult(ks.SecretShare).Equal(ks.PublicShares[ks.ID]) {
+		return fmt.Errorf("secret share does not match public share")
+	}
+	return nil
+}
+
+// checkCurve checks that curve is a 256-bit curve with a known order
+func checkCurve(curve *curves.Curve) error {
+	if curve == nil {
+		return internal.ErrNilArguments
+	}
+	ec, err := curve.ToEllipticCurve()
+	if err != nil {
+		return err
+	}
+	if ec.Params().BitSize != 256 {
+		return fmt.Errorf("invalid curve size")
+	}
+	return nil
+}
+
+// checkParties checks that the ids are distinct, non-zero, at least two and include id
+func checkParties(id uint32, parties []uint32) error {
+	if len(parties) < 2 {
+		return fmt.Errorf("at least two parties are required")
+	}
+	seen := make(map[uint32]bool, len(parties))
+	for _, j := range parties {
+		if j == 0 {
+			return fmt.Errorf("party id cannot be zero")
+		}
+		if seen[j] {
+			return fmt.Errorf("duplicate party id %d", j)
+		}
+		seen[j] = true
+	}
+	if !seen[id] {
+		return fmt.Errorf("parties do not include %d", id)
+	}
+	return nil
+}
+
+// checkInput checks that the map in has an entry for each party other than id.
+// An entry for id itself is allowed and ignored.
+func checkInput(id uint32, parties []uint32, in interface{}) error {
+	v := reflect.ValueOf(in)
+	if v.Kind() != reflect.Map {
+		return fmt.Errorf("parameter `in` must be a map; instead is %v", v.Kind())
+	}
+	expected := make(map[uint32]bool, len(parties))
+	for _, j := range parties {
+		expected[j] = true
+	}
+	for _, key := range v.MapKeys() {
+		if !expected[uint32(key.Uint())] {
+			return fmt.Errorf("party id=%v is not valid", key.Uint())
+		}
+	}
+	for _, j := range parties {
+		if j == id {
+			continue
+		}
+		if !v.MapIndex(reflect.ValueOf(j)).IsValid() {
+			return fmt.Errorf("missing input from party id=%v", j)
+		}
+	}
+	return nil
+}
+
+// curveOrder returns the order q of the group of a curve accepted by checkCurve
+func curveOrder(curve *curves.Curve) *big.Int {
+	ec, err := curve.ToEllipticCurve()
+	if err != nil {
+		return nil
+	}
+	return ec.Params().N
+}
+
+// affine returns the affine coordinates of a point of a curve accepted by checkCurve
+func affine(p curves.Point) (x, y *big.Int) {
+	b := p.ToAffineUncompressed()
+	fieldSize := (len(b) - 1) / 2
+	return new(big.Int).SetBytes(b[1 : fieldSize+1]), new(big.Int).SetBytes(b[fieldSize+1:])
+}
+
+// onCurve checks that p is a point of curve other than the identity
+func onCurve(curve *curves.Curve, p curves.Point) bool {
+	return p != nil && p.CurveName() == curve.Name && p.IsOnCurve() && !p.IsIdentity()
+}
+
+// randomNonZero samples a uniform non-zero scalar
+func randomNonZero(curve *curves.Curve) (curves.Scalar, error) {
+	for i := 0; i < 8; i++ {
+		k := curve.Scalar.Random(crand.Reader)
+		if k == nil {
+			return nil, fmt.Errorf("unable to sample a random scalar")
+		}
+		if !k.IsZero() {
+			return k, nil
+		}
+	}
+	return nil, fmt.Errorf("unable to sample a non-zero scalar")
+}
+
+// hashValues returns the SHA3-256 digest of the length-prefixed values
+func hashValues(values ...[]byte) []byte {
+	h := sha3.New256()
+	var length [8]byte
+	for _, v := range values {
+		binary.BigEndian.PutUint64(length[:], uint64(len(v)))
+		_, _ = h.Write(length[:])
+		_, _ = h.Write(v)
+	}
+	return h.Sum(nil)
+}
+
+// idBytes encodes a party id for hashing
+func idBytes(id uint32) []byte {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], id)
+	return b[:]
+}
+
+// xorRid combines the rid_j of all parties
+func xorRid(rids map[uint32][]byte) ([]byte, error) {
+	rid := make([]byte, ridLength)
+	for j, r := range rids {
+		if len(r) != ridLength {
+			return nil, fmt.Errorf("invalid rid from party %d", j)
+		}
+		for k := range rid {
+			rid[k] ^= r[k]
+		}
+	}
+	return rid, nil
+}
+
+// openCommitment checks that the witness opens the commitment to digest
+func openCommitment(c core.Commitment, w *core.Witness, digest []byte) error {
+	if c == nil || w == nil {
+		return internal.ErrNilArguments
+	}
+	if string(w.Msg) != string(digest) {
+		return fmt.Errorf("decommitment does not match the revealed values")
+	}
+	ok, err := core.Open(c, *w)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("invalid decommitment")
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package cggmp
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	tt "github.com/TEENet-io/kryptology/internal"
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
+)
+
+// 1024-bit safe primes, so that each Paillier modulus has at least 2048 bits
+var testPrimes = []*big.Int{
+	tt.B10("186141419611617071752010179586510154515933389116254425631491755419216243670159714804545944298892950871169229878325987039840135057969555324774918895952900547869933648175107076399993833724447909579697857041081987997463765989497319509683575289675966710007879762972723174353568113668226442698275449371212397561567"),
+	tt.B10("94210786053667323206442523040419729883258172350738703980637961803118626748668924192069593010365236618255120977661397310932923345291377692570649198560048403943687994859423283474169530971418656709749020402756179383990602363122039939937953514870699284906666247063852187255623958659551404494107714695311474384687"),
+	tt.B10("130291226847076770981564372061529572170236135412763130013877155698259035960569046218348763182598589633420963942796327547969527085797839549642610021986391589746295634536750785366034581957858065740296991986002552598751827526181747791647357767502200771965093659353354985289411489453223546075843993686648576029043"),
+	tt.B10("172938910323633442195852028319756134734590277522945546987913328782597284762767185925315797321999389252040294991952361905020940252121762387957669654615602135429944435719699091344247805645764550860505536884031064967454028383404046221898300153428182409080298694828920944094158777327533157774919783417586902830043"),
+	tt.B10("135841191929788643010555393808775051922265083622266098277752143441294911675705272940799534437169053045878247274810449617960047255023823301284034559807472662111224710158898548617194658983006262996831617082584649612602010680423107108651221824216065228161009680618243402116924511141821829055830713600437589058643"),
+	tt.B10("179677777376220950493907657233669314916823596507009854134559513388779535023958212632715646194917807302098015450071151245496651913873851032302340489007561121851068326577148680474495447007833318066335149850926605897908761267606415610900931306044455332084757793630487163583451178807470499389106913845684353833379"),
+	tt.B10("196576931859098680370388202020086631604584490828609819764890020064880575503817891126703473215983239396058738287255240835101797315137072822716923594188151190460588551553676484461393180135097616711975997391550414447010491794087888246885960280296709672609456539741162207414899687167396008233995214434586323322859"),
+	tt.B10("271336420864746369701165973306090650688066226258594853124089876839120277465060891854507381090238664515950686049792387028144049076707224579184820539700879884119579186284072404459682082855184644444282438298561112002507411996589407330801765394106772460665497195944412067027079123717579308322520985921886949051399"),
+	tt.B10("147653127360336844448178027222853805809444645720500374788954343695331927468524513989671450440433430392339037667457657655958027740671071573403925974795764987870476118984896439440386146680643457835633462311776946902713168513155240275028008685964121441954481847113848701823211862974120297600518927026940189810103"),
+	tt.B10("311771090987243597109711542316907830756641693311804000593662622484722315782429237915515708860530841821213561483232298821623675096481796856960171671330638042763441430256097782130268494276848432981045602236986861083392706904041234926428759947857376161689191720483868111001987710383245853931937989224732484206639"),
+	tt.B10("348545239501897032367950520763624245702184225360238826931782856428685149253861325854706825698843098817604431561258712026020688621010635185480321876001016614912927680387840531641703966894322797491484955817022624047355473480912508041252361257911175397626575812830091471419378132244146077774966527307225203863239"),
+	tt.B10("167562983031509383478485987630533113343120902430985961468758712448125734458812918541051012669749885569679178971612428577288632429606851871845164719448590160530844833425628143996971699662729056519326776907622035340086832629206691942750594912221135787534670122007438859975313187460872690748138136170080913902203"),
+}
+
+// testPrimeGenerator returns the safe primes in order, in place of core.GenerateSafePrime
+func testPrimeGenerator(primes ...*big.Int) func(uint) (*big.Int, error) {
+	var mu sync.Mutex
+	next := 0
+	return func(uint) (*big.Int, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if next == len(primes) {
+			return nil, fmt.Errorf("out of test primes")
+		}
+		next++
+		return primes[next-1], nil
+	}
+}
+
+// runKeygen runs key generation among parties and returns their key shares
+func runKeygen(t *testing.T, curve *curves.Curve, parties []uint32) map[uint32]*KeyShare {
+	sid := []byte("keygen test session")
+	participants := make(map[uint32]*KeygenParticipant, len(parties))
+	for _, id := range parties {
+		kp, err := NewKeygenParticipant(curve, id, parties, sid)
+		require.NoError(t, err)
+		participants[id] = kp
+	}
+
+	r1 := make(map[uint32]*KeygenRound1Bcast, len(parties))
+	for id, kp := range participants {
+		bcast, err := kp.Round1()
+		require.NoError(t, err)
+		r1[id] = bcast
+	}
+	r2 := make(map[uint32]*KeygenRound2Bcast, len(parties))
+	for id, kp := range participants {
+		bcast, err := kp.Round2(r1)
+		require.NoError(t, err)
+		r2[id] = bcast
+	}
+	r3 := make(map[uint32]*KeygenRound3Bcast, len(parties))
+	for id, kp := range participants {
+		bcast, err := kp.Round3(r2)
+		require.NoError(t, err)
+		r3[id] = bcast
+	}
+	shares := make(map[uint32]*KeyShare, len(parties))
+	for id, kp := range participants {
+		share, err := kp.Output(r3)
+		require.NoError(t, err)
+		shares[id] = share
+	}
+	return shares
+}
+
+// newTestRefreshParticipants creates refresh participants that take their Paillier primes
+// from testPrimes, starting at offset
+func newTestRefreshParticipants(t *testing.T, shares map[uint32]*KeyShare, offset int) map[uint32]*RefreshParticipant {
+	participants := make(map[uint32]*RefreshParticipant, len(shares))
+	for i, id := range shares[firstParty(shares)].Parties {
+		p := offset + 2*i
+		rp, err := newRefreshParticipant(shares[id], []byte("refresh test session"),
+			testPrimeGenerator(testPrimes[p], testPrimes[p+1]))
+		require.NoError(t, err)
+		participants[id] = rp
+	}
+	return participants
+}
+
+// runRefresh runs auxiliary info and key refresh and returns the refreshed key shares
+func runRefresh(t *testing.T, shares map[uint32]*KeyShare, offset int) map[uint32]*KeyShare {
+	participants := newTestRefreshParticipants(t, shares, offset)
+	r1 := make(map[uint32]*RefreshRound1Bcast, len(participants))
+	for id, rp := range participants {
+		bcast, err := rp.Round1()
+		require.NoError(t, err)
+		r1[id] = bcast
+	}
+	r2 := make(map[uint32]*RefreshRound2Bcast, len(participants))
+	for id, rp := range participants {
+		bcast, err := rp.Round2(r1)
+		require.NoError(t, err)
+		r2[id] = bcast
+	}
+	r3 := make(map[uint32]*RefreshRound3Bcast, len(participants))
+	r3p2p := make(map[uint32]map[uint32]*RefreshRound3P2PSend, len(participants))
+	for id, rp := range participants {
+		bcast, p2p, err := rp.Round3(r2)
+		require.NoError(t, err)
+		r3[id] = bcast
+		r3p2p[id] = p2p
+	}
+	refreshed := make(map[uint32]*KeyShare, len(participants))
+	for id, rp := range participants {
+		p2p := make(map[uint32]*RefreshRound3P2PSend, len(participants)-1)
+		for from, sent := range r3p2p {
+			if from != id {
+				p2p[from] = sent[id]
+			}
+		}
+		share, err := rp.Output(r3, p2p)
+		require.NoError(t, err)
+		refreshed[id] = share
+	}
+	return refreshed
+}
+
+// firstParty returns the smallest id of shares
+func firstParty(shares map[uint32]*KeyShare) uint32 {
+	first := uint32(0)
+	for id := range shares {
+		if first == 0 || id < first {
+			first = id
+		}
+	}
+	return first
+}
+
+// testHash is the digest signed in the tests
+func testHash(msg string) []byte {
+	h := sha256.Sum256([]byte(msg))
+	return h[:]
+}
+
+func TestCheckParties(t *testing.T) {
+	require.NoError(t, checkParties(1, []uint32{1, 2}))
+	require.Error(t, checkParties(1, []uint32{1}))
+	require.Error(t, checkParties(3, []uint32{1, 2}))
+	require.Error(t, checkParties(1, []uint32{1, 1}))
+	require.Error(t, checkParties(1, []uint32{0, 1}))
+}
+
+func TestCheckInput(t *testing.T) {
+	parties := []uint32{1, 2, 3}
+	require.NoError(t, checkInput(1, parties, map[uint32]*KeygenRound1Bcast{2: nil, 3: nil}))
+	require.NoError(t, checkInput(1, parties, map[uint32]*KeygenRound1Bcast{1: nil, 2: nil, 3: nil}))
+	require.Error(t, checkInput(1, parties, map[uint32]*KeygenRound1Bcast{2: nil}))
+	require.Error(t, checkInput(1, parties, map[uint32]*KeygenRound1Bcast{2: nil, 3: nil, 4: nil}))
+	require.Error(t, checkInput(1, parties, []uint32{2, 3}))
+}
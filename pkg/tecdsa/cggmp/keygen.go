@@ -0,0 +1,237 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package cggmp
+
+import (
+	crand "crypto/rand"
+	"fmt"
+
+	"github.com/TEENet-io/kryptology/internal"
+	"github.com/TEENet-io/kryptology/pkg/core"
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
+	"github.com/TEENet-io/kryptology/pkg/zkp/schnorr"
+)
+
+const keygenProtocol = "keygen"
+
+// KeygenParticipant is a party of CGGMP21 key generation
+// [CGGMP21] fig 5
+type KeygenParticipant struct {
+	Curve *curves.Curve
+	ID    uint32
+	Round uint
+	// parties are the ids of all the parties, including ID
+	parties []uint32
+	sid     []byte
+	state   *keygenState
+}
+
+// keygenState holds the values accumulated during the key generation rounds
+type keygenState struct {
+	// Round 1 variables
+	x       curves.Scalar
+	X       curves.Point
+	rid     []byte
+	witness *core.Witness
+	// Round 2 variables
+	commitments map[uint32]core.Commitment
+	// Round 3 variables
+	publicShares map[uint32]curves.Point
+	rids         map[uint32][]byte
+	combinedRid  []byte
+}
+
+// KeygenRound1Bcast contains values to be broadcast to all parties after the completion of keygen round 1
+type KeygenRound1Bcast struct {
+	Commitment core.Commitment
+}
+
+// KeygenRound2Bcast contains values to be broadcast to all parties after the completion of keygen round 2
+type KeygenRound2Bcast struct {
+	Rid         []byte
+	PublicShare curves.Point
+	Witness     *core.Witness
+}
+
+// KeygenRound3Bcast contains values to be broadcast to all parties after the completion of keygen round 3
+type KeygenRound3Bcast struct {
+	Proof *schnorr.Proof
+}
+
+// NewKeygenParticipant creates a party of key generation among parties, which must include id.
+// sid is a session identifier that all parties agree on and that is unique to this execution
+func NewKeygenParticipant(curve *curves.Curve, id uint32, parties []uint32, sid []byte) (*KeygenParticipant, error) {
+	if len(sid) == 0 {
+		return nil, internal.ErrNilArguments
+	}
+	if err := checkCurve(curve); err != nil {
+		return nil, err
+	}
+	if err := checkParties(id, parties); err != nil {
+		return nil, err
+	}
+	return &KeygenParticipant{
+		Curve:   curve,
+		ID:      id,
+		Round:   1,
+		parties: append([]uint32{}, parties...),
+		sid:     append([]byte{}, sid...),
+		state:   &keygenState{},
+	}, nil
+}
+
+// Round1 samples the share x_i and rid_i, and commits to them
+// [CGGMP21] fig 5 round 1
+func (kp *KeygenParticipant) Round1() (*KeygenRound1Bcast, error) {
+	if kp.Round != 1 {
+		return nil, internal.ErrInvalidRound
+	}
+
+	// Sample x_i ← F_q, rid_i ← {0,1}^κ and set X_i = g^{x_i}
+	x, err := randomNonZero(kp.Curve)
+	if err != nil {
+		return nil, err
+	}
+	rid := make([]byte, ridLength)
+	if _, err = crand.Read(rid); err != nil {
+		return nil, err
+	}
+	X := kp.Curve.ScalarBaseMult(x)
+
+	// V_i = H(sid, i, rid_i, X_i)
+	c, w, err := core.Commit(keygenDigest(kp.sid, kp.ID, rid, X))
+	if err != nil {
+		return nil, err
+	}
+
+	kp.state.x = x
+	kp.state.X = X
+	kp.state.rid = rid
+	kp.state.witness = w
+	kp.Round = 2
+	return &KeygenRound1Bcast{Commitment: c}, nil
+}
+
+// Round2 stores the commitments of the other parties and reveals rid_i and X_i
+// [CGGMP21] fig 5 round 2
+func (kp *KeygenParticipant) Round2(in map[uint32]*KeygenRound1Bcast) (*KeygenRound2Bcast, error) {
+	if kp.Round != 2 {
+		return nil, internal.ErrInvalidRound
+	}
+	if err := checkInput(kp.ID, kp.parties, in); err != nil {
+		return nil, err
+	}
+	kp.state.commitments = make(map[uint32]core.Commitment, len(kp.parties)-1)
+	for _, j := range kp.parties {
+		if j == kp.ID {
+			continue
+		}
+		if in[j] == nil || len(in[j].Commitment) == 0 {
+			return nil, newAbortError(keygenProtocol, 2, j, internal.ErrNilArguments)
+		}
+		kp.state.commitments[j] = in[j].Commitment
+	}
+	kp.Round = 3
+	return &KeygenRound2Bcast{
+		Rid:         kp.state.rid,
+		PublicShare: kp.state.X,
+		Witness:     kp.state.witness,
+	}, nil
+}
+
+// Round3 checks the decommitments, computes rid and proves knowledge of x_i
+// [CGGMP21] fig 5 round 3
+func (kp *KeygenParticipant) Round3(in map[uint32]*KeygenRound2Bcast) (*KeygenRound3Bcast, error) {
+	if kp.Round != 3 {
+		return nil, internal.ErrInvalidRound
+	}
+	if err := checkInput(kp.ID, kp.parties, in); err != nil {
+		return nil, err
+	}
+	kp.state.publicShares = map[uint32]curves.Point{kp.ID: kp.state.X}
+	kp.state.rids = map[uint32][]byte{kp.ID: kp.state.rid}
+	for _, j := range kp.parties {
+		if j == kp.ID {
+			continue
+		}
+		msg := in[j]
+		if msg == nil || !onCurve(kp.Curve, msg.PublicShare) || len(msg.Rid) != ridLength {
+			return nil, newAbortError(keygenProtocol, 3, j, fmt.Errorf("invalid round 2 broadcast"))
+		}
+		digest := keygenDigest(kp.sid, j, msg.Rid, msg.PublicShare)
+		if err := openCommitment(kp.state.commitments[j], msg.Witness, digest); err != nil {
+			return nil, newAbortError(keygenProtocol, 3, j, err)
+		}
+		kp.state.publicShares[j] = msg.PublicShare
+		kp.state.rids[j] = msg.Rid
+	}
+
+	// rid = ⊕_j rid_j
+	rid, err := xorRid(kp.state.rids)
+	if err != nil {
+		return nil, err
+	}
+	kp.state.combinedRid = rid
+
+	// ψ_i = M(prove, Π^sch_(sid, i, rid), X_i; x_i)
+	proof, err := schnorr.NewProver(kp.Curve, nil, keygenSessionId(kp.sid, rid, kp.ID)).Prove(kp.state.x)
+	if err != nil {
+		return nil, err
+	}
+	kp.Round = 4
+	return &KeygenRound3Bcast{Proof: proof}, nil
+}
+
+// Output verifies the proofs of the other parties and returns the key share
+// [CGGMP21] fig 5 output
+func (kp *KeygenParticipant) Output(in map[uint32]*KeygenRound3Bcast) (*KeyShare, error) {
+	if kp.Round != 4 {
+		return nil, internal.ErrInvalidRound
+	}
+	if err := checkInput(kp.ID, kp.parties, in); err != nil {
+		return nil, err
+	}
+	publicKey := kp.state.X
+	for _, j := range kp.parties {
+		if j == kp.ID {
+			continue
+		}
+		X := kp.state.publicShares[j]
+		if in[j] == nil || in[j].Proof == nil || in[j].Proof.Statement == nil || !in[j].Proof.Statement.Equal(X) {
+			return nil, newAbortError(keygenProtocol, 4, j, fmt.Errorf("invalid schnorr proof"))
+		}
+		err := schnorr.Verify(in[j].Proof, kp.Curve, nil, keygenSessionId(kp.sid, kp.state.combinedRid, j))
+		if err != nil {
+			return nil, newAbortError(keygenProtocol, 4, j, err)
+		}
+		publicKey = publicKey.Add(X)
+	}
+	if publicKey.IsIdentity() {
+		return nil, fmt.Errorf("public key is the identity")
+	}
+
+	kp.Round = 5
+	return &KeyShare{
+		Curve:        kp.Curve,
+		ID:           kp.ID,
+		Parties:      append([]uint32{}, kp.parties...),
+		SecretShare:  kp.state.x,
+		PublicShares: kp.state.publicShares,
+		PublicKey:    publicKey,
+		Rid:          kp.state.combinedRid,
+	}, nil
+}
+
+// keygenDigest is the value committed to in round 1
+func keygenDigest(sid []byte, id uint32, rid []byte, X curves.Point) []byte {
+	return hashValues(sid, idBytes(id), rid, X.ToAffineCompressed())
+}
+
+// keygenSessionId binds the schnorr proof of a party to the session and to rid
+func keygenSessionId(sid, rid []byte, id uint32) []byte {
+	return hashValues([]byte(keygenProtocol), sid, rid, idBytes(id))
+}
@@ -0,0 +1,131 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package cggmp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/TEENet-io/kryptology/internal"
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
+)
+
+func TestKeygenWorks(t *testing.T) {
+	for _, curve := range []*curves.Curve{curves.K256(), curves.P256()} {
+		parties := []uint32{1, 2, 3}
+		shares := runKeygen(t, curve, parties)
+		require.Len(t, shares, 3)
+
+		secret := curve.Scalar.Zero()
+		for _, id := range parties {
+			share := shares[id]
+			require.NoError(t, share.validate())
+			require.True(t, share.PublicKey.Equal(shares[1].PublicKey))
+			require.Equal(t, shares[1].Rid, share.Rid)
+			require.False(t, share.hasAuxInfo())
+			secret = secret.Add(share.SecretShare)
+		}
+		require.True(t, curve.ScalarBaseMult(secret).Equal(shares[1].PublicKey))
+	}
+}
+
+func TestNewKeygenParticipantErrors(t *testing.T) {
+	sid := []byte("sid")
+	_, err := NewKeygenParticipant(nil, 1, []uint32{1, 2}, sid)
+	require.Error(t, err)
+	_, err = NewKeygenParticipant(curves.ED25519(), 1, []uint32{1, 2}, sid)
+	require.Error(t, err)
+	_, err = NewKeygenParticipant(curves.K256(), 1, []uint32{1, 2}, nil)
+	require.Error(t, err)
+	_, err = NewKeygenParticipant(curves.K256(), 3, []uint32{1, 2}, sid)
+	require.Error(t, err)
+}
+
+func TestKeygenInvalidRound(t *testing.T) {
+	kp, err := NewKeygenParticipant(curves.K256(), 1, []uint32{1, 2}, []byte("sid"))
+	require.NoError(t, err)
+	_, err = kp.Round2(map[uint32]*KeygenRound1Bcast{})
+	require.Equal(t, internal.ErrInvalidRound, err)
+	_, err = kp.Round1()
+	require.NoError(t, err)
+	_, err = kp.Round1()
+	require.Equal(t, internal.ErrInvalidRound, err)
+}
+
+func TestKeygenCulprits(t *testing.T) {
+	curve := curves.K256()
+	parties := []uint32{1, 2, 3}
+	sid := []byte("keygen test session")
+	setup := func() (map[uint32]*KeygenParticipant, map[uint32]*KeygenRound2Bcast) {
+		participants := make(map[uint32]*KeygenParticipant, len(parties))
+		r1 := make(map[uint32]*KeygenRound1Bcast, len(parties))
+		for _, id := range parties {
+			kp, err := NewKeygenParticipant(curve, id, parties, sid)
+			require.NoError(t, err)
+			participants[id] = kp
+			r1[id], err = kp.Round1()
+			require.NoError(t, err)
+		}
+		r2 := make(map[uint32]*KeygenRound2Bcast, len(parties))
+		for id, kp := range participants {
+			var err error
+			r2[id], err = kp.Round2(r1)
+			require.NoError(t, err)
+		}
+		return participants, r2
+	}
+
+	t.Run("changed public share", func(t *testing.T) {
+		participants, r2 := setup()
+		r2[2] = &KeygenRound2Bcast{
+			Rid:         r2[2].Rid,
+			PublicShare: r2[2].PublicShare.Double(),
+			Witness:     r2[2].Witness,
+		}
+		_, err := participants[1].Round3(r2)
+		culprit, ok := Culprit(err)
+		require.True(t, ok)
+		require.Equal(t, uint32(2), culprit)
+	})
+
+	t.Run("changed rid", func(t *testing.T) {
+		participants, r2 := setup()
+		rid := append([]byte{}, r2[3].Rid...)
+		rid[0] ^= 1
+		r2[3] = &KeygenRound2Bcast{Rid: rid, PublicShare: r2[3].PublicShare, Witness: r2[3].Witness}
+		_, err := participants[1].Round3(r2)
+		culprit, ok := Culprit(err)
+		require.True(t, ok)
+		require.Equal(t, uint32(3), culprit)
+	})
+
+	t.Run("invalid schnorr proof", func(t *testing.T) {
+		participants, r2 := setup()
+		r3 := make(map[uint32]*KeygenRound3Bcast, len(parties))
+		for id, kp := range participants {
+			var err error
+			r3[id], err = kp.Round3(r2)
+			require.NoError(t, err)
+		}
+		// A proof from another party is bound to its own id
+		r3[2] = r3[3]
+		_, err := participants[1].Output(r3)
+		culprit, ok := Culprit(err)
+		require.True(t, ok)
+		require.Equal(t, uint32(2), culprit)
+	})
+
+	t.Run("missing party", func(t *testing.T) {
+		participants, r2 := setup()
+		delete(r2, 3)
+		_, err := participants[1].Round3(r2)
+		require.Error(t, err)
+		_, ok := Culprit(err)
+		require.False(t, ok)
+	})
+}
@@ -0,0 +1,601 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package cggmp
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/TEENet-io/kryptology/internal"
+	"github.com/TEENet-io/kryptology/pkg/core"
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
+	"github.com/TEENet-io/kryptology/pkg/paillier"
+	"github.com/TEENet-io/kryptology/pkg/tecdsa/cggmp/proof"
+)
+
+const presignProtocol = "presign"
+
+// PresignParticipant is a party of CGGMP21 presigning
+// [CGGMP21] fig 8
+//
+// The three rounds of the paper are followed by a fourth round in which each party publishes
+// S_i = R^{χ_i}, so that signature shares can be checked one by one and an invalid one attributed
+// to its sender without the expensive identification proofs of the paper.
+type PresignParticipant struct {
+	Round uint
+	share *KeyShare
+	// ssid binds the range proofs to this execution and to the rid of the key share
+	ssid  []byte
+	state *presignState
+}
+
+// presignState holds the values accumulated during the presigning rounds
+type presignState struct {
+	// Round 1 variables
+	k, gamma curves.Scalar
+	// K_i = enc_i(k_i; ρ_i) and G_i = enc_i(γ_i; ν_i)
+	K, G     paillier.Ciphertext
+	rho, nu  *big.Int
+	Ks, Gs   map[uint32]paillier.Ciphertext
+	Gamma    curves.Point
+	betas    map[uint32]*big.Int
+	betaHats map[uint32]*big.Int
+	// Round 3 variables
+	Gammas   map[uint32]curves.Point
+	GammaSum curves.Point
+	delta    curves.Scalar
+	chi      curves.Scalar
+	Delta    curves.Point
+	// Round 4 variables
+	R     curves.Point
+	Rbars map[uint32]curves.Point
+	S     curves.Point
+}
+
+// PresignRound1Bcast contains values to be broadcast to all parties after the completion of presigning round 1
+type PresignRound1Bcast struct {
+	K, G paillier.Ciphertext
+}
+
+// PresignRound1P2PSend contains values to be sent to a specific party after the completion of presigning round 1
+type PresignRound1P2PSend struct {
+	// EncProof proves that K is in range, with the recipient's Ring-Pedersen parameters
+	EncProof *proof.EncProof
+}
+
+// PresignRound2Bcast contains values to be broadcast to all parties after the completion of presigning round 2
+type PresignRound2Bcast struct {
+	Gamma curves.Point
+}
+
+// PresignRound2P2PSend contains values to be sent to a specific party after the completion of presigning round 2
+type PresignRound2P2PSend struct {
+	// D = γ_i·K_j + enc_j(β) and F = enc_i(β), for the product k_j γ_i
+	D, F paillier.Ciphertext
+	// DHat = x_i·K_j + enc_j(β̂) and FHat = enc_i(β̂), for the product k_j x_i
+	DHat, FHat paillier.Ciphertext
+	AffG       *proof.AffGProof
+	AffGHat    *proof.AffGProof
+	// LogStar proves that G_i encrypts the discrete log of Γ_i
+	LogStar *proof.LogStarProof
+}
+
+// PresignRound3Bcast contains values to be broadcast to all parties after the completion of presigning round 3
+type PresignRound3Bcast struct {
+	// Delta is δ_i, DeltaPoint is Δ_i = Γ^{k_i}
+	Delta      *big.Int
+	DeltaPoint curves.Point
+}
+
+// PresignRound3P2PSend contains values to be sent to a specific party after the completion of presigning round 3
+type PresignRound3P2PSend struct {
+	// LogStar proves that K_i encrypts the discrete log of Δ_i to the base Γ
+	LogStar *proof.LogStarProof
+}
+
+// PresignRound4Bcast contains values to be broadcast to all parties after the completion of presigning round 4
+type PresignRound4Bcast struct {
+	// S is S_i = R^{χ_i}
+	S curves.Point
+}
+
+// NewPresignParticipant creates a party of presigning for a key share that carries auxiliary info.
+// sid is a session identifier that all parties agree on and that is unique to this execution
+func NewPresignParticipant(share *KeyShare, sid []byte) (*PresignParticipant, error) {
+	if len(sid) == 0 {
+		return nil, internal.ErrNilArguments
+	}
+	if err := share.validate(); err != nil {
+		return nil, err
+	}
+	if !share.hasAuxInfo() {
+		return nil, fmt.Errorf("key share has no auxiliary info")
+	}
+	return &PresignParticipant{
+		Round: 1,
+		share: share,
+		ssid:  hashValues([]byte(presignProtocol), sid, share.Rid),
+		state: &presignState{},
+	}, nil
+}
+
+// Round1 samples k_i and γ_i and sends their encryptions, with proofs that k_i is in range
+// [CGGMP21] fig 8 round 1
+func (pp *PresignParticipant) Round1() (*PresignRound1Bcast, map[uint32]*PresignRound1P2PSend, error) {
+	if pp.Round != 1 {
+		return nil, nil, internal.ErrInvalidRound
+	}
+	ks := pp.share
+	pk := &ks.PaillierKey.PublicKey
+
+	// Sample k_i, γ_i ← F_q, ρ_i, ν_i ← Z_N_i* and set K_i = enc_i(k_i; ρ_i), G_i = enc_i(γ_i; ν_i)
+	k, err := randomNonZero(ks.Curve)
+	if err != nil {
+		return nil, nil, err
+	}
+	gamma, err := randomNonZero(ks.Curve)
+	if err != nil {
+		return nil, nil, err
+	}
+	K, rho, err := pk.Encrypt(k.BigInt())
+	if err != nil {
+		return nil, nil, err
+	}
+	G, nu, err := pk.Encrypt(gamma.BigInt())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// ψ_{j,i}^0 = M(prove, Π^enc_j, (K_i); (k_i, ρ_i))
+	p2p := make(map[uint32]*PresignRound1P2PSend, len(ks.Parties)-1)
+	for _, j := range ks.Parties {
+		if j == ks.ID {
+			continue
+		}
+		encProof, err := (&proof.EncProofParams{
+			Curve:        ks.Curve,
+			PublicKey:    pk,
+			RingPedersen: ks.RingPedersen[j],
+			Pi:           ks.ID,
+			Sid:          pp.ssid,
+			K:            K,
+			Plaintext:    k.BigInt(),
+			Nonce:        rho,
+		}).Prove()
+		if err != nil {
+			return nil, nil, err
+		}
+		p2p[j] = &PresignRound1P2PSend{EncProof: encProof}
+	}
+
+	pp.state.k = k
+	pp.state.gamma = gamma
+	pp.state.K = K
+	pp.state.G = G
+	pp.state.rho = rho
+	pp.state.nu = nu
+	pp.Round = 2
+	return &PresignRound1Bcast{K: K, G: G}, p2p, nil
+}
+
+// Round2 checks the range proofs and runs the two multiplicative-to-additive conversions with each party
+// [CGGMP21] fig 8 round 2
+func (pp *PresignParticipant) Round2(in map[uint32]*PresignRound1Bcast, p2p map[uint32]*PresignRound1P2PSend) (*PresignRound2Bcast, map[uint32]*PresignRound2P2PSend, error) {
+	if pp.Round != 2 {
+		return nil, nil, internal.ErrInvalidRound
+	}
+	ks := pp.share
+	if err := checkInput(ks.ID, ks.Parties, in); err != nil {
+		return nil, nil, err
+	}
+	if err := checkInput(ks.ID, ks.Parties, p2p); err != nil {
+		return nil, nil, err
+	}
+	pp.state.Ks = make(map[uint32]paillier.Ciphertext, len(ks.Parties)-1)
+	pp.state.Gs = make(map[uint32]paillier.Ciphertext, len(ks.Parties)-1)
+	for _, j := range ks.Parties {
+		if j == ks.ID {
+			continue
+		}
+		if in[j] == nil || in[j].K == nil || in[j].G == nil || p2p[j] == nil || p2p[j].EncProof == nil {
+			return nil, nil, newAbortError(presignProtocol, 2, j, internal.ErrNilArguments)
+		}
+		if err := core.In(in[j].G, ks.PaillierKeys[j].N2); err != nil {
+			return nil, nil, newAbortError(presignProtocol, 2, j, err)
+		}
+		if err := p2p[j].EncProof.Verify(&proof.EncVerifyParams{
+			Curve:        ks.Curve,
+			PublicKey:    ks.PaillierKeys[j],
+			RingPedersen: ks.RingPedersen[ks.ID],
+			Pi:           j,
+			Sid:          pp.ssid,
+			K:            in[j].K,
+		}); err != nil {
+			return nil, nil, newAbortError(presignProtocol, 2, j, err)
+		}
+		pp.state.Ks[j] = in[j].K
+		pp.state.Gs[j] = in[j].G
+	}
+
+	// Γ_i = g^{γ_i}
+	Gamma := ks.Curve.ScalarBaseMult(pp.state.gamma)
+	pk := &ks.PaillierKey.PublicKey
+	pp.state.betas = make(map[uint32]*big.Int, len(ks.Parties)-1)
+	pp.state.betaHats = make(map[uint32]*big.Int, len(ks.Parties)-1)
+	out := make(map[uint32]*PresignRound2P2PSend, len(ks.Parties)-1)
+	for j, Kj := range pp.state.Ks {
+		// D_{j,i} = (γ_i ⊙ K_j) ⊕ enc_j(β_{i,j}), F_{j,i} = enc_i(β_{i,j}) with ψ_{j,i} = Π^aff-g
+		D, F, beta, affG, err := pp.mta(j, Kj, pp.state.gamma, Gamma)
+		if err != nil {
+			return nil, nil, err
+		}
+		// D̂_{j,i} = (x_i ⊙ K_j) ⊕ enc_j(β̂_{i,j}), F̂_{j,i} = enc_i(β̂_{i,j}) with ψ̂_{j,i} = Π^aff-g
+		DHat, FHat, betaHat, affGHat, err := pp.mta(j, Kj, ks.SecretShare, ks.PublicShares[ks.ID])
+		if err != nil {
+			return nil, nil, err
+		}
+		// ψ'_{j,i} = M(prove, Π^log*_j, (G_i, Γ_i, g); (γ_i, ν_i))
+		logStar, err := (&proof.LogStarProofParams{
+			Curve:        ks.Curve,
+			PublicKey:    pk,
+			RingPedersen: ks.RingPedersen[j],
+			Pi:           ks.ID,
+			Sid:          pp.ssid,
+			C:            pp.state.G,
+			X:            Gamma,
+			Plaintext:    pp.state.gamma.BigInt(),
+			Nonce:        pp.state.nu,
+		}).Prove()
+		if err != nil {
+			return nil, nil, err
+		}
+		pp.state.betas[j] = beta
+		pp.state.betaHats[j] = betaHat
+		out[j] = &PresignRound2P2PSend{
+			D:       D,
+			F:       F,
+			DHat:    DHat,
+			FHat:    FHat,
+			AffG:    affG,
+			AffGHat: affGHat,
+			LogStar: logStar,
+		}
+	}
+
+	pp.state.Gamma = Gamma
+	pp.Round = 3
+	return &PresignRound2Bcast{Gamma: Gamma}, out, nil
+}
+
+// Round3 checks the proofs of round 2 and computes the additive shares δ_i of kγ and χ_i of kx
+// [CGGMP21] fig 8 round 3
+func (pp *PresignParticipant) Round3(in map[uint32]*PresignRound2Bcast, p2p map[uint32]*PresignRound2P2PSend) (*PresignRound3Bcast, map[uint32]*PresignRound3P2PSend, error) {
+	if pp.Round != 3 {
+		return nil, nil, internal.ErrInvalidRound
+	}
+	ks := pp.share
+	if err := checkInput(ks.ID, ks.Parties, in); err != nil {
+		return nil, nil, err
+	}
+	if err := checkInput(ks.ID, ks.Parties, p2p); err != nil {
+		return nil, nil, err
+	}
+	sk := ks.PaillierKey
+	q := curveOrder(ks.Curve)
+
+	// δ_i = γ_i k_i + Σ_j (α_{i,j} - β_{i,j}) and χ_i = x_i k_i + Σ_j (α̂_{i,j} - β̂_{i,j})
+	delta := pp.state.gamma.Mul(pp.state.k)
+	chi := ks.SecretShare.Mul(pp.state.k)
+	GammaSum := pp.state.Gamma
+	pp.state.Gammas = make(map[uint32]curves.Point, len(ks.Parties)-1)
+	for _, j := range ks.Parties {
+		if j == ks.ID {
+			continue
+		}
+		if in[j] == nil || !onCurve(ks.Curve, in[j].Gamma) {
+			return nil, nil, newAbortError(presignProtocol, 3, j, fmt.Errorf("invalid round 2 broadcast"))
+		}
+		if err := pp.verifyRound2(j, in[j].Gamma, p2p[j]); err != nil {
+			return nil, nil, newAbortError(presignProtocol, 3, j, err)
+		}
+		alpha, err := decryptSigned(sk, p2p[j].D, q)
+		if err != nil {
+			return nil, nil, newAbortError(presignProtocol, 3, j, err)
+		}
+		alphaHat, err := decryptSigned(sk, p2p[j].DHat, q)
+		if err != nil {
+			return nil, nil, newAbortError(presignProtocol, 3, j, err)
+		}
+		if delta, err = addBigInts(ks.Curve, delta, alpha, new(big.Int).Neg(pp.state.betas[j])); err != nil {
+			return nil, nil, err
+		}
+		if chi, err = addBigInts(ks.Curve, chi, alphaHat, new(big.Int).Neg(pp.state.betaHats[j])); err != nil {
+			return nil, nil, err
+		}
+		pp.state.Gammas[j] = in[j].Gamma
+		GammaSum = GammaSum.Add(in[j].Gamma)
+	}
+
+	// Γ = ∏_j Γ_j and Δ_i = Γ^{k_i}
+	Delta := GammaSum.Mul(pp.state.k)
+
+	// ψ''_{j,i} = M(prove, Π^log*_j, (K_i, Δ_i, Γ); (k_i, ρ_i))
+	pk := &ks.PaillierKey.PublicKey
+	out := make(map[uint32]*PresignRound3P2PSend, len(ks.Parties)-1)
+	for _, j := range ks.Parties {
+		if j == ks.ID {
+			continue
+		}
+		logStar, err := (&proof.LogStarProofParams{
+			Curve:        ks.Curve,
+			PublicKey:    pk,
+			RingPedersen: ks.RingPedersen[j],
+			Pi:           ks.ID,
+			Sid:          pp.ssid,
+			C:            pp.state.K,
+			X:            Delta,
+			G:            GammaSum,
+			Plaintext:    pp.state.k.BigInt(),
+			Nonce:        pp.state.rho,
+		}).Prove()
+		if err != nil {
+			return nil, nil, err
+		}
+		out[j] = &PresignRound3P2PSend{LogStar: logStar}
+	}
+
+	pp.state.GammaSum = GammaSum
+	pp.state.delta = delta
+	pp.state.chi = chi
+	pp.state.Delta = Delta
+	pp.Round = 4
+	return &PresignRound3Bcast{Delta: delta.BigInt(), DeltaPoint: Delta}, out, nil
+}
+
+// Round4 checks the proofs of round 3, computes R = Γ^{δ^-1} and publishes S_i = R^{χ_i}
+// [CGGMP21] fig 8 output
+func (pp *PresignParticipant) Round4(in map[uint32]*PresignRound3Bcast, p2p map[uint32]*PresignRound3P2PSend) (*PresignRound4Bcast, error) {
+	if pp.Round != 4 {
+		return nil, internal.ErrInvalidRound
+	}
+	ks := pp.share
+	if err := checkInput(ks.ID, ks.Parties, in); err != nil {
+		return nil, err
+	}
+	if err := checkInput(ks.ID, ks.Parties, p2p); err != nil {
+		return nil, err
+	}
+
+	// δ = Σ_j δ_j and check g^δ = ∏_j Δ_j
+	delta := pp.state.delta
+	DeltaSum := pp.state.Delta
+	Deltas := map[uint32]curves.Point{ks.ID: pp.state.Delta}
+	for _, j := range ks.Parties {
+		if j == ks.ID {
+			continue
+		}
+		msg := in[j]
+		if msg == nil || msg.Delta == nil || !onCurve(ks.Curve, msg.DeltaPoint) || p2p[j] == nil || p2p[j].LogStar == nil {
+			return nil, newAbortError(presignProtocol, 4, j, fmt.Errorf("invalid round 3 message"))
+		}
+		if err := p2p[j].LogStar.Verify(&proof.LogStarVerifyParams{
+			Curve:        ks.Curve,
+			PublicKey:    ks.PaillierKeys[j],
+			RingPedersen: ks.RingPedersen[ks.ID],
+			Pi:           j,
+			Sid:          pp.ssid,
+			C:            pp.state.Ks[j],
+			X:            msg.DeltaPoint,
+			G:            pp.state.GammaSum,
+		}); err != nil {
+			return nil, newAbortError(presignProtocol, 4, j, err)
+		}
+		var err error
+		if delta, err = addBigInts(ks.Curve, delta, msg.Delta); err != nil {
+			return nil, newAbortError(presignProtocol, 4, j, err)
+		}
+		DeltaSum = DeltaSum.Add(msg.DeltaPoint)
+		Deltas[j] = msg.DeltaPoint
+	}
+	if !ks.Curve.ScalarBaseMult(delta).Equal(DeltaSum) {
+		return nil, fmt.Errorf("g^δ != ∏Δ_j")
+	}
+	deltaInv, err := delta.Invert()
+	if err != nil {
+		return nil, err
+	}
+
+	// R = Γ^{δ^-1} and R̄_j = Δ_j^{δ^-1} = R^{k_j}
+	R := pp.state.GammaSum.Mul(deltaInv)
+	Rbars := make(map[uint32]curves.Point, len(ks.Parties))
+	for j, D := range Deltas {
+		Rbars[j] = D.Mul(deltaInv)
+	}
+	S := R.Mul(pp.state.chi)
+
+	pp.state.R = R
+	pp.state.Rbars = Rbars
+	pp.state.S = S
+	pp.Round = 5
+	return &PresignRound4Bcast{S: S}, nil
+}
+
+// Output checks that the S_j are consistent with the public key and returns the presignature
+func (pp *PresignParticipant) Output(in map[uint32]*PresignRound4Bcast) (*Presignature, error) {
+	if pp.Round != 5 {
+		return nil, internal.ErrInvalidRound
+	}
+	ks := pp.share
+	if err := checkInput(ks.ID, ks.Parties, in); err != nil {
+		return nil, err
+	}
+	Ss := map[uint32]curves.Point{ks.ID: pp.state.S}
+	sum := pp.state.S
+	for _, j := range ks.Parties {
+		if j == ks.ID {
+			continue
+		}
+		if in[j] == nil || !onCurve(ks.Curve, in[j].S) {
+			return nil, newAbortError(presignProtocol, 5, j, fmt.Errorf("invalid round 4 broadcast"))
+		}
+		Ss[j] = in[j].S
+		sum = sum.Add(in[j].S)
+	}
+	// ∏_j S_j = R^{kx} = X
+	if !sum.Equal(ks.PublicKey) {
+		return nil, fmt.Errorf("∏S_j != X")
+	}
+
+	pp.Round = 6
+	return &Presignature{
+		Curve:     ks.Curve,
+		ID:        ks.ID,
+		Parties:   append([]uint32{}, ks.Parties...),
+		PublicKey: ks.PublicKey,
+		R:         pp.state.R,
+		Rbars:     pp.state.Rbars,
+		Ss:        Ss,
+		k:         pp.state.k,
+		chi:       pp.state.chi,
+	}, nil
+}
+
+// mta computes D = (x ⊙ K_j) ⊕ enc_j(β; s) and F = enc_i(β; r) for a random β ∈ ±2^ℓ',
+// and proves it with Π^aff-g against X = g^x under the Ring-Pedersen parameters of j
+func (pp *PresignParticipant) mta(j uint32, Kj paillier.Ciphertext, x curves.Scalar, X curves.Point) (
+	paillier.Ciphertext, paillier.Ciphertext, *big.Int, *proof.AffGProof, error) {
+	ks := pp.share
+	pkj, pki := ks.PaillierKeys[j], &ks.PaillierKey.PublicKey
+
+	beta, err := randomSigned(proof.EllPrime)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	// enc_j(β; s)
+	encBeta, s, err := pkj.Encrypt(new(big.Int).Mod(beta, pkj.N))
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	xK, err := pkj.Mul(x.BigInt(), Kj)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	D, err := pkj.Add(xK, encBeta)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	// enc_i(β; r)
+	F, r, err := pki.Encrypt(new(big.Int).Mod(beta, pki.N))
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	affG, err := (&proof.AffGProofParams{
+		Curve:        ks.Curve,
+		PublicKey0:   pkj,
+		PublicKey1:   pki,
+		RingPedersen: ks.RingPedersen[j],
+		Pi:           ks.ID,
+		Sid:          pp.ssid,
+		C:            Kj,
+		D:            D,
+		Y:            F,
+		X:            X,
+		ScalarX:      x.BigInt(),
+		ScalarY:      beta,
+		Rho:          s,
+		RhoY:         r,
+	}).Prove()
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	return D, F, beta, affG, nil
+}
+
+// verifyRound2 checks the proofs sent by party j in round 2
+func (pp *PresignParticipant) verifyRound2(j uint32, Gamma curves.Point, msg *PresignRound2P2PSend) error {
+	ks := pp.share
+	if msg == nil || msg.AffG == nil || msg.AffGHat == nil || msg.LogStar == nil ||
+		core.AnyNil(msg.D, msg.F, msg.DHat, msg.FHat) {
+		return internal.ErrNilArguments
+	}
+	pki, pkj, rpi := &ks.PaillierKey.PublicKey, ks.PaillierKeys[j], ks.RingPedersen[ks.ID]
+	if err := msg.AffG.Verify(&proof.AffGVerifyParams{
+		Curve:        ks.Curve,
+		PublicKey0:   pki,
+		PublicKey1:   pkj,
+		RingPedersen: rpi,
+		Pi:           j,
+		Sid:          pp.ssid,
+		C:            pp.state.K,
+		D:            msg.D,
+		Y:            msg.F,
+		X:            Gamma,
+	}); err != nil {
+		return err
+	}
+	if err := msg.AffGHat.Verify(&proof.AffGVerifyParams{
+		Curve:        ks.Curve,
+		PublicKey0:   pki,
+		PublicKey1:   pkj,
+		RingPedersen: rpi,
+		Pi:           j,
+		Sid:          pp.ssid,
+		C:            pp.state.K,
+		D:            msg.DHat,
+		Y:            msg.FHat,
+		X:            ks.PublicShares[j],
+	}); err != nil {
+		return err
+	}
+	return msg.LogStar.Verify(&proof.LogStarVerifyParams{
+		Curve:        ks.Curve,
+		PublicKey:    pkj,
+		RingPedersen: rpi,
+		Pi:           j,
+		Sid:          pp.ssid,
+		C:            pp.state.Gs[j],
+		X:            Gamma,
+	})
+}
+
+// decryptSigned decrypts c and lifts the plaintext from Z_N to (-N/2, N/2] before reducing it mod q
+func decryptSigned(sk *paillier.SecretKey, c paillier.Ciphertext, q *big.Int) (*big.Int, error) {
+	m, err := sk.Decrypt(c)
+	if err != nil {
+		return nil, err
+	}
+	if m.Cmp(new(big.Int).Rsh(sk.N, 1)) == 1 {
+		m.Sub(m, sk.N)
+	}
+	return m.Mod(m, q), nil
+}
+
+// randomSigned returns a uniform integer in [-2^bits, 2^bits]
+func randomSigned(bits uint) (*big.Int, error) {
+	bound := new(big.Int).Lsh(core.One, bits)
+	r, err := core.Rand(new(big.Int).Add(new(big.Int).Lsh(bound, 1), core.One))
+	if err != nil {
+		return nil, err
+	}
+	return r.Sub(r, bound), nil
+}
+
+// addBigInts computes s + Σ_i v_i mod q for signed v_i
+func addBigInts(curve *curves.Curve, s curves.Scalar, v ...*big.Int) (curves.Scalar, error) {
+	q := curveOrder(curve)
+	for _, vi := range v {
+		if vi == nil {
+			return nil, internal.ErrNilArguments
+		}
+		e, err := curve.Scalar.SetBigInt(new(big.Int).Mod(vi, q))
+		if err != nil {
+			return nil, err
+		}
+		s = s.Add(e)
+	}
+	return s, nil
+}
@@ -0,0 +1,266 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package cggmp
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/TEENet-io/kryptology/internal"
+	"github.com/TEENet-io/kryptology/pkg/core"
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
+)
+
+// presignRun holds the participants and messages of a presigning run
+type presignRun struct {
+	participants map[uint32]*PresignParticipant
+	r1           map[uint32]*PresignRound1Bcast
+	r1p2p        map[uint32]map[uint32]*PresignRound1P2PSend
+	r2           map[uint32]*PresignRound2Bcast
+	r2p2p        map[uint32]map[uint32]*PresignRound2P2PSend
+	r3           map[uint32]*PresignRound3Bcast
+	r3p2p        map[uint32]map[uint32]*PresignRound3P2PSend
+	r4           map[uint32]*PresignRound4Bcast
+}
+
+// newPresignRun creates the presigning participants of shares and runs rounds up to round
+func newPresignRun(t *testing.T, shares map[uint32]*KeyShare, round int) *presignRun {
+	run := &presignRun{participants: make(map[uint32]*PresignParticipant, len(shares))}
+	for id, share := range shares {
+		pp, err := NewPresignParticipant(share, []byte("presign"))
+		require.NoError(t, err)
+		run.participants[id] = pp
+	}
+	if round >= 1 {
+		run.r1 = make(map[uint32]*PresignRound1Bcast, len(shares))
+		run.r1p2p = make(map[uint32]map[uint32]*PresignRound1P2PSend, len(shares))
+		for id, pp := range run.participants {
+			var err error
+			run.r1[id], run.r1p2p[id], err = pp.Round1()
+			require.NoError(t, err)
+		}
+	}
+	if round >= 2 {
+		run.r2 = make(map[uint32]*PresignRound2Bcast, len(shares))
+		run.r2p2p = make(map[uint32]map[uint32]*PresignRound2P2PSend, len(shares))
+		for id, pp := range run.participants {
+			var err error
+			run.r2[id], run.r2p2p[id], err = pp.Round2(run.r1, run.round1P2P(id))
+			require.NoError(t, err)
+		}
+	}
+	if round >= 3 {
+		run.r3 = make(map[uint32]*PresignRound3Bcast, len(shares))
+		run.r3p2p = make(map[uint32]map[uint32]*PresignRound3P2PSend, len(shares))
+		for id, pp := range run.participants {
+			var err error
+			run.r3[id], run.r3p2p[id], err = pp.Round3(run.r2, run.round2P2P(id))
+			require.NoError(t, err)
+		}
+	}
+	if round >= 4 {
+		run.r4 = make(map[uint32]*PresignRound4Bcast, len(shares))
+		for id, pp := range run.participants {
+			var err error
+			run.r4[id], err = pp.Round4(run.r3, run.round3P2P(id))
+			require.NoError(t, err)
+		}
+	}
+	return run
+}
+
+// round1P2P collects the round 1 P2P messages sent to id
+func (run *presignRun) round1P2P(id uint32) map[uint32]*PresignRound1P2PSend {
+	out := make(map[uint32]*PresignRound1P2PSend, len(run.r1p2p))
+	for from, sent := range run.r1p2p {
+		if from != id {
+			out[from] = sent[id]
+		}
+	}
+	return out
+}
+
+// round2P2P collects the round 2 P2P messages sent to id
+func (run *presignRun) round2P2P(id uint32) map[uint32]*PresignRound2P2PSend {
+	out := make(map[uint32]*PresignRound2P2PSend, len(run.r2p2p))
+	for from, sent := range run.r2p2p {
+		if from != id {
+			out[from] = sent[id]
+		}
+	}
+	return out
+}
+
+// round3P2P collects the round 3 P2P messages sent to id
+func (run *presignRun) round3P2P(id uint32) map[uint32]*PresignRound3P2PSend {
+	out := make(map[uint32]*PresignRound3P2PSend, len(run.r3p2p))
+	for from, sent := range run.r3p2p {
+		if from != id {
+			out[from] = sent[id]
+		}
+	}
+	return out
+}
+
+// runPresign runs presigning and returns the presignatures of all parties
+func runPresign(t *testing.T, shares map[uint32]*KeyShare) map[uint32]*Presignature {
+	run := newPresignRun(t, shares, 4)
+	presignatures := make(map[uint32]*Presignature, len(shares))
+	for id, pp := range run.participants {
+		presignature, err := pp.Output(run.r4)
+		require.NoError(t, err)
+		presignatures[id] = presignature
+	}
+	return presignatures
+}
+
+// testShares caches the key shares of presignTestShares, as auxiliary info is slow to generate
+var testShares = make(map[string]map[uint32]*KeyShare)
+
+// presignTestShares runs key generation and auxiliary info among three parties, once per curve
+func presignTestShares(t *testing.T, curve *curves.Curve) map[uint32]*KeyShare {
+	if shares, ok := testShares[curve.Name]; ok {
+		return shares
+	}
+	shares := runRefresh(t, runKeygen(t, curve, []uint32{1, 2, 3}), 0)
+	testShares[curve.Name] = shares
+	return shares
+}
+
+func TestPresignWorks(t *testing.T) {
+	for _, curve := range []*curves.Curve{curves.K256(), curves.P256()} {
+		shares := presignTestShares(t, curve)
+		presignatures := runPresign(t, shares)
+
+		// R = g^{1/k} with R̄_j = R^{k_j}
+		k := curve.Scalar.Zero()
+		for _, p := range presignatures {
+			require.True(t, p.R.Equal(presignatures[1].R))
+			require.True(t, p.R.Mul(p.k).Equal(p.Rbars[p.ID]))
+			k = k.Add(p.k)
+		}
+		require.True(t, presignatures[1].R.Mul(k).Equal(curve.NewGeneratorPoint()))
+	}
+}
+
+func TestNewPresignParticipantWithoutAuxInfo(t *testing.T) {
+	shares := runKeygen(t, curves.K256(), []uint32{1, 2})
+	_, err := NewPresignParticipant(shares[1], []byte("presign"))
+	require.Error(t, err)
+}
+
+func TestPresignOtherSession(t *testing.T) {
+	shares := presignTestShares(t, curves.K256())
+	_, err := NewPresignParticipant(shares[1], nil)
+	require.Equal(t, internal.ErrNilArguments, err)
+
+	// Party 2 proves that K_2 is in range in another session
+	run := newPresignRun(t, shares, 1)
+	other, err := NewPresignParticipant(shares[2], []byte("another presign"))
+	require.NoError(t, err)
+	r1, p2p, err := other.Round1()
+	require.NoError(t, err)
+	in := map[uint32]*PresignRound1Bcast{2: r1, 3: run.r1[3]}
+	received := run.round1P2P(1)
+	received[2] = p2p[1]
+	_, _, err = run.participants[1].Round2(in, received)
+	culprit, ok := Culprit(err)
+	require.True(t, ok, "expected an abort, got %v", err)
+	require.Equal(t, uint32(2), culprit)
+}
+
+func TestPresignInvalidRound(t *testing.T) {
+	shares := presignTestShares(t, curves.K256())
+	pp, err := NewPresignParticipant(shares[1], []byte("presign"))
+	require.NoError(t, err)
+	_, _, err = pp.Round2(nil, nil)
+	require.Equal(t, internal.ErrInvalidRound, err)
+	_, err = pp.Output(nil)
+	require.Equal(t, internal.ErrInvalidRound, err)
+}
+
+func TestPresignCulprits(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+	shares := presignTestShares(t, curves.K256())
+	requireCulprit := func(t *testing.T, err error, expected uint32) {
+		culprit, ok := Culprit(err)
+		require.True(t, ok, "expected an abort, got %v", err)
+		require.Equal(t, expected, culprit)
+	}
+
+	t.Run("K not in range", func(t *testing.T) {
+		run := newPresignRun(t, shares, 1)
+		// K_2 replaced with an encryption of a value far out of range
+		pk := shares[2].PaillierKeys[2]
+		K, _, err := pk.Encrypt(new(big.Int).Lsh(core.One, 1500))
+		require.NoError(t, err)
+		r1 := map[uint32]*PresignRound1Bcast{2: {K: K, G: run.r1[2].G}, 3: run.r1[3]}
+		_, _, err = run.participants[1].Round2(r1, run.round1P2P(1))
+		requireCulprit(t, err, 2)
+	})
+
+	t.Run("wrong multiplication", func(t *testing.T) {
+		run := newPresignRun(t, shares, 2)
+		p2p := run.round2P2P(1)
+		tampered := *p2p[3]
+		// D encrypts k_1 γ_3 + β + 1
+		pk := shares[1].PaillierKeys[1]
+		one, _, err := pk.Encrypt(core.One)
+		require.NoError(t, err)
+		tampered.D, err = pk.Add(tampered.D, one)
+		require.NoError(t, err)
+		p2p[3] = &tampered
+		_, _, err = run.participants[1].Round3(run.r2, p2p)
+		requireCulprit(t, err, 3)
+	})
+
+	t.Run("gamma does not match G", func(t *testing.T) {
+		run := newPresignRun(t, shares, 2)
+		r2 := map[uint32]*PresignRound2Bcast{
+			2: {Gamma: run.r2[2].Gamma.Double()},
+			3: run.r2[3],
+		}
+		_, _, err := run.participants[1].Round3(r2, run.round2P2P(1))
+		requireCulprit(t, err, 2)
+	})
+
+	t.Run("delta does not match K", func(t *testing.T) {
+		run := newPresignRun(t, shares, 3)
+		r3 := map[uint32]*PresignRound3Bcast{
+			2: run.r3[2],
+			3: {Delta: run.r3[3].Delta, DeltaPoint: run.r3[3].DeltaPoint.Double()},
+		}
+		_, err := run.participants[1].Round4(r3, run.round3P2P(1))
+		requireCulprit(t, err, 3)
+	})
+
+	t.Run("wrong delta share", func(t *testing.T) {
+		run := newPresignRun(t, shares, 3)
+		r3 := map[uint32]*PresignRound3Bcast{
+			2: {Delta: new(big.Int).Add(run.r3[2].Delta, core.One), DeltaPoint: run.r3[2].DeltaPoint},
+			3: run.r3[3],
+		}
+		// δ cannot be attributed without the identification proofs of the paper
+		_, err := run.participants[1].Round4(r3, run.round3P2P(1))
+		require.Error(t, err)
+		_, ok := Culprit(err)
+		require.False(t, ok)
+	})
+
+	t.Run("S does not sum to the public key", func(t *testing.T) {
+		run := newPresignRun(t, shares, 4)
+		r4 := map[uint32]*PresignRound4Bcast{2: {S: run.r4[2].S.Double()}, 3: run.r4[3]}
+		_, err := run.participants[1].Output(r4)
+		require.Error(t, err)
+		_, ok := Culprit(err)
+		require.False(t, ok)
+	})
+}
@@ -0,0 +1,286 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package proof
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/TEENet-io/kryptology/internal"
+	"github.com/TEENet-io/kryptology/pkg/core"
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
+	"github.com/TEENet-io/kryptology/pkg/paillier"
+)
+
+// AffGProofParams contains the inputs to AffGProof.Prove
+type AffGProofParams struct {
+	Curve *curves.Curve
+	// PublicKey0 is the Paillier key N0 of the verifier, under which C and D are encrypted
+	PublicKey0 *paillier.PublicKey
+	// PublicKey1 is the Paillier key N1 of the prover, under which Y is encrypted
+	PublicKey1 *paillier.PublicKey
+	// RingPedersen are the parameters of the verifier
+	RingPedersen *paillier.RingPedersenParams
+	Pi           uint32
+	// Sid is the session the proof is bound to, such as the ssid of CGGMP21. The prover and the verifier must
+	// use the same value, which may be empty.
+	Sid []byte
+	// D = C^x (1+N0)^y ρ^N0 mod N0², Y = enc_N1(y; ρy) and X = g^x
+	C, D, Y paillier.Ciphertext
+	X       curves.Point
+	// Witness x ∈ ±2^ℓ, y ∈ ±2^ℓ' and the nonces ρ, ρy
+	ScalarX, ScalarY, Rho, RhoY *big.Int
+}
+
+// AffGVerifyParams contains the inputs to AffGProof.Verify
+type AffGVerifyParams struct {
+	Curve        *curves.Curve
+	PublicKey0   *paillier.PublicKey
+	PublicKey1   *paillier.PublicKey
+	RingPedersen *paillier.RingPedersenParams
+	Pi           uint32
+	Sid          []byte
+	C, D, Y      paillier.Ciphertext
+	X            curves.Point
+}
+
+// AffGProof proves that D is the affine operation x·C + y on the plaintext of C,
+// where x is the discrete log of X and y is the plaintext of Y, with x ∈ ±2^{ℓ+ε} and y ∈ ±2^{ℓ'+ε}
+type AffGProof struct {
+	A              *big.Int
+	Bx             curves.Point
+	By, E, S, F, T *big.Int
+	Z1, Z2, Z3, Z4 *big.Int
+	W, Wy          *big.Int
+}
+
+// Prove that a Paillier ciphertext is an affine operation on another, with a group commitment
+// [CGGMP21] fig 15
+func (p *AffGProofParams) Prove() (*AffGProof, error) {
+	if p.Curve == nil || p.X == nil || p.Pi == 0 ||
+		core.AnyNil(p.C, p.D, p.Y, p.ScalarX, p.ScalarY, p.Rho, p.RhoY) {
+		return nil, internal.ErrNilArguments
+	}
+	if err := checkPublicKey(p.PublicKey0); err != nil {
+		return nil, err
+	}
+	if err := checkPublicKey(p.PublicKey1); err != nil {
+		return nil, err
+	}
+	if err := checkRingPedersen(p.RingPedersen); err != nil {
+		return nil, err
+	}
+	q, err := curveOrder(p.Curve)
+	if err != nil {
+		return nil, err
+	}
+	pk0, pk1, nHat := p.PublicKey0, p.PublicKey1, p.RingPedersen.N
+
+	// 1. Sample α ← ±2^{ℓ+ε}, β ← ±2^{ℓ'+ε}, r ← Z_N0*, ry ← Z_N1*,
+	//    γ, δ ← ±2^{ℓ+ε}·N̂, m, μ ← ±2^ℓ·N̂
+	alpha, err := randomSigned(Ell+Epsilon, core.One)
+	if err != nil {
+		return nil, err
+	}
+	beta, err := randomSigned(EllPrime+Epsilon, core.One)
+	if err != nil {
+		return nil, err
+	}
+	r, err := randomUnit(pk0.N)
+	if err != nil {
+		return nil, err
+	}
+	ry, err := randomUnit(pk1.N)
+	if err != nil {
+		return nil, err
+	}
+	gamma, err := randomSigned(Ell+Epsilon, nHat)
+	if err != nil {
+		return nil, err
+	}
+	delta, err := randomSigned(Ell+Epsilon, nHat)
+	if err != nil {
+		return nil, err
+	}
+	m, err := randomSigned(Ell, nHat)
+	if err != nil {
+		return nil, err
+	}
+	mu, err := randomSigned(Ell, nHat)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. A = C^α (1+N0)^β r^N0 mod N0², Bx = g^α, By = (1+N1)^β ry^N1 mod N1²,
+	//    E = s^α t^γ, S = s^x t^m, F = s^β t^δ, T = s^y t^μ mod N̂
+	proof := &AffGProof{By: encrypt(pk1, beta, ry)}
+	if proof.A, err = mulExp(encrypt(pk0, beta, r), p.C, alpha, pk0.N2); err != nil {
+		return nil, err
+	}
+	a, err := scalar(p.Curve, alpha, q)
+	if err != nil {
+		return nil, err
+	}
+	proof.Bx = p.Curve.ScalarBaseMult(a)
+	if proof.E, err = pedersen(p.RingPedersen, alpha, gamma); err != nil {
+		return nil, err
+	}
+	if proof.S, err = pedersen(p.RingPedersen, p.ScalarX, m); err != nil {
+		return nil, err
+	}
+	if proof.F, err = pedersen(p.RingPedersen, beta, delta); err != nil {
+		return nil, err
+	}
+	if proof.T, err = pedersen(p.RingPedersen, p.ScalarY, mu); err != nil {
+		return nil, err
+	}
+
+	// 3. e ← FS-HASH(statement, A, Bx, By, E, S, F, T, Pi, Sid)
+	e, err := affGChallenge(q, &AffGVerifyParams{
+		PublicKey0:   pk0,
+		PublicKey1:   pk1,
+		RingPedersen: p.RingPedersen,
+		Pi:           p.Pi,
+		Sid:          p.Sid,
+		C:            p.C,
+		D:            p.D,
+		Y:            p.Y,
+		X:            p.X,
+	}, proof)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. z1 = α + ex, z2 = β + ey, z3 = γ + em, z4 = δ + eμ, w = r ρ^e mod N0, wy = ry ρy^e mod N1
+	proof.Z1 = new(big.Int).Add(alpha, new(big.Int).Mul(e, p.ScalarX))
+	proof.Z2 = new(big.Int).Add(beta, new(big.Int).Mul(e, p.ScalarY))
+	proof.Z3 = new(big.Int).Add(gamma, new(big.Int).Mul(e, m))
+	proof.Z4 = new(big.Int).Add(delta, new(big.Int).Mul(e, mu))
+	if proof.W, err = mulExp(r, p.Rho, e, pk0.N); err != nil {
+		return nil, err
+	}
+	if proof.Wy, err = mulExp(ry, p.RhoY, e, pk1.N); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// Verify that a Paillier ciphertext is an affine operation on another, with a group commitment
+// [CGGMP21] fig 15
+func (p *AffGProof) Verify(params *AffGVerifyParams) error {
+	if p == nil || params == nil || params.Curve == nil || params.X == nil || params.Pi == 0 ||
+		core.AnyNil(params.C, params.D, params.Y) {
+		return internal.ErrNilArguments
+	}
+	if p.Bx == nil || core.AnyNil(p.A, p.By, p.E, p.S, p.F, p.T, p.Z1, p.Z2, p.Z3, p.Z4, p.W, p.Wy) {
+		return internal.ErrNilArguments
+	}
+	if err := checkPublicKey(params.PublicKey0); err != nil {
+		return err
+	}
+	if err := checkPublicKey(params.PublicKey1); err != nil {
+		return err
+	}
+	if err := checkRingPedersen(params.RingPedersen); err != nil {
+		return err
+	}
+	q, err := curveOrder(params.Curve)
+	if err != nil {
+		return err
+	}
+	pk0, pk1, rp := params.PublicKey0, params.PublicKey1, params.RingPedersen
+	if err = checkUnits(pk0.N2, params.C, params.D, p.A); err != nil {
+		return err
+	}
+	if err = checkUnits(pk1.N2, params.Y, p.By); err != nil {
+		return err
+	}
+	if err = checkUnits(pk0.N, p.W); err != nil {
+		return err
+	}
+	if err = checkUnits(pk1.N, p.Wy); err != nil {
+		return err
+	}
+	if err = checkUnits(rp.N, p.E, p.S, p.F, p.T); err != nil {
+		return err
+	}
+	if !p.Bx.IsOnCurve() || !params.X.IsOnCurve() {
+		return internal.ErrNotOnCurve
+	}
+
+	// z1 ∈ ±2^{ℓ+ε}, z2 ∈ ±2^{ℓ'+ε}
+	if !inRange(p.Z1, Ell+Epsilon) || !inRange(p.Z2, EllPrime+Epsilon) {
+		return fmt.Errorf("aff-g proof response out of range")
+	}
+
+	e, err := affGChallenge(q, params, p)
+	if err != nil {
+		return err
+	}
+
+	// C^z1 (1+N0)^z2 w^N0 = A D^e mod N0²
+	lhs, err := mulExp(encrypt(pk0, p.Z2, p.W), params.C, p.Z1, pk0.N2)
+	if err != nil {
+		return err
+	}
+	rhs, err := mulExp(p.A, params.D, e, pk0.N2)
+	if err != nil {
+		return err
+	}
+	if lhs.Cmp(rhs) != 0 {
+		return fmt.Errorf("invalid aff-g proof")
+	}
+
+	// g^z1 = Bx X^e
+	z1, err := scalar(params.Curve, p.Z1, q)
+	if err != nil {
+		return err
+	}
+	es, err := scalar(params.Curve, e, q)
+	if err != nil {
+		return err
+	}
+	if !params.Curve.ScalarBaseMult(z1).Equal(p.Bx.Add(params.X.Mul(es))) {
+		return fmt.Errorf("invalid aff-g proof")
+	}
+
+	// (1+N1)^z2 wy^N1 = By Y^e mod N1²
+	if rhs, err = mulExp(p.By, params.Y, e, pk1.N2); err != nil {
+		return err
+	}
+	if encrypt(pk1, p.Z2, p.Wy).Cmp(rhs) != 0 {
+		return fmt.Errorf("invalid aff-g proof")
+	}
+
+	// s^z1 t^z3 = E S^e, s^z2 t^z4 = F T^e mod N̂
+	checks := []struct {
+		x, y, commitment, value *big.Int
+	}{
+		{p.Z1, p.Z3, p.E, p.S},
+		{p.Z2, p.Z4, p.F, p.T},
+	}
+	for _, c := range checks {
+		if lhs, err = pedersen(rp, c.x, c.y); err != nil {
+			return err
+		}
+		if rhs, err = mulExp(c.commitment, c.value, e, rp.N); err != nil {
+			return err
+		}
+		if lhs.Cmp(rhs) != 0 {
+			return fmt.Errorf("invalid aff-g proof")
+		}
+	}
+	return nil
+}
+
+// affGChallenge computes the Fiat-Shamir challenge of AffGProof
+func affGChallenge(q *big.Int, params *AffGVerifyParams, p *AffGProof) (*big.Int, error) {
+	rp := params.RingPedersen
+	return challenge(q, params.PublicKey0.N, params.PublicKey1.N, rp.N, rp.S, rp.T,
+		params.C, params.D, params.Y, pointInt(params.X),
+		p.A, pointInt(p.Bx), p.By, p.E, p.S, p.F, p.T, new(big.Int).SetUint64(uint64(params.Pi)), sidInt(params.Sid))
+}
@@ -0,0 +1,148 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package proof
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/TEENet-io/kryptology/pkg/core"
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
+	"github.com/TEENet-io/kryptology/pkg/paillier"
+)
+
+// affGSetup builds the statement D = C^x (1+N0)^y ρ^N0, Y = enc_N1(y; ρy), X = g^x
+// where C is encrypted under the verifier's key N0 and the prover's key is N1
+func affGSetup(t *testing.T, curve *curves.Curve) (*AffGProofParams, *paillier.SecretKey) {
+	prover, verifier, rp := testSetup(t)
+	pk0, pk1 := &verifier.PublicKey, &prover.PublicKey
+
+	k := curve.Scalar.Random(rand.Reader)
+	C, _ := testEncrypt(t, pk0, k.BigInt())
+	x := curve.Scalar.Random(rand.Reader)
+	y, err := randomSigned(EllPrime, core.One)
+	require.NoError(t, err)
+	rho, err := randomUnit(pk0.N)
+	require.NoError(t, err)
+	D, err := mulExp(encrypt(pk0, y, rho), C, x.BigInt(), pk0.N2)
+	require.NoError(t, err)
+	Y, rhoY := testEncrypt(t, pk1, y)
+
+	return &AffGProofParams{
+		Curve:        curve,
+		PublicKey0:   pk0,
+		PublicKey1:   pk1,
+		RingPedersen: rp,
+		Pi:           2,
+		Sid:          []byte("session"),
+		C:            C,
+		D:            D,
+		Y:            Y,
+		X:            curve.ScalarBaseMult(x),
+		ScalarX:      x.BigInt(),
+		ScalarY:      y,
+		Rho:          rho,
+		RhoY:         rhoY,
+	}, verifier
+}
+
+func affGVerifyParams(p *AffGProofParams) *AffGVerifyParams {
+	return &AffGVerifyParams{
+		Curve:        p.Curve,
+		PublicKey0:   p.PublicKey0,
+		PublicKey1:   p.PublicKey1,
+		RingPedersen: p.RingPedersen,
+		Pi:           p.Pi,
+		Sid:          p.Sid,
+		C:            p.C,
+		D:            p.D,
+		Y:            p.Y,
+		X:            p.X,
+	}
+}
+
+func TestAffGProofWorks(t *testing.T) {
+	for _, curve := range []*curves.Curve{curves.K256(), curves.P256()} {
+		params, verifier := affGSetup(t, curve)
+		proof, err := params.Prove()
+		require.NoError(t, err)
+		require.NoError(t, proof.Verify(affGVerifyParams(params)))
+
+		// D decrypts to k·x + y, which is negative when y is
+		q, err := curveOrder(curve)
+		require.NoError(t, err)
+		d, err := verifier.Decrypt(params.D)
+		require.NoError(t, err)
+		if d.Cmp(new(big.Int).Rsh(verifier.N, 1)) == 1 {
+			d.Sub(d, verifier.N)
+		}
+		c, err := verifier.Decrypt(params.C)
+		require.NoError(t, err)
+		expected := new(big.Int).Mul(c, params.ScalarX)
+		expected.Add(expected, params.ScalarY)
+		expected.Mod(expected, q)
+		require.Equal(t, expected, d.Mod(d, q))
+
+		// Wrong prover id
+		wrong := affGVerifyParams(params)
+		wrong.Pi = 1
+		require.Error(t, proof.Verify(wrong))
+
+		// Wrong session
+		wrong = affGVerifyParams(params)
+		wrong.Sid = []byte("another session")
+		require.Error(t, proof.Verify(wrong))
+
+		// Wrong group commitment
+		wrong = affGVerifyParams(params)
+		wrong.X = params.X.Add(curve.NewGeneratorPoint())
+		require.Error(t, proof.Verify(wrong))
+
+		// Wrong encryption of y
+		wrong = affGVerifyParams(params)
+		wrong.Y, _ = testEncrypt(t, params.PublicKey1, params.ScalarY)
+		require.Error(t, proof.Verify(wrong))
+	}
+}
+
+func TestAffGProofTampered(t *testing.T) {
+	params, _ := affGSetup(t, curves.K256())
+	proof, err := params.Prove()
+	require.NoError(t, err)
+	verifyParams := affGVerifyParams(params)
+
+	for _, tamper := range []func(p *AffGProof){
+		func(p *AffGProof) { p.Z1 = new(big.Int).Add(p.Z1, core.One) },
+		func(p *AffGProof) { p.Z2 = new(big.Int).Add(p.Z2, core.One) },
+		func(p *AffGProof) { p.Z3 = new(big.Int).Add(p.Z3, core.One) },
+		func(p *AffGProof) { p.Z4 = new(big.Int).Add(p.Z4, core.One) },
+		func(p *AffGProof) { p.W = new(big.Int).Add(p.W, core.One) },
+		func(p *AffGProof) { p.Wy = new(big.Int).Add(p.Wy, core.One) },
+		func(p *AffGProof) { p.Bx = p.Bx.Double() },
+		func(p *AffGProof) { p.Z2 = new(big.Int).Lsh(core.One, EllPrime+Epsilon+1) },
+		func(p *AffGProof) { p.T = nil },
+	} {
+		tampered := *proof
+		tamper(&tampered)
+		require.Error(t, tampered.Verify(verifyParams))
+	}
+}
+
+func TestAffGProofWrongWitness(t *testing.T) {
+	params, _ := affGSetup(t, curves.K256())
+	// D no longer matches x
+	params.ScalarX = new(big.Int).Add(params.ScalarX, core.One)
+	proof, err := params.Prove()
+	require.NoError(t, err)
+	require.Error(t, proof.Verify(affGVerifyParams(params)))
+
+	_, err = (&AffGProofParams{Curve: curves.K256()}).Prove()
+	require.Error(t, err)
+}
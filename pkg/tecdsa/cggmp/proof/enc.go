@@ -0,0 +1,179 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package proof
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/TEENet-io/kryptology/internal"
+	"github.com/TEENet-io/kryptology/pkg/core"
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
+	"github.com/TEENet-io/kryptology/pkg/paillier"
+)
+
+// EncProofParams contains the inputs to EncProof.Prove
+type EncProofParams struct {
+	Curve *curves.Curve
+	// PublicKey is the prover's Paillier key N0
+	PublicKey *paillier.PublicKey
+	// RingPedersen are the parameters of the verifier
+	RingPedersen *paillier.RingPedersenParams
+	Pi           uint32
+	// Sid is the session the proof is bound to, such as the ssid of CGGMP21. The prover and the verifier must
+	// use the same value, which may be empty.
+	Sid []byte
+	// K = enc(k; ρ) under PublicKey
+	K paillier.Ciphertext
+	// Plaintext k ∈ ±2^ℓ and Nonce ρ of K
+	Plaintext, Nonce *big.Int
+}
+
+// EncVerifyParams contains the inputs to EncProof.Verify
+type EncVerifyParams struct {
+	Curve        *curves.Curve
+	PublicKey    *paillier.PublicKey
+	RingPedersen *paillier.RingPedersenParams
+	Pi           uint32
+	Sid          []byte
+	K            paillier.Ciphertext
+}
+
+// EncProof proves that the Paillier ciphertext K encrypts a plaintext in ±2^{ℓ+ε}
+type EncProof struct {
+	S, A, C    *big.Int
+	Z1, Z2, Z3 *big.Int
+}
+
+// Prove that a Paillier ciphertext encrypts a plaintext in range
+// [CGGMP21] fig 14
+func (p *EncProofParams) Prove() (*EncProof, error) {
+	if p.Curve == nil || p.Pi == 0 || core.AnyNil(p.K, p.Plaintext, p.Nonce) {
+		return nil, internal.ErrNilArguments
+	}
+	if err := checkPublicKey(p.PublicKey); err != nil {
+		return nil, err
+	}
+	if err := checkRingPedersen(p.RingPedersen); err != nil {
+		return nil, err
+	}
+	q, err := curveOrder(p.Curve)
+	if err != nil {
+		return nil, err
+	}
+	n0, nHat := p.PublicKey.N, p.RingPedersen.N
+
+	// 1. Sample α ← ±2^{ℓ+ε}, μ ← ±2^ℓ·N̂, r ← Z_N0*, γ ← ±2^{ℓ+ε}·N̂
+	alpha, err := randomSigned(Ell+Epsilon, core.One)
+	if err != nil {
+		return nil, err
+	}
+	mu, err := randomSigned(Ell, nHat)
+	if err != nil {
+		return nil, err
+	}
+	r, err := randomUnit(n0)
+	if err != nil {
+		return nil, err
+	}
+	gamma, err := randomSigned(Ell+Epsilon, nHat)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. S = s^k t^μ, A = (1+N0)^α r^N0 mod N0², C = s^α t^γ mod N̂
+	proof := &EncProof{A: encrypt(p.PublicKey, alpha, r)}
+	if proof.S, err = pedersen(p.RingPedersen, p.Plaintext, mu); err != nil {
+		return nil, err
+	}
+	if proof.C, err = pedersen(p.RingPedersen, alpha, gamma); err != nil {
+		return nil, err
+	}
+
+	// 3. e ← FS-HASH(statement, S, A, C, Pi, Sid)
+	e, err := encChallenge(q, p.PublicKey, p.RingPedersen, p.K, proof, p.Pi, p.Sid)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. z1 = α + ek, z2 = r ρ^e mod N0, z3 = γ + eμ
+	proof.Z1 = new(big.Int).Add(alpha, new(big.Int).Mul(e, p.Plaintext))
+	if proof.Z2, err = mulExp(r, p.Nonce, e, n0); err != nil {
+		return nil, err
+	}
+	proof.Z3 = new(big.Int).Add(gamma, new(big.Int).Mul(e, mu))
+	return proof, nil
+}
+
+// Verify that a Paillier ciphertext encrypts a plaintext in range
+// [CGGMP21] fig 14
+func (p *EncProof) Verify(params *EncVerifyParams) error {
+	if p == nil || params == nil || params.Curve == nil || params.K == nil || params.Pi == 0 {
+		return internal.ErrNilArguments
+	}
+	if core.AnyNil(p.S, p.A, p.C, p.Z1, p.Z2, p.Z3) {
+		return internal.ErrNilArguments
+	}
+	if err := checkPublicKey(params.PublicKey); err != nil {
+		return err
+	}
+	if err := checkRingPedersen(params.RingPedersen); err != nil {
+		return err
+	}
+	q, err := curveOrder(params.Curve)
+	if err != nil {
+		return err
+	}
+	pk, rp := params.PublicKey, params.RingPedersen
+	if err = checkUnits(pk.N2, params.K, p.A); err != nil {
+		return err
+	}
+	if err = checkUnits(pk.N, p.Z2); err != nil {
+		return err
+	}
+	if err = checkUnits(rp.N, p.S, p.C); err != nil {
+		return err
+	}
+
+	// z1 ∈ ±2^{ℓ+ε}
+	if !inRange(p.Z1, Ell+Epsilon) {
+		return fmt.Errorf("enc proof response out of range")
+	}
+
+	e, err := encChallenge(q, pk, rp, params.K, p, params.Pi, params.Sid)
+	if err != nil {
+		return err
+	}
+
+	// (1+N0)^z1 z2^N0 = A K^e mod N0²
+	rhs, err := mulExp(p.A, params.K, e, pk.N2)
+	if err != nil {
+		return err
+	}
+	if encrypt(pk, p.Z1, p.Z2).Cmp(rhs) != 0 {
+		return fmt.Errorf("invalid enc proof")
+	}
+
+	// s^z1 t^z3 = C S^e mod N̂
+	lhs, err := pedersen(rp, p.Z1, p.Z3)
+	if err != nil {
+		return err
+	}
+	if rhs, err = mulExp(p.C, p.S, e, rp.N); err != nil {
+		return err
+	}
+	if lhs.Cmp(rhs) != 0 {
+		return fmt.Errorf("invalid enc proof")
+	}
+	return nil
+}
+
+// encChallenge computes the Fiat-Shamir challenge of EncProof
+func encChallenge(q *big.Int, pk *paillier.PublicKey, rp *paillier.RingPedersenParams, k paillier.Ciphertext,
+	p *EncProof, pi uint32, sid []byte) (*big.Int, error) {
+	return challenge(q, pk.N, rp.N, rp.S, rp.T, k, p.S, p.A, p.C, new(big.Int).SetUint64(uint64(pi)), sidInt(sid))
+}
@@ -0,0 +1,125 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package proof
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/TEENet-io/kryptology/pkg/core"
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
+)
+
+func TestEncProofWorks(t *testing.T) {
+	sk, _, rp := testSetup(t)
+	curve := curves.K256()
+	k, err := randomSigned(Ell, core.One)
+	require.NoError(t, err)
+	K, rho := testEncrypt(t, &sk.PublicKey, k)
+
+	proof, err := (&EncProofParams{
+		Curve:        curve,
+		PublicKey:    &sk.PublicKey,
+		RingPedersen: rp,
+		Pi:           1,
+		Sid:          []byte("session"),
+		K:            K,
+		Plaintext:    k,
+		Nonce:        rho,
+	}).Prove()
+	require.NoError(t, err)
+	params := &EncVerifyParams{Curve: curve, PublicKey: &sk.PublicKey, RingPedersen: rp, Pi: 1, Sid: []byte("session"), K: K}
+	require.NoError(t, proof.Verify(params))
+
+	bytes, err := json.Marshal(proof)
+	require.NoError(t, err)
+	unmarshaled := new(EncProof)
+	require.NoError(t, json.Unmarshal(bytes, unmarshaled))
+	require.NoError(t, unmarshaled.Verify(params))
+
+	// Wrong prover id
+	wrong := *params
+	wrong.Pi = 2
+	require.Error(t, proof.Verify(&wrong))
+
+	// Wrong session
+	wrong = *params
+	wrong.Sid = []byte("another session")
+	require.Error(t, proof.Verify(&wrong))
+
+	// Wrong ciphertext
+	wrong = *params
+	wrong.K, _ = testEncrypt(t, &sk.PublicKey, k)
+	require.Error(t, proof.Verify(&wrong))
+}
+
+func TestEncProofTampered(t *testing.T) {
+	sk, _, rp := testSetup(t)
+	curve := curves.K256()
+	k, err := randomSigned(Ell, core.One)
+	require.NoError(t, err)
+	K, rho := testEncrypt(t, &sk.PublicKey, k)
+	proof, err := (&EncProofParams{
+		Curve:        curve,
+		PublicKey:    &sk.PublicKey,
+		RingPedersen: rp,
+		Pi:           1,
+		K:            K,
+		Plaintext:    k,
+		Nonce:        rho,
+	}).Prove()
+	require.NoError(t, err)
+	params := &EncVerifyParams{Curve: curve, PublicKey: &sk.PublicKey, RingPedersen: rp, Pi: 1, K: K}
+
+	for _, tamper := range []func(p *EncProof){
+		func(p *EncProof) { p.Z1 = new(big.Int).Add(p.Z1, core.One) },
+		func(p *EncProof) { p.Z2 = new(big.Int).Add(p.Z2, core.One) },
+		func(p *EncProof) { p.Z3 = new(big.Int).Add(p.Z3, core.One) },
+		func(p *EncProof) { p.S = new(big.Int).Add(p.S, core.One) },
+		func(p *EncProof) { p.Z1 = new(big.Int).Lsh(core.One, Ell+Epsilon+1) },
+		func(p *EncProof) { p.A = nil },
+	} {
+		tampered := *proof
+		tamper(&tampered)
+		require.Error(t, tampered.Verify(params))
+	}
+}
+
+func TestEncProofOutOfRange(t *testing.T) {
+	sk, _, rp := testSetup(t)
+	// A plaintext far outside ±2^ℓ makes z1 fall outside ±2^{ℓ+ε}
+	k := new(big.Int).Lsh(core.One, Ell+Epsilon+8)
+	K, rho := testEncrypt(t, &sk.PublicKey, k)
+	proof, err := (&EncProofParams{
+		Curve:        curves.K256(),
+		PublicKey:    &sk.PublicKey,
+		RingPedersen: rp,
+		Pi:           1,
+		K:            K,
+		Plaintext:    k,
+		Nonce:        rho,
+	}).Prove()
+	require.NoError(t, err)
+	require.Error(t, proof.Verify(&EncVerifyParams{
+		Curve:        curves.K256(),
+		PublicKey:    &sk.PublicKey,
+		RingPedersen: rp,
+		Pi:           1,
+		K:            K,
+	}))
+}
+
+func TestEncProofNilArguments(t *testing.T) {
+	sk, _, rp := testSetup(t)
+	_, err := (&EncProofParams{Curve: curves.K256(), PublicKey: &sk.PublicKey, RingPedersen: rp, Pi: 1}).Prove()
+	require.Error(t, err)
+	var proof *EncProof
+	require.Error(t, proof.Verify(&EncVerifyParams{}))
+}
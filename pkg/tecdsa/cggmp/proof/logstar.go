@@ -0,0 +1,215 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package proof
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/TEENet-io/kryptology/internal"
+	"github.com/TEENet-io/kryptology/pkg/core"
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
+	"github.com/TEENet-io/kryptology/pkg/paillier"
+)
+
+// LogStarProofParams contains the inputs to LogStarProof.Prove
+type LogStarProofParams struct {
+	Curve *curves.Curve
+	// PublicKey is the prover's Paillier key N0
+	PublicKey *paillier.PublicKey
+	// RingPedersen are the parameters of the verifier
+	RingPedersen *paillier.RingPedersenParams
+	Pi           uint32
+	// Sid is the session the proof is bound to, such as the ssid of CGGMP21. The prover and the verifier must
+	// use the same value, which may be empty.
+	Sid []byte
+	// C = enc(x; ρ) under PublicKey
+	C paillier.Ciphertext
+	// X = G^x, where G is the generator if nil
+	X, G curves.Point
+	// Plaintext x ∈ ±2^ℓ and Nonce ρ of C
+	Plaintext, Nonce *big.Int
+}
+
+// LogStarVerifyParams contains the inputs to LogStarProof.Verify
+type LogStarVerifyParams struct {
+	Curve        *curves.Curve
+	PublicKey    *paillier.PublicKey
+	RingPedersen *paillier.RingPedersenParams
+	Pi           uint32
+	Sid          []byte
+	C            paillier.Ciphertext
+	X, G         curves.Point
+}
+
+// LogStarProof proves that the Paillier ciphertext C encrypts the discrete log of X to the base G,
+// and that it is in ±2^{ℓ+ε}
+type LogStarProof struct {
+	S, A       *big.Int
+	Y          curves.Point
+	D          *big.Int
+	Z1, Z2, Z3 *big.Int
+}
+
+// Prove knowledge of the exponent of X encrypted in C
+// [CGGMP21] fig 25
+func (p *LogStarProofParams) Prove() (*LogStarProof, error) {
+	if p.Curve == nil || p.X == nil || p.Pi == 0 || core.AnyNil(p.C, p.Plaintext, p.Nonce) {
+		return nil, internal.ErrNilArguments
+	}
+	if err := checkPublicKey(p.PublicKey); err != nil {
+		return nil, err
+	}
+	if err := checkRingPedersen(p.RingPedersen); err != nil {
+		return nil, err
+	}
+	q, err := curveOrder(p.Curve)
+	if err != nil {
+		return nil, err
+	}
+	g := p.G
+	if g == nil {
+		g = p.Curve.NewGeneratorPoint()
+	}
+	n0, nHat := p.PublicKey.N, p.RingPedersen.N
+
+	// 1. Sample α ← ±2^{ℓ+ε}, μ ← ±2^ℓ·N̂, r ← Z_N0*, γ ← ±2^{ℓ+ε}·N̂
+	alpha, err := randomSigned(Ell+Epsilon, core.One)
+	if err != nil {
+		return nil, err
+	}
+	mu, err := randomSigned(Ell, nHat)
+	if err != nil {
+		return nil, err
+	}
+	r, err := randomUnit(n0)
+	if err != nil {
+		return nil, err
+	}
+	gamma, err := randomSigned(Ell+Epsilon, nHat)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. S = s^x t^μ, A = (1+N0)^α r^N0 mod N0², Y = G^α, D = s^α t^γ mod N̂
+	proof := &LogStarProof{A: encrypt(p.PublicKey, alpha, r)}
+	if proof.S, err = pedersen(p.RingPedersen, p.Plaintext, mu); err != nil {
+		return nil, err
+	}
+	a, err := scalar(p.Curve, alpha, q)
+	if err != nil {
+		return nil, err
+	}
+	proof.Y = g.Mul(a)
+	if proof.D, err = pedersen(p.RingPedersen, alpha, gamma); err != nil {
+		return nil, err
+	}
+
+	// 3. e ← FS-HASH(statement, S, A, Y, D, Pi, Sid)
+	e, err := logStarChallenge(q, p.PublicKey, p.RingPedersen, p.C, p.X, g, proof, p.Pi, p.Sid)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. z1 = α + ex, z2 = r ρ^e mod N0, z3 = γ + eμ
+	proof.Z1 = new(big.Int).Add(alpha, new(big.Int).Mul(e, p.Plaintext))
+	if proof.Z2, err = mulExp(r, p.Nonce, e, n0); err != nil {
+		return nil, err
+	}
+	proof.Z3 = new(big.Int).Add(gamma, new(big.Int).Mul(e, mu))
+	return proof, nil
+}
+
+// Verify knowledge of the exponent of X encrypted in C
+// [CGGMP21] fig 25
+func (p *LogStarProof) Verify(params *LogStarVerifyParams) error {
+	if p == nil || params == nil || params.Curve == nil || params.C == nil || params.X == nil || params.Pi == 0 {
+		return internal.ErrNilArguments
+	}
+	if p.Y == nil || core.AnyNil(p.S, p.A, p.D, p.Z1, p.Z2, p.Z3) {
+		return internal.ErrNilArguments
+	}
+	if err := checkPublicKey(params.PublicKey); err != nil {
+		return err
+	}
+	if err := checkRingPedersen(params.RingPedersen); err != nil {
+		return err
+	}
+	q, err := curveOrder(params.Curve)
+	if err != nil {
+		return err
+	}
+	g := params.G
+	if g == nil {
+		g = params.Curve.NewGeneratorPoint()
+	}
+	pk, rp := params.PublicKey, params.RingPedersen
+	if err = checkUnits(pk.N2, params.C, p.A); err != nil {
+		return err
+	}
+	if err = checkUnits(pk.N, p.Z2); err != nil {
+		return err
+	}
+	if err = checkUnits(rp.N, p.S, p.D); err != nil {
+		return err
+	}
+	if !p.Y.IsOnCurve() || !params.X.IsOnCurve() || !g.IsOnCurve() {
+		return internal.ErrNotOnCurve
+	}
+
+	// z1 ∈ ±2^{ℓ+ε}
+	if !inRange(p.Z1, Ell+Epsilon) {
+		return fmt.Errorf("log* proof response out of range")
+	}
+
+	e, err := logStarChallenge(q, pk, rp, params.C, params.X, g, p, params.Pi, params.Sid)
+	if err != nil {
+		return err
+	}
+
+	// (1+N0)^z1 z2^N0 = A C^e mod N0²
+	rhs, err := mulExp(p.A, params.C, e, pk.N2)
+	if err != nil {
+		return err
+	}
+	if encrypt(pk, p.Z1, p.Z2).Cmp(rhs) != 0 {
+		return fmt.Errorf("invalid log* proof")
+	}
+
+	// G^z1 = Y X^e
+	z1, err := scalar(params.Curve, p.Z1, q)
+	if err != nil {
+		return err
+	}
+	es, err := scalar(params.Curve, e, q)
+	if err != nil {
+		return err
+	}
+	if !g.Mul(z1).Equal(p.Y.Add(params.X.Mul(es))) {
+		return fmt.Errorf("invalid log* proof")
+	}
+
+	// s^z1 t^z3 = D S^e mod N̂
+	lhs, err := pedersen(rp, p.Z1, p.Z3)
+	if err != nil {
+		return err
+	}
+	if rhs, err = mulExp(p.D, p.S, e, rp.N); err != nil {
+		return err
+	}
+	if lhs.Cmp(rhs) != 0 {
+		return fmt.Errorf("invalid log* proof")
+	}
+	return nil
+}
+
+// logStarChallenge computes the Fiat-Shamir challenge of LogStarProof
+func logStarChallenge(q *big.Int, pk *paillier.PublicKey, rp *paillier.RingPedersenParams, c paillier.Ciphertext,
+	x, g curves.Point, p *LogStarProof, pi uint32, sid []byte) (*big.Int, error) {
+	return challenge(q, pk.N, rp.N, rp.S, rp.T, c, pointInt(x), pointInt(g), p.S, p.A, pointInt(p.Y), p.D,
+		new(big.Int).SetUint64(uint64(pi)), sidInt(sid))
+}
@@ -0,0 +1,123 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package proof
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/TEENet-io/kryptology/pkg/core"
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
+)
+
+func TestLogStarProofWorks(t *testing.T) {
+	sk, _, rp := testSetup(t)
+	for _, curve := range []*curves.Curve{curves.K256(), curves.P256()} {
+		for _, g := range []curves.Point{nil, curve.Point.Random(rand.Reader)} {
+			x := curve.Scalar.Random(rand.Reader)
+			C, rho := testEncrypt(t, &sk.PublicKey, x.BigInt())
+			base := g
+			if base == nil {
+				base = curve.NewGeneratorPoint()
+			}
+			X := base.Mul(x)
+
+			proof, err := (&LogStarProofParams{
+				Curve:        curve,
+				PublicKey:    &sk.PublicKey,
+				RingPedersen: rp,
+				Pi:           3,
+				Sid:          []byte("session"),
+				C:            C,
+				X:            X,
+				G:            g,
+				Plaintext:    x.BigInt(),
+				Nonce:        rho,
+			}).Prove()
+			require.NoError(t, err)
+			params := &LogStarVerifyParams{
+				Curve:        curve,
+				PublicKey:    &sk.PublicKey,
+				RingPedersen: rp,
+				Pi:           3,
+				Sid:          []byte("session"),
+				C:            C,
+				X:            X,
+				G:            g,
+			}
+			require.NoError(t, proof.Verify(params))
+
+			// Wrong session
+			wrong := *params
+			wrong.Sid = []byte("another session")
+			require.Error(t, proof.Verify(&wrong))
+
+			// Wrong point
+			wrong = *params
+			wrong.X = X.Add(curve.NewGeneratorPoint())
+			require.Error(t, proof.Verify(&wrong))
+
+			// Wrong base
+			wrong = *params
+			wrong.G = curve.Point.Random(rand.Reader)
+			require.Error(t, proof.Verify(&wrong))
+		}
+	}
+}
+
+func TestLogStarProofTampered(t *testing.T) {
+	sk, _, rp := testSetup(t)
+	curve := curves.K256()
+	x := curve.Scalar.Random(rand.Reader)
+	C, rho := testEncrypt(t, &sk.PublicKey, x.BigInt())
+	X := curve.ScalarBaseMult(x)
+	proof, err := (&LogStarProofParams{
+		Curve:        curve,
+		PublicKey:    &sk.PublicKey,
+		RingPedersen: rp,
+		Pi:           1,
+		C:            C,
+		X:            X,
+		Plaintext:    x.BigInt(),
+		Nonce:        rho,
+	}).Prove()
+	require.NoError(t, err)
+	params := &LogStarVerifyParams{Curve: curve, PublicKey: &sk.PublicKey, RingPedersen: rp, Pi: 1, C: C, X: X}
+
+	for _, tamper := range []func(p *LogStarProof){
+		func(p *LogStarProof) { p.Z1 = new(big.Int).Add(p.Z1, core.One) },
+		func(p *LogStarProof) { p.Z2 = new(big.Int).Add(p.Z2, core.One) },
+		func(p *LogStarProof) { p.Z3 = new(big.Int).Add(p.Z3, core.One) },
+		func(p *LogStarProof) { p.Y = p.Y.Double() },
+		func(p *LogStarProof) { p.D = new(big.Int).Add(p.D, core.One) },
+		func(p *LogStarProof) { p.Y = nil },
+	} {
+		tampered := *proof
+		tamper(&tampered)
+		require.Error(t, tampered.Verify(params))
+	}
+
+	// Encryption of a different exponent
+	y := curve.Scalar.Random(rand.Reader)
+	D, rhoY := testEncrypt(t, &sk.PublicKey, y.BigInt())
+	proof, err = (&LogStarProofParams{
+		Curve:        curve,
+		PublicKey:    &sk.PublicKey,
+		RingPedersen: rp,
+		Pi:           1,
+		C:            D,
+		X:            X,
+		Plaintext:    y.BigInt(),
+		Nonce:        rhoY,
+	}).Prove()
+	require.NoError(t, err)
+	params.C = D
+	require.Error(t, proof.Verify(params))
+}
@@ -0,0 +1,187 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// Package proof contains the zero-knowledge proofs of CGGMP21 presigning that are not about the
+// Paillier modulus itself [CGGMP21] https://eprint.iacr.org/2021/060.pdf
+//   - Paillier encryption in range, Πenc (fig 14)
+//   - Paillier affine operation with group commitment in range, Πaff-g (fig 15)
+//   - knowledge of exponent vs Paillier encryption, Πlog* (fig 25)
+//
+// Every proof is made to a specific verifier: it uses the verifier's Ring-Pedersen parameters,
+// which must have been checked with paillier.PrmProof. Challenges are computed with Fiat-Shamir
+// over the whole statement, the prover's identifier and the session id Sid.
+package proof
+
+import (
+	crand "crypto/rand"
+	"fmt"
+	"math/big"
+
+	"github.com/TEENet-io/kryptology/internal"
+	"github.com/TEENet-io/kryptology/pkg/core"
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
+	"github.com/TEENet-io/kryptology/pkg/paillier"
+)
+
+const (
+	// Ell is ℓ, the bit length of the secrets k_i, γ_i and x_i
+	Ell = 256
+	// EllPrime is ℓ', the bit length of the masks β_{i,j} of the affine operations
+	EllPrime = 5 * Ell
+	// Epsilon is the slackness parameter ε of the range proofs
+	Epsilon = 2 * Ell
+)
+
+// curveOrder returns q, the order of the group of curve
+func curveOrder(curve *curves.Curve) (*big.Int, error) {
+	if curve == nil {
+		return nil, internal.ErrNilArguments
+	}
+	ec, err := curve.ToEllipticCurve()
+	if err != nil {
+		return nil, err
+	}
+	return ec.Params().N, nil
+}
+
+// challenge computes the Fiat-Shamir challenge e ∈ [0, q) of values
+func challenge(q *big.Int, values ...*big.Int) (*big.Int, error) {
+	for counter := int64(0); ; counter++ {
+		h, err := core.FiatShamir(append(values, big.NewInt(counter))...)
+		if err != nil {
+			return nil, err
+		}
+		e := new(big.Int).SetBytes(h)
+		// Reject rather than reduce so that e is uniform in [0, q)
+		if e.Cmp(q) == -1 {
+			return e, nil
+		}
+	}
+}
+
+// sidInt encodes a session id as an integer for hashing. The leading one keeps leading zero bytes of sid.
+func sidInt(sid []byte) *big.Int {
+	return new(big.Int).SetBytes(append([]byte{1}, sid...))
+}
+
+// pointInt returns the compressed encoding of p as an integer, for hashing
+func pointInt(p curves.Point) *big.Int {
+	return new(big.Int).SetBytes(p.ToAffineCompressed())
+}
+
+// randomSigned returns a uniform integer in [-2^bits·m, 2^bits·m]
+func randomSigned(bits uint, m *big.Int) (*big.Int, error) {
+	bound := new(big.Int).Lsh(m, bits)
+	r, err := crand.Int(crand.Reader, new(big.Int).Add(new(big.Int).Lsh(bound, 1), core.One))
+	if err != nil {
+		return nil, err
+	}
+	return r.Sub(r, bound), nil
+}
+
+// inRange checks |x| ≤ 2^bits
+func inRange(x *big.Int, bits uint) bool {
+	return x.CmpAbs(new(big.Int).Lsh(core.One, bits)) <= 0
+}
+
+// randomUnit returns a uniform element of Z_N*
+func randomUnit(n *big.Int) (*big.Int, error) {
+	for {
+		r, err := core.Rand(n)
+		if err != nil {
+			return nil, err
+		}
+		if r.Sign() == 1 && new(big.Int).GCD(nil, nil, r, n).Cmp(core.One) == 0 {
+			return r, nil
+		}
+	}
+}
+
+// exp computes b^e mod m for a signed exponent e
+func exp(b, e, m *big.Int) (*big.Int, error) {
+	r := new(big.Int).Exp(b, e, m)
+	// Exp returns nil when e is negative and b is not invertible
+	if r == nil {
+		return nil, fmt.Errorf("cannot compute the multiplicative inverse")
+	}
+	return r, nil
+}
+
+// pedersen computes s^x t^y mod N̂ for signed exponents x and y
+func pedersen(rp *paillier.RingPedersenParams, x, y *big.Int) (*big.Int, error) {
+	sx, err := exp(rp.S, x, rp.N)
+	if err != nil {
+		return nil, err
+	}
+	ty, err := exp(rp.T, y, rp.N)
+	if err != nil {
+		return nil, err
+	}
+	sx.Mul(sx, ty)
+	return sx.Mod(sx, rp.N), nil
+}
+
+// encrypt computes (1+N)^m r^N mod N² for a signed message m
+func encrypt(pk *paillier.PublicKey, m, r *big.Int) *big.Int {
+	// (1+N)^m = 1 + mN mod N²
+	c := new(big.Int).Mod(m, pk.N)
+	c.Mul(c, pk.N)
+	c.Add(c, core.One)
+	c.Mul(c, new(big.Int).Exp(r, pk.N, pk.N2))
+	return c.Mod(c, pk.N2)
+}
+
+// mulExp computes a·b^e mod m
+func mulExp(a, b, e, m *big.Int) (*big.Int, error) {
+	r, err := exp(b, e, m)
+	if err != nil {
+		return nil, err
+	}
+	r.Mul(r, a)
+	return r.Mod(r, m), nil
+}
+
+// scalar returns x mod q as a scalar of curve
+func scalar(curve *curves.Curve, x, q *big.Int) (curves.Scalar, error) {
+	return curve.Scalar.SetBigInt(new(big.Int).Mod(x, q))
+}
+
+// checkRingPedersen checks that the Ring-Pedersen parameters are present and are units
+func checkRingPedersen(rp *paillier.RingPedersenParams) error {
+	if rp == nil || core.AnyNil(rp.N, rp.S, rp.T) {
+		return internal.ErrNilArguments
+	}
+	for _, v := range []*big.Int{rp.S, rp.T} {
+		if err := core.In(v, rp.N); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkUnits checks that each of values is in Z_N*
+func checkUnits(n *big.Int, values ...*big.Int) error {
+	for _, v := range values {
+		if v == nil {
+			return internal.ErrNilArguments
+		}
+		if err := core.In(v, n); err != nil {
+			return err
+		}
+		if new(big.Int).GCD(nil, nil, v, n).Cmp(core.One) != 0 {
+			return fmt.Errorf("value is not a unit")
+		}
+	}
+	return nil
+}
+
+// checkPublicKey checks that a Paillier public key is present with N² cached
+func checkPublicKey(pk *paillier.PublicKey) error {
+	if pk == nil || pk.N == nil || pk.N2 == nil {
+		return internal.ErrNilArguments
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package proof
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	tt "github.com/TEENet-io/kryptology/internal"
+	"github.com/TEENet-io/kryptology/pkg/core"
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
+	"github.com/TEENet-io/kryptology/pkg/paillier"
+)
+
+// 1024-bit safe primes, so that each Paillier modulus has 2048 bits
+var testPrimes = []*big.Int{
+	tt.B10("186141419611617071752010179586510154515933389116254425631491755419216243670159714804545944298892950871169229878325987039840135057969555324774918895952900547869933648175107076399993833724447909579697857041081987997463765989497319509683575289675966710007879762972723174353568113668226442698275449371212397561567"),
+	tt.B10("94210786053667323206442523040419729883258172350738703980637961803118626748668924192069593010365236618255120977661397310932923345291377692570649198560048403943687994859423283474169530971418656709749020402756179383990602363122039939937953514870699284906666247063852187255623958659551404494107714695311474384687"),
+	tt.B10("130291226847076770981564372061529572170236135412763130013877155698259035960569046218348763182598589633420963942796327547969527085797839549642610021986391589746295634536750785366034581957858065740296991986002552598751827526181747791647357767502200771965093659353354985289411489453223546075843993686648576029043"),
+	tt.B10("172938910323633442195852028319756134734590277522945546987913328782597284762767185925315797321999389252040294991952361905020940252121762387957669654615602135429944435719699091344247805645764550860505536884031064967454028383404046221898300153428182409080298694828920944094158777327533157774919783417586902830043"),
+}
+
+// testSetup returns the prover's Paillier key and the verifier's Paillier key and Ring-Pedersen parameters
+func testSetup(t *testing.T) (*paillier.SecretKey, *paillier.SecretKey, *paillier.RingPedersenParams) {
+	prover, err := paillier.NewSecretKey(testPrimes[0], testPrimes[1])
+	require.NoError(t, err)
+	verifier, err := paillier.NewSecretKey(testPrimes[2], testPrimes[3])
+	require.NoError(t, err)
+	rp, _, err := paillier.NewRingPedersenParams(verifier)
+	require.NoError(t, err)
+	return prover, verifier, rp
+}
+
+// testEncrypt encrypts a signed message under pk and returns the ciphertext and nonce
+func testEncrypt(t *testing.T, pk *paillier.PublicKey, m *big.Int) (*big.Int, *big.Int) {
+	r, err := randomUnit(pk.N)
+	require.NoError(t, err)
+	return encrypt(pk, m, r), r
+}
+
+func TestRandomSigned(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		x, err := randomSigned(Ell, core.One)
+		require.NoError(t, err)
+		require.True(t, inRange(x, Ell))
+	}
+	require.True(t, inRange(new(big.Int).Lsh(core.One, Ell), Ell))
+	require.True(t, inRange(new(big.Int).Neg(new(big.Int).Lsh(core.One, Ell)), Ell))
+	require.False(t, inRange(new(big.Int).Add(new(big.Int).Lsh(core.One, Ell), core.One), Ell))
+}
+
+func TestEncryptSigned(t *testing.T) {
+	sk, _, _ := testSetup(t)
+	m := big.NewInt(-42)
+	c, _ := testEncrypt(t, &sk.PublicKey, m)
+	d, err := sk.Decrypt(c)
+	require.NoError(t, err)
+	require.Equal(t, new(big.Int).Add(sk.N, m), d)
+}
+
+func TestChallengeInRange(t *testing.T) {
+	q, err := curveOrder(curves.K256())
+	require.NoError(t, err)
+	e1, err := challenge(q, big.NewInt(1), big.NewInt(2))
+	require.NoError(t, err)
+	e2, err := challenge(q, big.NewInt(1), big.NewInt(2))
+	require.NoError(t, err)
+	require.Equal(t, e1, e2)
+	require.Equal(t, -1, e1.Cmp(q))
+	_, err = curveOrder(nil)
+	require.Error(t, err)
+}
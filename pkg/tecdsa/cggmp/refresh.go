@@ -0,0 +1,498 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package cggmp
+
+import (
+	crand "crypto/rand"
+	"fmt"
+	"math/big"
+
+	"github.com/TEENet-io/kryptology/internal"
+	"github.com/TEENet-io/kryptology/pkg/core"
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
+	"github.com/TEENet-io/kryptology/pkg/paillier"
+	"github.com/TEENet-io/kryptology/pkg/zkp/schnorr"
+)
+
+const refreshProtocol = "refresh"
+
+// minModulusBits is the minimum bit length of the Paillier modulus of a party
+const minModulusBits = 2*paillier.PaillierPrimeBits - 1
+
+// RefreshParticipant is a party of CGGMP21 auxiliary info and key refresh. It generates a new
+// Paillier key and Ring-Pedersen parameters, and re-randomizes the key shares without changing the public key
+// [CGGMP21] fig 7
+type RefreshParticipant struct {
+	Round        uint
+	share        *KeyShare
+	sid          []byte
+	genSafePrime func(uint) (*big.Int, error)
+	state        *refreshState
+}
+
+// refreshState holds the values accumulated during the refresh rounds
+type refreshState struct {
+	// Round 1 variables
+	sk           *paillier.SecretKey
+	ringPedersen *paillier.RingPedersenParams
+	prmProof     *paillier.PrmProof
+	// shares x_i^j of zero and their public values X_i^j
+	shares      map[uint32]curves.Scalar
+	sharePoints map[uint32]curves.Point
+	rid         []byte
+	witness     *core.Witness
+	// Round 2 variables
+	commitments map[uint32]core.Commitment
+	// Round 3 variables
+	peers       map[uint32]*RefreshRound2Bcast
+	combinedRid []byte
+}
+
+// RefreshRound1Bcast contains values to be broadcast to all parties after the completion of refresh round 1
+type RefreshRound1Bcast struct {
+	Commitment core.Commitment
+}
+
+// RefreshRound2Bcast contains values to be broadcast to all parties after the completion of refresh round 2
+type RefreshRound2Bcast struct {
+	// RingPedersen parameters over the new Paillier modulus N of the party
+	RingPedersen *paillier.RingPedersenParams
+	PrmProof     *paillier.PrmProof
+	// SharePoints are the X_i^j = g^{x_i^j} of the shares of zero
+	SharePoints map[uint32]curves.Point
+	Rid         []byte
+	Witness     *core.Witness
+}
+
+// RefreshRound3Bcast contains values to be broadcast to all parties after the completion of refresh round 3
+type RefreshRound3Bcast struct {
+	ModProof *paillier.ModProof
+	// SchnorrProofs prove knowledge of each x_i^j
+	SchnorrProofs map[uint32]*schnorr.Proof
+}
+
+// RefreshRound3P2PSend contains values to be sent to a specific party after the completion of refresh round 3
+type RefreshRound3P2PSend struct {
+	// Share is x_i^j encrypted under the recipient's new Paillier key
+	Share    paillier.Ciphertext
+	FacProof *paillier.FacProof
+}
+
+// NewRefreshParticipant creates a party of auxiliary info and key refresh for the key share.
+// sid is a session identifier that all parties agree on and that is unique to this execution
+func NewRefreshParticipant(share *KeyShare, sid []byte) (*RefreshParticipant, error) {
+	return newRefreshParticipant(share, sid, core.GenerateSafePrime)
+}
+
+// newRefreshParticipant creates a party that generates its Paillier primes with genSafePrime
+func newRefreshParticipant(share *KeyShare, sid []byte, genSafePrime func(uint) (*big.Int, error)) (*RefreshParticipant, error) {
+	if len(sid) == 0 || genSafePrime == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if err := share.validate(); err != nil {
+		return nil, err
+	}
+	return &RefreshParticipant{
+		Round:        1,
+		share:        share,
+		sid:          append([]byte{}, sid...),
+		genSafePrime: genSafePrime,
+		state:        &refreshState{},
+	}, nil
+}
+
+// Round1 generates the Paillier key, Ring-Pedersen parameters and shares of zero, and commits to them
+// [CGGMP21] fig 7 round 1
+func (rp *RefreshParticipant) Round1() (*RefreshRound1Bcast, error) {
+	if rp.Round != 1 {
+		return nil, internal.ErrInvalidRound
+	}
+	ks := rp.share
+
+	// Sample safe primes p_i, q_i and set N_i = p_i q_i, with Ring-Pedersen parameters s_i, t_i over N_i
+	sk, err := rp.newPaillierKey()
+	if err != nil {
+		return nil, err
+	}
+	ringPedersen, lambda, err := paillier.NewRingPedersenParams(sk)
+	if err != nil {
+		return nil, err
+	}
+	// ψ̂_i = M(prove, Π^prm_(sid, i), (N_i, s_i, t_i); λ_i)
+	prmProof, err := (&paillier.PrmProofParams{
+		RingPedersen: ringPedersen,
+		Lambda:       lambda,
+		Totient:      sk.Totient,
+		Pi:           ks.ID,
+		Sid:          refreshProofSid(rp.sid, nil),
+	}).Prove()
+	if err != nil {
+		return nil, err
+	}
+
+	// Sample x_i^j ← F_q with Σ_j x_i^j = 0 and set X_i^j = g^{x_i^j}
+	shares := make(map[uint32]curves.Scalar, len(ks.Parties))
+	sharePoints := make(map[uint32]curves.Point, len(ks.Parties))
+	sum := ks.Curve.Scalar.Zero()
+	for _, j := range ks.Parties {
+		if j == ks.ID {
+			continue
+		}
+		x, err := randomNonZero(ks.Curve)
+		if err != nil {
+			return nil, err
+		}
+		shares[j] = x
+		sum = sum.Add(x)
+	}
+	shares[ks.ID] = sum.Neg()
+	for j, x := range shares {
+		sharePoints[j] = ks.Curve.ScalarBaseMult(x)
+	}
+
+	rid := make([]byte, ridLength)
+	if _, err = crand.Read(rid); err != nil {
+		return nil, err
+	}
+
+	// V_i = H(sid, i, X_i, N_i, s_i, t_i, ψ̂_i, rid_i)
+	msg := &RefreshRound2Bcast{
+		RingPedersen: ringPedersen,
+		PrmProof:     prmProof,
+		SharePoints:  sharePoints,
+		Rid:          rid,
+	}
+	c, w, err := core.Commit(msg.digest(rp.sid, ks.ID, ks.Parties))
+	if err != nil {
+		return nil, err
+	}
+
+	rp.state.sk = sk
+	rp.state.ringPedersen = ringPedersen
+	rp.state.prmProof = prmProof
+	rp.state.shares = shares
+	rp.state.sharePoints = sharePoints
+	rp.state.rid = rid
+	rp.state.witness = w
+	rp.Round = 2
+	return &RefreshRound1Bcast{Commitment: c}, nil
+}
+
+// Round2 stores the commitments of the other parties and reveals the committed values
+// [CGGMP21] fig 7 round 2
+func (rp *RefreshParticipant) Round2(in map[uint32]*RefreshRound1Bcast) (*RefreshRound2Bcast, error) {
+	if rp.Round != 2 {
+		return nil, internal.ErrInvalidRound
+	}
+	ks := rp.share
+	if err := checkInput(ks.ID, ks.Parties, in); err != nil {
+		return nil, err
+	}
+	rp.state.commitments = make(map[uint32]core.Commitment, len(ks.Parties)-1)
+	for _, j := range ks.Parties {
+		if j == ks.ID {
+			continue
+		}
+		if in[j] == nil || len(in[j].Commitment) == 0 {
+			return nil, newAbortError(refreshProtocol, 2, j, internal.ErrNilArguments)
+		}
+		rp.state.commitments[j] = in[j].Commitment
+	}
+	rp.Round = 3
+	return &RefreshRound2Bcast{
+		RingPedersen: rp.state.ringPedersen,
+		PrmProof:     rp.state.prmProof,
+		SharePoints:  rp.state.sharePoints,
+		Rid:          rp.state.rid,
+		Witness:      rp.state.witness,
+	}, nil
+}
+
+// Round3 checks the revealed values, proves that the new Paillier modulus is well formed
+// and sends each party its encrypted share of zero
+// [CGGMP21] fig 7 round 3
+func (rp *RefreshParticipant) Round3(in map[uint32]*RefreshRound2Bcast) (*RefreshRound3Bcast, map[uint32]*RefreshRound3P2PSend, error) {
+	if rp.Round != 3 {
+		return nil, nil, internal.ErrInvalidRound
+	}
+	ks := rp.share
+	if err := checkInput(ks.ID, ks.Parties, in); err != nil {
+		return nil, nil, err
+	}
+	rp.state.peers = make(map[uint32]*RefreshRound2Bcast, len(ks.Parties)-1)
+	rids := map[uint32][]byte{ks.ID: rp.state.rid}
+	for _, j := range ks.Parties {
+		if j == ks.ID {
+			continue
+		}
+		if err := rp.verifyRound2(j, in[j]); err != nil {
+			return nil, nil, newAbortError(refreshProtocol, 3, j, err)
+		}
+		rp.state.peers[j] = in[j]
+		rids[j] = in[j].Rid
+	}
+
+	// rid = ⊕_j rid_j
+	rid, err := xorRid(rids)
+	if err != nil {
+		return nil, nil, err
+	}
+	rp.state.combinedRid = rid
+
+	// ψ_i = M(prove, Π^mod_(sid, i, rid), N_i; p_i, q_i)
+	modProof, err := (&paillier.ModProofParams{
+		SecretKey: rp.state.sk,
+		Pi:        ks.ID,
+		Sid:       refreshProofSid(rp.sid, rid),
+	}).Prove()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// ψ_i^j = M(prove, Π^sch_(sid, i, rid), X_i^j; x_i^j)
+	schnorrProofs := make(map[uint32]*schnorr.Proof, len(ks.Parties))
+	for _, j := range ks.Parties {
+		prover := schnorr.NewProver(ks.Curve, nil, refreshSessionId(rp.sid, rid, ks.ID, j))
+		if schnorrProofs[j], err = prover.Prove(rp.state.shares[j]); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// For each j: C_i^j = enc_j(x_i^j) and φ_i^j = M(prove, Π^fac_(sid, i, rid), (N_i, s_j, t_j); p_i, q_i)
+	p2p := make(map[uint32]*RefreshRound3P2PSend, len(ks.Parties)-1)
+	for j, peer := range rp.state.peers {
+		pk, err := paillier.NewPubkey(peer.RingPedersen.N)
+		if err != nil {
+			return nil, nil, err
+		}
+		c, _, err := pk.Encrypt(rp.state.shares[j].BigInt())
+		if err != nil {
+			return nil, nil, err
+		}
+		facProof, err := (&paillier.FacProofParams{
+			SecretKey:    rp.state.sk,
+			RingPedersen: peer.RingPedersen,
+			Pi:           ks.ID,
+			Sid:          refreshProofSid(rp.sid, rid),
+		}).Prove()
+		if err != nil {
+			return nil, nil, err
+		}
+		p2p[j] = &RefreshRound3P2PSend{Share: c, FacProof: facProof}
+	}
+
+	rp.Round = 4
+	return &RefreshRound3Bcast{ModProof: modProof, SchnorrProofs: schnorrProofs}, p2p, nil
+}
+
+// Output verifies the proofs of the other parties, decrypts the shares of zero sent to this party
+// and returns the refreshed key share with the new auxiliary info
+// [CGGMP21] fig 7 output
+func (rp *RefreshParticipant) Output(in map[uint32]*RefreshRound3Bcast, p2p map[uint32]*RefreshRound3P2PSend) (*KeyShare, error) {
+	if rp.Round != 4 {
+		return nil, internal.ErrInvalidRound
+	}
+	ks := rp.share
+	if err := checkInput(ks.ID, ks.Parties, in); err != nil {
+		return nil, err
+	}
+	if err := checkInput(ks.ID, ks.Parties, p2p); err != nil {
+		return nil, err
+	}
+
+	paillierKeys := map[uint32]*paillier.PublicKey{ks.ID: &rp.state.sk.PublicKey}
+	ringPedersen := map[uint32]*paillier.RingPedersenParams{ks.ID: rp.state.ringPedersen}
+	// x_i = x_i + Σ_j x_j^i
+	secretShare := ks.SecretShare.Add(rp.state.shares[ks.ID])
+	for _, j := range ks.Parties {
+		if j == ks.ID {
+			continue
+		}
+		pk, err := paillier.NewPubkey(rp.state.peers[j].RingPedersen.N)
+		if err != nil {
+			return nil, err
+		}
+		x, err := rp.verifyRound3(j, pk, in[j], p2p[j])
+		if err != nil {
+			return nil, newAbortError(refreshProtocol, 4, j, err)
+		}
+		secretShare = secretShare.Add(x)
+		paillierKeys[j] = pk
+		ringPedersen[j] = rp.state.peers[j].RingPedersen
+	}
+
+	// X_k = X_k · ∏_j X_j^k
+	publicShares := make(map[uint32]curves.Point, len(ks.Parties))
+	for _, k := range ks.Parties {
+		X := ks.PublicShares[k].Add(rp.state.sharePoints[k])
+		for _, peer := range rp.state.peers {
+			X = X.Add(peer.SharePoints[k])
+		}
+		publicShares[k] = X
+	}
+	if !ks.Curve.ScalarBaseMult(secretShare).Equal(publicShares[ks.ID]) {
+		return nil, fmt.Errorf("refreshed secret share does not match its public share")
+	}
+
+	rp.Round = 5
+	return &KeyShare{
+		Curve:        ks.Curve,
+		ID:           ks.ID,
+		Parties:      append([]uint32{}, ks.Parties...),
+		SecretShare:  secretShare,
+		PublicShares: publicShares,
+		PublicKey:    ks.PublicKey,
+		Rid:          rp.state.combinedRid,
+		PaillierKey:  rp.state.sk,
+		PaillierKeys: paillierKeys,
+		RingPedersen: ringPedersen,
+	}, nil
+}
+
+// verifyRound2 checks the values revealed by party j
+func (rp *RefreshParticipant) verifyRound2(j uint32, msg *RefreshRound2Bcast) error {
+	ks := rp.share
+	if msg == nil || msg.RingPedersen == nil || msg.PrmProof == nil || msg.Witness == nil ||
+		core.AnyNil(msg.RingPedersen.N, msg.RingPedersen.S, msg.RingPedersen.T) {
+		return internal.ErrNilArguments
+	}
+	if len(msg.Rid) != ridLength || len(msg.SharePoints) != len(ks.Parties) {
+		return fmt.Errorf("invalid round 2 broadcast")
+	}
+	// ∏_k X_j^k = 1
+	sum := ks.Curve.NewIdentityPoint()
+	for _, k := range ks.Parties {
+		if !onCurve(ks.Curve, msg.SharePoints[k]) {
+			return internal.ErrNotOnCurve
+		}
+		sum = sum.Add(msg.SharePoints[k])
+	}
+	if !sum.IsIdentity() {
+		return fmt.Errorf("shares are not shares of zero")
+	}
+	if err := openCommitment(rp.state.commitments[j], msg.Witness, msg.digest(rp.sid, j, ks.Parties)); err != nil {
+		return err
+	}
+	// N_j ≥ 2^{8κ-1}
+	if msg.RingPedersen.N.BitLen() < minModulusBits {
+		return fmt.Errorf("paillier modulus is too small")
+	}
+	return msg.PrmProof.Verify(&paillier.PrmVerifyParams{
+		RingPedersen: msg.RingPedersen,
+		Pi:           j,
+		Sid:          refreshProofSid(rp.sid, nil),
+	})
+}
+
+// verifyRound3 checks the proofs of party j and returns the share of zero it sent to this party
+func (rp *RefreshParticipant) verifyRound3(j uint32, pk *paillier.PublicKey, msg *RefreshRound3Bcast,
+	p2p *RefreshRound3P2PSend) (curves.Scalar, error) {
+	ks := rp.share
+	if msg == nil || msg.ModProof == nil || p2p == nil || p2p.Share == nil || p2p.FacProof == nil {
+		return nil, internal.ErrNilArguments
+	}
+	proofSid := refreshProofSid(rp.sid, rp.state.combinedRid)
+	if err := msg.ModProof.Verify(&paillier.ModVerifyParams{PublicKey: pk, Pi: j, Sid: proofSid}); err != nil {
+		return nil, err
+	}
+	for _, k := range ks.Parties {
+		proof := msg.SchnorrProofs[k]
+		if proof == nil || proof.Statement == nil || !proof.Statement.Equal(rp.state.peers[j].SharePoints[k]) {
+			return nil, fmt.Errorf("invalid schnorr proof")
+		}
+		if err := schnorr.Verify(proof, ks.Curve, nil, refreshSessionId(rp.sid, rp.state.combinedRid, j, k)); err != nil {
+			return nil, err
+		}
+	}
+	if err := p2p.FacProof.Verify(&paillier.FacVerifyParams{
+		PublicKey:    pk,
+		RingPedersen: rp.state.ringPedersen,
+		Pi:           j,
+		Sid:          proofSid,
+	}); err != nil {
+		return nil, err
+	}
+
+	// x_j^i = dec_i(C_j^i) and check g^{x_j^i} = X_j^i
+	plaintext, err := rp.state.sk.Decrypt(p2p.Share)
+	if err != nil {
+		return nil, err
+	}
+	if err = core.In(plaintext, curveOrder(ks.Curve)); err != nil {
+		return nil, err
+	}
+	x, err := ks.Curve.Scalar.SetBigInt(plaintext)
+	if err != nil {
+		return nil, err
+	}
+	if !ks.Curve.ScalarBaseMult(x).Equal(rp.state.peers[j].SharePoints[ks.ID]) {
+		return nil, fmt.Errorf("encrypted share does not match its public value")
+	}
+	return x, nil
+}
+
+// newPaillierKey generates a Paillier key with two distinct safe primes
+func (rp *RefreshParticipant) newPaillierKey() (*paillier.SecretKey, error) {
+	values := make(chan *big.Int, 2)
+	errors := make(chan error, 2)
+
+	var p, q *big.Int
+	for p == q || p.Cmp(q) == 0 {
+		for range []int{1, 2} {
+			go func() {
+				value, err := rp.genSafePrime(paillier.PaillierPrimeBits)
+				values <- value
+				errors <- err
+			}()
+		}
+
+		for _, err := range []error{<-errors, <-errors} {
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		p, q = <-values, <-values
+	}
+	return paillier.NewSecretKey(p, q)
+}
+
+// digest is the value committed to in round 1
+func (msg *RefreshRound2Bcast) digest(sid []byte, id uint32, parties []uint32) []byte {
+	values := [][]byte{
+		sid,
+		idBytes(id),
+		msg.RingPedersen.N.Bytes(),
+		msg.RingPedersen.S.Bytes(),
+		msg.RingPedersen.T.Bytes(),
+	}
+	for _, v := range append(append([]*big.Int{}, msg.PrmProof.A...), msg.PrmProof.Z...) {
+		if v == nil {
+			values = append(values, nil)
+			continue
+		}
+		values = append(values, v.Bytes())
+	}
+	for _, k := range parties {
+		if msg.SharePoints[k] == nil {
+			values = append(values, nil)
+			continue
+		}
+		values = append(values, msg.SharePoints[k].ToAffineCompressed())
+	}
+	return hashValues(append(values, msg.Rid)...)
+}
+
+// refreshProofSid binds the Paillier proofs of a refresh to the session, and to rid once it is known
+func refreshProofSid(sid, rid []byte) []byte {
+	return hashValues([]byte(refreshProtocol), sid, rid)
+}
+
+// refreshSessionId binds the schnorr proof of party id about its share for party k to the session and to rid
+func refreshSessionId(sid, rid []byte, id, k uint32) []byte {
+	return hashValues([]byte(refreshProtocol), sid, rid, idBytes(id), idBytes(k))
+}
@@ -0,0 +1,177 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package cggmp
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/TEENet-io/kryptology/internal"
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
+	"github.com/TEENet-io/kryptology/pkg/paillier"
+)
+
+func TestRefreshWorks(t *testing.T) {
+	curve := curves.K256()
+	parties := []uint32{1, 2, 3}
+	shares := runKeygen(t, curve, parties)
+	refreshed := runRefresh(t, shares, 0)
+
+	secret := curve.Scalar.Zero()
+	for _, id := range parties {
+		share := refreshed[id]
+		require.NoError(t, share.validate())
+		require.True(t, share.hasAuxInfo())
+		require.True(t, share.PublicKey.Equal(shares[id].PublicKey))
+		require.NotEqual(t, 0, share.SecretShare.Cmp(shares[id].SecretShare))
+		require.NotEqual(t, shares[id].Rid, share.Rid)
+		require.GreaterOrEqual(t, share.PaillierKey.N.BitLen(), minModulusBits)
+		for _, j := range parties {
+			require.True(t, share.PublicShares[j].Equal(refreshed[1].PublicShares[j]))
+			require.Equal(t, share.PaillierKeys[j].N, refreshed[j].PaillierKey.N)
+			require.Equal(t, share.RingPedersen[j], refreshed[j].RingPedersen[j])
+		}
+		secret = secret.Add(share.SecretShare)
+	}
+	require.True(t, curve.ScalarBaseMult(secret).Equal(shares[1].PublicKey))
+
+	// A second refresh replaces the auxiliary info
+	again := runRefresh(t, refreshed, 2*len(parties))
+	for _, id := range parties {
+		require.NoError(t, again[id].validate())
+		require.NotEqual(t, refreshed[id].PaillierKey.N, again[id].PaillierKey.N)
+		require.True(t, again[id].PublicKey.Equal(shares[id].PublicKey))
+	}
+}
+
+func TestNewRefreshParticipantErrors(t *testing.T) {
+	shares := runKeygen(t, curves.K256(), []uint32{1, 2})
+	_, err := NewRefreshParticipant(shares[1], nil)
+	require.Error(t, err)
+	_, err = NewRefreshParticipant(nil, []byte("sid"))
+	require.Error(t, err)
+
+	// The secret share must match the public share
+	invalid := *shares[1]
+	invalid.SecretShare = invalid.SecretShare.Add(curves.K256().Scalar.One())
+	_, err = NewRefreshParticipant(&invalid, []byte("sid"))
+	require.Error(t, err)
+
+	rp, err := NewRefreshParticipant(shares[1], []byte("sid"))
+	require.NoError(t, err)
+	_, err = rp.Round2(map[uint32]*RefreshRound1Bcast{})
+	require.Equal(t, internal.ErrInvalidRound, err)
+}
+
+func TestRefreshCulprits(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+	curve := curves.K256()
+	parties := []uint32{1, 2, 3}
+	shares := runKeygen(t, curve, parties)
+
+	// Run the first two rounds honestly; the tampered broadcasts below are checked against them
+	participants := newTestRefreshParticipants(t, shares, 0)
+	r1 := make(map[uint32]*RefreshRound1Bcast, len(participants))
+	for id, rp := range participants {
+		var err error
+		r1[id], err = rp.Round1()
+		require.NoError(t, err)
+	}
+	r2 := make(map[uint32]*RefreshRound2Bcast, len(participants))
+	for id, rp := range participants {
+		var err error
+		r2[id], err = rp.Round2(r1)
+		require.NoError(t, err)
+	}
+	requireCulprit := func(t *testing.T, err error, expected uint32) {
+		culprit, ok := Culprit(err)
+		require.True(t, ok, "expected an abort, got %v", err)
+		require.Equal(t, expected, culprit)
+	}
+
+	t.Run("revealed values do not open the commitment", func(t *testing.T) {
+		tampered := *r2[2]
+		tampered.Rid = make([]byte, ridLength)
+		in := map[uint32]*RefreshRound2Bcast{2: &tampered, 3: r2[3]}
+		_, _, err := participants[1].Round3(in)
+		requireCulprit(t, err, 2)
+	})
+
+	t.Run("shares are not shares of zero", func(t *testing.T) {
+		tampered := *r2[3]
+		tampered.SharePoints = make(map[uint32]curves.Point, len(parties))
+		for k, p := range r2[3].SharePoints {
+			tampered.SharePoints[k] = p
+		}
+		tampered.SharePoints[1] = tampered.SharePoints[1].Double()
+		in := map[uint32]*RefreshRound2Bcast{2: r2[2], 3: &tampered}
+		_, _, err := participants[1].Round3(in)
+		requireCulprit(t, err, 3)
+	})
+
+	t.Run("invalid ring-pedersen parameters", func(t *testing.T) {
+		tampered := *r2[2]
+		tampered.RingPedersen = &paillier.RingPedersenParams{
+			N: r2[2].RingPedersen.N,
+			S: r2[2].RingPedersen.T,
+			T: r2[2].RingPedersen.S,
+		}
+		in := map[uint32]*RefreshRound2Bcast{2: &tampered, 3: r2[3]}
+		_, _, err := participants[1].Round3(in)
+		requireCulprit(t, err, 2)
+	})
+
+	r3 := make(map[uint32]*RefreshRound3Bcast, len(parties))
+	r3p2p := make(map[uint32]map[uint32]*RefreshRound3P2PSend, len(parties))
+	for id, rp := range participants {
+		var err error
+		r3[id], r3p2p[id], err = rp.Round3(r2)
+		require.NoError(t, err)
+	}
+	p2pTo1 := map[uint32]*RefreshRound3P2PSend{2: r3p2p[2][1], 3: r3p2p[3][1]}
+
+	t.Run("encrypted share does not match", func(t *testing.T) {
+		pk := participants[1].state.sk.PublicKey
+		c, _, err := pk.Encrypt(big.NewInt(42))
+		require.NoError(t, err)
+		p2p := map[uint32]*RefreshRound3P2PSend{
+			2: p2pTo1[2],
+			3: {Share: c, FacProof: p2pTo1[3].FacProof},
+		}
+		_, err = participants[1].Output(r3, p2p)
+		requireCulprit(t, err, 3)
+	})
+
+	t.Run("fac proof for another verifier", func(t *testing.T) {
+		p2p := map[uint32]*RefreshRound3P2PSend{
+			2: {Share: p2pTo1[2].Share, FacProof: r3p2p[2][3].FacProof},
+			3: p2pTo1[3],
+		}
+		_, err := participants[1].Output(r3, p2p)
+		requireCulprit(t, err, 2)
+	})
+
+	t.Run("mod proof of another party", func(t *testing.T) {
+		bcast := map[uint32]*RefreshRound3Bcast{
+			2: {ModProof: r3[3].ModProof, SchnorrProofs: r3[2].SchnorrProofs},
+			3: r3[3],
+		}
+		_, err := participants[1].Output(bcast, p2pTo1)
+		requireCulprit(t, err, 2)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		share, err := participants[1].Output(r3, p2pTo1)
+		require.NoError(t, err)
+		require.NoError(t, share.validate())
+		require.Equal(t, 0, share.PaillierKey.N.Cmp(participants[1].state.sk.N))
+	})
+}
@@ -0,0 +1,168 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package cggmp
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/TEENet-io/kryptology/internal"
+	"github.com/TEENet-io/kryptology/pkg/core"
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
+)
+
+const signProtocol = "sign"
+
+// Presignature is the output of a party of presigning. It can sign a single message.
+type Presignature struct {
+	Curve     *curves.Curve
+	ID        uint32
+	Parties   []uint32
+	PublicKey curves.Point
+	// R = g^{1/k}
+	R curves.Point
+	// Rbars map each party to R̄_j = R^{k_j}, and Ss to S_j = R^{χ_j}
+	Rbars map[uint32]curves.Point
+	Ss    map[uint32]curves.Point
+	// the additive shares k_i of k and χ_i of kx
+	k, chi curves.Scalar
+	// state of signing
+	used    bool
+	msgHash []byte
+	sigma   curves.Scalar
+}
+
+// SignatureShare is broadcast to all parties by Presignature.Sign
+type SignatureShare struct {
+	Sigma *big.Int
+}
+
+// Sign computes the signature share σ_i = k_i m + r χ_i of the message digest hash
+// [CGGMP21] fig 9 round 1
+func (p *Presignature) Sign(hash []byte) (*SignatureShare, error) {
+	if p == nil || p.k == nil || p.chi == nil || p.R == nil || len(hash) == 0 {
+		return nil, internal.ErrNilArguments
+	}
+	if p.used {
+		return nil, fmt.Errorf("presignature has already been used")
+	}
+	m, err := hashToScalar(p.Curve, hash)
+	if err != nil {
+		return nil, err
+	}
+	r, err := p.r()
+	if err != nil {
+		return nil, err
+	}
+
+	// The presignature must never sign two messages, even if signing fails afterwards
+	p.used = true
+	p.msgHash = append([]byte{}, hash...)
+	p.sigma = m.Mul(p.k).Add(r.Mul(p.chi))
+	// Erase the shares of the nonce
+	p.k, p.chi = nil, nil
+	return &SignatureShare{Sigma: p.sigma.BigInt()}, nil
+}
+
+// Output checks the signature shares of the other parties and combines them into a signature
+// [CGGMP21] fig 9 output
+func (p *Presignature) Output(in map[uint32]*SignatureShare) (*curves.EcdsaSignature, error) {
+	if p == nil || p.sigma == nil {
+		return nil, internal.ErrInvalidRound
+	}
+	if err := checkInput(p.ID, p.Parties, in); err != nil {
+		return nil, err
+	}
+	r, err := p.r()
+	if err != nil {
+		return nil, err
+	}
+
+	// σ = Σ_j σ_j, checking R^{σ_j} = R̄_j^m S_j^r so that an invalid signature
+	// can be attributed to the party that sent the bad share
+	s := p.sigma
+	for _, j := range p.Parties {
+		if j == p.ID {
+			continue
+		}
+		if in[j] == nil || in[j].Sigma == nil {
+			return nil, newAbortError(signProtocol, 1, j, fmt.Errorf("signature share cannot be nil"))
+		}
+		ok, err := checkSignatureShare(p.Curve, p.msgHash, p.R, r, p.Rbars[j], p.Ss[j], in[j].Sigma)
+		if err != nil {
+			return nil, newAbortError(signProtocol, 1, j, err)
+		}
+		if !ok {
+			return nil, newAbortError(signProtocol, 1, j, fmt.Errorf("invalid signature share"))
+		}
+		if s, err = addBigInts(p.Curve, s, in[j].Sigma); err != nil {
+			return nil, err
+		}
+	}
+
+	sOld := s.BigInt()
+	sNorm := normalizeS(p.Curve, sOld)
+	_, Ry := affine(p.R)
+	v := int(Ry.Bit(0))
+	if sOld.Cmp(sNorm) != 0 {
+		v ^= 1
+	}
+	sig := &curves.EcdsaSignature{V: v, R: r.BigInt(), S: sNorm}
+
+	pk, err := curves.NewEcPoint(p.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	if !curves.VerifyEcdsa(pk, p.msgHash, sig) {
+		return nil, fmt.Errorf("signature is not valid")
+	}
+	return sig, nil
+}
+
+// r returns the x coordinate of R mod q
+func (p *Presignature) r() (curves.Scalar, error) {
+	Rx, _ := affine(p.R)
+	return p.Curve.Scalar.SetBigInt(Rx.Mod(Rx, curveOrder(p.Curve)))
+}
+
+// checkSignatureShare checks R^{σ_j} = R̄_j^m S_j^r
+func checkSignatureShare(curve *curves.Curve, hash []byte, R curves.Point, r curves.Scalar, Rbar, S curves.Point, share *big.Int) (bool, error) {
+	if Rbar == nil || S == nil {
+		return false, internal.ErrNilArguments
+	}
+	if err := core.In(share, curveOrder(curve)); err != nil {
+		return false, err
+	}
+	m, err := hashToScalar(curve, hash)
+	if err != nil {
+		return false, err
+	}
+	sigma, err := curve.Scalar.SetBigInt(share)
+	if err != nil {
+		return false, err
+	}
+	return R.Mul(sigma).Equal(Rbar.Mul(m).Add(S.Mul(r))), nil
+}
+
+// hashToScalar converts a message digest to m ∈ Z_q, rejecting digests outside the field
+func hashToScalar(curve *curves.Curve, hash []byte) (curves.Scalar, error) {
+	m := new(big.Int).SetBytes(hash)
+	if err := core.In(m, curveOrder(curve)); err != nil {
+		return nil, err
+	}
+	return curve.Scalar.SetBigInt(m)
+}
+
+// normalizeS returns the "low S" form of s, so that signatures are not malleable
+// See <https://en.bitcoin.it/wiki/BIP_0062#Low_S_values_in_signatures>
+func normalizeS(curve *curves.Curve, s *big.Int) *big.Int {
+	q := curveOrder(curve)
+	if s.Cmp(new(big.Int).Rsh(q, 1)) == 1 {
+		return new(big.Int).Sub(q, s)
+	}
+	return new(big.Int).Set(s)
+}
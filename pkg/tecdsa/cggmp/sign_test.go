@@ -0,0 +1,111 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package cggmp
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/TEENet-io/kryptology/internal"
+	"github.com/TEENet-io/kryptology/pkg/core"
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
+)
+
+// signAll signs hash with the presignatures of all parties and returns the signature of each
+func signAll(t *testing.T, presignatures map[uint32]*Presignature, hash []byte) map[uint32]*curves.EcdsaSignature {
+	shares := make(map[uint32]*SignatureShare, len(presignatures))
+	for id, p := range presignatures {
+		share, err := p.Sign(hash)
+		require.NoError(t, err)
+		shares[id] = share
+	}
+	signatures := make(map[uint32]*curves.EcdsaSignature, len(presignatures))
+	for id, p := range presignatures {
+		sig, err := p.Output(shares)
+		require.NoError(t, err)
+		signatures[id] = sig
+	}
+	return signatures
+}
+
+func TestSignWorks(t *testing.T) {
+	for _, curve := range []*curves.Curve{curves.K256(), curves.P256()} {
+		shares := presignTestShares(t, curve)
+		pk, err := curves.NewEcPoint(shares[1].PublicKey)
+		require.NoError(t, err)
+
+		for _, msg := range []string{"first message", "second message"} {
+			hash := testHash(msg)
+			signatures := signAll(t, runPresign(t, shares), hash)
+			for _, sig := range signatures {
+				require.Equal(t, signatures[1], sig)
+				require.True(t, curves.VerifyEcdsa(pk, hash, sig))
+				// low s
+				require.True(t, sig.S.Cmp(new(big.Int).Rsh(curveOrder(curve), 1)) <= 0)
+			}
+		}
+	}
+}
+
+func TestSignAfterRefresh(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+	shares := presignTestShares(t, curves.K256())
+	refreshed := runRefresh(t, shares, 6)
+	pk, err := curves.NewEcPoint(shares[1].PublicKey)
+	require.NoError(t, err)
+	hash := testHash("refreshed")
+	for _, sig := range signAll(t, runPresign(t, refreshed), hash) {
+		require.True(t, curves.VerifyEcdsa(pk, hash, sig))
+	}
+}
+
+func TestSignPresignatureUsedOnce(t *testing.T) {
+	presignatures := runPresign(t, presignTestShares(t, curves.K256()))
+	p := presignatures[1]
+	_, err := p.Output(map[uint32]*SignatureShare{})
+	require.Equal(t, internal.ErrInvalidRound, err)
+	_, err = p.Sign(testHash("first"))
+	require.NoError(t, err)
+	_, err = p.Sign(testHash("first"))
+	require.Error(t, err)
+	_, err = p.Sign(testHash("second"))
+	require.Error(t, err)
+}
+
+func TestSignInvalidShare(t *testing.T) {
+	presignatures := runPresign(t, presignTestShares(t, curves.K256()))
+	hash := testHash("message")
+	shares := make(map[uint32]*SignatureShare, len(presignatures))
+	for id, p := range presignatures {
+		share, err := p.Sign(hash)
+		require.NoError(t, err)
+		shares[id] = share
+	}
+
+	invalid := map[uint32]*SignatureShare{
+		2: shares[2],
+		3: {Sigma: new(big.Int).Add(shares[3].Sigma, core.One)},
+	}
+	_, err := presignatures[1].Output(invalid)
+	culprit, ok := Culprit(err)
+	require.True(t, ok)
+	require.Equal(t, uint32(3), culprit)
+
+	invalid = map[uint32]*SignatureShare{2: {}, 3: shares[3]}
+	_, err = presignatures[1].Output(invalid)
+	culprit, ok = Culprit(err)
+	require.True(t, ok)
+	require.Equal(t, uint32(2), culprit)
+
+	// The valid shares still combine
+	_, err = presignatures[1].Output(shares)
+	require.NoError(t, err)
+}
@@ -0,0 +1,328 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// Package backup implements verifiable backups of the secret key shares produced by the DKLs18 DKG.
+//
+// A party encrypts its `SecretKeyShare` to the El-Gamal key of a recovery authority using `verenc/elgamal`. Together
+// with the ciphertext the party publishes its public share `SecretKeyShare·G`, the El-Gamal proof of encryption
+// correctness and a Chaum-Pedersen proof that the ciphertext encrypts exactly the discrete log of the public share.
+// Since the joint public key is `skA·skB·G`, the counterparty can check the public share against its own secret key
+// share and the joint public key without learning anything new.
+//
+// To recover, the authority decrypts the share and the party rebuilds its DKG output. The seed OT result cannot be
+// recovered from the backup; it is recreated by running the refresh protocol with the counterparty, which re-randomizes
+// both shares and performs a fresh seed OT.
+package backup
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"git.sr.ht/~sircmpwn/go-bare"
+	"github.com/gtank/merlin"
+	"github.com/pkg/errors"
+
+	"github.com/TEENet-io/kryptology/internal"
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
+	"github.com/TEENet-io/kryptology/pkg/ot/base/simplest"
+	"github.com/TEENet-io/kryptology/pkg/tecdsa/dkls/v1/dkg"
+	"github.com/TEENet-io/kryptology/pkg/verenc/elgamal"
+)
+
+const (
+	aliceRole = "alice"
+	bobRole   = "bob"
+)
+
+// Backup is a verifiable encryption of one party's secret key share to a recovery authority.
+type Backup struct {
+	// PublicShare is SecretKeyShare·G of the party that created the backup.
+	PublicShare curves.Point
+
+	// CipherText is the El-Gamal encryption of the secret key share to the authority.
+	CipherText *elgamal.CipherText
+
+	// EncryptionProof proves that CipherText is a well-formed encryption under the authority's key.
+	EncryptionProof *elgamal.ProofVerEnc
+
+	// LinkProof proves that CipherText encrypts the discrete log of PublicShare.
+	LinkProof *LinkProof
+
+	// ChainCode is the public BIP-32 chain code of the joint key, restored on recovery.
+	ChainCode [simplest.DigestSize]byte
+}
+
+// LinkProof is a Chaum-Pedersen proof that the El-Gamal randomness b satisfies C1 = b·G and C2 - PublicShare = b·Q,
+// where Q is the authority's encryption key.
+type LinkProof struct {
+	C curves.Scalar
+	S curves.Scalar
+}
+
+type backupMarshal struct {
+	Curve           string `bare:"curve"`
+	PublicShare     []byte `bare:"publicShare"`
+	CipherText      []byte `bare:"cipherText"`
+	EncryptionProof []byte `bare:"encryptionProof"`
+	LinkC           []byte `bare:"linkC"`
+	LinkS           []byte `bare:"linkS"`
+	ChainCode       []byte `bare:"chainCode"`
+}
+
+// NewAliceBackup verifiably encrypts Alice's secret key share to the authority's encryption key.
+// The sessionId binds the backup to a context chosen by the caller, and must be supplied again on verification.
+func NewAliceBackup(curve *curves.Curve, authority *elgamal.EncryptionKey, output *dkg.AliceOutput, sessionId []byte) (*Backup, error) {
+	if output == nil {
+		return nil, internal.ErrNilArguments
+	}
+	return newBackup(curve, authority, aliceRole, output.PublicKey, output.SecretKeyShare, output.ChainCode, sessionId)
+}
+
+// NewBobBackup verifiably encrypts Bob's secret key share to the authority's encryption key.
+// The sessionId binds the backup to a context chosen by the caller, and must be supplied again on verification.
+func NewBobBackup(curve *curves.Curve, authority *elgamal.EncryptionKey, output *dkg.BobOutput, sessionId []byte) (*Backup, error) {
+	if output == nil {
+		return nil, internal.ErrNilArguments
+	}
+	return newBackup(curve, authority, bobRole, output.PublicKey, output.SecretKeyShare, output.ChainCode, sessionId)
+}
+
+// VerifyAliceBackup is run by Bob to check that Alice's backup encrypts her share of the joint public key.
+func VerifyAliceBackup(curve *curves.Curve, authority *elgamal.EncryptionKey, backup *Backup, bobOutput *dkg.BobOutput, sessionId []byte) error {
+	if bobOutput == nil {
+		return internal.ErrNilArguments
+	}
+	return verifyCounterparty(curve, authority, aliceRole, backup, bobOutput.PublicKey, bobOutput.SecretKeyShare, bobOutput.ChainCode, sessionId)
+}
+
+// VerifyBobBackup is run by Alice to check that Bob's backup encrypts his share of the joint public key.
+func VerifyBobBackup(curve *curves.Curve, authority *elgamal.EncryptionKey, backup *Backup, aliceOutput *dkg.AliceOutput, sessionId []byte) error {
+	if aliceOutput == nil {
+		return internal.ErrNilArguments
+	}
+	return verifyCounterparty(curve, authority, bobRole, backup, aliceOutput.PublicKey, aliceOutput.SecretKeyShare, aliceOutput.ChainCode, sessionId)
+}
+
+// RecoverAlice decrypts Alice's backup with the authority's decryption key and rebuilds her DKG output.
+// The returned output has no seed OT result. It must be passed to `refresh.NewAlice` and the refresh protocol run
+// with Bob before the key can be used for signing.
+func RecoverAlice(curve *curves.Curve, authority *elgamal.DecryptionKey, backup *Backup, publicKey curves.Point, sessionId []byte) (*dkg.AliceOutput, error) {
+	secretKeyShare, err := recoverShare(curve, authority, aliceRole, backup, publicKey, sessionId)
+	if err != nil {
+		return nil, err
+	}
+	return &dkg.AliceOutput{
+		PublicKey:      publicKey,
+		SecretKeyShare: secretKeyShare,
+		ChainCode:      backup.ChainCode,
+	}, nil
+}
+
+// RecoverBob decrypts Bob's backup with the authority's decryption key and rebuilds his DKG output.
+// The returned output has no seed OT result. It must be passed to `refresh.NewBob` and the refresh protocol run
+// with Alice before the key can be used for signing.
+func RecoverBob(curve *curves.Curve, authority *elgamal.DecryptionKey, backup *Backup, publicKey curves.Point, sessionId []byte) (*dkg.BobOutput, error) {
+	secretKeyShare, err := recoverShare(curve, authority, bobRole, backup, publicKey, sessionId)
+	if err != nil {
+		return nil, err
+	}
+	return &dkg.BobOutput{
+		PublicKey:      publicKey,
+		SecretKeyShare: secretKeyShare,
+		ChainCode:      backup.ChainCode,
+	}, nil
+}
+
+// verify checks the encryption and link proofs of the backup. It does not check that the public share belongs to
+// the joint public key; use VerifyAliceBackup or VerifyBobBackup for that.
+func (b *Backup) verify(curve *curves.Curve, authority *elgamal.EncryptionKey, role string, publicKey curves.Point, sessionId []byte) error {
+	if b == nil || b.PublicShare == nil || b.CipherText == nil || b.EncryptionProof == nil || b.LinkProof == nil {
+		return internal.ErrNilArguments
+	}
+	if b.LinkProof.C == nil || b.LinkProof.S == nil {
+		return internal.ErrNilArguments
+	}
+	if curve == nil || authority == nil || authority.Value == nil || publicKey == nil {
+		return internal.ErrNilArguments
+	}
+	if b.PublicShare.IsIdentity() {
+		return fmt.Errorf("public share is the identity")
+	}
+	if !b.CipherText.MsgIsHashed {
+		return fmt.Errorf("backup ciphertext must encrypt a scalar")
+	}
+	nonce := proofNonce(role, publicKey, b.PublicShare, b.ChainCode, sessionId)
+	if err := authority.VerifyEncryptProof(nonce, b.CipherText, b.EncryptionProof); err != nil {
+		return errors.Wrap(err, "verifying backup encryption proof")
+	}
+
+	// A1 = s·G - c·C1, A2 = s·Q - c·(C2 - PublicShare)
+	generator := curve.NewGeneratorPoint()
+	a1 := generator.Mul(b.LinkProof.S).Sub(b.CipherText.C1.Mul(b.LinkProof.C))
+	a2 := authority.Value.Mul(b.LinkProof.S).Sub(b.CipherText.C2.Sub(b.PublicShare).Mul(b.LinkProof.C))
+	c := linkChallenge(curve, nonce, authority.Value, b.CipherText, a1, a2)
+	if c.Cmp(b.LinkProof.C) != 0 {
+		return fmt.Errorf("backup link proof verification failed")
+	}
+	return nil
+}
+
+// MarshalBinary serializes a backup to bytes.
+func (b Backup) MarshalBinary() ([]byte, error) {
+	if b.PublicShare == nil || b.CipherText == nil || b.EncryptionProof == nil || b.LinkProof == nil {
+		return nil, internal.ErrNilArguments
+	}
+	cipherText, err := b.CipherText.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	proof, err := b.EncryptionProof.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	tv := new(backupMarshal)
+	tv.Curve = b.PublicShare.CurveName()
+	tv.PublicShare = b.PublicShare.ToAffineCompressed()
+	tv.CipherText = cipherText
+	tv.EncryptionProof = proof
+	tv.LinkC = b.LinkProof.C.Bytes()
+	tv.LinkS = b.LinkProof.S.Bytes()
+	tv.ChainCode = b.ChainCode[:]
+	return bare.Marshal(tv)
+}
+
+// UnmarshalBinary deserializes a backup from bytes.
+func (b *Backup) UnmarshalBinary(data []byte) error {
+	tv := new(backupMarshal)
+	if err := bare.Unmarshal(data, tv); err != nil {
+		return err
+	}
+	curve := curves.GetCurveByName(tv.Curve)
+	if curve == nil {
+		return fmt.Errorf("unknown curve")
+	}
+	publicShare, err := curve.Point.FromAffineCompressed(tv.PublicShare)
+	if err != nil {
+		return err
+	}
+	cipherText := new(elgamal.CipherText)
+	if err = cipherText.UnmarshalBinary(tv.CipherText); err != nil {
+		return err
+	}
+	proof := new(elgamal.ProofVerEnc)
+	if err = proof.UnmarshalBinary(tv.EncryptionProof); err != nil {
+		return err
+	}
+	c, err := curve.Scalar.SetBytes(tv.LinkC)
+	if err != nil {
+		return err
+	}
+	s, err := curve.Scalar.SetBytes(tv.LinkS)
+	if err != nil {
+		return err
+	}
+	b.PublicShare = publicShare
+	b.CipherText = cipherText
+	b.EncryptionProof = proof
+	b.LinkProof = &LinkProof{C: c, S: s}
+	copy(b.ChainCode[:], tv.ChainCode)
+	return nil
+}
+
+func newBackup(curve *curves.Curve, authority *elgamal.EncryptionKey, role string, publicKey curves.Point, secretKeyShare curves.Scalar, chainCode [simplest.DigestSize]byte, sessionId []byte) (*Backup, error) {
+	if curve == nil || authority == nil || authority.Value == nil || publicKey == nil || secretKeyShare == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if secretKeyShare.IsZero() {
+		return nil, internal.ErrZeroValue
+	}
+	publicShare := curve.ScalarBaseMult(secretKeyShare)
+	nonce := proofNonce(role, publicKey, publicShare, chainCode, sessionId)
+
+	blinding := curve.Scalar.Random(rand.Reader)
+	for blinding.IsZero() {
+		blinding = curve.Scalar.Random(rand.Reader)
+	}
+	cipherText, proof, err := authority.VerifiableEncrypt(secretKeyShare.Bytes(), &elgamal.EncryptParams{
+		MessageIsHashed: true,
+		Blinding:        blinding,
+		GenProof:        true,
+		ProofNonce:      nonce,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "encrypting secret key share to authority")
+	}
+
+	// Chaum-Pedersen proof that log_G(C1) = log_Q(C2 - PublicShare) = blinding
+	k := curve.Scalar.Random(rand.Reader)
+	a1 := curve.ScalarBaseMult(k)
+	a2 := authority.Value.Mul(k)
+	c := linkChallenge(curve, nonce, authority.Value, cipherText, a1, a2)
+	s := k.Add(c.Mul(blinding))
+
+	return &Backup{
+		PublicShare:     publicShare,
+		CipherText:      cipherText,
+		EncryptionProof: proof,
+		LinkProof:       &LinkProof{C: c, S: s},
+		ChainCode:       chainCode,
+	}, nil
+}
+
+func verifyCounterparty(curve *curves.Curve, authority *elgamal.EncryptionKey, role string, backup *Backup, publicKey curves.Point, secretKeyShare curves.Scalar, chainCode [simplest.DigestSize]byte, sessionId []byte) error {
+	if backup == nil || backup.PublicShare == nil || publicKey == nil || secretKeyShare == nil {
+		return internal.ErrNilArguments
+	}
+	if err := backup.verify(curve, authority, role, publicKey, sessionId); err != nil {
+		return err
+	}
+	// The joint public key is skA·skB·G, so the backed up public share times our own share must be the public key.
+	if !backup.PublicShare.Mul(secretKeyShare).Equal(publicKey) {
+		return fmt.Errorf("backup public share does not match the joint public key")
+	}
+	if backup.ChainCode != chainCode {
+		return fmt.Errorf("backup chain code does not match")
+	}
+	return nil
+}
+
+func recoverShare(curve *curves.Curve, authority *elgamal.DecryptionKey, role string, backup *Backup, publicKey curves.Point, sessionId []byte) (curves.Scalar, error) {
+	if authority == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if err := backup.verify(curve, authority.EncryptionKey(), role, publicKey, sessionId); err != nil {
+		return nil, err
+	}
+	_, secretKeyShare, err := authority.VerifiableDecrypt(backup.CipherText)
+	if err != nil {
+		return nil, errors.Wrap(err, "decrypting backup")
+	}
+	if !curve.ScalarBaseMult(secretKeyShare).Equal(backup.PublicShare) {
+		return nil, fmt.Errorf("decrypted secret key share does not match the public share")
+	}
+	return secretKeyShare, nil
+}
+
+// proofNonce binds both proofs to the party, the joint key, the public share and the caller's session id.
+func proofNonce(role string, publicKey, publicShare curves.Point, chainCode [simplest.DigestSize]byte, sessionId []byte) []byte {
+	transcript := merlin.NewTranscript("Coinbase_DKLs_Backup")
+	transcript.AppendMessage([]byte("role"), []byte(role))
+	transcript.AppendMessage([]byte("session id"), sessionId)
+	transcript.AppendMessage([]byte("public key"), publicKey.ToAffineCompressed())
+	transcript.AppendMessage([]byte("public share"), publicShare.ToAffineCompressed())
+	transcript.AppendMessage([]byte("chain code"), chainCode[:])
+	return transcript.ExtractBytes([]byte("proof nonce"), 32)
+}
+
+func linkChallenge(curve *curves.Curve, nonce []byte, authority curves.Point, cipherText *elgamal.CipherText, a1, a2 curves.Point) curves.Scalar {
+	challengeBytes := append([]byte{}, nonce...)
+	challengeBytes = append(challengeBytes, authority.ToAffineCompressed()...)
+	challengeBytes = append(challengeBytes, cipherText.C1.ToAffineCompressed()...)
+	challengeBytes = append(challengeBytes, cipherText.C2.ToAffineCompressed()...)
+	challengeBytes = append(challengeBytes, a1.ToAffineCompressed()...)
+	challengeBytes = append(challengeBytes, a2.ToAffineCompressed()...)
+	return curve.Scalar.Hash(challengeBytes)
+}
@@ -0,0 +1,190 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package backup_test
+
+import (
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/TEENet-io/kryptology/internal"
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
+	"github.com/TEENet-io/kryptology/pkg/tecdsa/dkls/v1/backup"
+	"github.com/TEENet-io/kryptology/pkg/tecdsa/dkls/v1/dkg"
+	"github.com/TEENet-io/kryptology/pkg/tecdsa/dkls/v1/refresh"
+	"github.com/TEENet-io/kryptology/pkg/tecdsa/dkls/v1/sign"
+	"github.com/TEENet-io/kryptology/pkg/verenc/elgamal"
+)
+
+func performDKG(t *testing.T, curve *curves.Curve) (*dkg.AliceOutput, *dkg.BobOutput) {
+	t.Helper()
+
+	alice := dkg.NewAlice(curve)
+	bob := dkg.NewBob(curve)
+
+	seed, err := bob.Round1GenerateRandomSeed()
+	require.NoError(t, err)
+	round3Output, err := alice.Round2CommitToProof(seed)
+	require.NoError(t, err)
+	proof, err := bob.Round3SchnorrProve(round3Output)
+	require.NoError(t, err)
+	proof, err = alice.Round4VerifyAndReveal(proof)
+	require.NoError(t, err)
+	proof, err = bob.Round5DecommitmentAndStartOt(proof)
+	require.NoError(t, err)
+	compressedReceiversMaskedChoice, err := alice.Round6DkgRound2Ot(proof)
+	require.NoError(t, err)
+	challenge, err := bob.Round7DkgRound3Ot(compressedReceiversMaskedChoice)
+	require.NoError(t, err)
+	challengeResponse, err := alice.Round8DkgRound4Ot(challenge)
+	require.NoError(t, err)
+	challengeOpenings, err := bob.Round9DkgRound5Ot(challengeResponse)
+	require.NoError(t, err)
+	err = alice.Round10DkgRound6Ot(challengeOpenings)
+	require.NoError(t, err)
+
+	return alice.Output(), bob.Output()
+}
+
+func performRefresh(t *testing.T, curve *curves.Curve, aliceOutput *dkg.AliceOutput, bobOutput *dkg.BobOutput) (*dkg.AliceOutput, *dkg.BobOutput) {
+	t.Helper()
+	alice := refresh.NewAlice(curve, aliceOutput)
+	bob := refresh.NewBob(curve, bobOutput)
+
+	round1Output := alice.Round1RefreshGenerateSeed()
+	round2Output, err := bob.Round2RefreshProduceSeedAndMultiplyAndStartOT(round1Output)
+	require.NoError(t, err)
+	round3Output, err := alice.Round3RefreshMultiplyRound2Ot(round2Output)
+	require.NoError(t, err)
+	round4Output, err := bob.Round4RefreshRound3Ot(round3Output)
+	require.NoError(t, err)
+	round5Output, err := alice.Round5RefreshRound4Ot(round4Output)
+	require.NoError(t, err)
+	round6Output, err := bob.Round6RefreshRound5Ot(round5Output)
+	require.NoError(t, err)
+	err = alice.Round7DkgRound6Ot(round6Output)
+	require.NoError(t, err)
+	return alice.Output(), bob.Output()
+}
+
+func performSign(t *testing.T, curve *curves.Curve, aliceOutput *dkg.AliceOutput, bobOutput *dkg.BobOutput) {
+	t.Helper()
+	alice := sign.NewAlice(curve, sha3.New256(), aliceOutput)
+	bob := sign.NewBob(curve, sha3.New256(), bobOutput)
+
+	message := []byte("A message.")
+	seed, err := alice.Round1GenerateRandomSeed()
+	require.NoError(t, err)
+	round3Output, err := bob.Round2Initialize(seed)
+	require.NoError(t, err)
+	round4Output, err := alice.Round3Sign(message, round3Output)
+	require.NoError(t, err)
+	err = bob.Round4Final(message, round4Output)
+	require.NoError(t, err)
+}
+
+func TestBackupAndRecover(t *testing.T) {
+	t.Parallel()
+	curveInstances := []*curves.Curve{
+		curves.K256(),
+		curves.P256(),
+	}
+	for _, curve := range curveInstances {
+		boundCurve := curve
+		t.Run(fmt.Sprintf("testing backup for curve %s", boundCurve.Name), func(tt *testing.T) {
+			tt.Parallel()
+			aliceOutput, bobOutput := performDKG(tt, boundCurve)
+			ek, dk, err := elgamal.NewKeys(boundCurve)
+			require.NoError(tt, err)
+			sessionId := []byte("backup session")
+
+			aliceBackup, err := backup.NewAliceBackup(boundCurve, ek, aliceOutput, sessionId)
+			require.NoError(tt, err)
+			bobBackup, err := backup.NewBobBackup(boundCurve, ek, bobOutput, sessionId)
+			require.NoError(tt, err)
+
+			require.NoError(tt, backup.VerifyAliceBackup(boundCurve, ek, aliceBackup, bobOutput, sessionId))
+			require.NoError(tt, backup.VerifyBobBackup(boundCurve, ek, bobBackup, aliceOutput, sessionId))
+
+			// Alice loses her state; the authority recovers her share and she refreshes with Bob.
+			recovered, err := backup.RecoverAlice(boundCurve, dk, aliceBackup, bobOutput.PublicKey, sessionId)
+			require.NoError(tt, err)
+			require.Equal(tt, 0, recovered.SecretKeyShare.Cmp(aliceOutput.SecretKeyShare))
+			require.Nil(tt, recovered.SeedOtResult)
+			require.Equal(tt, aliceOutput.ChainCode, recovered.ChainCode)
+
+			aliceRefreshed, bobRefreshed := performRefresh(tt, boundCurve, recovered, bobOutput)
+			require.True(tt, aliceRefreshed.PublicKey.Equal(bobOutput.PublicKey))
+			require.NotNil(tt, aliceRefreshed.SeedOtResult)
+			performSign(tt, boundCurve, aliceRefreshed, bobRefreshed)
+
+			recoveredBob, err := backup.RecoverBob(boundCurve, dk, bobBackup, aliceOutput.PublicKey, sessionId)
+			require.NoError(tt, err)
+			require.Equal(tt, 0, recoveredBob.SecretKeyShare.Cmp(bobOutput.SecretKeyShare))
+		})
+	}
+}
+
+func TestBackupRejectsWrongContext(t *testing.T) {
+	curve := curves.K256()
+	aliceOutput, bobOutput := performDKG(t, curve)
+	ek, _, err := elgamal.NewKeys(curve)
+	require.NoError(t, err)
+	sessionId := []byte("backup session")
+
+	aliceBackup, err := backup.NewAliceBackup(curve, ek, aliceOutput, sessionId)
+	require.NoError(t, err)
+
+	// wrong session id
+	require.Error(t, backup.VerifyAliceBackup(curve, ek, aliceBackup, bobOutput, []byte("other session")))
+	// alice's backup presented as bob's
+	require.Error(t, backup.VerifyBobBackup(curve, ek, aliceBackup, aliceOutput, sessionId))
+	// wrong authority
+	otherEk, _, err := elgamal.NewKeys(curve)
+	require.NoError(t, err)
+	require.Error(t, backup.VerifyAliceBackup(curve, otherEk, aliceBackup, bobOutput, sessionId))
+
+	// a backup of a share unrelated to the joint public key
+	unrelated, err := backup.NewAliceBackup(curve, ek, &dkg.AliceOutput{
+		PublicKey:      aliceOutput.PublicKey,
+		SecretKeyShare: curve.Scalar.Random(rand.Reader),
+	}, sessionId)
+	require.NoError(t, err)
+	require.Error(t, backup.VerifyAliceBackup(curve, ek, unrelated, bobOutput, sessionId))
+
+	// a ciphertext of another share paired with alice's public share
+	forged := *unrelated
+	forged.PublicShare = aliceBackup.PublicShare
+	require.Error(t, backup.VerifyAliceBackup(curve, ek, &forged, bobOutput, sessionId))
+
+	// no curve
+	require.ErrorIs(t, backup.VerifyAliceBackup(nil, ek, aliceBackup, bobOutput, sessionId), internal.ErrNilArguments)
+	require.ErrorIs(t, backup.VerifyBobBackup(nil, ek, aliceBackup, aliceOutput, sessionId), internal.ErrNilArguments)
+}
+
+func TestBackupMarshalBinary(t *testing.T) {
+	curve := curves.P256()
+	aliceOutput, bobOutput := performDKG(t, curve)
+	ek, dk, err := elgamal.NewKeys(curve)
+	require.NoError(t, err)
+	sessionId := []byte("backup session")
+
+	aliceBackup, err := backup.NewAliceBackup(curve, ek, aliceOutput, sessionId)
+	require.NoError(t, err)
+	data, err := aliceBackup.MarshalBinary()
+	require.NoError(t, err)
+
+	decoded := new(backup.Backup)
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	require.NoError(t, backup.VerifyAliceBackup(curve, ek, decoded, bobOutput, sessionId))
+	recovered, err := backup.RecoverAlice(curve, dk, decoded, aliceOutput.PublicKey, sessionId)
+	require.NoError(t, err)
+	require.Equal(t, 0, recovered.SecretKeyShare.Cmp(aliceOutput.SecretKeyShare))
+}
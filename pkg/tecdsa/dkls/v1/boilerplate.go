@@ -14,37 +14,37 @@ import (
 
 // AliceDkg DKLS DKG implementation that satisfies the protocol iterator interface.
 type AliceDkg struct {
-	protoStepper
+	protocol.Stepper
 	*dkg.Alice
 }
 
 // BobDkg DKLS DKG implementation that satisfies the protocol iterator interface.
 type BobDkg struct {
-	protoStepper
+	protocol.Stepper
 	*dkg.Bob
 }
 
 // AliceSign DKLS sign implementation that satisfies the protocol iterator interface.
 type AliceSign struct {
-	protoStepper
+	protocol.Stepper
 	*sign.Alice
 }
 
 // BobSign DKLS sign implementation that satisfies the protocol iterator interface.
 type BobSign struct {
-	protoStepper
+	protocol.Stepper
 	*sign.Bob
 }
 
 // AliceRefresh DKLS refresh implementation that satisfies the protocol iterator interface.
 type AliceRefresh struct {
-	protoStepper
+	protocol.Stepper
 	*refresh.Alice
 }
 
 // BobRefresh DKLS refresh implementation that satisfies the protocol iterator interface.
 type BobRefresh struct {
-	protoStepper
+	protocol.Stepper
 	*refresh.Bob
 }
 
@@ -61,7 +61,7 @@ var (
 // NewAliceDkg creates a new protocol that can compute a DKG as Alice
 func NewAliceDkg(curve *curves.Curve, version uint) *AliceDkg {
 	a := &AliceDkg{Alice: dkg.NewAlice(curve)}
-	a.steps = []func(*protocol.Message) (*protocol.Message, error){
+	a.Stepper = protocol.NewStepper([]func(*protocol.Message) (*protocol.Message, error){
 		func(input *protocol.Message) (*protocol.Message, error) {
 			bobSeed, err := decodeDkgRound2Input(input)
 			if err != nil {
@@ -116,14 +116,14 @@ func NewAliceDkg(curve *curves.Curve, version uint) *AliceDkg {
 			}
 			return nil, nil
 		},
-	}
+	})
 	return a
 }
 
 // Result Returns an encoded version of Alice as sequence of bytes that can be used to initialize an AliceSign protocol.
 func (a *AliceDkg) Result(version uint) (*protocol.Message, error) {
 	// Sanity check
-	if !a.complete() {
+	if !a.Complete() {
 		return nil, nil
 	}
 	if a.Alice == nil {
@@ -137,7 +137,7 @@ func (a *AliceDkg) Result(version uint) (*protocol.Message, error) {
 // NewBobDkg Creates a new protocol that can compute a DKG as Bob.
 func NewBobDkg(curve *curves.Curve, version uint) *BobDkg {
 	b := &BobDkg{Bob: dkg.NewBob(curve)}
-	b.steps = []func(message *protocol.Message) (*protocol.Message, error){
+	b.Stepper = protocol.NewStepper([]func(message *protocol.Message) (*protocol.Message, error){
 		func(*protocol.Message) (*protocol.Message, error) {
 			commitment, err := b.Round1GenerateRandomSeed()
 			if err != nil {
@@ -189,14 +189,14 @@ func NewBobDkg(curve *curves.Curve, version uint) *BobDkg {
 			}
 			return encodeDkgRound9Output(opening, version)
 		},
-	}
+	})
 	return b
 }
 
 // Result returns an encoded version of Bob as sequence of bytes that can be used to  initialize an BobSign protocol.
 func (b *BobDkg) Result(version uint) (*protocol.Message, error) {
 	// Sanity check
-	if !b.complete() {
+	if !b.Complete() {
 		return nil, nil
 	}
 	if b.Bob == nil {
@@ -214,8 +214,12 @@ func NewAliceSign(curve *curves.Curve, hash hash.Hash, message []byte, dkgResult
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	a := &AliceSign{Alice: sign.NewAlice(curve, hash, dkgResult)}
-	a.steps = []func(message *protocol.Message) (*protocol.Message, error){
+	return newAliceSign(sign.NewAlice(curve, hash, dkgResult), message, version), nil
+}
+
+func newAliceSign(alice *sign.Alice, message []byte, version uint) *AliceSign {
+	a := &AliceSign{Alice: alice}
+	a.Stepper = protocol.NewStepper([]func(message *protocol.Message) (*protocol.Message, error){
 		func(*protocol.Message) (*protocol.Message, error) {
 			aliceCommitment, err := a.Round1GenerateRandomSeed()
 			if err != nil {
@@ -234,8 +238,8 @@ func NewAliceSign(curve *curves.Curve, hash hash.Hash, message []byte, dkgResult
 			}
 			return encodeSignRound3Output(round3Output, version)
 		},
-	}
-	return a, nil
+	})
+	return a
 }
 
 // NewBobSign creates a new protocol that can compute a signature as Bob.
@@ -245,8 +249,12 @@ func NewBobSign(curve *curves.Curve, hash hash.Hash, message []byte, dkgResultMe
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	b := &BobSign{Bob: sign.NewBob(curve, hash, dkgResult)}
-	b.steps = []func(message *protocol.Message) (*protocol.Message, error){
+	return newBobSign(sign.NewBob(curve, hash, dkgResult), message, version), nil
+}
+
+func newBobSign(bob *sign.Bob, message []byte, version uint) *BobSign {
+	b := &BobSign{Bob: bob}
+	b.Stepper = protocol.NewStepper([]func(message *protocol.Message) (*protocol.Message, error){
 		func(input *protocol.Message) (*protocol.Message, error) {
 			commitment, err := decodeSignRound2Input(input)
 			if err != nil {
@@ -269,8 +277,8 @@ func NewBobSign(curve *curves.Curve, hash hash.Hash, message []byte, dkgResultMe
 			}
 			return nil, nil
 		},
-	}
-	return b, nil
+	})
+	return b
 }
 
 // Result always returns an error.
@@ -282,7 +290,7 @@ func (a *AliceSign) Result(_ uint) (*protocol.Message, error) {
 // Result returns the signature that Bob computed as a *core.EcdsaSignature if the signing protocol completed successfully.
 func (b *BobSign) Result(version uint) (*protocol.Message, error) {
 	// We can't produce a signature until the protocol completes
-	if !b.complete() {
+	if !b.Complete() {
 		return nil, nil
 	}
 	if b.Bob == nil {
@@ -298,9 +306,12 @@ func NewAliceRefresh(curve *curves.Curve, dkgResultMessage *protocol.Message, ve
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
+	return newAliceRefresh(curve, dkgResult, version), nil
+}
 
+func newAliceRefresh(curve *curves.Curve, dkgResult *dkg.AliceOutput, version uint) *AliceRefresh {
 	a := &AliceRefresh{Alice: refresh.NewAlice(curve, dkgResult)}
-	a.steps = []func(*protocol.Message) (*protocol.Message, error){
+	a.Stepper = protocol.NewStepper([]func(*protocol.Message) (*protocol.Message, error){
 		func(input *protocol.Message) (*protocol.Message, error) {
 			aliceSeed := a.Round1RefreshGenerateSeed()
 			return encodeRefreshRound1Output(aliceSeed, version)
@@ -337,14 +348,14 @@ func NewAliceRefresh(curve *curves.Curve, dkgResultMessage *protocol.Message, ve
 			}
 			return nil, nil
 		},
-	}
-	return a, nil
+	})
+	return a
 }
 
 // Result Returns an encoded version of Alice as sequence of bytes that can be used to initialize an AliceSign protocol.
 func (a *AliceRefresh) Result(version uint) (*protocol.Message, error) {
 	// Sanity check
-	if !a.complete() {
+	if !a.Complete() {
 		return nil, nil
 	}
 	if a.Alice == nil {
@@ -361,9 +372,12 @@ func NewBobRefresh(curve *curves.Curve, dkgResultMessage *protocol.Message, vers
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
+	return newBobRefresh(curve, dkgResult, version), nil
+}
 
+func newBobRefresh(curve *curves.Curve, dkgResult *dkg.BobOutput, version uint) *BobRefresh {
 	b := &BobRefresh{Bob: refresh.NewBob(curve, dkgResult)}
-	b.steps = []func(message *protocol.Message) (*protocol.Message, error){
+	b.Stepper = protocol.NewStepper([]func(message *protocol.Message) (*protocol.Message, error){
 		func(input *protocol.Message) (*protocol.Message, error) {
 			round2Input, err := decodeRefreshRound2Input(input)
 			if err != nil {
@@ -397,14 +411,14 @@ func NewBobRefresh(curve *curves.Curve, dkgResultMessage *protocol.Message, vers
 			}
 			return encodeRefreshRound6Output(round6Output, version)
 		},
-	}
-	return b, nil
+	})
+	return b
 }
 
 // Result returns an encoded version of Bob as sequence of bytes that can be used to  initialize an BobSign protocol.
 func (b *BobRefresh) Result(version uint) (*protocol.Message, error) {
 	// Sanity check
-	if !b.complete() {
+	if !b.Complete() {
 		return nil, nil
 	}
 	if b.Bob == nil {
@@ -0,0 +1,308 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package v1
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
+	"github.com/TEENet-io/kryptology/pkg/core/protocol"
+	"github.com/TEENet-io/kryptology/pkg/ot/base/simplest"
+	"github.com/TEENet-io/kryptology/pkg/ot/extension/kos"
+	"github.com/TEENet-io/kryptology/pkg/tecdsa/dkls/v1/dkg"
+	"github.com/TEENet-io/kryptology/pkg/tecdsa/dkls/v1/refresh"
+	"github.com/TEENet-io/kryptology/pkg/tecdsa/dkls/v1/sign"
+	"github.com/TEENet-io/kryptology/pkg/zkp/schnorr"
+)
+
+// The canonical encoding used from protocol.Version2 on is specified in ENCODING.md. It is the codec of the curves
+// package without its header, since the message version is carried by protocol.Message: every length and count is a
+// 4-byte big-endian integer, variable length values are prefixed with their length, fixed size arrays are written as
+// is, scalars are fixed width big-endian integers and points are compressed SEC1. Payloads that carry curve elements
+// start with the name of the curve. Decoders reject trailing bytes, so every value has exactly one encoding.
+
+// encodePayload serializes `value` with gob for Version1, or with `write` in the canonical encoding for Version2.
+func encodePayload(version uint, value interface{}, write func(w *curves.Encoder)) ([]byte, error) {
+	switch version {
+	case protocol.Version1:
+		curves.RegisterGobTypes()
+		buf := bytes.NewBuffer([]byte{})
+		enc := gob.NewEncoder(buf)
+		if err := enc.Encode(value); err != nil {
+			return nil, errors.WithStack(err)
+		}
+		return buf.Bytes(), nil
+	case protocol.Version2:
+		w := new(curves.Encoder)
+		write(w)
+		return w.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unsupported version %d", version)
+	}
+}
+
+// decodePayload deserializes the payload of `m` into `value` with gob for Version0 and Version1 messages, or with
+// `read` for Version2 messages.
+func decodePayload(m *protocol.Message, value interface{}, read func(r *curves.Decoder)) error {
+	if m == nil {
+		return errors.New("nil message")
+	}
+	switch m.Version {
+	case protocol.Version0, protocol.Version1:
+		curves.RegisterGobTypes()
+		dec := gob.NewDecoder(bytes.NewBuffer(m.Payloads[payloadKey]))
+		if err := dec.Decode(value); err != nil {
+			return errors.WithStack(err)
+		}
+		return nil
+	case protocol.Version2:
+		r := curves.NewDecoder(m.Payloads[payloadKey])
+		read(r)
+		return r.Finish()
+	default:
+		return fmt.Errorf("unsupported version %d", m.Version)
+	}
+}
+
+// Encoders and decoders of the individual payloads follow, in the order in which ENCODING.md lists them.
+
+func writeDigests(w *curves.Encoder, digests [][simplest.DigestSize]byte) {
+	w.WriteUint32(uint32(len(digests)))
+	for i := range digests {
+		w.WriteFixed(digests[i][:])
+	}
+}
+
+func readDigests(r *curves.Decoder) [][simplest.DigestSize]byte {
+	out := make([][simplest.DigestSize]byte, r.ReadCount(simplest.DigestSize))
+	for i := range out {
+		r.ReadFixed(out[i][:])
+	}
+	return out
+}
+
+func writeDigestPairs(w *curves.Encoder, pairs [][2][simplest.DigestSize]byte) {
+	w.WriteUint32(uint32(len(pairs)))
+	for i := range pairs {
+		w.WriteFixed(pairs[i][0][:])
+		w.WriteFixed(pairs[i][1][:])
+	}
+}
+
+func readDigestPairs(r *curves.Decoder) [][2][simplest.DigestSize]byte {
+	out := make([][2][simplest.DigestSize]byte, r.ReadCount(2*simplest.DigestSize))
+	for i := range out {
+		r.ReadFixed(out[i][0][:])
+		r.ReadFixed(out[i][1][:])
+	}
+	return out
+}
+
+func writeMaskedChoices(w *curves.Encoder, choices []simplest.ReceiversMaskedChoices) {
+	w.WriteUint32(uint32(len(choices)))
+	for _, c := range choices {
+		w.WriteBytes(c)
+	}
+}
+
+func readMaskedChoices(r *curves.Decoder) []simplest.ReceiversMaskedChoices {
+	out := make([]simplest.ReceiversMaskedChoices, r.ReadCount(4))
+	for i := range out {
+		out[i] = r.ReadBytes()
+	}
+	return out
+}
+
+// writeSchnorrProof writes a proof without a curve header; the enclosing payload provides it.
+func writeSchnorrProof(w *curves.Encoder, proof *schnorr.Proof) {
+	w.WriteScalar(proof.C)
+	w.WriteScalar(proof.S)
+	w.WritePoint(proof.Statement)
+}
+
+func readSchnorrProof(r *curves.Decoder) *schnorr.Proof {
+	return &schnorr.Proof{
+		C:         r.ReadScalar(),
+		S:         r.ReadScalar(),
+		Statement: r.ReadPoint(),
+	}
+}
+
+func writeAliceOutput(w *curves.Encoder, output *dkg.AliceOutput) {
+	w.WriteCurve(output.PublicKey.CurveName())
+	w.WritePoint(output.PublicKey)
+	w.WriteScalar(output.SecretKeyShare)
+	w.WriteFixed(output.ChainCode[:])
+	w.WritePresent(output.SeedOtResult != nil)
+	if output.SeedOtResult == nil {
+		return
+	}
+	w.WriteBytes(output.SeedOtResult.PackedRandomChoiceBits)
+	writeDigests(w, output.SeedOtResult.OneTimePadDecryptionKey)
+}
+
+func readAliceOutput(r *curves.Decoder, output *dkg.AliceOutput) {
+	r.ReadCurve()
+	output.PublicKey = r.ReadPoint()
+	output.SecretKeyShare = r.ReadScalar()
+	r.ReadFixed(output.ChainCode[:])
+	if !r.ReadPresent() {
+		return
+	}
+	packed := r.ReadBytes()
+	pads := readDigests(r)
+	if r.Err() != nil {
+		return
+	}
+	if len(packed)*8 < len(pads) {
+		r.Fail("choice bits do not cover %d one time pads", len(pads))
+		return
+	}
+	// The unpacked choice bits are redundant, so they are rebuilt rather than transmitted.
+	choiceBits := make([]int, len(pads))
+	for i := range choiceBits {
+		choiceBits[i] = int(simplest.ExtractBitFromByteVector(packed, i))
+	}
+	output.SeedOtResult = &simplest.ReceiverOutput{
+		PackedRandomChoiceBits:  packed,
+		RandomChoiceBits:        choiceBits,
+		OneTimePadDecryptionKey: pads,
+	}
+}
+
+func writeBobOutput(w *curves.Encoder, output *dkg.BobOutput) {
+	w.WriteCurve(output.PublicKey.CurveName())
+	w.WritePoint(output.PublicKey)
+	w.WriteScalar(output.SecretKeyShare)
+	w.WriteFixed(output.ChainCode[:])
+	w.WritePresent(output.SeedOtResult != nil)
+	if output.SeedOtResult == nil {
+		return
+	}
+	writeDigestPairs(w, output.SeedOtResult.OneTimePadEncryptionKeys)
+}
+
+func readBobOutput(r *curves.Decoder, output *dkg.BobOutput) {
+	r.ReadCurve()
+	output.PublicKey = r.ReadPoint()
+	output.SecretKeyShare = r.ReadScalar()
+	r.ReadFixed(output.ChainCode[:])
+	if !r.ReadPresent() {
+		return
+	}
+	pads := readDigestPairs(r)
+	if r.Err() != nil {
+		return
+	}
+	output.SeedOtResult = &simplest.SenderOutput{OneTimePadEncryptionKeys: pads}
+}
+
+func writeSignRound2Output(w *curves.Encoder, output *sign.SignRound2Output) {
+	w.WriteCurve(output.DB.CurveName())
+	for _, kosOutput := range output.KosRound1Outputs {
+		for i := range kosOutput.U {
+			w.WriteFixed(kosOutput.U[i][:])
+		}
+		w.WriteFixed(kosOutput.WPrime[:])
+		w.WriteFixed(kosOutput.VPrime[:])
+	}
+	w.WritePoint(output.DB)
+	w.WriteFixed(output.Seed[:])
+}
+
+func readSignRound2Output(r *curves.Decoder) *sign.SignRound2Output {
+	r.ReadCurve()
+	output := new(sign.SignRound2Output)
+	for j := range output.KosRound1Outputs {
+		kosOutput := new(kos.Round1Output)
+		for i := range kosOutput.U {
+			r.ReadFixed(kosOutput.U[i][:])
+		}
+		r.ReadFixed(kosOutput.WPrime[:])
+		r.ReadFixed(kosOutput.VPrime[:])
+		output.KosRound1Outputs[j] = kosOutput
+	}
+	output.DB = r.ReadPoint()
+	r.ReadFixed(output.Seed[:])
+	return output
+}
+
+func writeSignRound3Output(w *curves.Encoder, output *sign.SignRound3Output) {
+	w.WriteCurve(output.RPrime.CurveName())
+	for _, multiplyOutput := range output.MultiplyRound2Outputs {
+		for i := range multiplyOutput.COTRound2Output.Tau {
+			for j := range multiplyOutput.COTRound2Output.Tau[i] {
+				w.WriteScalar(multiplyOutput.COTRound2Output.Tau[i][j])
+			}
+		}
+		for i := range multiplyOutput.R {
+			w.WriteScalar(multiplyOutput.R[i])
+		}
+		w.WriteScalar(multiplyOutput.U)
+	}
+	writeSchnorrProof(w, output.RSchnorrProof)
+	w.WritePoint(output.RPrime)
+	w.WriteScalar(output.EtaPhi)
+	w.WriteScalar(output.EtaSig)
+}
+
+func readSignRound3Output(r *curves.Decoder) *sign.SignRound3Output {
+	r.ReadCurve()
+	output := new(sign.SignRound3Output)
+	for k := range output.MultiplyRound2Outputs {
+		multiplyOutput := &sign.MultiplyRound2Output{COTRound2Output: new(kos.Round2Output)}
+		for i := range multiplyOutput.COTRound2Output.Tau {
+			for j := range multiplyOutput.COTRound2Output.Tau[i] {
+				multiplyOutput.COTRound2Output.Tau[i][j] = r.ReadScalar()
+			}
+		}
+		for i := range multiplyOutput.R {
+			multiplyOutput.R[i] = r.ReadScalar()
+		}
+		multiplyOutput.U = r.ReadScalar()
+		output.MultiplyRound2Outputs[k] = multiplyOutput
+	}
+	output.RSchnorrProof = readSchnorrProof(r)
+	output.RPrime = r.ReadPoint()
+	output.EtaPhi = r.ReadScalar()
+	output.EtaSig = r.ReadScalar()
+	return output
+}
+
+func writeSignature(w *curves.Encoder, signature *curves.EcdsaSignature) {
+	w.WriteFixed([]byte{byte(signature.V)})
+	w.WriteBigInt(signature.R)
+	w.WriteBigInt(signature.S)
+}
+
+func readSignature(r *curves.Decoder) *curves.EcdsaSignature {
+	var v [1]byte
+	r.ReadFixed(v[:])
+	return &curves.EcdsaSignature{
+		V: int(v[0]),
+		R: r.ReadBigInt(),
+		S: r.ReadBigInt(),
+	}
+}
+
+func writeRefreshRound2Output(w *curves.Encoder, output *refresh.RefreshRound2Output) {
+	w.WriteCurve(output.SeedOTRound1Output.Statement.CurveName())
+	writeSchnorrProof(w, output.SeedOTRound1Output)
+	w.WriteScalar(output.BobMultiplier)
+}
+
+func readRefreshRound2Output(r *curves.Decoder) *refresh.RefreshRound2Output {
+	r.ReadCurve()
+	return &refresh.RefreshRound2Output{
+		SeedOTRound1Output: readSchnorrProof(r),
+		BobMultiplier:      r.ReadScalar(),
+	}
+}
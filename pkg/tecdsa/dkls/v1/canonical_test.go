@@ -0,0 +1,275 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package v1
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
+	"github.com/TEENet-io/kryptology/pkg/core/protocol"
+	"github.com/TEENet-io/kryptology/pkg/ot/base/simplest"
+	"github.com/TEENet-io/kryptology/pkg/tecdsa/dkls/v1/dkg"
+	"github.com/TEENet-io/kryptology/pkg/zkp/schnorr"
+)
+
+// recorder wraps an iterator and keeps every message it emits.
+type recorder struct {
+	protocol.Iterator
+	messages []*protocol.Message
+}
+
+func (r *recorder) Next(input *protocol.Message) (*protocol.Message, error) {
+	output, err := r.Iterator.Next(input)
+	if output != nil {
+		r.messages = append(r.messages, output)
+	}
+	return output, err
+}
+
+func u32(n int) []byte {
+	return binary.BigEndian.AppendUint32(nil, uint32(n))
+}
+
+func lengthPrefixed(b []byte) []byte {
+	return append(u32(len(b)), b...)
+}
+
+func concat(parts ...[]byte) []byte {
+	var out []byte
+	for _, p := range parts {
+		out = append(out, p...)
+	}
+	return out
+}
+
+// DKG > Refresh > Sign, with every message in the canonical encoding.
+func TestProtocolsVersion2(t *testing.T) {
+	t.Parallel()
+	for _, curve := range []*curves.Curve{curves.K256(), curves.P256()} {
+		boundCurve := curve
+		t.Run(boundCurve.Name, func(tt *testing.T) {
+			tt.Parallel()
+			aliceDkg := &recorder{Iterator: NewAliceDkg(boundCurve, protocol.Version2)}
+			bobDkg := &recorder{Iterator: NewBobDkg(boundCurve, protocol.Version2)}
+			aErr, bErr := runIteratedProtocol(bobDkg, aliceDkg)
+			require.ErrorIs(tt, aErr, protocol.ErrProtocolFinished)
+			require.ErrorIs(tt, bErr, protocol.ErrProtocolFinished)
+			for _, m := range append(aliceDkg.messages, bobDkg.messages...) {
+				require.Equal(tt, uint(protocol.Version2), m.Version)
+			}
+			// Bob's first message is the bare 32 byte commitment.
+			require.Len(tt, bobDkg.messages[0].Payloads[payloadKey], 32)
+
+			aliceDkgResult, err := aliceDkg.Result(protocol.Version2)
+			require.NoError(tt, err)
+			bobDkgResult, err := bobDkg.Result(protocol.Version2)
+			require.NoError(tt, err)
+
+			aliceRefresh, err := NewAliceRefresh(boundCurve, aliceDkgResult, protocol.Version2)
+			require.NoError(tt, err)
+			bobRefresh, err := NewBobRefresh(boundCurve, bobDkgResult, protocol.Version2)
+			require.NoError(tt, err)
+			aErr, bErr = runIteratedProtocol(aliceRefresh, bobRefresh)
+			require.ErrorIs(tt, aErr, protocol.ErrProtocolFinished)
+			require.ErrorIs(tt, bErr, protocol.ErrProtocolFinished)
+			aliceRefreshResult, err := aliceRefresh.Result(protocol.Version2)
+			require.NoError(tt, err)
+			bobRefreshResult, err := bobRefresh.Result(protocol.Version2)
+			require.NoError(tt, err)
+
+			msg := []byte("As soon as you trust yourself, you will know how to live.")
+			aliceSign, err := NewAliceSign(boundCurve, sha3.New256(), msg, aliceRefreshResult, protocol.Version2)
+			require.NoError(tt, err)
+			bobSign, err := NewBobSign(boundCurve, sha3.New256(), msg, bobRefreshResult, protocol.Version2)
+			require.NoError(tt, err)
+			aErr, bErr = runIteratedProtocol(aliceSign, bobSign)
+			require.ErrorIs(tt, aErr, protocol.ErrProtocolFinished)
+			require.ErrorIs(tt, bErr, protocol.ErrProtocolFinished)
+			signature, err := bobSign.Result(protocol.Version2)
+			require.NoError(tt, err)
+
+			aliceOutput, err := DecodeAliceRefreshResult(aliceRefreshResult)
+			require.NoError(tt, err)
+			require.True(tt, verifySignatureMessage(tt, boundCurve, aliceOutput.PublicKey, msg, signature))
+		})
+	}
+}
+
+// A Version1 result migrates to Version2 by decoding and encoding it again.
+func TestMigrateGobResultToVersion2(t *testing.T) {
+	curve := curves.K256()
+	aliceV1, bobV1 := dkgResultMessages(t, curve)
+
+	// Version0 messages share the gob layout of Version1.
+	aliceV0 := *aliceV1
+	aliceV0.Version = protocol.Version0
+	_, err := DecodeAliceDkgResult(&aliceV0)
+	require.NoError(t, err)
+
+	aliceOutput, err := DecodeAliceDkgResult(aliceV1)
+	require.NoError(t, err)
+	bobOutput, err := DecodeBobDkgResult(bobV1)
+	require.NoError(t, err)
+	aliceV2, err := EncodeAliceDkgOutput(aliceOutput, protocol.Version2)
+	require.NoError(t, err)
+	bobV2, err := EncodeBobDkgOutput(bobOutput, protocol.Version2)
+	require.NoError(t, err)
+
+	aliceMigrated, err := DecodeAliceDkgResult(aliceV2)
+	require.NoError(t, err)
+	require.True(t, aliceMigrated.PublicKey.Equal(aliceOutput.PublicKey))
+	require.Equal(t, 0, aliceMigrated.SecretKeyShare.Cmp(aliceOutput.SecretKeyShare))
+	require.Equal(t, aliceOutput.ChainCode, aliceMigrated.ChainCode)
+	require.Equal(t, aliceOutput.SeedOtResult, aliceMigrated.SeedOtResult)
+	bobMigrated, err := DecodeBobDkgResult(bobV2)
+	require.NoError(t, err)
+	require.Equal(t, bobOutput.SeedOtResult, bobMigrated.SeedOtResult)
+
+	// Encoding is deterministic.
+	again, err := EncodeAliceDkgOutput(aliceMigrated, protocol.Version2)
+	require.NoError(t, err)
+	require.Equal(t, aliceV2.Payloads, again.Payloads)
+
+	aliceSign, err := NewAliceSign(curve, sha3.New256(), []byte("message"), aliceV2, protocol.Version2)
+	require.NoError(t, err)
+	bobSign, err := NewBobSign(curve, sha3.New256(), []byte("message"), bobV2, protocol.Version2)
+	require.NoError(t, err)
+	aErr, bErr := runIteratedProtocol(aliceSign, bobSign)
+	require.ErrorIs(t, aErr, protocol.ErrProtocolFinished)
+	require.ErrorIs(t, bErr, protocol.ErrProtocolFinished)
+}
+
+// The schema tests build the expected payloads by hand from ENCODING.md.
+func TestSchemaResults(t *testing.T) {
+	curve := curves.K256()
+	sk := curve.Scalar.New(7)
+	pk := curve.ScalarBaseMult(sk)
+	chainCode := [32]byte{1, 2, 3}
+	pads := []simplest.OneTimePadDecryptionKey{{0xaa}, {0xbb}, {0xcc}}
+	packed := []byte{0x05}
+
+	aliceMessage, err := EncodeAliceDkgOutput(&dkg.AliceOutput{
+		PublicKey:      pk,
+		SecretKeyShare: sk,
+		ChainCode:      chainCode,
+		SeedOtResult: &simplest.ReceiverOutput{
+			PackedRandomChoiceBits:  packed,
+			RandomChoiceBits:        []int{1, 0, 1},
+			OneTimePadDecryptionKey: pads,
+		},
+	}, protocol.Version2)
+	require.NoError(t, err)
+	skBytes := make([]byte, 32)
+	skBytes[31] = 7
+	expected := concat(
+		lengthPrefixed([]byte("secp256k1")),
+		lengthPrefixed(pk.ToAffineCompressed()),
+		lengthPrefixed(skBytes),
+		chainCode[:],
+		[]byte{1},
+		lengthPrefixed(packed),
+		u32(3), pads[0][:], pads[1][:], pads[2][:],
+	)
+	require.Equal(t, expected, aliceMessage.Payloads[payloadKey])
+	decoded, err := DecodeAliceDkgResult(aliceMessage)
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 0, 1}, decoded.SeedOtResult.RandomChoiceBits)
+
+	p256 := curves.P256()
+	bobMessage, err := EncodeBobDkgOutput(&dkg.BobOutput{
+		PublicKey:      p256.Point.Generator(),
+		SecretKeyShare: p256.Scalar.One(),
+	}, protocol.Version2)
+	require.NoError(t, err)
+	one := make([]byte, 32)
+	one[31] = 1
+	expected = concat(
+		lengthPrefixed([]byte("P-256")),
+		lengthPrefixed(p256.Point.Generator().ToAffineCompressed()),
+		lengthPrefixed(one),
+		make([]byte, 32),
+		[]byte{0},
+	)
+	require.Equal(t, expected, bobMessage.Payloads[payloadKey])
+	decodedBob, err := DecodeBobDkgResult(bobMessage)
+	require.NoError(t, err)
+	require.Nil(t, decodedBob.SeedOtResult)
+}
+
+func TestSchemaRounds(t *testing.T) {
+	curve := curves.K256()
+	two := make([]byte, 32)
+	two[31] = 2
+	three := make([]byte, 32)
+	three[31] = 3
+
+	proof := &schnorr.Proof{C: curve.Scalar.New(2), S: curve.Scalar.New(3), Statement: curve.Point.Generator()}
+	message, err := encodeDkgRound3Output(proof, protocol.Version2)
+	require.NoError(t, err)
+	require.Equal(t, concat(
+		lengthPrefixed([]byte("secp256k1")),
+		lengthPrefixed(two),
+		lengthPrefixed(three),
+		lengthPrefixed(curve.Point.Generator().ToAffineCompressed()),
+	), message.Payloads[payloadKey])
+
+	message, err = encodeRefreshRound1Output(curve.Scalar.New(2), protocol.Version2)
+	require.NoError(t, err)
+	require.Equal(t, concat(lengthPrefixed([]byte("secp256k1")), lengthPrefixed(two)), message.Payloads[payloadKey])
+
+	message, err = encodeDkgRound6Output([]simplest.ReceiversMaskedChoices{{1, 2}, {}}, protocol.Version2)
+	require.NoError(t, err)
+	require.Equal(t, concat(u32(2), lengthPrefixed([]byte{1, 2}), u32(0)), message.Payloads[payloadKey])
+
+	message, err = encodeDkgRound9Output([]simplest.ChallengeOpening{{{1}, {2}}}, protocol.Version2)
+	require.NoError(t, err)
+	first, second := make([]byte, 32), make([]byte, 32)
+	first[0], second[0] = 1, 2
+	require.Equal(t, concat(u32(1), first, second), message.Payloads[payloadKey])
+
+	message, err = encodeSignature(&curves.EcdsaSignature{V: 1, R: big.NewInt(0x0102), S: big.NewInt(3)}, protocol.Version2)
+	require.NoError(t, err)
+	require.Equal(t, concat([]byte{1}, lengthPrefixed([]byte{1, 2}), lengthPrefixed([]byte{3})), message.Payloads[payloadKey])
+}
+
+func TestCanonicalDecoderRejectsNonCanonicalPayloads(t *testing.T) {
+	curve := curves.K256()
+	order := new(big.Int).Add(curve.Scalar.One().Neg().BigInt(), big.NewInt(1))
+	tests := []struct {
+		name    string
+		payload []byte
+	}{
+		{"trailing bytes", concat(lengthPrefixed([]byte("secp256k1")), lengthPrefixed(make([]byte, 32)), []byte{0})},
+		{"truncated", concat(lengthPrefixed([]byte("secp256k1")), u32(32), make([]byte, 31))},
+		{"short scalar", concat(lengthPrefixed([]byte("secp256k1")), lengthPrefixed([]byte{1}))},
+		{"unreduced scalar", concat(lengthPrefixed([]byte("secp256k1")), lengthPrefixed(order.FillBytes(make([]byte, 32))))},
+		{"unknown curve", concat(lengthPrefixed([]byte("secp256r2")), lengthPrefixed(make([]byte, 32)))},
+		{"huge length", concat(lengthPrefixed([]byte("secp256k1")), u32(1<<31))},
+	}
+	for _, test := range tests {
+		_, err := decodeRefreshRound2Input(newRefreshProtocolMessage(test.payload, "1", protocol.Version2))
+		require.Error(t, err, test.name)
+	}
+
+	_, err := decodeDkgRound7Input(newDkgProtocolMessage(u32(1<<30), "6", protocol.Version2))
+	require.Error(t, err)
+	_, err = DecodeSignature(newSignProtocolMessage(concat([]byte{0}, lengthPrefixed([]byte{0, 1}), lengthPrefixed([]byte{1})), "signature", protocol.Version2))
+	require.Error(t, err)
+
+	for _, version := range []uint{0, 400} {
+		_, err = decodeDkgRound2Input(newDkgProtocolMessage(make([]byte, 32), "1", version))
+		require.Error(t, err, fmt.Sprint(version))
+		_, err = encodeDkgRound1Output([32]byte{}, version)
+		require.Error(t, err, fmt.Sprint(version))
+	}
+}
@@ -30,15 +30,21 @@ func GenerateAndDeal(curve *curves.Curve) (*dkg.AliceOutput, *dkg.BobOutput, err
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "couldn't produce OT results")
 	}
+	chainCode := [simplest.DigestSize]byte{}
+	if _, err = rand.Read(chainCode[:]); err != nil {
+		return nil, nil, errors.Wrap(err, "couldn't produce chain code")
+	}
 	alice := &dkg.AliceOutput{
 		PublicKey:      publicKey,
 		SecretKeyShare: aliceSecretShare,
 		SeedOtResult:   aliceOTOutput,
+		ChainCode:      chainCode,
 	}
 	bob := &dkg.BobOutput{
 		PublicKey:      publicKey,
 		SecretKeyShare: bobSecretShare,
 		SeedOtResult:   bobOTOutput,
+		ChainCode:      chainCode,
 	}
 	return alice, bob, nil
 }
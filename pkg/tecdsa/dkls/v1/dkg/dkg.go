@@ -36,6 +36,10 @@ type AliceOutput struct {
 	// This output must be kept secret. Although, if it is lost the users can run another OT protocol and obtain
 	// new values to replace it.
 	SeedOtResult *simplest.ReceiverOutput
+
+	// ChainCode is the BIP-32 chain code of the joint public key, derived from the DKG transcript.
+	// This value is public, and is the same for Alice and Bob.
+	ChainCode [simplest.DigestSize]byte
 }
 
 // BobOutput is the result of running DKG for Bob. It contains both the public and secret values that are needed
@@ -53,6 +57,10 @@ type BobOutput struct {
 	// This output must be kept secret. Although, if it is lost the users can run another OT protocol and obtain
 	// new values to replace it.
 	SeedOtResult *simplest.SenderOutput
+
+	// ChainCode is the BIP-32 chain code of the joint public key, derived from the DKG transcript.
+	// This value is public, and is the same for Alice and Bob.
+	ChainCode [simplest.DigestSize]byte
 }
 
 // Alice struct encoding Alice's state during one execution of the overall signing algorithm.
@@ -73,6 +81,9 @@ type Alice struct {
 	// publicKey is the joint public key of Alice and Bob.
 	publicKey curves.Point
 
+	// chainCode is the BIP-32 chain code of the joint public key.
+	chainCode [simplest.DigestSize]byte
+
 	curve *curves.Curve
 
 	transcript *merlin.Transcript
@@ -98,6 +109,9 @@ type Bob struct {
 	// 32-byte transcript salt which will be used for Alice's schnorr proof
 	aliceSalt [simplest.DigestSize]byte
 
+	// chainCode is the BIP-32 chain code of the joint public key.
+	chainCode [simplest.DigestSize]byte
+
 	curve *curves.Curve
 
 	transcript *merlin.Transcript
@@ -192,6 +206,7 @@ func (bob *Bob) Round3SchnorrProve(round2Output *Round2Output) (*schnorr.Proof,
 	copy(bob.aliceSalt[:], bob.transcript.ExtractBytes([]byte("salt for alice schnorr"), simplest.DigestSize))
 	bob.secretKeyShare = bob.curve.Scalar.Random(rand.Reader)
 	copy(uniqueSessionId[:], bob.transcript.ExtractBytes([]byte("salt for bob schnorr"), simplest.DigestSize))
+	copy(bob.chainCode[:], bob.transcript.ExtractBytes([]byte("chain code"), simplest.DigestSize))
 	bob.prover = schnorr.NewProver(bob.curve, nil, uniqueSessionId[:])
 	proof, err := bob.prover.Prove(bob.secretKeyShare)
 	if err != nil {
@@ -205,6 +220,7 @@ func (alice *Alice) Round4VerifyAndReveal(proof *schnorr.Proof) (*schnorr.Proof,
 	var err error
 	uniqueSessionId := [simplest.DigestSize]byte{}
 	copy(uniqueSessionId[:], alice.transcript.ExtractBytes([]byte("salt for bob schnorr"), simplest.DigestSize))
+	copy(alice.chainCode[:], alice.transcript.ExtractBytes([]byte("chain code"), simplest.DigestSize))
 	if err = schnorr.Verify(proof, alice.curve, nil, uniqueSessionId[:]); err != nil {
 		return nil, errors.Wrap(err, "alice's verification of Bob's schnorr proof failed in DKG round 3")
 	}
@@ -258,6 +274,7 @@ func (alice *Alice) Output() *AliceOutput {
 		PublicKey:      alice.publicKey,
 		SecretKeyShare: alice.secretKeyShare,
 		SeedOtResult:   alice.receiver.Output,
+		ChainCode:      alice.chainCode,
 	}
 }
 
@@ -268,5 +285,6 @@ func (bob *Bob) Output() *BobOutput {
 		PublicKey:      bob.publicKey,
 		SecretKeyShare: bob.secretKeyShare,
 		SeedOtResult:   bob.sender.Output,
+		ChainCode:      bob.chainCode,
 	}
 }
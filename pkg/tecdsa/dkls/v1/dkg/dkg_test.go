@@ -66,6 +66,9 @@ func TestDkg(t *testing.T) {
 			computedPublicKeyB := pkB.Mul(alice.Output().SecretKeyShare)
 			require.True(tt, computedPublicKeyB.Equal(alice.Output().PublicKey))
 			require.True(tt, computedPublicKeyB.Equal(bob.Output().PublicKey))
+
+			require.Equal(tt, alice.Output().ChainCode, bob.Output().ChainCode)
+			require.NotEqual(tt, [32]byte{}, alice.Output().ChainCode)
 		})
 	}
 }
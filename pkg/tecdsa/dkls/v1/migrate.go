@@ -0,0 +1,253 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package v1
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
+	"github.com/TEENet-io/kryptology/pkg/core/protocol"
+	"github.com/TEENet-io/kryptology/pkg/ot/base/simplest"
+	"github.com/TEENet-io/kryptology/pkg/ot/extension/kos"
+	v0 "github.com/TEENet-io/kryptology/pkg/tecdsa/dkls/v0"
+	"github.com/TEENet-io/kryptology/pkg/tecdsa/dkls/v1/dkg"
+)
+
+// ErrSeedOtNotConvertible is returned when a V0 DKG result has no usable seed OT state. Such a key can still be moved
+// to V1 with NewAliceMigration and NewBobMigration, which run a fresh seed OT.
+var ErrSeedOtNotConvertible = fmt.Errorf("the seed OT state of the v0 dkg result cannot be carried over")
+
+// ConvertAliceDkgOutput converts a V0 Alice DKG result into a V1 DKG output.
+// The V0 version of DKls `gob` encoded the entire `Alice` object as DKG state. This function decodes it, extracts what
+// the V1 signing algorithm needs, and converts the curve Scalar and Points to the V1 types.
+//
+// The following data mapping and conversion is performed.
+//   - v0.alice.Pk              -> Converted to v1.PublicKey (a curve Point)
+//   - v0.alice.SkA             -> Converted to v1.SecretKeyShare (a scalar value)
+//   - v0.alice.Receiver.Packed -> Converted to v1.SeedOtResult.PackedRandomChoiceBits (the random choice bits in OT)
+//   - v0.alice.Receiver.Packed -> Converted to v1.SeedOtResult.RandomChoiceBits (the random choice bits in OT in unpacked form)
+//   - v0.alice.Receiver.Rho    -> Converted to v1.SeedOtResult.OneTimePadDecryptionKey (the Rho value in the paper)
+//
+// V0 has no chain code, so one is derived from the public key; see v0ChainCode.
+//
+// If the V0 result carries no seed OT output, the returned output has a nil SeedOtResult. It cannot be used to sign,
+// but it can be used to run a refresh, which is what NewAliceMigration does.
+func ConvertAliceDkgOutput(params *v0.Params, dkgResult []byte) (*dkg.AliceOutput, error) {
+	return convertAliceDkgOutput(params, dkgResult, false)
+}
+
+// convertAliceDkgOutput is ConvertAliceDkgOutput. If keepEmptySeedOt is set, an all zero seed OT state is converted
+// as is, as ConvertAliceDkgOutputToV1 always did, instead of being dropped.
+func convertAliceDkgOutput(params *v0.Params, dkgResult []byte, keepEmptySeedOt bool) (*dkg.AliceOutput, error) {
+	alice, err := v0.DecodeAlice(params, dkgResult)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if alice.SkA == nil || alice.Pk == nil {
+		return nil, fmt.Errorf("v0 dkg result is incomplete")
+	}
+	curve, err := curveFromV0Params(params)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, err := curve.Point.Set(alice.Pk.X, alice.Pk.Y)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	secretKey, err := curve.Scalar.SetBigInt(alice.SkA)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	output := &dkg.AliceOutput{
+		PublicKey:      publicKey,
+		SecretKeyShare: secretKey,
+		ChainCode:      v0ChainCode(publicKey),
+	}
+	if alice.Receiver == nil || (!keepEmptySeedOt && alice.Receiver.Rho == [kos.Kappa][simplest.DigestSize]byte{}) {
+		return output, nil
+	}
+
+	packedChoiceBits := make([]byte, len(alice.Receiver.Packed))
+	copy(packedChoiceBits, alice.Receiver.Packed[:])
+
+	randomChoiceBits := make([]int, kos.Kappa)
+	for i := 0; i < len(randomChoiceBits); i++ {
+		randomChoiceBits[i] = int(simplest.ExtractBitFromByteVector(packedChoiceBits, i))
+	}
+
+	decryptionPads := make([]simplest.OneTimePadDecryptionKey, kos.Kappa)
+	for i := 0; i < kos.Kappa; i++ {
+		decryptionPads[i] = alice.Receiver.Rho[i]
+	}
+
+	output.SeedOtResult = &simplest.ReceiverOutput{
+		PackedRandomChoiceBits:  packedChoiceBits,
+		RandomChoiceBits:        randomChoiceBits,
+		OneTimePadDecryptionKey: decryptionPads,
+	}
+	return output, nil
+}
+
+// ConvertBobDkgOutput converts a V0 Bob DKG result into a V1 DKG output. See ConvertAliceDkgOutput.
+//
+// The following data mapping and conversion is performed.
+//   - v0.bob.Pk         -> Converted to v1.PublicKey (a curve Point)
+//   - v0.bob.SkB        -> Converted to v1.SecretKeyShare (a scalar value)
+//   - v0.bob.Sender.Rho -> Converted to v1.SeedOtResult.OneTimePadEncryptionKeys (the Rho value in the paper)
+func ConvertBobDkgOutput(params *v0.Params, dkgResult []byte) (*dkg.BobOutput, error) {
+	return convertBobDkgOutput(params, dkgResult, false)
+}
+
+// convertBobDkgOutput is ConvertBobDkgOutput. See convertAliceDkgOutput for keepEmptySeedOt.
+func convertBobDkgOutput(params *v0.Params, dkgResult []byte, keepEmptySeedOt bool) (*dkg.BobOutput, error) {
+	bob, err := v0.DecodeBob(params, dkgResult)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if bob.SkB == nil || bob.Pk == nil {
+		return nil, fmt.Errorf("v0 dkg result is incomplete")
+	}
+	curve, err := curveFromV0Params(params)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, err := curve.Point.Set(bob.Pk.X, bob.Pk.Y)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	secretKey, err := curve.Scalar.SetBigInt(bob.SkB)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	output := &dkg.BobOutput{
+		PublicKey:      publicKey,
+		SecretKeyShare: secretKey,
+		ChainCode:      v0ChainCode(publicKey),
+	}
+	if bob.Sender == nil || (!keepEmptySeedOt && bob.Sender.Rho == [kos.Kappa]simplest.OneTimePadEncryptionKeys{}) {
+		return output, nil
+	}
+	encryptionPads := make([]simplest.OneTimePadEncryptionKeys, kos.Kappa)
+	for i := 0; i < kos.Kappa; i++ {
+		encryptionPads[i] = bob.Sender.Rho[i]
+	}
+	output.SeedOtResult = &simplest.SenderOutput{
+		OneTimePadEncryptionKeys: encryptionPads,
+	}
+	return output, nil
+}
+
+// MigrateAliceDkgResult converts a V0 Alice DKG result into a V1 result message of the given version, which can be
+// passed to NewAliceSign or NewAliceRefresh. It fails with ErrSeedOtNotConvertible if the seed OT state cannot be
+// carried over.
+func MigrateAliceDkgResult(params *v0.Params, dkgResult []byte, version uint) (*protocol.Message, error) {
+	output, err := ConvertAliceDkgOutput(params, dkgResult)
+	if err != nil {
+		return nil, err
+	}
+	if output.SeedOtResult == nil {
+		return nil, ErrSeedOtNotConvertible
+	}
+	return EncodeAliceDkgOutput(output, version)
+}
+
+// MigrateBobDkgResult converts a V0 Bob DKG result into a V1 result message of the given version. See
+// MigrateAliceDkgResult.
+func MigrateBobDkgResult(params *v0.Params, dkgResult []byte, version uint) (*protocol.Message, error) {
+	output, err := ConvertBobDkgOutput(params, dkgResult)
+	if err != nil {
+		return nil, err
+	}
+	if output.SeedOtResult == nil {
+		return nil, ErrSeedOtNotConvertible
+	}
+	return EncodeBobDkgOutput(output, version)
+}
+
+// ConvertAliceDkgOutputToV1 converts the V0 output to V1 output, encoded as a Version1 message. Unlike
+// MigrateAliceDkgResult it does not check the seed OT state, which is converted as it is found.
+func ConvertAliceDkgOutputToV1(params *v0.Params, dkgResult []byte) (*protocol.Message, error) {
+	output, err := convertAliceDkgOutput(params, dkgResult, true)
+	if err != nil {
+		return nil, err
+	}
+	return EncodeAliceDkgOutput(output, protocol.Version1)
+}
+
+// ConvertBobDkgOutputToV1 converts the V0 output to V1 output, encoded as a Version1 message. See
+// ConvertAliceDkgOutputToV1.
+func ConvertBobDkgOutputToV1(params *v0.Params, dkgResult []byte) (*protocol.Message, error) {
+	output, err := convertBobDkgOutput(params, dkgResult, true)
+	if err != nil {
+		return nil, err
+	}
+	return EncodeBobDkgOutput(output, protocol.Version1)
+}
+
+// NewAliceMigration creates a protocol that moves a V0 Alice DKG result to V1 by running a V1 key refresh with Bob,
+// who runs NewBobMigration. The refresh runs a fresh seed OT, so it works whether or not the V0 seed OT state could be
+// carried over, and it re-randomizes the key shares as a refresh always does. Its Result is a V1 DKG result message.
+func NewAliceMigration(params *v0.Params, dkgResult []byte, version uint) (*AliceRefresh, error) {
+	output, err := ConvertAliceDkgOutput(params, dkgResult)
+	if err != nil {
+		return nil, err
+	}
+	curve, err := curveFromV0Params(params)
+	if err != nil {
+		return nil, err
+	}
+	return newAliceRefresh(curve, output, version), nil
+}
+
+// NewBobMigration creates a protocol that moves a V0 Bob DKG result to V1 by running a V1 key refresh with Alice.
+// See NewAliceMigration.
+func NewBobMigration(params *v0.Params, dkgResult []byte, version uint) (*BobRefresh, error) {
+	output, err := ConvertBobDkgOutput(params, dkgResult)
+	if err != nil {
+		return nil, err
+	}
+	curve, err := curveFromV0Params(params)
+	if err != nil {
+		return nil, err
+	}
+	return newBobRefresh(curve, output, version), nil
+}
+
+func curveFromV0Params(params *v0.Params) (*curves.Curve, error) {
+	if params == nil || params.Curve == nil {
+		return nil, fmt.Errorf("nil v0 params")
+	}
+	switch params.Curve.Params().Name {
+	case curves.K256Name:
+		return curves.K256(), nil
+	case curves.P256Name:
+		return curves.P256(), nil
+	default:
+		return nil, fmt.Errorf("unsupported curve %s", params.Curve.Params().Name)
+	}
+}
+
+// v0ChainCode derives a BIP-32 chain code for a key created by the V0 DKG, which did not agree on one. Both parties
+// derive the same value from the public key alone. A chain code from a V1 DKG depends on the whole DKG transcript,
+// whereas this one can be computed by anyone who knows the public key, who can then link keys derived from it.
+func v0ChainCode(publicKey curves.Point) [simplest.DigestSize]byte {
+	h := sha3.New256()
+	_, _ = h.Write([]byte("Coinbase_DKLs_v0_ChainCode"))
+	_, _ = h.Write(publicKey.ToAffineCompressed())
+	var chainCode [simplest.DigestSize]byte
+	copy(chainCode[:], h.Sum(nil))
+	return chainCode
+}
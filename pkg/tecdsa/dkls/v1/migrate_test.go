@@ -0,0 +1,138 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
+	"github.com/TEENet-io/kryptology/pkg/core/protocol"
+	v0 "github.com/TEENet-io/kryptology/pkg/tecdsa/dkls/v0"
+)
+
+func v0Dkg(t *testing.T) (*v0.Params, *v0.AliceDkg, *v0.BobDkg) {
+	t.Helper()
+	params, err := v0.NewParams(btcec.S256(), curves.K256Scalar{})
+	require.NoError(t, err)
+	alice := v0.NewAliceDkg(params)
+	bob := v0.NewBobDkg(params)
+	_, _ = runV0IteratedProtocol(alice, bob)
+	return params, alice, bob
+}
+
+func signAndVerify(t *testing.T, curve *curves.Curve, aliceResult, bobResult *protocol.Message, version uint) {
+	t.Helper()
+	msg := []byte("migrated")
+	aliceSign, err := NewAliceSign(curve, sha3.New256(), msg, aliceResult, version)
+	require.NoError(t, err)
+	bobSign, err := NewBobSign(curve, sha3.New256(), msg, bobResult, version)
+	require.NoError(t, err)
+	aErr, bErr := runIteratedProtocol(aliceSign, bobSign)
+	require.ErrorIs(t, aErr, protocol.ErrProtocolFinished)
+	require.ErrorIs(t, bErr, protocol.ErrProtocolFinished)
+	signature, err := bobSign.Result(version)
+	require.NoError(t, err)
+	aliceOutput, err := DecodeAliceDkgResult(aliceResult)
+	require.NoError(t, err)
+	require.True(t, verifySignatureMessage(t, curve, aliceOutput.PublicKey, msg, signature))
+}
+
+func TestMigrateV0DkgResult(t *testing.T) {
+	params, alice, bob := v0Dkg(t)
+	aliceBytes, err := v0.EncodeAlice(alice.Alice)
+	require.NoError(t, err)
+	bobBytes, err := v0.EncodeBob(bob.Bob)
+	require.NoError(t, err)
+
+	aliceOutput, err := ConvertAliceDkgOutput(params, aliceBytes)
+	require.NoError(t, err)
+	bobOutput, err := ConvertBobDkgOutput(params, bobBytes)
+	require.NoError(t, err)
+	require.True(t, aliceOutput.PublicKey.Equal(bobOutput.PublicKey))
+	require.Equal(t, aliceOutput.ChainCode, bobOutput.ChainCode)
+	require.NotNil(t, aliceOutput.SeedOtResult)
+	require.NotNil(t, bobOutput.SeedOtResult)
+
+	aliceResult, err := MigrateAliceDkgResult(params, aliceBytes, protocol.Version2)
+	require.NoError(t, err)
+	bobResult, err := MigrateBobDkgResult(params, bobBytes, protocol.Version2)
+	require.NoError(t, err)
+	require.Equal(t, uint(protocol.Version2), aliceResult.Version)
+	signAndVerify(t, curves.K256(), aliceResult, bobResult, protocol.Version2)
+}
+
+func TestConvertV0WithEmptySeedOt(t *testing.T) {
+	params, alice, bob := v0Dkg(t)
+	// A seed OT state that was persisted without its outputs.
+	for i := range alice.Alice.Receiver.Rho {
+		alice.Alice.Receiver.Rho[i] = [32]byte{}
+	}
+	for i := range bob.Bob.Sender.Rho {
+		bob.Bob.Sender.Rho[i] = [2][32]byte{}
+	}
+	aliceBytes, err := v0.EncodeAlice(alice.Alice)
+	require.NoError(t, err)
+	bobBytes, err := v0.EncodeBob(bob.Bob)
+	require.NoError(t, err)
+
+	_, err = MigrateAliceDkgResult(params, aliceBytes, protocol.Version1)
+	require.ErrorIs(t, err, ErrSeedOtNotConvertible)
+	_, err = MigrateBobDkgResult(params, bobBytes, protocol.Version1)
+	require.ErrorIs(t, err, ErrSeedOtNotConvertible)
+
+	// The V1 converters keep converting the state as they find it
+	aliceResult, err := ConvertAliceDkgOutputToV1(params, aliceBytes)
+	require.NoError(t, err)
+	aliceOutput, err := DecodeAliceDkgResult(aliceResult)
+	require.NoError(t, err)
+	require.NotNil(t, aliceOutput.SeedOtResult)
+	bobResult, err := ConvertBobDkgOutputToV1(params, bobBytes)
+	require.NoError(t, err)
+	bobOutput, err := DecodeBobDkgResult(bobResult)
+	require.NoError(t, err)
+	require.NotNil(t, bobOutput.SeedOtResult)
+}
+
+func TestMigrateV0WithoutSeedOt(t *testing.T) {
+	params, alice, bob := v0Dkg(t)
+	// Drop the seed OT state, as if it had never been persisted.
+	alice.Alice.Receiver = nil
+	bob.Bob.Sender = nil
+	aliceBytes, err := v0.EncodeAlice(alice.Alice)
+	require.NoError(t, err)
+	bobBytes, err := v0.EncodeBob(bob.Bob)
+	require.NoError(t, err)
+
+	_, err = MigrateAliceDkgResult(params, aliceBytes, protocol.Version1)
+	require.ErrorIs(t, err, ErrSeedOtNotConvertible)
+	_, err = MigrateBobDkgResult(params, bobBytes, protocol.Version1)
+	require.ErrorIs(t, err, ErrSeedOtNotConvertible)
+
+	aliceMigration, err := NewAliceMigration(params, aliceBytes, protocol.Version1)
+	require.NoError(t, err)
+	bobMigration, err := NewBobMigration(params, bobBytes, protocol.Version1)
+	require.NoError(t, err)
+	aErr, bErr := runIteratedProtocol(aliceMigration, bobMigration)
+	require.ErrorIs(t, aErr, protocol.ErrProtocolFinished)
+	require.ErrorIs(t, bErr, protocol.ErrProtocolFinished)
+
+	aliceResult, err := aliceMigration.Result(protocol.Version1)
+	require.NoError(t, err)
+	bobResult, err := bobMigration.Result(protocol.Version1)
+	require.NoError(t, err)
+	aliceOutput, err := DecodeAliceDkgResult(aliceResult)
+	require.NoError(t, err)
+	require.NotNil(t, aliceOutput.SeedOtResult)
+	publicKey, err := curves.K256().Point.Set(alice.Alice.Pk.X, alice.Alice.Pk.Y)
+	require.NoError(t, err)
+	require.True(t, aliceOutput.PublicKey.Equal(publicKey))
+	signAndVerify(t, curves.K256(), aliceResult, bobResult, protocol.Version1)
+}
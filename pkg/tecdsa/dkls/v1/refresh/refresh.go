@@ -38,6 +38,9 @@ type Alice struct {
 	// publicKey is the joint public key of Alice and Bob.
 	publicKey curves.Point
 
+	// chainCode is the BIP-32 chain code of the joint public key; refresh leaves it unchanged.
+	chainCode [simplest.DigestSize]byte
+
 	curve *curves.Curve
 
 	transcript *merlin.Transcript
@@ -55,6 +58,9 @@ type Bob struct {
 	// publicKey is the joint public key of Alice and Bob.
 	publicKey curves.Point
 
+	// chainCode is the BIP-32 chain code of the joint public key; refresh leaves it unchanged.
+	chainCode [simplest.DigestSize]byte
+
 	curve *curves.Curve
 
 	transcript *merlin.Transcript
@@ -71,6 +77,7 @@ func NewAlice(curve *curves.Curve, dkgOutput *dkg.AliceOutput) *Alice {
 		curve:          curve,
 		secretKeyShare: dkgOutput.SecretKeyShare,
 		publicKey:      dkgOutput.PublicKey,
+		chainCode:      dkgOutput.ChainCode,
 		transcript:     merlin.NewTranscript("Coinbase_DKLs_Refresh"),
 	}
 }
@@ -81,6 +88,7 @@ func NewBob(curve *curves.Curve, dkgOutput *dkg.BobOutput) *Bob {
 		curve:          curve,
 		secretKeyShare: dkgOutput.SecretKeyShare,
 		publicKey:      dkgOutput.PublicKey,
+		chainCode:      dkgOutput.ChainCode,
 		transcript:     merlin.NewTranscript("Coinbase_DKLs_Refresh"),
 	}
 }
@@ -165,6 +173,7 @@ func (alice *Alice) Output() *dkg.AliceOutput {
 		PublicKey:      alice.publicKey,
 		SecretKeyShare: alice.secretKeyShare,
 		SeedOtResult:   alice.receiver.Output,
+		ChainCode:      alice.chainCode,
 	}
 }
 
@@ -173,5 +182,6 @@ func (bob *Bob) Output() *dkg.BobOutput {
 		PublicKey:      bob.publicKey,
 		SecretKeyShare: bob.secretKeyShare,
 		SeedOtResult:   bob.sender.Output,
+		ChainCode:      bob.chainCode,
 	}
 }
@@ -0,0 +1,335 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package v1
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/TEENet-io/kryptology/internal"
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
+	"github.com/TEENet-io/kryptology/pkg/core/protocol"
+	"github.com/TEENet-io/kryptology/pkg/tecdsa/dkls/v1/dkg"
+	"github.com/TEENet-io/kryptology/pkg/tecdsa/dkls/v1/sign"
+)
+
+// SessionIdMetadataKey is the metadata key under which sign messages produced by a session manager carry their
+// session id. Transports multiplexing many sessions route messages by this value.
+const SessionIdMetadataKey = "session"
+
+// DefaultSessionWindow is the number of session counters below the highest one seen that Bob still accepts. Sessions
+// started by Alice are allowed to arrive out of order within this window.
+const DefaultSessionWindow = 1 << 16
+
+var (
+	// ErrSessionIdReuse is returned when Bob is asked to accept a session id he has already accepted, or one too old
+	// to be checked against his replay window.
+	ErrSessionIdReuse = fmt.Errorf("sign session id has already been used")
+
+	// ErrUnknownSession is returned when a message refers to a session that is not active.
+	ErrUnknownSession = fmt.Errorf("unknown sign session")
+)
+
+// SignSessionId identifies one run of the sign protocol. The first 8 bytes are a big-endian counter chosen by Alice and
+// the rest are random. The id is bound into the transcript of the run, so every OT extension session id derived
+// during signing is unique to it.
+type SignSessionId [32]byte
+
+// String returns the hex encoding of the id, as carried in message metadata.
+func (id SignSessionId) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+func (id SignSessionId) counter() uint64 {
+	return binary.BigEndian.Uint64(id[:8])
+}
+
+// SessionIdFromMessage returns the session id carried by a message produced by a session manager.
+func SessionIdFromMessage(m *protocol.Message) (SignSessionId, error) {
+	var id SignSessionId
+	if m == nil || m.Metadata == nil {
+		return id, internal.ErrNilArguments
+	}
+	raw, err := hex.DecodeString(m.Metadata[SessionIdMetadataKey])
+	if err != nil {
+		return id, errors.Wrap(err, "decoding sign session id")
+	}
+	if len(raw) != len(id) {
+		return id, fmt.Errorf("invalid sign session id length %d", len(raw))
+	}
+	copy(id[:], raw)
+	return id, nil
+}
+
+func tagMessage(m *protocol.Message, id SignSessionId) *protocol.Message {
+	if m == nil {
+		return nil
+	}
+	if m.Metadata == nil {
+		m.Metadata = map[string]string{}
+	}
+	m.Metadata[SessionIdMetadataKey] = id.String()
+	return m
+}
+
+// signSession serializes the steps of one iterator; different sessions progress independently.
+type signSession struct {
+	sync.Mutex
+	iterator interface {
+		protocol.Iterator
+		Complete() bool
+	}
+}
+
+// AliceSignSessions runs many concurrent sign protocols as Alice with a single DKG output.
+//
+// The DKG output, including the seed OT result, is only read during signing, so it is safe to share between sessions.
+// What must never be shared is the per-run state, which every session owns. Each session gets its own hash
+// instance and a fresh session id bound into its transcript.
+type AliceSignSessions struct {
+	curve     *curves.Curve
+	newHash   func() hash.Hash
+	dkgOutput *dkg.AliceOutput
+	version   uint
+
+	mu       sync.Mutex
+	counter  uint64
+	sessions map[SignSessionId]*signSession
+}
+
+// NewAliceSignSessions creates a session manager for Alice from the result message of a DKG or refresh. `newHash`
+// must return a new instance of the hash used to digest messages on every call.
+func NewAliceSignSessions(curve *curves.Curve, newHash func() hash.Hash, dkgResultMessage *protocol.Message, version uint) (*AliceSignSessions, error) {
+	if curve == nil || newHash == nil {
+		return nil, internal.ErrNilArguments
+	}
+	dkgOutput, err := DecodeAliceDkgResult(dkgResultMessage)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &AliceSignSessions{
+		curve:     curve,
+		newHash:   newHash,
+		dkgOutput: dkgOutput,
+		version:   version,
+		// Starting from the clock keeps counters increasing across restarts, which Bob's replay window relies on.
+		counter:  uint64(time.Now().UnixNano()),
+		sessions: map[SignSessionId]*signSession{},
+	}, nil
+}
+
+// Start begins signing `message` in a new session. It returns the session id and Alice's first message, which must
+// reach Bob together with the id and the message to sign.
+func (s *AliceSignSessions) Start(message []byte) (SignSessionId, *protocol.Message, error) {
+	var id SignSessionId
+	if _, err := rand.Read(id[8:]); err != nil {
+		return id, nil, errors.Wrap(err, "sampling sign session id")
+	}
+
+	s.mu.Lock()
+	s.counter++
+	binary.BigEndian.PutUint64(id[:8], s.counter)
+	alice := sign.NewAlice(s.curve, s.newHash(), s.dkgOutput)
+	alice.BindSessionId(id[:])
+	session := &signSession{iterator: newAliceSign(alice, message, s.version)}
+	s.sessions[id] = session
+	session.Lock()
+	s.mu.Unlock()
+	defer session.Unlock()
+
+	output, err := session.iterator.Next(nil)
+	if err != nil {
+		s.remove(id)
+		return id, nil, err
+	}
+	return id, tagMessage(output, id), nil
+}
+
+// Handle routes a message from Bob to its session and returns Alice's reply. Once Alice has sent her last message the
+// session is closed. A session that fails is closed and cannot be resumed.
+func (s *AliceSignSessions) Handle(input *protocol.Message) (*protocol.Message, error) {
+	id, err := SessionIdFromMessage(input)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	session, ok := s.sessions[id]
+	s.mu.Unlock()
+	if !ok {
+		return nil, ErrUnknownSession
+	}
+
+	session.Lock()
+	defer session.Unlock()
+	output, err := session.iterator.Next(input)
+	if err != nil || session.iterator.Complete() {
+		s.remove(id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return tagMessage(output, id), nil
+}
+
+// Abort closes a session without completing it.
+func (s *AliceSignSessions) Abort(id SignSessionId) {
+	s.remove(id)
+}
+
+// Active returns the number of sessions in progress.
+func (s *AliceSignSessions) Active() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.sessions)
+}
+
+func (s *AliceSignSessions) remove(id SignSessionId) {
+	s.mu.Lock()
+	delete(s.sessions, id)
+	s.mu.Unlock()
+}
+
+// BobSignSessions runs many concurrent sign protocols as Bob with a single DKG output, and refuses session ids that
+// were already used.
+//
+// Reuse is tracked with a sliding window over the counters of the ids: Bob remembers every counter within `window` of
+// the highest one he has accepted, and rejects anything older. Memory stays bounded however many sessions are run.
+type BobSignSessions struct {
+	curve     *curves.Curve
+	newHash   func() hash.Hash
+	dkgOutput *dkg.BobOutput
+	version   uint
+	window    uint64
+
+	mu       sync.Mutex
+	highest  uint64
+	seen     map[uint64]struct{}
+	sessions map[SignSessionId]*signSession
+}
+
+// NewBobSignSessions creates a session manager for Bob from the result message of a DKG or refresh, with the
+// replay window set to DefaultSessionWindow.
+func NewBobSignSessions(curve *curves.Curve, newHash func() hash.Hash, dkgResultMessage *protocol.Message, version uint) (*BobSignSessions, error) {
+	if curve == nil || newHash == nil {
+		return nil, internal.ErrNilArguments
+	}
+	dkgOutput, err := DecodeBobDkgResult(dkgResultMessage)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &BobSignSessions{
+		curve:     curve,
+		newHash:   newHash,
+		dkgOutput: dkgOutput,
+		version:   version,
+		window:    DefaultSessionWindow,
+		seen:      map[uint64]struct{}{},
+		sessions:  map[SignSessionId]*signSession{},
+	}, nil
+}
+
+// Accept opens the session `id` to sign `message`. It must be called before the first message of the session is
+// handled, and fails with ErrSessionIdReuse if the id has been accepted before.
+func (s *BobSignSessions) Accept(id SignSessionId, message []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counter := id.counter()
+	if s.highest >= s.window && counter <= s.highest-s.window {
+		return ErrSessionIdReuse
+	}
+	if _, ok := s.seen[counter]; ok {
+		return ErrSessionIdReuse
+	}
+	s.seen[counter] = struct{}{}
+	if counter > s.highest {
+		s.highest = counter
+	}
+	if uint64(len(s.seen)) > 2*s.window {
+		for c := range s.seen {
+			if c <= s.highest-s.window {
+				delete(s.seen, c)
+			}
+		}
+	}
+
+	bob := sign.NewBob(s.curve, s.newHash(), s.dkgOutput)
+	bob.BindSessionId(id[:])
+	s.sessions[id] = &signSession{iterator: newBobSign(bob, message, s.version)}
+	return nil
+}
+
+// Handle routes a message from Alice to its accepted session and returns Bob's reply, or nil once Bob has verified
+// the signature. A session that fails is closed and cannot be resumed.
+func (s *BobSignSessions) Handle(input *protocol.Message) (*protocol.Message, error) {
+	id, err := SessionIdFromMessage(input)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	session, ok := s.sessions[id]
+	s.mu.Unlock()
+	if !ok {
+		return nil, ErrUnknownSession
+	}
+
+	session.Lock()
+	defer session.Unlock()
+	output, err := session.iterator.Next(input)
+	if err != nil {
+		s.remove(id)
+		return nil, err
+	}
+	return tagMessage(output, id), nil
+}
+
+// Result returns the signature produced by a completed session and closes it.
+func (s *BobSignSessions) Result(id SignSessionId) (*protocol.Message, error) {
+	s.mu.Lock()
+	session, ok := s.sessions[id]
+	s.mu.Unlock()
+	if !ok {
+		return nil, ErrUnknownSession
+	}
+
+	session.Lock()
+	defer session.Unlock()
+	if !session.iterator.Complete() {
+		return nil, fmt.Errorf("sign session %s has not completed", id)
+	}
+	s.remove(id)
+	result, err := session.iterator.Result(s.version)
+	if err != nil {
+		return nil, err
+	}
+	return tagMessage(result, id), nil
+}
+
+// Abort closes a session without completing it. Its id stays used.
+func (s *BobSignSessions) Abort(id SignSessionId) {
+	s.remove(id)
+}
+
+// Active returns the number of accepted sessions that have not been closed.
+func (s *BobSignSessions) Active() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.sessions)
+}
+
+func (s *BobSignSessions) remove(id SignSessionId) {
+	s.mu.Lock()
+	delete(s.sessions, id)
+	s.mu.Unlock()
+}
@@ -0,0 +1,234 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package v1
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
+	"github.com/TEENet-io/kryptology/pkg/core/protocol"
+)
+
+func dkgResultMessages(t *testing.T, curve *curves.Curve) (*protocol.Message, *protocol.Message) {
+	t.Helper()
+	aliceDkg := NewAliceDkg(curve, protocol.Version1)
+	bobDkg := NewBobDkg(curve, protocol.Version1)
+	aErr, bErr := runIteratedProtocol(bobDkg, aliceDkg)
+	require.ErrorIs(t, aErr, protocol.ErrProtocolFinished)
+	require.ErrorIs(t, bErr, protocol.ErrProtocolFinished)
+	aliceResult, err := aliceDkg.Result(protocol.Version1)
+	require.NoError(t, err)
+	bobResult, err := bobDkg.Result(protocol.Version1)
+	require.NoError(t, err)
+	return aliceResult, bobResult
+}
+
+func verifySignatureMessage(t *testing.T, curve *curves.Curve, publicKey curves.Point, msg []byte, signatureMessage *protocol.Message) bool {
+	t.Helper()
+	signature, err := DecodeSignature(signatureMessage)
+	require.NoError(t, err)
+	digest := sha3.Sum256(msg)
+	uncompressed := publicKey.ToAffineUncompressed()
+	ecCurve, err := curve.ToEllipticCurve()
+	require.NoError(t, err)
+	return curves.VerifyEcdsa(&curves.EcPoint{
+		Curve: ecCurve,
+		X:     new(big.Int).SetBytes(uncompressed[1:33]),
+		Y:     new(big.Int).SetBytes(uncompressed[33:]),
+	}, digest[:], signature)
+}
+
+// envelope is what the test transport carries from Alice to Bob: the first message of a session also announces the
+// message to sign.
+type envelope struct {
+	message *protocol.Message
+	toSign  []byte
+}
+
+func TestConcurrentSignSessions(t *testing.T) {
+	curve := curves.K256()
+	aliceDkgResult, bobDkgResult := dkgResultMessages(t, curve)
+	publicKey, err := DecodeAliceDkgResult(aliceDkgResult)
+	require.NoError(t, err)
+
+	aliceSessions, err := NewAliceSignSessions(curve, sha3.New256, aliceDkgResult, protocol.Version1)
+	require.NoError(t, err)
+	bobSessions, err := NewBobSignSessions(curve, sha3.New256, bobDkgResult, protocol.Version1)
+	require.NoError(t, err)
+
+	const sessions = 50
+	toBob := make(chan envelope)
+	toAlice := make(chan *protocol.Message)
+	signatures := make(chan *protocol.Message, sessions)
+	errs := make(chan error, 4*sessions)
+
+	// Each party reads one multiplexed channel and handles every message on its own goroutine, so rounds of
+	// different sessions interleave arbitrarily.
+	go func() {
+		for e := range toBob {
+			go func(e envelope) {
+				id, err := SessionIdFromMessage(e.message)
+				if err != nil {
+					errs <- err
+					return
+				}
+				if e.toSign != nil {
+					if err := bobSessions.Accept(id, e.toSign); err != nil {
+						errs <- err
+						return
+					}
+				}
+				reply, err := bobSessions.Handle(e.message)
+				if err != nil {
+					errs <- err
+					return
+				}
+				if reply != nil {
+					toAlice <- reply
+					return
+				}
+				signature, err := bobSessions.Result(id)
+				if err != nil {
+					errs <- err
+					return
+				}
+				signatures <- signature
+			}(e)
+		}
+	}()
+	go func() {
+		for m := range toAlice {
+			go func(m *protocol.Message) {
+				reply, err := aliceSessions.Handle(m)
+				if err != nil {
+					errs <- err
+					return
+				}
+				toBob <- envelope{message: reply}
+			}(m)
+		}
+	}()
+
+	messages := map[SignSessionId][]byte{}
+	var mu sync.Mutex
+	var starts sync.WaitGroup
+	for i := 0; i < sessions; i++ {
+		starts.Add(1)
+		go func(i int) {
+			defer starts.Done()
+			msg := []byte(fmt.Sprintf("transaction %d", i))
+			id, first, err := aliceSessions.Start(msg)
+			if err != nil {
+				errs <- err
+				return
+			}
+			mu.Lock()
+			messages[id] = msg
+			mu.Unlock()
+			toBob <- envelope{message: first, toSign: msg}
+		}(i)
+	}
+	starts.Wait()
+
+	for i := 0; i < sessions; i++ {
+		select {
+		case err := <-errs:
+			require.NoError(t, err)
+		case signature := <-signatures:
+			id, err := SessionIdFromMessage(signature)
+			require.NoError(t, err)
+			require.True(t, verifySignatureMessage(t, curve, publicKey.PublicKey, messages[id], signature))
+		}
+	}
+	require.Len(t, messages, sessions)
+	require.Zero(t, aliceSessions.Active())
+	require.Zero(t, bobSessions.Active())
+}
+
+func TestSignSessionIdReuse(t *testing.T) {
+	curve := curves.K256()
+	aliceDkgResult, bobDkgResult := dkgResultMessages(t, curve)
+	aliceSessions, err := NewAliceSignSessions(curve, sha3.New256, aliceDkgResult, protocol.Version1)
+	require.NoError(t, err)
+	bobSessions, err := NewBobSignSessions(curve, sha3.New256, bobDkgResult, protocol.Version1)
+	require.NoError(t, err)
+
+	msg := []byte("a message")
+	id, first, err := aliceSessions.Start(msg)
+	require.NoError(t, err)
+	require.NoError(t, bobSessions.Accept(id, msg))
+	require.ErrorIs(t, bobSessions.Accept(id, msg), ErrSessionIdReuse)
+
+	// A completed session cannot be accepted again either.
+	reply, err := bobSessions.Handle(first)
+	require.NoError(t, err)
+	reply, err = aliceSessions.Handle(reply)
+	require.NoError(t, err)
+	_, err = aliceSessions.Handle(reply)
+	require.ErrorIs(t, err, ErrUnknownSession)
+	reply, err = bobSessions.Handle(reply)
+	require.NoError(t, err)
+	require.Nil(t, reply)
+	_, err = bobSessions.Result(id)
+	require.NoError(t, err)
+	require.ErrorIs(t, bobSessions.Accept(id, msg), ErrSessionIdReuse)
+
+	// Counters that fall out of the replay window are refused.
+	old := id
+	old[0] ^= 0x80
+	newer := id
+	newer[0] |= 0x80
+	if old.counter() > newer.counter() {
+		old, newer = newer, old
+	}
+	require.NoError(t, bobSessions.Accept(newer, msg))
+	require.ErrorIs(t, bobSessions.Accept(old, msg), ErrSessionIdReuse)
+}
+
+func TestSignSessionUnknown(t *testing.T) {
+	curve := curves.K256()
+	aliceDkgResult, bobDkgResult := dkgResultMessages(t, curve)
+	aliceSessions, err := NewAliceSignSessions(curve, sha3.New256, aliceDkgResult, protocol.Version1)
+	require.NoError(t, err)
+	bobSessions, err := NewBobSignSessions(curve, sha3.New256, bobDkgResult, protocol.Version1)
+	require.NoError(t, err)
+
+	// Bob handles nothing he has not accepted.
+	_, first, err := aliceSessions.Start([]byte("a message"))
+	require.NoError(t, err)
+	_, err = bobSessions.Handle(first)
+	require.ErrorIs(t, err, ErrUnknownSession)
+
+	// Untagged messages are refused.
+	_, err = bobSessions.Handle(&protocol.Message{Version: protocol.Version1})
+	require.Error(t, err)
+
+	// A session bound to one id does not complete with the transcript of another.
+	msg := []byte("a message")
+	id1, first1, err := aliceSessions.Start(msg)
+	require.NoError(t, err)
+	id2, _, err := aliceSessions.Start(msg)
+	require.NoError(t, err)
+	require.NotEqual(t, id1, id2)
+	require.NoError(t, bobSessions.Accept(id2, msg))
+	first1.Metadata[SessionIdMetadataKey] = id2.String()
+	reply, err := bobSessions.Handle(first1)
+	require.NoError(t, err)
+	reply.Metadata[SessionIdMetadataKey] = id1.String()
+	reply, err = aliceSessions.Handle(reply)
+	if err == nil {
+		reply.Metadata[SessionIdMetadataKey] = id2.String()
+		_, err = bobSessions.Handle(reply)
+	}
+	require.Error(t, err)
+}
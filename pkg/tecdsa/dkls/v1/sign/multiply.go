@@ -64,7 +64,7 @@ func generateGadgetVector(curve *curves.Curve) ([kos.L]curves.Scalar, error) {
 		if _, err = shake.Read(bytes[:]); err != nil {
 			return gadget, err
 		}
-		gadget[i], err = curve.Scalar.SetBytes(bytes[:])
+		gadget[i], err = curve.Scalar.SetBytesReduce(bytes[:])
 		if err != nil {
 			return gadget, errors.Wrap(err, "creating gadget scalar from bytes")
 		}
@@ -204,7 +204,7 @@ func (sender *MultiplySender) Round2Multiply(alpha curves.Scalar, round1Output *
 	for k := 0; k < 2; k++ {
 		label := []byte(fmt.Sprintf("draw challenge chi %d", k))
 		randomBytes := sender.transcript.ExtractBytes(label, kos.KappaBytes)
-		chi[k], err = sender.curve.Scalar.SetBytes(randomBytes)
+		chi[k], err = sender.curve.Scalar.SetBytesReduce(randomBytes)
 		if err != nil {
 			return nil, errors.Wrap(err, "setting chi scalar from bytes")
 		}
@@ -241,7 +241,7 @@ func (receiver *MultiplyReceiver) Round3Multiply(round2Output *MultiplyRound2Out
 	for k := 0; k < chiWidth; k++ {
 		label := []byte(fmt.Sprintf("draw challenge chi %d", k))
 		randomBytes := receiver.transcript.ExtractBytes(label, kos.KappaBytes)
-		chi[k], err = receiver.curve.Scalar.SetBytes(randomBytes)
+		chi[k], err = receiver.curve.Scalar.SetBytesReduce(randomBytes)
 		if err != nil {
 			return errors.Wrap(err, "setting chi scalar from bytes")
 		}
@@ -36,6 +36,7 @@ type Alice struct {
 	seedOtResults  *simplest.ReceiverOutput
 	secretKeyShare curves.Scalar // the witness
 	publicKey      curves.Point
+	tweak          curves.Scalar // additive tweak of a derived child key; zero when signing with publicKey itself
 	curve          *curves.Curve
 	transcript     *merlin.Transcript
 }
@@ -50,6 +51,7 @@ type Bob struct {
 	seedOtResults  *simplest.SenderOutput
 	secretKeyShare curves.Scalar
 	publicKey      curves.Point
+	tweak          curves.Scalar // additive tweak of a derived child key; zero when signing with publicKey itself
 	transcript     *merlin.Transcript
 	// multiplyReceivers are 2 receivers that are used to perform the two multiplications needed:
 	// 1. (phi + 1/kA) * (1/kB)
@@ -62,12 +64,21 @@ type Bob struct {
 
 // NewAlice creates a party that can participate in protocol runs of DKLs sign, in the role of Alice.
 func NewAlice(curve *curves.Curve, hash hash.Hash, dkgOutput *dkg.AliceOutput) *Alice {
+	return NewDerivedAlice(curve, hash, dkgOutput, curve.Scalar.Zero())
+}
+
+// NewDerivedAlice creates Alice for signing with the derived child key `PublicKey + tweak·G`, e.g. a BIP-32 child
+// key whose tweak was returned by `bip32.ExtendedKey.DerivePath`. Both parties must use the same tweak.
+// Since the joint secret key is skA·skB, the tweak cannot be added to a single share. Instead, both parties fold
+// r·tweak into the digest term of their signature shares, s = (h + r·(sk + tweak)) / k.
+func NewDerivedAlice(curve *curves.Curve, hash hash.Hash, dkgOutput *dkg.AliceOutput, tweak curves.Scalar) *Alice {
 	return &Alice{
 		hash:           hash,
 		seedOtResults:  dkgOutput.SeedOtResult,
 		curve:          curve,
 		secretKeyShare: dkgOutput.SecretKeyShare,
 		publicKey:      dkgOutput.PublicKey,
+		tweak:          tweak,
 		transcript:     merlin.NewTranscript("Coinbase_DKLs_Sign"),
 	}
 }
@@ -75,16 +86,35 @@ func NewAlice(curve *curves.Curve, hash hash.Hash, dkgOutput *dkg.AliceOutput) *
 // NewBob creates a party that can participate in protocol runs of DKLs sign, in the role of Bob.
 // This party receives the signature at the end.
 func NewBob(curve *curves.Curve, hash hash.Hash, dkgOutput *dkg.BobOutput) *Bob {
+	return NewDerivedBob(curve, hash, dkgOutput, curve.Scalar.Zero())
+}
+
+// NewDerivedBob creates Bob for signing with the derived child key `PublicKey + tweak·G`. The signature Bob obtains
+// verifies under the child key. See NewDerivedAlice.
+func NewDerivedBob(curve *curves.Curve, hash hash.Hash, dkgOutput *dkg.BobOutput, tweak curves.Scalar) *Bob {
 	return &Bob{
 		hash:           hash,
 		seedOtResults:  dkgOutput.SeedOtResult,
 		curve:          curve,
 		secretKeyShare: dkgOutput.SecretKeyShare,
 		publicKey:      dkgOutput.PublicKey,
+		tweak:          tweak,
 		transcript:     merlin.NewTranscript("Coinbase_DKLs_Sign"),
 	}
 }
 
+// BindSessionId appends an externally agreed session identifier to Alice's transcript, so that every sub-session id
+// derived during this run (the OT extension and schnorr proof salts) is bound to it. If used, it must be called with
+// the same value by both parties before the first round.
+func (alice *Alice) BindSessionId(sessionId []byte) {
+	alice.transcript.AppendMessage([]byte("session_id"), sessionId)
+}
+
+// BindSessionId appends an externally agreed session identifier to Bob's transcript. See Alice.BindSessionId.
+func (bob *Bob) BindSessionId(sessionId []byte) {
+	bob.transcript.AppendMessage([]byte("session_id"), sessionId)
+}
+
 // SignRound2Output is the output of the 3rd round of the protocol.
 type SignRound2Output struct {
 	// KosRound1Outputs is the output of the first round of OT Extension, stored for future rounds.
@@ -199,7 +229,7 @@ func (alice *Alice) Round3Sign(message []byte, round2Output *SignRound2Output) (
 	kPrimeA := alice.curve.Scalar.Random(rand.Reader)
 	round3Output.RPrime = round2Output.DB.Mul(kPrimeA)
 	hashRPrimeBytes := sha3.Sum256(round3Output.RPrime.ToAffineCompressed())
-	hashRPrime, err := alice.curve.Scalar.SetBytes(hashRPrimeBytes[:])
+	hashRPrime, err := alice.curve.Scalar.SetBytesReduce(hashRPrimeBytes[:])
 	if err != nil {
 		return nil, errors.Wrap(err, "setting hashRPrime scalar from bytes")
 	}
@@ -228,7 +258,7 @@ func (alice *Alice) Round3Sign(message []byte, round2Output *SignRound2Output) (
 	other := r.Mul(multiplySenders[0].outputAdditiveShare.Neg())
 	gamma1 = gamma1.Add(other)
 	hashGamma1Bytes := sha3.Sum256(gamma1.ToAffineCompressed())
-	hashGamma1, err := alice.curve.Scalar.SetBytes(hashGamma1Bytes[:])
+	hashGamma1, err := alice.curve.Scalar.SetBytesReduce(hashGamma1Bytes[:])
 	if err != nil {
 		return nil, errors.Wrap(err, "setting hashGamma1 scalar from bytes")
 	}
@@ -237,7 +267,12 @@ func (alice *Alice) Round3Sign(message []byte, round2Output *SignRound2Output) (
 		return nil, errors.Wrap(err, "writing message to hash in alice round 4 sign")
 	}
 	digest := alice.hash.Sum(nil)
-	hOfMAsInteger, err := alice.curve.Scalar.SetBytes(digest)
+	// ECDSA takes the leftmost bits of the digest that fit the order, which are whole bytes for 256-bit curves,
+	// and reduces them modulo the order (SEC1 4.1.3 step 5).
+	if width := len(alice.curve.Scalar.Zero().Bytes()); len(digest) > width {
+		digest = digest[:width]
+	}
+	hOfMAsInteger, err := alice.curve.Scalar.SetBytesReduce(digest)
 	if err != nil {
 		return nil, errors.Wrap(err, "setting hOfMAsInteger scalar from bytes")
 	}
@@ -245,18 +280,20 @@ func (alice *Alice) Round3Sign(message []byte, round2Output *SignRound2Output) (
 	if len(affineCompressedForm) != 33 {
 		return nil, errors.New("the compressed form must be exactly 33 bytes")
 	}
-	// Discard the leading byte and parse the rest as the X coordinate.
-	rX, err := alice.curve.Scalar.SetBytes(affineCompressedForm[1:])
+	// Discard the leading byte and reduce the X coordinate modulo the order.
+	rX, err := alice.curve.Scalar.SetBytesReduce(affineCompressedForm[1:])
 	if err != nil {
 		return nil, errors.Wrap(err, "setting rX scalar from bytes")
 	}
 
+	// for a derived key, h + r·tweak takes the place of h; see NewDerivedAlice.
+	hOfMAsInteger = hOfMAsInteger.Add(rX.Mul(alice.tweak))
 	sigA := hOfMAsInteger.Mul(multiplySenders[0].outputAdditiveShare).Add(rX.Mul(multiplySenders[1].outputAdditiveShare))
 	gamma2 := alice.publicKey.Mul(multiplySenders[0].outputAdditiveShare)
 	other = alice.curve.ScalarBaseMult(multiplySenders[1].outputAdditiveShare.Neg())
 	gamma2 = gamma2.Add(other)
 	hashGamma2Bytes := sha3.Sum256(gamma2.ToAffineCompressed())
-	hashGamma2, err := alice.curve.Scalar.SetBytes(hashGamma2Bytes[:])
+	hashGamma2, err := alice.curve.Scalar.SetBytesReduce(hashGamma2Bytes[:])
 	if err != nil {
 		return nil, errors.Wrap(err, "setting hashGamma2 scalar from bytes")
 	}
@@ -277,7 +314,7 @@ func (bob *Bob) Round4Final(message []byte, round3Output *SignRound3Output) erro
 		return errors.Wrap(err, "error in round 3 multiply 1 within sign round 5")
 	}
 	rPrimeHashedBytes := sha3.Sum256(round3Output.RPrime.ToAffineCompressed())
-	rPrimeHashed, err := bob.curve.Scalar.SetBytes(rPrimeHashedBytes[:])
+	rPrimeHashed, err := bob.curve.Scalar.SetBytesReduce(rPrimeHashedBytes[:])
 	if err != nil {
 		return errors.Wrap(err, "setting rPrimeHashed scalar from bytes")
 	}
@@ -291,23 +328,23 @@ func (bob *Bob) Round4Final(message []byte, round3Output *SignRound3Output) erro
 	if err = schnorr.Verify(round3Output.RSchnorrProof, bob.curve, bob.dB, uniqueSessionId[:]); err != nil {
 		return errors.Wrap(err, "bob's verification of alice's schnorr proof re: r failed")
 	}
-	zero := bob.curve.Scalar.Zero()
 	affineCompressedForm := r.ToAffineCompressed()
 	if len(affineCompressedForm) != 33 {
 		return errors.New("the compressed form must be exactly 33 bytes")
 	}
 	rY := affineCompressedForm[0] & 0x1 // this is bit(0) of Y coordinate
-	rX, err := bob.curve.Scalar.SetBytes(affineCompressedForm[1:])
+	// The X coordinate is reduced modulo the order, as r is in ECDSA.
+	rX, err := bob.curve.Scalar.SetBytesReduce(affineCompressedForm[1:])
 	if err != nil {
 		return errors.Wrap(err, "setting rX scalar from bytes")
 	}
 	bob.Signature = &curves.EcdsaSignature{
-		R: rX.Add(zero).BigInt(), // slight trick here; add it to 0 just to mod it by q (now it's mod p!)
+		R: rX.BigInt(),
 		V: int(rY),
 	}
 	gamma1 := r.Mul(bob.multiplyReceivers[0].outputAdditiveShare)
 	gamma1HashedBytes := sha3.Sum256(gamma1.ToAffineCompressed())
-	gamma1Hashed, err := bob.curve.Scalar.SetBytes(gamma1HashedBytes[:])
+	gamma1Hashed, err := bob.curve.Scalar.SetBytesReduce(gamma1HashedBytes[:])
 	if err != nil {
 		return errors.Wrap(err, "setting gamma1Hashed scalar from bytes")
 	}
@@ -317,7 +354,12 @@ func (bob *Bob) Round4Final(message []byte, round3Output *SignRound3Output) erro
 		return errors.Wrap(err, "writing message to hash in Bob sign round 5 final")
 	}
 	digestBytes := bob.hash.Sum(nil)
-	digest, err := bob.curve.Scalar.SetBytes(digestBytes)
+	// As in Alice's round, the digest is truncated to the width of the order and reduced (SEC1 4.1.3 step 5).
+	truncated := digestBytes
+	if width := len(bob.curve.Scalar.Zero().Bytes()); len(truncated) > width {
+		truncated = truncated[:width]
+	}
+	digest, err := bob.curve.Scalar.SetBytesReduce(truncated)
 	if err != nil {
 		return errors.Wrap(err, "setting digest scalar from bytes")
 	}
@@ -325,12 +367,14 @@ func (bob *Bob) Round4Final(message []byte, round3Output *SignRound3Output) erro
 	if err != nil {
 		return errors.Wrap(err, "setting capitalR scalar from big int")
 	}
+	// for a derived key, h + r·tweak takes the place of h; see NewDerivedAlice.
+	digest = digest.Add(capitalR.Mul(bob.tweak))
 	sigB := digest.Mul(theta).Add(capitalR.Mul(bob.multiplyReceivers[1].outputAdditiveShare))
 	gamma2 := bob.curve.ScalarBaseMult(bob.multiplyReceivers[1].outputAdditiveShare)
 	other := bob.publicKey.Mul(theta.Neg())
 	gamma2 = gamma2.Add(other)
 	gamma2HashedBytes := sha3.Sum256(gamma2.ToAffineCompressed())
-	gamma2Hashed, err := bob.curve.Scalar.SetBytes(gamma2HashedBytes[:])
+	gamma2Hashed, err := bob.curve.Scalar.SetBytesReduce(gamma2HashedBytes[:])
 	if err != nil {
 		return errors.Wrap(err, "setting gamma2Hashed scalar from bytes")
 	}
@@ -340,8 +384,8 @@ func (bob *Bob) Round4Final(message []byte, round3Output *SignRound3Output) erro
 		bob.Signature.S = scalarS.Neg().BigInt()
 		bob.Signature.V ^= 1
 	}
-	// now verify the signature
-	unCompressedAffinePublicKey := bob.publicKey.ToAffineUncompressed()
+	// now verify the signature under the (possibly derived) public key
+	unCompressedAffinePublicKey := bob.publicKey.Add(bob.curve.ScalarBaseMult(bob.tweak)).ToAffineUncompressed()
 	if len(unCompressedAffinePublicKey) != 65 {
 		return errors.New("the uncompressed form must have exactly 65 bytes")
 	}
@@ -7,12 +7,15 @@
 package sign
 
 import (
+	"crypto/ecdsa"
 	"crypto/rand"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/sha3"
 
+	"github.com/TEENet-io/kryptology/pkg/bip32"
 	"github.com/TEENet-io/kryptology/pkg/core/curves"
 	"github.com/TEENet-io/kryptology/pkg/ot/base/simplest"
 	"github.com/TEENet-io/kryptology/pkg/ot/extension/kos"
@@ -52,6 +55,56 @@ func TestSign(t *testing.T) {
 	}
 }
 
+func TestSignDerivedKey(t *testing.T) {
+	curveInstances := []*curves.Curve{
+		curves.K256(),
+		curves.P256(),
+	}
+	for _, curve := range curveInstances {
+		hashKeySeed := [simplest.DigestSize]byte{}
+		_, err := rand.Read(hashKeySeed[:])
+		require.NoError(t, err)
+
+		baseOtSenderOutput, baseOtReceiverOutput, err := ottest.RunSimplestOT(curve, kos.Kappa, hashKeySeed)
+		require.NoError(t, err)
+
+		secretKeyShareA := curve.Scalar.Random(rand.Reader)
+		secretKeyShareB := curve.Scalar.Random(rand.Reader)
+		publicKey := curve.ScalarBaseMult(secretKeyShareA.Mul(secretKeyShareB))
+		chainCode := [simplest.DigestSize]byte{}
+		_, err = rand.Read(chainCode[:])
+		require.NoError(t, err)
+
+		master, err := bip32.NewMasterKey(curve, publicKey, chainCode)
+		require.NoError(t, err)
+		child, tweak, err := master.DerivePath([]uint32{0, 7})
+		require.NoError(t, err)
+
+		alice := NewDerivedAlice(curve, sha3.New256(), &dkg.AliceOutput{SeedOtResult: baseOtReceiverOutput, SecretKeyShare: secretKeyShareA, PublicKey: publicKey}, tweak)
+		bob := NewDerivedBob(curve, sha3.New256(), &dkg.BobOutput{SeedOtResult: baseOtSenderOutput, SecretKeyShare: secretKeyShareB, PublicKey: publicKey}, tweak)
+
+		message := []byte("A message.")
+		seed, err := alice.Round1GenerateRandomSeed()
+		require.NoError(t, err)
+		round3Output, err := bob.Round2Initialize(seed)
+		require.NoError(t, err)
+		round4Output, err := alice.Round3Sign(message, round3Output)
+		require.NoError(t, err)
+		err = bob.Round4Final(message, round4Output)
+		require.NoError(t, err, "curve: %s", curve.Name)
+
+		digest := sha3.Sum256(message)
+		ellipticCurve, err := curve.ToEllipticCurve()
+		require.NoError(t, err)
+		childKey := child.PublicKey.ToAffineUncompressed()
+		require.True(t, ecdsa.Verify(&ecdsa.PublicKey{
+			Curve: ellipticCurve,
+			X:     new(big.Int).SetBytes(childKey[1:33]),
+			Y:     new(big.Int).SetBytes(childKey[33:]),
+		}, digest[:], bob.Signature.R, bob.Signature.S))
+	}
+}
+
 func BenchmarkSign(b *testing.B) {
 	curve := curves.K256()
 	hashKeySeed := [simplest.DigestSize]byte{}
@@ -1,11 +1,6 @@
 package v1
 
 import (
-	"bytes"
-	"encoding/gob"
-
-	"github.com/pkg/errors"
-
 	"github.com/TEENet-io/kryptology/pkg/core/curves"
 	"github.com/TEENet-io/kryptology/pkg/core/protocol"
 	"github.com/TEENet-io/kryptology/pkg/tecdsa/dkls/v1/sign"
@@ -21,102 +16,82 @@ func newSignProtocolMessage(payload []byte, round string, version uint) *protoco
 }
 
 func encodeSignRound1Output(commitment [32]byte, version uint) (*protocol.Message, error) {
-	if version != protocol.Version1 {
-		return nil, errors.New("only version 1 is supported")
-	}
-	buf := bytes.NewBuffer([]byte{})
-	enc := gob.NewEncoder(buf)
-	if err := enc.Encode(&commitment); err != nil {
-		return nil, errors.WithStack(err)
+	payload, err := encodePayload(version, &commitment, func(w *curves.Encoder) {
+		w.WriteFixed(commitment[:])
+	})
+	if err != nil {
+		return nil, err
 	}
-	return newSignProtocolMessage(buf.Bytes(), "1", version), nil
+	return newSignProtocolMessage(payload, "1", version), nil
 }
 
 func decodeSignRound2Input(m *protocol.Message) ([32]byte, error) {
-	if m.Version != protocol.Version1 {
-		return [32]byte{}, errors.New("only version 1 is supported")
-	}
-	buf := bytes.NewBuffer(m.Payloads[payloadKey])
-	dec := gob.NewDecoder(buf)
 	decoded := [32]byte{}
-	if err := dec.Decode(&decoded); err != nil {
-		return [32]byte{}, errors.WithStack(err)
+	if err := decodePayload(m, &decoded, func(r *curves.Decoder) {
+		r.ReadFixed(decoded[:])
+	}); err != nil {
+		return [32]byte{}, err
 	}
 	return decoded, nil
 }
 
 func encodeSignRound2Output(output *sign.SignRound2Output, version uint) (*protocol.Message, error) {
-	if version != protocol.Version1 {
-		return nil, errors.New("only version 1 is supported")
-	}
-	buf := bytes.NewBuffer([]byte{})
-	enc := gob.NewEncoder(buf)
-	if err := enc.Encode(output); err != nil {
-		return nil, errors.WithStack(err)
+	payload, err := encodePayload(version, output, func(w *curves.Encoder) {
+		writeSignRound2Output(w, output)
+	})
+	if err != nil {
+		return nil, err
 	}
-	return newSignProtocolMessage(buf.Bytes(), "2", version), nil
+	return newSignProtocolMessage(payload, "2", version), nil
 }
 
 func decodeSignRound3Input(m *protocol.Message) (*sign.SignRound2Output, error) {
-	if m.Version != protocol.Version1 {
-		return nil, errors.New("only version 1 is supported")
-	}
-	buf := bytes.NewBuffer(m.Payloads[payloadKey])
-	dec := gob.NewDecoder(buf)
 	decoded := &sign.SignRound2Output{}
-	if err := dec.Decode(&decoded); err != nil {
-		return nil, errors.WithStack(err)
+	if err := decodePayload(m, decoded, func(r *curves.Decoder) {
+		*decoded = *readSignRound2Output(r)
+	}); err != nil {
+		return nil, err
 	}
 	return decoded, nil
 }
 
 func encodeSignRound3Output(output *sign.SignRound3Output, version uint) (*protocol.Message, error) {
-	if version != protocol.Version1 {
-		return nil, errors.New("only version 1 is supported")
-	}
-	buf := bytes.NewBuffer([]byte{})
-	enc := gob.NewEncoder(buf)
-	if err := enc.Encode(output); err != nil {
-		return nil, errors.WithStack(err)
+	payload, err := encodePayload(version, output, func(w *curves.Encoder) {
+		writeSignRound3Output(w, output)
+	})
+	if err != nil {
+		return nil, err
 	}
-	return newSignProtocolMessage(buf.Bytes(), "3", version), nil
+	return newSignProtocolMessage(payload, "3", version), nil
 }
 
 func decodeSignRound4Input(m *protocol.Message) (*sign.SignRound3Output, error) {
-	if m.Version != protocol.Version1 {
-		return nil, errors.New("only version 1 is supported")
-	}
-	buf := bytes.NewBuffer(m.Payloads[payloadKey])
-	dec := gob.NewDecoder(buf)
 	decoded := &sign.SignRound3Output{}
-	if err := dec.Decode(&decoded); err != nil {
-		return nil, errors.WithStack(err)
+	if err := decodePayload(m, decoded, func(r *curves.Decoder) {
+		*decoded = *readSignRound3Output(r)
+	}); err != nil {
+		return nil, err
 	}
 	return decoded, nil
 }
 
 func encodeSignature(signature *curves.EcdsaSignature, version uint) (*protocol.Message, error) {
-	if version != protocol.Version1 {
-		return nil, errors.New("only version 1 is supported")
+	payload, err := encodePayload(version, signature, func(w *curves.Encoder) {
+		writeSignature(w, signature)
+	})
+	if err != nil {
+		return nil, err
 	}
-	buf := bytes.NewBuffer([]byte{})
-	enc := gob.NewEncoder(buf)
-	if err := enc.Encode(signature); err != nil {
-		return nil, errors.WithStack(err)
-	}
-	return newSignProtocolMessage(buf.Bytes(), "signature", version), nil
+	return newSignProtocolMessage(payload, "signature", version), nil
 }
 
 // DecodeSignature serializes the signature.
 func DecodeSignature(m *protocol.Message) (*curves.EcdsaSignature, error) {
-	if m.Version != protocol.Version1 {
-		return nil, errors.New("only version 1 is supported")
-	}
-	buf := bytes.NewBuffer(m.Payloads[payloadKey])
-	dec := gob.NewDecoder(buf)
 	decoded := &curves.EcdsaSignature{}
-	if err := dec.Decode(decoded); err != nil {
-		return nil, errors.WithStack(err)
+	if err := decodePayload(m, decoded, func(r *curves.Decoder) {
+		*decoded = *readSignature(r)
+	}); err != nil {
+		return nil, err
 	}
 	return decoded, nil
 }
@@ -8,15 +8,16 @@
 package dealer
 
 import (
-	"crypto/elliptic"
+	crand "crypto/rand"
 	"encoding/json"
 	"fmt"
 	"math/big"
 
+	"github.com/TEENet-io/kryptology/internal"
 	"github.com/TEENet-io/kryptology/pkg/core"
 	"github.com/TEENet-io/kryptology/pkg/core/curves"
 	"github.com/TEENet-io/kryptology/pkg/paillier"
-	v1 "github.com/TEENet-io/kryptology/pkg/sharing/v1"
+	"github.com/TEENet-io/kryptology/pkg/sharing"
 )
 
 // ParticipantData represents all data to be sent to a participant
@@ -26,7 +27,7 @@ type ParticipantData struct {
 	DecryptKey     *paillier.SecretKey
 	SecretKeyShare *Share
 	// Public values set to all signing participants
-	EcdsaPublicKey *curves.EcPoint
+	EcdsaPublicKey curves.Point
 	KeyGenType     KeyGenType
 	PublicShares   map[uint32]*PublicShare
 	EncryptKeys    map[uint32]*paillier.PublicKey
@@ -45,11 +46,18 @@ type ParticipantDataJson struct {
 }
 
 func (pd ParticipantData) MarshalJSON() ([]byte, error) {
+	if pd.EcdsaPublicKey == nil || pd.KeyGenType == nil {
+		return nil, internal.ErrNilArguments
+	}
+	pk, err := curves.NewEcPoint(pd.EcdsaPublicKey)
+	if err != nil {
+		return nil, err
+	}
 	data := ParticipantDataJson{
 		Id:             pd.Id,
 		DecryptKey:     pd.DecryptKey,
 		SecretKeyShare: pd.SecretKeyShare,
-		EcdsaPublicKey: pd.EcdsaPublicKey,
+		EcdsaPublicKey: pk,
 		PublicShares:   pd.PublicShares,
 		EncryptKeys:    pd.EncryptKeys,
 	}
@@ -66,6 +74,13 @@ func (pd *ParticipantData) UnmarshalJSON(bytes []byte) error {
 	if err := json.Unmarshal(bytes, data); err != nil {
 		return err
 	}
+	if data.EcdsaPublicKey == nil {
+		return fmt.Errorf("missing ecdsa public key")
+	}
+	pk, err := data.EcdsaPublicKey.ToPoint()
+	if err != nil {
+		return err
+	}
 	if data.DealerParams != nil {
 		pd.KeyGenType = TrustedDealerKeyGenType{
 			ProofParams: data.DealerParams,
@@ -80,7 +95,7 @@ func (pd *ParticipantData) UnmarshalJSON(bytes []byte) error {
 	pd.DecryptKey = data.DecryptKey
 	pd.SecretKeyShare = data.SecretKeyShare
 	pd.PublicShares = data.PublicShares
-	pd.EcdsaPublicKey = data.EcdsaPublicKey
+	pd.EcdsaPublicKey = pk
 	return nil
 }
 
@@ -95,15 +110,50 @@ type ProofParams struct {
 	H2 *big.Int
 }
 
+// RingPedersen returns the parameters as Ring-Pedersen parameters, with t = H1 and s = H2
+func (pp ProofParams) RingPedersen() *paillier.RingPedersenParams {
+	return &paillier.RingPedersenParams{N: pp.N, S: pp.H2, T: pp.H1}
+}
+
 // PublicShare can be sent to a Participant so it can be used to convert Share to its additive form
 type PublicShare struct {
+	Point curves.Point
+}
+
+// publicShareJson encapsulates the data that is serialized to JSON
+type publicShareJson struct {
 	Point *curves.EcPoint
 }
 
+func (ps PublicShare) MarshalJSON() ([]byte, error) {
+	point, err := curves.NewEcPoint(ps.Point)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(publicShareJson{point})
+}
+
+func (ps *PublicShare) UnmarshalJSON(bytes []byte) error {
+	data := new(publicShareJson)
+	if err := json.Unmarshal(bytes, data); err != nil {
+		return err
+	}
+	if data.Point == nil {
+		return fmt.Errorf("missing public share point")
+	}
+	point, err := data.Point.ToPoint()
+	if err != nil {
+		return err
+	}
+	ps.Point = point
+	return nil
+}
+
 // Share represents a piece of the ECDSA private key and a commitment to the share
 type Share struct {
-	*v1.ShamirShare
-	Point *curves.EcPoint
+	Identifier uint32        // x-coordinate
+	Value      curves.Scalar // y-coordinate
+	Point      curves.Point  // Value·G
 }
 
 // ShareJson encapsulates the data that is serialized to JSON
@@ -116,10 +166,17 @@ type ShareJson struct {
 }
 
 func (s Share) MarshalJSON() ([]byte, error) {
+	if s.Value == nil || s.Point == nil {
+		return nil, internal.ErrNilArguments
+	}
+	point, err := curves.NewEcPoint(s.Point)
+	if err != nil {
+		return nil, err
+	}
 	return json.Marshal(ShareJson{
 		Identifier: s.Identifier,
 		Value:      s.Value.BigInt(),
-		Point:      s.Point,
+		Point:      point,
 	})
 }
 
@@ -129,11 +186,20 @@ func (s *Share) UnmarshalJSON(bytes []byte) error {
 	if err != nil {
 		return err
 	}
-	s.ShamirShare = new(v1.ShamirShare)
-	s.ShamirShare.Identifier = sh.Identifier
-	f := curves.NewField(sh.Point.Curve.Params().N)
-	s.ShamirShare.Value = f.NewElement(sh.Value)
-	s.Point = sh.Point
+	if sh.Value == nil || sh.Point == nil {
+		return fmt.Errorf("missing share value or point")
+	}
+	point, err := sh.Point.ToPoint()
+	if err != nil {
+		return err
+	}
+	value, err := curves.GetCurveByName(point.CurveName()).Scalar.SetBigInt(sh.Value)
+	if err != nil {
+		return err
+	}
+	s.Identifier = sh.Identifier
+	s.Value = value
+	s.Point = point
 	return nil
 }
 
@@ -171,18 +237,25 @@ func PreparePublicShares(sharesMap map[uint32]*Share) (map[uint32]*PublicShare,
 	return publicSharesMap, nil
 }
 
-// New Secret generates a new private key
-func NewSecret(curve elliptic.Curve) (*big.Int, error) {
-	return core.Rand(curve.Params().N)
+// NewSecret generates a new private key
+func NewSecret(curve *curves.Curve) (curves.Scalar, error) {
+	if curve == nil {
+		return nil, internal.ErrNilArguments
+	}
+	return curve.Scalar.Random(crand.Reader), nil
 }
 
-func DerivePublicKey(curve elliptic.Curve, secretKey *big.Int) (*curves.EcPoint, error) {
-	return curves.NewScalarBaseMult(curve, secretKey)
+// DerivePublicKey computes secretKey·G
+func DerivePublicKey(curve *curves.Curve, secretKey curves.Scalar) (curves.Point, error) {
+	if curve == nil || secretKey == nil {
+		return nil, internal.ErrNilArguments
+	}
+	return curve.ScalarBaseMult(secretKey), nil
 }
 
-// NewDealerShares generates the Secp256k1 private key shares and public key
-// if ikm == nil, a new private key will be generated
-func NewDealerShares(curve elliptic.Curve, threshold, total uint32, ikm *big.Int) (*curves.EcPoint, map[uint32]*Share, error) {
+// NewDealerShares generates the private key shares and public key for a 256-bit short Weierstrass curve,
+// i.e. curves.K256 or curves.P256. If ikm == nil, a new private key will be generated
+func NewDealerShares(curve *curves.Curve, threshold, total uint32, ikm curves.Scalar) (curves.Point, map[uint32]*Share, error) {
 	if total < threshold {
 		return nil, nil, fmt.Errorf("parts cannot be less than threshold")
 	}
@@ -195,8 +268,8 @@ func NewDealerShares(curve elliptic.Curve, threshold, total uint32, ikm *big.Int
 	if threshold > 255 {
 		return nil, nil, fmt.Errorf("threshold cannot exceed 255")
 	}
-	if curve.Params().BitSize != 256 {
-		return nil, nil, fmt.Errorf("invalid curve size")
+	if err := CheckCurve(curve); err != nil {
+		return nil, nil, err
 	}
 	var err error
 	if ikm == nil {
@@ -211,32 +284,48 @@ func NewDealerShares(curve elliptic.Curve, threshold, total uint32, ikm *big.Int
 		return nil, nil, err
 	}
 
-	q := curves.NewField(curve.Params().N)
-	shamir, err := v1.NewShamir(int(threshold), int(total), q)
+	shamir, err := sharing.NewShamir(threshold, total, curve)
 	if err != nil {
 		return nil, nil, err
 	}
 	// Create the shares to be distributed to participants
-	shares, err := shamir.Split(ikm.Bytes())
+	shares, err := shamir.Split(ikm, crand.Reader)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	dSharesMap := make(map[uint32]*Share, total)
-	for i, s := range shares {
-		// Create a commitment to the private share value
-		publicShare, err := curves.NewScalarBaseMult(curve, s.Value.BigInt())
+	for _, s := range shares {
+		value, err := curve.Scalar.SetBytes(s.Value)
 		if err != nil {
 			return nil, nil, err
 		}
-		dSharesMap[uint32(i+1)] = &Share{
-			s,
-			publicShare,
+		// Create a commitment to the private share value
+		dSharesMap[s.Id] = &Share{
+			Identifier: s.Id,
+			Value:      value,
+			Point:      curve.ScalarBaseMult(value),
 		}
 	}
 	return pk, dSharesMap, nil
 }
 
+// CheckCurve returns an error unless curve is a 256-bit curve with an elliptic.Curve equivalent,
+// which the range proofs of [spec] use as group parameters
+func CheckCurve(curve *curves.Curve) error {
+	if curve == nil {
+		return internal.ErrNilArguments
+	}
+	ec, err := curve.ToEllipticCurve()
+	if err != nil {
+		return err
+	}
+	if ec.Params().BitSize != 256 {
+		return fmt.Errorf("invalid curve size")
+	}
+	return nil
+}
+
 // genProofParams creates all the values needed for ProofParams using the specified
 // genSafePrime function, genRandInMod function, and number of bits
 func genProofParams(genSafePrime func(uint) (*big.Int, error), genRandInMod func(*big.Int) (*big.Int, error), bits uint) (*ProofParams, error) {
@@ -7,16 +7,16 @@
 package dealer
 
 import (
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"testing"
 
-	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/stretchr/testify/require"
 
 	tt "github.com/TEENet-io/kryptology/internal"
 	"github.com/TEENet-io/kryptology/pkg/core/curves"
-	v1 "github.com/TEENet-io/kryptology/pkg/sharing/v1"
+	"github.com/TEENet-io/kryptology/pkg/sharing"
 )
 
 type proofParamsTest struct {
@@ -197,81 +197,108 @@ func TestSameResult(t *testing.T) {
 }
 
 func TestNewDealerShares(t *testing.T) {
-	curve := btcec.S256()
-	for _, secretIsNil := range []bool{true, false} {
-		t.Run(fmt.Sprintf("NewDealerShare should not fail if bool(ikm == nil) is %t", secretIsNil), func(t *testing.T) {
-			var ikm *big.Int
-			var err error
-			if secretIsNil {
-				ikm, err = NewSecret(curve)
-				require.NoError(t, err)
-			}
-			pk, sharesMap, err := NewDealerShares(curve, 2, 3, ikm)
-			if err != nil {
-				t.Errorf("NewDealerShares failed: %v", err)
-				t.FailNow()
-			}
-
-			if pk == nil {
-				t.Errorf("NewDealerShares public key is nil")
-				t.FailNow()
-			}
+	for _, curve := range []*curves.Curve{curves.K256(), curves.P256()} {
+		for _, secretIsNil := range []bool{true, false} {
+			t.Run(fmt.Sprintf("%s NewDealerShare should not fail if bool(ikm == nil) is %t", curve.Name, secretIsNil), func(t *testing.T) {
+				var ikm curves.Scalar
+				var err error
+				if secretIsNil {
+					ikm, err = NewSecret(curve)
+					require.NoError(t, err)
+				}
+				pk, sharesMap, err := NewDealerShares(curve, 2, 3, ikm)
+				if err != nil {
+					t.Errorf("NewDealerShares failed: %v", err)
+					t.FailNow()
+				}
 
-			if secretIsNil {
-				derivedPublicKey, err := DerivePublicKey(curve, ikm)
-				require.NoError(t, err)
-				require.Equal(t, pk.X, derivedPublicKey.X)
-				require.Equal(t, pk.Y, derivedPublicKey.Y)
-			}
+				if pk == nil {
+					t.Errorf("NewDealerShares public key is nil")
+					t.FailNow()
+				}
 
-			if len(sharesMap) != 3 {
-				t.Errorf("NewDealerShares didn't produce enough shares")
-				t.FailNow()
-			}
+				if secretIsNil {
+					derivedPublicKey, err := DerivePublicKey(curve, ikm)
+					require.NoError(t, err)
+					require.True(t, pk.Equal(derivedPublicKey))
+				}
 
-			for _, s := range sharesMap {
-				if s.ShamirShare == nil {
-					t.Errorf("NewDealerShares didn't produce valid sharesMap")
+				if len(sharesMap) != 3 {
+					t.Errorf("NewDealerShares didn't produce enough shares")
 					t.FailNow()
 				}
-				if s.Point == nil {
-					t.Errorf("NewDealerShares didn't produce valid public sharesMap")
-					t.FailNow()
+
+				for _, s := range sharesMap {
+					if s.Value == nil {
+						t.Errorf("NewDealerShares didn't produce valid sharesMap")
+						t.FailNow()
+					}
+					if s.Point == nil {
+						t.Errorf("NewDealerShares didn't produce valid public sharesMap")
+						t.FailNow()
+					}
+					require.True(t, curve.ScalarBaseMult(s.Value).Equal(s.Point))
 				}
-				x, y := curve.ScalarMult(curve.Gx, curve.Gy, s.ShamirShare.Value.Bytes())
-				require.Equal(t, x, s.Point.X)
-				require.Equal(t, y, s.Point.Y)
-			}
 
-			n := curves.NewField(curve.N)
-			combiner, err := v1.NewShamir(2, 3, n)
-			require.NoError(t, err)
+				combiner, err := sharing.NewShamir(2, 3, curve)
+				require.NoError(t, err)
 
-			sShareArray := make([]*v1.ShamirShare, len(sharesMap))
-			for i, s := range sharesMap {
-				// Skip sharesMap[0] which is always nil
-				if i == 0 && s != nil {
-					t.Errorf("expected sharesMap[0] to be nil")
-					t.FailNow()
-				} else {
-					sShareArray[i-1] = s.ShamirShare
+				sShareArray := make([]*sharing.ShamirShare, 0, len(sharesMap))
+				for i, s := range sharesMap {
+					require.Equal(t, i, s.Identifier)
+					sShareArray = append(sShareArray, &sharing.ShamirShare{Id: s.Identifier, Value: s.Value.Bytes()})
 				}
-			}
-			sk, err := combiner.Combine(sShareArray...)
-			if err != nil {
-				t.Errorf("Shares could not be recombined")
-			}
-			pkx, pky := curve.ScalarBaseMult(sk)
-			require.NotNil(t, pkx, pky)
-			require.Equal(t, pkx, pk.X) // nolint
-			require.Equal(t, pky, pk.Y) // nolint
+				sk, err := combiner.Combine(sShareArray...)
+				if err != nil {
+					t.Errorf("Shares could not be recombined")
+				}
+				require.True(t, curve.ScalarBaseMult(sk).Equal(pk))
+			})
+		}
+	}
+}
+
+func TestNewDealerSharesInvalidCurve(t *testing.T) {
+	_, _, err := NewDealerShares(nil, 2, 3, nil)
+	require.Error(t, err)
+	_, _, err = NewDealerShares(curves.ED25519(), 2, 3, nil)
+	require.Error(t, err)
+	_, _, err = NewDealerShares(curves.BLS12381G1(), 2, 3, nil)
+	require.Error(t, err)
+}
 
-		})
+func TestShareJsonRoundTrip(t *testing.T) {
+	for _, curve := range []*curves.Curve{curves.K256(), curves.P256()} {
+		pk, sharesMap, err := NewDealerShares(curve, 2, 3, nil)
+		require.NoError(t, err)
+		publicShares, err := PreparePublicShares(sharesMap)
+		require.NoError(t, err)
+		data := ParticipantData{
+			Id:             1,
+			SecretKeyShare: sharesMap[1],
+			EcdsaPublicKey: pk,
+			KeyGenType:     TrustedDealerKeyGenType{ProofParams: &ProofParams{N: big.NewInt(77), H1: big.NewInt(4), H2: big.NewInt(16)}},
+			PublicShares:   publicShares,
+		}
+		bytes, err := json.Marshal(data)
+		require.NoError(t, err)
+
+		decoded := new(ParticipantData)
+		require.NoError(t, json.Unmarshal(bytes, decoded))
+		require.Equal(t, curve.Name, decoded.EcdsaPublicKey.CurveName())
+		require.True(t, decoded.EcdsaPublicKey.Equal(pk))
+		require.Equal(t, sharesMap[1].Identifier, decoded.SecretKeyShare.Identifier)
+		require.Equal(t, 0, decoded.SecretKeyShare.Value.Cmp(sharesMap[1].Value))
+		require.True(t, decoded.SecretKeyShare.Point.Equal(sharesMap[1].Point))
+		for i, ps := range publicShares {
+			require.True(t, decoded.PublicShares[i].Point.Equal(ps.Point))
+		}
+		require.Equal(t, data.KeyGenType, decoded.KeyGenType)
 	}
 }
 
 func TestPreparePublicShares(t *testing.T) {
-	curve := btcec.S256()
+	curve := curves.P256()
 	pk, sharesMap, err := NewDealerShares(curve, 2, 3, nil)
 	if err != nil {
 		t.Errorf("NewDealerShares failed: %v", err)
@@ -294,7 +321,6 @@ func TestPreparePublicShares(t *testing.T) {
 		t.Errorf("len(publicShares) != len(sharesMap): %d != %d", len(publicShares), len(sharesMap))
 	}
 	for i := range publicShares {
-		require.Equal(t, publicShares[i].Point.X, sharesMap[i].Point.X)
-		require.Equal(t, publicShares[i].Point.Y, sharesMap[i].Point.Y)
+		require.True(t, publicShares[i].Point.Equal(sharesMap[i].Point))
 	}
 }
@@ -0,0 +1,263 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package participant
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/TEENet-io/kryptology/internal"
+	"github.com/TEENet-io/kryptology/pkg/core"
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
+	"github.com/TEENet-io/kryptology/pkg/paillier"
+	"github.com/TEENet-io/kryptology/pkg/tecdsa/gg20/dealer"
+	"github.com/TEENet-io/kryptology/pkg/tecdsa/gg20/proof"
+)
+
+// ErrEvidenceNotConvincing is returned by Evidence.Verify when the recorded messages are valid,
+// i.e. the evidence does not show that the accused cosigner misbehaved.
+var ErrEvidenceNotConvincing = fmt.Errorf("evidence does not show misbehavior")
+
+// AbortError is returned by a signing round that failed because a specific cosigner sent
+// an invalid message. The caller can exclude the Culprit and restart signing with the
+// remaining cosigners.
+//
+// Evidence holds the offending message together with the public values needed to check it,
+// so that other parties need not trust the accuser. It is as binding as the transport:
+// if messages are not authenticated, the culprit can claim the message was not theirs.
+//
+// Two checks of [spec] cannot be attributed to a single cosigner from public values and
+// are returned as plain errors: V != g in round 6, and the product of the S_j not being
+// the public key. Both fail before any signature share is released.
+type AbortError struct {
+	Round    uint
+	Culprit  uint32
+	Evidence Evidence
+	err      error
+}
+
+// Error returns the reason of the abort.
+func (e *AbortError) Error() string {
+	return fmt.Sprintf("signing round %d aborted by cosigner %d: %v", e.Round, e.Culprit, e.err)
+}
+
+// Unwrap returns the underlying verification error.
+func (e *AbortError) Unwrap() error {
+	return e.err
+}
+
+// Culprit returns the id of the cosigner that caused err, if err is or wraps an AbortError.
+func Culprit(err error) (uint32, bool) {
+	var abort *AbortError
+	if errors.As(err, &abort) {
+		return abort.Culprit, true
+	}
+	return 0, false
+}
+
+// newAbortError attributes err to the cosigner culprit in the signer's current round.
+func (signer *Signer) newAbortError(culprit uint32, evidence Evidence, err error) *AbortError {
+	return &AbortError{
+		Round:    signer.Round,
+		Culprit:  culprit,
+		Evidence: evidence,
+		err:      err,
+	}
+}
+
+// Evidence records a message of a cosigner with the public values it is checked against.
+type Evidence interface {
+	// Verify returns nil if the evidence shows that the cosigner misbehaved,
+	// and ErrEvidenceNotConvincing if the message is valid.
+	Verify() error
+}
+
+// RangeProofEvidence is a range (1) proof of a cosigner's k_j ciphertext that
+// does not verify. See SignRound2.
+type RangeProofEvidence struct {
+	Curve        *curves.Curve
+	DealerParams *dealer.ProofParams
+	Pk           *paillier.PublicKey
+	Ciphertext   *big.Int
+	Proof        *proof.Range1Proof
+}
+
+// Verify checks that the range proof is invalid.
+func (e *RangeProofEvidence) Verify() error {
+	if e.Proof == nil {
+		return nil
+	}
+	pp := &proof.Proof1Params{
+		Curve:        e.Curve,
+		Pk:           e.Pk,
+		DealerParams: e.DealerParams,
+		C:            e.Ciphertext,
+	}
+	if e.Proof.Verify(pp) != nil {
+		return nil
+	}
+	return ErrEvidenceNotConvincing
+}
+
+// MtaEvidence is an MtA response of a cosigner whose range (2) proof does not verify,
+// or whose ciphertext cannot be decrypted. B is nil for the response over γ_j and
+// is W_j for the response over w_j. See SignRound3.
+type MtaEvidence struct {
+	Curve        *curves.Curve
+	DealerParams *dealer.ProofParams
+	Pk           *paillier.PublicKey
+	C1           *big.Int
+	B            curves.Point
+	Response     proof.ResponseProof
+}
+
+// Verify checks that the MtA response is invalid.
+func (e *MtaEvidence) Verify() error {
+	if e.Pk == nil {
+		return internal.ErrNilArguments
+	}
+	if e.Response.C2 == nil || core.In(e.Response.C2, e.Pk.N2) != nil {
+		return nil
+	}
+	cp := &proof.ResponseCheckParams{
+		Curve:        e.Curve,
+		DealerParams: e.DealerParams,
+		Pk:           e.Pk,
+		C1:           e.C1,
+		B:            e.B,
+	}
+	var err error
+	if e.B == nil {
+		err = e.Response.Verify(cp)
+	} else {
+		err = e.Response.VerifyWc(cp)
+	}
+	if err != nil {
+		return nil
+	}
+	return ErrEvidenceNotConvincing
+}
+
+// CommitmentEvidence is a witness of a cosigner that does not open its round 1
+// commitment to a point Γ_j. See SignRound5.
+type CommitmentEvidence struct {
+	Curve      *curves.Curve
+	Commitment core.Commitment
+	Witness    *core.Witness
+}
+
+// Verify checks that the witness does not open the commitment.
+func (e *CommitmentEvidence) Verify() error {
+	if e.Witness == nil {
+		return nil
+	}
+	ok, err := core.Open(e.Commitment, *e.Witness)
+	if err != nil || !ok {
+		return nil
+	}
+	if e.Curve == nil {
+		return nil
+	}
+	if _, err = e.Curve.Point.FromAffineUncompressed(e.Witness.Msg); err != nil {
+		return nil
+	}
+	return ErrEvidenceNotConvincing
+}
+
+// PdlEvidence is a proof of a cosigner that \overline{R_j} = R^{k_j} which does not verify.
+// See SignRound6Full.
+type PdlEvidence struct {
+	Curve        *curves.Curve
+	DealerParams *dealer.ProofParams
+	Pk           *paillier.PublicKey
+	Ciphertext   *big.Int
+	R, Rbar      curves.Point
+	Proof        *proof.PdlProof
+}
+
+// Verify checks that the PDL proof is invalid.
+func (e *PdlEvidence) Verify() error {
+	if e.Proof == nil {
+		return nil
+	}
+	pv := &proof.PdlVerifyParams{
+		Curve:        e.Curve,
+		DealerParams: e.DealerParams,
+		Pk:           e.Pk,
+		PointX:       e.Rbar,
+		PointR:       e.R,
+		C:            e.Ciphertext,
+	}
+	if e.Proof.Verify(pv) != nil {
+		return nil
+	}
+	return ErrEvidenceNotConvincing
+}
+
+// SignatureShareEvidence is a signature share s_j = m k_j + r σ_j of a cosigner that does not
+// satisfy R^{s_j} = \overline{R_j}^m S_j^r, where \overline{R_j} = R^{k_j} and S_j = R^{σ_j} were
+// broadcast in round 5. See SignOutput.
+type SignatureShareEvidence struct {
+	Curve   *curves.Curve
+	Hash    []byte
+	R, Rbar curves.Point
+	S       curves.Point
+	Share   *big.Int
+}
+
+// Verify checks that the signature share is invalid.
+func (e *SignatureShareEvidence) Verify() error {
+	if e.Share == nil || e.S == nil || e.Rbar == nil {
+		return nil
+	}
+	ok, err := checkSignatureShare(e.Curve, e.Hash, e.R, e.Rbar, e.S, e.Share)
+	if err != nil || !ok {
+		return nil
+	}
+	return ErrEvidenceNotConvincing
+}
+
+// checkSignatureShare reports whether R^{s_j} = \overline{R_j}^m S_j^r.
+func checkSignatureShare(curve *curves.Curve, hash []byte, R, Rbar, S curves.Point, share *big.Int) (bool, error) {
+	if curve == nil || R == nil || Rbar == nil || S == nil || share == nil {
+		return false, internal.ErrNilArguments
+	}
+	if !Rbar.IsOnCurve() || !S.IsOnCurve() {
+		return false, internal.ErrNotOnCurve
+	}
+	m, err := curve.Scalar.SetBigInt(new(big.Int).SetBytes(hash))
+	if err != nil {
+		return false, err
+	}
+	s, err := curve.Scalar.SetBigInt(share)
+	if err != nil {
+		return false, err
+	}
+	Rx, _ := affine(R)
+	r, err := curve.Scalar.SetBigInt(Rx)
+	if err != nil {
+		return false, err
+	}
+	lhs := R.Mul(s)
+	rhs := Rbar.Mul(m).Add(S.Mul(r))
+	return lhs.Equal(rhs), nil
+}
+
+// responseProof returns the concrete response proof behind a ResponseFinalizer, if there is one.
+func responseProof(f proof.ResponseFinalizer) (proof.ResponseProof, bool) {
+	switch p := f.(type) {
+	case proof.ResponseProof:
+		return p, true
+	case *proof.ResponseProof:
+		if p != nil {
+			return *p, true
+		}
+	}
+	return proof.ResponseProof{}, false
+}
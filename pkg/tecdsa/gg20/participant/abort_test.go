@@ -0,0 +1,291 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package participant
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TEENet-io/kryptology/pkg/core"
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
+	"github.com/TEENet-io/kryptology/pkg/tecdsa/gg20/proof"
+)
+
+const culpritId = uint32(3)
+
+// tampering changes the messages the culprit sends in a round before they are delivered
+type tampering struct {
+	round1 func(bcast *Round1Bcast, p2p map[uint32]*Round1P2PSend)
+	round2 func(p2p map[uint32]*P2PSend)
+	round4 func(bcast *Round4Bcast)
+	round5 func(bcast *Round5Bcast, p2p map[uint32]*Round5P2PSend)
+	round6 func(bcast *Round6FullBcast)
+}
+
+// runTamperedSigning runs a 2 of 3 signing between signers 1, 2 and 3 where the culprit's messages
+// are changed by tamper, and returns the errors of the honest signers in the first round that failed.
+func runTamperedSigning(t *testing.T, useDistributed bool, tamper tampering) map[uint32]error {
+	msg := make([]byte, 32)
+	hash, err := core.Hash(msg, btcec.S256())
+	require.NoError(t, err)
+	_, signers := setupSignersMap(t, curves.K256(), 3, 5, false, k256Verifier, useDistributed)
+	ids := []uint32{1, 2, 3}
+	for _, id := range ids {
+		cosigners := make([]uint32, 0, 2)
+		for _, j := range ids {
+			if j != id {
+				cosigners = append(cosigners, j)
+			}
+		}
+		require.NoError(t, signers[id].setCosigners(cosigners))
+	}
+	honest := []uint32{1, 2}
+	failures := func(errs map[uint32]error) bool {
+		for _, err := range errs {
+			if err != nil {
+				return true
+			}
+		}
+		return false
+	}
+
+	r1Bcast := make(map[uint32]*Round1Bcast, 3)
+	r1P2p := make(map[uint32]map[uint32]*Round1P2PSend, 3)
+	for _, id := range ids {
+		r1Bcast[id], r1P2p[id], err = signers[id].SignRound1()
+		require.NoError(t, err)
+	}
+	if tamper.round1 != nil {
+		tamper.round1(r1Bcast[culpritId], r1P2p[culpritId])
+	}
+
+	r2P2p := make(map[uint32]map[uint32]*P2PSend, 3)
+	errs := make(map[uint32]error, 2)
+	for _, id := range ids {
+		in := make(map[uint32]*Round1Bcast, 2)
+		var p2p map[uint32]*Round1P2PSend
+		if useDistributed {
+			p2p = make(map[uint32]*Round1P2PSend, 2)
+		}
+		for _, j := range ids {
+			if j == id {
+				continue
+			}
+			in[j] = r1Bcast[j]
+			if useDistributed {
+				p2p[j] = r1P2p[j][id]
+			}
+		}
+		r2P2p[id], err = signers[id].SignRound2(in, p2p)
+		if id != culpritId {
+			errs[id] = err
+		}
+	}
+	if failures(errs) {
+		return errs
+	}
+	if tamper.round2 != nil {
+		tamper.round2(r2P2p[culpritId])
+	}
+
+	r3Bcast := make(map[uint32]*Round3Bcast, 3)
+	for _, id := range ids {
+		in := make(map[uint32]*P2PSend, 2)
+		for _, j := range ids {
+			if j != id {
+				in[j] = r2P2p[j][id]
+			}
+		}
+		r3Bcast[id], err = signers[id].SignRound3(in)
+		if id != culpritId {
+			errs[id] = err
+		}
+	}
+	if failures(errs) {
+		return errs
+	}
+
+	r4Bcast := make(map[uint32]*Round4Bcast, 3)
+	for _, id := range ids {
+		in := make(map[uint32]*Round3Bcast, 2)
+		for _, j := range ids {
+			if j != id {
+				in[j] = r3Bcast[j]
+			}
+		}
+		r4Bcast[id], err = signers[id].SignRound4(in)
+		require.NoError(t, err)
+	}
+	if tamper.round4 != nil {
+		tamper.round4(r4Bcast[culpritId])
+	}
+
+	r5Bcast := make(map[uint32]*Round5Bcast, 3)
+	r5P2p := make(map[uint32]map[uint32]*Round5P2PSend, 3)
+	for _, id := range ids {
+		in := make(map[uint32]*Round4Bcast, 2)
+		for _, j := range ids {
+			if j != id {
+				in[j] = r4Bcast[j]
+			}
+		}
+		r5Bcast[id], r5P2p[id], err = signers[id].SignRound5(in)
+		if id != culpritId {
+			errs[id] = err
+		}
+	}
+	if failures(errs) {
+		return errs
+	}
+	if tamper.round5 != nil {
+		tamper.round5(r5Bcast[culpritId], r5P2p[culpritId])
+	}
+
+	r6Bcast := make(map[uint32]*Round6FullBcast, 3)
+	for _, id := range ids {
+		in := make(map[uint32]*Round5Bcast, 2)
+		var p2p map[uint32]*Round5P2PSend
+		if useDistributed {
+			p2p = make(map[uint32]*Round5P2PSend, 2)
+		}
+		for _, j := range ids {
+			if j == id {
+				continue
+			}
+			in[j] = r5Bcast[j]
+			if useDistributed {
+				p2p[j] = r5P2p[j][id]
+			}
+		}
+		r6Bcast[id], err = signers[id].SignRound6Full(hash.Bytes(), in, p2p)
+		if id != culpritId {
+			errs[id] = err
+		}
+	}
+	if failures(errs) {
+		return errs
+	}
+	if tamper.round6 != nil {
+		tamper.round6(r6Bcast[culpritId])
+	}
+
+	for _, id := range honest {
+		in := make(map[uint32]*Round6FullBcast, 2)
+		for _, j := range ids {
+			if j != id {
+				in[j] = r6Bcast[j]
+			}
+		}
+		_, errs[id] = signers[id].SignOutput(in)
+	}
+	return errs
+}
+
+// requireAbort checks that every honest signer blames the culprit in round with convincing evidence
+func requireAbort(t *testing.T, errs map[uint32]error, round uint) {
+	t.Helper()
+	require.Len(t, errs, 2)
+	for _, err := range errs {
+		require.Error(t, err)
+		culprit, ok := Culprit(err)
+		require.True(t, ok, "%v", err)
+		require.Equal(t, culpritId, culprit)
+
+		var abort *AbortError
+		require.ErrorAs(t, err, &abort)
+		require.Equal(t, round, abort.Round)
+		require.NotNil(t, abort.Evidence)
+		require.NoError(t, abort.Evidence.Verify())
+	}
+}
+
+func TestSignIdentifiableAbort(t *testing.T) {
+	for _, useDistributed := range []bool{false, true} {
+		t.Run("invalid range proof", func(t *testing.T) {
+			errs := runTamperedSigning(t, useDistributed, tampering{
+				round1: func(bcast *Round1Bcast, _ map[uint32]*Round1P2PSend) {
+					bcast.Ctxt = new(big.Int).Add(bcast.Ctxt, core.One)
+				},
+			})
+			requireAbort(t, errs, 2)
+		})
+
+		t.Run("invalid mta response", func(t *testing.T) {
+			errs := runTamperedSigning(t, useDistributed, tampering{
+				round2: func(p2p map[uint32]*P2PSend) {
+					for j, send := range p2p {
+						rp := *send.Proof3.(*proof.ResponseProof)
+						rp.C2 = new(big.Int).Add(rp.C2, core.One)
+						p2p[j] = &P2PSend{Proof2: send.Proof2, Proof3: rp}
+					}
+				},
+			})
+			requireAbort(t, errs, 3)
+		})
+
+		t.Run("invalid commitment opening", func(t *testing.T) {
+			errs := runTamperedSigning(t, useDistributed, tampering{
+				round4: func(bcast *Round4Bcast) {
+					witness := *bcast.Witness
+					witness.Msg = append([]byte{}, witness.Msg...)
+					witness.Msg[1] ^= 1
+					bcast.Witness = &witness
+				},
+			})
+			requireAbort(t, errs, 5)
+		})
+
+		t.Run("invalid pdl proof", func(t *testing.T) {
+			errs := runTamperedSigning(t, useDistributed, tampering{
+				round5: func(bcast *Round5Bcast, _ map[uint32]*Round5P2PSend) {
+					bcast.Rbar = bcast.Rbar.Double()
+				},
+			})
+			requireAbort(t, errs, 6)
+		})
+	}
+
+	t.Run("invalid signature share", func(t *testing.T) {
+		errs := runTamperedSigning(t, false, tampering{
+			round6: func(bcast *Round6FullBcast) {
+				bcast.sElement = new(big.Int).Add(bcast.sElement, core.One)
+			},
+		})
+		requireAbort(t, errs, 7)
+	})
+
+	t.Run("unattributable S", func(t *testing.T) {
+		errs := runTamperedSigning(t, false, tampering{
+			round5: func(bcast *Round5Bcast, _ map[uint32]*Round5P2PSend) {
+				bcast.S = bcast.S.Double()
+			},
+		})
+		for _, err := range errs {
+			require.Error(t, err)
+			_, ok := Culprit(err)
+			require.False(t, ok)
+		}
+	})
+}
+
+func TestSignatureShareEvidenceNotConvincing(t *testing.T) {
+	errs := runTamperedSigning(t, false, tampering{
+		round6: func(bcast *Round6FullBcast) {
+			bcast.sElement = new(big.Int).Add(bcast.sElement, core.One)
+		},
+	})
+	var abort *AbortError
+	require.ErrorAs(t, errs[1], &abort)
+	evidence := abort.Evidence.(*SignatureShareEvidence)
+
+	// The culprit's real share satisfies the check, so an accusation built from it fails
+	evidence.Share = new(big.Int).Sub(evidence.Share, core.One)
+	require.ErrorIs(t, evidence.Verify(), ErrEvidenceNotConvincing)
+}
@@ -7,7 +7,6 @@
 package participant
 
 import (
-	"crypto/elliptic"
 	"encoding/json"
 	"testing"
 
@@ -38,7 +37,7 @@ func BenchmarkDealingShares(b *testing.B) {
 		b.SkipNow()
 	}
 
-	curve := btcec.S256()
+	curve := curves.K256()
 	b.Run("Secp256k1 - 2 of 2", func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
 			err := benchDealShares(curve, 2, 2)
@@ -96,7 +95,7 @@ func BenchmarkDealingShares(b *testing.B) {
 	})
 }
 
-func benchDealShares(curve elliptic.Curve, threshold, count uint32) error {
+func benchDealShares(curve *curves.Curve, threshold, count uint32) error {
 	_, sharesMap, err := dealer.NewDealerShares(curve, threshold, count, nil)
 	if err != nil {
 		return err
@@ -120,8 +119,8 @@ func BenchmarkDealingPaillierKeys(b *testing.B) {
 }
 
 func BenchmarkSigning(b *testing.B) {
-	curve := btcec.S256()
-	hash, err := core.Hash([]byte("It is not good to have a rule of many."), curve)
+	curve := curves.K256()
+	hash, err := core.Hash([]byte("It is not good to have a rule of many."), btcec.S256())
 	require.NoError(b, err)
 	hashBytes := hash.Bytes()
 
@@ -196,7 +195,47 @@ func BenchmarkSigning(b *testing.B) {
 	})
 }
 
-func benchSign(b *testing.B, hash []byte, curve elliptic.Curve, verify curves.EcdsaVerify, threshold, count uint32) error {
+// BenchmarkSigningPaillier compares signing with Paillier decryption by λ(N) and by CRT,
+// and with encryption nonces precomputed before the signing rounds
+func BenchmarkSigningPaillier(b *testing.B) {
+	curve := curves.K256()
+	hash, err := core.Hash([]byte("It is not good to have a rule of many."), btcec.S256())
+	require.NoError(b, err)
+	hashBytes := hash.Bytes()
+
+	for _, mode := range []struct {
+		name     string
+		paillier benchPaillier
+	}{
+		{"Lambda decryption", benchPaillierLambda},
+		{"CRT decryption", benchPaillierCrt},
+		{"CRT decryption and precomputed nonces", benchPaillierPrecomputed},
+	} {
+		mode := mode
+		b.Run("Secp256k1 - 3 of 5 - "+mode.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				require.NoError(b,
+					benchSignPaillier(b, hashBytes, curve, k256Verifier, 3, 5, mode.paillier),
+				)
+			}
+		})
+	}
+}
+
+// benchPaillier selects how the signers use their Paillier keys
+type benchPaillier int
+
+const (
+	benchPaillierLambda benchPaillier = iota
+	benchPaillierCrt
+	benchPaillierPrecomputed
+)
+
+func benchSign(b *testing.B, hash []byte, curve *curves.Curve, verify curves.EcdsaVerify, threshold, count uint32) error {
+	return benchSignPaillier(b, hash, curve, verify, threshold, count, benchPaillierCrt)
+}
+
+func benchSignPaillier(b *testing.B, hash []byte, curve *curves.Curve, verify curves.EcdsaVerify, threshold, count uint32, mode benchPaillier) error {
 	// Setup signers
 	b.StopTimer()
 
@@ -210,7 +249,17 @@ func benchSign(b *testing.B, hash []byte, curve elliptic.Curve, verify curves.Ec
 	keyPrimesArray := genPrimesArray(int(threshold))
 	for i := range sharesMap {
 		keysMap[i], _ = paillier.NewSecretKey(keyPrimesArray[i-1].p, keyPrimesArray[i-1].q)
+		if mode == benchPaillierLambda {
+			sk := keysMap[i]
+			keysMap[i] = &paillier.SecretKey{PublicKey: sk.PublicKey, Lambda: sk.Lambda, Totient: sk.Totient, U: sk.U}
+		}
 		pubKeys[i] = &keysMap[i].PublicKey
+		if mode == benchPaillierPrecomputed {
+			// One encryption in round 1 and two in the MtA of round 2 for every other signer
+			if err := pubKeys[i].Precompute(2 * int(threshold)); err != nil {
+				return err
+			}
+		}
 	}
 	proofParams := &dealer.TrustedDealerKeyGenType{
 		ProofParams: dealerParams,
@@ -338,7 +387,7 @@ func benchSign(b *testing.B, hash []byte, curve elliptic.Curve, verify curves.Ec
 // Benchmark 2-party signing
 func BenchmarkSign2p(b *testing.B) {
 	// Dealer-related setup (not part of signing being measured)
-	k256 := btcec.S256()
+	k256 := curves.K256()
 
 	pk, sharesMap, err := dealer.NewDealerShares(k256, 2, 2, nil)
 	require.NoError(b, err)
@@ -383,8 +432,8 @@ func BenchmarkSign2p(b *testing.B) {
 }
 
 type signingSetup struct {
-	curve        elliptic.Curve
-	pk           *curves.EcPoint
+	curve        *curves.Curve
+	pk           curves.Point
 	sharesMap    map[uint32]*dealer.Share
 	pubSharesMap map[uint32]*dealer.PublicShare
 	pubkeys      map[uint32]*paillier.PublicKey
@@ -395,7 +444,7 @@ type signingSetup struct {
 // Run a 2-party signing protocol and report messaging metrics.
 func sign2p(b *testing.B, bw *msgCounter, setup *signingSetup) {
 	// Hash of message for signature
-	hashBi, err := core.Hash([]byte("I will be brief. Your noble son is mad."), setup.curve)
+	hashBi, err := core.Hash([]byte("I will be brief. Your noble son is mad."), btcec.S256())
 	require.NoError(b, err)
 	msgHash := hashBi.Bytes()
 
@@ -7,13 +7,14 @@
 package participant
 
 import (
+	crand "crypto/rand"
 	"fmt"
 	"math/big"
 
 	"github.com/TEENet-io/kryptology/internal"
 	"github.com/TEENet-io/kryptology/pkg/core"
 	"github.com/TEENet-io/kryptology/pkg/paillier"
-	v1 "github.com/TEENet-io/kryptology/pkg/sharing/v1"
+	"github.com/TEENet-io/kryptology/pkg/sharing"
 	"github.com/TEENet-io/kryptology/pkg/tecdsa/gg20/proof"
 )
 
@@ -24,6 +25,8 @@ type DkgRound1Bcast struct {
 	Pki              *paillier.PublicKey
 	H1i, H2i, Ni     *big.Int
 	Proof1i, Proof2i *proof.CdlProof
+	// Πmod proofs that Pki and Ni are Paillier-Blum moduli
+	ModProofPki, ModProofNi *paillier.ModProof
 }
 
 // DkgRound1 performs round 1 distributed key generation operation
@@ -42,27 +45,28 @@ func (dp *DkgParticipant) DkgRound1(threshold, total uint32) (*DkgRound1Bcast, e
 	}
 
 	// Step 1: choose ui from Z_q randomly
-	ui, err := core.Rand(dp.Curve.Params().N)
+	ui, err := randomNonZero(dp.Curve)
 	if err != nil {
 		return nil, err
 	}
 
 	// Step 2: Compute [vi0,...,vit], [xi1,...,xin] <- FeldmanShare(g, ui, t, q, [p1...pn])
-	feldman, err := v1.NewFeldman(threshold, total, dp.Curve)
+	feldman, err := sharing.NewFeldman(threshold, total, dp.Curve)
 	if err != nil {
 		return nil, err
 	}
 
-	// V is an array of FeldmanVerifiers, X is an array of ShamirShares
-	V, X, err := feldman.Split(ui.Bytes())
+	// V holds the Feldman commitments, X maps each player id to its ShamirShare
+	verifier, X, err := feldman.Split(ui, crand.Reader)
 	if err != nil {
 		return nil, err
 	}
+	V := verifier.Commitments
 
-	// Convert V (type []FeldmanVerifier) to a single byteV (type []byte)
+	// Convert V (type []curves.Point) to a single byteV (type []byte)
 	var byteV []byte
 	for i := 0; i < len(V); i++ {
-		byteV = append(byteV, V[i].Bytes()...)
+		byteV = append(byteV, V[i].ToAffineUncompressed()...)
 	}
 
 	// Step 3: [Ci, Di] = Commit([vi0...vit])
@@ -103,6 +107,22 @@ func (dp *DkgParticipant) DkgRound1(threshold, total uint32) (*DkgRound1Bcast, e
 	// Step 7: Compute tildeNi = Pi*Qi
 	tildeNi := new(big.Int).Mul(Pi, Qi)
 
+	// Prove that pki and tildeNi are Paillier-Blum moduli, as neither is otherwise checked to have no
+	// small factors. See https://eprint.iacr.org/2021/1621.pdf and [CGGMP21] fig 16
+	sid := dp.paillierSid(threshold, total)
+	modProofPki, err := (&paillier.ModProofParams{SecretKey: ski, Pi: dp.id, Sid: sid}).Prove()
+	if err != nil {
+		return nil, err
+	}
+	skNi, err := paillier.NewSecretKey(Pi, Qi)
+	if err != nil {
+		return nil, err
+	}
+	modProofNi, err := (&paillier.ModProofParams{SecretKey: skNi, Pi: dp.id, Sid: sid}).Prove()
+	if err != nil {
+		return nil, err
+	}
+
 	// Step 8-9: Sample f, alpha from Z_tildeNi*
 	f, err := core.Rand(tildeNi)
 	if err != nil {
@@ -183,6 +203,6 @@ func (dp *DkgParticipant) DkgRound1(threshold, total uint32) (*DkgRound1Bcast, e
 
 	// Step 15: EchoBroadcast Ci, pki, tildeNi, h1i, h2i, proof1, proof2
 	return &DkgRound1Bcast{
-		dp.id, Ci, pki, h1i, h2i, tildeNi, proof1, proof2,
+		dp.id, Ci, pki, h1i, h2i, tildeNi, proof1, proof2, modProofPki, modProofNi,
 	}, nil
 }
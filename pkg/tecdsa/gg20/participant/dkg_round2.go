@@ -7,12 +7,13 @@
 package participant
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/TEENet-io/kryptology/internal"
 	"github.com/TEENet-io/kryptology/pkg/core"
 	"github.com/TEENet-io/kryptology/pkg/paillier"
-	v1 "github.com/TEENet-io/kryptology/pkg/sharing/v1"
+	"github.com/TEENet-io/kryptology/pkg/sharing"
 	"github.com/TEENet-io/kryptology/pkg/tecdsa/gg20/dealer"
 	"github.com/TEENet-io/kryptology/pkg/tecdsa/gg20/proof"
 )
@@ -24,7 +25,30 @@ type DkgRound2Bcast struct {
 
 // DkgRound2P2PSend contains value that will be P2PSend to all other player Pj
 type DkgRound2P2PSend struct {
-	xij *v1.ShamirShare
+	xij *sharing.ShamirShare
+	// Πfac proof that the sender's Paillier key has no small factors, under the parameters of Pj
+	facProof *paillier.FacProof
+}
+
+// DkgRound2P2PSendJSON is used in JSON<>DkgRound2P2PSend conversions.
+type DkgRound2P2PSendJSON struct {
+	Xij      *sharing.ShamirShare
+	FacProof *paillier.FacProof
+}
+
+func (p2p DkgRound2P2PSend) MarshalJSON() ([]byte, error) {
+	return json.Marshal(DkgRound2P2PSendJSON{p2p.xij, p2p.facProof})
+}
+
+func (p2p *DkgRound2P2PSend) UnmarshalJSON(data []byte) error {
+	message := &DkgRound2P2PSendJSON{}
+	err := json.Unmarshal(data, message)
+	if err != nil {
+		return err
+	}
+	p2p.xij = message.Xij
+	p2p.facProof = message.FacProof
+	return nil
 }
 
 // DkgRound2 implements distributed key generation round 2
@@ -67,6 +91,7 @@ func (dp *DkgParticipant) DkgRound2(params map[uint32]*DkgRound1Bcast) (*DkgRoun
 	}
 
 	dp.state.otherParticipantData = make(map[uint32]*dkgParticipantData)
+	sid := dp.paillierSid(dp.state.Threshold, dp.state.Limit)
 
 	// For j = [1...n]
 	expKeySize := 2 * paillier.PaillierPrimeBits
@@ -86,6 +111,18 @@ func (dp *DkgParticipant) DkgRound2(params map[uint32]*DkgRound1Bcast) (*DkgRoun
 			return nil, nil, fmt.Errorf("invalid paillier keys")
 		}
 
+		// If pkj or tildeN_j is not a Paillier-Blum modulus, Abort
+		if err := param.ModProofPki.Verify(&paillier.ModVerifyParams{PublicKey: param.Pki, Pi: id, Sid: sid}); err != nil {
+			return nil, nil, err
+		}
+		tildeNj, err := paillier.NewPubkey(param.Ni)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := param.ModProofNi.Verify(&paillier.ModVerifyParams{PublicKey: tildeNj, Pi: id, Sid: sid}); err != nil {
+			return nil, nil, err
+		}
+
 		// If VerifyCompositeDL(pi_1j^CDL, g, q, h1j, h2j, tildeN_j) = False, Abort
 		cdlParams1.H1 = param.H1i
 		cdlParams1.H2 = param.H2i
@@ -103,23 +140,38 @@ func (dp *DkgParticipant) DkgRound2(params map[uint32]*DkgRound1Bcast) (*DkgRoun
 			return nil, nil, err
 		}
 
+		proofParams := &dealer.ProofParams{
+			N:  param.Ni,
+			H1: param.H1i,
+			H2: param.H2i,
+		}
+
+		// Prove to player Pj that pki has no small factors, under the now verified parameters of Pj
+		facParams := paillier.FacProofParams{
+			SecretKey:    dp.state.Sk,
+			RingPedersen: proofParams.RingPedersen(),
+			Pi:           dp.id,
+			Sid:          sid,
+		}
+		facProof, err := facParams.Prove()
+		if err != nil {
+			return nil, nil, err
+		}
+
 		// P2PSend xij to player Pj
-		if dp.state.X == nil || dp.state.X[id-1] == nil {
+		if dp.state.X == nil || dp.state.X[id] == nil {
 			return nil, nil, fmt.Errorf("Missing Shamir share to P2P send")
 		}
 		p2PSend[id] = &DkgRound2P2PSend{
-			xij: dp.state.X[id-1],
+			xij:      dp.state.X[id],
+			facProof: facProof,
 		}
 
 		// Store other parties data
 		dp.state.otherParticipantData[id] = &dkgParticipantData{
-			PublicKey:  param.Pki,
-			Commitment: param.Ci,
-			ProofParams: &dealer.ProofParams{
-				N:  param.Ni,
-				H1: param.H1i,
-				H2: param.H2i,
-			},
+			PublicKey:   param.Pki,
+			Commitment:  param.Ci,
+			ProofParams: proofParams,
 		}
 	}
 
@@ -7,21 +7,19 @@
 package participant
 
 import (
-	"crypto/elliptic"
 	"fmt"
-	"math/big"
 
 	"github.com/TEENet-io/kryptology/internal"
 	"github.com/TEENet-io/kryptology/pkg/core"
 	"github.com/TEENet-io/kryptology/pkg/core/curves"
 	"github.com/TEENet-io/kryptology/pkg/paillier"
-	v1 "github.com/TEENet-io/kryptology/pkg/sharing/v1"
+	"github.com/TEENet-io/kryptology/pkg/sharing"
 )
 
 // DkgRound3 computes dkg round 3 as shown in
 // [spec] fig. 5: DistKeyGenRoun3
-func (dp *DkgParticipant) DkgRound3(d map[uint32]*core.Witness, x map[uint32]*v1.ShamirShare) (paillier.PsfProof, error) {
-	if len(d) == 0 || len(x) == 0 {
+func (dp *DkgParticipant) DkgRound3(d map[uint32]*core.Witness, p2p map[uint32]*DkgRound2P2PSend) (paillier.PsfProof, error) {
+	if len(d) == 0 || len(p2p) == 0 {
 		return nil, internal.ErrNilArguments
 	}
 	if dp.Round != 3 {
@@ -29,18 +27,16 @@ func (dp *DkgParticipant) DkgRound3(d map[uint32]*core.Witness, x map[uint32]*v1
 	}
 
 	// Extract the share verifiers from the commitment
-	verifiers := make(map[uint32][]*v1.ShareVerifier, len(d))
-	// NOTE: ID-1 because participant IDs are 1-based
+	verifiers := make(map[uint32][]curves.Point, len(d))
 	verifiers[dp.id] = dp.state.V
-	verifierSize := internal.CalcFieldSize(dp.Curve) * 2
-	feldman, err := v1.NewFeldman(dp.state.Threshold, dp.state.Limit, dp.Curve)
+	verifierSize := len(dp.Curve.NewGeneratorPoint().ToAffineUncompressed())
+
+	// 1. set xi = xii
+	xi, err := dp.Curve.Scalar.SetBytes(dp.state.X[dp.id].Value)
 	if err != nil {
 		return nil, err
 	}
 
-	// 1. set xi = xii
-	xi := dp.state.X[dp.id-1]
-
 	// 2. for j = [1,...,n]
 	for j, wit := range d {
 		// 3. if i == j continue
@@ -62,34 +58,43 @@ func (dp *DkgParticipant) DkgRound3(d map[uint32]*core.Witness, x map[uint32]*v1
 		}
 
 		// 6. If FeldmanVerify(g, q, xji, pi, [vj0, . . . , vjt]) = False, Abort
-		if ok, err := feldman.Verify(x[j], verifiers[j]); !ok {
-			if err != nil {
-				return nil, err
-			} else {
-				return nil, fmt.Errorf("invalid share for participant %d", j+1)
-			}
+		if p2p[j] == nil || p2p[j].xij == nil || p2p[j].xij.Id != dp.id {
+			return nil, fmt.Errorf("invalid share for participant %d", j+1)
+		}
+		feldman := sharing.FeldmanVerifier{Commitments: verifiers[j]}
+		if err = feldman.Verify(p2p[j].xij); err != nil {
+			return nil, err
+		}
+
+		// If pkj has small factors, Abort
+		facParams := &paillier.FacVerifyParams{
+			PublicKey:    dp.state.otherParticipantData[j].PublicKey,
+			RingPedersen: dp.proofParams().RingPedersen(),
+			Pi:           j,
+			Sid:          dp.paillierSid(dp.state.Threshold, dp.state.Limit),
+		}
+		if err = p2p[j].facProof.Verify(facParams); err != nil {
+			return nil, err
 		}
 
 		// 7. Compute xi = xi + xji mod q
-		xi.Value = xi.Value.Add(x[j].Value)
+		xji, err := dp.Curve.Scalar.SetBytes(p2p[j].xij.Value)
+		if err != nil {
+			return nil, err
+		}
+		xi = xi.Add(xji)
 	}
 
-	v := make([]*curves.EcPoint, dp.state.Threshold)
+	v := make([]curves.Point, dp.state.Threshold)
 	// 8. for j = [0,...,t]
 	for j := 0; j < int(dp.state.Threshold); j++ {
 		// 9. Set vj = 1 or identity point
-		v[j], err = curves.NewScalarBaseMult(dp.Curve, big.NewInt(0))
-		if err != nil {
-			return nil, err
-		}
+		v[j] = dp.Curve.NewIdentityPoint()
 
 		// 10. for k = [1,...,n]
 		for _, verifier := range verifiers {
 			// 11. Compute vj = vj · vkj in G
-			v[j], err = v[j].Add(verifier[j])
-			if err != nil {
-				return nil, err
-			}
+			v[j] = v[j].Add(verifier[j])
 		}
 	}
 
@@ -98,50 +103,34 @@ func (dp *DkgParticipant) DkgRound3(d map[uint32]*core.Witness, x map[uint32]*v1
 
 	// This is a sanity check to make sure nothing went wrong when
 	// computing the public key
-	if !dp.Curve.IsOnCurve(y.X, y.Y) || y.IsIdentity() {
+	if !y.IsOnCurve() || y.IsIdentity() {
 		return nil, fmt.Errorf("invalid public key")
 	}
 
 	// Xj's
-	publicShares := make([]*curves.EcPoint, dp.state.Limit)
+	publicShares := make([]curves.Point, dp.state.Limit)
 
 	// 13. for j = [1,...,n]
 	for j := 0; j < int(dp.state.Limit); j++ {
-		id := uint32(j + 1)
+		pj := dp.Curve.Scalar.New(j + 1)
 		// 14. Set Xj = y
-		publicShares[j] = &curves.EcPoint{
-			Curve: dp.Curve,
-			X:     new(big.Int).Set(y.X),
-			Y:     new(big.Int).Set(y.Y),
-		}
+		publicShares[j] = y
 		// 15. for k = [1,...,t]
-		for k := 0; k < int(dp.state.Threshold); k++ {
+		ck := dp.Curve.Scalar.One()
+		for k := 1; k < int(dp.state.Threshold); k++ {
 			// 16. compute ck = pj^k mod q
-			pj := big.NewInt(int64(id))
-			ck, err := core.Mul(pj, big.NewInt(int64(k+1)), dp.Curve.Params().N)
-			if err != nil {
-				return nil, err
-			}
+			ck = ck.Mul(pj)
 			// 17. compute Xj = Xj x vk ^ ck in G
-			t, err := v[k].ScalarMult(ck)
-			if err != nil {
-				return nil, err
-			}
-			// Xj = Xj * t in G
-			publicShares[j], err = publicShares[j].Add(t)
-			if err != nil {
-				return nil, err
-			}
+			publicShares[j] = publicShares[j].Add(v[k].Mul(ck))
 		}
 	}
 
 	// 18. Compute πPSF = ProvePSF(ski.N, ski.φ(N), y, g, q, pi)
-	psfParams := paillier.PsfProofParams{
-		Curve:     dp.Curve,
-		SecretKey: dp.state.Sk,
-		Pi:        dp.id,
-		Y:         y,
+	psfParams, err := dp.psfParams(y)
+	if err != nil {
+		return nil, err
 	}
+	psfParams.SecretKey = dp.state.Sk
 	psfProof, err := psfParams.Prove()
 	if err != nil {
 		return nil, err
@@ -149,32 +138,48 @@ func (dp *DkgParticipant) DkgRound3(d map[uint32]*core.Witness, x map[uint32]*v1
 
 	dp.Round = 4
 	dp.state.Y = y
-	dp.state.Xi = xi.Value.BigInt()
+	dp.state.Xi = xi
 	dp.state.PublicShares = publicShares
 
 	return psfProof, nil
 }
 
+// psfParams returns the PSF proof parameters of this participant for the public key y.
+// Paillier proofs are still defined over elliptic.Curve so the points are converted here.
+func (dp *DkgParticipant) psfParams(y curves.Point) (*paillier.PsfProofParams, error) {
+	ec, err := dp.Curve.ToEllipticCurve()
+	if err != nil {
+		return nil, err
+	}
+	Y, err := curves.NewEcPoint(y)
+	if err != nil {
+		return nil, err
+	}
+	return &paillier.PsfProofParams{
+		Curve: ec,
+		Pi:    dp.id,
+		Y:     Y,
+	}, nil
+}
+
 // unmarshalFeldmanVerifiers converts a byte sequence into
 // a number of feldman verifiers
-func unmarshalFeldmanVerifiers(curve elliptic.Curve, msg []byte, verifierSize, threshold int) ([]*v1.ShareVerifier, error) {
+func unmarshalFeldmanVerifiers(curve *curves.Curve, msg []byte, verifierSize, threshold int) ([]curves.Point, error) {
 	if len(msg)%verifierSize != 0 {
 		return nil, fmt.Errorf("invalid committed verifier shares")
 	}
 	numShares := len(msg) / verifierSize
 
 	// 5. If [vj0,...,vjt] = ⊥, Abort
-	if numShares != threshold {
+	if numShares != threshold || numShares == 0 {
 		return nil, fmt.Errorf("invalid number of verifier shares")
 	}
 
 	// Extract verifiers from bytes
-	verifiers := make([]*v1.ShareVerifier, numShares)
+	verifiers := make([]curves.Point, numShares)
 	var err error
 	for k := 0; k < numShares; k++ {
-		value := make([]byte, verifierSize)
-		copy(value, msg[k*verifierSize:(k+1)*verifierSize])
-		verifiers[k], err = curves.PointFromBytesUncompressed(curve, value)
+		verifiers[k], err = curve.Point.FromAffineUncompressed(msg[k*verifierSize : (k+1)*verifierSize])
 		if err != nil {
 			return nil, err
 		}
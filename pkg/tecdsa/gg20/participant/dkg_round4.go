@@ -8,7 +8,6 @@ package participant
 
 import (
 	"fmt"
-	"math/big"
 
 	"github.com/TEENet-io/kryptology/internal"
 	"github.com/TEENet-io/kryptology/pkg/core/curves"
@@ -18,9 +17,9 @@ import (
 
 // DkgResult is all the data generated from the DKG
 type DkgResult struct {
-	PublicShares    []*curves.EcPoint
-	VerificationKey *curves.EcPoint
-	SigningKeyShare *big.Int
+	PublicShares    []curves.Point
+	VerificationKey curves.Point
+	SigningKeyShare curves.Scalar
 	EncryptionKey   *paillier.SecretKey
 	ParticipantData map[uint32]*DkgParticipantData
 }
@@ -49,9 +48,13 @@ func (dp *DkgParticipant) DkgRound4(psfProof map[uint32]paillier.PsfProof) (*Dkg
 		}
 	}
 
+	psfParams, err := dp.psfParams(dp.state.Y)
+	if err != nil {
+		return nil, err
+	}
 	verifyPsfParams := paillier.PsfVerifyParams{
-		Curve: dp.Curve,
-		Y:     dp.state.Y,
+		Curve: psfParams.Curve,
+		Y:     psfParams.Y,
 	}
 	// 1. for j = [1,...,n]
 	for id, p := range psfProof {
@@ -7,25 +7,24 @@
 package participant
 
 import (
-	"crypto/elliptic"
+	crand "crypto/rand"
+	"encoding/json"
 	"math/big"
 	"sync"
 	"testing"
 
-	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/stretchr/testify/require"
 
 	tt "github.com/TEENet-io/kryptology/internal"
 	"github.com/TEENet-io/kryptology/pkg/core"
 	"github.com/TEENet-io/kryptology/pkg/core/curves"
 	"github.com/TEENet-io/kryptology/pkg/paillier"
-	v1 "github.com/TEENet-io/kryptology/pkg/sharing/v1"
-	"github.com/TEENet-io/kryptology/pkg/tecdsa/gg20/dealer"
+	"github.com/TEENet-io/kryptology/pkg/sharing"
 	"github.com/TEENet-io/kryptology/pkg/tecdsa/gg20/proof"
 )
 
-func setupDkgRound3ParticipantMap(curve elliptic.Curve, t, n int) map[uint32]*DkgParticipant {
-	feldman, _ := v1.NewFeldman(uint32(t), uint32(n), curve)
+func setupDkgRound3ParticipantMap(curve *curves.Curve, t, n int) map[uint32]*DkgParticipant {
+	feldman, _ := sharing.NewFeldman(uint32(t), uint32(n), curve)
 	participants := make(map[uint32]*DkgParticipant, n)
 	prime1Idx := 0
 	pIds := make(map[uint32]*dkgParticipantData, n)
@@ -34,12 +33,13 @@ func setupDkgRound3ParticipantMap(curve elliptic.Curve, t, n int) map[uint32]*Dk
 	}
 
 	for i := 0; i < n; i++ {
-		u, _ := core.Rand(curve.Params().N)
-		v, x, _ := feldman.Split(u.Bytes())
+		u := curve.Scalar.Random(crand.Reader)
+		verifier, x, _ := feldman.Split(u, crand.Reader)
+		v := verifier.Commitments
 		sk, _ := paillier.NewSecretKey(testPrimes[prime1Idx], testPrimes[prime1Idx+1])
 		id := uint32(i + 1)
 		pIds[id].PublicKey = &sk.PublicKey
-		pIds[id].ProofParams = &dealer.ProofParams{}
+		pIds[id].ProofParams = dealerParams
 		participants[id] = &DkgParticipant{
 			Curve: curve,
 			id:    id,
@@ -47,6 +47,9 @@ func setupDkgRound3ParticipantMap(curve elliptic.Curve, t, n int) map[uint32]*Dk
 			state: &dkgstate{
 				Sk:                   sk,
 				Pk:                   &sk.PublicKey,
+				N:                    dealerParams.N,
+				H1:                   dealerParams.H1,
+				H2:                   dealerParams.H2,
 				Threshold:            uint32(t),
 				Limit:                uint32(n),
 				X:                    x,
@@ -60,6 +63,34 @@ func setupDkgRound3ParticipantMap(curve elliptic.Curve, t, n int) map[uint32]*Dk
 	return participants
 }
 
+// dkgRound3P2P returns the round 2 P2P messages participant id receives from all participants
+func dkgRound3P2P(t *testing.T, participants map[uint32]*DkgParticipant, id uint32) map[uint32]*DkgRound2P2PSend {
+	p2p := make(map[uint32]*DkgRound2P2PSend, len(participants))
+	for j, p := range participants {
+		facParams := paillier.FacProofParams{
+			SecretKey:    p.state.Sk,
+			RingPedersen: participants[id].proofParams().RingPedersen(),
+			Pi:           j,
+			Sid:          p.paillierSid(p.state.Threshold, p.state.Limit),
+		}
+		facProof, err := facParams.Prove()
+		require.NoError(t, err)
+		p2p[j] = &DkgRound2P2PSend{xij: p.state.X[id], facProof: facProof}
+	}
+	return p2p
+}
+
+// receivedP2P returns the round 2 P2P messages participant id receives from the other participants
+func receivedP2P(sent map[uint32]map[uint32]*DkgRound2P2PSend, id uint32) map[uint32]*DkgRound2P2PSend {
+	p2p := make(map[uint32]*DkgRound2P2PSend, len(sent))
+	for j, send := range sent {
+		if j != id {
+			p2p[j] = send[id]
+		}
+	}
+	return p2p
+}
+
 func setupDkgRound3Commitments(t *testing.T, participants map[uint32]*DkgParticipant, playerCnt int) map[uint32]*core.Witness {
 	var err error
 	// Setup commitments for each player so they can be passed as inputs
@@ -75,59 +106,90 @@ func setupDkgRound3Commitments(t *testing.T, participants map[uint32]*DkgPartici
 	return decommitments
 }
 
-func commitVerifiers(v []*v1.ShareVerifier) (core.Commitment, *core.Witness, error) {
+func commitVerifiers(v []curves.Point) (core.Commitment, *core.Witness, error) {
 	var bytes []byte
 	for _, vi := range v {
-		bytes = append(bytes, vi.Bytes()...)
+		bytes = append(bytes, vi.ToAffineUncompressed()...)
 	}
 	return core.Commit(bytes)
 }
 
+// Ensures that marshal-unmarshal DkgRound2P2PSend is the identity function
+func TestMarshalDkgRound2P2PSendRoundTrip(t *testing.T) {
+	participants := setupDkgRound3ParticipantMap(curves.K256(), 2, 2)
+	expected := dkgRound3P2P(t, participants, 1)[2]
+
+	// Marshal and test
+	jsonBytes, err := json.Marshal(expected)
+	require.NoError(t, err)
+	require.NotNil(t, jsonBytes)
+
+	// Unmarshal and test
+	var actual DkgRound2P2PSend
+	err = json.Unmarshal(jsonBytes, &actual)
+	require.NoError(t, err)
+	require.Equal(t, expected.xij, actual.xij)
+	require.Equal(t, expected.facProof, actual.facProof)
+	require.NoError(t, actual.facProof.Verify(&paillier.FacVerifyParams{
+		PublicKey:    participants[2].state.Pk,
+		RingPedersen: participants[1].proofParams().RingPedersen(),
+		Pi:           2,
+		Sid:          participants[2].paillierSid(participants[2].state.Threshold, participants[2].state.Limit),
+	}))
+}
+
+// Public shares must be X_j = Π v_k^{j^k}, the points of the signing key shares
+func TestDkgRound3PublicSharesMatchKeyShares(t *testing.T) {
+	curve := curves.K256()
+	playerCnt := 3
+	playerMin := 3
+	participants := setupDkgRound3ParticipantMap(curve, playerMin, playerCnt)
+	decommitments := setupDkgRound3Commitments(t, participants, playerCnt)
+
+	for id, p := range participants {
+		_, err := p.DkgRound3(decommitments, dkgRound3P2P(t, participants, id))
+		require.NoError(t, err)
+	}
+	for _, p := range participants {
+		for i, e := range p.state.PublicShares {
+			require.True(t, e.Equal(curve.ScalarBaseMult(participants[uint32(i+1)].state.Xi)))
+		}
+	}
+}
+
 func TestDkgRound3Works(t *testing.T) {
 	// Setup
-	curve := btcec.S256()
+	curve := curves.K256()
 	playerCnt := 3
 	playerMin := 2
 	participants := setupDkgRound3ParticipantMap(curve, playerMin, playerCnt)
 	decommitments := setupDkgRound3Commitments(t, participants, playerCnt)
 
 	// Actual test
-	res1, err := participants[1].DkgRound3(decommitments, map[uint32]*v1.ShamirShare{
-		1: participants[1].state.X[0],
-		2: participants[2].state.X[0],
-		3: participants[3].state.X[0],
-	})
+	res1, err := participants[1].DkgRound3(decommitments, dkgRound3P2P(t, participants, 1))
 	require.NoError(t, err)
 	require.NotNil(t, res1)
-	res2, err := participants[2].DkgRound3(decommitments, map[uint32]*v1.ShamirShare{
-		1: participants[1].state.X[1],
-		2: participants[2].state.X[1],
-		3: participants[3].state.X[1],
-	})
+	res2, err := participants[2].DkgRound3(decommitments, dkgRound3P2P(t, participants, 2))
 	require.NoError(t, err)
 	require.NotNil(t, res2)
-	res3, err := participants[3].DkgRound3(decommitments, map[uint32]*v1.ShamirShare{
-		1: participants[1].state.X[2],
-		2: participants[2].state.X[2],
-		3: participants[3].state.X[2],
-	})
+	res3, err := participants[3].DkgRound3(decommitments, dkgRound3P2P(t, participants, 3))
 	require.NoError(t, err)
 	require.NotNil(t, res3)
-	field := curves.NewField(curve.Params().N)
-	shamir, _ := v1.NewShamir(playerMin, playerCnt, field)
+	shamir, _ := sharing.NewShamir(uint32(playerMin), uint32(playerCnt), curve)
 
 	// Check that they all generated the same public key
-	require.Equal(t, participants[1].state.Y, participants[2].state.Y)
-	require.Equal(t, participants[1].state.Y, participants[3].state.Y)
+	require.True(t, participants[1].state.Y.Equal(participants[2].state.Y))
+	require.True(t, participants[1].state.Y.Equal(participants[3].state.Y))
 
 	// Check that they all generated the same public shares
 	for i, e := range participants[1].state.PublicShares {
-		require.Equal(t, e, participants[2].state.PublicShares[i])
-		require.Equal(t, e, participants[3].state.PublicShares[i])
+		require.True(t, e.Equal(participants[2].state.PublicShares[i]))
+		require.True(t, e.Equal(participants[3].state.PublicShares[i]))
 	}
-	share1 := v1.NewShamirShare(1, participants[1].state.Xi.Bytes(), field)
-	share2 := v1.NewShamirShare(2, participants[2].state.Xi.Bytes(), field)
-	share3 := v1.NewShamirShare(3, participants[3].state.Xi.Bytes(), field)
+
+	share1 := &sharing.ShamirShare{Id: 1, Value: participants[1].state.Xi.Bytes()}
+	share2 := &sharing.ShamirShare{Id: 2, Value: participants[2].state.Xi.Bytes()}
+	share3 := &sharing.ShamirShare{Id: 3, Value: participants[3].state.Xi.Bytes()}
 	secret12, err := shamir.Combine(share1, share2)
 	require.NoError(t, err)
 	secret13, err := shamir.Combine(share1, share3)
@@ -135,41 +197,31 @@ func TestDkgRound3Works(t *testing.T) {
 	secret23, err := shamir.Combine(share2, share3)
 	require.NoError(t, err)
 
-	require.Equal(t, secret12, secret13)
-	require.Equal(t, secret12, secret23)
-	pk, err := curves.NewScalarBaseMult(curve, new(big.Int).SetBytes(secret12))
-	require.NoError(t, err)
-	require.True(t, participants[1].state.Y.Equals(pk))
+	require.Equal(t, 0, secret12.Cmp(secret13))
+	require.Equal(t, 0, secret12.Cmp(secret23))
+	require.True(t, participants[1].state.Y.Equal(curve.ScalarBaseMult(secret12)))
 }
 
 func TestDkgRound3RepeatCall(t *testing.T) {
 	// Setup
-	curve := btcec.S256()
+	curve := curves.K256()
 	playerCnt := 3
 	playerMin := 2
 	participants := setupDkgRound3ParticipantMap(curve, playerMin, playerCnt)
 	decommitments := setupDkgRound3Commitments(t, participants, playerCnt)
 
 	// Actual test
-	res1, err := participants[1].DkgRound3(decommitments, map[uint32]*v1.ShamirShare{
-		1: participants[1].state.X[0],
-		2: participants[2].state.X[0],
-		3: participants[3].state.X[0],
-	})
+	res1, err := participants[1].DkgRound3(decommitments, dkgRound3P2P(t, participants, 1))
 	require.NoError(t, err)
 	require.NotNil(t, res1)
-	res2, err := participants[1].DkgRound3(decommitments, map[uint32]*v1.ShamirShare{
-		1: participants[1].state.X[0],
-		2: participants[2].state.X[0],
-		3: participants[3].state.X[0],
-	})
+	res2, err := participants[1].DkgRound3(decommitments, dkgRound3P2P(t, participants, 1))
 	require.Error(t, err)
 	require.Nil(t, res2)
 }
 
 func TestDkgRound3InvalidWitnesses(t *testing.T) {
 	// Setup
-	curve := btcec.S256()
+	curve := curves.K256()
 	playerCnt := 3
 	playerMin := 2
 	participants := setupDkgRound3ParticipantMap(curve, playerMin, playerCnt)
@@ -179,11 +231,7 @@ func TestDkgRound3InvalidWitnesses(t *testing.T) {
 	decommitments[2].Msg[1] ^= decommitments[1].Msg[1]
 
 	// Actual test
-	res1, err := participants[1].DkgRound3(decommitments, map[uint32]*v1.ShamirShare{
-		1: participants[1].state.X[0],
-		2: participants[2].state.X[0],
-		3: participants[3].state.X[0],
-	})
+	res1, err := participants[1].DkgRound3(decommitments, dkgRound3P2P(t, participants, 1))
 	require.Error(t, err)
 	require.Nil(t, res1)
 
@@ -194,47 +242,53 @@ func TestDkgRound3InvalidWitnesses(t *testing.T) {
 	// corrupt 3rd participant
 	decommitments[3].Msg[0] ^= decommitments[1].Msg[0]
 	decommitments[3].Msg[1] ^= decommitments[1].Msg[1]
-	res2, err := participants[1].DkgRound3(decommitments, map[uint32]*v1.ShamirShare{
-		1: participants[1].state.X[0],
-		2: participants[2].state.X[0],
-		3: participants[3].state.X[0],
-	})
+	res2, err := participants[1].DkgRound3(decommitments, dkgRound3P2P(t, participants, 1))
 	require.Error(t, err)
 	require.Nil(t, res2)
 }
 
+func TestDkgRound3InvalidFacProof(t *testing.T) {
+	curve := curves.K256()
+	participants := setupDkgRound3ParticipantMap(curve, 2, 3)
+	decommitments := setupDkgRound3Commitments(t, participants, 3)
+
+	// A proof for another prover's key
+	p2p := dkgRound3P2P(t, participants, 1)
+	p2p[2].facProof = p2p[3].facProof
+	_, err := participants[1].DkgRound3(decommitments, p2p)
+	require.Error(t, err)
+
+	// A missing proof
+	p2p = dkgRound3P2P(t, participants, 1)
+	p2p[3].facProof = nil
+	_, err = participants[1].DkgRound3(decommitments, p2p)
+	require.Error(t, err)
+}
+
 func TestDkgRound3InvalidShares(t *testing.T) {
 	// Setup
-	curve := btcec.S256()
+	curve := curves.K256()
 	playerCnt := 3
 	playerMin := 2
 	participants := setupDkgRound3ParticipantMap(curve, playerMin, playerCnt)
 	decommitments := setupDkgRound3Commitments(t, participants, playerCnt)
 
 	// corrupt 2nd participant share
-	temp := participants[2].state.X[0].Value
-	participants[2].state.X[0].Value = curves.NewField(curve.Params().N).NewElement(big.NewInt(1))
+	temp := participants[2].state.X[1].Value
+	participants[2].state.X[1].Value = curve.Scalar.One().Bytes()
 
 	// Actual test
-	res1, err := participants[1].DkgRound3(decommitments, map[uint32]*v1.ShamirShare{
-		1: participants[1].state.X[0],
-		2: participants[2].state.X[0],
-		3: participants[3].state.X[0],
-	})
+	res1, err := participants[1].DkgRound3(decommitments, dkgRound3P2P(t, participants, 1))
 	require.Error(t, err)
 	require.Nil(t, res1)
 
 	// restore 2nd participant share
-	participants[2].state.X[0].Value = temp
+	participants[2].state.X[1].Value = temp
 
 	// corrupt 3rd participant share
-	participants[3].state.X[0].Value = curves.NewField(curve.Params().N).NewElement(big.NewInt(1))
+	participants[3].state.X[1].Value = curve.Scalar.One().Bytes()
 
-	res2, err := participants[1].DkgRound3(decommitments, map[uint32]*v1.ShamirShare{
-		1: participants[1].state.X[0],
-		2: participants[2].state.X[0],
-		3: participants[3].state.X[0],
-	})
+	res2, err := participants[1].DkgRound3(decommitments, dkgRound3P2P(t, participants, 1))
 	require.Error(t, err)
 	require.Nil(t, res2)
 }
@@ -243,7 +297,7 @@ func TestDkgRound1Works(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping test in short mode.")
 	}
-	curve := btcec.S256()
+	curve := curves.K256()
 	total := 3
 	threshold := 2
 
@@ -334,7 +388,7 @@ func TestDkgRound1RepeatCall(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping test in short mode.")
 	}
-	curve := btcec.S256()
+	curve := curves.K256()
 	total := 3
 	threshold := 2
 	dkgParticipant := &DkgParticipant{
@@ -357,15 +411,26 @@ func TestDkgRound1RepeatCall(t *testing.T) {
 /*
 Tests for DKG Round 2
 */
+// dkgModProofs returns the Πmod proofs of DKG round 1 for the paillier key sk and tildeN = (2p+1)(2q+1)
+func dkgModProofs(sk *paillier.SecretKey, p, q *big.Int, id uint32, sid []byte) (*paillier.ModProof, *paillier.ModProof) {
+	proofPk, _ := (&paillier.ModProofParams{SecretKey: sk, Pi: id, Sid: sid}).Prove()
+	P := new(big.Int).Lsh(p, 1)
+	Q := new(big.Int).Lsh(q, 1)
+	skN, _ := paillier.NewSecretKey(P.Add(P, core.One), Q.Add(Q, core.One))
+	proofN, _ := (&paillier.ModProofParams{SecretKey: skN, Pi: id, Sid: sid}).Prove()
+	return proofPk, proofN
+}
+
 // Setup DKG Round2 parameters for 3 parties.
-func setupDkgRound2Params(curve elliptic.Curve, threshold, total int) (map[uint32]*DkgParticipant, map[uint32]*DkgRound1Bcast) {
+func setupDkgRound2Params(curve *curves.Curve, threshold, total int) (map[uint32]*DkgParticipant, map[uint32]*DkgRound1Bcast) {
 	dkgParticipants := make(map[uint32]*DkgParticipant, total)
 	dpOutputs := make(map[uint32]*DkgRound1Bcast, total)
-	feldman, _ := v1.NewFeldman(uint32(threshold), uint32(total), curve)
+	feldman, _ := sharing.NewFeldman(uint32(threshold), uint32(total), curve)
 
 	// Setup parameters for player 1
-	u1, _ := core.Rand(curve.Params().N)
-	v1, x1, _ := feldman.Split(u1.Bytes())
+	u1 := curve.Scalar.Random(crand.Reader)
+	verifier1, x1, _ := feldman.Split(u1, crand.Reader)
+	v1 := verifier1.Commitments
 	c1, d1, _ := commitVerifiers(v1)
 	sk1, _ := paillier.NewSecretKey(testPrimes[0], testPrimes[1])
 	pk1 := &sk1.PublicKey
@@ -428,10 +493,12 @@ func setupDkgRound2Params(curve elliptic.Curve, threshold, total int) (map[uint3
 		Proof1i:    proof11,
 		Proof2i:    proof12,
 	}
+	dpOutputs[1].ModProofPki, dpOutputs[1].ModProofNi = dkgModProofs(sk1, p1, q1, 1, dkgParticipants[1].paillierSid(uint32(threshold), uint32(total)))
 
 	// Setup parameters for player 2
-	u2, _ := core.Rand(curve.Params().N)
-	v2, x2, _ := feldman.Split(u2.Bytes())
+	u2 := curve.Scalar.Random(crand.Reader)
+	verifier2, x2, _ := feldman.Split(u2, crand.Reader)
+	v2 := verifier2.Commitments
 	c2, d2, _ := commitVerifiers(v2)
 	sk2, _ := paillier.NewSecretKey(testPrimes[1], testPrimes[2])
 	pk2 := &sk2.PublicKey
@@ -494,10 +561,12 @@ func setupDkgRound2Params(curve elliptic.Curve, threshold, total int) (map[uint3
 		Proof1i:    proof21,
 		Proof2i:    proof22,
 	}
+	dpOutputs[2].ModProofPki, dpOutputs[2].ModProofNi = dkgModProofs(sk2, p2, q2, 2, dkgParticipants[2].paillierSid(uint32(threshold), uint32(total)))
 
 	// Setup parameters for player 3
-	u3, _ := core.Rand(curve.Params().N)
-	v3, x3, _ := feldman.Split(u3.Bytes())
+	u3 := curve.Scalar.Random(crand.Reader)
+	verifier3, x3, _ := feldman.Split(u3, crand.Reader)
+	v3 := verifier3.Commitments
 	c3, d3, _ := commitVerifiers(v3)
 	sk3, _ := paillier.NewSecretKey(testPrimes[2], testPrimes[3])
 	pk3 := &sk3.PublicKey
@@ -560,12 +629,13 @@ func setupDkgRound2Params(curve elliptic.Curve, threshold, total int) (map[uint3
 		Proof1i:    proof31,
 		Proof2i:    proof32,
 	}
+	dpOutputs[3].ModProofPki, dpOutputs[3].ModProofNi = dkgModProofs(sk3, p3, q3, 3, dkgParticipants[3].paillierSid(uint32(threshold), uint32(total)))
 
 	return dkgParticipants, dpOutputs
 }
 
 func TestDkgRound2Works(t *testing.T) {
-	curve := btcec.S256()
+	curve := curves.K256()
 	total := 3
 	threshold := 2
 	dkgParticipants, dpOutputs := setupDkgRound2Params(curve, threshold, total)
@@ -598,7 +668,7 @@ func TestDkgRound2Works(t *testing.T) {
 
 // Test when the broadcast of Round 1 is tampered
 func TestDkgRound2Tampered(t *testing.T) {
-	curve := btcec.S256()
+	curve := curves.K256()
 	total := 3
 	threshold := 2
 	dkgParticipants, dpOutputs := setupDkgRound2Params(curve, threshold, total)
@@ -629,9 +699,22 @@ func TestDkgRound2Tampered(t *testing.T) {
 	require.Error(t, err)
 }
 
+// Test that the Πmod proofs of round 1 do not verify in another ceremony
+func TestDkgRound2OtherSession(t *testing.T) {
+	curve := curves.K256()
+	total := 3
+	threshold := 2
+	dkgParticipants, dpOutputs := setupDkgRound2Params(curve, threshold, total)
+
+	participant := dkgParticipants[1]
+	participant.SessionId = []byte("another ceremony")
+	_, _, err := participant.DkgRound2(dpOutputs)
+	require.Error(t, err)
+}
+
 // Test repeat call of DKG round 2
 func TestDkgRound2RepeatCall(t *testing.T) {
-	curve := btcec.S256()
+	curve := curves.K256()
 	total := 3
 	threshold := 2
 	dkgParticipants, dpOutputs := setupDkgRound2Params(curve, threshold, total)
@@ -653,7 +736,7 @@ func TestDkgRound2RepeatCall(t *testing.T) {
 
 // Test the case when the number of received broadcast messages is not enough
 func TestDkgRound2NotEnoughParties(t *testing.T) {
-	curve := btcec.S256()
+	curve := curves.K256()
 	total := 3
 	threshold := 2
 	dkgParticipants, dpOutputs := setupDkgRound2Params(curve, threshold, total)
@@ -670,33 +753,38 @@ func TestDkgRound2NotEnoughParties(t *testing.T) {
 	require.Nil(t, p2psend)
 }
 
+// Test when a Paillier modulus is not proven to be a Paillier-Blum modulus
+func TestDkgRound2InvalidModProof(t *testing.T) {
+	curve := curves.K256()
+	dkgParticipants, dpOutputs := setupDkgRound2Params(curve, 2, 3)
+	participant := dkgParticipants[1]
+
+	// Player 3 replays the proofs of player 2
+	dpOutputs[3].ModProofPki = dpOutputs[2].ModProofPki
+	_, _, err := participant.DkgRound2(dpOutputs)
+	require.Error(t, err)
+
+	dkgParticipants, dpOutputs = setupDkgRound2Params(curve, 2, 3)
+	dpOutputs[2].ModProofNi = nil
+	_, _, err = dkgParticipants[1].DkgRound2(dpOutputs)
+	require.Error(t, err)
+}
+
 func TestDkgRound4Works(t *testing.T) {
 	// Setup
-	curve := btcec.S256()
+	curve := curves.K256()
 	playerCnt := 3
 	playerMin := 2
 	participants := setupDkgRound3ParticipantMap(curve, playerMin, playerCnt)
 	decommitments := setupDkgRound3Commitments(t, participants, playerCnt)
 
-	res1, err := participants[1].DkgRound3(decommitments, map[uint32]*v1.ShamirShare{
-		1: participants[1].state.X[0],
-		2: participants[2].state.X[0],
-		3: participants[3].state.X[0],
-	})
+	res1, err := participants[1].DkgRound3(decommitments, dkgRound3P2P(t, participants, 1))
 	require.NoError(t, err)
 	require.NotNil(t, res1)
-	res2, err := participants[2].DkgRound3(decommitments, map[uint32]*v1.ShamirShare{
-		1: participants[1].state.X[1],
-		2: participants[2].state.X[1],
-		3: participants[3].state.X[1],
-	})
+	res2, err := participants[2].DkgRound3(decommitments, dkgRound3P2P(t, participants, 2))
 	require.NoError(t, err)
 	require.NotNil(t, res2)
-	res3, err := participants[3].DkgRound3(decommitments, map[uint32]*v1.ShamirShare{
-		1: participants[1].state.X[2],
-		2: participants[2].state.X[2],
-		3: participants[3].state.X[2],
-	})
+	res3, err := participants[3].DkgRound3(decommitments, dkgRound3P2P(t, participants, 3))
 	require.NoError(t, err)
 	require.NotNil(t, res3)
 
@@ -721,12 +809,10 @@ func TestDkgRound4Works(t *testing.T) {
 	require.NotNil(t, out3)
 
 	// check that the shares result in valid secret key and public key
-	field := curves.NewField(curve.Params().N)
-
-	shamir, _ := v1.NewShamir(playerMin, playerCnt, field)
-	share1 := v1.NewShamirShare(1, out1.SigningKeyShare.Bytes(), field)
-	share2 := v1.NewShamirShare(2, out2.SigningKeyShare.Bytes(), field)
-	share3 := v1.NewShamirShare(3, out3.SigningKeyShare.Bytes(), field)
+	shamir, _ := sharing.NewShamir(uint32(playerMin), uint32(playerCnt), curve)
+	share1 := &sharing.ShamirShare{Id: 1, Value: out1.SigningKeyShare.Bytes()}
+	share2 := &sharing.ShamirShare{Id: 2, Value: out2.SigningKeyShare.Bytes()}
+	share3 := &sharing.ShamirShare{Id: 3, Value: out3.SigningKeyShare.Bytes()}
 	secret12, err := shamir.Combine(share1, share2)
 	require.NoError(t, err)
 	secret13, err := shamir.Combine(share1, share3)
@@ -734,40 +820,26 @@ func TestDkgRound4Works(t *testing.T) {
 	secret23, err := shamir.Combine(share2, share3)
 	require.NoError(t, err)
 
-	require.Equal(t, secret12, secret13)
-	require.Equal(t, secret12, secret23)
-	pk, err := curves.NewScalarBaseMult(curve, new(big.Int).SetBytes(secret12))
-	require.NoError(t, err)
-	require.True(t, participants[1].state.Y.Equals(pk))
+	require.Equal(t, 0, secret12.Cmp(secret13))
+	require.Equal(t, 0, secret12.Cmp(secret23))
+	require.True(t, participants[1].state.Y.Equal(curve.ScalarBaseMult(secret12)))
 }
 
 func TestDkgRound4RepeatCall(t *testing.T) {
 	// Setup
-	curve := btcec.S256()
+	curve := curves.K256()
 	playerCnt := 3
 	playerMin := 2
 	participants := setupDkgRound3ParticipantMap(curve, playerMin, playerCnt)
 	decommitments := setupDkgRound3Commitments(t, participants, playerCnt)
 
-	res1, err := participants[1].DkgRound3(decommitments, map[uint32]*v1.ShamirShare{
-		1: participants[1].state.X[0],
-		2: participants[2].state.X[0],
-		3: participants[3].state.X[0],
-	})
+	res1, err := participants[1].DkgRound3(decommitments, dkgRound3P2P(t, participants, 1))
 	require.NoError(t, err)
 	require.NotNil(t, res1)
-	res2, err := participants[2].DkgRound3(decommitments, map[uint32]*v1.ShamirShare{
-		1: participants[1].state.X[1],
-		2: participants[2].state.X[1],
-		3: participants[3].state.X[1],
-	})
+	res2, err := participants[2].DkgRound3(decommitments, dkgRound3P2P(t, participants, 2))
 	require.NoError(t, err)
 	require.NotNil(t, res2)
-	res3, err := participants[3].DkgRound3(decommitments, map[uint32]*v1.ShamirShare{
-		1: participants[1].state.X[2],
-		2: participants[2].state.X[2],
-		3: participants[3].state.X[2],
-	})
+	res3, err := participants[3].DkgRound3(decommitments, dkgRound3P2P(t, participants, 3))
 	require.NoError(t, err)
 	require.NotNil(t, res3)
 
@@ -789,31 +861,19 @@ func TestDkgRound4RepeatCall(t *testing.T) {
 
 func TestDkgRound4NotEnoughProofs(t *testing.T) {
 	// Setup
-	curve := btcec.S256()
+	curve := curves.K256()
 	playerCnt := 3
 	playerMin := 2
 	participants := setupDkgRound3ParticipantMap(curve, playerMin, playerCnt)
 	decommitments := setupDkgRound3Commitments(t, participants, playerCnt)
 
-	res1, err := participants[1].DkgRound3(decommitments, map[uint32]*v1.ShamirShare{
-		1: participants[1].state.X[0],
-		2: participants[2].state.X[0],
-		3: participants[3].state.X[0],
-	})
+	res1, err := participants[1].DkgRound3(decommitments, dkgRound3P2P(t, participants, 1))
 	require.NoError(t, err)
 	require.NotNil(t, res1)
-	res2, err := participants[2].DkgRound3(decommitments, map[uint32]*v1.ShamirShare{
-		1: participants[1].state.X[1],
-		2: participants[2].state.X[1],
-		3: participants[3].state.X[1],
-	})
+	res2, err := participants[2].DkgRound3(decommitments, dkgRound3P2P(t, participants, 2))
 	require.NoError(t, err)
 	require.NotNil(t, res2)
-	res3, err := participants[3].DkgRound3(decommitments, map[uint32]*v1.ShamirShare{
-		1: participants[1].state.X[2],
-		2: participants[2].state.X[2],
-		3: participants[3].state.X[2],
-	})
+	res3, err := participants[3].DkgRound3(decommitments, dkgRound3P2P(t, participants, 3))
 	require.NoError(t, err)
 	require.NotNil(t, res3)
 
@@ -832,17 +892,13 @@ func TestDkgRound4NotEnoughProofs(t *testing.T) {
 
 func TestDkgRound4NoProofs(t *testing.T) {
 	// Setup
-	curve := btcec.S256()
+	curve := curves.K256()
 	playerCnt := 3
 	playerMin := 2
 	participants := setupDkgRound3ParticipantMap(curve, playerMin, playerCnt)
 	decommitments := setupDkgRound3Commitments(t, participants, playerCnt)
 
-	res1, err := participants[1].DkgRound3(decommitments, map[uint32]*v1.ShamirShare{
-		1: participants[1].state.X[0],
-		2: participants[2].state.X[0],
-		3: participants[3].state.X[0],
-	})
+	res1, err := participants[1].DkgRound3(decommitments, dkgRound3P2P(t, participants, 1))
 	require.NoError(t, err)
 	require.NotNil(t, res1)
 
@@ -854,31 +910,19 @@ func TestDkgRound4NoProofs(t *testing.T) {
 
 func TestDkgRound4WrongProofs(t *testing.T) {
 	// Setup
-	curve := btcec.S256()
+	curve := curves.K256()
 	playerCnt := 3
 	playerMin := 2
 	participants := setupDkgRound3ParticipantMap(curve, playerMin, playerCnt)
 	decommitments := setupDkgRound3Commitments(t, participants, playerCnt)
 
-	res1, err := participants[1].DkgRound3(decommitments, map[uint32]*v1.ShamirShare{
-		1: participants[1].state.X[0],
-		2: participants[2].state.X[0],
-		3: participants[3].state.X[0],
-	})
+	res1, err := participants[1].DkgRound3(decommitments, dkgRound3P2P(t, participants, 1))
 	require.NoError(t, err)
 	require.NotNil(t, res1)
-	res2, err := participants[2].DkgRound3(decommitments, map[uint32]*v1.ShamirShare{
-		1: participants[1].state.X[1],
-		2: participants[2].state.X[1],
-		3: participants[3].state.X[1],
-	})
+	res2, err := participants[2].DkgRound3(decommitments, dkgRound3P2P(t, participants, 2))
 	require.NoError(t, err)
 	require.NotNil(t, res2)
-	res3, err := participants[3].DkgRound3(decommitments, map[uint32]*v1.ShamirShare{
-		1: participants[1].state.X[2],
-		2: participants[2].state.X[2],
-		3: participants[3].state.X[2],
-	})
+	res3, err := participants[3].DkgRound3(decommitments, dkgRound3P2P(t, participants, 3))
 	require.NoError(t, err)
 	require.NotNil(t, res3)
 
@@ -901,7 +945,7 @@ func TestDkgFullRoundsWorks(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping test in short mode.")
 	}
-	curve := btcec.S256()
+	curve := curves.K256()
 	total := 3
 	threshold := 2
 	var err error
@@ -942,25 +986,13 @@ func TestDkgFullRoundsWorks(t *testing.T) {
 	decommitments[2] = dkgR2Bcast[2].Di
 	decommitments[3] = dkgR2Bcast[3].Di
 
-	dkgR3Out[1], err = dkgParticipants[1].DkgRound3(decommitments, map[uint32]*v1.ShamirShare{
-		1: dkgParticipants[1].state.X[0],
-		2: dkgParticipants[2].state.X[0],
-		3: dkgParticipants[3].state.X[0],
-	})
+	dkgR3Out[1], err = dkgParticipants[1].DkgRound3(decommitments, receivedP2P(dkgR2P2PSend, 1))
 	require.NoError(t, err)
 
-	dkgR3Out[2], err = dkgParticipants[2].DkgRound3(decommitments, map[uint32]*v1.ShamirShare{
-		1: dkgParticipants[1].state.X[1],
-		2: dkgParticipants[2].state.X[1],
-		3: dkgParticipants[3].state.X[1],
-	})
+	dkgR3Out[2], err = dkgParticipants[2].DkgRound3(decommitments, receivedP2P(dkgR2P2PSend, 2))
 	require.NoError(t, err)
 
-	dkgR3Out[3], err = dkgParticipants[3].DkgRound3(decommitments, map[uint32]*v1.ShamirShare{
-		1: dkgParticipants[1].state.X[2],
-		2: dkgParticipants[2].state.X[2],
-		3: dkgParticipants[3].state.X[2],
-	})
+	dkgR3Out[3], err = dkgParticipants[3].DkgRound3(decommitments, receivedP2P(dkgR2P2PSend, 3))
 	require.NoError(t, err)
 
 	// Run Dkg Round 4
@@ -971,12 +1003,10 @@ func TestDkgFullRoundsWorks(t *testing.T) {
 	}
 
 	// Check that the shares result in valid secret key and public key
-	field := curves.NewField(curve.Params().N)
-
-	shamir, _ := v1.NewShamir(threshold, total, field)
-	share1 := v1.NewShamirShare(1, dkgR4Out[1].SigningKeyShare.Bytes(), field)
-	share2 := v1.NewShamirShare(2, dkgR4Out[2].SigningKeyShare.Bytes(), field)
-	share3 := v1.NewShamirShare(3, dkgR4Out[3].SigningKeyShare.Bytes(), field)
+	shamir, _ := sharing.NewShamir(uint32(threshold), uint32(total), curve)
+	share1 := &sharing.ShamirShare{Id: 1, Value: dkgR4Out[1].SigningKeyShare.Bytes()}
+	share2 := &sharing.ShamirShare{Id: 2, Value: dkgR4Out[2].SigningKeyShare.Bytes()}
+	share3 := &sharing.ShamirShare{Id: 3, Value: dkgR4Out[3].SigningKeyShare.Bytes()}
 	secret12, err := shamir.Combine(share1, share2)
 	require.NoError(t, err)
 	secret13, err := shamir.Combine(share1, share3)
@@ -984,17 +1014,15 @@ func TestDkgFullRoundsWorks(t *testing.T) {
 	secret23, err := shamir.Combine(share2, share3)
 	require.NoError(t, err)
 
-	require.Equal(t, secret12, secret13)
-	require.Equal(t, secret12, secret23)
+	require.Equal(t, 0, secret12.Cmp(secret13))
+	require.Equal(t, 0, secret12.Cmp(secret23))
 
 	// Check the relationship of verification key and signing key is valid
-	pk, err := curves.NewScalarBaseMult(curve, new(big.Int).SetBytes(secret12))
-	require.NoError(t, err)
-	require.True(t, dkgParticipants[1].state.Y.Equals(pk))
+	require.True(t, dkgParticipants[1].state.Y.Equal(curve.ScalarBaseMult(secret12)))
 
 	// Check every participant has the same verification key
-	require.Equal(t, dkgParticipants[1].state.Y, dkgParticipants[2].state.Y)
-	require.Equal(t, dkgParticipants[1].state.Y, dkgParticipants[3].state.Y)
+	require.True(t, dkgParticipants[1].state.Y.Equal(dkgParticipants[2].state.Y))
+	require.True(t, dkgParticipants[1].state.Y.Equal(dkgParticipants[3].state.Y))
 
 	// Testing validity of paillier public key and secret key
 	// Check every participant receives equal paillier public keys from other parties
@@ -1036,8 +1064,8 @@ func TestDkgFullRoundsWorks(t *testing.T) {
 	require.NoError(t, err)
 
 	// Checking public shares are equal
-	require.Equal(t, dkgParticipants[1].state.PublicShares, dkgParticipants[2].state.PublicShares)
-	require.Equal(t, dkgParticipants[1].state.PublicShares, dkgParticipants[3].state.PublicShares)
+	requirePointsEqual(t, dkgParticipants[1].state.PublicShares, dkgParticipants[2].state.PublicShares)
+	requirePointsEqual(t, dkgParticipants[1].state.PublicShares, dkgParticipants[3].state.PublicShares)
 
 	// Checking proof params are equal
 	require.Equal(t, dkgR4Out[1].ParticipantData[2].ProofParams, dkgR4Out[3].ParticipantData[2].ProofParams)
@@ -1049,7 +1077,7 @@ func TestDkgFullRoundsParallel(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping test in short mode.")
 	}
-	curve := btcec.S256()
+	curve := curves.K256()
 	total := 3
 	threshold := 2
 
@@ -1135,11 +1163,7 @@ func TestDkgFullRoundsParallel(t *testing.T) {
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		result, err := dkgParticipants[1].DkgRound3(decommitments, map[uint32]*v1.ShamirShare{
-			1: dkgParticipants[1].state.X[0],
-			2: dkgParticipants[2].state.X[0],
-			3: dkgParticipants[3].state.X[0],
-		})
+		result, err := dkgParticipants[1].DkgRound3(decommitments, receivedP2P(dkgR2P2PSend, 1))
 		if err != nil {
 			errChan3 <- err
 			return
@@ -1152,11 +1176,7 @@ func TestDkgFullRoundsParallel(t *testing.T) {
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		result, err := dkgParticipants[2].DkgRound3(decommitments, map[uint32]*v1.ShamirShare{
-			1: dkgParticipants[1].state.X[1],
-			2: dkgParticipants[2].state.X[1],
-			3: dkgParticipants[3].state.X[1],
-		})
+		result, err := dkgParticipants[2].DkgRound3(decommitments, receivedP2P(dkgR2P2PSend, 2))
 		if err != nil {
 			errChan3 <- err
 			return
@@ -1169,11 +1189,7 @@ func TestDkgFullRoundsParallel(t *testing.T) {
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		result, err := dkgParticipants[3].DkgRound3(decommitments, map[uint32]*v1.ShamirShare{
-			1: dkgParticipants[1].state.X[2],
-			2: dkgParticipants[2].state.X[2],
-			3: dkgParticipants[3].state.X[2],
-		})
+		result, err := dkgParticipants[3].DkgRound3(decommitments, receivedP2P(dkgR2P2PSend, 3))
 		if err != nil {
 			errChan3 <- err
 			return
@@ -1218,12 +1234,10 @@ func TestDkgFullRoundsParallel(t *testing.T) {
 	}
 
 	// Check that the shares result in valid secret key and public key
-	field := curves.NewField(curve.Params().N)
-
-	shamir, _ := v1.NewShamir(threshold, total, field)
-	share1 := v1.NewShamirShare(1, dkgR4Out[1].SigningKeyShare.Bytes(), field)
-	share2 := v1.NewShamirShare(2, dkgR4Out[2].SigningKeyShare.Bytes(), field)
-	share3 := v1.NewShamirShare(3, dkgR4Out[3].SigningKeyShare.Bytes(), field)
+	shamir, _ := sharing.NewShamir(uint32(threshold), uint32(total), curve)
+	share1 := &sharing.ShamirShare{Id: 1, Value: dkgR4Out[1].SigningKeyShare.Bytes()}
+	share2 := &sharing.ShamirShare{Id: 2, Value: dkgR4Out[2].SigningKeyShare.Bytes()}
+	share3 := &sharing.ShamirShare{Id: 3, Value: dkgR4Out[3].SigningKeyShare.Bytes()}
 	secret12, err := shamir.Combine(share1, share2)
 	require.NoError(t, err)
 	secret13, err := shamir.Combine(share1, share3)
@@ -1231,17 +1245,15 @@ func TestDkgFullRoundsParallel(t *testing.T) {
 	secret23, err := shamir.Combine(share2, share3)
 	require.NoError(t, err)
 
-	require.Equal(t, secret12, secret13)
-	require.Equal(t, secret12, secret23)
+	require.Equal(t, 0, secret12.Cmp(secret13))
+	require.Equal(t, 0, secret12.Cmp(secret23))
 
 	// Check the relationship of verification key and signing key is valid
-	pk, err := curves.NewScalarBaseMult(curve, new(big.Int).SetBytes(secret12))
-	require.NoError(t, err)
-	require.True(t, dkgParticipants[1].state.Y.Equals(pk))
+	require.True(t, dkgParticipants[1].state.Y.Equal(curve.ScalarBaseMult(secret12)))
 
 	// Check every participant has the same verification key
-	require.Equal(t, dkgParticipants[1].state.Y, dkgParticipants[2].state.Y)
-	require.Equal(t, dkgParticipants[1].state.Y, dkgParticipants[3].state.Y)
+	require.True(t, dkgParticipants[1].state.Y.Equal(dkgParticipants[2].state.Y))
+	require.True(t, dkgParticipants[1].state.Y.Equal(dkgParticipants[3].state.Y))
 
 	// Testing validity of paillier public key and secret key
 	// Check every participant receives equal paillier public keys from other parties
@@ -1283,11 +1295,20 @@ func TestDkgFullRoundsParallel(t *testing.T) {
 	require.NoError(t, err)
 
 	// Checking public shares are equal
-	require.Equal(t, dkgParticipants[1].state.PublicShares, dkgParticipants[2].state.PublicShares)
-	require.Equal(t, dkgParticipants[1].state.PublicShares, dkgParticipants[3].state.PublicShares)
+	requirePointsEqual(t, dkgParticipants[1].state.PublicShares, dkgParticipants[2].state.PublicShares)
+	requirePointsEqual(t, dkgParticipants[1].state.PublicShares, dkgParticipants[3].state.PublicShares)
 
 	// Checking proof params are equal
 	require.Equal(t, dkgR4Out[1].ParticipantData[2].ProofParams, dkgR4Out[3].ParticipantData[2].ProofParams)
 	require.Equal(t, dkgR4Out[1].ParticipantData[3].ProofParams, dkgR4Out[2].ParticipantData[3].ProofParams)
 	require.Equal(t, dkgR4Out[2].ParticipantData[1].ProofParams, dkgR4Out[3].ParticipantData[1].ProofParams)
 }
+
+// requirePointsEqual checks that two lists of points are equal element-wise
+func requirePointsEqual(t *testing.T, expected, actual []curves.Point) {
+	t.Helper()
+	require.Len(t, actual, len(expected))
+	for i, e := range expected {
+		require.True(t, e.Equal(actual[i]), "point %d differs", i)
+	}
+}
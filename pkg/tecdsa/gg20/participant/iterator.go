@@ -0,0 +1,497 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package participant
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/TEENet-io/kryptology/internal"
+	"github.com/TEENet-io/kryptology/pkg/core"
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
+	"github.com/TEENet-io/kryptology/pkg/core/protocol"
+	"github.com/TEENet-io/kryptology/pkg/paillier"
+	"github.com/TEENet-io/kryptology/pkg/tecdsa/gg20/dealer"
+)
+
+// DkgIterator runs the GG20 DKG of one participant as a protocol.Iterator.
+//
+// The first call to Next takes a nil input. Every later call takes the protocol.Combine of the messages the
+// participant received from all other participants in the previous round. The last call returns a nil message.
+// Payloads are JSON encoded; protocol.Version1 is the only supported version.
+type DkgIterator struct {
+	protocol.Stepper
+	*DkgParticipant
+	result *DkgResult
+}
+
+// SignIterator runs the GG20 signing of one signer as a protocol.Iterator. See DkgIterator for the inputs of Next.
+type SignIterator struct {
+	protocol.Stepper
+	*Signer
+	signature *curves.EcdsaSignature
+}
+
+var (
+	// Static type assertions
+	_ protocol.Iterator = &DkgIterator{}
+	_ protocol.Iterator = &SignIterator{}
+)
+
+// NewDkgIterator creates the DKG of participant id out of total participants, threshold of which are needed to sign.
+// Participant ids are 1 to total. The ceremony's SessionId, if any, is set on the iterator before the first call to Next.
+func NewDkgIterator(curve *curves.Curve, id, threshold, total uint32, version uint) (*DkgIterator, error) {
+	if curve == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if err := dealer.CheckCurve(curve); err != nil {
+		return nil, err
+	}
+	if id == 0 || id > total {
+		return nil, fmt.Errorf("participant id %d is not in [1, %d]", id, total)
+	}
+	if err := CheckIteratorVersion(version); err != nil {
+		return nil, err
+	}
+	d := &DkgIterator{
+		DkgParticipant: &DkgParticipant{
+			Curve: curve,
+			id:    id,
+			Round: 1,
+			state: &dkgstate{
+				Threshold: threshold,
+				Limit:     total,
+			},
+		},
+	}
+	peers := make(map[uint32]bool, total-1)
+	for j := uint32(1); j <= total; j++ {
+		if j != id {
+			peers[j] = true
+		}
+	}
+	d.Stepper = protocol.NewStepper([]func(*protocol.Message) (*protocol.Message, error){
+		func(*protocol.Message) (*protocol.Message, error) {
+			bcast, err := d.DkgRound1(threshold, total)
+			if err != nil {
+				return nil, err
+			}
+			return EncodeRoundOutput(protocol.Gg20Dkg, version, id, 1, bcast, nil)
+		},
+		func(input *protocol.Message) (*protocol.Message, error) {
+			messages, err := protocol.SplitRound(input, protocol.Gg20Dkg, version, 1, peers)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			in := make(map[uint32]*DkgRound1Bcast, len(messages))
+			for j, m := range messages {
+				in[j] = new(DkgRound1Bcast)
+				if err := DecodePayload(m, protocol.BroadcastPayload, in[j]); err != nil {
+					return nil, err
+				}
+			}
+			bcast, p2p, err := d.DkgRound2(in)
+			if err != nil {
+				return nil, err
+			}
+			direct := make(map[uint32]interface{}, len(p2p))
+			for j, send := range p2p {
+				direct[j] = send
+			}
+			return EncodeRoundOutput(protocol.Gg20Dkg, version, id, 2, bcast, direct)
+		},
+		func(input *protocol.Message) (*protocol.Message, error) {
+			messages, err := protocol.SplitRound(input, protocol.Gg20Dkg, version, 2, peers)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			witnesses := make(map[uint32]*core.Witness, len(messages))
+			shares := make(map[uint32]*DkgRound2P2PSend, len(messages))
+			for j, m := range messages {
+				bcast := new(DkgRound2Bcast)
+				if err := DecodePayload(m, protocol.BroadcastPayload, bcast); err != nil {
+					return nil, err
+				}
+				send := new(DkgRound2P2PSend)
+				if err := DecodePayload(m, protocol.DirectPayload, send); err != nil {
+					return nil, err
+				}
+				if bcast.Di == nil || send.xij == nil || send.xij.Id != id {
+					return nil, fmt.Errorf("invalid dkg round 2 message from participant %d", j)
+				}
+				if err := send.xij.Validate(curve); err != nil {
+					return nil, err
+				}
+				witnesses[j] = bcast.Di
+				shares[j] = send
+			}
+			psfProof, err := d.DkgRound3(witnesses, shares)
+			if err != nil {
+				return nil, err
+			}
+			return EncodeRoundOutput(protocol.Gg20Dkg, version, id, 3, psfProof, nil)
+		},
+		func(input *protocol.Message) (*protocol.Message, error) {
+			messages, err := protocol.SplitRound(input, protocol.Gg20Dkg, version, 3, peers)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			proofs := make(map[uint32]paillier.PsfProof, len(messages))
+			for j, m := range messages {
+				var psfProof paillier.PsfProof
+				if err := DecodePayload(m, protocol.BroadcastPayload, &psfProof); err != nil {
+					return nil, err
+				}
+				proofs[j] = psfProof
+			}
+			d.result, err = d.DkgRound4(proofs)
+			if err != nil {
+				return nil, err
+			}
+			return nil, nil
+		},
+	})
+	return d, nil
+}
+
+// Result returns the JSON encoded dealer.ParticipantData of this participant, which NewSignIterator takes.
+func (d *DkgIterator) Result(version uint) (*protocol.Message, error) {
+	if !d.Complete() {
+		return nil, nil
+	}
+	if d.result == nil {
+		return nil, protocol.ErrNotInitialized
+	}
+	data, err := d.ParticipantData()
+	if err != nil {
+		return nil, err
+	}
+	return EncodeResult(protocol.Gg20Dkg, version, d.id, data)
+}
+
+// ParticipantData returns the key share and public values of this participant in the form a trusted dealer
+// hands them out, with the proof parameters of every participant.
+func (d *DkgIterator) ParticipantData() (*dealer.ParticipantData, error) {
+	if d.result == nil {
+		return nil, protocol.ErrNotInitialized
+	}
+	state := d.state
+	proofParams := make(map[uint32]*dealer.ProofParams, len(d.result.ParticipantData)+1)
+	encryptKeys := make(map[uint32]*paillier.PublicKey, len(d.result.ParticipantData)+1)
+	for j, data := range d.result.ParticipantData {
+		proofParams[j] = data.ProofParams
+		encryptKeys[j] = data.PublicKey
+	}
+	proofParams[d.id] = &dealer.ProofParams{N: state.N, H1: state.H1, H2: state.H2}
+	encryptKeys[d.id] = state.Pk
+
+	publicShares := make(map[uint32]*dealer.PublicShare, len(d.result.PublicShares))
+	for i, point := range d.result.PublicShares {
+		publicShares[uint32(i+1)] = &dealer.PublicShare{Point: point}
+	}
+	return &dealer.ParticipantData{
+		Id:         d.id,
+		DecryptKey: d.result.EncryptionKey,
+		SecretKeyShare: &dealer.Share{
+			Identifier: d.id,
+			Value:      d.result.SigningKeyShare,
+			Point:      d.result.PublicShares[d.id-1],
+		},
+		EcdsaPublicKey: d.result.VerificationKey,
+		KeyGenType:     dealer.DistributedKeyGenType{ProofParams: proofParams},
+		PublicShares:   publicShares,
+		EncryptKeys:    encryptKeys,
+	}, nil
+}
+
+// DecodeDkgResult decodes the result of a DkgIterator.
+func DecodeDkgResult(m *protocol.Message) (*dealer.ParticipantData, error) {
+	data := new(dealer.ParticipantData)
+	if err := DecodeResult(m, protocol.Gg20Dkg, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// NewSignIterator creates the signing of hash, a message digest smaller than the group order, by the participant
+// info. Signers are the ids of all signers, including info.Id.
+func NewSignIterator(info *dealer.ParticipantData, signers []uint32, hash []byte, version uint) (*SignIterator, error) {
+	if info == nil || info.SecretKeyShare == nil || info.EcdsaPublicKey == nil || info.KeyGenType == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if err := CheckIteratorVersion(version); err != nil {
+		return nil, err
+	}
+	chosen := make(map[uint32]*dealer.PublicShare, len(signers))
+	for _, id := range signers {
+		share, ok := info.PublicShares[id]
+		if !ok {
+			return nil, fmt.Errorf("no public share for signer %d", id)
+		}
+		chosen[id] = share
+	}
+	if _, ok := chosen[info.Id]; !ok {
+		return nil, fmt.Errorf("signers do not include %d", info.Id)
+	}
+	p := Participant{*info.SecretKeyShare, info.DecryptKey}
+	signer, err := p.PrepareToSign(info.EcdsaPublicKey, curves.VerifyEcdsa,
+		curves.GetCurveByName(info.EcdsaPublicKey.CurveName()), info.KeyGenType, chosen, info.EncryptKeys)
+	if err != nil {
+		return nil, err
+	}
+	s := &SignIterator{Signer: signer}
+	id := signer.id
+	peers := signer.state.cosigners
+	dkgMode := !info.KeyGenType.IsTrustedDealer()
+	s.Stepper = protocol.NewStepper([]func(*protocol.Message) (*protocol.Message, error){
+		func(*protocol.Message) (*protocol.Message, error) {
+			bcast, p2p, err := s.SignRound1()
+			if err != nil {
+				return nil, err
+			}
+			direct := make(map[uint32]interface{}, len(p2p))
+			for j, send := range p2p {
+				direct[j] = send
+			}
+			return EncodeRoundOutput(protocol.Gg20Sign, version, id, 1, bcast, direct)
+		},
+		func(input *protocol.Message) (*protocol.Message, error) {
+			messages, err := protocol.SplitRound(input, protocol.Gg20Sign, version, 1, peers)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			in := make(map[uint32]*Round1Bcast, len(messages))
+			var p2p map[uint32]*Round1P2PSend
+			if dkgMode {
+				p2p = make(map[uint32]*Round1P2PSend, len(messages))
+			}
+			for j, m := range messages {
+				in[j] = new(Round1Bcast)
+				if err := DecodePayload(m, protocol.BroadcastPayload, in[j]); err != nil {
+					return nil, err
+				}
+				if dkgMode {
+					p2p[j] = new(Round1P2PSend)
+					if err := DecodePayload(m, protocol.DirectPayload, p2p[j]); err != nil {
+						return nil, err
+					}
+				}
+			}
+			out, err := s.SignRound2(in, p2p)
+			if err != nil {
+				return nil, err
+			}
+			direct := make(map[uint32]interface{}, len(out))
+			for j, send := range out {
+				direct[j] = send
+			}
+			return EncodeRoundOutput(protocol.Gg20Sign, version, id, 2, nil, direct)
+		},
+		func(input *protocol.Message) (*protocol.Message, error) {
+			messages, err := protocol.SplitRound(input, protocol.Gg20Sign, version, 2, peers)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			in := make(map[uint32]*P2PSend, len(messages))
+			for j, m := range messages {
+				in[j] = new(P2PSend)
+				if err := DecodePayload(m, protocol.DirectPayload, in[j]); err != nil {
+					return nil, err
+				}
+			}
+			bcast, err := s.SignRound3(in)
+			if err != nil {
+				return nil, err
+			}
+			return EncodeRoundOutput(protocol.Gg20Sign, version, id, 3, bcast, nil)
+		},
+		func(input *protocol.Message) (*protocol.Message, error) {
+			messages, err := protocol.SplitRound(input, protocol.Gg20Sign, version, 3, peers)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			in := make(map[uint32]*Round3Bcast, len(messages))
+			for j, m := range messages {
+				in[j] = new(Round3Bcast)
+				if err := DecodePayload(m, protocol.BroadcastPayload, in[j]); err != nil {
+					return nil, err
+				}
+			}
+			bcast, err := s.SignRound4(in)
+			if err != nil {
+				return nil, err
+			}
+			return EncodeRoundOutput(protocol.Gg20Sign, version, id, 4, bcast, nil)
+		},
+		func(input *protocol.Message) (*protocol.Message, error) {
+			messages, err := protocol.SplitRound(input, protocol.Gg20Sign, version, 4, peers)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			in := make(map[uint32]*Round4Bcast, len(messages))
+			for j, m := range messages {
+				in[j] = new(Round4Bcast)
+				if err := DecodePayload(m, protocol.BroadcastPayload, in[j]); err != nil {
+					return nil, err
+				}
+			}
+			bcast, p2p, err := s.SignRound5(in)
+			if err != nil {
+				return nil, err
+			}
+			direct := make(map[uint32]interface{}, len(p2p))
+			for j, send := range p2p {
+				direct[j] = send
+			}
+			return EncodeRoundOutput(protocol.Gg20Sign, version, id, 5, bcast, direct)
+		},
+		func(input *protocol.Message) (*protocol.Message, error) {
+			messages, err := protocol.SplitRound(input, protocol.Gg20Sign, version, 5, peers)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			in := make(map[uint32]*Round5Bcast, len(messages))
+			var p2p map[uint32]*Round5P2PSend
+			if dkgMode {
+				p2p = make(map[uint32]*Round5P2PSend, len(messages))
+			}
+			for j, m := range messages {
+				in[j] = new(Round5Bcast)
+				if err := DecodePayload(m, protocol.BroadcastPayload, in[j]); err != nil {
+					return nil, err
+				}
+				if dkgMode {
+					p2p[j] = new(Round5P2PSend)
+					if err := DecodePayload(m, protocol.DirectPayload, p2p[j]); err != nil {
+						return nil, err
+					}
+				}
+			}
+			bcast, err := s.SignRound6Full(hash, in, p2p)
+			if err != nil {
+				return nil, err
+			}
+			return EncodeRoundOutput(protocol.Gg20Sign, version, id, 6, bcast, nil)
+		},
+		func(input *protocol.Message) (*protocol.Message, error) {
+			messages, err := protocol.SplitRound(input, protocol.Gg20Sign, version, 6, peers)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			in := make(map[uint32]*Round6FullBcast, len(messages))
+			for j, m := range messages {
+				in[j] = new(Round6FullBcast)
+				if err := DecodePayload(m, protocol.BroadcastPayload, in[j]); err != nil {
+					return nil, err
+				}
+			}
+			s.signature, err = s.SignOutput(in)
+			if err != nil {
+				return nil, err
+			}
+			return nil, nil
+		},
+	})
+	return s, nil
+}
+
+// Result returns the JSON encoded curves.EcdsaSignature.
+func (s *SignIterator) Result(version uint) (*protocol.Message, error) {
+	if !s.Complete() {
+		return nil, nil
+	}
+	if s.signature == nil {
+		return nil, protocol.ErrNotInitialized
+	}
+	return EncodeResult(protocol.Gg20Sign, version, s.id, s.signature)
+}
+
+// DecodeSignature decodes the result of a SignIterator.
+func DecodeSignature(m *protocol.Message) (*curves.EcdsaSignature, error) {
+	signature := new(curves.EcdsaSignature)
+	if err := DecodeResult(m, protocol.Gg20Sign, signature); err != nil {
+		return nil, err
+	}
+	return signature, nil
+}
+
+const resultPayload = "result"
+
+// CheckIteratorVersion returns an error unless version is supported by the GG20 iterators
+func CheckIteratorVersion(version uint) error {
+	if version != protocol.Version1 {
+		return fmt.Errorf("unsupported version %d", version)
+	}
+	return nil
+}
+
+// EncodeRoundOutput JSON encodes the broadcast and direct values of a round of a GG20 iterator.
+func EncodeRoundOutput(protocolName string, version uint, sender uint32, round int,
+	broadcast interface{}, direct map[uint32]interface{}) (*protocol.Message, error) {
+	if err := CheckIteratorVersion(version); err != nil {
+		return nil, err
+	}
+	var bcast []byte
+	if broadcast != nil {
+		var err error
+		if bcast, err = json.Marshal(broadcast); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+	p2p := make(map[uint32][]byte, len(direct))
+	for j, value := range direct {
+		payload, err := json.Marshal(value)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		p2p[j] = payload
+	}
+	return protocol.NewRoundOutput(protocolName, version, sender, round, bcast, p2p), nil
+}
+
+// DecodePayload JSON decodes the payload under key of a delivered message of a GG20 iterator.
+func DecodePayload(m *protocol.Message, key string, value interface{}) error {
+	payload, ok := m.Payloads[key]
+	if !ok {
+		sender, _ := protocol.Sender(m)
+		return fmt.Errorf("message from party %d has no %s payload", sender, key)
+	}
+	return errors.WithStack(json.Unmarshal(payload, value))
+}
+
+// EncodeResult JSON encodes the result of a GG20 iterator.
+func EncodeResult(protocolName string, version uint, id uint32, value interface{}) (*protocol.Message, error) {
+	if err := CheckIteratorVersion(version); err != nil {
+		return nil, err
+	}
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &protocol.Message{
+		Protocol: protocolName,
+		Version:  version,
+		Payloads: map[string][]byte{resultPayload: payload},
+		Metadata: map[string]string{protocol.SenderMetadataKey: protocol.PeerPayload(id)},
+	}, nil
+}
+
+// DecodeResult JSON decodes the result of a GG20 iterator of the given protocol.
+func DecodeResult(m *protocol.Message, protocolName string, value interface{}) error {
+	if m == nil {
+		return internal.ErrNilArguments
+	}
+	if m.Protocol != protocolName {
+		return fmt.Errorf("expected a %s result, got %s", protocolName, m.Protocol)
+	}
+	if err := CheckIteratorVersion(m.Version); err != nil {
+		return err
+	}
+	return DecodePayload(m, resultPayload, value)
+}
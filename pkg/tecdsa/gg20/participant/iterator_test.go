@@ -0,0 +1,230 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package participant
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/TEENet-io/kryptology/pkg/core"
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
+	"github.com/TEENet-io/kryptology/pkg/core/protocol"
+	"github.com/TEENet-io/kryptology/pkg/paillier"
+	"github.com/TEENet-io/kryptology/pkg/tecdsa/gg20/dealer"
+)
+
+// runIterators drives the iterators to completion, delivering the output of every party to all others
+func runIterators(t *testing.T, iterators map[uint32]protocol.Iterator) {
+	t.Helper()
+	inputs := make(map[uint32]*protocol.Message, len(iterators))
+	for {
+		outputs := make(map[uint32]*protocol.Message, len(iterators))
+		done := true
+		for id, iterator := range iterators {
+			output, err := iterator.Next(inputs[id])
+			if err == protocol.ErrProtocolFinished {
+				continue
+			}
+			require.NoError(t, err, "party %d", id)
+			if output != nil {
+				outputs[id] = output
+				done = false
+			}
+		}
+		if done {
+			return
+		}
+		for id := range iterators {
+			var delivered []*protocol.Message
+			for j, output := range outputs {
+				if j == id {
+					continue
+				}
+				if m := protocol.Deliver(output, id); m != nil {
+					delivered = append(delivered, m)
+				}
+			}
+			inputs[id] = nil
+			if len(delivered) > 0 {
+				combined, err := protocol.Combine(delivered)
+				require.NoError(t, err)
+				inputs[id] = combined
+			}
+		}
+	}
+}
+
+// dealParticipantData deals a 2 of 3 key on curve and returns the data of each participant
+func dealParticipantData(t *testing.T, curve *curves.Curve, useDistributed bool) map[uint32]*dealer.ParticipantData {
+	pk, sharesMap, err := dealer.NewDealerShares(curve, 2, 3, nil)
+	require.NoError(t, err)
+	pubSharesMap, err := dealer.PreparePublicShares(sharesMap)
+	require.NoError(t, err)
+
+	primes := genPrimesArray(6)
+	secretKeys := make(map[uint32]*paillier.SecretKey, 3)
+	pubKeys := make(map[uint32]*paillier.PublicKey, 3)
+	proofParams := make(map[uint32]*dealer.ProofParams, 3)
+	for i := range sharesMap {
+		secretKeys[i], err = paillier.NewSecretKey(primes[i-1].p, primes[i-1].q)
+		require.NoError(t, err)
+		pubKeys[i] = &secretKeys[i].PublicKey
+		proofParams[i], err = dealer.NewProofParamsWithPrimes(primes[i+2].p, primes[i+2].q)
+		require.NoError(t, err)
+	}
+	var keyGenType dealer.KeyGenType = dealer.TrustedDealerKeyGenType{ProofParams: dealerParams}
+	if useDistributed {
+		keyGenType = dealer.DistributedKeyGenType{ProofParams: proofParams}
+	}
+
+	data := make(map[uint32]*dealer.ParticipantData, 3)
+	for i := range sharesMap {
+		dealt := &dealer.ParticipantData{
+			Id:             i,
+			DecryptKey:     secretKeys[i],
+			SecretKeyShare: sharesMap[i],
+			EcdsaPublicKey: pk,
+			KeyGenType:     keyGenType,
+			PublicShares:   pubSharesMap,
+			EncryptKeys:    pubKeys,
+		}
+		// Participants receive their data serialized
+		bytes, err := json.Marshal(dealt)
+		require.NoError(t, err)
+		data[i] = new(dealer.ParticipantData)
+		require.NoError(t, json.Unmarshal(bytes, data[i]))
+	}
+	return data
+}
+
+// hashFor returns the digest of msg in the scalar field of curve
+func hashFor(t *testing.T, curve *curves.Curve, msg []byte) []byte {
+	ec, err := curve.ToEllipticCurve()
+	require.NoError(t, err)
+	hash, err := core.Hash(msg, ec)
+	require.NoError(t, err)
+	return hash.Bytes()
+}
+
+// verifySignature checks signature against the public key of the participant data
+func verifySignature(t *testing.T, data *dealer.ParticipantData, hash []byte, signature *curves.EcdsaSignature) bool {
+	pk, err := curves.NewEcPoint(data.EcdsaPublicKey)
+	require.NoError(t, err)
+	return curves.VerifyEcdsa(pk, hash, signature)
+}
+
+func TestSignIterator(t *testing.T) {
+	for _, curve := range []*curves.Curve{curves.K256(), curves.P256()} {
+		hash := hashFor(t, curve, []byte("iterator"))
+		for _, useDistributed := range []bool{false, true} {
+			data := dealParticipantData(t, curve, useDistributed)
+			signers := []uint32{1, 3}
+			iterators := make(map[uint32]protocol.Iterator, len(signers))
+			var err error
+			for _, id := range signers {
+				iterators[id], err = NewSignIterator(data[id], signers, hash, protocol.Version1)
+				require.NoError(t, err)
+			}
+			runIterators(t, iterators)
+
+			for _, iterator := range iterators {
+				result, err := iterator.Result(protocol.Version1)
+				require.NoError(t, err)
+				signature, err := DecodeSignature(result)
+				require.NoError(t, err)
+				require.True(t, verifySignature(t, data[1], hash, signature), curve.Name)
+
+				_, err = iterator.Next(nil)
+				require.ErrorIs(t, err, protocol.ErrProtocolFinished)
+			}
+		}
+	}
+}
+
+func TestSignIteratorInvalidInput(t *testing.T) {
+	data := dealParticipantData(t, curves.K256(), false)
+	hash := make([]byte, 32)
+
+	_, err := NewSignIterator(data[1], []uint32{1, 2}, hash, protocol.Version0)
+	require.Error(t, err)
+	_, err = NewSignIterator(data[1], []uint32{2, 3}, hash, protocol.Version1)
+	require.Error(t, err)
+	_, err = NewSignIterator(data[1], []uint32{1, 4}, hash, protocol.Version1)
+	require.Error(t, err)
+
+	iterator, err := NewSignIterator(data[1], []uint32{1, 2}, hash, protocol.Version1)
+	require.NoError(t, err)
+	result, err := iterator.Result(protocol.Version1)
+	require.NoError(t, err)
+	require.Nil(t, result)
+
+	_, err = iterator.Next(nil)
+	require.NoError(t, err)
+	// Round 2 input must hold the message of every cosigner, from round 1
+	_, err = iterator.Next(protocol.NewRoundOutput(protocol.Gg20Sign, protocol.Version1, 2, 2, []byte("{}"), nil))
+	require.Error(t, err)
+	combined, err := protocol.Combine([]*protocol.Message{
+		protocol.Deliver(protocol.NewRoundOutput(protocol.Gg20Sign, protocol.Version1, 3, 1, []byte("{}"), nil), 1),
+	})
+	require.NoError(t, err)
+	_, err = iterator.Next(combined)
+	require.Error(t, err)
+}
+
+func TestDkgIterator(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+	curve := curves.P256()
+	iterators := make(map[uint32]protocol.Iterator, 3)
+	for id := uint32(1); id <= 3; id++ {
+		iterator, err := NewDkgIterator(curve, id, 2, 3, protocol.Version1)
+		require.NoError(t, err)
+		iterators[id] = iterator
+	}
+	runIterators(t, iterators)
+
+	data := make(map[uint32]*dealer.ParticipantData, 3)
+	for id, iterator := range iterators {
+		result, err := iterator.Result(protocol.Version1)
+		require.NoError(t, err)
+		data[id], err = DecodeDkgResult(result)
+		require.NoError(t, err)
+		require.False(t, data[id].KeyGenType.IsTrustedDealer())
+		require.True(t, data[id].EcdsaPublicKey.Equal(data[1].EcdsaPublicKey))
+	}
+
+	hash := hashFor(t, curve, []byte("dkg iterator"))
+	signers := []uint32{2, 3}
+	signIterators := make(map[uint32]protocol.Iterator, 2)
+	var err error
+	for _, id := range signers {
+		signIterators[id], err = NewSignIterator(data[id], signers, hash, protocol.Version1)
+		require.NoError(t, err)
+	}
+	runIterators(t, signIterators)
+	result, err := signIterators[2].Result(protocol.Version1)
+	require.NoError(t, err)
+	signature, err := DecodeSignature(result)
+	require.NoError(t, err)
+	require.True(t, verifySignature(t, data[1], hash, signature))
+}
+
+func TestNewDkgIteratorInvalidInput(t *testing.T) {
+	_, err := NewDkgIterator(nil, 1, 2, 3, protocol.Version1)
+	require.Error(t, err)
+	_, err = NewDkgIterator(curves.ED25519(), 1, 2, 3, protocol.Version1)
+	require.Error(t, err)
+	_, err = NewDkgIterator(curves.K256(), 0, 2, 3, protocol.Version1)
+	require.Error(t, err)
+	_, err = NewDkgIterator(curves.K256(), 4, 2, 3, protocol.Version1)
+	require.Error(t, err)
+	_, err = NewDkgIterator(curves.K256(), 1, 2, 3, protocol.Version2)
+	require.Error(t, err)
+}
@@ -8,7 +8,8 @@
 package participant
 
 import (
-	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/binary"
 	"fmt"
 	"math/big"
 	"reflect"
@@ -16,8 +17,9 @@ import (
 	"github.com/TEENet-io/kryptology/internal"
 	"github.com/TEENet-io/kryptology/pkg/core"
 	"github.com/TEENet-io/kryptology/pkg/core/curves"
+	"github.com/TEENet-io/kryptology/pkg/core/protocol"
 	"github.com/TEENet-io/kryptology/pkg/paillier"
-	v1 "github.com/TEENet-io/kryptology/pkg/sharing/v1"
+	"github.com/TEENet-io/kryptology/pkg/sharing"
 	"github.com/TEENet-io/kryptology/pkg/tecdsa/gg20/dealer"
 )
 
@@ -30,20 +32,23 @@ type Participant struct {
 // Signer is a tECDSA player that holds the additive shares needed for performing the signing operation
 type Signer struct {
 	sk              *paillier.SecretKey            // paillier secret key assigned to this signer
-	share           *v1.ShamirShare                // secret signing share for this signer
+	share           curves.Scalar                  // additive secret signing share for this signer
 	publicSharesMap map[uint32]*dealer.PublicShare // public shares of our cosigners
 	id              uint32                         // The ID assigned to this signer's shamir share
 	// This is minimum number of signers required to produce a valid signature,
 	// not the security threshold (as specified in [spec][GG20])
 	threshold uint
-	PublicKey *curves.EcPoint
-	Curve     elliptic.Curve
+	PublicKey curves.Point
+	Curve     *curves.Curve
 	Round     uint   // current signing round in our linear state machine
 	state     *state // Accumulated intermediate values associated with signing
 }
 
 // NewSigner C=creates a new signer from a dealer-provided output and a specific set of co-signers
 func NewSigner(info *dealer.ParticipantData, cosigners []uint32) (*Signer, error) {
+	if info == nil || info.SecretKeyShare == nil || info.EcdsaPublicKey == nil {
+		return nil, internal.ErrNilArguments
+	}
 	// Create the participant
 	p := Participant{*info.SecretKeyShare, info.DecryptKey}
 
@@ -59,7 +64,7 @@ func NewSigner(info *dealer.ParticipantData, cosigners []uint32) (*Signer, error
 		func(*curves.EcPoint, []byte, *curves.EcdsaSignature) bool {
 			return true
 		},
-		info.EcdsaPublicKey.Curve,
+		curves.GetCurveByName(info.EcdsaPublicKey.CurveName()),
 		info.KeyGenType,
 		chosenOnes,
 		info.EncryptKeys)
@@ -140,9 +145,9 @@ type state struct {
 	cosigners map[uint32]bool
 
 	// Round 1 variables
-	ki     *big.Int
-	gammai *big.Int
-	Gammai *curves.EcPoint
+	ki     curves.Scalar
+	gammai curves.Scalar
+	Gammai curves.Point
 	Ci     core.Commitment
 	Di     *core.Witness
 	ci     paillier.Ciphertext
@@ -156,86 +161,74 @@ type state struct {
 	pks   map[uint32]*paillier.PublicKey
 
 	// Round 3 variables
-	deltai *big.Int
-	sigmai *big.Int
+	deltai curves.Scalar
+	sigmai curves.Scalar
 
 	// Round 4 variables
-	delta *big.Int
+	delta curves.Scalar
 
 	// Round 5 variables
-	r     *big.Int
-	Rbari *curves.EcPoint
-	Rbark *curves.EcPoint
-	R     *curves.EcPoint
+	r     curves.Scalar
+	Rbari curves.Point
+	Rbark curves.Point
+	R     curves.Point
 
 	// Round 6 variables
-	si *big.Int
+	Rbarj map[uint32]curves.Point
+	Sj    map[uint32]curves.Point
+	si    curves.Scalar
 }
 
 // convertToAdditive takes all the publicShares and changes them to their additive form
 // for this participant. Only t shares are needed for this step
 // [spec] §4.figure 4: convertToAdditive
-func (p Participant) convertToAdditive(curve elliptic.Curve, publicSharesMap map[uint32]*dealer.PublicShare) (*Signer, error) {
+func (p Participant) convertToAdditive(curve *curves.Curve, publicSharesMap map[uint32]*dealer.PublicShare) (*Signer, error) {
 	if publicSharesMap == nil {
 		return nil, fmt.Errorf("public shares cannot be nil")
 	}
 	if len(publicSharesMap) < 2 {
 		return nil, fmt.Errorf("threshold must be at least 2")
 	}
-	emptyP := Participant{Share: dealer.Share{}}
-	if p == emptyP || p.Value == nil || p.Point == nil {
+	if p.Value == nil || p.Point == nil {
 		return nil, fmt.Errorf("participant share cannot be nil")
 	}
 
-	field := curves.NewField(curve.Params().N)
-	x, err := makeXMap(curve, publicSharesMap)
+	identities := make([]uint32, 0, len(publicSharesMap))
+	for i, ps := range publicSharesMap {
+		if ps == nil || ps.Point == nil {
+			return nil, internal.ErrNilArguments
+		}
+		identities = append(identities, i)
+	}
+	shamir, err := sharing.NewShamir(uint32(len(publicSharesMap)), uint32(len(publicSharesMap)), curve)
 	if err != nil {
 		return nil, err
 	}
+	// lambda(j) = \Prod_{j \= k} { x_k / (x_k - x_j) }
+	lagrange, err := shamir.LagrangeCoeffs(identities)
+	if err != nil {
+		return nil, fmt.Errorf("unable to calculate additive shares due to duplicates")
+	}
 
 	additiveMap := make(map[uint32]*dealer.PublicShare, len(publicSharesMap))
-	var maxIndex uint32 = 0
-	var privateKeyShare *v1.ShamirShare
-
-	// Compute lagrange coefficients
-	for j := range publicSharesMap {
-		// l is our lambda_j
-		l := field.One()
-		for k := range publicSharesMap {
-			// Don't compute lagrange coefficients for self-point
-			if j == k {
-				continue
-			}
-
-			// lambda(j) = \Prod_{j \= k} { x_k / (x_k - x_j) }
-			den := x[k].Sub(x[j])
-			if den.IsEqual(field.Zero()) {
-				return nil, fmt.Errorf("unable to calculate additive shares due to duplicates")
-			}
-			l = l.Mul(x[k].Div(den))
-		}
-
+	var privateKeyShare curves.Scalar
+	for j, l := range lagrange {
 		// Additive public shares for all signers this round
-		w, err := publicSharesMap[j].Point.ScalarMult(l.BigInt())
-		if err != nil {
-			return nil, err
-		}
-
-		additiveMap[j] = &dealer.PublicShare{Point: w}
-		if j > maxIndex {
-			maxIndex = j
-		}
+		additiveMap[j] = &dealer.PublicShare{Point: publicSharesMap[j].Point.Mul(l)}
 
 		// compute additive private share
 		if j == p.Identifier {
-			wI := l.Mul(field.ElementFromBytes(p.Share.Value.Bytes()))
-			privateKeyShare = v1.NewShamirShare(p.Share.Identifier, wI.Bytes(), field)
+			privateKeyShare = l.Mul(p.Value)
 		}
 	}
+	if privateKeyShare == nil {
+		return nil, fmt.Errorf("public shares do not include participant %d", p.Identifier)
+	}
 
 	return &Signer{
 		sk:              p.sk,
 		share:           privateKeyShare,
+		id:              p.Identifier,
 		publicSharesMap: additiveMap,
 		Round:           1,
 		state:           &state{},
@@ -244,20 +237,25 @@ func (p Participant) convertToAdditive(curve elliptic.Curve, publicSharesMap map
 
 // PrepareToSign creates a Signer out of a Participant. The expected co-signers for the signing rounds are
 // expected to be exactly those included in the publicSharesMap
-func (p Participant) PrepareToSign(pubKey *curves.EcPoint,
+func (p Participant) PrepareToSign(pubKey curves.Point,
 	verify curves.EcdsaVerify,
-	curve elliptic.Curve,
+	curve *curves.Curve,
 	keyGenType dealer.KeyGenType,
 	publicSharesMap map[uint32]*dealer.PublicShare,
 	pubKeys map[uint32]*paillier.PublicKey) (*Signer, error) {
 	if pubKey == nil || verify == nil || curve == nil || keyGenType == nil || len(publicSharesMap) < 1 {
 		return nil, internal.ErrNilArguments
 	}
+	if err := dealer.CheckCurve(curve); err != nil {
+		return nil, err
+	}
+	if pubKey.CurveName() != curve.Name {
+		return nil, fmt.Errorf("public key is not on curve %s", curve.Name)
+	}
 	signer, err := p.convertToAdditive(curve, publicSharesMap)
 	if err != nil {
 		return nil, err
 	}
-	signer.id = signer.share.Identifier
 
 	signer.state.cosigners = make(map[uint32]bool, len(publicSharesMap)-1)
 	for id := range publicSharesMap {
@@ -279,28 +277,16 @@ func (p Participant) PrepareToSign(pubKey *curves.EcPoint,
 	return signer, nil
 }
 
-// Creates a map of polynomial x-coordinates that are field elements.
-func makeXMap(curve elliptic.Curve, publicSharesMap map[uint32]*dealer.PublicShare) (map[uint32]*curves.Element, error) {
-	field := curves.NewField(curve.Params().N)
-	x := make(map[uint32]*curves.Element, len(publicSharesMap))
-
-	// Convert identifiers to a field element
-	for i, ps := range publicSharesMap {
-		if ps == nil {
-			return nil, internal.ErrNilArguments
-		}
-
-		x[i] = field.ElementFromBytes([]byte{byte(i)})
-	}
-	return x, nil
-}
-
 // DkgParticipant is a DKG player that contains information needed to perform DKG rounds and finally get info for signing rounds.
 type DkgParticipant struct {
-	Curve elliptic.Curve
-	state *dkgstate
-	id    uint32
-	Round uint
+	Curve *curves.Curve
+	// SessionId identifies the DKG ceremony and must be the same for all participants. The Πmod and Πfac proofs are
+	// bound to it, so that they cannot be replayed in another ceremony. It is set before round 1 and may be empty, in
+	// which case the proofs are only bound to the curve, the threshold and the number of participants.
+	SessionId []byte
+	state     *dkgstate
+	id        uint32
+	Round     uint
 }
 
 type dkgParticipantData struct {
@@ -319,18 +305,45 @@ type dkgstate struct {
 	H1 *big.Int
 	H2 *big.Int
 	// This participants verifiers from FeldmanShare
-	V []*v1.ShareVerifier
+	V []curves.Point
 	// This participants shares from FeldmanShare
-	X         []*v1.ShamirShare
-	Y         *curves.EcPoint
+	X         map[uint32]*sharing.ShamirShare
+	Y         curves.Point
 	Threshold uint32
 	Limit     uint32
 	// Commitments and paillier public keys received from other participants
 	otherParticipantData map[uint32]*dkgParticipantData
 	// xi returned from Round 3
-	Xi *big.Int
+	Xi curves.Scalar
 	// X1,...,Xn returned from Round 3
-	PublicShares []*curves.EcPoint
+	PublicShares []curves.Point
+}
+
+// proofParams returns the Ring-Pedersen parameters generated in DKG round 1
+func (dp *DkgParticipant) proofParams() *dealer.ProofParams {
+	return &dealer.ProofParams{N: dp.state.N, H1: dp.state.H1, H2: dp.state.H2}
+}
+
+// paillierSid returns the session id of the Paillier proofs of a DKG with the given threshold and participants
+func (dp *DkgParticipant) paillierSid(threshold, total uint32) []byte {
+	return PaillierProofSid(protocol.Gg20Dkg, []byte(dp.Curve.Name), uint32Bytes(threshold), uint32Bytes(total), dp.SessionId)
+}
+
+// PaillierProofSid hashes the protocol name and the values that identify a ceremony into the session id of its
+// Πmod, Πprm and Πfac proofs. Each value is length prefixed.
+func PaillierProofSid(protocolName string, values ...[]byte) []byte {
+	h := sha256.New()
+	for _, v := range append([][]byte{[]byte(protocolName)}, values...) {
+		_, _ = h.Write(uint32Bytes(uint32(len(v))))
+		_, _ = h.Write(v)
+	}
+	return h.Sum(nil)
+}
+
+func uint32Bytes(v uint32) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, v)
+	return b
 }
 
 // Check DKG round number is valid
@@ -340,3 +353,43 @@ func (dp *DkgParticipant) verifyDkgRound(dkground uint) error {
 	}
 	return nil
 }
+
+// curveOrder returns the order q of the group of a curve accepted by dealer.CheckCurve
+func curveOrder(curve *curves.Curve) *big.Int {
+	ec, err := curve.ToEllipticCurve()
+	if err != nil {
+		return nil
+	}
+	return ec.Params().N
+}
+
+// affine returns the affine coordinates of a point of a curve accepted by dealer.CheckCurve
+func affine(p curves.Point) (x, y *big.Int) {
+	b := p.ToAffineUncompressed()
+	fieldSize := (len(b) - 1) / 2
+	return new(big.Int).SetBytes(b[1 : fieldSize+1]), new(big.Int).SetBytes(b[fieldSize+1:])
+}
+
+// hashToScalar converts a message digest to m ∈ Z_q, rejecting digests outside the field
+func hashToScalar(curve *curves.Curve, hash []byte) (curves.Scalar, error) {
+	m := new(big.Int).SetBytes(hash)
+	if err := core.In(m, curveOrder(curve)); err != nil {
+		return nil, err
+	}
+	return curve.Scalar.SetBigInt(m)
+}
+
+// addBigInts computes s + \sum_i v_i mod q
+func addBigInts(curve *curves.Curve, s curves.Scalar, v ...*big.Int) (curves.Scalar, error) {
+	for _, vi := range v {
+		if vi == nil {
+			return nil, internal.ErrNilArguments
+		}
+		e, err := curve.Scalar.SetBigInt(vi)
+		if err != nil {
+			return nil, err
+		}
+		s = s.Add(e)
+	}
+	return s, nil
+}
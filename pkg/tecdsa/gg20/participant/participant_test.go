@@ -20,12 +20,13 @@ import (
 
 	"github.com/TEENet-io/kryptology/internal"
 	"github.com/TEENet-io/kryptology/pkg/core"
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
 	"github.com/TEENet-io/kryptology/pkg/paillier"
 	"github.com/TEENet-io/kryptology/pkg/tecdsa/gg20/dealer"
 )
 
 func TestConvertToAdditiveWorks(t *testing.T) {
-	curve := btcec.S256()
+	curve := curves.K256()
 	_, shares, err := dealer.NewDealerShares(curve, 3, 5, nil)
 	if err != nil {
 		t.Errorf("NewDealerShares failed: %v", err)
@@ -36,13 +37,7 @@ func TestConvertToAdditiveWorks(t *testing.T) {
 	}
 
 	for _, s := range shares {
-		pi := Participant{
-			dealer.Share{
-				ShamirShare: s.ShamirShare,
-				Point:       s.Point,
-			},
-			nil,
-		}
+		pi := Participant{*s, nil}
 		_, err := pi.convertToAdditive(curve, publicShares)
 		if err != nil {
 			t.Errorf("convertToAdditive failed: %v", err)
@@ -51,7 +46,7 @@ func TestConvertToAdditiveWorks(t *testing.T) {
 }
 
 func TestConvertToAdditiveNil(t *testing.T) {
-	curve := btcec.S256()
+	curve := curves.K256()
 	var publicSharesMap map[uint32]*dealer.PublicShare
 
 	pi := Participant{Share: dealer.Share{}}
@@ -75,7 +70,7 @@ func TestConvertToAdditiveNil(t *testing.T) {
 }
 
 func TestConvertToAdditiveNotEnoughShares(t *testing.T) {
-	curve := btcec.S256()
+	curve := curves.K256()
 	_, shares, err := dealer.NewDealerShares(curve, 2, 3, nil)
 	if err != nil {
 		t.Errorf("NewDealerShares failed: %v", err)
@@ -95,7 +90,7 @@ func TestConvertToAdditiveNotEnoughShares(t *testing.T) {
 }
 
 func TestConvertToAdditiveRecombine(t *testing.T) {
-	curve := btcec.S256()
+	curve := curves.K256()
 	pk, sharesMap, err := dealer.NewDealerShares(curve, 3, 5, nil)
 	if err != nil {
 		t.Errorf("NewDealerShares failed: %v", err)
@@ -103,12 +98,7 @@ func TestConvertToAdditiveRecombine(t *testing.T) {
 
 	// 5*4*3 possible combinations, try them all.
 	for i, s := range sharesMap {
-		pi := Participant{
-			dealer.Share{
-				ShamirShare: s.ShamirShare,
-				Point:       s.Point,
-			}, nil,
-		}
+		pi := Participant{*s, nil}
 		for j := range sharesMap {
 			if i == j {
 				continue
@@ -140,14 +130,14 @@ func TestConvertToAdditiveRecombine(t *testing.T) {
 				}
 
 				// See if combining works
-				x := new(big.Int)
-				y := new(big.Int)
+				sum := curve.NewIdentityPoint()
 				for _, ps := range pss.publicSharesMap {
-					x, y = curve.Add(x, y, ps.Point.X, ps.Point.Y)
+					sum = sum.Add(ps.Point)
 				}
+				require.True(t, pk.Equal(sum))
 
-				require.Equal(t, pk.X, x)
-				require.Equal(t, pk.Y, y)
+				// The additive shares of the signers sum to the secret key
+				require.True(t, pss.publicSharesMap[i].Point.Equal(curve.ScalarBaseMult(pss.share)))
 			}
 		}
 	}
@@ -330,7 +320,7 @@ func TestVerifyStateMapCosigners(t *testing.T) {
 func TestNormalizeSK256(t *testing.T) {
 	// btcec always produces normalized signatures
 	// instead just double check that s gets negated
-	curve := btcec.S256()
+	curve := curves.K256()
 
 	signer := Signer{
 		state: &state{},
@@ -360,14 +350,14 @@ func TestNormalizeSK256(t *testing.T) {
 }
 
 func TestNormalizeSK256Identity(t *testing.T) {
-	curve := btcec.S256()
+	curve := curves.K256()
 
 	signer := Signer{
 		state: &state{},
 	}
 	signer.Curve = curve
 	for i := 0; i < 1000; i++ {
-		msg, err := core.Rand(curve.N)
+		msg, err := core.Rand(btcec.S256().N)
 		require.NoError(t, err)
 		sk, err := btcec.NewPrivateKey()
 		require.NoError(t, err)
@@ -377,20 +367,20 @@ func TestNormalizeSK256Identity(t *testing.T) {
 		r, s, err := ecdsa.Sign(rand.Reader, sk.ToECDSA(), hash[:])
 		require.NoError(t, err)
 		_ = r // unused
-		
+
 		// normalizeS ensures s is in the lower half of the field
 		sNorm := signer.normalizeS(s)
-		
+
 		// Check that sNorm is indeed normalized (s <= n/2)
-		halfN := new(big.Int).Div(curve.N, big.NewInt(2))
+		halfN := new(big.Int).Div(btcec.S256().N, big.NewInt(2))
 		require.LessOrEqual(t, sNorm.Cmp(halfN), 0, "normalized S should be <= n/2")
-		
+
 		// If s was already normalized, it should be unchanged
 		// If s was > n/2, then sNorm should be n - s
 		if s.Cmp(halfN) <= 0 {
 			require.Equal(t, s, sNorm)
 		} else {
-			expected := new(big.Int).Sub(curve.N, s)
+			expected := new(big.Int).Sub(btcec.S256().N, s)
 			require.Equal(t, expected, sNorm)
 		}
 	}
@@ -401,7 +391,7 @@ func TestNormalizeSP256(t *testing.T) {
 	signer := Signer{
 		state: &state{},
 	}
-	signer.Curve = p256
+	signer.Curve = curves.P256()
 	sk, err := ecdsa.GenerateKey(p256, rand.Reader)
 	require.NoError(t, err)
 	for i := 0; i < 1000; i++ {
@@ -417,7 +407,7 @@ func TestNormalizeSP256(t *testing.T) {
 
 func TestSerializeParticipantData(t *testing.T) {
 	participants := 5
-	curve := btcec.S256()
+	curve := curves.K256()
 	ecdsaPk, sharesMap, err := dealer.NewDealerShares(curve, 3, uint32(participants), nil)
 	require.NoError(t, err)
 	pubSharesMap, err := dealer.PreparePublicShares(sharesMap)
@@ -458,10 +448,15 @@ func TestSerializeParticipantData(t *testing.T) {
 		require.NoError(t, json.Unmarshal(data, p2))
 		require.Equal(t, p.Id, p2.Id)
 		require.Equal(t, p.DecryptKey, p2.DecryptKey)
-		require.Equal(t, p.SecretKeyShare, p2.SecretKeyShare)
+		require.Equal(t, p.SecretKeyShare.Identifier, p2.SecretKeyShare.Identifier)
+		require.Equal(t, 0, p.SecretKeyShare.Value.Cmp(p2.SecretKeyShare.Value))
+		require.True(t, p.SecretKeyShare.Point.Equal(p2.SecretKeyShare.Point))
 		require.Equal(t, p.KeyGenType, p2.KeyGenType)
-		require.Equal(t, p.EcdsaPublicKey, p2.EcdsaPublicKey)
+		require.True(t, p.EcdsaPublicKey.Equal(p2.EcdsaPublicKey))
 		require.Equal(t, p.EncryptKeys, p2.EncryptKeys)
-		require.Equal(t, p.PublicShares, p2.PublicShares)
+		require.Len(t, p2.PublicShares, len(p.PublicShares))
+		for id, ps := range p.PublicShares {
+			require.True(t, ps.Point.Equal(p2.PublicShares[id].Point))
+		}
 	}
 }
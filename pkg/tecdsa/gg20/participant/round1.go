@@ -7,6 +7,7 @@
 package participant
 
 import (
+	crand "crypto/rand"
 	"fmt"
 	"math/big"
 
@@ -44,31 +45,28 @@ func (signer *Signer) SignRound1() (*Round1Bcast, map[uint32]*Round1P2PSend, err
 	pk := &signer.sk.PublicKey
 
 	// 1. k_i \getsr Z_q
-	k, err := core.Rand(signer.Curve.Params().N)
+	k, err := randomNonZero(signer.Curve)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	// 2. \gamma_i \getsr Z_q
-	gamma, err := core.Rand(signer.Curve.Params().N)
+	gamma, err := randomNonZero(signer.Curve)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	// 3. \Gamma_i = g^{\gamma_i} in \G
-	Gamma, err := curves.NewScalarBaseMult(signer.Curve, gamma)
-	if err != nil {
-		return nil, nil, err
-	}
+	Gamma := signer.Curve.ScalarBaseMult(gamma)
 
 	// 4. C_i, D_i = Commit(\Gamma_i)
-	Ci, Di, err := core.Commit(Gamma.Bytes())
+	Ci, Di, err := core.Commit(Gamma.ToAffineUncompressed())
 	if err != nil {
 		return nil, nil, err
 	}
 
 	// 5. c_i, r_i = PaillierEncryptAndReturnRandomness(pk_i, k_i)
-	ctxt, r, err := pk.Encrypt(k)
+	ctxt, r, err := pk.Encrypt(k.BigInt())
 	if err != nil {
 		return nil, nil, err
 	}
@@ -76,7 +74,7 @@ func (signer *Signer) SignRound1() (*Round1Bcast, map[uint32]*Round1P2PSend, err
 	pp := proof.Proof1Params{
 		Curve: signer.Curve,
 		Pk:    pk,
-		A:     k,
+		A:     k.BigInt(),
 		C:     ctxt,
 		R:     r,
 	}
@@ -129,3 +127,18 @@ func (signer *Signer) SignRound1() (*Round1Bcast, map[uint32]*Round1P2PSend, err
 	// (figure 8) 10. Broadcast (C_i, c_i)
 	return &bcast, p2p, nil
 }
+
+// randomNonZero samples a uniformly random non-zero scalar, matching the [1, q) range
+// previously produced by core.Rand
+func randomNonZero(curve *curves.Curve) (curves.Scalar, error) {
+	for i := 0; i < 8; i++ {
+		k := curve.Scalar.Random(crand.Reader)
+		if k == nil {
+			return nil, fmt.Errorf("unable to sample a random scalar")
+		}
+		if !k.IsZero() {
+			return k, nil
+		}
+	}
+	return nil, fmt.Errorf("unable to sample a non-zero scalar")
+}
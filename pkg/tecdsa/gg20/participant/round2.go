@@ -8,6 +8,7 @@ package participant
 
 import (
 	"encoding/json"
+	"fmt"
 	"math/big"
 
 	"github.com/TEENet-io/kryptology/pkg/core"
@@ -81,21 +82,25 @@ func (signer *Signer) SignRound2(params map[uint32]*Round1Bcast, p2p map[uint32]
 		pp.Pk = signer.state.pks[j]
 		pp.C = param.Ctxt
 
-		if signer.state.keyGenType.IsTrustedDealer() {
-			if err := param.Proof.Verify(pp); err != nil {
-				return nil, err
-			}
-		} else {
+		rangeProof := param.Proof
+		if !signer.state.keyGenType.IsTrustedDealer() {
 			// The case using DKG, verify range proof in P2PSend
-			if err := p2p[j].Verify(pp); err != nil {
-				return nil, err
-			}
+			rangeProof = p2p[j]
+		}
+		if err := verifyRange1Proof(rangeProof, pp); err != nil {
+			return nil, signer.newAbortError(j, &RangeProofEvidence{
+				Curve:        pp.Curve,
+				DealerParams: pp.DealerParams,
+				Pk:           pp.Pk,
+				Ciphertext:   pp.C,
+				Proof:        rangeProof,
+			}, err)
 		}
 
 		// 4. Compute c^{\gamma}_{ji}, \beta_{ji}, \pi^{Range2}_{ji} = MtaResponse(γ_i,g,q,pk_j,N~,h1,h2,c_j)
 		rpp.C1 = param.Ctxt
 		rpp.DealerParams = signer.state.keyGenType.GetProofParams(j)
-		rpp.SmallB = signer.state.gammai
+		rpp.SmallB = signer.state.gammai.BigInt()
 		rpp.Pk = signer.state.pks[j]
 		proofGamma, err := rpp.Prove()
 		if err != nil {
@@ -103,7 +108,7 @@ func (signer *Signer) SignRound2(params map[uint32]*Round1Bcast, p2p map[uint32]
 		}
 
 		// 5. Compute c^{w}_{ji}, \vu_{ji}, \pi^{Range3}_{ji} = MtaResponse_wc(w_i,W_i,g,q,pk_j,N~,h1,h2,c_j)
-		rpp.SmallB = signer.share.Value.BigInt()
+		rpp.SmallB = signer.share.BigInt()
 		proofW, err := rpp.ProveWc()
 		if err != nil {
 			return nil, err
@@ -128,3 +133,11 @@ func (signer *Signer) SignRound2(params map[uint32]*Round1Bcast, p2p map[uint32]
 	signer.Round = 3
 	return p2PSend, nil
 }
+
+// verifyRange1Proof verifies a range (1) proof that may be missing from a message
+func verifyRange1Proof(rangeProof *proof.Range1Proof, pp *proof.Proof1Params) error {
+	if rangeProof == nil {
+		return fmt.Errorf("range proof cannot be nil")
+	}
+	return rangeProof.Verify(pp)
+}
@@ -11,7 +11,6 @@ import (
 	"fmt"
 	"math/big"
 
-	"github.com/TEENet-io/kryptology/pkg/core"
 	"github.com/TEENet-io/kryptology/pkg/tecdsa/gg20/proof"
 )
 
@@ -58,16 +57,10 @@ func (s *Signer) SignRound3(in map[uint32]*P2PSend) (*Round3Bcast, error) {
 	}
 
 	// 1. Compute δ_i = k_i γ_i mod q
-	deltai, err := core.Mul(s.state.ki, s.state.gammai, s.Curve.Params().N)
-	if err != nil {
-		return nil, err
-	}
+	deltai := s.state.ki.Mul(s.state.gammai)
 
 	// 2. Compute σ_i = k_i w_i mod q
-	sigmai, err := core.Mul(s.state.ki, s.share.Value.BigInt(), s.Curve.Params().N)
-	if err != nil {
-		return nil, err
-	}
+	sigmai := s.state.ki.Mul(s.share)
 
 	// 3. For j=[1,...,t+1]
 	verifyParams := &proof.ResponseVerifyParams{
@@ -84,16 +77,17 @@ func (s *Signer) SignRound3(in map[uint32]*P2PSend) (*Round3Bcast, error) {
 			continue
 		}
 
-		if value == nil {
-			return nil, fmt.Errorf("P2P message for participant %v cannot be nil", j)
+		if value == nil || value.Proof2 == nil || value.Proof3 == nil {
+			return nil, s.newAbortError(j, nil, fmt.Errorf("P2P message for participant %v cannot be nil", j))
 		}
 
 		// 5. Compute α_ij = MtAFinalize(g,q,sk_i,pk_i,N~,h1,h2,c_i,c_ij,π_ij)
+		verifyParams.B = nil
 		alphaij, err := value.Proof2.Finalize(verifyParams)
 
 		// 6. If α_ij = ⊥, Abort
 		if err != nil {
-			return nil, err
+			return nil, s.newAbortError(j, s.mtaEvidence(verifyParams, value.Proof2), err)
 		}
 
 		// 7. Compute μ_ij = MtAFinalize_wc(g,q,sk_i,pk_i,N~,h1,h2,c_i,c_ij,π_ij,W_j)
@@ -102,29 +96,20 @@ func (s *Signer) SignRound3(in map[uint32]*P2PSend) (*Round3Bcast, error) {
 
 		// 8. If μ_ij = ⊥, Abort
 		if err != nil {
-			return nil, err
+			return nil, s.newAbortError(j, s.mtaEvidence(verifyParams, value.Proof3), err)
 		}
 
 		// 9. Compute δ_i = δ_i + α_ij + β_ji  mod q
-		deltai, err = core.Add(deltai, alphaij, s.Curve.Params().N)
-		if err != nil {
-			return nil, err
-		}
-		deltai, err = core.Add(deltai, s.state.betaj[j], s.Curve.Params().N)
+		deltai, err = addBigInts(s.Curve, deltai, alphaij, s.state.betaj[j])
 		if err != nil {
 			return nil, err
 		}
 
 		// 10. Compute σ_i = σ_i + μ_ij + ν_ji  mod q
-		sigmai, err = core.Add(sigmai, mu, s.Curve.Params().N)
+		sigmai, err = addBigInts(s.Curve, sigmai, mu, s.state.vuj[j])
 		if err != nil {
 			return nil, err
 		}
-		sigmai, err = core.Add(sigmai, s.state.vuj[j], s.Curve.Params().N)
-		if err != nil {
-			return nil, err
-		}
-
 	}
 
 	// 12. Return δ_i, σ_i
@@ -136,5 +121,22 @@ func (s *Signer) SignRound3(in map[uint32]*P2PSend) (*Round3Bcast, error) {
 	s.Round = 4
 
 	// 11. Broadcast δ_i to all other players
-	return &Round3Bcast{deltai}, nil
+	return &Round3Bcast{deltai.BigInt()}, nil
+}
+
+// mtaEvidence records a failed MtA response for other parties to check.
+// It returns nil if the response has no concrete proof behind it.
+func (s *Signer) mtaEvidence(vp *proof.ResponseVerifyParams, response proof.ResponseFinalizer) Evidence {
+	rp, ok := responseProof(response)
+	if !ok {
+		return nil
+	}
+	return &MtaEvidence{
+		Curve:        vp.Curve,
+		DealerParams: vp.DealerParams,
+		Pk:           &s.sk.PublicKey,
+		C1:           vp.C1,
+		B:            vp.B,
+		Response:     rp,
+	}
 }
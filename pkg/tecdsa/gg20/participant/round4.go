@@ -7,7 +7,7 @@
 package participant
 
 import (
-	"math/big"
+	"fmt"
 
 	"github.com/TEENet-io/kryptology/pkg/core"
 )
@@ -30,7 +30,7 @@ func (s *Signer) SignRound4(deltas map[uint32]*Round3Bcast) (*Round4Bcast, error
 	}
 
 	// 1. Set δ = δ_i
-	delta := new(big.Int).Set(s.state.deltai)
+	delta := s.state.deltai
 
 	// 2. For j=[1,...,t+1]
 	for j, deltaj := range deltas {
@@ -39,8 +39,12 @@ func (s *Signer) SignRound4(deltas map[uint32]*Round3Bcast) (*Round4Bcast, error
 			continue
 		}
 
+		if deltaj == nil || deltaj.deltaElement == nil {
+			return nil, s.newAbortError(j, nil, fmt.Errorf("delta of participant %v cannot be nil", j))
+		}
+
 		// 4. Compute δ = δ + δ_j mod q
-		delta, err = core.Add(delta, deltaj.deltaElement, s.Curve.Params().N)
+		delta, err = addBigInts(s.Curve, delta, deltaj.deltaElement)
 		if err != nil {
 			return nil, err
 		}
@@ -7,6 +7,7 @@
 package participant
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/TEENet-io/kryptology/pkg/core"
@@ -17,8 +18,55 @@ import (
 // Round5Bcast are the values to be broadcast to the other players at the conclusion
 // of signing round 5
 type Round5Bcast struct {
+	Rbar  curves.Point
+	Proof *proof.PdlProof
+	// S is S_i = R^{σ_i}. It lets the other players check the signature share s_i
+	// and attribute an invalid signature to its sender.
+	S curves.Point
+}
+
+// round5BcastJson encapsulates the data that is serialized to JSON
+type round5BcastJson struct {
 	Rbar  *curves.EcPoint
 	Proof *proof.PdlProof
+	S     *curves.EcPoint
+}
+
+func (r5b Round5Bcast) MarshalJSON() ([]byte, error) {
+	data := round5BcastJson{Proof: r5b.Proof}
+	var err error
+	if r5b.Rbar != nil {
+		if data.Rbar, err = curves.NewEcPoint(r5b.Rbar); err != nil {
+			return nil, err
+		}
+	}
+	if r5b.S != nil {
+		if data.S, err = curves.NewEcPoint(r5b.S); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(data)
+}
+
+func (r5b *Round5Bcast) UnmarshalJSON(bytes []byte) error {
+	data := new(round5BcastJson)
+	if err := json.Unmarshal(bytes, data); err != nil {
+		return err
+	}
+	var err error
+	r5b.Rbar, r5b.S = nil, nil
+	if data.Rbar != nil {
+		if r5b.Rbar, err = data.Rbar.ToPoint(); err != nil {
+			return err
+		}
+	}
+	if data.S != nil {
+		if r5b.S, err = data.S.ToPoint(); err != nil {
+			return err
+		}
+	}
+	r5b.Proof = data.Proof
+	return nil
 }
 
 // Round5P2PSend are the values sent to each participant at the conclusion of
@@ -40,65 +88,61 @@ func (signer *Signer) SignRound5(witnesses map[uint32]*Round4Bcast) (*Round5Bcas
 
 	// 2. For j = [1,...,t+1]
 	for j, d := range witnesses {
-		if d == nil {
-			return nil, nil, fmt.Errorf("input witnesses cannot be nil")
-		}
 		// 3. If i == j, continue
 		if j == signer.id {
 			continue
 		}
+		if d == nil || d.Witness == nil {
+			return nil, nil, signer.newAbortError(j, nil, fmt.Errorf("input witnesses cannot be nil"))
+		}
+		evidence := &CommitmentEvidence{
+			Curve:      signer.Curve,
+			Commitment: signer.state.Cj[j],
+			Witness:    d.Witness,
+		}
 
 		// FUTURE: match commitment with identifier instead of index
 		// 4. Compute Γ_j = Open(C_j , D_j)
 		ok, err := core.Open(signer.state.Cj[j], *d.Witness)
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, signer.newAbortError(j, evidence, err)
 		}
 		if !ok {
-			return nil, nil, fmt.Errorf("commitment couldn't be opened")
+			return nil, nil, signer.newAbortError(j, evidence, fmt.Errorf("commitment couldn't be opened"))
 		}
 
 		// 5. If Γ_j = ⊥, Abort
-		Gammaj, err := curves.PointFromBytesUncompressed(signer.Curve, d.Witness.Msg)
+		Gammaj, err := signer.Curve.Point.FromAffineUncompressed(d.Witness.Msg)
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, signer.newAbortError(j, evidence, err)
 		}
 
 		// 6. Compute R = R · Γ_j in G
-		R, err = R.Add(Gammaj)
-		if err != nil {
-			return nil, nil, err
-		}
+		R = R.Add(Gammaj)
 	}
 
 	// 7. Compute R= R^{δ^{−1}} in G
-	deltaInv, err := core.Inv(signer.state.delta, signer.Curve.Params().N)
+	deltaInv, err := signer.state.delta.Invert()
 	if err != nil {
 		return nil, nil, err
 	}
-	R, err = R.ScalarMult(deltaInv)
-	if err != nil {
-		return nil, nil, err
+	R = R.Mul(deltaInv)
+	if R.IsIdentity() {
+		return nil, nil, fmt.Errorf("R cannot be the identity")
 	}
 
 	// 9. Compute \overline{R_i} = R^{k_i}
-	Rbari, err := R.ScalarMult(signer.state.ki)
-	if err != nil {
-		return nil, nil, err
-	}
+	Rbari := R.Mul(signer.state.ki)
 
-	// Sanity check
-	Rbark, err := R.ScalarMult(signer.state.sigmai)
-	if err != nil {
-		return nil, nil, err
-	}
+	// Compute S_i = R^{σ_i}, which binds the signature share s_i in SignOutput
+	Rbark := R.Mul(signer.state.sigmai)
 
-	bcast := &Round5Bcast{Rbari, nil}
+	bcast := &Round5Bcast{Rbar: Rbari, S: Rbark}
 	p2p := make(map[uint32]*Round5P2PSend)
 	pdlParams := proof.PdlProofParams{
 		Curve:   signer.Curve,
 		Pk:      &signer.sk.PublicKey,
-		ScalarX: signer.state.ki,
+		ScalarX: signer.state.ki.BigInt(),
 		PointX:  Rbari,
 		C:       signer.state.ci,
 		ScalarR: signer.state.ri,
@@ -137,7 +181,11 @@ func (signer *Signer) SignRound5(witnesses map[uint32]*Round4Bcast) (*Round5Bcas
 	signer.state.R = R
 
 	// 8. Set r = R_x
-	signer.state.r = R.X
+	Rx, _ := affine(R)
+	signer.state.r, err = signer.Curve.Scalar.SetBigInt(Rx)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	// 12. Set \overline{R}_i
 	signer.state.Rbari = Rbari
@@ -145,7 +193,7 @@ func (signer *Signer) SignRound5(witnesses map[uint32]*Round4Bcast) (*Round5Bcas
 
 	signer.Round = 6
 
-	// 11. TrustedDealer - Broadcast {R_i, π^{kCONSIST}_i} to all other players
-	// 13. DKG - Broadcast R_i to all other players, P2PSend π^{kCONSIST}_ij
+	// 11. TrustedDealer - Broadcast {R_i, π^{kCONSIST}_i, S_i} to all other players
+	// 13. DKG - Broadcast {R_i, S_i} to all other players, P2PSend π^{kCONSIST}_ij
 	return bcast, p2p, nil
 }
@@ -80,10 +80,12 @@ func (signer *Signer) SignRound6Full(hash []byte, in map[uint32]*Round5Bcast, p2
 func (signer *Signer) signRound6Offline(in map[uint32]*Round5Bcast, p2p map[uint32]*Round5P2PSend) error {
 	// FUTURE: determine round state variables to accommodate on/offline modes
 	// before this function is exported
-	var err error
 
-	// 1. Set V = \bar{R}_i
+	// 1. Set V = \bar{R}_i and S = S_i
 	v := signer.state.Rbari
+	s := signer.state.Rbark
+	signer.state.Rbarj = make(map[uint32]curves.Point, len(in))
+	signer.state.Sj = make(map[uint32]curves.Point, len(in))
 
 	// 2. For j=[1,...,t+1]
 	for j, value := range in {
@@ -91,6 +93,9 @@ func (signer *Signer) signRound6Offline(in map[uint32]*Round5Bcast, p2p map[uint
 		if j == signer.id {
 			continue
 		}
+		if value == nil || !signer.onCurve(value.Rbar) || !signer.onCurve(value.S) {
+			return signer.newAbortError(j, nil, fmt.Errorf("invalid round 5 broadcast from participant %v", j))
+		}
 
 		// 4. TrustedDealer - If VerifyPDL(πkCONSIST,g,q,R,pkj,N,h1,h2,cj,Rj) = False, Abort
 		// 4. DKG - If VerifyPDL(πkCONSIST_j,g,q,R,pkj,Nj,h1j,h2j,cj,Rj) = False, Abort
@@ -103,26 +108,38 @@ func (signer *Signer) signRound6Offline(in map[uint32]*Round5Bcast, p2p map[uint
 			PointR:       signer.state.R,
 			C:            signer.state.cj[j],
 		}
-		if signer.state.keyGenType.IsTrustedDealer() {
-			if err := value.Proof.Verify(verifyProofParams); err != nil {
-				return err
-			}
-		} else {
-			if err := p2p[j].Verify(verifyProofParams); err != nil {
-				return err
-			}
+		pdl := value.Proof
+		if !signer.state.keyGenType.IsTrustedDealer() {
+			pdl = p2p[j]
+		}
+		if err := verifyPdlProof(pdl, verifyProofParams); err != nil {
+			return signer.newAbortError(j, &PdlEvidence{
+				Curve:        signer.Curve,
+				DealerParams: verifyProofParams.DealerParams,
+				Pk:           verifyProofParams.Pk,
+				Ciphertext:   verifyProofParams.C,
+				R:            verifyProofParams.PointR,
+				Rbar:         verifyProofParams.PointX,
+				Proof:        pdl,
+			}, err)
 		}
 
 		// 5. Compute V = V · R_j in G
-		v, err = v.Add(value.Rbar)
-		if err != nil {
-			return err
-		}
+		v = v.Add(value.Rbar)
+
+		// Compute S = S · S_j in G
+		s = s.Add(value.S)
+		signer.state.Rbarj[j] = value.Rbar
+		signer.state.Sj[j] = value.S
 	}
 	// 6 If V != g, Abort
-	if !v.IsBasePoint() {
+	if !v.Equal(signer.Curve.NewGeneratorPoint()) {
 		return fmt.Errorf("V != g")
 	}
+	// If S != y, Abort
+	if !s.Equal(signer.PublicKey) {
+		return fmt.Errorf("S != y")
+	}
 	// 7. return r, k, \sigma,
 	// These are already stored
 	return nil
@@ -138,43 +155,31 @@ func (signer *Signer) signRound6Online(hash []byte) (*Round6FullBcast, error) {
 	// We receive the message already hashed to allow flexibility for the callers
 	// to hash the message according to the library they use
 	// However, we check the hash is in the field
-	m := new(big.Int).SetBytes(hash)
-	if err := core.In(m, signer.Curve.Params().N); err != nil {
+	m, err := hashToScalar(signer.Curve, hash)
+	if err != nil {
 		return nil, err
 	}
 	signer.state.msgHash = hash
 
 	// 8. Compute s_i = m k_i + r σ_i mod q
-	m, err := core.Mul(m, signer.state.ki, signer.Curve.Params().N)
-	if err != nil {
-		return nil, err
-	}
-	rTmp, err := core.Mul(signer.state.r, signer.state.sigmai, signer.Curve.Params().N)
-	if err != nil {
-		return nil, err
-	}
-	si, err := core.Add(m, rTmp, signer.Curve.Params().N)
-	if err != nil {
-		return nil, err
-	}
+	si := m.Mul(signer.state.ki).Add(signer.state.r.Mul(signer.state.sigmai))
 
 	signer.state.si = si
 	signer.Round = 7
 
 	// 9. Broadcast s_i to all other players
 	// 10. Return s_i
-	return &Round6FullBcast{si}, nil
+	return &Round6FullBcast{si.BigInt()}, nil
 }
 
 // SignOutput performs the signature aggregation step in
 // [spec] §5.fig 5
 func (signer *Signer) SignOutput(in map[uint32]*Round6FullBcast) (*curves.EcdsaSignature, error) {
-	var err error
-	if err = signer.verifyStateMap(7, in); err != nil {
+	if err := signer.verifyStateMap(7, in); err != nil {
 		return nil, err
 	}
 	// 1. Set s = s_i
-	s := new(big.Int).Set(signer.state.si)
+	s := signer.state.si
 
 	// 2. For j = [1,...,t+1]
 	for j, sj := range in {
@@ -183,26 +188,53 @@ func (signer *Signer) SignOutput(in map[uint32]*Round6FullBcast) (*curves.EcdsaS
 			continue
 		}
 
+		// Check R^{s_j} = \overline{R_j}^m S_j^r so that an invalid signature
+		// can be attributed to the player that sent the bad share
+		evidence := &SignatureShareEvidence{
+			Curve: signer.Curve,
+			Hash:  signer.state.msgHash,
+			R:     signer.state.R,
+			Rbar:  signer.state.Rbarj[j],
+			S:     signer.state.Sj[j],
+		}
+		if sj == nil || sj.sElement == nil {
+			return nil, signer.newAbortError(j, evidence, fmt.Errorf("signature share of participant %v cannot be nil", j))
+		}
+		evidence.Share = sj.sElement
+		ok, err := checkSignatureShare(signer.Curve, signer.state.msgHash, signer.state.R,
+			signer.state.Rbarj[j], signer.state.Sj[j], sj.sElement)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, signer.newAbortError(j, evidence, fmt.Errorf("invalid signature share"))
+		}
+
 		// 4. Compute s = s + s_j mod q
-		s, err = core.Add(s, sj.sElement, signer.Curve.Params().N)
+		s, err = addBigInts(signer.Curve, s, sj.sElement)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	sOld := new(big.Int).Set(s)
-	s = signer.normalizeS(s)
-	v := int(signer.state.R.Y.Bit(0))
+	sOld := s.BigInt()
+	sNorm := signer.normalizeS(sOld)
+	_, Ry := affine(signer.state.R)
+	v := int(Ry.Bit(0))
 
-	if sOld.Cmp(s) != 0 {
+	if sOld.Cmp(sNorm) != 0 {
 		v ^= 1
 	}
 
 	// 5. Set \sigma = (r, s)
-	sigma := &curves.EcdsaSignature{V: v, R: signer.state.r, S: s}
+	sigma := &curves.EcdsaSignature{V: v, R: signer.state.r.BigInt(), S: sNorm}
 
 	// 6. If ECDSAVerify(y, \sigma, M) = False, Abort
-	if !signer.state.verify(signer.PublicKey, signer.state.msgHash, sigma) {
+	pk, err := curves.NewEcPoint(signer.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	if !signer.state.verify(pk, signer.state.msgHash, sigma) {
 		return nil, fmt.Errorf("signature is not valid")
 	}
 
@@ -230,14 +262,27 @@ func (signer Signer) normalizeS(s *big.Int) *big.Int {
 	// lies in the lower half of its range.
 	// See <https://en.bitcoin.it/wiki/BIP_0062#Low_S_values_in_signatures>
 	qDiv2 := new(big.Int)
-	qDiv2 = qDiv2.Div(signer.Curve.Params().N, core.Two)
+	qDiv2 = qDiv2.Div(curveOrder(signer.Curve), core.Two)
 
 	// Check whether a scalar is higher than the group order divided
 	// by 2. If true, we negate s.
 	// Not constant time, it would be better to conditionally negate with check in constant time
 	// but since `s` is a public value anyway, this is allowed to be variable time
 	if s.Cmp(qDiv2) == 1 {
-		return new(big.Int).Sub(signer.Curve.Params().N, s)
+		return new(big.Int).Sub(curveOrder(signer.Curve), s)
 	}
 	return new(big.Int).Set(s)
 }
+
+// verifyPdlProof verifies a PDL proof that may be missing from a message
+func verifyPdlProof(pdl *proof.PdlProof, pv *proof.PdlVerifyParams) error {
+	if pdl == nil {
+		return fmt.Errorf("pdl proof cannot be nil")
+	}
+	return pdl.Verify(pv)
+}
+
+// onCurve checks that p is a point of the signing curve other than the identity
+func (signer *Signer) onCurve(p curves.Point) bool {
+	return p != nil && p.CurveName() == signer.Curve.Name && p.IsOnCurve() && !p.IsIdentity()
+}
@@ -9,8 +9,8 @@ package participant
 import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
+	crand "crypto/rand"
 	"encoding/json"
-	"fmt"
 	"math/big"
 	"testing"
 
@@ -110,8 +110,8 @@ func genPrimesArray(count int) []struct{ p, q *big.Int } {
 }
 
 // Creates a set of signers that are usable for testing
-func setupSignersMap(t *testing.T, curve elliptic.Curve, playerThreshold, playerCnt int,
-	addRound1 bool, verify curves.EcdsaVerify, useDistributed bool) (*curves.EcPoint, map[uint32]*Signer) {
+func setupSignersMap(t *testing.T, curve *curves.Curve, playerThreshold, playerCnt int,
+	addRound1 bool, verify curves.EcdsaVerify, useDistributed bool) (curves.Point, map[uint32]*Signer) {
 
 	if playerThreshold > playerCnt {
 		t.Errorf("threshold cannot be larger than count")
@@ -186,7 +186,7 @@ func setupSignersMap(t *testing.T, curve elliptic.Curve, playerThreshold, player
 }
 
 func TestSignerSignRound1Works(t *testing.T) {
-	curve := btcec.S256()
+	curve := curves.K256()
 	playerCnt := 5
 	playerMin := 3
 	for _, useDistributed := range []bool{false, true} {
@@ -241,7 +241,7 @@ func TestSignerSignRound1Works(t *testing.T) {
 }
 
 func TestSignerSignRound1RepeatCall(t *testing.T) {
-	curve := btcec.S256()
+	curve := curves.K256()
 	playerCnt := 5
 	playerMin := 3
 	_, signers := setupSignersMap(t, curve, playerMin, playerCnt, false, dummyVerifier, false)
@@ -253,7 +253,7 @@ func TestSignerSignRound1RepeatCall(t *testing.T) {
 
 func TestSignerSignRound2Works(t *testing.T) {
 	var err error
-	curve := btcec.S256()
+	curve := curves.K256()
 	playerCnt := 5
 	playerMin := 3
 	for _, useDistributed := range []bool{false, true} {
@@ -338,7 +338,7 @@ func TestSignerSignRound2Works(t *testing.T) {
 }
 
 func TestSignerSignRound2RepeatCall(t *testing.T) {
-	curve := btcec.S256()
+	curve := curves.K256()
 	playerCnt := 5
 	playerMin := 3
 	for _, useDistributed := range []bool{false, true} {
@@ -384,12 +384,12 @@ func (m *responseProofMock) FinalizeWc(vp *proof.ResponseVerifyParams) (*big.Int
 func TestSignRound3(t *testing.T) {
 	for _, useDistributed := range []bool{false, true} {
 		// Reasonably valid setup for testing round 3
-		_, signers := setupSignersMap(t, btcec.S256(), 3, 5, true, dummyVerifier, useDistributed)
+		_, signers := setupSignersMap(t, curves.K256(), 3, 5, true, dummyVerifier, useDistributed)
 
 		for _, s := range signers {
 			// Set variables that are expected to be present at the end of round 2
-			s.state.ki = core.One
-			s.state.gammai = core.One
+			s.state.ki = curves.K256().Scalar.One()
+			s.state.gammai = curves.K256().Scalar.One()
 			s.state.betaj = make(map[uint32]*big.Int)
 			s.state.vuj = make(map[uint32]*big.Int)
 			p2p := make(map[uint32]*P2PSend)
@@ -430,7 +430,7 @@ func TestSignRound3(t *testing.T) {
 			})
 
 			t.Run("return value matches state", func(t *testing.T) {
-				require.Equal(t, bcast.deltaElement, s.state.deltai)
+				require.Equal(t, bcast.deltaElement, s.state.deltai.BigInt())
 			})
 
 			t.Run("round variable is updated", func(t *testing.T) {
@@ -450,7 +450,7 @@ func TestSignRound3(t *testing.T) {
 
 func TestSignRound4(t *testing.T) {
 	// Reasonably valid setup for testing round 4
-	_, signers := setupSignersMap(t, btcec.S256(), 3, 5, true, dummyVerifier, false)
+	_, signers := setupSignersMap(t, curves.K256(), 3, 5, true, dummyVerifier, false)
 
 	for _, s := range signers {
 		// Set variables that are expected to be present at the end of round 3
@@ -463,10 +463,10 @@ func TestSignRound4(t *testing.T) {
 			ones[j] = &Round3Bcast{core.One}
 			s.state.cosigners[j] = true
 		}
-		s.state.ki = core.One
-		s.state.gammai = core.One
-		s.state.deltai = core.One
-		s.state.sigmai = core.One
+		s.state.ki = curves.K256().Scalar.One()
+		s.state.gammai = curves.K256().Scalar.One()
+		s.state.deltai = curves.K256().Scalar.One()
+		s.state.sigmai = curves.K256().Scalar.One()
 		s.state.Di = &core.Witness{}
 
 		// Test that invalid signing rounds states are rejected
@@ -500,7 +500,7 @@ func TestSignRound4(t *testing.T) {
 
 func TestSignerSignRound5Works(t *testing.T) {
 	var err error
-	curve := btcec.S256()
+	curve := curves.K256()
 	playerCnt := 5
 	playerMin := 3
 	for _, useDistributed := range []bool{false, true} {
@@ -609,7 +609,8 @@ func TestSignerSignRound5Works(t *testing.T) {
 		if signers[1].state.R == nil {
 			t.Errorf("Expected R to be set")
 		}
-		if signers[1].state.r.Cmp(signers[1].state.R.X) != 0 {
+		Rx, _ := affine(signers[1].state.R)
+		if signers[1].state.r.BigInt().Cmp(new(big.Int).Mod(Rx, curveOrder(curve))) != 0 {
 			t.Errorf("Expected r == Rx")
 		}
 
@@ -628,34 +629,30 @@ func TestSignerSignRound5Works(t *testing.T) {
 }
 
 func TestSignerSignRound6WorksK256(t *testing.T) {
-	curve := btcec.S256()
 	msg := make([]byte, 32)
-	hash, err := core.Hash(msg, curve)
+	hash, err := core.Hash(msg, btcec.S256())
 	require.NoError(t, err)
-	fullroundstest3Signers(t, curve, hash.Bytes(), k256Verifier)
+	fullroundstest3Signers(t, curves.K256(), hash.Bytes(), k256Verifier)
 }
 
 func TestSignerSignRound6WorksP256(t *testing.T) {
-	curve := elliptic.P256()
 	msg := make([]byte, 32)
-	hash, err := core.Hash(msg, curve)
+	hash, err := core.Hash(msg, elliptic.P256())
 	require.NoError(t, err)
-	fullroundstest3Signers(t, curve, hash.Bytes(), ecdsaVerifier)
+	fullroundstest3Signers(t, curves.P256(), hash.Bytes(), ecdsaVerifier)
 }
 
-func fullroundstest3Signers(t *testing.T, curve elliptic.Curve, msg []byte, verify curves.EcdsaVerify) {
+func fullroundstest3Signers(t *testing.T, curve *curves.Curve, msg []byte, verify curves.EcdsaVerify) {
 	var err error
 	playerCnt := 5
 	playerMin := 3
 	for _, useDistributed := range []bool{false, true} {
 		pk, signers := setupSignersMap(t, curve, playerMin, playerCnt, false, verify, useDistributed)
 
-		sk := signers[1].share.Value.Add(signers[2].share.Value).Add(signers[3].share.Value)
-		
+		sk := signers[1].share.Add(signers[2].share).Add(signers[3].share)
+
 		// Verify the combined shares equal the public key
-		// For generic elliptic curves, compute pk = sk * G
-		ppkX, ppkY := curve.ScalarBaseMult(sk.Bytes())
-		if ppkX.Cmp(pk.X) != 0 || ppkY.Cmp(pk.Y) != 0 {
+		if !curve.ScalarBaseMult(sk).Equal(pk) {
 			t.Errorf("Invalid shares")
 			t.FailNow()
 		}
@@ -744,19 +741,9 @@ func fullroundstest3Signers(t *testing.T, curve elliptic.Curve, msg []byte, veri
 		round5Bcast[3], r5P2p[3], err = signers[3].SignRound5(map[uint32]*Round4Bcast{1: round4Bcast[1], 2: round4Bcast[2]})
 		require.NoError(t, err)
 
-		Rbark, err := signers[1].state.Rbark.Add(signers[2].state.Rbark)
-		require.NoError(t, err)
-
-		Rbark, err = Rbark.Add(signers[3].state.Rbark)
-		require.NoError(t, err)
-
-		Rbark.Y, err = core.Neg(Rbark.Y, curve.Params().P)
-		require.NoError(t, err)
-		Rbark, err = Rbark.Add(pk)
-		require.NoError(t, err)
-
-		if !Rbark.IsIdentity() {
-			t.Errorf("%v != %v", Rbark.X, pk.X)
+		Rbark := signers[1].state.Rbark.Add(signers[2].state.Rbark).Add(signers[3].state.Rbark)
+		if !Rbark.Sub(pk).IsIdentity() {
+			t.Errorf("%v != %v", Rbark, pk)
 			t.FailNow()
 		}
 
@@ -817,13 +804,17 @@ func fullroundstest3Signers(t *testing.T, curve elliptic.Curve, msg []byte, veri
 		require.NoError(t, err)
 
 		// Use the public key we already have
+		ecPk, err := curves.NewEcPoint(pk)
+		require.NoError(t, err)
 		ecdsaPK := &ecdsa.PublicKey{
-			Curve: curve,
-			X:     pk.X,
-			Y:     pk.Y,
+			Curve: ecPk.Curve,
+			X:     ecPk.X,
+			Y:     ecPk.Y,
+		}
+		for _, sig := range sigs {
+			require.True(t, ecdsa.Verify(ecdsaPK, msg, sig.R, sig.S))
+			require.Equal(t, sigs[0], sig)
 		}
-		ok := ecdsa.Verify(ecdsaPK, msg, sigs[0].R, sigs[0].S)
-		fmt.Println("ok:", ok)
 	}
 }
 
@@ -887,12 +878,32 @@ func TestMarshalRound4BcastRoundTrip(t *testing.T) {
 
 // Ensures that marshal-unmarshal Round5Bcast is the identity function
 func TestMarshalRound5BcastRoundTrip(t *testing.T) {
+	for _, curve := range []*curves.Curve{curves.K256(), curves.P256()} {
+		expected := Round5Bcast{
+			Rbar:  curve.Point.Random(crand.Reader),
+			S:     curve.Point.Random(crand.Reader),
+			Proof: &proof.PdlProof{},
+		}
+
+		// Marshal and test
+		jsonBytes, err := json.Marshal(expected)
+		require.NoError(t, err)
+		require.NotNil(t, jsonBytes)
+
+		// Unmarshal and test
+		var actual Round5Bcast
+		err = json.Unmarshal(jsonBytes, &actual)
+		require.NoError(t, err)
+
+		require.True(t, expected.Rbar.Equal(actual.Rbar))
+		require.True(t, expected.S.Equal(actual.S))
+		require.Equal(t, expected.Proof, actual.Proof)
+	}
+}
+
+// Ensures that a Round5Bcast without points still round trips
+func TestMarshalRound5BcastNilPoints(t *testing.T) {
 	expected := Round5Bcast{
-		Rbar: &curves.EcPoint{
-			Curve: btcec.S256(),
-			X:     tt.B10("22963319250927626464432314334264998185524558636490611781390004531598870711554"),
-			Y:     tt.B10("5554841823708042459730720811862682529836762867109252962559124452631448728441"),
-		},
 		Proof: &proof.PdlProof{},
 	}
 
@@ -7,12 +7,12 @@
 package proof
 
 import (
-	"crypto/elliptic"
 	"encoding/json"
 	"fmt"
 	"math/big"
 
 	mod "github.com/TEENet-io/kryptology/pkg/core"
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
 )
 
 const ell = 128
@@ -23,7 +23,7 @@ const ell = 128
 // ScalarX is a random element from Z_N~ and it should be kept as secret
 // N is the field modulus
 type CdlProofParams struct {
-	Curve                      elliptic.Curve
+	Curve                      *curves.Curve
 	Pi, Qi, H1, H2, ScalarX, N *big.Int
 }
 
@@ -57,7 +57,7 @@ func (cdlp *CdlProof) UnmarshalJSON(bytes []byte) error {
 }
 
 type CdlVerifyParams struct {
-	Curve     elliptic.Curve
+	Curve     *curves.Curve
 	H1, H2, N *big.Int
 }
 
@@ -75,9 +75,13 @@ func (p CdlProofParams) Prove() (*CdlProof, error) {
 	prod := new(big.Int).Mul(p.Pi, p.Qi)
 	alpha := make([]*big.Int, ell)
 	fsInput := make([]*big.Int, ell+6)
-	fsInput[0] = p.Curve.Params().Gx
-	fsInput[1] = p.Curve.Params().Gy
-	fsInput[2] = p.Curve.Params().N
+	params, err := groupParams(p.Curve)
+	if err != nil {
+		return nil, err
+	}
+	fsInput[0] = params.Gx
+	fsInput[1] = params.Gy
+	fsInput[2] = params.N
 	fsInput[3] = p.N
 	fsInput[4] = p.H1
 	fsInput[5] = p.H2
@@ -137,9 +141,13 @@ func (p CdlProof) Verify(cv *CdlVerifyParams) error {
 
 	// 2. Compute e = FS-HASH(g,q,N,h1,h2,[u1,...,u_ell])
 	fsInput := make([]*big.Int, ell+6)
-	fsInput[0] = cv.Curve.Params().Gx
-	fsInput[1] = cv.Curve.Params().Gy
-	fsInput[2] = cv.Curve.Params().N
+	params, err := groupParams(cv.Curve)
+	if err != nil {
+		return err
+	}
+	fsInput[0] = params.Gx
+	fsInput[1] = params.Gy
+	fsInput[2] = params.N
 	fsInput[3] = cv.N
 	fsInput[4] = cv.H1
 	fsInput[5] = cv.H2
@@ -11,11 +11,11 @@ import (
 	"math/big"
 	"testing"
 
-	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/stretchr/testify/require"
 
 	tt "github.com/TEENet-io/kryptology/internal"
 	mod "github.com/TEENet-io/kryptology/pkg/core"
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
 )
 
 // To test ProveCompositeDL, the input must satisfy the following relationship
@@ -23,7 +23,7 @@ import (
 // H2 = H1^x mod N
 // P, Q are 1024 bits
 func TestCdlProof(t *testing.T) {
-	curve := btcec.S256()
+	curve := curves.K256()
 	params := []*CdlProofParams{
 		{
 			Curve:   curve,
@@ -80,7 +80,7 @@ func TestCdlProof(t *testing.T) {
 }
 
 func TestCdlProofTampered(t *testing.T) {
-	curve := btcec.S256()
+	curve := curves.K256()
 	params := []*CdlProofParams{
 		{
 			Curve:   curve,
@@ -160,7 +160,7 @@ func TestCdlProofTampered(t *testing.T) {
 // In this test, we set h1, h2, alpha as random values purposely, which should make the verification incorrect.
 // Pi, Qi and N are generated correctly via a side program and P = 2*Pi+1, Q=2*Qi+1, N = PQ
 func TestCdlProofRandValues(t *testing.T) {
-	curve := btcec.S256()
+	curve := curves.K256()
 	pi := tt.B10("69194751040870458870606183726555435909086788535387699389669514131170791430457074867750473589740101538563709151356299341625165252163443101897409551452286117584229969813571483217371266470819768503264181903858655002445121864855018050830086220605407845379111572386400613256577806622758645652928390261158201056559")
 	qi := tt.B10("82637633161541176464703465424481086073529001916957361226495184953100155149539385427685606853188505515091549850006242891763636582655438360592297819967249750168526728829169019442914966932888398845776455389981984845838757231468840109235034665375458310070782153041383978515298908502237439774363022952285938551943")
 	n := tt.B10("22872361812878489876060907543278948578387147675520825541510527859532146822027982754603729909037595317258111424852220535313592335395829235521522221441210174887876906980298605562656860093862386445954521521259318033327437806280309919241306055629653903861746476630826350344558570927747295219571966411822552470277193572834840982644421834542817903768311471107148331859193087484753051900698022956193382070220395102397179763375245079349836869922078435771068117889295943356668151757001536241849212462656770832021260539449326135398784589973524619988879740444034914415022192221461822737062898569969299902763089827704220688593553")
@@ -201,7 +201,7 @@ func TestCdlProofInvalidParams(t *testing.T) {
 		t.Errorf("CdlProve succeeded but should've failed")
 	}
 
-	curve := btcec.S256()
+	curve := curves.K256()
 	P := tt.B10("165767109498679333927172882988675240871786832994588749158965767673945611886648976955012980205938446757878886183316455017521300834957764745162353525575268392435587843607612470895721541047254417540270756145682130189228024724293713540420700979243740619312487567234262826018540404128735554374146182348085336281439")
 	Q := tt.B10("138451631119797627683944394514738428837020078954518535124773153508211993035282574374708542371034182930645915695336645534003697272777264261785750332260172837811934440302323563909582466079621984165168311186255004180759468783222026674257257121598775537240537892901360209680738434517685889621651841176243400986247")
 	pi := new(big.Int).Div(new(big.Int).Sub(P, big.NewInt(1)), big.NewInt(2))
@@ -319,7 +319,7 @@ func TestMarshalJsonCdlProof(t *testing.T) {
 // P = 1028783406134480509185302717063
 // Q = 1093599917998934718101903070203
 func TestSmallModulus(t *testing.T) {
-	curve := btcec.S256()
+	curve := curves.K256()
 	pi := tt.B10("514391703067240254592651358531")
 	qi := tt.B10("546799958999467359050951535101")
 	n := tt.B10("1125077448587332637478027734178491491371406782346677534973789")
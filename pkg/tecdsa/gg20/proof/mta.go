@@ -8,7 +8,6 @@
 package proof
 
 import (
-	"crypto/elliptic"
 	"encoding/json"
 	"fmt"
 	"math/big"
@@ -22,21 +21,31 @@ import (
 // ResponseProofParams encapsulates the values over which a range proof (2) is computed.
 // [spec] §7.fig 8
 type ResponseProofParams struct {
-	Curve        elliptic.Curve
+	Curve        *curves.Curve
 	DealerParams *dealer.ProofParams
 	Pk           *paillier.PublicKey
 	SmallB, C1   *big.Int
-	B            *curves.EcPoint
+	B            curves.Point
 }
 
 // ResponseVerifyParams encapsulates the values over which a range proof (2) is verified.
 // [spec] §7.fig 10
 type ResponseVerifyParams struct {
-	Curve        elliptic.Curve
+	Curve        *curves.Curve
 	DealerParams *dealer.ProofParams
 	Sk           *paillier.SecretKey
 	C1           *big.Int
-	B            *curves.EcPoint
+	B            curves.Point
+}
+
+// ResponseCheckParams encapsulates the public values over which a range proof (2) is verified.
+// Unlike ResponseVerifyParams it needs only the recipient's public key, so any party can check the proof.
+type ResponseCheckParams struct {
+	Curve        *curves.Curve
+	DealerParams *dealer.ProofParams
+	Pk           *paillier.PublicKey
+	C1           *big.Int
+	B            curves.Point
 }
 
 // ResponseFinalizer captures the interface provided by a response proof
@@ -57,7 +66,7 @@ type ResponseProof struct {
 // Proof1Params encapsulates the values over which a range proof (1) is computed.
 // [spec] fig 10
 type Proof1Params struct {
-	Curve        elliptic.Curve
+	Curve        *curves.Curve
 	Pk           *paillier.PublicKey
 	DealerParams *dealer.ProofParams
 	A, C, R      *big.Int
@@ -83,21 +92,21 @@ type range1ProofJSON struct {
 // proof2Params encapsulates the values over which a range proof (2) is computed.
 // [spec] fig 12
 type proof2Params struct {
-	curve           elliptic.Curve
+	curve           *curves.Curve
 	dealerParams    *dealer.ProofParams
 	pk              *paillier.PublicKey
 	y, r, c1, c2, x *big.Int
-	X               *curves.EcPoint
+	X               curves.Point
 }
 
 // verifyProof2Params encapsulates the values over which a range proof (2) is computed.
 // [spec] fig 12
 type verifyProof2Params struct {
-	curve        elliptic.Curve
+	curve        *curves.Curve
 	dealerParams *dealer.ProofParams
 	pk           *paillier.PublicKey
 	c1, c2       *big.Int
-	X            *curves.EcPoint
+	X            curves.Point
 }
 
 // Range2Proof encapsulates the results returned in proof (2)
@@ -211,7 +220,11 @@ func genResponseProof(rp ResponseProofParams, wc bool) (*ResponseProof, error) {
 	// The mitigation is described in section 3 from
 	// https://eprint.iacr.org/2019/114.pdf
 	// 3. \beta' = Z_{\mathbb{q5}}
-	q5, err := core.Exp(rp.Curve.Params().N, big.NewInt(5), nil)
+	params, err := groupParams(rp.Curve)
+	if err != nil {
+		return nil, err
+	}
+	q5, err := core.Exp(params.N, big.NewInt(5), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -230,7 +243,7 @@ func genResponseProof(rp ResponseProofParams, wc bool) (*ResponseProof, error) {
 		return nil, err
 	}
 	// step 6
-	beta, err := core.Neg(betaTick, rp.Curve.Params().N)
+	beta, err := core.Neg(betaTick, params.N)
 	if err != nil {
 		return nil, err
 	}
@@ -266,43 +279,26 @@ func genResponseProof(rp ResponseProofParams, wc bool) (*ResponseProof, error) {
 // Finalize checks a range (2) proof: [spec] fig 13: MtaFinalize
 // and returns the paillier encrypted random value
 func (rp ResponseProof) Finalize(vp *ResponseVerifyParams) (*big.Int, error) {
-	v2Params := verifyProof2Params{
-		curve:        vp.Curve,
-		dealerParams: vp.DealerParams,
-		pk: &paillier.PublicKey{
-			N:  vp.Sk.N,
-			N2: vp.Sk.N2,
-		},
-		c1: vp.C1,
-		c2: rp.C2,
-	}
-	if err := rp.R2proof.Verify(&v2Params); err != nil {
+	if err := rp.Verify(vp.check()); err != nil {
 		return nil, err
 	}
 	alpha, err := vp.Sk.Decrypt(rp.C2)
 	if err != nil {
 		return nil, err
 	}
+	params, err := groupParams(vp.Curve)
+	if err != nil {
+		return nil, err
+	}
 
-	return alpha.Mod(alpha, vp.Curve.Params().N), nil
+	return alpha.Mod(alpha, params.N), nil
 }
 
 // FinalizeWc checks a range (2) proof: [spec] fig 13: MtaFinalize_wc
 // and returns the paillier encrypted random value
 func (rp ResponseProof) FinalizeWc(vp *ResponseVerifyParams) (*big.Int, error) {
-	v2Params := verifyProof2Params{
-		curve:        vp.Curve,
-		dealerParams: vp.DealerParams,
-		pk: &paillier.PublicKey{
-			N:  vp.Sk.N,
-			N2: vp.Sk.N2,
-		},
-		c1: vp.C1,
-		c2: rp.C2,
-		X:  vp.B,
-	}
 	// 1. If MtaVerifyRange2_wc(...) = False, Return Error
-	if err := rp.R2proof.VerifyWc(&v2Params); err != nil {
+	if err := rp.VerifyWc(vp.check()); err != nil {
 		return nil, err
 	}
 	// 2. Compute \alpha = Decrypt(sk, C2)
@@ -310,21 +306,76 @@ func (rp ResponseProof) FinalizeWc(vp *ResponseVerifyParams) (*big.Int, error) {
 	if err != nil {
 		return nil, err
 	}
+	params, err := groupParams(vp.Curve)
+	if err != nil {
+		return nil, err
+	}
 	// 3. Return \alpha mod q
-	return alpha.Mod(alpha, vp.Curve.Params().N), nil
+	return alpha.Mod(alpha, params.N), nil
+}
+
+// Verify checks the range (2) proof of a response without decrypting it,
+// which is step 1 of [spec] fig 13: MtaFinalize
+func (rp ResponseProof) Verify(vp *ResponseCheckParams) error {
+	return rp.verify(vp, false)
+}
+
+// VerifyWc checks the range (2) proof of a response without decrypting it,
+// which is step 1 of [spec] fig 13: MtaFinalize_wc
+func (rp ResponseProof) VerifyWc(vp *ResponseCheckParams) error {
+	return rp.verify(vp, true)
+}
+
+func (rp ResponseProof) verify(vp *ResponseCheckParams, wc bool) error {
+	if rp.R2proof == nil || rp.C2 == nil {
+		return fmt.Errorf("response proof values cannot be nil")
+	}
+	if vp == nil || vp.Curve == nil || vp.Pk == nil || vp.C1 == nil || (wc && vp.B == nil) {
+		return fmt.Errorf("response check params cannot be nil")
+	}
+	v2Params := verifyProof2Params{
+		curve:        vp.Curve,
+		dealerParams: vp.DealerParams,
+		pk:           vp.Pk,
+		c1:           vp.C1,
+		c2:           rp.C2,
+	}
+	if wc {
+		v2Params.X = vp.B
+		return rp.R2proof.VerifyWc(&v2Params)
+	}
+	return rp.R2proof.Verify(&v2Params)
+}
+
+// check returns the public part of the verify params
+func (vp *ResponseVerifyParams) check() *ResponseCheckParams {
+	return &ResponseCheckParams{
+		Curve:        vp.Curve,
+		DealerParams: vp.DealerParams,
+		Pk: &paillier.PublicKey{
+			N:  vp.Sk.N,
+			N2: vp.Sk.N2,
+		},
+		C1: vp.C1,
+		B:  vp.B,
+	}
 }
 
 // Prove computes a range proof over these parameters
 // [spec] fig 10: MtaProveRange1
 func (pp Proof1Params) Prove() (*Range1Proof, error) {
-	if err := core.In(pp.A, pp.Curve.Params().N); err != nil {
+	params, err := groupParams(pp.Curve)
+	if err != nil {
+		return nil, err
+	}
+	if err := core.In(pp.A, params.N); err != nil {
 		return nil, err
 	}
 	if err := core.In(pp.R, pp.Pk.N); err != nil {
 		return nil, err
 	}
 	// Fetch our randomized values
-	rp, err := rand1(pp.Pk.N, pp.DealerParams.N, pp.Curve.Params().N)
+	rp, err := rand1(pp.Pk.N, pp.DealerParams.N, params.N)
 	if err != nil {
 		return nil, err
 	}
@@ -388,6 +439,10 @@ func rand1(N, Ntilde, q *big.Int) (*randProof1Params, error) {
 // genProof1 deterministically computes a range proof
 // [spec] fig 10: MtaProveRange1
 func genProof1(in Proof1Params, rp *randProof1Params) (*Range1Proof, error) {
+	params, err := groupParams(in.Curve)
+	if err != nil {
+		return nil, err
+	}
 	// 6: z = h_1^a * h_2^\rho mod N~
 	z, err := pedersen(in.DealerParams.H1, in.DealerParams.H2, in.A, rp.rho, in.DealerParams.N)
 	if err != nil {
@@ -407,7 +462,7 @@ func genProof1(in Proof1Params, rp *randProof1Params) (*Range1Proof, error) {
 	}
 
 	// 9: e = H(g, q, Pk, N~, h_1, h_2, c, z, u, w)
-	bytes, err := core.FiatShamir(in.Curve.Params().Gx, in.Curve.Params().Gy, in.Curve.Params().N, in.Pk.N, in.DealerParams.N, in.DealerParams.H1, in.DealerParams.H2, in.C, z, u, w)
+	bytes, err := core.FiatShamir(params.Gx, params.Gy, params.N, in.Pk.N, in.DealerParams.N, in.DealerParams.H1, in.DealerParams.H2, in.C, z, u, w)
 	if err != nil {
 		return nil, err
 	}
@@ -446,7 +501,10 @@ func genProof1(in Proof1Params, rp *randProof1Params) (*Range1Proof, error) {
 
 // Verify checks a range (1) proof: [spec] §7.fig 7: MtaVerifyRange1
 func (pi Range1Proof) Verify(pp *Proof1Params) error {
-	params := pp.Curve.Params()
+	params, err := groupParams(pp.Curve)
+	if err != nil {
+		return err
+	}
 	// Rings in which we'll operate
 	q3, err := core.Exp(params.N, big.NewInt(3), nil) // q^3
 	if err != nil {
@@ -535,7 +593,11 @@ func (pi Range1Proof) wHatConstruct(pp *Proof1Params) (*big.Int, error) {
 // Prove computes a range proof over these parameters
 // [spec] fig 12: MtaProveRange2
 func (pp proof2Params) Prove() (*Range2Proof, error) {
-	randParams, err := rand2(pp.pk.N, pp.dealerParams.N, pp.curve.Params().N)
+	params, err := groupParams(pp.curve)
+	if err != nil {
+		return nil, err
+	}
+	randParams, err := rand2(pp.pk.N, pp.dealerParams.N, params.N)
 	if err != nil {
 		return nil, err
 	}
@@ -548,7 +610,11 @@ func (pp proof2Params) ProveWc() (*Range2Proof, error) {
 	if pp.X == nil {
 		return nil, fmt.Errorf("X must have a value")
 	}
-	randParams, err := rand2(pp.pk.N, pp.dealerParams.N, pp.curve.Params().N)
+	params, err := groupParams(pp.curve)
+	if err != nil {
+		return nil, err
+	}
+	randParams, err := rand2(pp.pk.N, pp.dealerParams.N, params.N)
 	if err != nil {
 		return nil, err
 	}
@@ -557,7 +623,10 @@ func (pp proof2Params) ProveWc() (*Range2Proof, error) {
 
 // genProof2 creates the proof for MtAProveRange2
 func genProof2(pp proof2Params, rp *randProof2Params, wc bool) (*Range2Proof, error) {
-	curveParams := pp.curve.Params()
+	curveParams, err := groupParams(pp.curve)
+	if err != nil {
+		return nil, err
+	}
 	if err := core.In(pp.x, curveParams.N); err != nil {
 		return nil, fmt.Errorf("x is not in q")
 	}
@@ -574,15 +643,14 @@ func genProof2(pp proof2Params, rp *randProof2Params, wc bool) (*Range2Proof, er
 	// receives MtAProveRange2    (g, q, Pk, nTilde, h1, h2, x, y, r, C1, C2)
 	// receives MtAProveRange2_wc (g, q, Pk, nTilde, h1, h2, x, y, r, C1, C2, X)
 
-	var u *curves.EcPoint
+	var ux, uy *big.Int
 	if wc {
 		// u = g^\alpha
-		ux, uy := pp.curve.ScalarBaseMult(rp.alpha.Bytes())
-		u = &curves.EcPoint{
-			Curve: pp.curve,
-			X:     ux,
-			Y:     uy,
+		alpha, err := pp.curve.Scalar.SetBigInt(rp.alpha)
+		if err != nil {
+			return nil, err
 		}
+		ux, uy = affine(pp.curve.ScalarBaseMult(alpha))
 	}
 
 	// z = h1^x * h2^rho mod N ̃
@@ -626,7 +694,8 @@ func genProof2(pp proof2Params, rp *randProof2Params, wc bool) (*Range2Proof, er
 	var challenge []byte
 	if wc {
 		// g || q || Pk || N ̃ || h1 || h2 || X || C1 || C2 || u || z || z' || t || v || w
-		challenge, err = core.FiatShamir(curveParams.Gx, curveParams.Gy, curveParams.N, pp.pk.N, pp.dealerParams.N, pp.dealerParams.H1, pp.dealerParams.H2, pp.X.X, pp.X.Y, pp.c1, pp.c2, u.X, u.Y, z, zTick, t, v, w)
+		xx, xy := affine(pp.X)
+		challenge, err = core.FiatShamir(curveParams.Gx, curveParams.Gy, curveParams.N, pp.pk.N, pp.dealerParams.N, pp.dealerParams.H1, pp.dealerParams.H2, xx, xy, pp.c1, pp.c2, ux, uy, z, zTick, t, v, w)
 		if err != nil {
 			return nil, err
 		}
@@ -695,13 +764,18 @@ func (pi Range2Proof) VerifyWc(pp *verifyProof2Params) error {
 func verify2Proof(pi Range2Proof, pp *verifyProof2Params, wc bool) error {
 	// 1: Set N = pk.N
 
+	curveParams, err := groupParams(pp.curve)
+	if err != nil {
+		return err
+	}
+
 	// Rings in which we'll operate
-	q3, err := core.Exp(pp.curve.Params().N, big.NewInt(3), nil) // q^3
+	q3, err := core.Exp(curveParams.N, big.NewInt(3), nil) // q^3
 	if err != nil {
 		return err
 	}
 
-	q7, err := core.Exp(pp.curve.Params().N, big.NewInt(7), nil) // q^7
+	q7, err := core.Exp(curveParams.N, big.NewInt(7), nil) // q^7
 	if err != nil {
 		return err
 	}
@@ -738,7 +812,7 @@ func verify2Proof(pi Range2Proof, pp *verifyProof2Params, wc bool) error {
 		return fmt.Errorf("w hat construction error: %w", err)
 	}
 
-	var uHat *curves.EcPoint
+	var uHat curves.Point
 	if wc {
 		// steps 3, 4
 		uHat, err = pi.uHatConstruct(pp)
@@ -747,11 +821,12 @@ func verify2Proof(pi Range2Proof, pp *verifyProof2Params, wc bool) error {
 		}
 	}
 
-	curveParams := pp.curve.Params()
 	var challenge []byte
 	if wc {
 		// g || q || Pk || N ̃ || h1 || h2 || X || c1 || c2 || uHat || z || zHatTick || t || vHat || wHat
-		challenge, err = core.FiatShamir(curveParams.Gx, curveParams.Gy, curveParams.N, pp.pk.N, pp.dealerParams.N, pp.dealerParams.H1, pp.dealerParams.H2, pp.X.X, pp.X.Y, pp.c1, pp.c2, uHat.X, uHat.Y, pi.z, zHatTick, pi.t, vHat, wHat)
+		xx, xy := affine(pp.X)
+		uHatX, uHatY := affine(uHat)
+		challenge, err = core.FiatShamir(curveParams.Gx, curveParams.Gy, curveParams.N, pp.pk.N, pp.dealerParams.N, pp.dealerParams.H1, pp.dealerParams.H2, xx, xy, pp.c1, pp.c2, uHatX, uHatY, pi.z, zHatTick, pi.t, vHat, wHat)
 		if err != nil {
 			return err
 		}
@@ -772,29 +847,19 @@ func verify2Proof(pi Range2Proof, pp *verifyProof2Params, wc bool) error {
 	return nil
 }
 
-func (pi Range2Proof) uHatConstruct(pp *verifyProof2Params) (*curves.EcPoint, error) {
+func (pi Range2Proof) uHatConstruct(pp *verifyProof2Params) (curves.Point, error) {
 	// 3. Compute s1' = s1 mod q
-	s1Tick := new(big.Int).Mod(pi.s1, pp.curve.Params().N)
-
-	// 4: \hat{u} = g^{s^\prime_1} . X^{-e} in G
-
-	gS1Tick, err := curves.NewScalarBaseMult(pp.curve, s1Tick)
-	if err != nil {
-		return nil, err
-	}
-
-	negE := new(big.Int).Neg(pi.e)
-
-	XnegE, err := pp.X.ScalarMult(negE)
+	s1Tick, err := pp.curve.Scalar.SetBigInt(pi.s1)
 	if err != nil {
 		return nil, err
 	}
 
-	uHat, err := gS1Tick.Add(XnegE)
+	// 4: \hat{u} = g^{s^\prime_1} . X^{-e} in G
+	e, err := pp.curve.Scalar.SetBigInt(pi.e)
 	if err != nil {
 		return nil, err
 	}
-	return uHat, err
+	return pp.curve.ScalarBaseMult(s1Tick).Sub(pp.X.Mul(e)), nil
 }
 
 func (pi Range2Proof) zHatTickConstruct(pp *verifyProof2Params) (*big.Int, error) {
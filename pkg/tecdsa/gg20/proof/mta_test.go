@@ -11,7 +11,6 @@ import (
 	"math/big"
 	"testing"
 
-	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/stretchr/testify/require"
 
 	tt "github.com/TEENet-io/kryptology/internal"
@@ -22,9 +21,9 @@ import (
 )
 
 func TestRange1Proof(t *testing.T) {
-	curve := btcec.S256()
+	curve := curves.K256()
 	params := []*Proof1Params{{
-		Curve: curve.CurveParams,
+		Curve: curve,
 		Pk:    makeNewPaillierPublicKey(t, tt.B10("141823146723804727729633738357765828294831142954010692457328076293957505983859895806895734447482792591026897171204062485196286886661302420276834336655587982881158751341502328470707400685538658612981911773025635736943733001923468023720033301313420189810298555472599689752973326558212722047094791724129635174941")),
 		DealerParams: &dealer.ProofParams{
 			N:  tt.B10("135817986946410153263607521492868157288929876347703239389804036854326452848342067707805833332721355089496671444901101084429868705550525577068432132709786157994652561102559125256427177197007418406633665154772412807319781659630513167839812152507439439445572264448924538846645935065905728327076331348468251587961"),
@@ -36,7 +35,7 @@ func TestRange1Proof(t *testing.T) {
 		R: tt.B10("51886819834371251006015218788911494248706876016519888346806242851820213680996324974371873475760855517466937827668415006977450699788621663903939308570896707699568767367925269253643717512759597981479828523349172771324686694516983594834820044069638828375581061206377589070672524406557592378617469206619362344334"),
 	},
 		{
-			Curve: curve.CurveParams,
+			Curve: curve,
 			Pk:    makeNewPaillierPublicKey(t, tt.B10("9463658995206843770851525158043255816500680189464439363095520560386614479556714668895588462872126103893747723284862135073561694263269764946949629217223601")),
 			DealerParams: &dealer.ProofParams{
 				N:  tt.B10("10273826729942063692697010060060594189312421215159102246808412466917904003223823687218001436152302280389572289298911434937289415755177478451846062468556417"),
@@ -48,7 +47,7 @@ func TestRange1Proof(t *testing.T) {
 			R: tt.B10("5429643852343163390381887406843848421434905359379877890761970908590486754109980221258199728602735196971674594950794639675145303739445896672634255126793715"),
 		},
 		{
-			Curve: curve.CurveParams,
+			Curve: curve,
 			Pk:    makeNewPaillierPublicKey(t, tt.B10("26509846098969606030666644039648735072473371434396262226628957752776958016920176604138513076285080490756189037154836350358953193044108325638765720332205170853305196270848703082212703922373221666660374795896208650056354982913710298628785967974006931457072686585546613336263077109720447885211641481085196018808984886607748129379016797794987745814597073536482747374479333608767826317573570455719831930309513750297610046842033491846725866000900668533198008806351432839430072881565290122414283921152984764756250407839754767248421661857280750005054466634329774370484024085212040716987194046113511394116210477123260601300881")),
 			DealerParams: &dealer.ProofParams{
 				H1: tt.B10("14992883567326790009834034452774380881734723148422937597913759615616527894329716582094216131278069891754415684125978444772511456388384539478359237139420627595253084679427853969706385560348203106008084518063368655525999918846958064735464480540227040261127629154933497666914570262795996253259552162123968669420229672303143236908906153814910725786469012911748229438005634598038592141662328047446496393313102162064295444876003000587651597062721350331609362965763517981159778802267296421256980684242791310186114847130909958129781491826248268454674630252197299528151229014292276126945753681298480222125140847225297966918532"),
@@ -68,9 +67,9 @@ func TestRange1Proof(t *testing.T) {
 }
 
 func TestRange1ProofValuesTampered(t *testing.T) {
-	curve := btcec.S256()
+	curve := curves.K256()
 	params := []*Proof1Params{{
-		Curve: curve.CurveParams,
+		Curve: curve,
 		Pk:    makeNewPaillierPublicKey(t, tt.B10("141823146723804727729633738357765828294831142954010692457328076293957505983859895806895734447482792591026897171204062485196286886661302420276834336655587982881158751341502328470707400685538658612981911773025635736943733001923468023720033301313420189810298555472599689752973326558212722047094791724129635174941")),
 		DealerParams: &dealer.ProofParams{
 			N:  tt.B10("135817986946410153263607521492868157288929876347703239389804036854326452848342067707805833332721355089496671444901101084429868705550525577068432132709786157994652561102559125256427177197007418406633665154772412807319781659630513167839812152507439439445572264448924538846645935065905728327076331348468251587961"),
@@ -95,7 +94,7 @@ func TestRange1ProofValuesTampered(t *testing.T) {
 }
 
 func TestRange1ProofRandValue(t *testing.T) {
-	curve := btcec.S256()
+	curve := curves.K256()
 	q := tt.B10("115792089237316195423570985008687907852837564279074904382605163141518161494337")
 	pk, _ := crypto.Rand(q)
 	nTilde, _ := crypto.Rand(q)
@@ -105,7 +104,7 @@ func TestRange1ProofRandValue(t *testing.T) {
 	c, _ := crypto.Rand(q)
 	r, _ := crypto.Rand(pk)
 	params := []*Proof1Params{{
-		Curve: curve.CurveParams,
+		Curve: curve,
 		Pk:    makeNewPaillierPublicKey(t, pk),
 		DealerParams: &dealer.ProofParams{
 			N:  nTilde,
@@ -138,10 +137,10 @@ func TestRange1ProofInvalidInputs(t *testing.T) {
 	a, _ := crypto.Rand(q)
 	c, _ := crypto.Rand(q)
 	r := new(big.Int).Add(pk, crypto.One)
-	curve := btcec.S256()
+	curve := curves.K256()
 
 	pp := Proof1Params{
-		Curve: curve.CurveParams,
+		Curve: curve,
 		Pk:    makeNewPaillierPublicKey(t, pk),
 		DealerParams: &dealer.ProofParams{
 			N:  nTilde,
@@ -166,7 +165,7 @@ func TestRange1ProofInvalidInputs(t *testing.T) {
 }
 
 func TestRange2Proof(t *testing.T) {
-	curve := btcec.S256()
+	curve := curves.K256()
 	params := []*proof2Params{
 		{
 			curve: curve,
@@ -229,7 +228,7 @@ func TestRange2Proof(t *testing.T) {
 }
 
 func TestRange2ProofValuesTampered(t *testing.T) {
-	curve := btcec.S256()
+	curve := curves.K256()
 	params := []*proof2Params{
 		{
 			curve: curve,
@@ -329,7 +328,7 @@ func TestRange2ProofRandValue(t *testing.T) {
 	nn := new(big.Int).Mul(pk, pk)
 	c1, _ := crypto.Rand(nn)
 	c2, _ := crypto.Rand(nn)
-	curve := btcec.S256()
+	curve := curves.K256()
 	params := []*proof2Params{{
 		curve: curve,
 		pk:    makeNewPaillierPublicKey(t, pk),
@@ -377,7 +376,7 @@ func TestRange2ProofInvalidInputs(t *testing.T) {
 	nn := new(big.Int).Mul(pk, pk)
 	c1, _ := crypto.Rand(nn)
 	c2, _ := crypto.Rand(nn)
-	curve := btcec.S256()
+	curve := curves.K256()
 	pp := proof2Params{
 		curve: curve,
 		pk:    makeNewPaillierPublicKey(t, pk),
@@ -401,7 +400,7 @@ func TestRange2ProofInvalidInputs(t *testing.T) {
 }
 
 func TestRange2ProofWc(t *testing.T) {
-	curve := btcec.S256()
+	curve := curves.K256()
 	params := []*proof2Params{
 		{
 			curve: curve,
@@ -416,11 +415,7 @@ func TestRange2ProofWc(t *testing.T) {
 			r:  tt.B10("4363484852273523603262320180197243948656453076626233042069908639363078809310709196408992073830475754771592087220152292969998955037959739958755662786605637"),
 			c1: tt.B10("143139889611903589791816262441353094495263441207310098132338003158492847002109314489881492343532425133051402774239042571452064034364626727430154115486293525771026058106095404307844006484693932483119088608294103884465173259538644991990959041058781505906814834658717310198971570959147449328032151332111925175205"),
 			c2: tt.B10("19195348154199773778746648720722898279135046719896678010947233222132884371365736173725982230103203752735057259846207279148538892285247200735637734794475203364830106371076254231163224494408103114834371528149937496661574602804458404535257757455480616316607980648677031500879028841134692321704698761187480067873"),
-			X: &curves.EcPoint{
-				Curve: curve,
-				X:     tt.B10("91728638115298553698534579680860154297052620960857262003204971580447260676714"),
-				Y:     tt.B10("46528621496185269900738225944046033204111426188519579310716037563019923659840"),
-			},
+			X:  newPoint(t, curve, tt.B10("91728638115298553698534579680860154297052620960857262003204971580447260676714"), tt.B10("46528621496185269900738225944046033204111426188519579310716037563019923659840")),
 		},
 		{
 			curve: curve,
@@ -435,11 +430,7 @@ func TestRange2ProofWc(t *testing.T) {
 			r:  tt.B10("888058240099898369796301614560032313892768638268907539325375604144277016366624173232747685340457922458934109617422293672549612621418610901551787056438299857915004944612536735623417151331408192254932880030374366112203761635966298450"),
 			c1: tt.B10("1053114622466963003050574957242008810794692807386408905242320867953216982016919832662798802583673970059568892771139314303098776240720837149047157440593394428053903998661246679248461257929216872492254813539989922056355923322352092748537060888144046806888646757812380709971001358158052219367381617739549374202298322532799430177580147769298175501100566420263545685855762900224502196330893960190794093573840711343202150393882356493072497241709222593553058376990428749"),
 			c2: tt.B10("41340698469333152688260868181324402159016800779397564916350495374017145720920675724935526413079768433553005381005392056459149330405772567609741329078236657113817238466831526092986465023085283588691781682327796646400197406692686047685044126078255854462075285791347534285693993198879333558346181066297564145446802840188653998155595102332574923195778188986280370012966298916870489742913815430939983119279229418973563724812108992374019807799303115231239067250729564"),
-			X: &curves.EcPoint{
-				Curve: curve,
-				X:     tt.B10("107601712100661113061031434183161356564972924141547945478098809692346131828080"),
-				Y:     tt.B10("3589084389824339800037562590910754992453197762153609650478957748952965377128"),
-			},
+			X:  newPoint(t, curve, tt.B10("107601712100661113061031434183161356564972924141547945478098809692346131828080"), tt.B10("3589084389824339800037562590910754992453197762153609650478957748952965377128")),
 		},
 		{
 			curve: curve,
@@ -454,11 +445,7 @@ func TestRange2ProofWc(t *testing.T) {
 			r:  tt.B10("59238036350161433683964600968466153339586133851223832290239510260777927531675142454348071309595252200374748010110311763004371190255994800674183695925497200262590510116882625824957107227468018079094027884062705673526463098161390567333481840166248876465673217759292849432688242893016519027966940180059894985824"),
 			c1: tt.B10("10775808173033770984516167593505365443783752555324100755349598877836047307677011637063063086315464238435942493513507939945317658034289026591317406423694552504425405748691173106069124708756001018953795030004747650666979352069544640241341341634464323746030746804743164353982891624309639357226399617037483637891422140507649333375651193920153187885728755575682029759253435182307427668124884893428171790688719491734746237016553855965720036356784163356580399681227692884720048765980707977190599610039433157123735266679615119769970198124644524904227106442303993553933698710757937136567369366989575579828638106312021244735576"),
 			c2: tt.B10("8669994847222944827731413185238631223342512933073598749594380741902881926721448323152761878273603280020540092523354623686620318555949946475124609531059425098852796639856168570781706247043134655390950061788371876610687989021227302828684645137706649838806983143756979394641645839296366192418845809885213117243547864131270828413851148272506504758952499726235700808985132855761659056175068850855751051424547525636181432636854662717404566880331863655422392173296127670644628347486696859388167996605618709772946306399239151611786081555662954810096380818816671849617579744285984415619450579838201336298597808039360903321169"),
-			X: &curves.EcPoint{
-				Curve: curve,
-				X:     tt.B10("90150166232895062437787689690145553528266570780800420868158771113502089398282"),
-				Y:     tt.B10("1869077702054421301647617103108125015760730326845474403195185534538329305747"),
-			},
+			X:  newPoint(t, curve, tt.B10("90150166232895062437787689690145553528266570780800420868158771113502089398282"), tt.B10("1869077702054421301647617103108125015760730326845474403195185534538329305747")),
 		},
 		{
 			curve: curve,
@@ -473,11 +460,7 @@ func TestRange2ProofWc(t *testing.T) {
 			r:  tt.B10("9946567100548993249012340658105268778650693965119700801608149211510796739900388058218198700978846608588059973112468669026266207961026625823948372235183934590831468483656837588613887746109602208558361942956054575713888749011558935168263227475826290050119941331949176438039072729823415114695606557506377839668633000824594261781349788019381290494222894432779202335608576778880386740215251099705459476129479606075600774519041233411211390500861888372768175157849318504734268770297902212819860691399274500082304841576969990476081103065632008731128674268935918170996647482274630474817804277720647335686780855127689206010551"),
 			c1: tt.B10("390111299656227329049911645962500049392377387594152457668485483110800144720345180158229237161842828307254342803083379655054467517296523210675130263404686580646992792502830916302607437160813512962532146963059202294210687402129360020946526304905339187636229176261028993936810993006653314076335851074005727014314790162490593160207216642747021985552411284433670026363853068691081342083837886029591829540186654863374285161120653539204838867832932794810504576871885290319716389567292694319143715930162553277300961962282646376301436460212705495080036300372011810745765622016658529618697349936654020519012125853508298624943295528382686465797342272133086342053444730761082595522615614885422356326860879270969967076748246605215181659738839204838987139706656213356349676893722627421667492378370979872311805729149448736645369425823598900065737429573410247512812271614068171292075691207858929206060218031819070034607110062687579951509005561963770102163971963284714329558342723034716742547265888594343447182501412167939138160985186539850524487166372948687703793947452682996182101681482493637159856664957425454529042197760223673798097694156006735908818858773992720569533879034557981434329714599172480672498298131329669845732971041195290211843618632"),
 			c2: tt.B10("76393016218744255122410008505645830302161163757329991891258193877243572573191359161137092611285681900971054214148874111315803943453441271236132564570471997219962256463739327253241831710550984604323623133440863687807106971417162302656340154123556196059267019813650785102320279454558442468410965964046508464880900882369207970551681600152529643742652562215939692356050969371682711938942857698415959186253370728002534848118688046065083131510944868385773466286126970213703120620436158606369088391686830900507433014047022014733758972553521226409513599033661420933842107861418213897609271736060400182527314036585596069558406632015325212074833626053690174036592575384425473077817035410251985240494795357939164949401141794583831427715487478002037651985206501266398847135251773278706910054417397500331605503217051476405590922249668129192842410789555156003154535383608379807091956699767500212411986125645384769913601379471947987356817181837661893259115204167989970965778865999290551846611944010029042259919662437696792390865082254535489075497914595253489177724352050301559729156702646069006066128704645423500048450688987383641355944930480381483959540276139530148577955364459669692042789035810263488174971695734761749891009880633926881798792574"),
-			X: &curves.EcPoint{
-				Curve: curve,
-				X:     tt.B10("84674449911578766155706641035916912012817709924247813477800860461954988274117"),
-				Y:     tt.B10("49870043053956780909515260091420037915179380266406189682065174550325177769213"),
-			},
+			X:  newPoint(t, curve, tt.B10("84674449911578766155706641035916912012817709924247813477800860461954988274117"), tt.B10("49870043053956780909515260091420037915179380266406189682065174550325177769213")),
 		},
 	}
 	for _, pp := range params {
@@ -510,7 +493,7 @@ func TestRange2ProofWcInvalidInputs(t *testing.T) {
 	nn := new(big.Int).Mul(pk, pk)
 	c1, _ := crypto.Rand(nn)
 	c2, _ := crypto.Rand(nn)
-	curve := btcec.S256()
+	curve := curves.K256()
 	pp := proof2Params{
 		curve: curve,
 		pk:    makeNewPaillierPublicKey(t, pk),
@@ -531,11 +514,7 @@ func TestRange2ProofWcInvalidInputs(t *testing.T) {
 	if err == nil {
 		t.Errorf("Expected proof to fail but succeeded")
 	}
-	pp.X = &curves.EcPoint{
-		Curve: curve,
-		X:     crypto.One,
-		Y:     crypto.One,
-	}
+	pp.X = curve.NewGeneratorPoint()
 
 	pp.x.Add(q, crypto.One)
 
@@ -547,7 +526,7 @@ func TestRange2ProofWcInvalidInputs(t *testing.T) {
 }
 
 func TestRange2ProofWcTampered(t *testing.T) {
-	curve := btcec.S256()
+	curve := curves.K256()
 	params := []*proof2Params{
 		{
 			curve: curve,
@@ -562,11 +541,7 @@ func TestRange2ProofWcTampered(t *testing.T) {
 			r:  tt.B10("1479534906543044211837231294218146026461926193132920007306744928145282345504037584342904362659217507974835202601777094522405553436698892393763885061388478"),
 			c1: tt.B10("143139889611903589791816262441353094495263441207310098132338003158492847002109314489881492343532425133051402774239042571452064034364626727430154115486293525771026058106095404307844006484693932483119088608294103884465173259538644991990959041058781505906814834658717310198971570959147449328032151332111925175205"),
 			c2: tt.B10("118850417101393756978218229112645750124529443709777802628131640767910204536747768079123339300198360815481275960898822338743893982286509338811075702711051016050016330207766224111849625677925169769209362045134781493418045321274455428812004395599285384603402897485645035555320943199972214345978870565639942564875"),
-			X: &curves.EcPoint{
-				Curve: curve,
-				X:     tt.B10("22963319250927626464432314334264998185524558636490611781390004531598870711554"),
-				Y:     tt.B10("5554841823708042459730720811862682529836762867109252962559124452631448728441"),
-			},
+			X:  newPoint(t, curve, tt.B10("22963319250927626464432314334264998185524558636490611781390004531598870711554"), tt.B10("5554841823708042459730720811862682529836762867109252962559124452631448728441")),
 		},
 	}
 
@@ -591,7 +566,7 @@ func TestRange2ProofWcTampered(t *testing.T) {
 }
 
 func TestMtaResponse(t *testing.T) {
-	curve := btcec.S256()
+	curve := curves.K256()
 	p := tt.B10("165498465971525536497859961269214938631289964308823560526920537236787050377699904896554622379770774622567664583533323254169290844053351296829514419428489585830394868303448384771151376037064711115810339324861594209655768995895643373763166292366557525131878080032169065959558884224551806641003919879441772258023")
 	q := tt.B10("153220808452726670380485250948911100156879705361932013063379432599289284377538415448437552509228215741069875651231891196863559464003506000735603508391315084830677543632875274002601909274977876224268309554767555583618065737119993835971994691072180460197745186395985316826257903003552375842892383205848110359007")
 	sk1, _ := paillier.NewSecretKey(p, q)
@@ -616,11 +591,7 @@ func TestMtaResponse(t *testing.T) {
 			},
 			SmallB: tt.B10("3672791578831700453156064277674396724953117514808181048561503554767812240270"),
 			C1:     tt.B10("77752317560568208206364290265071376718942102730865626173881960000095692236560274288202792728825652824950440213334665539641290999004150367191954333808152149418358084240829193835628503450268249050991899350405972770791757133533190107036510955688542448103156155740141942033820625257531299610966919367173008180688"),
-			B: &curves.EcPoint{
-				Curve: curve,
-				X:     tt.B10("1404866900921975962487332641664497649570820021171040875292185052444634265070"),
-				Y:     tt.B10("22517056746740517460185947144352325511166429596179017818627772864271597116261"),
-			},
+			B:      newPoint(t, curve, tt.B10("1404866900921975962487332641664497649570820021171040875292185052444634265070"), tt.B10("22517056746740517460185947144352325511166429596179017818627772864271597116261")),
 		},
 		{
 			Curve: curve,
@@ -632,11 +603,7 @@ func TestMtaResponse(t *testing.T) {
 			},
 			SmallB: tt.B10("35757537348996621957461215098882730872747662393337533981591895725421179051196"),
 			C1:     tt.B10("92352588588873729555430055172430595475910468157254446887005633321086645506984959001356424661276448180525800287366079400461958418643751933701277823127881543396640181010723902113675414173812412486759363992283619394596611725910844787234817793297468668543716260158106119514868237915982895111931557116755302785277"),
-			B: &curves.EcPoint{
-				Curve: curve,
-				X:     tt.B10("47119159900193871131301454349459631159900055770279833902936369018055039407305"),
-				Y:     tt.B10("115146922864592374131907157924472992158066770148954896711377713046850262233029"),
-			},
+			B:      newPoint(t, curve, tt.B10("47119159900193871131301454349459631159900055770279833902936369018055039407305"), tt.B10("115146922864592374131907157924472992158066770148954896711377713046850262233029")),
 		},
 	}
 
@@ -657,14 +624,14 @@ func TestMtaResponse(t *testing.T) {
 		if err != nil {
 			t.Errorf("MtAFinalize failed: %v", err)
 		}
-		if err := crypto.In(alpha, curve.N); err != nil {
+		if err := crypto.In(alpha, curveOrder(t, curve)); err != nil {
 			t.Errorf("MtAFinalize failed. alpha is not in q")
 		}
 	}
 }
 
 func TestMtaResponseTampered(t *testing.T) {
-	curve := btcec.S256()
+	curve := curves.K256()
 	p := tt.B10("165498465971525536497859961269214938631289964308823560526920537236787050377699904896554622379770774622567664583533323254169290844053351296829514419428489585830394868303448384771151376037064711115810339324861594209655768995895643373763166292366557525131878080032169065959558884224551806641003919879441772258023")
 	q := tt.B10("153220808452726670380485250948911100156879705361932013063379432599289284377538415448437552509228215741069875651231891196863559464003506000735603508391315084830677543632875274002601909274977876224268309554767555583618065737119993835971994691072180460197745186395985316826257903003552375842892383205848110359007")
 	sk1, _ := paillier.NewSecretKey(p, q)
@@ -689,11 +656,7 @@ func TestMtaResponseTampered(t *testing.T) {
 			},
 			SmallB: tt.B10("3672791578831700453156064277674396724953117514808181048561503554767812240270"),
 			C1:     tt.B10("77752317560568208206364290265071376718942102730865626173881960000095692236560274288202792728825652824950440213334665539641290999004150367191954333808152149418358084240829193835628503450268249050991899350405972770791757133533190107036510955688542448103156155740141942033820625257531299610966919367173008180688"),
-			B: &curves.EcPoint{
-				Curve: curve,
-				X:     tt.B10("1404866900921975962487332641664497649570820021171040875292185052444634265070"),
-				Y:     tt.B10("22517056746740517460185947144352325511166429596179017818627772864271597116261"),
-			},
+			B:      newPoint(t, curve, tt.B10("1404866900921975962487332641664497649570820021171040875292185052444634265070"), tt.B10("22517056746740517460185947144352325511166429596179017818627772864271597116261")),
 		},
 		{
 			Curve: curve,
@@ -705,11 +668,7 @@ func TestMtaResponseTampered(t *testing.T) {
 			},
 			SmallB: tt.B10("35757537348996621957461215098882730872747662393337533981591895725421179051196"),
 			C1:     tt.B10("92352588588873729555430055172430595475910468157254446887005633321086645506984959001356424661276448180525800287366079400461958418643751933701277823127881543396640181010723902113675414173812412486759363992283619394596611725910844787234817793297468668543716260158106119514868237915982895111931557116755302785277"),
-			B: &curves.EcPoint{
-				Curve: curve,
-				X:     tt.B10("47119159900193871131301454349459631159900055770279833902936369018055039407305"),
-				Y:     tt.B10("115146922864592374131907157924472992158066770148954896711377713046850262233029"),
-			},
+			B:      newPoint(t, curve, tt.B10("47119159900193871131301454349459631159900055770279833902936369018055039407305"), tt.B10("115146922864592374131907157924472992158066770148954896711377713046850262233029")),
 		},
 	}
 
@@ -738,7 +697,7 @@ func TestMtaResponseTampered(t *testing.T) {
 }
 
 func TestMtaResponseWrongSk(t *testing.T) {
-	curve := btcec.S256()
+	curve := curves.K256()
 	p := tt.B10("165498465971525536497859961269214938631289964308823560526920537236787050377699904896554622379770774622567664583533323254169290844053351296829514419428489585830394868303448384771151376037064711115810339324861594209655768995895643373763166292366557525131878080032169065959558884224551806641003919879441772258023")
 	q := tt.B10("153220808452726670380485250948911100156879705361932013063379432599289284377538415448437552509228215741069875651231891196863559464003506000735603508391315084830677543632875274002601909274977876224268309554767555583618065737119993835971994691072180460197745186395985316826257903003552375842892383205848110359007")
 	sk1, _ := paillier.NewSecretKey(p, q)
@@ -763,11 +722,7 @@ func TestMtaResponseWrongSk(t *testing.T) {
 			},
 			SmallB: tt.B10("3672791578831700453156064277674396724953117514808181048561503554767812240270"),
 			C1:     tt.B10("77752317560568208206364290265071376718942102730865626173881960000095692236560274288202792728825652824950440213334665539641290999004150367191954333808152149418358084240829193835628503450268249050991899350405972770791757133533190107036510955688542448103156155740141942033820625257531299610966919367173008180688"),
-			B: &curves.EcPoint{
-				Curve: curve,
-				X:     tt.B10("1404866900921975962487332641664497649570820021171040875292185052444634265070"),
-				Y:     tt.B10("22517056746740517460185947144352325511166429596179017818627772864271597116261"),
-			},
+			B:      newPoint(t, curve, tt.B10("1404866900921975962487332641664497649570820021171040875292185052444634265070"), tt.B10("22517056746740517460185947144352325511166429596179017818627772864271597116261")),
 		},
 		{
 			Curve: curve,
@@ -779,11 +734,7 @@ func TestMtaResponseWrongSk(t *testing.T) {
 			},
 			SmallB: tt.B10("35757537348996621957461215098882730872747662393337533981591895725421179051196"),
 			C1:     tt.B10("92352588588873729555430055172430595475910468157254446887005633321086645506984959001356424661276448180525800287366079400461958418643751933701277823127881543396640181010723902113675414173812412486759363992283619394596611725910844787234817793297468668543716260158106119514868237915982895111931557116755302785277"),
-			B: &curves.EcPoint{
-				Curve: curve,
-				X:     tt.B10("47119159900193871131301454349459631159900055770279833902936369018055039407305"),
-				Y:     tt.B10("115146922864592374131907157924472992158066770148954896711377713046850262233029"),
-			},
+			B:      newPoint(t, curve, tt.B10("47119159900193871131301454349459631159900055770279833902936369018055039407305"), tt.B10("115146922864592374131907157924472992158066770148954896711377713046850262233029")),
 		},
 	}
 
@@ -812,7 +763,7 @@ func TestMtaResponseWrongSk(t *testing.T) {
 }
 
 func TestMtaResponseWc(t *testing.T) {
-	curve := btcec.S256()
+	curve := curves.K256()
 	p := tt.B10("165498465971525536497859961269214938631289964308823560526920537236787050377699904896554622379770774622567664583533323254169290844053351296829514419428489585830394868303448384771151376037064711115810339324861594209655768995895643373763166292366557525131878080032169065959558884224551806641003919879441772258023")
 	q := tt.B10("153220808452726670380485250948911100156879705361932013063379432599289284377538415448437552509228215741069875651231891196863559464003506000735603508391315084830677543632875274002601909274977876224268309554767555583618065737119993835971994691072180460197745186395985316826257903003552375842892383205848110359007")
 	sk1, _ := paillier.NewSecretKey(p, q)
@@ -837,11 +788,7 @@ func TestMtaResponseWc(t *testing.T) {
 			},
 			SmallB: tt.B10("52267911369760448083721726508303370554429030131465579507196814348877287376175"),
 			C1:     tt.B10("93754185865506539900146251138967028050138438425830227162734497980682090590177901823650921334915913917868690888765728438724497317559474386038306965827476012034259085194788921672874210195001360283191491538466572357948080845312295163017659705576708240686678523086913486497655485353079002424965875207215069433398"),
-			B: &curves.EcPoint{
-				Curve: curve,
-				X:     tt.B10("46954861409208447737222535381893412230292203379578782164747089670087262529053"),
-				Y:     tt.B10("27561307727226443248314925239745475174755673899477133056173695085314410311303"),
-			},
+			B:      newPoint(t, curve, tt.B10("46954861409208447737222535381893412230292203379578782164747089670087262529053"), tt.B10("27561307727226443248314925239745475174755673899477133056173695085314410311303")),
 		},
 		{
 			Curve: curve,
@@ -853,11 +800,7 @@ func TestMtaResponseWc(t *testing.T) {
 			},
 			SmallB: tt.B10("96239849653450913364080147788247184659137655804206953534092167696450698926934"),
 			C1:     tt.B10("83665058672718242257733172987490955606067969188309375700725991273542909812324380350060502232466695592442821671447507874987206896988955864837869918873659407684672821529999047344427388923843813425378165980734381968601276897393925133091718868356318532966891511178329432381215866238662246512828543244610787501373"),
-			B: &curves.EcPoint{
-				Curve: curve,
-				X:     tt.B10("73152511031340534294337476499647134418743213468977810079263243542061796393179"),
-				Y:     tt.B10("37239378704657125612907515682572196819463356277616280533808406179170645816253"),
-			},
+			B:      newPoint(t, curve, tt.B10("73152511031340534294337476499647134418743213468977810079263243542061796393179"), tt.B10("37239378704657125612907515682572196819463356277616280533808406179170645816253")),
 		},
 	}
 	for i, pp := range params {
@@ -877,14 +820,14 @@ func TestMtaResponseWc(t *testing.T) {
 		if err != nil {
 			t.Errorf("MtAFinalizeWc failed: %v", err)
 		}
-		if err := crypto.In(alpha, curve.N); err != nil {
+		if err := crypto.In(alpha, curveOrder(t, curve)); err != nil {
 			t.Errorf("MtAFinalizeWc failed. alpha is not in q")
 		}
 	}
 }
 
 func TestMtaResponseWcTampered(t *testing.T) {
-	curve := btcec.S256()
+	curve := curves.K256()
 	p := tt.B10("165498465971525536497859961269214938631289964308823560526920537236787050377699904896554622379770774622567664583533323254169290844053351296829514419428489585830394868303448384771151376037064711115810339324861594209655768995895643373763166292366557525131878080032169065959558884224551806641003919879441772258023")
 	q := tt.B10("153220808452726670380485250948911100156879705361932013063379432599289284377538415448437552509228215741069875651231891196863559464003506000735603508391315084830677543632875274002601909274977876224268309554767555583618065737119993835971994691072180460197745186395985316826257903003552375842892383205848110359007")
 	sk1, _ := paillier.NewSecretKey(p, q)
@@ -909,11 +852,7 @@ func TestMtaResponseWcTampered(t *testing.T) {
 			},
 			SmallB: tt.B10("3672791578831700453156064277674396724953117514808181048561503554767812240270"),
 			C1:     tt.B10("77752317560568208206364290265071376718942102730865626173881960000095692236560274288202792728825652824950440213334665539641290999004150367191954333808152149418358084240829193835628503450268249050991899350405972770791757133533190107036510955688542448103156155740141942033820625257531299610966919367173008180688"),
-			B: &curves.EcPoint{
-				Curve: curve,
-				X:     tt.B10("1404866900921975962487332641664497649570820021171040875292185052444634265070"),
-				Y:     tt.B10("22517056746740517460185947144352325511166429596179017818627772864271597116261"),
-			},
+			B:      newPoint(t, curve, tt.B10("1404866900921975962487332641664497649570820021171040875292185052444634265070"), tt.B10("22517056746740517460185947144352325511166429596179017818627772864271597116261")),
 		},
 		{
 			Curve: curve,
@@ -925,11 +864,7 @@ func TestMtaResponseWcTampered(t *testing.T) {
 			},
 			SmallB: tt.B10("35757537348996621957461215098882730872747662393337533981591895725421179051196"),
 			C1:     tt.B10("92352588588873729555430055172430595475910468157254446887005633321086645506984959001356424661276448180525800287366079400461958418643751933701277823127881543396640181010723902113675414173812412486759363992283619394596611725910844787234817793297468668543716260158106119514868237915982895111931557116755302785277"),
-			B: &curves.EcPoint{
-				Curve: curve,
-				X:     tt.B10("47119159900193871131301454349459631159900055770279833902936369018055039407305"),
-				Y:     tt.B10("115146922864592374131907157924472992158066770148954896711377713046850262233029"),
-			},
+			B:      newPoint(t, curve, tt.B10("47119159900193871131301454349459631159900055770279833902936369018055039407305"), tt.B10("115146922864592374131907157924472992158066770148954896711377713046850262233029")),
 		},
 	}
 
@@ -955,7 +890,7 @@ func TestMtaResponseWcTampered(t *testing.T) {
 			t.Errorf("MtAFinalize succeeded but should've failed")
 		}
 		vp.C1.Sub(vp.C1, crypto.One)
-		vp.B.X, vp.B.Y = curve.Add(vp.B.X, vp.B.Y, crypto.One, crypto.Zero)
+		vp.B = vp.B.Add(curve.NewGeneratorPoint())
 		alpha, err = rp.FinalizeWc(vp)
 		if err == nil {
 			t.Errorf("MtAFinalize succeeded but should've failed")
@@ -967,7 +902,7 @@ func TestMtaResponseWcTampered(t *testing.T) {
 }
 
 func TestMtaResponseWcWrongSk(t *testing.T) {
-	curve := btcec.S256()
+	curve := curves.K256()
 	p := tt.B10("165498465971525536497859961269214938631289964308823560526920537236787050377699904896554622379770774622567664583533323254169290844053351296829514419428489585830394868303448384771151376037064711115810339324861594209655768995895643373763166292366557525131878080032169065959558884224551806641003919879441772258023")
 	q := tt.B10("153220808452726670380485250948911100156879705361932013063379432599289284377538415448437552509228215741069875651231891196863559464003506000735603508391315084830677543632875274002601909274977876224268309554767555583618065737119993835971994691072180460197745186395985316826257903003552375842892383205848110359007")
 	sk1, _ := paillier.NewSecretKey(p, q)
@@ -992,11 +927,7 @@ func TestMtaResponseWcWrongSk(t *testing.T) {
 			},
 			SmallB: tt.B10("3672791578831700453156064277674396724953117514808181048561503554767812240270"),
 			C1:     tt.B10("77752317560568208206364290265071376718942102730865626173881960000095692236560274288202792728825652824950440213334665539641290999004150367191954333808152149418358084240829193835628503450268249050991899350405972770791757133533190107036510955688542448103156155740141942033820625257531299610966919367173008180688"),
-			B: &curves.EcPoint{
-				Curve: curve,
-				X:     tt.B10("1404866900921975962487332641664497649570820021171040875292185052444634265070"),
-				Y:     tt.B10("22517056746740517460185947144352325511166429596179017818627772864271597116261"),
-			},
+			B:      newPoint(t, curve, tt.B10("1404866900921975962487332641664497649570820021171040875292185052444634265070"), tt.B10("22517056746740517460185947144352325511166429596179017818627772864271597116261")),
 		},
 		{
 			Curve: curve,
@@ -1008,11 +939,7 @@ func TestMtaResponseWcWrongSk(t *testing.T) {
 			},
 			SmallB: tt.B10("35757537348996621957461215098882730872747662393337533981591895725421179051196"),
 			C1:     tt.B10("92352588588873729555430055172430595475910468157254446887005633321086645506984959001356424661276448180525800287366079400461958418643751933701277823127881543396640181010723902113675414173812412486759363992283619394596611725910844787234817793297468668543716260158106119514868237915982895111931557116755302785277"),
-			B: &curves.EcPoint{
-				Curve: curve,
-				X:     tt.B10("47119159900193871131301454349459631159900055770279833902936369018055039407305"),
-				Y:     tt.B10("115146922864592374131907157924472992158066770148954896711377713046850262233029"),
-			},
+			B:      newPoint(t, curve, tt.B10("47119159900193871131301454349459631159900055770279833902936369018055039407305"), tt.B10("115146922864592374131907157924472992158066770148954896711377713046850262233029")),
 		},
 	}
 
@@ -1147,7 +1074,7 @@ func TestMarshalJsonRange2Proof(t *testing.T) {
 
 // Test that ResponseProof can be marshaled and unmarshaled correctly
 func TestMarshalJsonResponseProof(t *testing.T) {
-	curve := btcec.S256()
+	curve := curves.K256()
 	p := tt.B10("165498465971525536497859961269214938631289964308823560526920537236787050377699904896554622379770774622567664583533323254169290844053351296829514419428489585830394868303448384771151376037064711115810339324861594209655768995895643373763166292366557525131878080032169065959558884224551806641003919879441772258023")
 	q := tt.B10("153220808452726670380485250948911100156879705361932013063379432599289284377538415448437552509228215741069875651231891196863559464003506000735603508391315084830677543632875274002601909274977876224268309554767555583618065737119993835971994691072180460197745186395985316826257903003552375842892383205848110359007")
 	sk1, _ := paillier.NewSecretKey(p, q)
@@ -1162,11 +1089,7 @@ func TestMarshalJsonResponseProof(t *testing.T) {
 		},
 		SmallB: tt.B10("3672791578831700453156064277674396724953117514808181048561503554767812240270"),
 		C1:     tt.B10("77752317560568208206364290265071376718942102730865626173881960000095692236560274288202792728825652824950440213334665539641290999004150367191954333808152149418358084240829193835628503450268249050991899350405972770791757133533190107036510955688542448103156155740141942033820625257531299610966919367173008180688"),
-		B: &curves.EcPoint{
-			Curve: curve,
-			X:     tt.B10("1404866900921975962487332641664497649570820021171040875292185052444634265070"),
-			Y:     tt.B10("22517056746740517460185947144352325511166429596179017818627772864271597116261"),
-		},
+		B:      newPoint(t, curve, tt.B10("1404866900921975962487332641664497649570820021171040875292185052444634265070"), tt.B10("22517056746740517460185947144352325511166429596179017818627772864271597116261")),
 	}
 
 	test, err := rpp.Prove()
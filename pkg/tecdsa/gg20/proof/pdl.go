@@ -11,7 +11,6 @@
 package proof
 
 import (
-	"crypto/elliptic"
 	"encoding/json"
 	"fmt"
 	"math/big"
@@ -25,11 +24,11 @@ import (
 // PdlProofParams encapsulates the parameters for ProvePDL in
 // [spec] fig 14
 type PdlProofParams struct {
-	Curve               elliptic.Curve
+	Curve               *curves.Curve
 	DealerParams        *dealer.ProofParams
 	Pk                  *paillier.PublicKey
 	ScalarX, ScalarR, C *big.Int
-	PointX, PointR      *curves.EcPoint
+	PointX, PointR      curves.Point
 }
 
 // PdlProof is the proof generated in
@@ -72,10 +71,10 @@ func (pdlp *PdlProof) UnmarshalJSON(bytes []byte) error {
 // PdlVerifyParams encapsulates the parameters for VerifyPDL in
 // [spec] fig 14
 type PdlVerifyParams struct {
-	Curve          elliptic.Curve
+	Curve          *curves.Curve
 	DealerParams   *dealer.ProofParams
 	Pk             *paillier.PublicKey
-	PointX, PointR *curves.EcPoint
+	PointX, PointR curves.Point
 	C              *big.Int
 }
 
@@ -92,6 +91,10 @@ func (p PdlProofParams) Prove() (*PdlProof, error) {
 		p.Pk == nil || p.Curve == nil || p.ScalarX == nil || p.ScalarR == nil {
 		return nil, fmt.Errorf("invalid params")
 	}
+	params, err := groupParams(p.Curve)
+	if err != nil {
+		return nil, err
+	}
 	// step 1
 	// set N=pk.N
 
@@ -102,10 +105,11 @@ func (p PdlProofParams) Prove() (*PdlProof, error) {
 	}
 
 	// 6. Compute u=R^α in G
-	u, err := p.PointR.ScalarMult(randParams.alpha)
+	alpha, err := p.Curve.Scalar.SetBigInt(randParams.alpha)
 	if err != nil {
 		return nil, err
 	}
+	u := p.PointR.Mul(alpha)
 
 	// 7. Compute z=h1^x h2^ρ mod N ̃
 	z, err := pedersen(p.DealerParams.H1, p.DealerParams.H2, p.ScalarX, randParams.rho, p.DealerParams.N)
@@ -126,10 +130,13 @@ func (p PdlProofParams) Prove() (*PdlProof, error) {
 	}
 
 	// 10. Compute e = H(pk,N~,h1,h2,g,q,R,X,c,u,z,v,w)
+	rx, ry := affine(p.PointR)
+	xx, xy := affine(p.PointX)
+	ux, uy := affine(u)
 	challenge, err := crypto.FiatShamir(p.Pk.N, p.DealerParams.N, p.DealerParams.H1,
-		p.DealerParams.H2, p.Curve.Params().Gx, p.Curve.Params().Gy,
-		p.Curve.Params().N, p.PointR.X, p.PointR.Y, p.PointX.X, p.PointX.Y, p.C,
-		u.X, u.Y, z, v, w)
+		p.DealerParams.H2, params.Gx, params.Gy,
+		params.N, rx, ry, xx, xy, p.C,
+		ux, uy, z, v, w)
 	if err != nil {
 		return nil, err
 	}
@@ -174,7 +181,11 @@ func (p PdlProof) Verify(pv *PdlVerifyParams) error {
 	// Set N = pk.N
 	// ----
 
-	q3, err := crypto.Exp(pv.Curve.Params().N, big.NewInt(3), nil)
+	params, err := groupParams(pv.Curve)
+	if err != nil {
+		return err
+	}
+	q3, err := crypto.Exp(params.N, big.NewInt(3), nil)
 	if err != nil {
 		return err
 	}
@@ -203,11 +214,14 @@ func (p PdlProof) Verify(pv *PdlVerifyParams) error {
 	}
 
 	// step 7
+	rx, ry := affine(pv.PointR)
+	xx, xy := affine(pv.PointX)
+	uHatX, uHatY := affine(uHat)
 	challenge, err := crypto.FiatShamir(
 		pv.Pk.N, pv.DealerParams.N, pv.DealerParams.H1, pv.DealerParams.H2,
-		pv.Curve.Params().Gx, pv.Curve.Params().Gy, pv.Curve.Params().N,
-		pv.PointR.X, pv.PointR.Y, pv.PointX.X, pv.PointX.Y, pv.C, uHat.X,
-		uHat.Y, p.z, vHat, wHat)
+		params.Gx, params.Gy, params.N,
+		rx, ry, xx, xy, pv.C, uHatX,
+		uHatY, p.z, vHat, wHat)
 	if err != nil {
 		return err
 	}
@@ -219,25 +233,19 @@ func (p PdlProof) Verify(pv *PdlVerifyParams) error {
 	return nil
 }
 
-func (p PdlProof) uHatConstruct(pv *PdlVerifyParams) (*curves.EcPoint, error) {
+func (p PdlProof) uHatConstruct(pv *PdlVerifyParams) (curves.Point, error) {
 	// 3. Compute s1' = s1 mod q
-	s1Tick := new(big.Int).Mod(p.s1, pv.Curve.Params().N)
-
-	// 4. \hat{u} = R^{s^\prime_1} . X^-e in G
-	pointRtoS1Tick, err := pv.PointR.ScalarMult(s1Tick)
+	s1Tick, err := pv.Curve.Scalar.SetBigInt(p.s1)
 	if err != nil {
 		return nil, err
 	}
-	negE := new(big.Int).Neg(p.e)
-	pointXtoNegE, err := pv.PointX.ScalarMult(negE)
-	if err != nil {
-		return nil, err
-	}
-	uHat, err := pointRtoS1Tick.Add(pointXtoNegE)
+
+	// 4. \hat{u} = R^{s^\prime_1} . X^-e in G
+	e, err := pv.Curve.Scalar.SetBigInt(p.e)
 	if err != nil {
 		return nil, err
 	}
-	return uHat, err
+	return pv.PointR.Mul(s1Tick).Sub(pv.PointX.Mul(e)), nil
 }
 
 func (p PdlProof) vHatConstruct(pv *PdlVerifyParams) (*big.Int, error) {
@@ -285,13 +293,17 @@ func (p PdlProof) wHatConstruct(pv *PdlVerifyParams) (*big.Int, error) {
 // randPdl computes the random values for Prove in
 // [spec] fig 14
 func randPdl(p PdlProofParams) (*randPdlParams, error) {
+	params, err := groupParams(p.Curve)
+	if err != nil {
+		return nil, err
+	}
 	// The rings in which to operate
-	q3, err := crypto.Exp(p.Curve.Params().N, big.NewInt(3), nil)
+	q3, err := crypto.Exp(params.N, big.NewInt(3), nil)
 	if err != nil {
 		return nil, err
 	}
 	q3Ntilde := new(big.Int).Mul(q3, p.DealerParams.N)
-	qNtilde := new(big.Int).Mul(p.Curve.Params().N, p.DealerParams.N)
+	qNtilde := new(big.Int).Mul(params.N, p.DealerParams.N)
 
 	// 1. \alpha \getsr \Z_{q^3}
 	alpha, err := crypto.Rand(q3)
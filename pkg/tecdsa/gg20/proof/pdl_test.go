@@ -11,7 +11,6 @@ import (
 	"math/big"
 	"testing"
 
-	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/stretchr/testify/require"
 
 	tt "github.com/TEENet-io/kryptology/internal"
@@ -28,8 +27,29 @@ func makeNewPaillierPublicKey(t *testing.T, n *big.Int) *paillier.PublicKey {
 	return publicKey
 }
 
+func newPoint(t *testing.T, curve *curves.Curve, x, y *big.Int) curves.Point {
+	t.Helper()
+	point, err := curve.Point.Set(x, y)
+	require.NoError(t, err)
+	return point
+}
+
+func scalarBaseMult(t *testing.T, curve *curves.Curve, k *big.Int) curves.Point {
+	t.Helper()
+	scalar, err := curve.Scalar.SetBigInt(k)
+	require.NoError(t, err)
+	return curve.ScalarBaseMult(scalar)
+}
+
+func curveOrder(t *testing.T, curve *curves.Curve) *big.Int {
+	t.Helper()
+	params, err := groupParams(curve)
+	require.NoError(t, err)
+	return params.N
+}
+
 func TestPdlProof(t *testing.T) {
-	curve := btcec.S256()
+	curve := curves.K256()
 	params := []*PdlProofParams{
 		{
 			Curve: curve,
@@ -42,16 +62,8 @@ func TestPdlProof(t *testing.T) {
 			C:       tt.B10("4664726001400863462852903463489684025203539312043087122435633780307370116923286498286011073893114770129124053266553951568210161226338715793626006742280222408787817580104395413260066838171514476835074847117030809020314721196250676941071342943510687061594910173132843733171341122020444569560262354325159459786"),
 			ScalarR: tt.B10("6318180506937413445377662339737616688323403611418981231506634960717709610306639938046692771174821698278861338843502933136372971219816146088826194073514165"),
 			ScalarX: tt.B10("8895158955508830352755492106542967678464513230702348453977370181840454571559"),
-			PointX: &curves.EcPoint{
-				Curve: curve,
-				X:     tt.B10("76094108851287611405923621794156813263013115318084984186541038825106228865281"),
-				Y:     tt.B10("87411113406201178695670215615289769677595073686246590331802071626605210109743"),
-			},
-			PointR: &curves.EcPoint{
-				Curve: curve,
-				X:     tt.B10("8378869356347693656335563305413627471884674848153157571999293829483801014921"),
-				Y:     tt.B10("80919981448100403067082012436084777199105270046135069091255381624902461382599"),
-			},
+			PointX:  newPoint(t, curve, tt.B10("76094108851287611405923621794156813263013115318084984186541038825106228865281"), tt.B10("87411113406201178695670215615289769677595073686246590331802071626605210109743")),
+			PointR:  newPoint(t, curve, tt.B10("8378869356347693656335563305413627471884674848153157571999293829483801014921"), tt.B10("80919981448100403067082012436084777199105270046135069091255381624902461382599")),
 		},
 		{
 			Curve: curve,
@@ -64,16 +76,8 @@ func TestPdlProof(t *testing.T) {
 			C:       tt.B10("22945662658826687122951524609605390162419018623016094527985190729136533122138237274890476792343375181731556056387967150127382752299222119038995116814564676335308780927745023238367652917410224228904840247506797913269791009050330669049148699546893290523696971497415351124890632011355109852928076331069242976047"),
 			ScalarR: tt.B10("1456346843273107174185972100354877067846031294442486303631964113650903606327961364695375229133208501529871889372266089869069469359943307589956370077023244"),
 			ScalarX: tt.B10("102510244919994967843708738855867526851165091005601348643599270378342008853277"),
-			PointX: &curves.EcPoint{
-				Curve: curve,
-				X:     tt.B10("86918276961810349294276103416548851884759982251107"),
-				Y:     tt.B10("87194829221142880348582938487511785107150118762739500766654458540580527283772"),
-			},
-			PointR: &curves.EcPoint{
-				Curve: curve,
-				X:     tt.B10("12444758997853784844785736093813953015213016988344585020934721604959711243454"),
-				Y:     tt.B10("41081235245407629494159229858676719985037195350871196150228771571530041697953"),
-			},
+			PointX:  newPoint(t, curve, tt.B10("86918276961810349294276103416548851884759982251107"), tt.B10("87194829221142880348582938487511785107150118762739500766654458540580527283772")),
+			PointR:  newPoint(t, curve, tt.B10("12444758997853784844785736093813953015213016988344585020934721604959711243454"), tt.B10("41081235245407629494159229858676719985037195350871196150228771571530041697953")),
 		},
 		{
 			Curve: curve,
@@ -86,16 +90,8 @@ func TestPdlProof(t *testing.T) {
 			C:       tt.B10("4896957344081721172301761558878471817093957186883505910055484342858487410780344086015815560906271262760225544259435128094000760341287151463805517048421021266091498903664022813965524626993319004586190337532327563453628353189636257770661887076638254789941394875768438354317008447900448371463627310758169649159129081536916678375894293541030505690802142053594972076696842850896392543134018313744617812407507540361629152950683990975258614557530404228537967408981617289478956979359018250290787402363698811959464011054735419841399680079109795365937107391761037923382540359217859416039274471453061333934384874194680103175375"),
 			ScalarR: tt.B10("71432491042795891026819073612920634365551967354604700070651069540194938209260355758675443987816023583431255584159007910956894102538667454292922436540633732978863886218440548882671875019295174919761937584083905373135768543476976716116826486377526415911236104646671272094245775861150495950556869225179514569498"),
 			ScalarX: tt.B10("29086759249526442678185238367636452309232548742213292674117516677478809708340"),
-			PointX: &curves.EcPoint{
-				Curve: curve,
-				X:     tt.B10("86918276961810349294276103416548851884759982251107"),
-				Y:     tt.B10("87194829221142880348582938487511785107150118762739500766654458540580527283772"),
-			},
-			PointR: &curves.EcPoint{
-				Curve: curve,
-				X:     tt.B10("35373660716694800516809670505588645945109927020371800385493928646782619107408"),
-				Y:     tt.B10("93831376976173894628926808190406953951928006489246828107520931318493467317132"),
-			},
+			PointX:  newPoint(t, curve, tt.B10("86918276961810349294276103416548851884759982251107"), tt.B10("87194829221142880348582938487511785107150118762739500766654458540580527283772")),
+			PointR:  newPoint(t, curve, tt.B10("35373660716694800516809670505588645945109927020371800385493928646782619107408"), tt.B10("93831376976173894628926808190406953951928006489246828107520931318493467317132")),
 		},
 		{
 			Curve: curve,
@@ -108,16 +104,8 @@ func TestPdlProof(t *testing.T) {
 			C:       tt.B10("312072118059655464479623752896370931287580695147558967175370385693010851904602632704190780873037853776949002099920910929454906746297000764022883262339810373530190503057275803212082490648752402411014710837921985963414326996430994445745103595174798048796872071058757782900936657543421311350819518047263143986520258140347967846766008789950384655455533378645886309519148467471177056605854541459719525084429218454218213528571466092270319677971542765590930957717305827486734849822346435125175072130311484595478227013437950067433362331207479003306966751040290017607880331032857536340225782113656911961520425583296868974183306576630007254668037105123599321810686598872455495139523140221304146923992336080310963429648303646680527705397398978349770824945433952717955829331451943599927661217135182414690477094098800503935476443441681845234327088836940113610943548236652767504866712759845009489949232476510935796276956643665275622378791333366356819315458176981133159815798879989329421090265495234364154899918069686655261893245095558625642862189807914671729788023888722841227953770641754100300708912019242483762170421213715045277109343120955558835679335136820251570631374672604143498438152345107896404355150794115568555566877217881851216716202636"),
 			ScalarR: tt.B10("12900317669231902409891919243971118700371339340973561350358176269148021513243084734168439199346596288634953438481056037867044379527349847448380560153748923321339676070090389745139441984141990380824035678411732060617869487616828715411492104244485577512780240110853142143842539932038847998591353405830524617088326337057091796898754978001112967800124945178039660843489033360568972462506417939839595757327089284304802004150446809871851916545282806087722771656348015818813850000672740747971701015100804323897662661550498524602873193812403530774371979198086396784333450586513136371130153973494143884071046170273825540295768"),
 			ScalarX: tt.B10("70007072993875354057337439998121157887778082743724503170686544130591021558682"),
-			PointX: &curves.EcPoint{
-				Curve: curve,
-				X:     tt.B10("86918276961810349294276103416548851884759982251107"),
-				Y:     tt.B10("87194829221142880348582938487511785107150118762739500766654458540580527283772"),
-			},
-			PointR: &curves.EcPoint{
-				Curve: curve,
-				X:     tt.B10("69223975912566247908360773758405000717925830486569659950886484171255446147778"),
-				Y:     tt.B10("32579403683948471182574476344537350186238230569150920925924612041995719512988"),
-			},
+			PointX:  newPoint(t, curve, tt.B10("86918276961810349294276103416548851884759982251107"), tt.B10("87194829221142880348582938487511785107150118762739500766654458540580527283772")),
+			PointR:  newPoint(t, curve, tt.B10("69223975912566247908360773758405000717925830486569659950886484171255446147778"), tt.B10("32579403683948471182574476344537350186238230569150920925924612041995719512988")),
 		},
 	}
 
@@ -140,7 +128,7 @@ func TestPdlProof(t *testing.T) {
 }
 
 func TestPdlProofTampered(t *testing.T) {
-	curve := btcec.S256()
+	curve := curves.K256()
 	params := []*PdlProofParams{
 		{
 			Curve: curve,
@@ -153,16 +141,8 @@ func TestPdlProofTampered(t *testing.T) {
 			C:       tt.B10("4664726001400863462852903463489684025203539312043087122435633780307370116923286498286011073893114770129124053266553951568210161226338715793626006742280222408787817580104395413260066838171514476835074847117030809020314721196250676941071342943510687061594910173132843733171341122020444569560262354325159459786"),
 			ScalarR: tt.B10("6318180506937413445377662339737616688323403611418981231506634960717709610306639938046692771174821698278861338843502933136372971219816146088826194073514165"),
 			ScalarX: tt.B10("8895158955508830352755492106542967678464513230702348453977370181840454571559"),
-			PointX: &curves.EcPoint{
-				Curve: curve,
-				X:     tt.B10("76094108851287611405923621794156813263013115318084984186541038825106228865281"),
-				Y:     tt.B10("87411113406201178695670215615289769677595073686246590331802071626605210109743"),
-			},
-			PointR: &curves.EcPoint{
-				Curve: curve,
-				X:     tt.B10("8378869356347693656335563305413627471884674848153157571999293829483801014921"),
-				Y:     tt.B10("80919981448100403067082012436084777199105270046135069091255381624902461382599"),
-			},
+			PointX:  newPoint(t, curve, tt.B10("76094108851287611405923621794156813263013115318084984186541038825106228865281"), tt.B10("87411113406201178695670215615289769677595073686246590331802071626605210109743")),
+			PointR:  newPoint(t, curve, tt.B10("8378869356347693656335563305413627471884674848153157571999293829483801014921"), tt.B10("80919981448100403067082012436084777199105270046135069091255381624902461382599")),
 		},
 		{
 			Curve: curve,
@@ -175,16 +155,8 @@ func TestPdlProofTampered(t *testing.T) {
 			C:       tt.B10("22945662658826687122951524609605390162419018623016094527985190729136533122138237274890476792343375181731556056387967150127382752299222119038995116814564676335308780927745023238367652917410224228904840247506797913269791009050330669049148699546893290523696971497415351124890632011355109852928076331069242976047"),
 			ScalarR: tt.B10("1456346843273107174185972100354877067846031294442486303631964113650903606327961364695375229133208501529871889372266089869069469359943307589956370077023244"),
 			ScalarX: tt.B10("102510244919994967843708738855867526851165091005601348643599270378342008853277"),
-			PointX: &curves.EcPoint{
-				Curve: curve,
-				X:     tt.B10("86918276961810349294276103416548851884759982251107"),
-				Y:     tt.B10("87194829221142880348582938487511785107150118762739500766654458540580527283772"),
-			},
-			PointR: &curves.EcPoint{
-				Curve: curve,
-				X:     tt.B10("12444758997853784844785736093813953015213016988344585020934721604959711243454"),
-				Y:     tt.B10("41081235245407629494159229858676719985037195350871196150228771571530041697953"),
-			},
+			PointX:  newPoint(t, curve, tt.B10("86918276961810349294276103416548851884759982251107"), tt.B10("87194829221142880348582938487511785107150118762739500766654458540580527283772")),
+			PointR:  newPoint(t, curve, tt.B10("12444758997853784844785736093813953015213016988344585020934721604959711243454"), tt.B10("41081235245407629494159229858676719985037195350871196150228771571530041697953")),
 		},
 		{
 			Curve: curve,
@@ -197,16 +169,8 @@ func TestPdlProofTampered(t *testing.T) {
 			C:       tt.B10("4896957344081721172301761558878471817093957186883505910055484342858487410780344086015815560906271262760225544259435128094000760341287151463805517048421021266091498903664022813965524626993319004586190337532327563453628353189636257770661887076638254789941394875768438354317008447900448371463627310758169649159129081536916678375894293541030505690802142053594972076696842850896392543134018313744617812407507540361629152950683990975258614557530404228537967408981617289478956979359018250290787402363698811959464011054735419841399680079109795365937107391761037923382540359217859416039274471453061333934384874194680103175375"),
 			ScalarR: tt.B10("71432491042795891026819073612920634365551967354604700070651069540194938209260355758675443987816023583431255584159007910956894102538667454292922436540633732978863886218440548882671875019295174919761937584083905373135768543476976716116826486377526415911236104646671272094245775861150495950556869225179514569498"),
 			ScalarX: tt.B10("29086759249526442678185238367636452309232548742213292674117516677478809708340"),
-			PointX: &curves.EcPoint{
-				Curve: curve,
-				X:     tt.B10("86918276961810349294276103416548851884759982251107"),
-				Y:     tt.B10("87194829221142880348582938487511785107150118762739500766654458540580527283772"),
-			},
-			PointR: &curves.EcPoint{
-				Curve: curve,
-				X:     tt.B10("35373660716694800516809670505588645945109927020371800385493928646782619107408"),
-				Y:     tt.B10("93831376976173894628926808190406953951928006489246828107520931318493467317132"),
-			},
+			PointX:  newPoint(t, curve, tt.B10("86918276961810349294276103416548851884759982251107"), tt.B10("87194829221142880348582938487511785107150118762739500766654458540580527283772")),
+			PointR:  newPoint(t, curve, tt.B10("35373660716694800516809670505588645945109927020371800385493928646782619107408"), tt.B10("93831376976173894628926808190406953951928006489246828107520931318493467317132")),
 		},
 		{
 			Curve: curve,
@@ -219,16 +183,8 @@ func TestPdlProofTampered(t *testing.T) {
 			C:       tt.B10("312072118059655464479623752896370931287580695147558967175370385693010851904602632704190780873037853776949002099920910929454906746297000764022883262339810373530190503057275803212082490648752402411014710837921985963414326996430994445745103595174798048796872071058757782900936657543421311350819518047263143986520258140347967846766008789950384655455533378645886309519148467471177056605854541459719525084429218454218213528571466092270319677971542765590930957717305827486734849822346435125175072130311484595478227013437950067433362331207479003306966751040290017607880331032857536340225782113656911961520425583296868974183306576630007254668037105123599321810686598872455495139523140221304146923992336080310963429648303646680527705397398978349770824945433952717955829331451943599927661217135182414690477094098800503935476443441681845234327088836940113610943548236652767504866712759845009489949232476510935796276956643665275622378791333366356819315458176981133159815798879989329421090265495234364154899918069686655261893245095558625642862189807914671729788023888722841227953770641754100300708912019242483762170421213715045277109343120955558835679335136820251570631374672604143498438152345107896404355150794115568555566877217881851216716202636"),
 			ScalarR: tt.B10("12900317669231902409891919243971118700371339340973561350358176269148021513243084734168439199346596288634953438481056037867044379527349847448380560153748923321339676070090389745139441984141990380824035678411732060617869487616828715411492104244485577512780240110853142143842539932038847998591353405830524617088326337057091796898754978001112967800124945178039660843489033360568972462506417939839595757327089284304802004150446809871851916545282806087722771656348015818813850000672740747971701015100804323897662661550498524602873193812403530774371979198086396784333450586513136371130153973494143884071046170273825540295768"),
 			ScalarX: tt.B10("70007072993875354057337439998121157887778082743724503170686544130591021558682"),
-			PointX: &curves.EcPoint{
-				Curve: curve,
-				X:     tt.B10("86918276961810349294276103416548851884759982251107"),
-				Y:     tt.B10("87194829221142880348582938487511785107150118762739500766654458540580527283772"),
-			},
-			PointR: &curves.EcPoint{
-				Curve: curve,
-				X:     tt.B10("69223975912566247908360773758405000717925830486569659950886484171255446147778"),
-				Y:     tt.B10("32579403683948471182574476344537350186238230569150920925924612041995719512988"),
-			},
+			PointX:  newPoint(t, curve, tt.B10("86918276961810349294276103416548851884759982251107"), tt.B10("87194829221142880348582938487511785107150118762739500766654458540580527283772")),
+			PointR:  newPoint(t, curve, tt.B10("69223975912566247908360773758405000717925830486569659950886484171255446147778"), tt.B10("32579403683948471182574476344537350186238230569150920925924612041995719512988")),
 		},
 	}
 
@@ -267,13 +223,13 @@ func TestPdlProofTampered(t *testing.T) {
 }
 
 func TestPdlProofRandValues(t *testing.T) {
-	curve := btcec.S256()
+	curve := curves.K256()
 	n := tt.B10("10963150595205830129496851796933410785873564590065350533653414494671353802134199060973898221372286631263311589006105573391294835324596497070909690030594529")
 	c, _ := crypto.Rand(n)
-	r, _ := crypto.Rand(curve.Params().N)
-	x, _ := crypto.Rand(curve.Params().N)
-	pointX, _ := curves.NewScalarBaseMult(curve, x)
-	pointR, _ := curves.NewScalarBaseMult(curve, r)
+	r, _ := crypto.Rand(curveOrder(t, curve))
+	x, _ := crypto.Rand(curveOrder(t, curve))
+	pointX := scalarBaseMult(t, curve, x)
+	pointR := scalarBaseMult(t, curve, r)
 	params := []*PdlProofParams{
 		{
 			Curve: curve,
@@ -313,13 +269,13 @@ func TestPdlProofInvalidParams(t *testing.T) {
 	if _, err := params.Prove(); err == nil {
 		t.Errorf("PdlProve succeeded but should've failed.")
 	}
-	curve := btcec.S256()
+	curve := curves.K256()
 	n := tt.B10("10963150595205830129496851796933410785873564590065350533653414494671353802134199060973898221372286631263311589006105573391294835324596497070909690030594529")
 	c, _ := crypto.Rand(n)
-	r, _ := crypto.Rand(curve.Params().N)
-	x, _ := crypto.Rand(curve.Params().N)
-	pointX, _ := curves.NewScalarBaseMult(curve, x)
-	pointR, _ := curves.NewScalarBaseMult(curve, r)
+	r, _ := crypto.Rand(curveOrder(t, curve))
+	x, _ := crypto.Rand(curveOrder(t, curve))
+	pointX := scalarBaseMult(t, curve, x)
+	pointR := scalarBaseMult(t, curve, r)
 	params = PdlProofParams{
 		Curve: curve,
 		Pk:    makeNewPaillierPublicKey(t, tt.B10("10453436341595661308638945160744691461102262556435425363472041530426551492325701856213450266088099390020182300308521148275956201847403116901822304716109601")),
@@ -7,10 +7,12 @@
 package proof
 
 import (
+	"crypto/elliptic"
 	"math/big"
 
 	"github.com/TEENet-io/kryptology/internal"
 	core "github.com/TEENet-io/kryptology/pkg/core"
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
 )
 
 // inc computes the incremented commitment in the extension ring:
@@ -62,3 +64,22 @@ func schnorr(x, y, z *big.Int) (*big.Int, error) {
 	}
 	return r.Add(r, z), nil // r+z = xy+z
 }
+
+// groupParams returns the generator and order of curve, which are part of the range proof transcripts
+func groupParams(curve *curves.Curve) (*elliptic.CurveParams, error) {
+	if curve == nil {
+		return nil, internal.ErrNilArguments
+	}
+	ec, err := curve.ToEllipticCurve()
+	if err != nil {
+		return nil, err
+	}
+	return ec.Params(), nil
+}
+
+// affine returns the affine coordinates of p
+func affine(p curves.Point) (x, y *big.Int) {
+	b := p.ToAffineUncompressed()
+	fieldSize := (len(b) - 1) / 2
+	return new(big.Int).SetBytes(b[1 : fieldSize+1]), new(big.Int).SetBytes(b[fieldSize+1:])
+}
@@ -7,10 +7,10 @@
 package resharing_test
 
 import (
-	"crypto/elliptic"
 	"fmt"
 	"math/big"
 
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
 	"github.com/TEENet-io/kryptology/pkg/paillier"
 	"github.com/TEENet-io/kryptology/pkg/tecdsa/gg20/dealer"
 	"github.com/TEENet-io/kryptology/pkg/tecdsa/gg20/resharing"
@@ -19,7 +19,7 @@ import (
 // Example_resharingFrom3to5 demonstrates how to change from 3 participants (2-of-3)
 // to 5 participants (3-of-5) while preserving the same ECDSA public key.
 func Example_resharingFrom3to5() {
-	curve := elliptic.P256()
+	curve := curves.P256()
 
 	// Step 1: Assume we have an existing 2-of-3 setup
 	// In production, these would be the actual participant data from your key generation
@@ -129,7 +129,7 @@ func Example_resharingFrom3to5() {
 // Example_resharingParticipantRemoval demonstrates removing participants
 // while maintaining the threshold signature capability.
 func Example_resharingParticipantRemoval() {
-	curve := elliptic.P256()
+	curve := curves.P256()
 
 	// Start with 5 participants (3-of-5)
 	ecdsaPublicKey, initialShares, _ := dealer.NewDealerShares(curve, 3, 5, nil)
@@ -185,7 +185,7 @@ func Example_resharingParticipantRemoval() {
 // Example_resharingThresholdChange demonstrates changing only the threshold
 // without changing the participant set.
 func Example_resharingThresholdChange() {
-	curve := elliptic.P256()
+	curve := curves.P256()
 
 	// Start with 2-of-5 threshold
 	ecdsaPublicKey, initialShares, _ := dealer.NewDealerShares(curve, 2, 5, nil)
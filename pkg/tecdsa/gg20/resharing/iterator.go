@@ -0,0 +1,201 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package resharing
+
+import (
+	"fmt"
+
+	"github.com/TEENet-io/kryptology/internal"
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
+	"github.com/TEENet-io/kryptology/pkg/core/protocol"
+	"github.com/TEENet-io/kryptology/pkg/tecdsa/gg20/dealer"
+	"github.com/TEENet-io/kryptology/pkg/tecdsa/gg20/participant"
+)
+
+// ReshareIterator runs the resharing of one participant as a protocol.Iterator.
+//
+// The first call to Next takes a nil input. Every later call takes the protocol.Combine of the messages the
+// participant received in the previous round: in round 1 from the old parties, afterwards from the new parties.
+// Old parties that are not new parties finish after round 1; new parties that are not old parties return a nil
+// message from round 1. Payloads are JSON encoded; protocol.Version1 is the only supported version.
+type ReshareIterator struct {
+	protocol.Stepper
+	*ReshareParticipant
+	// self is the round 1 share an old party that is also a new party sends to itself
+	self *ReshareRound1Bcast
+}
+
+// Static type assertion
+var _ protocol.Iterator = &ReshareIterator{}
+
+// NewReshareIterator creates the resharing of participant id. See NewReshareParticipant for the arguments.
+func NewReshareIterator(
+	id uint32,
+	oldParticipantData *dealer.ParticipantData,
+	publicKey curves.Point,
+	config *Config,
+	version uint,
+) (*ReshareIterator, error) {
+	if err := participant.CheckIteratorVersion(version); err != nil {
+		return nil, err
+	}
+	rp, err := NewReshareParticipant(id, oldParticipantData, publicKey, config)
+	if err != nil {
+		return nil, err
+	}
+	isOld, oldPeers := partyIn(id, config.OldParties)
+	isNew, newPeers := partyIn(id, config.NewParties)
+	if !isOld && !isNew {
+		return nil, fmt.Errorf("participant %d is neither an old nor a new party", id)
+	}
+	if isOld && oldParticipantData == nil {
+		return nil, internal.ErrNilArguments
+	}
+
+	r := &ReshareIterator{ReshareParticipant: rp}
+	steps := []func(*protocol.Message) (*protocol.Message, error){
+		func(*protocol.Message) (*protocol.Message, error) {
+			if !isOld {
+				return nil, nil
+			}
+			messages, err := r.ReshareRound1()
+			if err != nil {
+				return nil, err
+			}
+			r.self = messages[id]
+			direct := make(map[uint32]interface{}, len(messages))
+			for j, m := range messages {
+				if j != id {
+					direct[j] = m
+				}
+			}
+			return participant.EncodeRoundOutput(protocol.Gg20Reshare, version, id, 1, nil, direct)
+		},
+	}
+	if !isNew {
+		r.Stepper = protocol.NewStepper(steps)
+		return r, nil
+	}
+	steps = append(steps,
+		func(input *protocol.Message) (*protocol.Message, error) {
+			received, err := splitReshareRound(input, version, 1, oldPeers)
+			if err != nil {
+				return nil, err
+			}
+			messages := make([]*ReshareRound1Bcast, 0, len(config.OldParties))
+			for j, m := range received {
+				msg := new(ReshareRound1Bcast)
+				if err := participant.DecodePayload(m, protocol.DirectPayload, msg); err != nil {
+					return nil, err
+				}
+				if msg.FromID != j {
+					return nil, fmt.Errorf("round 1 message from participant %d claims to be from %d", j, msg.FromID)
+				}
+				messages = append(messages, msg)
+			}
+			if r.self != nil {
+				messages = append(messages, r.self)
+			}
+			if err := r.ReshareRound1Accept(messages); err != nil {
+				return nil, err
+			}
+			bcast, err := r.ReshareRound2()
+			if err != nil {
+				return nil, err
+			}
+			return participant.EncodeRoundOutput(protocol.Gg20Reshare, version, id, 2, bcast, nil)
+		},
+		func(input *protocol.Message) (*protocol.Message, error) {
+			received, err := splitReshareRound(input, version, 2, newPeers)
+			if err != nil {
+				return nil, err
+			}
+			messages := make([]*ReshareRound2Bcast, 0, len(received))
+			for j, m := range received {
+				msg := new(ReshareRound2Bcast)
+				if err := participant.DecodePayload(m, protocol.BroadcastPayload, msg); err != nil {
+					return nil, err
+				}
+				if msg.ParticipantID != j {
+					return nil, fmt.Errorf("round 2 message from participant %d claims to be from %d", j, msg.ParticipantID)
+				}
+				messages = append(messages, msg)
+			}
+			if err := r.ReshareRound2Accept(messages); err != nil {
+				return nil, err
+			}
+			bcast, err := r.ReshareRound3()
+			if err != nil {
+				return nil, err
+			}
+			return participant.EncodeRoundOutput(protocol.Gg20Reshare, version, id, 3, bcast, nil)
+		},
+		func(input *protocol.Message) (*protocol.Message, error) {
+			received, err := splitReshareRound(input, version, 3, newPeers)
+			if err != nil {
+				return nil, err
+			}
+			messages := make([]*ReshareRound3Bcast, 0, len(received))
+			for j, m := range received {
+				msg := new(ReshareRound3Bcast)
+				if err := participant.DecodePayload(m, protocol.BroadcastPayload, msg); err != nil {
+					return nil, err
+				}
+				if msg.ParticipantID != j {
+					return nil, fmt.Errorf("round 3 message from participant %d claims to be from %d", j, msg.ParticipantID)
+				}
+				messages = append(messages, msg)
+			}
+			return nil, r.ReshareRound3Accept(messages)
+		},
+	)
+	r.Stepper = protocol.NewStepper(steps)
+	return r, nil
+}
+
+// Result returns the JSON encoded dealer.ParticipantData of a new party. It is nil for an old party that is not
+// a new party.
+func (r *ReshareIterator) Result(version uint) (*protocol.Message, error) {
+	if !r.Complete() || r.Round != 4 {
+		return nil, nil
+	}
+	data, err := r.GetReshareResult()
+	if err != nil {
+		return nil, err
+	}
+	return participant.EncodeResult(protocol.Gg20Reshare, version, r.ID, data)
+}
+
+// DecodeReshareResult decodes the result of a ReshareIterator.
+func DecodeReshareResult(m *protocol.Message) (*dealer.ParticipantData, error) {
+	data := new(dealer.ParticipantData)
+	if err := participant.DecodeResult(m, protocol.Gg20Reshare, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// partyIn reports whether id is in parties, and returns the other parties as a set.
+func partyIn(id uint32, parties []uint32) (bool, map[uint32]bool) {
+	found := false
+	peers := make(map[uint32]bool, len(parties))
+	for _, j := range parties {
+		if j == id {
+			found = true
+		} else {
+			peers[j] = true
+		}
+	}
+	return found, peers
+}
+
+func splitReshareRound(input *protocol.Message, version uint, round int, peers map[uint32]bool) (map[uint32]*protocol.Message, error) {
+	if len(peers) == 0 && input == nil {
+		return nil, nil
+	}
+	return protocol.SplitRound(input, protocol.Gg20Reshare, version, round, peers)
+}
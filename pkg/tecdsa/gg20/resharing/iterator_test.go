@@ -0,0 +1,146 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package resharing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
+	"github.com/TEENet-io/kryptology/pkg/core/protocol"
+	"github.com/TEENet-io/kryptology/pkg/sharing"
+	"github.com/TEENet-io/kryptology/pkg/tecdsa/gg20/dealer"
+)
+
+// runReshareIterators drives the iterators to completion, delivering the output of every party to all others
+func runReshareIterators(t *testing.T, iterators map[uint32]protocol.Iterator) {
+	inputs := make(map[uint32]*protocol.Message, len(iterators))
+	for {
+		outputs := make(map[uint32]*protocol.Message, len(iterators))
+		finished := 0
+		for id, iterator := range iterators {
+			output, err := iterator.Next(inputs[id])
+			if err == protocol.ErrProtocolFinished {
+				finished++
+				continue
+			}
+			require.NoError(t, err, "participant %d", id)
+			if output != nil {
+				outputs[id] = output
+			}
+		}
+		if finished == len(iterators) {
+			return
+		}
+		for id := range iterators {
+			var delivered []*protocol.Message
+			for j, output := range outputs {
+				if m := protocol.Deliver(output, id); j != id && m != nil {
+					delivered = append(delivered, m)
+				}
+			}
+			inputs[id] = nil
+			if len(delivered) > 0 {
+				combined, err := protocol.Combine(delivered)
+				require.NoError(t, err)
+				inputs[id] = combined
+			}
+		}
+	}
+}
+
+func TestReshareIterator(t *testing.T) {
+	curve := curves.P256()
+	ecdsaPublicKey, initialShares, err := dealer.NewDealerShares(curve, 2, 3, nil)
+	require.NoError(t, err)
+
+	// Participant 1 leaves, 4 and 5 join
+	config := &Config{
+		OldThreshold: 2,
+		NewThreshold: 3,
+		OldParties:   []uint32{1, 2, 3},
+		NewParties:   []uint32{2, 3, 4, 5},
+	}
+	iterators := make(map[uint32]protocol.Iterator, 5)
+	for id := uint32(1); id <= 5; id++ {
+		var data *dealer.ParticipantData
+		if share, ok := initialShares[id]; ok {
+			paillierKey, err := generateTestPaillierKey()
+			require.NoError(t, err)
+			data = &dealer.ParticipantData{
+				Id:             id,
+				SecretKeyShare: share,
+				DecryptKey:     paillierKey,
+				EcdsaPublicKey: ecdsaPublicKey,
+			}
+		}
+		iterators[id], err = NewReshareIterator(id, data, ecdsaPublicKey, config, protocol.Version1)
+		require.NoError(t, err)
+	}
+	runReshareIterators(t, iterators)
+
+	result, err := iterators[1].Result(protocol.Version1)
+	require.NoError(t, err)
+	require.Nil(t, result)
+
+	newData := make(map[uint32]*dealer.ParticipantData, 4)
+	for _, id := range config.NewParties {
+		result, err := iterators[id].Result(protocol.Version1)
+		require.NoError(t, err)
+		newData[id], err = DecodeReshareResult(result)
+		require.NoError(t, err)
+		require.Len(t, newData[id].PublicShares, 4)
+		require.Len(t, newData[id].EncryptKeys, 4)
+	}
+	require.NoError(t, VerifyReshareResult(newData, ecdsaPublicKey))
+
+	// Any 3 of the new shares recombine to the original secret
+	signers := []uint32{2, 4, 5}
+	shamir, err := sharing.NewShamir(3, 3, curve)
+	require.NoError(t, err)
+	coeffs, err := shamir.LagrangeCoeffs(signers)
+	require.NoError(t, err)
+	secret := curve.Scalar.Zero()
+	for _, id := range signers {
+		secret = secret.Add(coeffs[id].Mul(newData[id].SecretKeyShare.Value))
+	}
+	require.True(t, curve.ScalarBaseMult(secret).Equal(ecdsaPublicKey))
+}
+
+func TestReshareIteratorInvalidInput(t *testing.T) {
+	curve := curves.P256()
+	ecdsaPublicKey, _, err := dealer.NewDealerShares(curve, 2, 3, nil)
+	require.NoError(t, err)
+	config := &Config{
+		OldThreshold: 2,
+		NewThreshold: 2,
+		OldParties:   []uint32{1, 2, 3},
+		NewParties:   []uint32{1, 2, 3, 4},
+	}
+
+	_, err = NewReshareIterator(4, nil, ecdsaPublicKey, config, protocol.Version0)
+	require.Error(t, err)
+	_, err = NewReshareIterator(6, nil, ecdsaPublicKey, config, protocol.Version1)
+	require.Error(t, err)
+	_, err = NewReshareIterator(1, nil, ecdsaPublicKey, config, protocol.Version1)
+	require.Error(t, err)
+
+	iterator, err := NewReshareIterator(4, nil, ecdsaPublicKey, config, protocol.Version1)
+	require.NoError(t, err)
+	output, err := iterator.Next(nil)
+	require.NoError(t, err)
+	require.Nil(t, output)
+
+	// Round 1 shares must come from all old parties
+	share := protocol.NewRoundOutput(protocol.Gg20Reshare, protocol.Version1, 1, 1, nil,
+		map[uint32][]byte{4: []byte(`{"FromID":1,"ToID":4}`)})
+	combined, err := protocol.Combine([]*protocol.Message{protocol.Deliver(share, 4)})
+	require.NoError(t, err)
+	_, err = iterator.Next(combined)
+	require.Error(t, err)
+}
@@ -9,7 +9,7 @@
 package resharing
 
 import (
-	"crypto/elliptic"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"math/big"
@@ -17,8 +17,10 @@ import (
 	"github.com/TEENet-io/kryptology/internal"
 	"github.com/TEENet-io/kryptology/pkg/core"
 	"github.com/TEENet-io/kryptology/pkg/core/curves"
+	"github.com/TEENet-io/kryptology/pkg/core/protocol"
 	"github.com/TEENet-io/kryptology/pkg/paillier"
 	"github.com/TEENet-io/kryptology/pkg/tecdsa/gg20/dealer"
+	"github.com/TEENet-io/kryptology/pkg/tecdsa/gg20/participant"
 )
 
 // Config contains parameters for resharing
@@ -27,38 +29,51 @@ type Config struct {
 	NewThreshold uint32
 	OldParties   []uint32
 	NewParties   []uint32
+	// SessionId identifies the resharing and must be the same for all participants. The Paillier proofs of the new
+	// participants are bound to it and to the rest of the configuration. It may be empty.
+	SessionId []byte
 }
 
 // ReshareParticipant represents a participant in the resharing protocol
 type ReshareParticipant struct {
 	ID             uint32
 	Round          uint
-	OldShare       *dealer.Share     // nil for new participants
-	NewShare       *dealer.Share     // generated after resharing
-	PublicKey      *curves.EcPoint   // ECDSA public key (remains unchanged)
-	Curve          elliptic.Curve
+	OldShare       *dealer.Share // nil for new participants
+	NewShare       *dealer.Share // generated after resharing
+	PublicKey      curves.Point  // ECDSA public key (remains unchanged)
+	Curve          *curves.Curve
 	PaillierKey    *paillier.SecretKey
 	PaillierPubKey *paillier.PublicKey
 	Config         *Config
 
 	// Internal state
-	coefficients   []*big.Int                       // polynomial coefficients (round 1)
-	commitments    []*curves.EcPoint                // VSS commitments (round 1)
-	receivedShares map[uint32]*big.Int              // shares received from old participants
-	paillierKeys   map[uint32]*paillier.PublicKey   // collected in round 2
-	publicShares   map[uint32]*dealer.PublicShare   // collected in round 3
+	coefficients   []curves.Scalar                // polynomial coefficients (round 1)
+	commitments    []curves.Point                 // VSS commitments (round 1)
+	receivedShares map[uint32]curves.Scalar       // shares received from old participants
+	paillierKeys   map[uint32]*paillier.PublicKey // collected in round 2
+	publicShares   map[uint32]*dealer.PublicShare // collected in round 3
+
+	// Ring-Pedersen parameters over our Paillier modulus and the discrete log of S to the base T (round 2)
+	ringPedersen *paillier.RingPedersenParams
+	lambda       *big.Int
+	// Ring-Pedersen parameters of the other new participants, collected in round 2
+	peerRingPedersen map[uint32]*paillier.RingPedersenParams
 }
 
 // NewReshareParticipant creates a new resharing participant
 func NewReshareParticipant(
 	id uint32,
 	oldParticipantData *dealer.ParticipantData, // nil for new participants
-	publicKey *curves.EcPoint,
+	publicKey curves.Point,
 	config *Config,
 ) (*ReshareParticipant, error) {
 	if config == nil || publicKey == nil {
 		return nil, internal.ErrNilArguments
 	}
+	curve := curves.GetCurveByName(publicKey.CurveName())
+	if err := dealer.CheckCurve(curve); err != nil {
+		return nil, err
+	}
 
 	var oldShare *dealer.Share
 	var paillierKey *paillier.SecretKey
@@ -92,14 +107,31 @@ func NewReshareParticipant(
 		Round:          1,
 		OldShare:       oldShare,
 		PublicKey:      publicKey,
-		Curve:          publicKey.Curve,
+		Curve:          curve,
 		PaillierKey:    paillierKey,
 		PaillierPubKey: &paillierKey.PublicKey,
 		Config:         config,
-		receivedShares: make(map[uint32]*big.Int),
+		receivedShares: make(map[uint32]curves.Scalar),
 	}, nil
 }
 
+// paillierSid returns the session id of the Paillier proofs, which binds them to the public key and the configuration
+func (rp *ReshareParticipant) paillierSid() []byte {
+	thresholds := make([]byte, 8)
+	binary.BigEndian.PutUint32(thresholds, rp.Config.OldThreshold)
+	binary.BigEndian.PutUint32(thresholds[4:], rp.Config.NewThreshold)
+	return participant.PaillierProofSid(protocol.Gg20Reshare, []byte(rp.Curve.Name), rp.PublicKey.ToAffineCompressed(),
+		thresholds, partiesBytes(rp.Config.OldParties), partiesBytes(rp.Config.NewParties), rp.Config.SessionId)
+}
+
+func partiesBytes(parties []uint32) []byte {
+	b := make([]byte, 4*len(parties))
+	for i, id := range parties {
+		binary.BigEndian.PutUint32(b[4*i:], id)
+	}
+	return b
+}
+
 // GetReshareResult returns the final resharing result after all rounds complete
 func (rp *ReshareParticipant) GetReshareResult() (*dealer.ParticipantData, error) {
 	if rp.Round != 4 {
@@ -141,29 +173,27 @@ func generatePaillierKey() (*paillier.SecretKey, error) {
 	return paillier.NewSecretKey(p, q)
 }
 
-func evaluatePolynomial(coefficients []*big.Int, x uint32, modulus *big.Int) *big.Int {
-	result := new(big.Int).Set(coefficients[0])
-	xPower := big.NewInt(int64(x))
-
-	for i := 1; i < len(coefficients); i++ {
-		term := new(big.Int).Mul(coefficients[i], xPower)
-		result.Add(result, term)
-		result.Mod(result, modulus)
-		xPower.Mul(xPower, big.NewInt(int64(x)))
-		xPower.Mod(xPower, modulus)
+func evaluatePolynomial(curve *curves.Curve, coefficients []curves.Scalar, x uint32) curves.Scalar {
+	// Horner's method: a_0 + x(a_1 + x(a_2 + ...))
+	xs := curve.Scalar.New(int(x))
+	result := coefficients[len(coefficients)-1]
+	for i := len(coefficients) - 2; i >= 0; i-- {
+		result = result.Mul(xs).Add(coefficients[i])
 	}
-
 	return result
 }
 
 // VerifyReshareResult verifies that resharing preserved the ECDSA public key
 func VerifyReshareResult(
 	participantData map[uint32]*dealer.ParticipantData,
-	expectedPublicKey *curves.EcPoint,
+	expectedPublicKey curves.Point,
 ) error {
 	if len(participantData) == 0 {
 		return errors.New("no participant data to verify")
 	}
+	if expectedPublicKey == nil {
+		return internal.ErrNilArguments
+	}
 
 	// Verify all participants have the same public key
 	for id, data := range participantData {
@@ -171,8 +201,7 @@ func VerifyReshareResult(
 			return fmt.Errorf("participant %d has nil public key", id)
 		}
 
-		if data.EcdsaPublicKey.X.Cmp(expectedPublicKey.X) != 0 ||
-		   data.EcdsaPublicKey.Y.Cmp(expectedPublicKey.Y) != 0 {
+		if !data.EcdsaPublicKey.Equal(expectedPublicKey) {
 			return fmt.Errorf("participant %d has incorrect public key", id)
 		}
 
@@ -186,4 +215,4 @@ func VerifyReshareResult(
 	}
 
 	return nil
-}
\ No newline at end of file
+}
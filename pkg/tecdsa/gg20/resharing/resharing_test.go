@@ -7,7 +7,6 @@
 package resharing
 
 import (
-	"crypto/elliptic"
 	"math/big"
 	"testing"
 
@@ -32,7 +31,7 @@ func generateTestPaillierKey() (*paillier.SecretKey, error) {
 
 func TestIntegration_CompleteResharing(t *testing.T) {
 	// Use P-256 curve
-	curve := elliptic.P256()
+	curve := curves.P256()
 	
 	// Step 1: Generate initial shares (2-of-3)
 	t.Log("Step 1: Generating initial ECDSA key shares (2-of-3)")
@@ -41,8 +40,7 @@ func TestIntegration_CompleteResharing(t *testing.T) {
 	require.NotNil(t, ecdsaPublicKey)
 	require.Len(t, initialShares, 3)
 	
-	t.Logf("Generated ECDSA public key: X=%s, Y=%s", 
-		ecdsaPublicKey.X.Text(16), ecdsaPublicKey.Y.Text(16))
+	t.Logf("Generated ECDSA public key: %x", ecdsaPublicKey.ToAffineCompressed())
 	
 	// Prepare participant data for old participants
 	oldParticipantData := make(map[uint32]*dealer.ParticipantData)
@@ -112,8 +110,7 @@ func TestIntegration_CompleteResharing(t *testing.T) {
 		newParticipantData[id] = result
 		
 		// Verify public key is preserved
-		assert.Equal(t, ecdsaPublicKey.X, result.EcdsaPublicKey.X)
-		assert.Equal(t, ecdsaPublicKey.Y, result.EcdsaPublicKey.Y)
+		assert.True(t, ecdsaPublicKey.Equal(result.EcdsaPublicKey))
 		
 		// Verify participant has required data
 		assert.NotNil(t, result.SecretKeyShare)
@@ -150,7 +147,7 @@ func TestIntegration_CompleteResharing(t *testing.T) {
 }
 
 func TestIntegration_ParticipantRemoval(t *testing.T) {
-	curve := elliptic.P256()
+	curve := curves.P256()
 	
 	// Start with 5 participants (3-of-5)
 	t.Log("Starting with 5 participants (3-of-5)")
@@ -220,7 +217,7 @@ func TestIntegration_ParticipantRemoval(t *testing.T) {
 }
 
 func TestIntegration_ThresholdChange(t *testing.T) {
-	curve := elliptic.P256()
+	curve := curves.P256()
 	
 	// Start with 2-of-5 threshold
 	t.Log("Starting with 2-of-5 threshold")
@@ -277,7 +274,7 @@ func TestIntegration_ThresholdChange(t *testing.T) {
 }
 
 func TestResharing_InvalidParameters(t *testing.T) {
-	curve := elliptic.P256()
+	curve := curves.P256()
 	publicKey, _, err := dealer.NewDealerShares(curve, 2, 3, nil)
 	require.NoError(t, err)
 
@@ -320,7 +317,7 @@ func TestResharing_InvalidParameters(t *testing.T) {
 }
 
 func TestResharing_RoundValidation(t *testing.T) {
-	curve := elliptic.P256()
+	curve := curves.P256()
 	publicKey, shares, err := dealer.NewDealerShares(curve, 2, 3, nil)
 	require.NoError(t, err)
 
@@ -368,7 +365,7 @@ func TestResharing_RoundValidation(t *testing.T) {
 }
 
 func TestResharing_ShareVerification(t *testing.T) {
-	curve := elliptic.P256()
+	curve := curves.P256()
 	publicKey, shares, err := dealer.NewDealerShares(curve, 2, 3, nil)
 	require.NoError(t, err)
 	
@@ -463,7 +460,7 @@ func executeTestResharing(t *testing.T, participants map[uint32]*ReshareParticip
 // Benchmark tests
 
 func BenchmarkResharing_3to5(b *testing.B) {
-	curve := elliptic.P256()
+	curve := curves.P256()
 	
 	// Setup initial shares
 	publicKey, initialShares, err := dealer.NewDealerShares(curve, 2, 3, nil)
@@ -524,7 +521,7 @@ func BenchmarkResharing_3to5(b *testing.B) {
 }
 
 func BenchmarkResharing_Round1(b *testing.B) {
-	curve := elliptic.P256()
+	curve := curves.P256()
 	publicKey, shares, _ := dealer.NewDealerShares(curve, 2, 3, nil)
 	
 	params := &Config{
@@ -551,7 +548,7 @@ func BenchmarkResharing_Round1(b *testing.B) {
 }
 
 func BenchmarkVerifyFeldmanShare(b *testing.B) {
-	curve := elliptic.P256()
+	curve := curves.P256()
 	publicKey, shares, _ := dealer.NewDealerShares(curve, 2, 3, nil)
 	
 	params := &Config{
@@ -587,7 +584,7 @@ func BenchmarkVerifyFeldmanShare(b *testing.B) {
 // Additional test cases for better coverage
 
 func TestResharing_EdgeCases(t *testing.T) {
-	curve := elliptic.P256()
+	curve := curves.P256()
 	
 	t.Run("MinimalThreshold_2of3", func(t *testing.T) {
 		// Test with minimal valid threshold (2-of-3)
@@ -686,12 +683,14 @@ func TestResharing_EdgeCases(t *testing.T) {
 			result, err := participants[id].GetReshareResult()
 			require.NoError(t, err)
 			assert.NotNil(t, result)
-			assert.Equal(t, publicKey.X, result.EcdsaPublicKey.X)
-			assert.Equal(t, publicKey.Y, result.EcdsaPublicKey.Y)
+			assert.True(t, publicKey.Equal(result.EcdsaPublicKey))
 		}
 	})
 	
 	t.Run("LargeScaleResharing", func(t *testing.T) {
+		if testing.Short() {
+			t.Skip("skipping test in short mode.")
+		}
 		// Test with larger number of participants (5-of-9 to 7-of-11)
 		publicKey, shares, err := dealer.NewDealerShares(curve, 5, 9, nil)
 		require.NoError(t, err)
@@ -751,7 +750,7 @@ func TestResharing_EdgeCases(t *testing.T) {
 }
 
 func TestResharing_ErrorCases(t *testing.T) {
-	curve := elliptic.P256()
+	curve := curves.P256()
 	publicKey, shares, err := dealer.NewDealerShares(curve, 2, 3, nil)
 	require.NoError(t, err)
 	
@@ -851,6 +850,83 @@ func TestResharing_ErrorCases(t *testing.T) {
 		assert.Contains(t, err.Error(), "nil Paillier key")
 	})
 	
+	t.Run("InvalidPaillierProofs", func(t *testing.T) {
+		participants := make(map[uint32]*ReshareParticipant)
+		for _, id := range params.OldParties {
+			paillierKey, err := generateTestPaillierKey()
+			require.NoError(t, err)
+			oldData := &dealer.ParticipantData{
+				Id:             id,
+				SecretKeyShare: shares[id],
+				DecryptKey:     paillierKey,
+				EcdsaPublicKey: publicKey,
+			}
+			participants[id], err = NewReshareParticipant(id, oldData, publicKey, params)
+			require.NoError(t, err)
+		}
+		
+		round1Messages := make(map[uint32][]*ReshareRound1Bcast)
+		for _, id := range params.OldParties {
+			messages, err := participants[id].ReshareRound1()
+			require.NoError(t, err)
+			for recipientID, msg := range messages {
+				round1Messages[recipientID] = append(round1Messages[recipientID], msg)
+			}
+		}
+		for _, id := range params.NewParties {
+			require.NoError(t, participants[id].ReshareRound1Accept(round1Messages[id]))
+		}
+		
+		round2Messages := make([]*ReshareRound2Bcast, 0, len(params.NewParties))
+		for _, id := range params.NewParties {
+			msg, err := participants[id].ReshareRound2()
+			require.NoError(t, err)
+			round2Messages = append(round2Messages, msg)
+		}
+		
+		// A Πmod proof replayed from another participant
+		replayed := *round2Messages[1]
+		replayed.ModProof = round2Messages[0].ModProof
+		err := participants[3].ReshareRound2Accept([]*ReshareRound2Bcast{round2Messages[0], &replayed, round2Messages[2]})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid Paillier key from participant 2")
+		
+		// Ring-Pedersen parameters without a Πprm proof
+		unproven := *round2Messages[1]
+		unproven.PrmProof = nil
+		err = participants[3].ReshareRound2Accept([]*ReshareRound2Bcast{round2Messages[0], &unproven, round2Messages[2]})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid Ring-Pedersen parameters from participant 2")
+		
+		// Proofs made for another resharing session
+		otherSession := *params
+		otherSession.SessionId = []byte("another resharing")
+		participants[3].Config = &otherSession
+		err = participants[3].ReshareRound2Accept(round2Messages)
+		assert.Error(t, err)
+		participants[3].Config = params
+		
+		for _, id := range params.NewParties {
+			require.NoError(t, participants[id].ReshareRound2Accept(round2Messages))
+		}
+		
+		round3Messages := make([]*ReshareRound3Bcast, 0, len(params.NewParties))
+		for _, id := range params.NewParties {
+			msg, err := participants[id].ReshareRound3()
+			require.NoError(t, err)
+			round3Messages = append(round3Messages, msg)
+		}
+		
+		// A Πfac proof made for another recipient
+		misdirected := *round3Messages[1]
+		misdirected.FacProofs = map[uint32]*paillier.FacProof{1: round3Messages[1].FacProofs[3]}
+		err = participants[1].ReshareRound3Accept([]*ReshareRound3Bcast{round3Messages[0], &misdirected, round3Messages[2]})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid Paillier key from participant 2")
+		
+		require.NoError(t, participants[1].ReshareRound3Accept(round3Messages))
+	})
+	
 	t.Run("MissingPublicShares", func(t *testing.T) {
 		paillierKey, err := generateTestPaillierKey()
 		require.NoError(t, err)
@@ -902,7 +978,7 @@ func TestResharing_LagrangeCoefficient(t *testing.T) {
 }
 
 func TestResharing_AdditionalCoverage(t *testing.T) {
-	curve := elliptic.P256()
+	curve := curves.P256()
 	
 	t.Run("GetReshareResult_NotComplete", func(t *testing.T) {
 		publicKey, shares, err := dealer.NewDealerShares(curve, 2, 3, nil)
@@ -1145,8 +1221,7 @@ func TestResharing_AdditionalCoverage(t *testing.T) {
 		p.Round = 3
 		
 		// Create a valid new share with point
-		sharePoint, err := curves.NewScalarBaseMult(curve, big.NewInt(123))
-		require.NoError(t, err)
+		sharePoint := curve.ScalarBaseMult(curve.Scalar.New(123))
 		p.NewShare = &dealer.Share{Point: sharePoint}
 		
 		// Accept incomplete shares (only 1 out of 3 needed)
@@ -1204,27 +1279,26 @@ func TestResharing_AdditionalCoverage(t *testing.T) {
 		require.NoError(t, err)
 		
 		// Create invalid message with mismatched commitments
-		badCommitment, err := curves.NewScalarBaseMult(curve, big.NewInt(999))
-		require.NoError(t, err)
+		badCommitment := curve.ScalarBaseMult(curve.Scalar.New(999))
 		
 		invalidMessages := []*ReshareRound1Bcast{
 			{
 				FromID:      1,
 				ToID:        2,
-				Share:       big.NewInt(123),
-				Commitments: []*curves.EcPoint{badCommitment, badCommitment},
+				Share:       curve.Scalar.New(123),
+				Commitments: []curves.Point{badCommitment, badCommitment},
 			},
 			{
 				FromID:      2,
 				ToID:        2,
-				Share:       big.NewInt(456),
-				Commitments: []*curves.EcPoint{badCommitment, badCommitment},
+				Share:       curve.Scalar.New(456),
+				Commitments: []curves.Point{badCommitment, badCommitment},
 			},
 			{
 				FromID:      3,
 				ToID:        2,
-				Share:       big.NewInt(789),
-				Commitments: []*curves.EcPoint{badCommitment, badCommitment},
+				Share:       curve.Scalar.New(789),
+				Commitments: []curves.Point{badCommitment, badCommitment},
 			},
 		}
 		
@@ -1249,8 +1323,8 @@ func TestResharing_AdditionalCoverage(t *testing.T) {
 		msg := &ReshareRound1Bcast{
 			FromID:      1,
 			ToID:        2,
-			Share:       big.NewInt(123),
-			Commitments: []*curves.EcPoint{nil, nil}, // nil will cause error
+			Share:       curve.Scalar.New(123),
+			Commitments: []curves.Point{nil, nil}, // nil will cause error
 		}
 		
 		result := p.verifyFeldmanShare(msg)
@@ -1294,15 +1368,15 @@ func TestResharing_AdditionalCoverage(t *testing.T) {
 			}
 		}
 		
-		// Old participant 1 (already in round 2) accepts shares as new participant
-		err = oldParticipants[0].ReshareRound1Accept(allMessages[1])
+		// An old participant (already in round 2) accepts shares as new participant
+		err = oldParticipants[0].ReshareRound1Accept(allMessages[oldParticipants[0].ID])
 		require.NoError(t, err)
 		assert.NotNil(t, oldParticipants[0].NewShare)
 	})
 }
 
 func TestResharing_VerifyReshareResult(t *testing.T) {
-	curve := elliptic.P256()
+	curve := curves.P256()
 	
 	t.Run("EmptyParticipantData", func(t *testing.T) {
 		publicKey, _, err := dealer.NewDealerShares(curve, 2, 3, nil)
@@ -8,22 +8,80 @@ package resharing
 
 import (
 	"crypto/rand"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
 
 	"github.com/TEENet-io/kryptology/internal"
 	"github.com/TEENet-io/kryptology/pkg/core/curves"
-	v1 "github.com/TEENet-io/kryptology/pkg/sharing/v1"
 	"github.com/TEENet-io/kryptology/pkg/tecdsa/gg20/dealer"
 )
 
 // ReshareRound1Bcast contains values to be broadcast to all new participants after round 1
 type ReshareRound1Bcast struct {
-	FromID      uint32            // Sender ID (old participant)
-	ToID        uint32            // Recipient ID (new participant)
-	Share       *big.Int          // The secret share for the recipient
-	Commitments []*curves.EcPoint // Feldman VSS commitments
+	FromID      uint32         // Sender ID (old participant)
+	ToID        uint32         // Recipient ID (new participant)
+	Share       curves.Scalar  // The secret share for the recipient
+	Commitments []curves.Point // Feldman VSS commitments
+}
+
+// reshareRound1BcastJson encapsulates the data that is serialized to JSON
+type reshareRound1BcastJson struct {
+	FromID      uint32
+	ToID        uint32
+	Share       *big.Int
+	Commitments []*curves.EcPoint
+}
+
+func (b ReshareRound1Bcast) MarshalJSON() ([]byte, error) {
+	if b.Share == nil {
+		return nil, internal.ErrNilArguments
+	}
+	data := reshareRound1BcastJson{
+		FromID:      b.FromID,
+		ToID:        b.ToID,
+		Share:       b.Share.BigInt(),
+		Commitments: make([]*curves.EcPoint, len(b.Commitments)),
+	}
+	for i, c := range b.Commitments {
+		point, err := curves.NewEcPoint(c)
+		if err != nil {
+			return nil, err
+		}
+		data.Commitments[i] = point
+	}
+	return json.Marshal(data)
+}
+
+func (b *ReshareRound1Bcast) UnmarshalJSON(bytes []byte) error {
+	data := new(reshareRound1BcastJson)
+	if err := json.Unmarshal(bytes, data); err != nil {
+		return err
+	}
+	if data.Share == nil || len(data.Commitments) == 0 {
+		return fmt.Errorf("missing share or commitments")
+	}
+	commitments := make([]curves.Point, len(data.Commitments))
+	for i, c := range data.Commitments {
+		if c == nil {
+			return fmt.Errorf("missing commitment %d", i)
+		}
+		point, err := c.ToPoint()
+		if err != nil {
+			return err
+		}
+		commitments[i] = point
+	}
+	share, err := curves.GetCurveByName(commitments[0].CurveName()).Scalar.SetBigInt(data.Share)
+	if err != nil {
+		return err
+	}
+	b.FromID = data.FromID
+	b.ToID = data.ToID
+	b.Share = share
+	b.Commitments = commitments
+	return nil
 }
 
 // ReshareRound1 generates shares for new participants (old participants only)
@@ -43,17 +101,24 @@ func (rp *ReshareParticipant) ReshareRound1() (map[uint32]*ReshareRound1Bcast, e
 	}
 
 	// Get the secret share from the old participant
-	secret := rp.OldShare.ShamirShare.Value.BigInt()
+	secret := rp.OldShare.Value
 	threshold := int(rp.Config.NewThreshold)
-	
+	ec, err := rp.Curve.ToEllipticCurve()
+	if err != nil {
+		return nil, err
+	}
+
 	// Calculate Lagrange coefficient for this participant at x=0
 	// This is needed when all old participants contribute to preserve the secret
-	lagrangeCoeff := calculateLagrangeCoefficient(rp.ID, rp.Config.OldParties, rp.Curve.Params().N)
-	
+	lagrangeCoeff, err := rp.Curve.Scalar.SetBigInt(
+		calculateLagrangeCoefficient(rp.ID, rp.Config.OldParties, ec.Params().N))
+	if err != nil {
+		return nil, err
+	}
+
 	// Apply Lagrange coefficient to the share
 	// This ensures proper reconstruction when all old participants contribute
-	adjustedSecret := new(big.Int).Mul(secret, lagrangeCoeff)
-	adjustedSecret.Mod(adjustedSecret, rp.Curve.Params().N)
+	adjustedSecret := secret.Mul(lagrangeCoeff)
 
 	// Create polynomial coefficients for Feldman VSS
 	// IMPORTANT: For proper resharing that preserves the public key,
@@ -61,33 +126,25 @@ func (rp *ReshareParticipant) ReshareRound1() (map[uint32]*ReshareRound1Bcast, e
 	// random coefficients for the higher degree terms.
 	// This ensures that when all old participants do this and new participants
 	// combine the shares, the original secret is preserved.
-	rp.coefficients = make([]*big.Int, threshold)
+	rp.coefficients = make([]curves.Scalar, threshold)
 	rp.coefficients[0] = adjustedSecret // Use the Lagrange-adjusted share as a_0
 
 	// Generate random coefficients for degree 1 to threshold-1
 	for i := 1; i < threshold; i++ {
-		coef, err := rand.Int(rand.Reader, rp.Curve.Params().N)
-		if err != nil {
-			return nil, fmt.Errorf("failed to generate coefficient: %w", err)
-		}
-		rp.coefficients[i] = coef
+		rp.coefficients[i] = rp.Curve.Scalar.Random(rand.Reader)
 	}
 
 	// Generate commitments C_i = g^{a_i} for Feldman VSS
-	rp.commitments = make([]*curves.EcPoint, threshold)
+	rp.commitments = make([]curves.Point, threshold)
 	for i := 0; i < threshold; i++ {
-		commitment, err := curves.NewScalarBaseMult(rp.Curve, rp.coefficients[i])
-		if err != nil {
-			return nil, fmt.Errorf("failed to generate commitment: %w", err)
-		}
-		rp.commitments[i] = commitment
+		rp.commitments[i] = rp.Curve.ScalarBaseMult(rp.coefficients[i])
 	}
 
 	// Generate shares for each new participant
 	messages := make(map[uint32]*ReshareRound1Bcast)
 	for _, newID := range rp.Config.NewParties {
 		// Evaluate polynomial at newID: f(newID) = sum(a_i * newID^i)
-		share := evaluatePolynomial(rp.coefficients, newID, rp.Curve.Params().N)
+		share := evaluatePolynomial(rp.Curve, rp.coefficients, newID)
 
 		messages[newID] = &ReshareRound1Bcast{
 			FromID:      rp.ID,
@@ -150,30 +207,20 @@ func (rp *ReshareParticipant) ReshareRound1Accept(messages []*ReshareRound1Bcast
 	// we simply sum their sub-shares. Each old participant created a polynomial with their
 	// share as the constant term and evaluated it at the new participant's ID.
 	// The sum of all these evaluations gives the new participant's share.
-	newShareValue := new(big.Int)
-	
+	newShareValue := rp.Curve.Scalar.Zero()
+
 	// Simply sum all received shares
 	for _, share := range rp.receivedShares {
-		newShareValue.Add(newShareValue, share)
-		newShareValue.Mod(newShareValue, rp.Curve.Params().N)
+		newShareValue = newShareValue.Add(share)
 	}
 
-	// Create the new share structure
-	field := curves.NewField(rp.Curve.Params().N)
+	// Create the new share structure with its public share point for verification
 	rp.NewShare = &dealer.Share{
-		ShamirShare: &v1.ShamirShare{
-			Identifier: rp.ID,
-			Value:      field.NewElement(newShareValue),
-		},
+		Identifier: rp.ID,
+		Value:      newShareValue,
+		Point:      rp.Curve.ScalarBaseMult(newShareValue),
 	}
 
-	// Generate public share point for verification
-	publicSharePoint, err := curves.NewScalarBaseMult(rp.Curve, newShareValue)
-	if err != nil {
-		return fmt.Errorf("failed to generate public share: %w", err)
-	}
-	rp.NewShare.Point = publicSharePoint
-
 	// Advance to next round (unless already in round 2 for old participants)
 	if rp.Round == 1 {
 		rp.Round = 2
@@ -186,64 +233,51 @@ func (rp *ReshareParticipant) verifyFeldmanShare(msg *ReshareRound1Bcast) bool {
 	// Verify: g^share = product(commitment_i^(id^i))
 	// This ensures the share is consistent with the polynomial commitments
 
-	// Compute expected value from commitments
-	var expected *curves.EcPoint
-	xPower := big.NewInt(1)
-	idBig := big.NewInt(int64(rp.ID))
-
-	for i, commitment := range msg.Commitments {
-		if i == 0 {
-			expected = commitment
-		} else {
-			xPower.Mul(xPower, idBig)
-			xPower.Mod(xPower, rp.Curve.Params().N)
-
-			term, err := commitment.ScalarMult(xPower)
-			if err != nil {
-				return false
-			}
-
-			expected, err = expected.Add(term)
-			if err != nil {
-				return false
-			}
-		}
+	if msg.Share == nil || len(msg.Commitments) == 0 {
+		return false
 	}
 
-	// Compute actual value from share
-	actual, err := curves.NewScalarBaseMult(rp.Curve, msg.Share)
-	if err != nil {
-		return false
+	// Compute expected value from commitments
+	expected := rp.Curve.NewIdentityPoint()
+	xPower := rp.Curve.Scalar.One()
+	id := rp.Curve.Scalar.New(int(rp.ID))
+
+	for _, commitment := range msg.Commitments {
+		if commitment == nil || commitment.CurveName() != rp.Curve.Name || !commitment.IsOnCurve() {
+			return false
+		}
+		expected = expected.Add(commitment.Mul(xPower))
+		xPower = xPower.Mul(id)
 	}
 
-	// Compare expected and actual
-	return actual.X.Cmp(expected.X) == 0 && actual.Y.Cmp(expected.Y) == 0
+	// Compute actual value from share and compare
+	return rp.Curve.ScalarBaseMult(msg.Share).Equal(expected)
 }
 
 // calculateLagrangeCoefficient calculates the Lagrange coefficient for a given party at x=0
 func calculateLagrangeCoefficient(partyID uint32, allPartyIDs []uint32, modulus *big.Int) *big.Int {
 	// L_i(0) = product((0 - x_j) / (x_i - x_j)) for all j != i
 	// Since we evaluate at 0: L_i(0) = product((-x_j) / (x_i - x_j))
-	
+
 	numerator := big.NewInt(1)
 	denominator := big.NewInt(1)
-	
+
 	for _, otherID := range allPartyIDs {
 		if otherID == partyID {
 			continue
 		}
-		
+
 		// Numerator: multiply by -otherID
 		term := big.NewInt(-int64(otherID))
 		numerator.Mul(numerator, term)
 		numerator.Mod(numerator, modulus)
-		
+
 		// Denominator: multiply by (partyID - otherID)
 		diff := big.NewInt(int64(partyID) - int64(otherID))
 		denominator.Mul(denominator, diff)
 		denominator.Mod(denominator, modulus)
 	}
-	
+
 	// Ensure positive values in modular arithmetic
 	if numerator.Sign() < 0 {
 		numerator.Add(numerator, modulus)
@@ -251,16 +285,16 @@ func calculateLagrangeCoefficient(partyID uint32, allPartyIDs []uint32, modulus
 	if denominator.Sign() < 0 {
 		denominator.Add(denominator, modulus)
 	}
-	
+
 	// Calculate numerator * denominator^(-1) mod modulus
 	denomInv := new(big.Int).ModInverse(denominator, modulus)
 	if denomInv == nil {
 		// This shouldn't happen with proper party IDs
 		panic("failed to compute modular inverse in Lagrange coefficient")
 	}
-	
+
 	result := new(big.Int).Mul(numerator, denomInv)
 	result.Mod(result, modulus)
-	
+
 	return result
-}
\ No newline at end of file
+}
@@ -17,6 +17,13 @@ import (
 type ReshareRound2Bcast struct {
 	ParticipantID uint32
 	PublicKey     *paillier.PublicKey
+	// RingPedersen are parameters over PublicKey.N, under which the other participants prove their
+	// Paillier keys have no small factors in round 3
+	RingPedersen *paillier.RingPedersenParams
+	// ModProof proves that PublicKey is a Paillier-Blum modulus
+	ModProof *paillier.ModProof
+	// PrmProof proves that RingPedersen is well formed
+	PrmProof *paillier.PrmProof
 }
 
 // ReshareRound2 distributes Paillier public keys
@@ -29,10 +36,42 @@ func (rp *ReshareParticipant) ReshareRound2() (*ReshareRound2Bcast, error) {
 		return nil, internal.ErrInvalidRound
 	}
 
+	// Ring-Pedersen parameters are generated once, as λ is needed again if the round is retried
+	if rp.ringPedersen == nil {
+		ringPedersen, lambda, err := paillier.NewRingPedersenParams(rp.PaillierKey)
+		if err != nil {
+			return nil, err
+		}
+		rp.ringPedersen, rp.lambda = ringPedersen, lambda
+	}
+	modParams := paillier.ModProofParams{
+		SecretKey: rp.PaillierKey,
+		Pi:        rp.ID,
+		Sid:       rp.paillierSid(),
+	}
+	modProof, err := modParams.Prove()
+	if err != nil {
+		return nil, err
+	}
+	prmParams := paillier.PrmProofParams{
+		RingPedersen: rp.ringPedersen,
+		Lambda:       rp.lambda,
+		Totient:      rp.PaillierKey.Totient,
+		Pi:           rp.ID,
+		Sid:          rp.paillierSid(),
+	}
+	prmProof, err := prmParams.Prove()
+	if err != nil {
+		return nil, err
+	}
+
 	// Don't advance round here, let Accept do it
 	return &ReshareRound2Bcast{
 		ParticipantID: rp.ID,
 		PublicKey:     rp.PaillierPubKey,
+		RingPedersen:  rp.ringPedersen,
+		ModProof:      modProof,
+		PrmProof:      prmProof,
 	}, nil
 }
 
@@ -62,6 +101,37 @@ func (rp *ReshareParticipant) ReshareRound2Accept(messages []*ReshareRound2Bcast
 		}
 	}
 
+	// Verify that every other Paillier key is a Paillier-Blum modulus, and that the Ring-Pedersen
+	// parameters sent with it are well formed. See https://eprint.iacr.org/2021/1621.pdf
+	rp.peerRingPedersen = make(map[uint32]*paillier.RingPedersenParams)
+	sid := rp.paillierSid()
+	for _, msg := range messages {
+		if msg.ParticipantID == rp.ID {
+			continue
+		}
+		if msg.RingPedersen == nil || msg.RingPedersen.N == nil || msg.PublicKey.N == nil ||
+			msg.RingPedersen.N.Cmp(msg.PublicKey.N) != 0 {
+			return fmt.Errorf("invalid Ring-Pedersen parameters from participant %d", msg.ParticipantID)
+		}
+		modParams := &paillier.ModVerifyParams{
+			PublicKey: msg.PublicKey,
+			Pi:        msg.ParticipantID,
+			Sid:       sid,
+		}
+		if err := msg.ModProof.Verify(modParams); err != nil {
+			return fmt.Errorf("invalid Paillier key from participant %d: %w", msg.ParticipantID, err)
+		}
+		prmParams := &paillier.PrmVerifyParams{
+			RingPedersen: msg.RingPedersen,
+			Pi:           msg.ParticipantID,
+			Sid:          sid,
+		}
+		if err := msg.PrmProof.Verify(prmParams); err != nil {
+			return fmt.Errorf("invalid Ring-Pedersen parameters from participant %d: %w", msg.ParticipantID, err)
+		}
+		rp.peerRingPedersen[msg.ParticipantID] = msg.RingPedersen
+	}
+
 	// Add our own key
 	rp.paillierKeys[rp.ID] = rp.PaillierPubKey
 
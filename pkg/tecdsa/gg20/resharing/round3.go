@@ -10,6 +10,7 @@ import (
 	"fmt"
 
 	"github.com/TEENet-io/kryptology/internal"
+	"github.com/TEENet-io/kryptology/pkg/paillier"
 	"github.com/TEENet-io/kryptology/pkg/tecdsa/gg20/dealer"
 )
 
@@ -17,6 +18,9 @@ import (
 type ReshareRound3Bcast struct {
 	ParticipantID uint32
 	PublicShare   *dealer.PublicShare
+	// FacProofs prove that our Paillier key has no small factors, keyed by the participant whose
+	// Ring-Pedersen parameters each proof uses
+	FacProofs map[uint32]*paillier.FacProof
 }
 
 // ReshareRound3 shares public share points
@@ -33,10 +37,26 @@ func (rp *ReshareParticipant) ReshareRound3() (*ReshareRound3Bcast, error) {
 		return nil, fmt.Errorf("public share point not computed")
 	}
 
+	facProofs := make(map[uint32]*paillier.FacProof, len(rp.peerRingPedersen))
+	for id, ringPedersen := range rp.peerRingPedersen {
+		facParams := paillier.FacProofParams{
+			SecretKey:    rp.PaillierKey,
+			RingPedersen: ringPedersen,
+			Pi:           rp.ID,
+			Sid:          rp.paillierSid(),
+		}
+		facProof, err := facParams.Prove()
+		if err != nil {
+			return nil, err
+		}
+		facProofs[id] = facProof
+	}
+
 	// Don't advance round here, let Accept do it
 	return &ReshareRound3Bcast{
 		ParticipantID: rp.ID,
 		PublicShare:   &dealer.PublicShare{Point: rp.NewShare.Point},
+		FacProofs:     facProofs,
 	}, nil
 }
 
@@ -68,6 +88,22 @@ func (rp *ReshareParticipant) ReshareRound3Accept(messages []*ReshareRound3Bcast
 			len(rp.publicShares), len(rp.Config.NewParties))
 	}
 
+	// Verify that no other Paillier key has small factors, under our Ring-Pedersen parameters
+	for _, msg := range messages {
+		if msg.ParticipantID == rp.ID {
+			continue
+		}
+		facParams := &paillier.FacVerifyParams{
+			PublicKey:    rp.paillierKeys[msg.ParticipantID],
+			RingPedersen: rp.ringPedersen,
+			Pi:           msg.ParticipantID,
+			Sid:          rp.paillierSid(),
+		}
+		if err := msg.FacProofs[rp.ID].Verify(facParams); err != nil {
+			return fmt.Errorf("invalid Paillier key from participant %d: %w", msg.ParticipantID, err)
+		}
+	}
+
 	// Advance to completion
 	rp.Round = 4
 	return nil
@@ -10,7 +10,8 @@ import (
 	"crypto/sha256"
 	"crypto/sha512"
 	"fmt"
-	"math/big"
+
+	"golang.org/x/crypto/sha3"
 
 	"github.com/TEENet-io/kryptology/pkg/core/curves"
 )
@@ -29,6 +30,58 @@ func (ed Ed25519ChallengeDeriver) DeriveChallenge(msg []byte, pubKey curves.Poin
 	return new(curves.ScalarEd25519).SetBytesWide(h.Sum(nil))
 }
 
+// Ristretto255ChallengeDeriver implements the challenge H2(R || PK || msg) of the
+// FROST(ristretto255, SHA-512) ciphersuite of RFC 9591 Section 6.2, which reduces
+// SHA-512(contextString || "chal" || R || PK || msg) modulo the group order.
+type Ristretto255ChallengeDeriver struct{}
+
+// ristretto255ContextString is the contextString of FROST(ristretto255, SHA-512)
+const ristretto255ContextString = "FROST-RISTRETTO255-SHA512-v1"
+
+func (d Ristretto255ChallengeDeriver) DeriveChallenge(msg []byte, pubKey curves.Point, r curves.Point) (curves.Scalar, error) {
+	h := sha512.New()
+	_, _ = h.Write([]byte(ristretto255ContextString))
+	_, _ = h.Write([]byte("chal"))
+	_, _ = h.Write(r.ToAffineCompressed())
+	_, _ = h.Write(pubKey.ToAffineCompressed())
+	_, _ = h.Write(msg)
+	return new(curves.ScalarRistretto255).SetBytesWide(h.Sum(nil))
+}
+
+// Ed448ChallengeDeriver implements the challenge H2(R || PK || msg) of the
+// FROST(Ed448, SHAKE256) ciphersuite of RFC 9591 Section 6.3, which reduces
+// SHAKE256(dom4(0, "") || R || PK || msg, 114) modulo the group order as in RFC 8032,
+// so the signatures verify as Ed448 signatures.
+type Ed448ChallengeDeriver struct{}
+
+// ed448Dom4 is dom4(0, "") of RFC 8032 for Ed448 without context
+const ed448Dom4 = "SigEd448\x00\x00"
+
+func (d Ed448ChallengeDeriver) DeriveChallenge(msg []byte, pubKey curves.Point, r curves.Point) (curves.Scalar, error) {
+	h := sha3.NewShake256()
+	_, _ = h.Write([]byte(ed448Dom4))
+	_, _ = h.Write(r.ToAffineCompressed())
+	_, _ = h.Write(pubKey.ToAffineCompressed())
+	_, _ = h.Write(msg)
+	var digest [114]byte
+	_, _ = h.Read(digest[:])
+	return new(curves.ScalarEd448).SetBytesWide(digest[:])
+}
+
+// P384ChallengeDeriver hashes R || PK || msg to a P-384 scalar with the hash_to_field
+// of P384_XMD:SHA-384_SSWU_RO_, since RFC 9591 has no P-384 ciphersuite.
+type P384ChallengeDeriver struct{}
+
+func (d P384ChallengeDeriver) DeriveChallenge(msg []byte, pubKey curves.Point, r curves.Point) (curves.Scalar, error) {
+	rBytes := r.ToAffineCompressed()
+	pkBytes := pubKey.ToAffineCompressed()
+	input := make([]byte, 0, len(rBytes)+len(pkBytes)+len(msg))
+	input = append(input, rBytes...)
+	input = append(input, pkBytes...)
+	input = append(input, msg...)
+	return new(curves.ScalarP384).Hash(input), nil
+}
+
 type Secp256k1ChallengeDeriver struct{}
 
 // DeriveChallenge implements the FROST challenge derivation for secp256k1 using SHA-256.
@@ -37,7 +90,7 @@ func (d Secp256k1ChallengeDeriver) DeriveChallenge(msg []byte, pubKey curves.Poi
 	_, _ = h.Write(r.ToAffineCompressed())
 	_, _ = h.Write(pubKey.ToAffineCompressed())
 	_, _ = h.Write(msg)
-	return new(curves.ScalarK256).SetBytes(h.Sum(nil))
+	return new(curves.ScalarK256).SetBytesReduce(h.Sum(nil))
 }
 
 // BIP340ChallengeDeriver implements the challenge derivation specified in
@@ -72,9 +125,6 @@ func (BIP340ChallengeDeriver) DeriveChallenge(msg []byte, pubKey curves.Point, r
 	_, _ = h.Write(rCompressed[1:]) // strip parity byte → 32-byte x-only
 	_, _ = h.Write(pCompressed[1:]) // strip parity byte → 32-byte x-only
 	_, _ = h.Write(msg)
-	// BIP-340 specifies int_from_bytes(...) as a big-endian integer reduction,
-	// but ScalarK256.SetBytes uses the FROST internal little-endian convention
-	// (see ReverseScalarBytes in core/curves/k256_curve.go). Going via big.Int
-	// gives us the spec-compliant big-endian interpretation.
-	return new(curves.ScalarK256).SetBigInt(new(big.Int).SetBytes(h.Sum(nil)))
+	// BIP-340 specifies int_from_bytes(...) mod n, a big-endian integer reduced modulo the order.
+	return new(curves.ScalarK256).SetBytesReduce(h.Sum(nil))
 }
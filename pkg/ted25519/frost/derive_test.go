@@ -0,0 +1,91 @@
+//
+// Copyright Coinbase, Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package frost
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/TEENet-io/kryptology/pkg/core/curves"
+	dkg "github.com/TEENet-io/kryptology/pkg/dkg/frost"
+	"github.com/TEENet-io/kryptology/pkg/sharing"
+)
+
+func TestSignWithDerivedKey(t *testing.T) {
+	tests := []struct {
+		curve    *curves.Curve
+		deriver  ChallengeDerive
+		xOnlyKey bool
+	}{
+		{curves.ED25519(), Ed25519ChallengeDeriver{}, false},
+		{curves.K256(), BIP340ChallengeDeriver{}, true},
+		{curves.RISTRETTO255(), Ristretto255ChallengeDeriver{}, false},
+	}
+	for _, test := range tests {
+		p1, err := dkg.NewDkgParticipant(1, 2, ctx, test.curve, 2)
+		require.NoError(t, err)
+		p2, err := dkg.NewDkgParticipant(2, 2, ctx, test.curve, 1)
+		require.NoError(t, err)
+		bcast1, p2psend1, err := p1.Round1(nil)
+		require.NoError(t, err)
+		bcast2, p2psend2, err := p2.Round1(nil)
+		require.NoError(t, err)
+		bcast := map[uint32]*dkg.Round1Bcast{1: bcast1, 2: bcast2}
+		_, err = p1.Round2(bcast, map[uint32]*sharing.ShamirShare{2: p2psend2[1]})
+		require.NoError(t, err)
+		_, err = p2.Round2(bcast, map[uint32]*sharing.ShamirShare{1: p2psend1[2]})
+		require.NoError(t, err)
+		require.Equal(t, p1.ChainCode, p2.ChainCode)
+
+		path := []uint32{3, 14}
+		d1, child, err := p1.DerivePath(path)
+		require.NoError(t, err)
+		d2, child2, err := p2.DerivePath(path)
+		require.NoError(t, err)
+		require.True(t, child.PublicKey.Equal(child2.PublicKey))
+		require.True(t, d1.VerificationKey.Equal(d2.VerificationKey))
+		if test.xOnlyKey {
+			require.Equal(t, child.PublicKey.ToAffineCompressed()[1:], d1.VerificationKey.ToAffineCompressed()[1:])
+		} else {
+			require.True(t, child.PublicKey.Equal(d1.VerificationKey))
+		}
+		require.True(t, test.curve.ScalarBaseMult(d1.SkShare).Equal(d1.VkShare))
+
+		scheme, err := sharing.NewShamir(2, 2, test.curve)
+		require.NoError(t, err)
+		lCoeffs, err := scheme.LagrangeCoeffs([]uint32{1, 2})
+		require.NoError(t, err)
+		signer1, err := NewSigner(d1, 1, 2, lCoeffs, []uint32{1, 2}, test.deriver)
+		require.NoError(t, err)
+		signer2, err := NewSigner(d2, 2, 2, lCoeffs, []uint32{1, 2}, test.deriver)
+		require.NoError(t, err)
+
+		round1Out1, err := signer1.SignRound1()
+		require.NoError(t, err)
+		round1Out2, err := signer2.SignRound1()
+		require.NoError(t, err)
+		round2Input := map[uint32]*Round1Bcast{1: round1Out1, 2: round1Out2}
+
+		msg := []byte("message")
+		round2Out1, err := signer1.SignRound2(msg, round2Input)
+		require.NoError(t, err)
+		round2Out2, err := signer2.SignRound2(msg, round2Input)
+		require.NoError(t, err)
+		round3Input := map[uint32]*Round2Bcast{1: round2Out1, 2: round2Out2}
+
+		round3Out, err := signer1.SignRound3(round3Input)
+		require.NoError(t, err)
+		ok, err := Verify(test.curve, test.deriver, d1.VerificationKey, msg, &Signature{Z: round3Out.Z, C: round3Out.C})
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		// The signature must not verify under the parent key.
+		ok, _ = Verify(test.curve, test.deriver, p1.VerificationKey, msg, &Signature{Z: round3Out.Z, C: round3Out.C})
+		require.False(t, ok)
+	}
+}
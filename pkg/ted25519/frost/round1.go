@@ -10,6 +10,7 @@ import (
 	"bytes"
 	crand "crypto/rand"
 	"encoding/gob"
+	"encoding/json"
 
 	"github.com/pkg/errors"
 
@@ -22,26 +23,73 @@ type Round1Bcast struct {
 	Di, Ei curves.Point
 }
 
+// Encode serializes the broadcast with the codec of the curves package
 func (result *Round1Bcast) Encode() ([]byte, error) {
-	gob.Register(result.Di) // just the point for now
-	gob.Register(result.Ei)
-	buf := &bytes.Buffer{}
-	enc := gob.NewEncoder(buf)
-	if err := enc.Encode(result); err != nil {
-		return nil, errors.Wrap(err, "couldn't encode round 1 broadcast")
+	if result == nil || result.Di == nil || result.Ei == nil {
+		return nil, internal.ErrNilArguments
 	}
-	return buf.Bytes(), nil
+	enc := curves.NewEncoder()
+	enc.WriteCurve(result.Di.CurveName())
+	enc.WritePoint(result.Di)
+	enc.WritePoint(result.Ei)
+	return enc.Bytes(), nil
 }
 
+// Decode deserializes the output of Encode, or the gob encoding used by earlier versions
 func (result *Round1Bcast) Decode(input []byte) error {
-	// Register all possible point types before decoding
-	gob.Register(&curves.PointEd25519{})
-	gob.Register(&curves.PointK256{})
-	buf := bytes.NewBuffer(input)
-	dec := gob.NewDecoder(buf)
-	if err := dec.Decode(result); err != nil {
+	if !curves.IsCodecEncoded(input) {
+		curves.RegisterGobTypes()
+		dec := gob.NewDecoder(bytes.NewBuffer(input))
+		if err := dec.Decode(result); err != nil {
+			return errors.Wrap(err, "couldn't decode round 1 broadcast")
+		}
+		return nil
+	}
+	dec := curves.NewDecoder(input)
+	dec.ReadHeader()
+	dec.ReadCurve()
+	di := dec.ReadPoint()
+	ei := dec.ReadPoint()
+	if err := dec.Finish(); err != nil {
+		return errors.Wrap(err, "couldn't decode round 1 broadcast")
+	}
+	result.Di, result.Ei = di, ei
+	return nil
+}
+
+type round1BcastJson struct {
+	Di json.RawMessage `json:"di"`
+	Ei json.RawMessage `json:"ei"`
+}
+
+// MarshalJSON serializes the broadcast with the JSON form of the codec of the curves package
+func (result Round1Bcast) MarshalJSON() ([]byte, error) {
+	di, err := curves.MarshalPointJSON(result.Di)
+	if err != nil {
+		return nil, err
+	}
+	ei, err := curves.MarshalPointJSON(result.Ei)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(round1BcastJson{Di: di, Ei: ei})
+}
+
+// UnmarshalJSON deserializes the output of MarshalJSON
+func (result *Round1Bcast) UnmarshalJSON(input []byte) error {
+	var v round1BcastJson
+	if err := json.Unmarshal(input, &v); err != nil {
+		return err
+	}
+	di, err := curves.UnmarshalPointJSON(v.Di)
+	if err != nil {
+		return errors.Wrap(err, "couldn't decode round 1 broadcast")
+	}
+	ei, err := curves.UnmarshalPointJSON(v.Ei)
+	if err != nil {
 		return errors.Wrap(err, "couldn't decode round 1 broadcast")
 	}
+	result.Di, result.Ei = di, ei
 	return nil
 }
 
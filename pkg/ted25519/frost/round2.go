@@ -9,6 +9,7 @@ package frost
 import (
 	"bytes"
 	"encoding/gob"
+	"encoding/json"
 	"fmt"
 
 	"github.com/pkg/errors"
@@ -23,28 +24,73 @@ type Round2Bcast struct {
 	Vki curves.Point
 }
 
+// Encode serializes the broadcast with the codec of the curves package
 func (result *Round2Bcast) Encode() ([]byte, error) {
-	gob.Register(result.Zi)
-	gob.Register(result.Vki) // just the point for now
-	buf := &bytes.Buffer{}
-	enc := gob.NewEncoder(buf)
-	if err := enc.Encode(result); err != nil {
-		return nil, errors.Wrap(err, "couldn't encode round 1 broadcast")
-	}
-	return buf.Bytes(), nil
+	if result == nil || result.Zi == nil || result.Vki == nil {
+		return nil, internal.ErrNilArguments
+	}
+	enc := curves.NewEncoder()
+	enc.WriteCurve(result.Vki.CurveName())
+	enc.WriteScalar(result.Zi)
+	enc.WritePoint(result.Vki)
+	return enc.Bytes(), nil
 }
 
+// Decode deserializes the output of Encode, or the gob encoding used by earlier versions
 func (result *Round2Bcast) Decode(input []byte) error {
-	// Register all possible scalar and point types before decoding
-	gob.Register(&curves.ScalarEd25519{})
-	gob.Register(&curves.ScalarK256{})
-	gob.Register(&curves.PointEd25519{})
-	gob.Register(&curves.PointK256{})
-	buf := bytes.NewBuffer(input)
-	dec := gob.NewDecoder(buf)
-	if err := dec.Decode(result); err != nil {
+	if !curves.IsCodecEncoded(input) {
+		curves.RegisterGobTypes()
+		dec := gob.NewDecoder(bytes.NewBuffer(input))
+		if err := dec.Decode(result); err != nil {
+			return errors.Wrap(err, "couldn't decode round 2 broadcast")
+		}
+		return nil
+	}
+	dec := curves.NewDecoder(input)
+	dec.ReadHeader()
+	dec.ReadCurve()
+	zi := dec.ReadScalar()
+	vki := dec.ReadPoint()
+	if err := dec.Finish(); err != nil {
+		return errors.Wrap(err, "couldn't decode round 2 broadcast")
+	}
+	result.Zi, result.Vki = zi, vki
+	return nil
+}
+
+type round2BcastJson struct {
+	Zi  json.RawMessage `json:"zi"`
+	Vki json.RawMessage `json:"vki"`
+}
+
+// MarshalJSON serializes the broadcast with the JSON form of the codec of the curves package
+func (result Round2Bcast) MarshalJSON() ([]byte, error) {
+	zi, err := curves.MarshalScalarJSON(result.Zi)
+	if err != nil {
+		return nil, err
+	}
+	vki, err := curves.MarshalPointJSON(result.Vki)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(round2BcastJson{Zi: zi, Vki: vki})
+}
+
+// UnmarshalJSON deserializes the output of MarshalJSON
+func (result *Round2Bcast) UnmarshalJSON(input []byte) error {
+	var v round2BcastJson
+	if err := json.Unmarshal(input, &v); err != nil {
+		return err
+	}
+	zi, err := curves.UnmarshalScalarJSON(v.Zi)
+	if err != nil {
+		return errors.Wrap(err, "couldn't decode round 2 broadcast")
+	}
+	vki, err := curves.UnmarshalPointJSON(v.Vki)
+	if err != nil {
 		return errors.Wrap(err, "couldn't decode round 2 broadcast")
 	}
+	result.Zi, result.Vki = zi, vki
 	return nil
 }
 
@@ -80,16 +126,17 @@ func (signer *Signer) SignRound2(msg []byte, round2Input map[uint32]*Round1Bcast
 		return nil, fmt.Errorf("Invalid length of round2Input")
 	}
 
-	// Step 2 - Check Dj, Ej on the curve and Store round2Input
+	// Step 2 - Check Dj, Ej are in the prime order subgroup and Store round2Input
 	for id, input := range round2Input {
 		if input == nil || input.Di == nil || input.Ei == nil {
 			return nil, fmt.Errorf("round2Input is nil from participant with id %d\n", id)
 		}
-		if !input.Di.IsOnCurve() || input.Di.IsIdentity() {
-			return nil, fmt.Errorf("commitment Di is not on the curve with id %d\n", id)
+		// A commitment with a small order component would leak through the cofactor of ed25519 or ed448
+		if _, err := signer.curve.PeerPoint(input.Di); err != nil {
+			return nil, fmt.Errorf("commitment Di is not in the prime order subgroup with id %d: %v", id, err)
 		}
-		if !input.Ei.IsOnCurve() || input.Ei.IsIdentity() {
-			return nil, fmt.Errorf("commitment Ei is not on the curve with id %d\n", id)
+		if _, err := signer.curve.PeerPoint(input.Ei); err != nil {
+			return nil, fmt.Errorf("commitment Ei is not in the prime order subgroup with id %d: %v", id, err)
 		}
 	}
 	// Store Dj, Ej for further usage.
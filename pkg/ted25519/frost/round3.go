@@ -47,10 +47,13 @@ func (signer *Signer) SignRound3(round3Input map[uint32]*Round2Bcast) (*Round3Bc
 	if round3Input == nil {
 		return nil, internal.ErrNilArguments
 	}
-	for _, data := range round3Input {
-		if data == nil {
+	for id, data := range round3Input {
+		if data == nil || data.Zi == nil || data.Vki == nil {
 			return nil, internal.ErrNilArguments
 		}
+		if _, err := signer.curve.PeerPoint(data.Vki); err != nil {
+			return nil, fmt.Errorf("verification share is not in the prime order subgroup with id %d: %v", id, err)
+		}
 	}
 
 	// Make sure the signer has commitments stored at the end of round 1.
@@ -139,9 +142,13 @@ func Verify(curve *curves.Curve, challengeDeriver ChallengeDerive, vk curves.Poi
 	c := signature.C
 
 	//R' = z*G - c*vk
-	zG := curve.ScalarBaseMult(z)
-	cvk := vk.Mul(c.Neg())
-	tempR := zG.Add(cvk)
+	tempR, err := curves.SumOfProductsVartime(
+		[]curves.Point{curve.NewGeneratorPoint(), vk},
+		[]curves.Scalar{z, c.Neg()},
+	)
+	if err != nil {
+		return false, err
+	}
 
 	//c' = H(m, R')
 	tempC, err := challengeDeriver.DeriveChallenge(msg, vk, tempR)
@@ -7,6 +7,10 @@
 package frost
 
 import (
+	"bytes"
+	crand "crypto/rand"
+	"encoding/gob"
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -403,3 +407,182 @@ func TestFullRoundsWorks(t *testing.T) {
 	require.Equal(t, result[1].C, result[3].C)
 	// require.Equal(t, c, result[3].C)
 }
+
+func TestFullRoundsWideCurves(t *testing.T) {
+	tests := []struct {
+		curve   *curves.Curve
+		deriver ChallengeDerive
+	}{
+		{curves.P384(), P384ChallengeDeriver{}},
+		{curves.ED448(), Ed448ChallengeDeriver{}},
+	}
+	for _, test := range tests {
+		p1, err := dkg.NewDkgParticipant(1, 2, ctx, test.curve, 2, 3)
+		require.NoError(t, err)
+		p2, err := dkg.NewDkgParticipant(2, 2, ctx, test.curve, 1, 3)
+		require.NoError(t, err)
+		p3, err := dkg.NewDkgParticipant(3, 2, ctx, test.curve, 1, 2)
+		require.NoError(t, err)
+		participants := map[uint32]*dkg.DkgParticipant{1: p1, 2: p2, 3: p3}
+		bcast := make(map[uint32]*dkg.Round1Bcast, 3)
+		p2p := make(map[uint32]dkg.Round1P2PSend, 3)
+		for id, p := range participants {
+			bcast[id], p2p[id], err = p.Round1(nil)
+			require.NoError(t, err)
+		}
+		for id, p := range participants {
+			shares := make(map[uint32]*sharing.ShamirShare, 2)
+			for jid := range participants {
+				if jid != id {
+					shares[jid] = p2p[jid][id]
+				}
+			}
+			_, err = p.Round2(bcast, shares)
+			require.NoError(t, err)
+		}
+		require.True(t, p1.VerificationKey.Equal(p3.VerificationKey))
+
+		scheme, err := sharing.NewShamir(2, 3, test.curve)
+		require.NoError(t, err)
+		signerIds := []uint32{1, 3}
+		lCoeffs, err := scheme.LagrangeCoeffs(signerIds)
+		require.NoError(t, err)
+		signer1, err := NewSigner(p1, 1, 2, lCoeffs, signerIds, test.deriver)
+		require.NoError(t, err)
+		signer3, err := NewSigner(p3, 3, 2, lCoeffs, signerIds, test.deriver)
+		require.NoError(t, err)
+
+		round1Out1, err := signer1.SignRound1()
+		require.NoError(t, err)
+		round1Out3, err := signer3.SignRound1()
+		require.NoError(t, err)
+		// The broadcasts survive encoding
+		enc, err := round1Out3.Encode()
+		require.NoError(t, err)
+		round1Out3 = new(Round1Bcast)
+		require.NoError(t, round1Out3.Decode(enc))
+		round2Input := map[uint32]*Round1Bcast{1: round1Out1, 3: round1Out3}
+
+		msg := []byte("message")
+		round2Out1, err := signer1.SignRound2(msg, round2Input)
+		require.NoError(t, err)
+		round2Out3, err := signer3.SignRound2(msg, round2Input)
+		require.NoError(t, err)
+		enc, err = round2Out3.Encode()
+		require.NoError(t, err)
+		round2Out3 = new(Round2Bcast)
+		require.NoError(t, round2Out3.Decode(enc))
+		round3Input := map[uint32]*Round2Bcast{1: round2Out1, 3: round2Out3}
+
+		round3Out, err := signer1.SignRound3(round3Input)
+		require.NoError(t, err)
+		ok, err := Verify(test.curve, test.deriver, p1.VerificationKey, msg, &Signature{Z: round3Out.Z, C: round3Out.C})
+		require.NoError(t, err)
+		require.True(t, ok)
+		ok, _ = Verify(test.curve, test.deriver, p1.VerificationKey, []byte("other"), &Signature{Z: round3Out.Z, C: round3Out.C})
+		require.False(t, ok)
+	}
+}
+
+func TestBroadcastEncodingAllCurves(t *testing.T) {
+	for _, curve := range []*curves.Curve{
+		curves.ED25519(), curves.K256(), curves.P256(), curves.PALLAS(), curves.BLS12381G1(), curves.ED448(),
+	} {
+		r1 := &Round1Bcast{
+			Di: curve.ScalarBaseMult(curve.Scalar.Random(crand.Reader)),
+			Ei: curve.ScalarBaseMult(curve.Scalar.Random(crand.Reader)),
+		}
+		r2 := &Round2Bcast{
+			Zi:  curve.Scalar.Random(crand.Reader),
+			Vki: curve.ScalarBaseMult(curve.Scalar.Random(crand.Reader)),
+		}
+
+		enc, err := r1.Encode()
+		require.NoError(t, err, curve.Name)
+		dec1 := new(Round1Bcast)
+		require.NoError(t, dec1.Decode(enc), curve.Name)
+		require.True(t, r1.Di.Equal(dec1.Di), curve.Name)
+		require.True(t, r1.Ei.Equal(dec1.Ei), curve.Name)
+		require.Error(t, new(Round1Bcast).Decode(enc[:len(enc)-1]), curve.Name)
+
+		enc, err = r2.Encode()
+		require.NoError(t, err, curve.Name)
+		dec2 := new(Round2Bcast)
+		require.NoError(t, dec2.Decode(enc), curve.Name)
+		require.Equal(t, 0, r2.Zi.Cmp(dec2.Zi), curve.Name)
+		require.True(t, r2.Vki.Equal(dec2.Vki), curve.Name)
+
+		enc, err = json.Marshal(r1)
+		require.NoError(t, err, curve.Name)
+		dec1 = new(Round1Bcast)
+		require.NoError(t, json.Unmarshal(enc, dec1), curve.Name)
+		require.True(t, r1.Di.Equal(dec1.Di), curve.Name)
+		require.True(t, r1.Ei.Equal(dec1.Ei), curve.Name)
+
+		enc, err = json.Marshal(r2)
+		require.NoError(t, err, curve.Name)
+		dec2 = new(Round2Bcast)
+		require.NoError(t, json.Unmarshal(enc, dec2), curve.Name)
+		require.Equal(t, 0, r2.Zi.Cmp(dec2.Zi), curve.Name)
+		require.True(t, r2.Vki.Equal(dec2.Vki), curve.Name)
+	}
+}
+
+func TestBroadcastDecodesLegacyGob(t *testing.T) {
+	for _, curve := range []*curves.Curve{curves.ED25519(), curves.K256()} {
+		r1 := &Round1Bcast{Di: curve.Point.Generator(), Ei: curve.Point.Generator().Double()}
+		r2 := &Round2Bcast{Zi: curve.Scalar.New(7), Vki: curve.Point.Generator()}
+		gob.Register(r2.Zi)
+		gob.Register(r2.Vki)
+
+		buf := new(bytes.Buffer)
+		require.NoError(t, gob.NewEncoder(buf).Encode(r1))
+		dec1 := new(Round1Bcast)
+		require.NoError(t, dec1.Decode(buf.Bytes()), curve.Name)
+		require.True(t, r1.Di.Equal(dec1.Di), curve.Name)
+		require.True(t, r1.Ei.Equal(dec1.Ei), curve.Name)
+
+		buf = new(bytes.Buffer)
+		require.NoError(t, gob.NewEncoder(buf).Encode(r2))
+		dec2 := new(Round2Bcast)
+		require.NoError(t, dec2.Decode(buf.Bytes()), curve.Name)
+		require.Equal(t, 0, r2.Zi.Cmp(dec2.Zi), curve.Name)
+		require.True(t, r2.Vki.Equal(dec2.Vki), curve.Name)
+	}
+}
+
+func TestSignRound2RejectsTorsionCommitment(t *testing.T) {
+	signer1, signer2 := PrepareNewSigners(t)
+	round1Out1, _ := signer1.SignRound1()
+	round1Out2, _ := signer2.SignRound1()
+	// y = 0 encodes a point of order 4
+	torsion, err := testCurve.Point.FromAffineCompressed(make([]byte, 32))
+	require.NoError(t, err)
+	round1Out2.Ei = round1Out2.Ei.Add(torsion)
+	round2Input := map[uint32]*Round1Bcast{signer1.id: round1Out1, signer2.id: round1Out2}
+	_, err = signer1.SignRound2([]byte("message"), round2Input)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "prime order subgroup")
+}
+
+func TestSignRound2RejectsCommitmentOfOtherCurve(t *testing.T) {
+	signer1, signer2 := PrepareNewSigners(t)
+	round1Out1, _ := signer1.SignRound1()
+	round1Out2, _ := signer2.SignRound1()
+	round1Out2.Di = curves.RISTRETTO255().Point.Generator()
+	round2Input := map[uint32]*Round1Bcast{signer1.id: round1Out1, signer2.id: round1Out2}
+	_, err := signer1.SignRound2([]byte("message"), round2Input)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "ristretto255")
+}
+
+func TestSignRound3RejectsTorsionVerificationShare(t *testing.T) {
+	signer1, signer2, round3Input := PrepareRound3Input(t)
+	// y = 0 encodes a point of order 4
+	torsion, err := testCurve.Point.FromAffineCompressed(make([]byte, 32))
+	require.NoError(t, err)
+	round3Input[signer2.id].Vki = round3Input[signer2.id].Vki.Add(torsion)
+	_, err = signer1.SignRound3(round3Input)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "prime order subgroup")
+}
@@ -230,6 +230,7 @@ func sign(signature, privateKey, message []byte) error {
 // Verify reports whether sig is a valid signature of message by publicKey. It
 // will panic if len(publicKey) is not PublicKeySize.
 // Previously publicKey is of type PublicKey
+// Public keys and R that are encoded non-canonically or have a small order component are rejected.
 func Verify(publicKey PublicKey, message, sig []byte) (bool, error) {
 	if l := len(publicKey); l != PublicKeySize {
 		return false, fmt.Errorf("ed25519: bad public key length: " + strconv.Itoa(l))
@@ -242,10 +243,14 @@ func Verify(publicKey PublicKey, message, sig []byte) (bool, error) {
 	var publicKeyBytes [32]byte
 	copy(publicKeyBytes[:], publicKey)
 
-	A, err := new(curves.PointEd25519).FromAffineCompressed(publicKeyBytes[:])
+	A, err := new(curves.PointEd25519).FromAffineCompressedStrict(publicKeyBytes[:])
 	if err != nil {
 		return false, err
 	}
+	// R' below is always canonical, but an R with a small order component must be rejected explicitly
+	if _, err = new(curves.PointEd25519).FromAffineCompressedStrict(sig[:32]); err != nil {
+		return false, err
+	}
 
 	// Negate sets A = -A, and returns A. It actually negates X and T but keep Y and Z
 	negA := A.Neg()
@@ -70,6 +70,32 @@ func TestSignVerify(t *testing.T) {
 	require.True(t, !ok)
 }
 
+func TestVerifyRejectsSmallOrder(t *testing.T) {
+	public, private, err := GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	message := []byte("test message")
+	sig, err := Sign(private, message)
+	require.NoError(t, err)
+
+	// y = 0 encodes a point of order 4
+	torsion, err := new(curves.PointEd25519).FromAffineCompressed(make([]byte, 32))
+	require.NoError(t, err)
+	addTorsion := func(b []byte) []byte {
+		p, err := new(curves.PointEd25519).FromAffineCompressed(b)
+		require.NoError(t, err)
+		return p.Add(torsion).ToAffineCompressed()
+	}
+
+	ok, err := Verify(addTorsion(public), message, sig)
+	require.Error(t, err)
+	require.False(t, ok)
+
+	mauled := append(addTorsion(sig[:32]), sig[32:]...)
+	ok, err = Verify(public, message, mauled)
+	require.Error(t, err)
+	require.False(t, ok)
+}
+
 func TestCryptoSigner(t *testing.T) {
 	var zero zeroReader
 	public, private, _ := GenerateKey(zero)
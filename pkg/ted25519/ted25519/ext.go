@@ -15,11 +15,11 @@ import (
 
 // GeAdd returns the sum of two public keys, a and b.
 func GeAdd(a PublicKey, b PublicKey) PublicKey {
-	aPoint, err := new(curves.PointEd25519).FromAffineCompressed(a)
+	aPoint, err := new(curves.PointEd25519).FromAffineCompressedStrict(a)
 	if err != nil {
 		panic("attempted to add invalid point: a")
 	}
-	bPoint, err := new(curves.PointEd25519).FromAffineCompressed(b)
+	bPoint, err := new(curves.PointEd25519).FromAffineCompressedStrict(b)
 	if err != nil {
 		panic("attempted to add invalid point: b")
 	}
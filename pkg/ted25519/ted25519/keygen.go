@@ -56,7 +56,7 @@ func CommitmentsFromBytes(bytes [][]byte) (Commitments, error) {
 		if err != nil {
 			return nil, err
 		}
-		comms[i], err = new(curves.PointEd25519).FromAffineCompressed(pubKey)
+		comms[i], err = new(curves.PointEd25519).FromAffineCompressedStrict(pubKey)
 		if err != nil {
 			return nil, err
 		}
@@ -199,6 +199,11 @@ func (share *KeyShare) VerifyVSS(commitments Commitments, config *ShareConfigura
 	if len(commitments) < config.T {
 		return false, fmt.Errorf("not enough verifiers to check")
 	}
+	for _, c := range commitments {
+		if _, err := curves.ED25519().PeerPoint(c); err != nil {
+			return false, err
+		}
+	}
 	field := curves.NewField(curves.Ed25519Order())
 	xBytes := make([]byte, 4)
 	binary.BigEndian.PutUint32(xBytes, share.Identifier)
@@ -107,6 +107,25 @@ func TestCommitmentsFromBytes(t *testing.T) {
 	}
 	_, err = CommitmentsFromBytes([][]byte{{0x01}})
 	require.Error(t, err)
+
+	// y = 0 encodes a point of order 4
+	torsion, err := curves.ED25519().Point.FromAffineCompressed(make([]byte, 32))
+	require.NoError(t, err)
+	_, err = CommitmentsFromBytes([][]byte{comms[0].Add(torsion).ToAffineCompressed()})
+	require.Error(t, err)
+}
+
+func TestVerifyVSSRejectsTorsionCommitment(t *testing.T) {
+	config := ShareConfiguration{T: 2, N: 3}
+	_, shares, comms, err := GenerateSharedKey(&config)
+	require.NoError(t, err)
+	// y = 0 encodes a point of order 4
+	torsion, err := curves.ED25519().Point.FromAffineCompressed(make([]byte, 32))
+	require.NoError(t, err)
+	comms[1] = comms[1].Add(torsion)
+	ok, err := shares[0].VerifyVSS(comms, &config)
+	require.Error(t, err)
+	require.False(t, ok)
 }
 
 func TestPublicKeyFromBytes(t *testing.T) {
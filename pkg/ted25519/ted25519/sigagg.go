@@ -33,6 +33,10 @@ func Aggregate(sigs []*PartialSignature, config *ShareConfiguration) (Signature,
 		}
 	}
 
+	if _, err := new(curves.PointEd25519).FromAffineCompressedStrict(noncePubkey); err != nil {
+		return nil, fmt.Errorf("ted25519: invalid nonce pubkey: %v", err)
+	}
+
 	// Convert signatures to a Shamir share representation so we can recombine them
 	sigShares := make([]*v1.ShamirShare, len(sigs))
 	field := curves.NewField(curves.Ed25519Order())
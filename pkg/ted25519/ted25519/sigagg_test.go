@@ -78,6 +78,12 @@ func TestSigAgg_validations(t *testing.T) {
 		err,
 		fmt.Sprintf("ted25519: unexpected nonce pubkey. got: %x expected: %x", sig2bytes[:32], sig1bytes[:32]),
 	)
+
+	// y = 0 encodes a point of order 4
+	torsionR := append(make([]byte, 32), sig1bytes[32:]...)
+	_, err = Aggregate([]*PartialSignature{NewPartialSignature(1, torsionR)}, &config)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid nonce pubkey")
 }
 
 func assertSignatureVerifies(t *testing.T, pub, message, sig []byte) {
@@ -46,7 +46,18 @@ func NewPaillierGroup() (*PaillierGroup, error) {
 // NewPaillierGroupWithPrimes create a new Paillier group for verifiable encryption
 // Order n^2 where n = p * q
 func NewPaillierGroupWithPrimes(p, q *big.Int) (*PaillierGroup, error) {
-	n := new(big.Int).Mul(p, q)
+	if p == nil || q == nil {
+		return nil, internal.ErrNilArguments
+	}
+	return NewPaillierGroupWithModulus(new(big.Int).Mul(p, q))
+}
+
+// NewPaillierGroupWithModulus creates a new Paillier group for verifiable encryption
+// from n = p * q when no single party knows p and q, e.g. n from pkg/dkg/biprime
+func NewPaillierGroupWithModulus(n *big.Int) (*PaillierGroup, error) {
+	if n == nil {
+		return nil, internal.ErrNilArguments
+	}
 	n2 := new(big.Int).Mul(n, n)
 	gTick, err := crypto.Rand(n2)
 	if err != nil {
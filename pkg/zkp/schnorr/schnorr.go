@@ -70,7 +70,7 @@ func (p *Prover) Prove(x curves.Scalar) (*Proof, error) {
 	if _, err = hash.Write(random.ToAffineCompressed()); err != nil {
 		return nil, errors.Wrap(err, "writing point K to hash in schnorr prove")
 	}
-	result.C, err = p.curve.Scalar.SetBytes(hash.Sum(nil))
+	result.C, err = challenge(p.curve, hash.Sum(nil))
 	if err != nil {
 		return nil, errors.Wrap(err, "writing point K to hash in schnorr prove")
 	}
@@ -84,9 +84,13 @@ func Verify(proof *Proof, curve *curves.Curve, basepoint curves.Point, uniqueSes
 	if basepoint == nil {
 		basepoint = curve.NewGeneratorPoint()
 	}
-	gs := basepoint.Mul(proof.S)
-	xc := proof.Statement.Mul(proof.C.Neg())
-	random := gs.Add(xc)
+	random, err := curves.SumOfProductsVartime(
+		[]curves.Point{basepoint, proof.Statement},
+		[]curves.Scalar{proof.S, proof.C.Neg()},
+	)
+	if err != nil {
+		return errors.Wrap(err, "computing point K in schnorr verify")
+	}
 	hash := sha3.New256()
 	if _, err := hash.Write(uniqueSessionId); err != nil {
 		return errors.Wrap(err, "writing salt to hash in schnorr verify")
@@ -100,12 +104,22 @@ func Verify(proof *Proof, curve *curves.Curve, basepoint curves.Point, uniqueSes
 	if _, err := hash.Write(random.ToAffineCompressed()); err != nil {
 		return errors.Wrap(err, "writing point K to hash in schnorr verify")
 	}
-	if subtle.ConstantTimeCompare(proof.C.Bytes(), hash.Sum(nil)) != 1 {
+	c, err := challenge(curve, hash.Sum(nil))
+	if err != nil {
+		return errors.Wrap(err, "computing challenge in schnorr verify")
+	}
+	if subtle.ConstantTimeCompare(proof.C.Bytes(), c.Bytes()) != 1 {
 		return fmt.Errorf("schnorr verification failed")
 	}
 	return nil
 }
 
+// challenge maps the hash digest to a scalar, reduced modulo the group order since the
+// scalars of curves such as ed25519 and ristretto255 have fewer bits than the digest.
+func challenge(curve *curves.Curve, digest []byte) (curves.Scalar, error) {
+	return curve.Scalar.SetBytesReduce(digest)
+}
+
 // ProveCommit generates _and_ commits to a schnorr proof which is later revealed; see Functionality 7.
 // returns the Proof and Commitment.
 func (p *Prover) ProveCommit(x curves.Scalar) (*Proof, Commitment, error) {
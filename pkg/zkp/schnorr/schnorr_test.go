@@ -3,6 +3,7 @@ package schnorr
 import (
 	"crypto/rand"
 	"fmt"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -15,13 +16,17 @@ func TestZKPOverMultipleCurves(t *testing.T) {
 	curveInstances := []*curves.Curve{
 		curves.K256(),
 		curves.P256(),
+		curves.ED25519(),
+		curves.RISTRETTO255(),
+		curves.PALLAS(),
+		curves.VESTA(),
+		curves.P384(),
+		curves.ED448(),
 		// TODO: the code fails on the following curves. Investigate if this is expected.
-		// curves.PALLAS(),
 		// curves.BLS12377G1(),
 		// curves.BLS12377G2(),
 		// curves.BLS12381G1(),
 		// curves.BLS12381G2(),
-		// curves.ED25519(),
 	}
 	for i, curve := range curveInstances {
 		uniqueSessionId := sha3.New256().Sum([]byte("random seed"))
@@ -35,3 +40,24 @@ func TestZKPOverMultipleCurves(t *testing.T) {
 		require.NoError(t, err, fmt.Sprintf("failed in curve %d", i))
 	}
 }
+
+func TestVerifyStatementOfOtherCurve(t *testing.T) {
+	uniqueSessionId := sha3.New256().Sum([]byte("random seed"))
+	prover := NewProver(curves.K256(), nil, uniqueSessionId)
+	proof, err := prover.Prove(curves.K256().Scalar.Random(rand.Reader))
+	require.NoError(t, err)
+
+	proof.Statement = curves.P256().Point.Generator()
+	require.Error(t, Verify(proof, curves.K256(), nil, uniqueSessionId))
+}
+
+func TestChallengeReducesDigest(t *testing.T) {
+	digest := sha3.Sum256([]byte("challenge"))
+	for _, curve := range []*curves.Curve{curves.K256(), curves.P256(), curves.ED25519(), curves.P384()} {
+		c, err := challenge(curve, digest[:])
+		require.NoError(t, err)
+		expected, err := curve.Scalar.SetBigInt(new(big.Int).SetBytes(digest[:]))
+		require.NoError(t, err)
+		require.Equal(t, 0, c.Cmp(expected), curve.Name)
+	}
+}